@@ -38,6 +38,7 @@ type parameters struct {
 	Direct          bool   `param:"desc=Attempt direct download of firmware;default=false"`
 	Simple          bool   `param:"desc=Use simple FOTA process;default=false"`
 	NoNew           bool   `param:"desc=No update expected (for direct download);default=false"`
+	Delta           bool   `param:"desc=Report delta support in simple FOTA process;default=false"`
 }
 
 func main() {
@@ -39,19 +39,22 @@ func doSimpleFOTA(config parameters) error {
 		ManufacturerName: config.Manufacturer,
 		SerialNumber:     config.Serial,
 		ModelNumber:      config.Model,
+		DeltaSupported:   config.Delta,
 	}
 
 	tlv := htest.NewTLVBuffer(
 		2 + len(report.FirmwareVersion) +
 			2 + len(report.ManufacturerName) +
 			2 + len(report.SerialNumber) +
-			2 + len(report.ModelNumber))
+			2 + len(report.ModelNumber) +
+			3)
 
 	tlv.Begin()
 	tlv.EncodeTLVString(htest.FirmwareID, report.FirmwareVersion)
 	tlv.EncodeTLVString(htest.ManufacturerID, report.ManufacturerName)
 	tlv.EncodeTLVString(htest.SerialID, report.SerialNumber)
 	tlv.EncodeTLVString(htest.ModelID, report.ModelNumber)
+	tlv.EncodeTLVBool(htest.DeltaID, report.DeltaSupported)
 
 	req := coap.Message{
 		Type:      coap.Confirmable,
@@ -72,7 +75,7 @@ func doSimpleFOTA(config parameters) error {
 		return err
 	}
 
-	fmt.Printf("Host: %s, Port: %d, Path: %s, Image: %t\n", response.Host, response.Port, response.Path, response.ImageAvailable)
+	fmt.Printf("Host: %s, Port: %d, Path: %s, Image: %t, Delta: %t\n", response.Host, response.Port, response.Path, response.ImageAvailable, response.Delta)
 
 	if !response.ImageAvailable && config.NoNew {
 		fmt.Println("No new expected and no firmware available")
@@ -76,6 +76,8 @@ const (
 	FirmwareMetadata_Reverted     FirmwareMetadata_FirmwareState = 8
 	FirmwareMetadata_UpdateFailed FirmwareMetadata_FirmwareState = 9
 	FirmwareMetadata_Completed    FirmwareMetadata_FirmwareState = 10
+	FirmwareMetadata_Incompatible FirmwareMetadata_FirmwareState = 11
+	FirmwareMetadata_Deferred     FirmwareMetadata_FirmwareState = 12
 )
 
 var FirmwareMetadata_FirmwareState_name = map[int32]string{
@@ -90,6 +92,8 @@ var FirmwareMetadata_FirmwareState_name = map[int32]string{
 	8:  "Reverted",
 	9:  "UpdateFailed",
 	10: "Completed",
+	11: "Incompatible",
+	12: "Deferred",
 }
 
 var FirmwareMetadata_FirmwareState_value = map[string]int32{
@@ -104,6 +108,8 @@ var FirmwareMetadata_FirmwareState_value = map[string]int32{
 	"Reverted":     8,
 	"UpdateFailed": 9,
 	"Completed":    10,
+	"Incompatible": 11,
+	"Deferred":     12,
 }
 
 func (x FirmwareMetadata_FirmwareState) String() string {
@@ -111,7 +117,7 @@ func (x FirmwareMetadata_FirmwareState) String() string {
 }
 
 func (FirmwareMetadata_FirmwareState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{8, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9, 0}
 }
 
 type OutputDataMessage_OutputMessageType int32
@@ -120,18 +126,21 @@ const (
 	OutputDataMessage_unknown   OutputDataMessage_OutputMessageType = 0
 	OutputDataMessage_keepalive OutputDataMessage_OutputMessageType = 1
 	OutputDataMessage_data      OutputDataMessage_OutputMessageType = 2
+	OutputDataMessage_event     OutputDataMessage_OutputMessageType = 3
 )
 
 var OutputDataMessage_OutputMessageType_name = map[int32]string{
 	0: "unknown",
 	1: "keepalive",
 	2: "data",
+	3: "event",
 }
 
 var OutputDataMessage_OutputMessageType_value = map[string]int32{
 	"unknown":   0,
 	"keepalive": 1,
 	"data":      2,
+	"event":     3,
 }
 
 func (x OutputDataMessage_OutputMessageType) String() string {
@@ -139,7 +148,7 @@ func (x OutputDataMessage_OutputMessageType) String() string {
 }
 
 func (OutputDataMessage_OutputMessageType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{14, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16, 0}
 }
 
 type Output_Type int32
@@ -174,7 +183,7 @@ func (x Output_Type) String() string {
 }
 
 func (Output_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{16, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18, 0}
 }
 
 type ErrorDetails struct {
@@ -420,11 +429,15 @@ type CollectionFirmware struct {
 	// The target firmware is set to the desired firmware image for the devices in
 	// this collection. If the management is set to "device" this will only be
 	// used if the target firmware isn't set on the device itself.
-	TargetFirmwareId     *wrappers.StringValue                 `protobuf:"bytes,2,opt,name=target_firmware_id,json=targetFirmwareId,proto3" json:"target_firmware_id,omitempty"`
-	Management           CollectionFirmware_FirmwareManagement `protobuf:"varint,3,opt,name=management,proto3,enum=apipb.CollectionFirmware_FirmwareManagement" json:"management,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
-	XXX_unrecognized     []byte                                `json:"-"`
-	XXX_sizecache        int32                                 `json:"-"`
+	TargetFirmwareId *wrappers.StringValue                 `protobuf:"bytes,2,opt,name=target_firmware_id,json=targetFirmwareId,proto3" json:"target_firmware_id,omitempty"`
+	Management       CollectionFirmware_FirmwareManagement `protobuf:"varint,3,opt,name=management,proto3,enum=apipb.CollectionFirmware_FirmwareManagement" json:"management,omitempty"`
+	// Unsigned firmware images are refused when this is set.
+	RequireSignature *wrappers.BoolValue `protobuf:"bytes,4,opt,name=require_signature,json=requireSignature,proto3" json:"require_signature,omitempty"`
+	// Devices in the collection are only updated inside the maintenance window.
+	MaintenanceWindow    *MaintenanceWindow `protobuf:"bytes,5,opt,name=maintenance_window,json=maintenanceWindow,proto3" json:"maintenance_window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *CollectionFirmware) Reset()         { *m = CollectionFirmware{} }
@@ -473,6 +486,83 @@ func (m *CollectionFirmware) GetManagement() CollectionFirmware_FirmwareManageme
 	return CollectionFirmware_unspecified
 }
 
+func (m *CollectionFirmware) GetRequireSignature() *wrappers.BoolValue {
+	if m != nil {
+		return m.RequireSignature
+	}
+	return nil
+}
+
+func (m *CollectionFirmware) GetMaintenanceWindow() *MaintenanceWindow {
+	if m != nil {
+		return m.MaintenanceWindow
+	}
+	return nil
+}
+
+// Maintenance window for firmware updates. Devices that need an update outside
+// the window are reported as "Deferred" until the window opens.
+type MaintenanceWindow struct {
+	// Cron-like schedule for the start of the window with five fields; minute,
+	// hour, day of month, month and day of week, f.e. "0 22 * * 1-5" for
+	// weekdays at 22:00. Set to an empty string to remove the window.
+	Schedule *wrappers.StringValue `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// The length of the window in minutes. The maximum is one week.
+	DurationMinutes *wrappers.Int32Value `protobuf:"bytes,2,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	// IANA time zone name for the schedule, f.e. "Europe/Oslo". Use "device" to
+	// use the time zone the network reports for the device. The default is UTC.
+	TimeZone             *wrappers.StringValue `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *MaintenanceWindow) Reset()         { *m = MaintenanceWindow{} }
+func (m *MaintenanceWindow) String() string { return proto.CompactTextString(m) }
+func (*MaintenanceWindow) ProtoMessage()    {}
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+}
+
+func (m *MaintenanceWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MaintenanceWindow.Unmarshal(m, b)
+}
+func (m *MaintenanceWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MaintenanceWindow.Marshal(b, m, deterministic)
+}
+func (m *MaintenanceWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaintenanceWindow.Merge(m, src)
+}
+func (m *MaintenanceWindow) XXX_Size() int {
+	return xxx_messageInfo_MaintenanceWindow.Size(m)
+}
+func (m *MaintenanceWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaintenanceWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaintenanceWindow proto.InternalMessageInfo
+
+func (m *MaintenanceWindow) GetSchedule() *wrappers.StringValue {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+func (m *MaintenanceWindow) GetDurationMinutes() *wrappers.Int32Value {
+	if m != nil {
+		return m.DurationMinutes
+	}
+	return nil
+}
+
+func (m *MaintenanceWindow) GetTimeZone() *wrappers.StringValue {
+	if m != nil {
+		return m.TimeZone
+	}
+	return nil
+}
+
 // Collection object
 type Collection struct {
 	// The ID of the collection. This is assigned by the backend.
@@ -496,7 +586,7 @@ func (m *Collection) Reset()         { *m = Collection{} }
 func (m *Collection) String() string { return proto.CompactTextString(m) }
 func (*Collection) ProtoMessage()    {}
 func (*Collection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
 }
 
 func (m *Collection) XXX_Unmarshal(b []byte) error {
@@ -567,7 +657,7 @@ func (m *NetworkMetadata) Reset()         { *m = NetworkMetadata{} }
 func (m *NetworkMetadata) String() string { return proto.CompactTextString(m) }
 func (*NetworkMetadata) ProtoMessage()    {}
 func (*NetworkMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
 }
 
 func (m *NetworkMetadata) XXX_Unmarshal(b []byte) error {
@@ -611,24 +701,26 @@ func (m *NetworkMetadata) GetCellId() *wrappers.Int64Value {
 
 // FirmwareMetadata object
 type FirmwareMetadata struct {
-	CurrentFirmwareId    *wrappers.StringValue `protobuf:"bytes,1,opt,name=current_firmware_id,json=currentFirmwareId,proto3" json:"current_firmware_id,omitempty"`
-	TargetFirmwareId     *wrappers.StringValue `protobuf:"bytes,2,opt,name=target_firmware_id,json=targetFirmwareId,proto3" json:"target_firmware_id,omitempty"`
-	FirmwareVersion      *wrappers.StringValue `protobuf:"bytes,3,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
-	SerialNumber         *wrappers.StringValue `protobuf:"bytes,4,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
-	ModelNumber          *wrappers.StringValue `protobuf:"bytes,5,opt,name=model_number,json=modelNumber,proto3" json:"model_number,omitempty"`
-	Manufacturer         *wrappers.StringValue `protobuf:"bytes,6,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
-	State                *wrappers.StringValue `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
-	StateMessage         *wrappers.StringValue `protobuf:"bytes,8,opt,name=state_message,json=stateMessage,proto3" json:"state_message,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	CurrentFirmwareId *wrappers.StringValue `protobuf:"bytes,1,opt,name=current_firmware_id,json=currentFirmwareId,proto3" json:"current_firmware_id,omitempty"`
+	TargetFirmwareId  *wrappers.StringValue `protobuf:"bytes,2,opt,name=target_firmware_id,json=targetFirmwareId,proto3" json:"target_firmware_id,omitempty"`
+	FirmwareVersion   *wrappers.StringValue `protobuf:"bytes,3,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	SerialNumber      *wrappers.StringValue `protobuf:"bytes,4,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	ModelNumber       *wrappers.StringValue `protobuf:"bytes,5,opt,name=model_number,json=modelNumber,proto3" json:"model_number,omitempty"`
+	Manufacturer      *wrappers.StringValue `protobuf:"bytes,6,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	State             *wrappers.StringValue `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	StateMessage      *wrappers.StringValue `protobuf:"bytes,8,opt,name=state_message,json=stateMessage,proto3" json:"state_message,omitempty"`
+	// The device's maintenance window. This overrides the collection's window.
+	MaintenanceWindow    *MaintenanceWindow `protobuf:"bytes,9,opt,name=maintenance_window,json=maintenanceWindow,proto3" json:"maintenance_window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *FirmwareMetadata) Reset()         { *m = FirmwareMetadata{} }
 func (m *FirmwareMetadata) String() string { return proto.CompactTextString(m) }
 func (*FirmwareMetadata) ProtoMessage()    {}
 func (*FirmwareMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
 }
 
 func (m *FirmwareMetadata) XXX_Unmarshal(b []byte) error {
@@ -705,6 +797,13 @@ func (m *FirmwareMetadata) GetStateMessage() *wrappers.StringValue {
 	return nil
 }
 
+func (m *FirmwareMetadata) GetMaintenanceWindow() *MaintenanceWindow {
+	if m != nil {
+		return m.MaintenanceWindow
+	}
+	return nil
+}
+
 // DeviceMetadata request object
 type DeviceMetadata struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -716,7 +815,7 @@ func (m *DeviceMetadata) Reset()         { *m = DeviceMetadata{} }
 func (m *DeviceMetadata) String() string { return proto.CompactTextString(m) }
 func (*DeviceMetadata) ProtoMessage()    {}
 func (*DeviceMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
 }
 
 func (m *DeviceMetadata) XXX_Unmarshal(b []byte) error {
@@ -765,7 +864,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
 }
 
 func (m *Device) XXX_Unmarshal(b []byte) error {
@@ -864,7 +963,7 @@ func (m *UpdateDeviceRequest) Reset()         { *m = UpdateDeviceRequest{} }
 func (m *UpdateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceRequest) ProtoMessage()    {}
 func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
 }
 
 func (m *UpdateDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -946,7 +1045,7 @@ func (m *UDPMetadata) Reset()         { *m = UDPMetadata{} }
 func (m *UDPMetadata) String() string { return proto.CompactTextString(m) }
 func (*UDPMetadata) ProtoMessage()    {}
 func (*UDPMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
 }
 
 func (m *UDPMetadata) XXX_Unmarshal(b []byte) error {
@@ -993,7 +1092,7 @@ func (m *CoAPMetadata) Reset()         { *m = CoAPMetadata{} }
 func (m *CoAPMetadata) String() string { return proto.CompactTextString(m) }
 func (*CoAPMetadata) ProtoMessage()    {}
 func (*CoAPMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
 }
 
 func (m *CoAPMetadata) XXX_Unmarshal(b []byte) error {
@@ -1028,8 +1127,103 @@ func (m *CoAPMetadata) GetPath() *wrappers.StringValue {
 	return nil
 }
 
+// Resource change event. Events are emitted when devices, collections and
+// outputs are created, changed or removed. Only one of the device, collection
+// and output fields is set.
+type ResourceEvent struct {
+	// The event type, ie "device.created", "device.moved", "device.retagged",
+	// "device.firmware" or "output.deleted"
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Time of event (in milliseconds since epoch)
+	Time         *wrappers.Int64Value  `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	CollectionId *wrappers.StringValue `protobuf:"bytes,3,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// The previous collection for devices that are moved
+	PreviousCollectionId *wrappers.StringValue `protobuf:"bytes,4,opt,name=previous_collection_id,json=previousCollectionId,proto3" json:"previous_collection_id,omitempty"`
+	Device               *Device               `protobuf:"bytes,5,opt,name=device,proto3" json:"device,omitempty"`
+	Collection           *Collection           `protobuf:"bytes,6,opt,name=collection,proto3" json:"collection,omitempty"`
+	// The output configuration is omitted from events
+	Output               *Output  `protobuf:"bytes,7,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceEvent) Reset()         { *m = ResourceEvent{} }
+func (m *ResourceEvent) String() string { return proto.CompactTextString(m) }
+func (*ResourceEvent) ProtoMessage()    {}
+func (*ResourceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+}
+
+func (m *ResourceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceEvent.Unmarshal(m, b)
+}
+func (m *ResourceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceEvent.Marshal(b, m, deterministic)
+}
+func (m *ResourceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceEvent.Merge(m, src)
+}
+func (m *ResourceEvent) XXX_Size() int {
+	return xxx_messageInfo_ResourceEvent.Size(m)
+}
+func (m *ResourceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceEvent proto.InternalMessageInfo
+
+func (m *ResourceEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ResourceEvent) GetTime() *wrappers.Int64Value {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *ResourceEvent) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *ResourceEvent) GetPreviousCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.PreviousCollectionId
+	}
+	return nil
+}
+
+func (m *ResourceEvent) GetDevice() *Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+func (m *ResourceEvent) GetCollection() *Collection {
+	if m != nil {
+		return m.Collection
+	}
+	return nil
+}
+
+func (m *ResourceEvent) GetOutput() *Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
 // The output data message contains payload plus metadata for a payload received
-// from a device.
+// from a device. Resource change events use the event type.
 type OutputDataMessage struct {
 	Type                 OutputDataMessage_OutputMessageType `protobuf:"varint,1,opt,name=type,proto3,enum=apipb.OutputDataMessage_OutputMessageType" json:"type,omitempty"`
 	Device               *Device                             `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
@@ -1038,6 +1232,7 @@ type OutputDataMessage struct {
 	Transport            string                              `protobuf:"bytes,5,opt,name=transport,proto3" json:"transport,omitempty"`
 	UdpMetaData          *UDPMetadata                        `protobuf:"bytes,6,opt,name=udp_meta_data,json=udpMetaData,proto3" json:"udp_meta_data,omitempty"`
 	CoapMetaData         *CoAPMetadata                       `protobuf:"bytes,7,opt,name=coap_meta_data,json=coapMetaData,proto3" json:"coap_meta_data,omitempty"`
+	ResourceEvent        *ResourceEvent                      `protobuf:"bytes,8,opt,name=resource_event,json=resourceEvent,proto3" json:"resource_event,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
 	XXX_unrecognized     []byte                              `json:"-"`
 	XXX_sizecache        int32                               `json:"-"`
@@ -1047,7 +1242,7 @@ func (m *OutputDataMessage) Reset()         { *m = OutputDataMessage{} }
 func (m *OutputDataMessage) String() string { return proto.CompactTextString(m) }
 func (*OutputDataMessage) ProtoMessage()    {}
 func (*OutputDataMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
 }
 
 func (m *OutputDataMessage) XXX_Unmarshal(b []byte) error {
@@ -1117,6 +1312,13 @@ func (m *OutputDataMessage) GetCoapMetaData() *CoAPMetadata {
 	return nil
 }
 
+func (m *OutputDataMessage) GetResourceEvent() *ResourceEvent {
+	if m != nil {
+		return m.ResourceEvent
+	}
+	return nil
+}
+
 // Output configuration.
 type OutputConfig struct {
 	// Webhook configuration: URL for host
@@ -1151,17 +1353,32 @@ type OutputConfig struct {
 	// MQTT configuration: Client ID
 	ClientId *wrappers.StringValue `protobuf:"bytes,15,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	// MQTT configuration: Topic name
-	TopicName            *wrappers.StringValue `protobuf:"bytes,16,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	TopicName *wrappers.StringValue `protobuf:"bytes,16,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	// Webhook configuration: Maximum number of messages in a single request.
+	// The default is 100.
+	MaxBatchSize *wrappers.Int32Value `protobuf:"bytes,17,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	// Webhook configuration: Maximum time in milliseconds to wait for a batch
+	// to fill up before it is sent. The default is 0, ie no waiting.
+	MaxLingerMs *wrappers.Int32Value `protobuf:"bytes,18,opt,name=max_linger_ms,json=maxLingerMs,proto3" json:"max_linger_ms,omitempty"`
+	// Webhook and IFTTT configuration: Maximum number of requests per second.
+	// The default is 0, ie no limit.
+	MaxRequestsPerSecond *wrappers.Int32Value `protobuf:"bytes,19,opt,name=max_requests_per_second,json=maxRequestsPerSecond,proto3" json:"max_requests_per_second,omitempty"`
+	// Webhook and IFTTT configuration: Maximum number of concurrent requests.
+	// The default is 1.
+	MaxConcurrentRequests *wrappers.Int32Value `protobuf:"bytes,20,opt,name=max_concurrent_requests,json=maxConcurrentRequests,proto3" json:"max_concurrent_requests,omitempty"`
+	// Webhook and MQTT configuration: Resource event types forwarded by the
+	// output, ie "device.created". No events are forwarded by default.
+	EventTypes           []string `protobuf:"bytes,21,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *OutputConfig) Reset()         { *m = OutputConfig{} }
 func (m *OutputConfig) String() string { return proto.CompactTextString(m) }
 func (*OutputConfig) ProtoMessage()    {}
 func (*OutputConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
 }
 
 func (m *OutputConfig) XXX_Unmarshal(b []byte) error {
@@ -1294,6 +1511,41 @@ func (m *OutputConfig) GetTopicName() *wrappers.StringValue {
 	return nil
 }
 
+func (m *OutputConfig) GetMaxBatchSize() *wrappers.Int32Value {
+	if m != nil {
+		return m.MaxBatchSize
+	}
+	return nil
+}
+
+func (m *OutputConfig) GetMaxLingerMs() *wrappers.Int32Value {
+	if m != nil {
+		return m.MaxLingerMs
+	}
+	return nil
+}
+
+func (m *OutputConfig) GetMaxRequestsPerSecond() *wrappers.Int32Value {
+	if m != nil {
+		return m.MaxRequestsPerSecond
+	}
+	return nil
+}
+
+func (m *OutputConfig) GetMaxConcurrentRequests() *wrappers.Int32Value {
+	if m != nil {
+		return m.MaxConcurrentRequests
+	}
+	return nil
+}
+
+func (m *OutputConfig) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
 // Output resource. Configuration
 type Output struct {
 	OutputId             *wrappers.StringValue `protobuf:"bytes,1,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
@@ -1311,7 +1563,7 @@ func (m *Output) Reset()         { *m = Output{} }
 func (m *Output) String() string { return proto.CompactTextString(m) }
 func (*Output) ProtoMessage()    {}
 func (*Output) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
 }
 
 func (m *Output) XXX_Unmarshal(b []byte) error {
@@ -1375,10 +1627,24 @@ func (m *Output) GetTags() map[string]string {
 }
 
 type Token struct {
-	Resource             *wrappers.StringValue `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
-	Write                *wrappers.BoolValue   `protobuf:"bytes,2,opt,name=write,proto3" json:"write,omitempty"`
-	Token                *wrappers.StringValue `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
-	Tags                 map[string]string     `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Resource *wrappers.StringValue `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Write    *wrappers.BoolValue   `protobuf:"bytes,2,opt,name=write,proto3" json:"write,omitempty"`
+	// The token itself. Only the hash of the token is stored so this is only
+	// set when the token is created or rotated.
+	Token *wrappers.StringValue `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Tags  map[string]string     `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The token identifier. This is the hex-encoded SHA-256 hash of the token.
+	// Both the identifier and the token can be used to reference the token.
+	Id *wrappers.StringValue `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	// Creation time (in milliseconds since epoch)
+	Created *wrappers.Int64Value `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	// Expiry time (in milliseconds since epoch). The token never expires if
+	// this is 0.
+	Expires *wrappers.Int64Value `protobuf:"bytes,7,opt,name=expires,proto3" json:"expires,omitempty"`
+	// The time the token was last used (in milliseconds since epoch)
+	LastUsed *wrappers.Int64Value `protobuf:"bytes,8,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	// The IP address the token was last used from
+	LastUsedIp           *wrappers.StringValue `protobuf:"bytes,9,opt,name=last_used_ip,json=lastUsedIp,proto3" json:"last_used_ip,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -1388,7 +1654,7 @@ func (m *Token) Reset()         { *m = Token{} }
 func (m *Token) String() string { return proto.CompactTextString(m) }
 func (*Token) ProtoMessage()    {}
 func (*Token) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
 }
 
 func (m *Token) XXX_Unmarshal(b []byte) error {
@@ -1437,9 +1703,46 @@ func (m *Token) GetTags() map[string]string {
 	return nil
 }
 
+func (m *Token) GetId() *wrappers.StringValue {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *Token) GetCreated() *wrappers.Int64Value {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Token) GetExpires() *wrappers.Int64Value {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+func (m *Token) GetLastUsed() *wrappers.Int64Value {
+	if m != nil {
+		return m.LastUsed
+	}
+	return nil
+}
+
+func (m *Token) GetLastUsedIp() *wrappers.StringValue {
+	if m != nil {
+		return m.LastUsedIp
+	}
+	return nil
+}
+
 type Member struct {
-	UserId               *wrappers.StringValue `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TeamId               *wrappers.StringValue `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId *wrappers.StringValue `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamId *wrappers.StringValue `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// The member's role in the team. This is one of Admin, Member, Viewer,
+	// Operator or FirmwareManager.
 	Role                 *wrappers.StringValue `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
 	Name                 *wrappers.StringValue `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
 	Email                *wrappers.StringValue `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
@@ -1459,7 +1762,7 @@ func (m *Member) Reset()         { *m = Member{} }
 func (m *Member) String() string { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()    {}
 func (*Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
 }
 
 func (m *Member) XXX_Unmarshal(b []byte) error {
@@ -1575,7 +1878,7 @@ func (m *MemberList) Reset()         { *m = MemberList{} }
 func (m *MemberList) String() string { return proto.CompactTextString(m) }
 func (*MemberList) ProtoMessage()    {}
 func (*MemberList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
 }
 
 func (m *MemberList) XXX_Unmarshal(b []byte) error {
@@ -1603,90 +1906,276 @@ func (m *MemberList) GetMembers() []*Member {
 	return nil
 }
 
-type Team struct {
-	TeamId               *wrappers.StringValue `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
-	Tags                 map[string]string     `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Members              []*Member             `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+// Resource limits for a team. Limits that are not set (or zero) are unlimited.
+// Quotas are set by the operator of the service.
+type TeamQuota struct {
+	MaxDevices           *wrappers.Int32Value `protobuf:"bytes,1,opt,name=max_devices,json=maxDevices,proto3" json:"max_devices,omitempty"`
+	MaxCollections       *wrappers.Int32Value `protobuf:"bytes,2,opt,name=max_collections,json=maxCollections,proto3" json:"max_collections,omitempty"`
+	MaxOutputs           *wrappers.Int32Value `protobuf:"bytes,3,opt,name=max_outputs,json=maxOutputs,proto3" json:"max_outputs,omitempty"`
+	MaxFirmwareBytes     *wrappers.Int64Value `protobuf:"bytes,4,opt,name=max_firmware_bytes,json=maxFirmwareBytes,proto3" json:"max_firmware_bytes,omitempty"`
+	MaxUplinkPerDay      *wrappers.Int32Value `protobuf:"bytes,5,opt,name=max_uplink_per_day,json=maxUplinkPerDay,proto3" json:"max_uplink_per_day,omitempty"`
+	MaxDownlinkPerDay    *wrappers.Int32Value `protobuf:"bytes,6,opt,name=max_downlink_per_day,json=maxDownlinkPerDay,proto3" json:"max_downlink_per_day,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *Team) Reset()         { *m = Team{} }
-func (m *Team) String() string { return proto.CompactTextString(m) }
-func (*Team) ProtoMessage()    {}
-func (*Team) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+func (m *TeamQuota) Reset()         { *m = TeamQuota{} }
+func (m *TeamQuota) String() string { return proto.CompactTextString(m) }
+func (*TeamQuota) ProtoMessage()    {}
+func (*TeamQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
 }
 
-func (m *Team) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Team.Unmarshal(m, b)
+func (m *TeamQuota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamQuota.Unmarshal(m, b)
 }
-func (m *Team) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Team.Marshal(b, m, deterministic)
+func (m *TeamQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamQuota.Marshal(b, m, deterministic)
 }
-func (m *Team) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Team.Merge(m, src)
+func (m *TeamQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamQuota.Merge(m, src)
 }
-func (m *Team) XXX_Size() int {
-	return xxx_messageInfo_Team.Size(m)
+func (m *TeamQuota) XXX_Size() int {
+	return xxx_messageInfo_TeamQuota.Size(m)
 }
-func (m *Team) XXX_DiscardUnknown() {
-	xxx_messageInfo_Team.DiscardUnknown(m)
+func (m *TeamQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamQuota.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Team proto.InternalMessageInfo
+var xxx_messageInfo_TeamQuota proto.InternalMessageInfo
 
-func (m *Team) GetTeamId() *wrappers.StringValue {
+func (m *TeamQuota) GetMaxDevices() *wrappers.Int32Value {
 	if m != nil {
-		return m.TeamId
+		return m.MaxDevices
 	}
 	return nil
 }
 
-func (m *Team) GetTags() map[string]string {
+func (m *TeamQuota) GetMaxCollections() *wrappers.Int32Value {
 	if m != nil {
-		return m.Tags
+		return m.MaxCollections
 	}
 	return nil
 }
 
-func (m *Team) GetMembers() []*Member {
+func (m *TeamQuota) GetMaxOutputs() *wrappers.Int32Value {
 	if m != nil {
-		return m.Members
+		return m.MaxOutputs
 	}
 	return nil
 }
 
-type Firmware struct {
-	ImageId              *wrappers.StringValue `protobuf:"bytes,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
-	Version              *wrappers.StringValue `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Filename             *wrappers.StringValue `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	Sha256               *wrappers.StringValue `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
-	Length               *wrappers.Int32Value  `protobuf:"bytes,5,opt,name=length,proto3" json:"length,omitempty"`
-	CollectionId         *wrappers.StringValue `protobuf:"bytes,6,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
-	Created              *wrappers.Int64Value  `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
-	Tags                 map[string]string     `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+func (m *TeamQuota) GetMaxFirmwareBytes() *wrappers.Int64Value {
+	if m != nil {
+		return m.MaxFirmwareBytes
+	}
+	return nil
 }
 
-func (m *Firmware) Reset()         { *m = Firmware{} }
-func (m *Firmware) String() string { return proto.CompactTextString(m) }
-func (*Firmware) ProtoMessage()    {}
-func (*Firmware) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+func (m *TeamQuota) GetMaxUplinkPerDay() *wrappers.Int32Value {
+	if m != nil {
+		return m.MaxUplinkPerDay
+	}
+	return nil
 }
 
-func (m *Firmware) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Firmware.Unmarshal(m, b)
-}
-func (m *Firmware) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Firmware.Marshal(b, m, deterministic)
+func (m *TeamQuota) GetMaxDownlinkPerDay() *wrappers.Int32Value {
+	if m != nil {
+		return m.MaxDownlinkPerDay
+	}
+	return nil
 }
-func (m *Firmware) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Firmware.Merge(m, src)
+
+// Current resource usage for a team. Message counts are for the current UTC
+// day.
+type TeamUsage struct {
+	Devices              *wrappers.Int32Value `protobuf:"bytes,1,opt,name=devices,proto3" json:"devices,omitempty"`
+	Collections          *wrappers.Int32Value `protobuf:"bytes,2,opt,name=collections,proto3" json:"collections,omitempty"`
+	Outputs              *wrappers.Int32Value `protobuf:"bytes,3,opt,name=outputs,proto3" json:"outputs,omitempty"`
+	FirmwareBytes        *wrappers.Int64Value `protobuf:"bytes,4,opt,name=firmware_bytes,json=firmwareBytes,proto3" json:"firmware_bytes,omitempty"`
+	UplinkToday          *wrappers.Int32Value `protobuf:"bytes,5,opt,name=uplink_today,json=uplinkToday,proto3" json:"uplink_today,omitempty"`
+	DownlinkToday        *wrappers.Int32Value `protobuf:"bytes,6,opt,name=downlink_today,json=downlinkToday,proto3" json:"downlink_today,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TeamUsage) Reset()         { *m = TeamUsage{} }
+func (m *TeamUsage) String() string { return proto.CompactTextString(m) }
+func (*TeamUsage) ProtoMessage()    {}
+func (*TeamUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+}
+
+func (m *TeamUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamUsage.Unmarshal(m, b)
+}
+func (m *TeamUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamUsage.Marshal(b, m, deterministic)
+}
+func (m *TeamUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamUsage.Merge(m, src)
+}
+func (m *TeamUsage) XXX_Size() int {
+	return xxx_messageInfo_TeamUsage.Size(m)
+}
+func (m *TeamUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamUsage proto.InternalMessageInfo
+
+func (m *TeamUsage) GetDevices() *wrappers.Int32Value {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *TeamUsage) GetCollections() *wrappers.Int32Value {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *TeamUsage) GetOutputs() *wrappers.Int32Value {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *TeamUsage) GetFirmwareBytes() *wrappers.Int64Value {
+	if m != nil {
+		return m.FirmwareBytes
+	}
+	return nil
+}
+
+func (m *TeamUsage) GetUplinkToday() *wrappers.Int32Value {
+	if m != nil {
+		return m.UplinkToday
+	}
+	return nil
+}
+
+func (m *TeamUsage) GetDownlinkToday() *wrappers.Int32Value {
+	if m != nil {
+		return m.DownlinkToday
+	}
+	return nil
+}
+
+type Team struct {
+	TeamId  *wrappers.StringValue `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Tags    map[string]string     `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Members []*Member             `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	// Quota and usage are only set when a single team is retrieved.
+	Quota                *TeamQuota `protobuf:"bytes,4,opt,name=quota,proto3" json:"quota,omitempty"`
+	Usage                *TeamUsage `protobuf:"bytes,5,opt,name=usage,proto3" json:"usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Team) Reset()         { *m = Team{} }
+func (m *Team) String() string { return proto.CompactTextString(m) }
+func (*Team) ProtoMessage()    {}
+func (*Team) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+}
+
+func (m *Team) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Team.Unmarshal(m, b)
+}
+func (m *Team) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Team.Marshal(b, m, deterministic)
+}
+func (m *Team) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Team.Merge(m, src)
+}
+func (m *Team) XXX_Size() int {
+	return xxx_messageInfo_Team.Size(m)
+}
+func (m *Team) XXX_DiscardUnknown() {
+	xxx_messageInfo_Team.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Team proto.InternalMessageInfo
+
+func (m *Team) GetTeamId() *wrappers.StringValue {
+	if m != nil {
+		return m.TeamId
+	}
+	return nil
+}
+
+func (m *Team) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Team) GetMembers() []*Member {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *Team) GetQuota() *TeamQuota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+func (m *Team) GetUsage() *TeamUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
+}
+
+type Firmware struct {
+	ImageId      *wrappers.StringValue `protobuf:"bytes,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	Version      *wrappers.StringValue `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Filename     *wrappers.StringValue `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Sha256       *wrappers.StringValue `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Length       *wrappers.Int32Value  `protobuf:"bytes,5,opt,name=length,proto3" json:"length,omitempty"`
+	CollectionId *wrappers.StringValue `protobuf:"bytes,6,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Created      *wrappers.Int64Value  `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
+	Tags         map[string]string     `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The signature of the SHA-256 checksum. Empty for unsigned images.
+	Signature []byte `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	// The signing key used for the signature.
+	SigningKeyId *wrappers.StringValue `protobuf:"bytes,10,opt,name=signing_key_id,json=signingKeyId,proto3" json:"signing_key_id,omitempty"`
+	// Compatibility rules for the image. Devices that don't match the rules
+	// won't be updated to the image.
+	Compatibility        *FirmwareCompatibility `protobuf:"bytes,11,opt,name=compatibility,proto3" json:"compatibility,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *Firmware) Reset()         { *m = Firmware{} }
+func (m *Firmware) String() string { return proto.CompactTextString(m) }
+func (*Firmware) ProtoMessage()    {}
+func (*Firmware) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+}
+
+func (m *Firmware) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Firmware.Unmarshal(m, b)
+}
+func (m *Firmware) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Firmware.Marshal(b, m, deterministic)
+}
+func (m *Firmware) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Firmware.Merge(m, src)
 }
 func (m *Firmware) XXX_Size() int {
 	return xxx_messageInfo_Firmware.Size(m)
@@ -1753,6 +2242,88 @@ func (m *Firmware) GetTags() map[string]string {
 	return nil
 }
 
+func (m *Firmware) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Firmware) GetSigningKeyId() *wrappers.StringValue {
+	if m != nil {
+		return m.SigningKeyId
+	}
+	return nil
+}
+
+func (m *Firmware) GetCompatibility() *FirmwareCompatibility {
+	if m != nil {
+		return m.Compatibility
+	}
+	return nil
+}
+
+// FirmwareCompatibility is the compatibility rules for a firmware image. Empty
+// fields match all devices.
+type FirmwareCompatibility struct {
+	// The manufacturer reported by the device. The match is case insensitive.
+	Manufacturer *wrappers.StringValue `protobuf:"bytes,1,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	// Glob pattern for the model number reported by the device, f.e. "EE0*"
+	ModelPattern *wrappers.StringValue `protobuf:"bytes,2,opt,name=model_pattern,json=modelPattern,proto3" json:"model_pattern,omitempty"`
+	// The minimum firmware version the device must run before it can be updated
+	// to the image.
+	MinimumVersion       *wrappers.StringValue `protobuf:"bytes,3,opt,name=minimum_version,json=minimumVersion,proto3" json:"minimum_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *FirmwareCompatibility) Reset()         { *m = FirmwareCompatibility{} }
+func (m *FirmwareCompatibility) String() string { return proto.CompactTextString(m) }
+func (*FirmwareCompatibility) ProtoMessage()    {}
+func (*FirmwareCompatibility) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+}
+
+func (m *FirmwareCompatibility) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FirmwareCompatibility.Unmarshal(m, b)
+}
+func (m *FirmwareCompatibility) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FirmwareCompatibility.Marshal(b, m, deterministic)
+}
+func (m *FirmwareCompatibility) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FirmwareCompatibility.Merge(m, src)
+}
+func (m *FirmwareCompatibility) XXX_Size() int {
+	return xxx_messageInfo_FirmwareCompatibility.Size(m)
+}
+func (m *FirmwareCompatibility) XXX_DiscardUnknown() {
+	xxx_messageInfo_FirmwareCompatibility.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FirmwareCompatibility proto.InternalMessageInfo
+
+func (m *FirmwareCompatibility) GetManufacturer() *wrappers.StringValue {
+	if m != nil {
+		return m.Manufacturer
+	}
+	return nil
+}
+
+func (m *FirmwareCompatibility) GetModelPattern() *wrappers.StringValue {
+	if m != nil {
+		return m.ModelPattern
+	}
+	return nil
+}
+
+func (m *FirmwareCompatibility) GetMinimumVersion() *wrappers.StringValue {
+	if m != nil {
+		return m.MinimumVersion
+	}
+	return nil
+}
+
 // Consider splitting into two objects, one for device, one for collection
 type ListMessagesRequest struct {
 	// The collection to query
@@ -1775,7 +2346,7 @@ func (m *ListMessagesRequest) Reset()         { *m = ListMessagesRequest{} }
 func (m *ListMessagesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListMessagesRequest) ProtoMessage()    {}
 func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
 }
 
 func (m *ListMessagesRequest) XXX_Unmarshal(b []byte) error {
@@ -1842,7 +2413,7 @@ func (m *ListMessagesResponse) Reset()         { *m = ListMessagesResponse{} }
 func (m *ListMessagesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListMessagesResponse) ProtoMessage()    {}
 func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
 }
 
 func (m *ListMessagesResponse) XXX_Unmarshal(b []byte) error {
@@ -1894,7 +2465,7 @@ func (m *UserProfile) Reset()         { *m = UserProfile{} }
 func (m *UserProfile) String() string { return proto.CompactTextString(m) }
 func (*UserProfile) ProtoMessage()    {}
 func (*UserProfile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
 }
 
 func (m *UserProfile) XXX_Unmarshal(b []byte) error {
@@ -2005,7 +2576,7 @@ func (m *DeleteCollectionRequest) Reset()         { *m = DeleteCollectionRequest
 func (m *DeleteCollectionRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteCollectionRequest) ProtoMessage()    {}
 func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
 }
 
 func (m *DeleteCollectionRequest) XXX_Unmarshal(b []byte) error {
@@ -2035,16 +2606,23 @@ func (m *DeleteCollectionRequest) GetCollectionId() *wrappers.StringValue {
 
 // List the collection you have access to
 type ListCollectionRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Maximum number of elements to return. All elements are returned if it
+	// isn't set. The maximum page size is 1000.
+	PageSize *wrappers.Int32Value `protobuf:"bytes,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Page token from the previous response
+	PageToken *wrappers.StringValue `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Tag filter expression, f.e. "site=oslo AND type!=gateway"
+	TagFilter            *wrappers.StringValue `protobuf:"bytes,3,opt,name=tag_filter,json=tagFilter,proto3" json:"tag_filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ListCollectionRequest) Reset()         { *m = ListCollectionRequest{} }
 func (m *ListCollectionRequest) String() string { return proto.CompactTextString(m) }
 func (*ListCollectionRequest) ProtoMessage()    {}
 func (*ListCollectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
 }
 
 func (m *ListCollectionRequest) XXX_Unmarshal(b []byte) error {
@@ -2065,19 +2643,42 @@ func (m *ListCollectionRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ListCollectionRequest proto.InternalMessageInfo
 
+func (m *ListCollectionRequest) GetPageSize() *wrappers.Int32Value {
+	if m != nil {
+		return m.PageSize
+	}
+	return nil
+}
+
+func (m *ListCollectionRequest) GetPageToken() *wrappers.StringValue {
+	if m != nil {
+		return m.PageToken
+	}
+	return nil
+}
+
+func (m *ListCollectionRequest) GetTagFilter() *wrappers.StringValue {
+	if m != nil {
+		return m.TagFilter
+	}
+	return nil
+}
+
 // Collection list. The list contains all the collections you have access to.
 type ListCollectionResponse struct {
-	Collections          []*Collection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Collections []*Collection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	// Token for the next page. It is empty on the last page.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListCollectionResponse) Reset()         { *m = ListCollectionResponse{} }
 func (m *ListCollectionResponse) String() string { return proto.CompactTextString(m) }
 func (*ListCollectionResponse) ProtoMessage()    {}
 func (*ListCollectionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
 }
 
 func (m *ListCollectionResponse) XXX_Unmarshal(b []byte) error {
@@ -2105,6 +2706,13 @@ func (m *ListCollectionResponse) GetCollections() []*Collection {
 	return nil
 }
 
+func (m *ListCollectionResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 // Retrieve a single collection
 type RetrieveCollectionRequest struct {
 	// The collection ID of the collection you are requesting
@@ -2118,7 +2726,7 @@ func (m *RetrieveCollectionRequest) Reset()         { *m = RetrieveCollectionReq
 func (m *RetrieveCollectionRequest) String() string { return proto.CompactTextString(m) }
 func (*RetrieveCollectionRequest) ProtoMessage()    {}
 func (*RetrieveCollectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
 }
 
 func (m *RetrieveCollectionRequest) XXX_Unmarshal(b []byte) error {
@@ -2158,7 +2766,7 @@ func (m *MessageStreamRequest) Reset()         { *m = MessageStreamRequest{} }
 func (m *MessageStreamRequest) String() string { return proto.CompactTextString(m) }
 func (*MessageStreamRequest) ProtoMessage()    {}
 func (*MessageStreamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
 }
 
 func (m *MessageStreamRequest) XXX_Unmarshal(b []byte) error {
@@ -2205,7 +2813,7 @@ func (m *DeviceRequest) Reset()         { *m = DeviceRequest{} }
 func (m *DeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*DeviceRequest) ProtoMessage()    {}
 func (*DeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
 }
 
 func (m *DeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -2241,7 +2849,17 @@ func (m *DeviceRequest) GetDeviceId() *wrappers.StringValue {
 }
 
 type ListDevicesRequest struct {
-	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Maximum number of elements to return. All elements are returned if it
+	// isn't set. The maximum page size is 1000.
+	PageSize *wrappers.Int32Value `protobuf:"bytes,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Page token from the previous response
+	PageToken *wrappers.StringValue `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Tag filter expression, f.e. "site=oslo AND type!=gateway"
+	TagFilter *wrappers.StringValue `protobuf:"bytes,4,opt,name=tag_filter,json=tagFilter,proto3" json:"tag_filter,omitempty"`
+	// Sort order. Devices can be sorted by "created" (the default), "imsi" and
+	// "last_seen". The last seen order has the most recently seen devices first.
+	SortBy               *wrappers.StringValue `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -2251,7 +2869,7 @@ func (m *ListDevicesRequest) Reset()         { *m = ListDevicesRequest{} }
 func (m *ListDevicesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListDevicesRequest) ProtoMessage()    {}
 func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
 }
 
 func (m *ListDevicesRequest) XXX_Unmarshal(b []byte) error {
@@ -2279,18 +2897,48 @@ func (m *ListDevicesRequest) GetCollectionId() *wrappers.StringValue {
 	return nil
 }
 
+func (m *ListDevicesRequest) GetPageSize() *wrappers.Int32Value {
+	if m != nil {
+		return m.PageSize
+	}
+	return nil
+}
+
+func (m *ListDevicesRequest) GetPageToken() *wrappers.StringValue {
+	if m != nil {
+		return m.PageToken
+	}
+	return nil
+}
+
+func (m *ListDevicesRequest) GetTagFilter() *wrappers.StringValue {
+	if m != nil {
+		return m.TagFilter
+	}
+	return nil
+}
+
+func (m *ListDevicesRequest) GetSortBy() *wrappers.StringValue {
+	if m != nil {
+		return m.SortBy
+	}
+	return nil
+}
+
 type ListDevicesResponse struct {
-	Devices              []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	// Token for the next page. It is empty on the last page.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListDevicesResponse) Reset()         { *m = ListDevicesResponse{} }
 func (m *ListDevicesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListDevicesResponse) ProtoMessage()    {}
 func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
 }
 
 func (m *ListDevicesResponse) XXX_Unmarshal(b []byte) error {
@@ -2318,8 +2966,278 @@ func (m *ListDevicesResponse) GetDevices() []*Device {
 	return nil
 }
 
-type ClearFirmwareErrorResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+func (m *ListDevicesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// Bulk import of devices. CSV data must have a header row with the imsi and
+// imei columns and an optional id column. The remaining columns are tags.
+// NDJSON data has one JSON object per line with the id, imsi, imei and tags
+// fields.
+type ImportDevicesRequest struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// The data format. This is either "csv" (the default) or "ndjson"
+	Format *wrappers.StringValue `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Data   []byte                `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Update existing devices in the collection with the same ID, IMSI or IMEI
+	Upsert               *wrappers.BoolValue `protobuf:"bytes,4,opt,name=upsert,proto3" json:"upsert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ImportDevicesRequest) Reset()         { *m = ImportDevicesRequest{} }
+func (m *ImportDevicesRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportDevicesRequest) ProtoMessage()    {}
+func (*ImportDevicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+}
+
+func (m *ImportDevicesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportDevicesRequest.Unmarshal(m, b)
+}
+func (m *ImportDevicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportDevicesRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportDevicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportDevicesRequest.Merge(m, src)
+}
+func (m *ImportDevicesRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportDevicesRequest.Size(m)
+}
+func (m *ImportDevicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportDevicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportDevicesRequest proto.InternalMessageInfo
+
+func (m *ImportDevicesRequest) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *ImportDevicesRequest) GetFormat() *wrappers.StringValue {
+	if m != nil {
+		return m.Format
+	}
+	return nil
+}
+
+func (m *ImportDevicesRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ImportDevicesRequest) GetUpsert() *wrappers.BoolValue {
+	if m != nil {
+		return m.Upsert
+	}
+	return nil
+}
+
+// Error for a single row in an import
+type ImportError struct {
+	// Row number in the data, starting at 1. The CSV header is row 1.
+	Row                  int32    `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportError) Reset()         { *m = ImportError{} }
+func (m *ImportError) String() string { return proto.CompactTextString(m) }
+func (*ImportError) ProtoMessage()    {}
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+}
+
+func (m *ImportError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportError.Unmarshal(m, b)
+}
+func (m *ImportError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportError.Marshal(b, m, deterministic)
+}
+func (m *ImportError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportError.Merge(m, src)
+}
+func (m *ImportError) XXX_Size() int {
+	return xxx_messageInfo_ImportError.Size(m)
+}
+func (m *ImportError) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportError proto.InternalMessageInfo
+
+func (m *ImportError) GetRow() int32 {
+	if m != nil {
+		return m.Row
+	}
+	return 0
+}
+
+func (m *ImportError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// The result of an import. Nothing is imported if there are errors.
+type ImportDevicesResponse struct {
+	Created              int32          `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Updated              int32          `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Errors               []*ImportError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ImportDevicesResponse) Reset()         { *m = ImportDevicesResponse{} }
+func (m *ImportDevicesResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportDevicesResponse) ProtoMessage()    {}
+func (*ImportDevicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
+}
+
+func (m *ImportDevicesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportDevicesResponse.Unmarshal(m, b)
+}
+func (m *ImportDevicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportDevicesResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportDevicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportDevicesResponse.Merge(m, src)
+}
+func (m *ImportDevicesResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportDevicesResponse.Size(m)
+}
+func (m *ImportDevicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportDevicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportDevicesResponse proto.InternalMessageInfo
+
+func (m *ImportDevicesResponse) GetCreated() int32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ImportDevicesResponse) GetUpdated() int32 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *ImportDevicesResponse) GetErrors() []*ImportError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type ExportDevicesRequest struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// The data format. This is either "csv" (the default) or "ndjson"
+	Format               *wrappers.StringValue `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ExportDevicesRequest) Reset()         { *m = ExportDevicesRequest{} }
+func (m *ExportDevicesRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportDevicesRequest) ProtoMessage()    {}
+func (*ExportDevicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
+}
+
+func (m *ExportDevicesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportDevicesRequest.Unmarshal(m, b)
+}
+func (m *ExportDevicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportDevicesRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportDevicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportDevicesRequest.Merge(m, src)
+}
+func (m *ExportDevicesRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportDevicesRequest.Size(m)
+}
+func (m *ExportDevicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportDevicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportDevicesRequest proto.InternalMessageInfo
+
+func (m *ExportDevicesRequest) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *ExportDevicesRequest) GetFormat() *wrappers.StringValue {
+	if m != nil {
+		return m.Format
+	}
+	return nil
+}
+
+// A chunk of exported devices. The chunks are in the same format as the
+// import.
+type ExportDevicesResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportDevicesResponse) Reset()         { *m = ExportDevicesResponse{} }
+func (m *ExportDevicesResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportDevicesResponse) ProtoMessage()    {}
+func (*ExportDevicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+}
+
+func (m *ExportDevicesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportDevicesResponse.Unmarshal(m, b)
+}
+func (m *ExportDevicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportDevicesResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportDevicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportDevicesResponse.Merge(m, src)
+}
+func (m *ExportDevicesResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportDevicesResponse.Size(m)
+}
+func (m *ExportDevicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportDevicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportDevicesResponse proto.InternalMessageInfo
+
+func (m *ExportDevicesResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ClearFirmwareErrorResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
@@ -2328,7 +3246,7 @@ func (m *ClearFirmwareErrorResponse) Reset()         { *m = ClearFirmwareErrorRe
 func (m *ClearFirmwareErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*ClearFirmwareErrorResponse) ProtoMessage()    {}
 func (*ClearFirmwareErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
 }
 
 func (m *ClearFirmwareErrorResponse) XXX_Unmarshal(b []byte) error {
@@ -2372,7 +3290,7 @@ func (m *SendMessageRequest) Reset()         { *m = SendMessageRequest{} }
 func (m *SendMessageRequest) String() string { return proto.CompactTextString(m) }
 func (*SendMessageRequest) ProtoMessage()    {}
 func (*SendMessageRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
 }
 
 func (m *SendMessageRequest) XXX_Unmarshal(b []byte) error {
@@ -2435,7 +3353,6 @@ func (m *SendMessageRequest) GetCoapPath() *wrappers.StringValue {
 	return nil
 }
 
-//
 type SendMessageResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -2446,7 +3363,7 @@ func (m *SendMessageResponse) Reset()         { *m = SendMessageResponse{} }
 func (m *SendMessageResponse) String() string { return proto.CompactTextString(m) }
 func (*SendMessageResponse) ProtoMessage()    {}
 func (*SendMessageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
 }
 
 func (m *SendMessageResponse) XXX_Unmarshal(b []byte) error {
@@ -2480,7 +3397,7 @@ func (m *MessageSendResult) Reset()         { *m = MessageSendResult{} }
 func (m *MessageSendResult) String() string { return proto.CompactTextString(m) }
 func (*MessageSendResult) ProtoMessage()    {}
 func (*MessageSendResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
 }
 
 func (m *MessageSendResult) XXX_Unmarshal(b []byte) error {
@@ -2530,7 +3447,7 @@ func (m *MultiSendMessageResponse) Reset()         { *m = MultiSendMessageRespon
 func (m *MultiSendMessageResponse) String() string { return proto.CompactTextString(m) }
 func (*MultiSendMessageResponse) ProtoMessage()    {}
 func (*MultiSendMessageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
 }
 
 func (m *MultiSendMessageResponse) XXX_Unmarshal(b []byte) error {
@@ -2587,7 +3504,7 @@ func (m *FirmwareRequest) Reset()         { *m = FirmwareRequest{} }
 func (m *FirmwareRequest) String() string { return proto.CompactTextString(m) }
 func (*FirmwareRequest) ProtoMessage()    {}
 func (*FirmwareRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
 }
 
 func (m *FirmwareRequest) XXX_Unmarshal(b []byte) error {
@@ -2623,7 +3540,14 @@ func (m *FirmwareRequest) GetImageId() *wrappers.StringValue {
 }
 
 type ListFirmwareRequest struct {
-	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Maximum number of elements to return. All elements are returned if it
+	// isn't set. The maximum page size is 1000.
+	PageSize *wrappers.Int32Value `protobuf:"bytes,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Page token from the previous response
+	PageToken *wrappers.StringValue `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Tag filter expression, f.e. "site=oslo AND type!=gateway"
+	TagFilter            *wrappers.StringValue `protobuf:"bytes,4,opt,name=tag_filter,json=tagFilter,proto3" json:"tag_filter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -2633,7 +3557,7 @@ func (m *ListFirmwareRequest) Reset()         { *m = ListFirmwareRequest{} }
 func (m *ListFirmwareRequest) String() string { return proto.CompactTextString(m) }
 func (*ListFirmwareRequest) ProtoMessage()    {}
 func (*ListFirmwareRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
 }
 
 func (m *ListFirmwareRequest) XXX_Unmarshal(b []byte) error {
@@ -2661,18 +3585,41 @@ func (m *ListFirmwareRequest) GetCollectionId() *wrappers.StringValue {
 	return nil
 }
 
+func (m *ListFirmwareRequest) GetPageSize() *wrappers.Int32Value {
+	if m != nil {
+		return m.PageSize
+	}
+	return nil
+}
+
+func (m *ListFirmwareRequest) GetPageToken() *wrappers.StringValue {
+	if m != nil {
+		return m.PageToken
+	}
+	return nil
+}
+
+func (m *ListFirmwareRequest) GetTagFilter() *wrappers.StringValue {
+	if m != nil {
+		return m.TagFilter
+	}
+	return nil
+}
+
 type ListFirmwareResponse struct {
-	Images               []*Firmware `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Images []*Firmware `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
+	// Token for the next page. It is empty on the last page.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListFirmwareResponse) Reset()         { *m = ListFirmwareResponse{} }
 func (m *ListFirmwareResponse) String() string { return proto.CompactTextString(m) }
 func (*ListFirmwareResponse) ProtoMessage()    {}
 func (*ListFirmwareResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
 }
 
 func (m *ListFirmwareResponse) XXX_Unmarshal(b []byte) error {
@@ -2700,20 +3647,29 @@ func (m *ListFirmwareResponse) GetImages() []*Firmware {
 	return nil
 }
 
+func (m *ListFirmwareResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type FirmwareUsageResponse struct {
-	ImageId              *wrappers.StringValue `protobuf:"bytes,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
-	Targeted             []string              `protobuf:"bytes,2,rep,name=targeted,proto3" json:"targeted,omitempty"`
-	Current              []string              `protobuf:"bytes,3,rep,name=current,proto3" json:"current,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	ImageId  *wrappers.StringValue `protobuf:"bytes,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	Targeted []string              `protobuf:"bytes,2,rep,name=targeted,proto3" json:"targeted,omitempty"`
+	Current  []string              `protobuf:"bytes,3,rep,name=current,proto3" json:"current,omitempty"`
+	// Update results for devices targeted with the image
+	Statistics           *FirmwareStatistics `protobuf:"bytes,4,opt,name=statistics,proto3" json:"statistics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *FirmwareUsageResponse) Reset()         { *m = FirmwareUsageResponse{} }
 func (m *FirmwareUsageResponse) String() string { return proto.CompactTextString(m) }
 func (*FirmwareUsageResponse) ProtoMessage()    {}
 func (*FirmwareUsageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{51}
 }
 
 func (m *FirmwareUsageResponse) XXX_Unmarshal(b []byte) error {
@@ -2755,394 +3711,1536 @@ func (m *FirmwareUsageResponse) GetCurrent() []string {
 	return nil
 }
 
-type CreateFirmwareRequest struct {
-	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
-	Image                []byte                `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Version              *wrappers.StringValue `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
-	Filename             *wrappers.StringValue `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"`
-	Tags                 map[string]string     `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+func (m *FirmwareUsageResponse) GetStatistics() *FirmwareStatistics {
+	if m != nil {
+		return m.Statistics
+	}
+	return nil
 }
 
-func (m *CreateFirmwareRequest) Reset()         { *m = CreateFirmwareRequest{} }
-func (m *CreateFirmwareRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateFirmwareRequest) ProtoMessage()    {}
-func (*CreateFirmwareRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+// Aggregated update results for a firmware image
+type FirmwareStatistics struct {
+	// Downloads started
+	Downloads int32 `protobuf:"varint,1,opt,name=downloads,proto3" json:"downloads,omitempty"`
+	// Downloads completed
+	Completed int32 `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	// Devices reporting the image as the current version after the download
+	Updated  int32 `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed   int32 `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	TimedOut int32 `protobuf:"varint,5,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	// Devices reverting to the previous version after the download
+	Reverted             int32    `protobuf:"varint,6,opt,name=reverted,proto3" json:"reverted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateFirmwareRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateFirmwareRequest.Unmarshal(m, b)
+func (m *FirmwareStatistics) Reset()         { *m = FirmwareStatistics{} }
+func (m *FirmwareStatistics) String() string { return proto.CompactTextString(m) }
+func (*FirmwareStatistics) ProtoMessage()    {}
+func (*FirmwareStatistics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{52}
 }
-func (m *CreateFirmwareRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateFirmwareRequest.Marshal(b, m, deterministic)
+
+func (m *FirmwareStatistics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FirmwareStatistics.Unmarshal(m, b)
 }
-func (m *CreateFirmwareRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateFirmwareRequest.Merge(m, src)
+func (m *FirmwareStatistics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FirmwareStatistics.Marshal(b, m, deterministic)
 }
-func (m *CreateFirmwareRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateFirmwareRequest.Size(m)
+func (m *FirmwareStatistics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FirmwareStatistics.Merge(m, src)
 }
-func (m *CreateFirmwareRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateFirmwareRequest.DiscardUnknown(m)
+func (m *FirmwareStatistics) XXX_Size() int {
+	return xxx_messageInfo_FirmwareStatistics.Size(m)
+}
+func (m *FirmwareStatistics) XXX_DiscardUnknown() {
+	xxx_messageInfo_FirmwareStatistics.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateFirmwareRequest proto.InternalMessageInfo
+var xxx_messageInfo_FirmwareStatistics proto.InternalMessageInfo
 
-func (m *CreateFirmwareRequest) GetCollectionId() *wrappers.StringValue {
+func (m *FirmwareStatistics) GetDownloads() int32 {
 	if m != nil {
-		return m.CollectionId
+		return m.Downloads
 	}
-	return nil
+	return 0
 }
 
-func (m *CreateFirmwareRequest) GetImage() []byte {
+func (m *FirmwareStatistics) GetCompleted() int32 {
 	if m != nil {
-		return m.Image
+		return m.Completed
 	}
-	return nil
+	return 0
 }
 
-func (m *CreateFirmwareRequest) GetVersion() *wrappers.StringValue {
+func (m *FirmwareStatistics) GetUpdated() int32 {
 	if m != nil {
-		return m.Version
+		return m.Updated
 	}
-	return nil
+	return 0
 }
 
-func (m *CreateFirmwareRequest) GetFilename() *wrappers.StringValue {
+func (m *FirmwareStatistics) GetFailed() int32 {
 	if m != nil {
-		return m.Filename
+		return m.Failed
 	}
-	return nil
+	return 0
 }
 
-func (m *CreateFirmwareRequest) GetTags() map[string]string {
+func (m *FirmwareStatistics) GetTimedOut() int32 {
 	if m != nil {
-		return m.Tags
+		return m.TimedOut
 	}
-	return nil
+	return 0
 }
 
-type ListOutputResponse struct {
-	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
-	Outputs              []*Output             `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+func (m *FirmwareStatistics) GetReverted() int32 {
+	if m != nil {
+		return m.Reverted
+	}
+	return 0
 }
 
-func (m *ListOutputResponse) Reset()         { *m = ListOutputResponse{} }
-func (m *ListOutputResponse) String() string { return proto.CompactTextString(m) }
-func (*ListOutputResponse) ProtoMessage()    {}
-func (*ListOutputResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
+// A firmware state transition for a device
+type FirmwareHistoryEntry struct {
+	EntryId *wrappers.StringValue `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// Time of the transition (in milliseconds since epoch)
+	Time         *wrappers.Int64Value  `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	CollectionId *wrappers.StringValue `protobuf:"bytes,3,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	DeviceId     *wrappers.StringValue `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// The image on the device when the transition happened
+	FromImageId *wrappers.StringValue `protobuf:"bytes,5,opt,name=from_image_id,json=fromImageId,proto3" json:"from_image_id,omitempty"`
+	// The target image for the update
+	ToImageId *wrappers.StringValue `protobuf:"bytes,6,opt,name=to_image_id,json=toImageId,proto3" json:"to_image_id,omitempty"`
+	// The states use the same names as the FirmwareMetadata state
+	PreviousState *wrappers.StringValue `protobuf:"bytes,7,opt,name=previous_state,json=previousState,proto3" json:"previous_state,omitempty"`
+	State         *wrappers.StringValue `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`
+	Message       *wrappers.StringValue `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	// Time spent in the previous state (in milliseconds)
+	Duration             *wrappers.Int64Value `protobuf:"bytes,10,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ListOutputResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListOutputResponse.Unmarshal(m, b)
+func (m *FirmwareHistoryEntry) Reset()         { *m = FirmwareHistoryEntry{} }
+func (m *FirmwareHistoryEntry) String() string { return proto.CompactTextString(m) }
+func (*FirmwareHistoryEntry) ProtoMessage()    {}
+func (*FirmwareHistoryEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{53}
 }
-func (m *ListOutputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListOutputResponse.Marshal(b, m, deterministic)
+
+func (m *FirmwareHistoryEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FirmwareHistoryEntry.Unmarshal(m, b)
 }
-func (m *ListOutputResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListOutputResponse.Merge(m, src)
+func (m *FirmwareHistoryEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FirmwareHistoryEntry.Marshal(b, m, deterministic)
 }
-func (m *ListOutputResponse) XXX_Size() int {
-	return xxx_messageInfo_ListOutputResponse.Size(m)
+func (m *FirmwareHistoryEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FirmwareHistoryEntry.Merge(m, src)
 }
-func (m *ListOutputResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListOutputResponse.DiscardUnknown(m)
+func (m *FirmwareHistoryEntry) XXX_Size() int {
+	return xxx_messageInfo_FirmwareHistoryEntry.Size(m)
+}
+func (m *FirmwareHistoryEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_FirmwareHistoryEntry.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListOutputResponse proto.InternalMessageInfo
+var xxx_messageInfo_FirmwareHistoryEntry proto.InternalMessageInfo
 
-func (m *ListOutputResponse) GetCollectionId() *wrappers.StringValue {
+func (m *FirmwareHistoryEntry) GetEntryId() *wrappers.StringValue {
 	if m != nil {
-		return m.CollectionId
+		return m.EntryId
 	}
 	return nil
 }
 
-func (m *ListOutputResponse) GetOutputs() []*Output {
+func (m *FirmwareHistoryEntry) GetTime() *wrappers.Int64Value {
 	if m != nil {
-		return m.Outputs
+		return m.Time
 	}
 	return nil
 }
 
-type ListOutputRequest struct {
-	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+func (m *FirmwareHistoryEntry) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
 }
 
-func (m *ListOutputRequest) Reset()         { *m = ListOutputRequest{} }
-func (m *ListOutputRequest) String() string { return proto.CompactTextString(m) }
-func (*ListOutputRequest) ProtoMessage()    {}
-func (*ListOutputRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+func (m *FirmwareHistoryEntry) GetDeviceId() *wrappers.StringValue {
+	if m != nil {
+		return m.DeviceId
+	}
+	return nil
 }
 
-func (m *ListOutputRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListOutputRequest.Unmarshal(m, b)
-}
-func (m *ListOutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListOutputRequest.Marshal(b, m, deterministic)
+func (m *FirmwareHistoryEntry) GetFromImageId() *wrappers.StringValue {
+	if m != nil {
+		return m.FromImageId
+	}
+	return nil
 }
-func (m *ListOutputRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListOutputRequest.Merge(m, src)
+
+func (m *FirmwareHistoryEntry) GetToImageId() *wrappers.StringValue {
+	if m != nil {
+		return m.ToImageId
+	}
+	return nil
 }
-func (m *ListOutputRequest) XXX_Size() int {
-	return xxx_messageInfo_ListOutputRequest.Size(m)
+
+func (m *FirmwareHistoryEntry) GetPreviousState() *wrappers.StringValue {
+	if m != nil {
+		return m.PreviousState
+	}
+	return nil
 }
-func (m *ListOutputRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListOutputRequest.DiscardUnknown(m)
+
+func (m *FirmwareHistoryEntry) GetState() *wrappers.StringValue {
+	if m != nil {
+		return m.State
+	}
+	return nil
 }
 
-var xxx_messageInfo_ListOutputRequest proto.InternalMessageInfo
+func (m *FirmwareHistoryEntry) GetMessage() *wrappers.StringValue {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
 
-func (m *ListOutputRequest) GetCollectionId() *wrappers.StringValue {
+func (m *FirmwareHistoryEntry) GetDuration() *wrappers.Int64Value {
 	if m != nil {
-		return m.CollectionId
+		return m.Duration
 	}
 	return nil
 }
 
-type OutputRequest struct {
-	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
-	OutputId             *wrappers.StringValue `protobuf:"bytes,2,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+type ListDeviceFirmwareHistoryRequest struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	DeviceId     *wrappers.StringValue `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Maximum number of entries to return. The default is 100.
+	Limit *wrappers.Int32Value `protobuf:"bytes,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Only return entries older than this entry. Use the next field in the
+	// response to get the next page.
+	Before               *wrappers.StringValue `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *OutputRequest) Reset()         { *m = OutputRequest{} }
-func (m *OutputRequest) String() string { return proto.CompactTextString(m) }
-func (*OutputRequest) ProtoMessage()    {}
-func (*OutputRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+func (m *ListDeviceFirmwareHistoryRequest) Reset()         { *m = ListDeviceFirmwareHistoryRequest{} }
+func (m *ListDeviceFirmwareHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeviceFirmwareHistoryRequest) ProtoMessage()    {}
+func (*ListDeviceFirmwareHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{54}
 }
 
-func (m *OutputRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OutputRequest.Unmarshal(m, b)
+func (m *ListDeviceFirmwareHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeviceFirmwareHistoryRequest.Unmarshal(m, b)
 }
-func (m *OutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OutputRequest.Marshal(b, m, deterministic)
+func (m *ListDeviceFirmwareHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeviceFirmwareHistoryRequest.Marshal(b, m, deterministic)
 }
-func (m *OutputRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OutputRequest.Merge(m, src)
+func (m *ListDeviceFirmwareHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeviceFirmwareHistoryRequest.Merge(m, src)
 }
-func (m *OutputRequest) XXX_Size() int {
-	return xxx_messageInfo_OutputRequest.Size(m)
+func (m *ListDeviceFirmwareHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeviceFirmwareHistoryRequest.Size(m)
 }
-func (m *OutputRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_OutputRequest.DiscardUnknown(m)
+func (m *ListDeviceFirmwareHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeviceFirmwareHistoryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OutputRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListDeviceFirmwareHistoryRequest proto.InternalMessageInfo
 
-func (m *OutputRequest) GetCollectionId() *wrappers.StringValue {
+func (m *ListDeviceFirmwareHistoryRequest) GetCollectionId() *wrappers.StringValue {
 	if m != nil {
 		return m.CollectionId
 	}
 	return nil
 }
 
-func (m *OutputRequest) GetOutputId() *wrappers.StringValue {
+func (m *ListDeviceFirmwareHistoryRequest) GetDeviceId() *wrappers.StringValue {
 	if m != nil {
-		return m.OutputId
+		return m.DeviceId
 	}
 	return nil
 }
 
-type OutputLogEntry struct {
-	Time                 *wrappers.DoubleValue `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
-	Message              *wrappers.StringValue `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Repeated             *wrappers.Int32Value  `protobuf:"bytes,3,opt,name=repeated,proto3" json:"repeated,omitempty"`
+func (m *ListDeviceFirmwareHistoryRequest) GetLimit() *wrappers.Int32Value {
+	if m != nil {
+		return m.Limit
+	}
+	return nil
+}
+
+func (m *ListDeviceFirmwareHistoryRequest) GetBefore() *wrappers.StringValue {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+type ListFirmwareHistoryRequest struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	ImageId      *wrappers.StringValue `protobuf:"bytes,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	// Maximum number of entries to return. The default is 100.
+	Limit *wrappers.Int32Value `protobuf:"bytes,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Only return entries older than this entry. Use the next field in the
+	// response to get the next page.
+	Before               *wrappers.StringValue `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *OutputLogEntry) Reset()         { *m = OutputLogEntry{} }
-func (m *OutputLogEntry) String() string { return proto.CompactTextString(m) }
-func (*OutputLogEntry) ProtoMessage()    {}
-func (*OutputLogEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+func (m *ListFirmwareHistoryRequest) Reset()         { *m = ListFirmwareHistoryRequest{} }
+func (m *ListFirmwareHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFirmwareHistoryRequest) ProtoMessage()    {}
+func (*ListFirmwareHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{55}
 }
 
-func (m *OutputLogEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OutputLogEntry.Unmarshal(m, b)
+func (m *ListFirmwareHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFirmwareHistoryRequest.Unmarshal(m, b)
 }
-func (m *OutputLogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OutputLogEntry.Marshal(b, m, deterministic)
+func (m *ListFirmwareHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFirmwareHistoryRequest.Marshal(b, m, deterministic)
 }
-func (m *OutputLogEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OutputLogEntry.Merge(m, src)
+func (m *ListFirmwareHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFirmwareHistoryRequest.Merge(m, src)
 }
-func (m *OutputLogEntry) XXX_Size() int {
-	return xxx_messageInfo_OutputLogEntry.Size(m)
+func (m *ListFirmwareHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFirmwareHistoryRequest.Size(m)
 }
-func (m *OutputLogEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_OutputLogEntry.DiscardUnknown(m)
+func (m *ListFirmwareHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFirmwareHistoryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OutputLogEntry proto.InternalMessageInfo
+var xxx_messageInfo_ListFirmwareHistoryRequest proto.InternalMessageInfo
 
-func (m *OutputLogEntry) GetTime() *wrappers.DoubleValue {
+func (m *ListFirmwareHistoryRequest) GetCollectionId() *wrappers.StringValue {
 	if m != nil {
-		return m.Time
+		return m.CollectionId
 	}
 	return nil
 }
 
-func (m *OutputLogEntry) GetMessage() *wrappers.StringValue {
+func (m *ListFirmwareHistoryRequest) GetImageId() *wrappers.StringValue {
 	if m != nil {
-		return m.Message
+		return m.ImageId
 	}
 	return nil
 }
 
-func (m *OutputLogEntry) GetRepeated() *wrappers.Int32Value {
+func (m *ListFirmwareHistoryRequest) GetLimit() *wrappers.Int32Value {
 	if m != nil {
-		return m.Repeated
+		return m.Limit
 	}
 	return nil
 }
 
-type OutputLogs struct {
-	Logs                 []*OutputLogEntry `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *ListFirmwareHistoryRequest) GetBefore() *wrappers.StringValue {
+	if m != nil {
+		return m.Before
+	}
+	return nil
 }
 
-func (m *OutputLogs) Reset()         { *m = OutputLogs{} }
-func (m *OutputLogs) String() string { return proto.CompactTextString(m) }
-func (*OutputLogs) ProtoMessage()    {}
-func (*OutputLogs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
+type ListFirmwareHistoryResponse struct {
+	// The entries, newest first
+	Entries []*FirmwareHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// The value for the before field for the next page. This isn't set if
+	// there are no more entries.
+	Next                 *wrappers.StringValue `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *OutputLogs) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OutputLogs.Unmarshal(m, b)
+func (m *ListFirmwareHistoryResponse) Reset()         { *m = ListFirmwareHistoryResponse{} }
+func (m *ListFirmwareHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFirmwareHistoryResponse) ProtoMessage()    {}
+func (*ListFirmwareHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{56}
 }
-func (m *OutputLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OutputLogs.Marshal(b, m, deterministic)
+
+func (m *ListFirmwareHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFirmwareHistoryResponse.Unmarshal(m, b)
 }
-func (m *OutputLogs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OutputLogs.Merge(m, src)
+func (m *ListFirmwareHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFirmwareHistoryResponse.Marshal(b, m, deterministic)
 }
-func (m *OutputLogs) XXX_Size() int {
-	return xxx_messageInfo_OutputLogs.Size(m)
+func (m *ListFirmwareHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFirmwareHistoryResponse.Merge(m, src)
 }
-func (m *OutputLogs) XXX_DiscardUnknown() {
-	xxx_messageInfo_OutputLogs.DiscardUnknown(m)
+func (m *ListFirmwareHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFirmwareHistoryResponse.Size(m)
+}
+func (m *ListFirmwareHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFirmwareHistoryResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OutputLogs proto.InternalMessageInfo
+var xxx_messageInfo_ListFirmwareHistoryResponse proto.InternalMessageInfo
 
-func (m *OutputLogs) GetLogs() []*OutputLogEntry {
+func (m *ListFirmwareHistoryResponse) GetEntries() []*FirmwareHistoryEntry {
 	if m != nil {
-		return m.Logs
+		return m.Entries
 	}
 	return nil
 }
 
-type OutputStatus struct {
-	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
-	OutputId             *wrappers.StringValue `protobuf:"bytes,2,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
-	Enabled              *wrappers.BoolValue   `protobuf:"bytes,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	ErrorCount           *wrappers.Int32Value  `protobuf:"bytes,4,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
-	Forwarded            *wrappers.Int32Value  `protobuf:"bytes,5,opt,name=forwarded,proto3" json:"forwarded,omitempty"`
-	Received             *wrappers.Int32Value  `protobuf:"bytes,6,opt,name=received,proto3" json:"received,omitempty"`
-	Retransmits          *wrappers.Int32Value  `protobuf:"bytes,7,opt,name=retransmits,proto3" json:"retransmits,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+func (m *ListFirmwareHistoryResponse) GetNext() *wrappers.StringValue {
+	if m != nil {
+		return m.Next
+	}
+	return nil
 }
 
-func (m *OutputStatus) Reset()         { *m = OutputStatus{} }
-func (m *OutputStatus) String() string { return proto.CompactTextString(m) }
-func (*OutputStatus) ProtoMessage()    {}
-func (*OutputStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
+type FirmwareCompatibilityResponse struct {
+	ImageId *wrappers.StringValue `protobuf:"bytes,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	// Devices in the collection that are compatible with the image
+	Compatible []string `protobuf:"bytes,2,rep,name=compatible,proto3" json:"compatible,omitempty"`
+	// Devices in the collection that aren't compatible with the image
+	Incompatible         []*FirmwareCompatibilityResponse_IncompatibleDevice `protobuf:"bytes,3,rep,name=incompatible,proto3" json:"incompatible,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                            `json:"-"`
+	XXX_unrecognized     []byte                                              `json:"-"`
+	XXX_sizecache        int32                                               `json:"-"`
 }
 
-func (m *OutputStatus) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OutputStatus.Unmarshal(m, b)
+func (m *FirmwareCompatibilityResponse) Reset()         { *m = FirmwareCompatibilityResponse{} }
+func (m *FirmwareCompatibilityResponse) String() string { return proto.CompactTextString(m) }
+func (*FirmwareCompatibilityResponse) ProtoMessage()    {}
+func (*FirmwareCompatibilityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{57}
 }
-func (m *OutputStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OutputStatus.Marshal(b, m, deterministic)
+
+func (m *FirmwareCompatibilityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FirmwareCompatibilityResponse.Unmarshal(m, b)
 }
-func (m *OutputStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OutputStatus.Merge(m, src)
+func (m *FirmwareCompatibilityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FirmwareCompatibilityResponse.Marshal(b, m, deterministic)
 }
-func (m *OutputStatus) XXX_Size() int {
-	return xxx_messageInfo_OutputStatus.Size(m)
+func (m *FirmwareCompatibilityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FirmwareCompatibilityResponse.Merge(m, src)
 }
-func (m *OutputStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_OutputStatus.DiscardUnknown(m)
+func (m *FirmwareCompatibilityResponse) XXX_Size() int {
+	return xxx_messageInfo_FirmwareCompatibilityResponse.Size(m)
+}
+func (m *FirmwareCompatibilityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FirmwareCompatibilityResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OutputStatus proto.InternalMessageInfo
+var xxx_messageInfo_FirmwareCompatibilityResponse proto.InternalMessageInfo
 
-func (m *OutputStatus) GetCollectionId() *wrappers.StringValue {
+func (m *FirmwareCompatibilityResponse) GetImageId() *wrappers.StringValue {
 	if m != nil {
-		return m.CollectionId
+		return m.ImageId
 	}
 	return nil
 }
 
-func (m *OutputStatus) GetOutputId() *wrappers.StringValue {
+func (m *FirmwareCompatibilityResponse) GetCompatible() []string {
 	if m != nil {
-		return m.OutputId
+		return m.Compatible
 	}
 	return nil
 }
 
-func (m *OutputStatus) GetEnabled() *wrappers.BoolValue {
+func (m *FirmwareCompatibilityResponse) GetIncompatible() []*FirmwareCompatibilityResponse_IncompatibleDevice {
 	if m != nil {
-		return m.Enabled
+		return m.Incompatible
 	}
 	return nil
 }
 
-func (m *OutputStatus) GetErrorCount() *wrappers.Int32Value {
-	if m != nil {
-		return m.ErrorCount
-	}
-	return nil
+type FirmwareCompatibilityResponse_IncompatibleDevice struct {
+	DeviceId             string   `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OutputStatus) GetForwarded() *wrappers.Int32Value {
-	if m != nil {
-		return m.Forwarded
-	}
-	return nil
+func (m *FirmwareCompatibilityResponse_IncompatibleDevice) Reset() {
+	*m = FirmwareCompatibilityResponse_IncompatibleDevice{}
+}
+func (m *FirmwareCompatibilityResponse_IncompatibleDevice) String() string {
+	return proto.CompactTextString(m)
+}
+func (*FirmwareCompatibilityResponse_IncompatibleDevice) ProtoMessage() {}
+func (*FirmwareCompatibilityResponse_IncompatibleDevice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{57, 0}
 }
 
-func (m *OutputStatus) GetReceived() *wrappers.Int32Value {
+func (m *FirmwareCompatibilityResponse_IncompatibleDevice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FirmwareCompatibilityResponse_IncompatibleDevice.Unmarshal(m, b)
+}
+func (m *FirmwareCompatibilityResponse_IncompatibleDevice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FirmwareCompatibilityResponse_IncompatibleDevice.Marshal(b, m, deterministic)
+}
+func (m *FirmwareCompatibilityResponse_IncompatibleDevice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FirmwareCompatibilityResponse_IncompatibleDevice.Merge(m, src)
+}
+func (m *FirmwareCompatibilityResponse_IncompatibleDevice) XXX_Size() int {
+	return xxx_messageInfo_FirmwareCompatibilityResponse_IncompatibleDevice.Size(m)
+}
+func (m *FirmwareCompatibilityResponse_IncompatibleDevice) XXX_DiscardUnknown() {
+	xxx_messageInfo_FirmwareCompatibilityResponse_IncompatibleDevice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FirmwareCompatibilityResponse_IncompatibleDevice proto.InternalMessageInfo
+
+func (m *FirmwareCompatibilityResponse_IncompatibleDevice) GetDeviceId() string {
 	if m != nil {
-		return m.Received
+		return m.DeviceId
 	}
-	return nil
+	return ""
 }
 
-func (m *OutputStatus) GetRetransmits() *wrappers.Int32Value {
+func (m *FirmwareCompatibilityResponse_IncompatibleDevice) GetReason() string {
 	if m != nil {
-		return m.Retransmits
+		return m.Reason
 	}
-	return nil
+	return ""
 }
 
-// Field mask settings
-type FieldMask struct {
-	Imsi                 *wrappers.BoolValue `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+type CreateFirmwareRequest struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Image        []byte                `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Version      *wrappers.StringValue `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Filename     *wrappers.StringValue `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"`
+	Tags         map[string]string     `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Detached signature of the image's SHA-256 checksum. If the signature is
+	// empty the image is signed with the signing key when the service holds
+	// the private key.
+	Signature []byte `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	// The signing key to verify or sign the image with.
+	SigningKeyId *wrappers.StringValue `protobuf:"bytes,7,opt,name=signing_key_id,json=signingKeyId,proto3" json:"signing_key_id,omitempty"`
+	// Compatibility rules for the image
+	Compatibility        *FirmwareCompatibility `protobuf:"bytes,8,opt,name=compatibility,proto3" json:"compatibility,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *CreateFirmwareRequest) Reset()         { *m = CreateFirmwareRequest{} }
+func (m *CreateFirmwareRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateFirmwareRequest) ProtoMessage()    {}
+func (*CreateFirmwareRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{58}
+}
+
+func (m *CreateFirmwareRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFirmwareRequest.Unmarshal(m, b)
+}
+func (m *CreateFirmwareRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFirmwareRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateFirmwareRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFirmwareRequest.Merge(m, src)
+}
+func (m *CreateFirmwareRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateFirmwareRequest.Size(m)
+}
+func (m *CreateFirmwareRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFirmwareRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFirmwareRequest proto.InternalMessageInfo
+
+func (m *CreateFirmwareRequest) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *CreateFirmwareRequest) GetImage() []byte {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+func (m *CreateFirmwareRequest) GetVersion() *wrappers.StringValue {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *CreateFirmwareRequest) GetFilename() *wrappers.StringValue {
+	if m != nil {
+		return m.Filename
+	}
+	return nil
+}
+
+func (m *CreateFirmwareRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *CreateFirmwareRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *CreateFirmwareRequest) GetSigningKeyId() *wrappers.StringValue {
+	if m != nil {
+		return m.SigningKeyId
+	}
+	return nil
+}
+
+func (m *CreateFirmwareRequest) GetCompatibility() *FirmwareCompatibility {
+	if m != nil {
+		return m.Compatibility
+	}
+	return nil
+}
+
+// SigningKey is a key used to sign firmware images in a collection. Keys
+// are either generated by the service or registered with a public key.
+// Images are signed by the service when it holds the private key.
+type SigningKey struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	KeyId        *wrappers.StringValue `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// The signature algorithm, either "ed25519" or "ecdsa-p256"
+	Algorithm *wrappers.StringValue `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// The DER encoded (PKIX) public key. A new key pair is generated if this
+	// is empty when the key is created.
+	PublicKey []byte `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Set if the private key is held by the service.
+	CanSign              *wrappers.BoolValue  `protobuf:"bytes,5,opt,name=can_sign,json=canSign,proto3" json:"can_sign,omitempty"`
+	Created              *wrappers.Int64Value `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SigningKey) Reset()         { *m = SigningKey{} }
+func (m *SigningKey) String() string { return proto.CompactTextString(m) }
+func (*SigningKey) ProtoMessage()    {}
+func (*SigningKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{59}
+}
+
+func (m *SigningKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SigningKey.Unmarshal(m, b)
+}
+func (m *SigningKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SigningKey.Marshal(b, m, deterministic)
+}
+func (m *SigningKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SigningKey.Merge(m, src)
+}
+func (m *SigningKey) XXX_Size() int {
+	return xxx_messageInfo_SigningKey.Size(m)
+}
+func (m *SigningKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_SigningKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SigningKey proto.InternalMessageInfo
+
+func (m *SigningKey) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *SigningKey) GetKeyId() *wrappers.StringValue {
+	if m != nil {
+		return m.KeyId
+	}
+	return nil
+}
+
+func (m *SigningKey) GetAlgorithm() *wrappers.StringValue {
+	if m != nil {
+		return m.Algorithm
+	}
+	return nil
+}
+
+func (m *SigningKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *SigningKey) GetCanSign() *wrappers.BoolValue {
+	if m != nil {
+		return m.CanSign
+	}
+	return nil
+}
+
+func (m *SigningKey) GetCreated() *wrappers.Int64Value {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+type SigningKeyRequest struct {
+	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	KeyId                *wrappers.StringValue `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *SigningKeyRequest) Reset()         { *m = SigningKeyRequest{} }
+func (m *SigningKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*SigningKeyRequest) ProtoMessage()    {}
+func (*SigningKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{60}
+}
+
+func (m *SigningKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SigningKeyRequest.Unmarshal(m, b)
+}
+func (m *SigningKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SigningKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *SigningKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SigningKeyRequest.Merge(m, src)
+}
+func (m *SigningKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_SigningKeyRequest.Size(m)
+}
+func (m *SigningKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SigningKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SigningKeyRequest proto.InternalMessageInfo
+
+func (m *SigningKeyRequest) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *SigningKeyRequest) GetKeyId() *wrappers.StringValue {
+	if m != nil {
+		return m.KeyId
+	}
+	return nil
+}
+
+type ListSigningKeysRequest struct {
+	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ListSigningKeysRequest) Reset()         { *m = ListSigningKeysRequest{} }
+func (m *ListSigningKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSigningKeysRequest) ProtoMessage()    {}
+func (*ListSigningKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{61}
+}
+
+func (m *ListSigningKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSigningKeysRequest.Unmarshal(m, b)
+}
+func (m *ListSigningKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSigningKeysRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSigningKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSigningKeysRequest.Merge(m, src)
+}
+func (m *ListSigningKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSigningKeysRequest.Size(m)
+}
+func (m *ListSigningKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSigningKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSigningKeysRequest proto.InternalMessageInfo
+
+func (m *ListSigningKeysRequest) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+type ListSigningKeysResponse struct {
+	Keys                 []*SigningKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListSigningKeysResponse) Reset()         { *m = ListSigningKeysResponse{} }
+func (m *ListSigningKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSigningKeysResponse) ProtoMessage()    {}
+func (*ListSigningKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{62}
+}
+
+func (m *ListSigningKeysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSigningKeysResponse.Unmarshal(m, b)
+}
+func (m *ListSigningKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSigningKeysResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSigningKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSigningKeysResponse.Merge(m, src)
+}
+func (m *ListSigningKeysResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSigningKeysResponse.Size(m)
+}
+func (m *ListSigningKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSigningKeysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSigningKeysResponse proto.InternalMessageInfo
+
+func (m *ListSigningKeysResponse) GetKeys() []*SigningKey {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type ListOutputResponse struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Outputs      []*Output             `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// Token for the next page. It is empty on the last page.
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOutputResponse) Reset()         { *m = ListOutputResponse{} }
+func (m *ListOutputResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOutputResponse) ProtoMessage()    {}
+func (*ListOutputResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{63}
+}
+
+func (m *ListOutputResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOutputResponse.Unmarshal(m, b)
+}
+func (m *ListOutputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOutputResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOutputResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOutputResponse.Merge(m, src)
+}
+func (m *ListOutputResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOutputResponse.Size(m)
+}
+func (m *ListOutputResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOutputResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOutputResponse proto.InternalMessageInfo
+
+func (m *ListOutputResponse) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *ListOutputResponse) GetOutputs() []*Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *ListOutputResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ListOutputRequest struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Maximum number of elements to return. All elements are returned if it
+	// isn't set. The maximum page size is 1000.
+	PageSize *wrappers.Int32Value `protobuf:"bytes,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Page token from the previous response
+	PageToken *wrappers.StringValue `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Tag filter expression, f.e. "site=oslo AND type!=gateway"
+	TagFilter            *wrappers.StringValue `protobuf:"bytes,4,opt,name=tag_filter,json=tagFilter,proto3" json:"tag_filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ListOutputRequest) Reset()         { *m = ListOutputRequest{} }
+func (m *ListOutputRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOutputRequest) ProtoMessage()    {}
+func (*ListOutputRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{64}
+}
+
+func (m *ListOutputRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOutputRequest.Unmarshal(m, b)
+}
+func (m *ListOutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOutputRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOutputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOutputRequest.Merge(m, src)
+}
+func (m *ListOutputRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOutputRequest.Size(m)
+}
+func (m *ListOutputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOutputRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOutputRequest proto.InternalMessageInfo
+
+func (m *ListOutputRequest) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *ListOutputRequest) GetPageSize() *wrappers.Int32Value {
+	if m != nil {
+		return m.PageSize
+	}
+	return nil
+}
+
+func (m *ListOutputRequest) GetPageToken() *wrappers.StringValue {
+	if m != nil {
+		return m.PageToken
+	}
+	return nil
+}
+
+func (m *ListOutputRequest) GetTagFilter() *wrappers.StringValue {
+	if m != nil {
+		return m.TagFilter
+	}
+	return nil
+}
+
+type OutputRequest struct {
+	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	OutputId             *wrappers.StringValue `protobuf:"bytes,2,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *OutputRequest) Reset()         { *m = OutputRequest{} }
+func (m *OutputRequest) String() string { return proto.CompactTextString(m) }
+func (*OutputRequest) ProtoMessage()    {}
+func (*OutputRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{65}
+}
+
+func (m *OutputRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputRequest.Unmarshal(m, b)
+}
+func (m *OutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputRequest.Marshal(b, m, deterministic)
+}
+func (m *OutputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputRequest.Merge(m, src)
+}
+func (m *OutputRequest) XXX_Size() int {
+	return xxx_messageInfo_OutputRequest.Size(m)
+}
+func (m *OutputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputRequest proto.InternalMessageInfo
+
+func (m *OutputRequest) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *OutputRequest) GetOutputId() *wrappers.StringValue {
+	if m != nil {
+		return m.OutputId
+	}
+	return nil
+}
+
+type OutputLogEntry struct {
+	Time                 *wrappers.DoubleValue `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Message              *wrappers.StringValue `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Repeated             *wrappers.Int32Value  `protobuf:"bytes,3,opt,name=repeated,proto3" json:"repeated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *OutputLogEntry) Reset()         { *m = OutputLogEntry{} }
+func (m *OutputLogEntry) String() string { return proto.CompactTextString(m) }
+func (*OutputLogEntry) ProtoMessage()    {}
+func (*OutputLogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{66}
+}
+
+func (m *OutputLogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputLogEntry.Unmarshal(m, b)
+}
+func (m *OutputLogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputLogEntry.Marshal(b, m, deterministic)
+}
+func (m *OutputLogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputLogEntry.Merge(m, src)
+}
+func (m *OutputLogEntry) XXX_Size() int {
+	return xxx_messageInfo_OutputLogEntry.Size(m)
+}
+func (m *OutputLogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputLogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputLogEntry proto.InternalMessageInfo
+
+func (m *OutputLogEntry) GetTime() *wrappers.DoubleValue {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *OutputLogEntry) GetMessage() *wrappers.StringValue {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *OutputLogEntry) GetRepeated() *wrappers.Int32Value {
+	if m != nil {
+		return m.Repeated
+	}
+	return nil
+}
+
+type OutputLogs struct {
+	Logs                 []*OutputLogEntry `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *OutputLogs) Reset()         { *m = OutputLogs{} }
+func (m *OutputLogs) String() string { return proto.CompactTextString(m) }
+func (*OutputLogs) ProtoMessage()    {}
+func (*OutputLogs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{67}
+}
+
+func (m *OutputLogs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputLogs.Unmarshal(m, b)
+}
+func (m *OutputLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputLogs.Marshal(b, m, deterministic)
+}
+func (m *OutputLogs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputLogs.Merge(m, src)
+}
+func (m *OutputLogs) XXX_Size() int {
+	return xxx_messageInfo_OutputLogs.Size(m)
+}
+func (m *OutputLogs) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputLogs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputLogs proto.InternalMessageInfo
+
+func (m *OutputLogs) GetLogs() []*OutputLogEntry {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type OutputStatus struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	OutputId     *wrappers.StringValue `protobuf:"bytes,2,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	Enabled      *wrappers.BoolValue   `protobuf:"bytes,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	ErrorCount   *wrappers.Int32Value  `protobuf:"bytes,4,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	Forwarded    *wrappers.Int32Value  `protobuf:"bytes,5,opt,name=forwarded,proto3" json:"forwarded,omitempty"`
+	Received     *wrappers.Int32Value  `protobuf:"bytes,6,opt,name=received,proto3" json:"received,omitempty"`
+	Retransmits  *wrappers.Int32Value  `protobuf:"bytes,7,opt,name=retransmits,proto3" json:"retransmits,omitempty"`
+	// Number of times a request has been held back by the rate or concurrency
+	// limits
+	Throttled *wrappers.Int32Value `protobuf:"bytes,8,opt,name=throttled,proto3" json:"throttled,omitempty"`
+	// Number of messages waiting to be sent
+	Queued *wrappers.Int32Value `protobuf:"bytes,9,opt,name=queued,proto3" json:"queued,omitempty"`
+	// Number of messages dropped because the queue was full
+	Dropped              *wrappers.Int32Value `protobuf:"bytes,10,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *OutputStatus) Reset()         { *m = OutputStatus{} }
+func (m *OutputStatus) String() string { return proto.CompactTextString(m) }
+func (*OutputStatus) ProtoMessage()    {}
+func (*OutputStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{68}
+}
+
+func (m *OutputStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputStatus.Unmarshal(m, b)
+}
+func (m *OutputStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputStatus.Marshal(b, m, deterministic)
+}
+func (m *OutputStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputStatus.Merge(m, src)
+}
+func (m *OutputStatus) XXX_Size() int {
+	return xxx_messageInfo_OutputStatus.Size(m)
+}
+func (m *OutputStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputStatus proto.InternalMessageInfo
+
+func (m *OutputStatus) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *OutputStatus) GetOutputId() *wrappers.StringValue {
+	if m != nil {
+		return m.OutputId
+	}
+	return nil
+}
+
+func (m *OutputStatus) GetEnabled() *wrappers.BoolValue {
+	if m != nil {
+		return m.Enabled
+	}
+	return nil
+}
+
+func (m *OutputStatus) GetErrorCount() *wrappers.Int32Value {
+	if m != nil {
+		return m.ErrorCount
+	}
+	return nil
+}
+
+func (m *OutputStatus) GetForwarded() *wrappers.Int32Value {
+	if m != nil {
+		return m.Forwarded
+	}
+	return nil
+}
+
+func (m *OutputStatus) GetReceived() *wrappers.Int32Value {
+	if m != nil {
+		return m.Received
+	}
+	return nil
+}
+
+func (m *OutputStatus) GetRetransmits() *wrappers.Int32Value {
+	if m != nil {
+		return m.Retransmits
+	}
+	return nil
+}
+
+func (m *OutputStatus) GetThrottled() *wrappers.Int32Value {
+	if m != nil {
+		return m.Throttled
+	}
+	return nil
+}
+
+func (m *OutputStatus) GetQueued() *wrappers.Int32Value {
+	if m != nil {
+		return m.Queued
+	}
+	return nil
+}
+
+func (m *OutputStatus) GetDropped() *wrappers.Int32Value {
+	if m != nil {
+		return m.Dropped
+	}
+	return nil
+}
+
+// Test delivery through an output. Either the output ID of an existing output
+// or an output configuration must be set. The message is a synthetic message
+// unless a device ID or a message time is set. If a device ID or message
+// time is set the most recent matching message from the data store is used.
+type OutputTestRequest struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// The existing output to test
+	OutputId *wrappers.StringValue `protobuf:"bytes,2,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	// Unsaved output configuration to test
+	Output *Output `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	// Use the most recent message from this device
+	DeviceId *wrappers.StringValue `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Use the message stored at this time (in milliseconds since epoch)
+	MessageTime *wrappers.Int64Value `protobuf:"bytes,5,opt,name=message_time,json=messageTime,proto3" json:"message_time,omitempty"`
+	// Payload for the synthetic message
+	Payload              *wrappers.BytesValue `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *OutputTestRequest) Reset()         { *m = OutputTestRequest{} }
+func (m *OutputTestRequest) String() string { return proto.CompactTextString(m) }
+func (*OutputTestRequest) ProtoMessage()    {}
+func (*OutputTestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{69}
+}
+
+func (m *OutputTestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputTestRequest.Unmarshal(m, b)
+}
+func (m *OutputTestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputTestRequest.Marshal(b, m, deterministic)
+}
+func (m *OutputTestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputTestRequest.Merge(m, src)
+}
+func (m *OutputTestRequest) XXX_Size() int {
+	return xxx_messageInfo_OutputTestRequest.Size(m)
+}
+func (m *OutputTestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputTestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputTestRequest proto.InternalMessageInfo
+
+func (m *OutputTestRequest) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *OutputTestRequest) GetOutputId() *wrappers.StringValue {
+	if m != nil {
+		return m.OutputId
+	}
+	return nil
+}
+
+func (m *OutputTestRequest) GetOutput() *Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *OutputTestRequest) GetDeviceId() *wrappers.StringValue {
+	if m != nil {
+		return m.DeviceId
+	}
+	return nil
+}
+
+func (m *OutputTestRequest) GetMessageTime() *wrappers.Int64Value {
+	if m != nil {
+		return m.MessageTime
+	}
+	return nil
+}
+
+func (m *OutputTestRequest) GetPayload() *wrappers.BytesValue {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type OutputTLSTrace struct {
+	HandshakeComplete    *wrappers.BoolValue   `protobuf:"bytes,1,opt,name=handshake_complete,json=handshakeComplete,proto3" json:"handshake_complete,omitempty"`
+	Version              *wrappers.StringValue `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	CipherSuite          *wrappers.StringValue `protobuf:"bytes,3,opt,name=cipher_suite,json=cipherSuite,proto3" json:"cipher_suite,omitempty"`
+	ServerName           *wrappers.StringValue `protobuf:"bytes,4,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	Error                *wrappers.StringValue `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *OutputTLSTrace) Reset()         { *m = OutputTLSTrace{} }
+func (m *OutputTLSTrace) String() string { return proto.CompactTextString(m) }
+func (*OutputTLSTrace) ProtoMessage()    {}
+func (*OutputTLSTrace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{70}
+}
+
+func (m *OutputTLSTrace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputTLSTrace.Unmarshal(m, b)
+}
+func (m *OutputTLSTrace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputTLSTrace.Marshal(b, m, deterministic)
+}
+func (m *OutputTLSTrace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputTLSTrace.Merge(m, src)
+}
+func (m *OutputTLSTrace) XXX_Size() int {
+	return xxx_messageInfo_OutputTLSTrace.Size(m)
+}
+func (m *OutputTLSTrace) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputTLSTrace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputTLSTrace proto.InternalMessageInfo
+
+func (m *OutputTLSTrace) GetHandshakeComplete() *wrappers.BoolValue {
+	if m != nil {
+		return m.HandshakeComplete
+	}
+	return nil
+}
+
+func (m *OutputTLSTrace) GetVersion() *wrappers.StringValue {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *OutputTLSTrace) GetCipherSuite() *wrappers.StringValue {
+	if m != nil {
+		return m.CipherSuite
+	}
+	return nil
+}
+
+func (m *OutputTLSTrace) GetServerName() *wrappers.StringValue {
+	if m != nil {
+		return m.ServerName
+	}
+	return nil
+}
+
+func (m *OutputTLSTrace) GetError() *wrappers.StringValue {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+// Timing for the test delivery. All values are in milliseconds from the start
+// of the test.
+type OutputTestTiming struct {
+	DnsLookup            *wrappers.DoubleValue `protobuf:"bytes,1,opt,name=dns_lookup,json=dnsLookup,proto3" json:"dns_lookup,omitempty"`
+	Connect              *wrappers.DoubleValue `protobuf:"bytes,2,opt,name=connect,proto3" json:"connect,omitempty"`
+	TlsHandshake         *wrappers.DoubleValue `protobuf:"bytes,3,opt,name=tls_handshake,json=tlsHandshake,proto3" json:"tls_handshake,omitempty"`
+	FirstByte            *wrappers.DoubleValue `protobuf:"bytes,4,opt,name=first_byte,json=firstByte,proto3" json:"first_byte,omitempty"`
+	Total                *wrappers.DoubleValue `protobuf:"bytes,5,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *OutputTestTiming) Reset()         { *m = OutputTestTiming{} }
+func (m *OutputTestTiming) String() string { return proto.CompactTextString(m) }
+func (*OutputTestTiming) ProtoMessage()    {}
+func (*OutputTestTiming) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{71}
+}
+
+func (m *OutputTestTiming) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputTestTiming.Unmarshal(m, b)
+}
+func (m *OutputTestTiming) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputTestTiming.Marshal(b, m, deterministic)
+}
+func (m *OutputTestTiming) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputTestTiming.Merge(m, src)
+}
+func (m *OutputTestTiming) XXX_Size() int {
+	return xxx_messageInfo_OutputTestTiming.Size(m)
+}
+func (m *OutputTestTiming) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputTestTiming.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputTestTiming proto.InternalMessageInfo
+
+func (m *OutputTestTiming) GetDnsLookup() *wrappers.DoubleValue {
+	if m != nil {
+		return m.DnsLookup
+	}
+	return nil
+}
+
+func (m *OutputTestTiming) GetConnect() *wrappers.DoubleValue {
+	if m != nil {
+		return m.Connect
+	}
+	return nil
+}
+
+func (m *OutputTestTiming) GetTlsHandshake() *wrappers.DoubleValue {
+	if m != nil {
+		return m.TlsHandshake
+	}
+	return nil
+}
+
+func (m *OutputTestTiming) GetFirstByte() *wrappers.DoubleValue {
+	if m != nil {
+		return m.FirstByte
+	}
+	return nil
+}
+
+func (m *OutputTestTiming) GetTotal() *wrappers.DoubleValue {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+type OutputTestResponse struct {
+	Success *wrappers.BoolValue   `protobuf:"bytes,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message *wrappers.StringValue `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The resolved remote address
+	RemoteAddress  *wrappers.StringValue `protobuf:"bytes,3,opt,name=remote_address,json=remoteAddress,proto3" json:"remote_address,omitempty"`
+	Tls            *OutputTLSTrace       `protobuf:"bytes,4,opt,name=tls,proto3" json:"tls,omitempty"`
+	HttpStatusCode *wrappers.Int32Value  `protobuf:"bytes,5,opt,name=http_status_code,json=httpStatusCode,proto3" json:"http_status_code,omitempty"`
+	// The first part of the response body
+	HttpResponseBody *wrappers.StringValue `protobuf:"bytes,6,opt,name=http_response_body,json=httpResponseBody,proto3" json:"http_response_body,omitempty"`
+	// The CONNACK return code from the MQTT broker
+	MqttReturnCode *wrappers.Int32Value `protobuf:"bytes,7,opt,name=mqtt_return_code,json=mqttReturnCode,proto3" json:"mqtt_return_code,omitempty"`
+	Timing         *OutputTestTiming    `protobuf:"bytes,8,opt,name=timing,proto3" json:"timing,omitempty"`
+	// The message sent through the output
+	Data                 *OutputDataMessage `protobuf:"bytes,9,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *OutputTestResponse) Reset()         { *m = OutputTestResponse{} }
+func (m *OutputTestResponse) String() string { return proto.CompactTextString(m) }
+func (*OutputTestResponse) ProtoMessage()    {}
+func (*OutputTestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{72}
+}
+
+func (m *OutputTestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputTestResponse.Unmarshal(m, b)
+}
+func (m *OutputTestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputTestResponse.Marshal(b, m, deterministic)
+}
+func (m *OutputTestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputTestResponse.Merge(m, src)
+}
+func (m *OutputTestResponse) XXX_Size() int {
+	return xxx_messageInfo_OutputTestResponse.Size(m)
+}
+func (m *OutputTestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputTestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputTestResponse proto.InternalMessageInfo
+
+func (m *OutputTestResponse) GetSuccess() *wrappers.BoolValue {
+	if m != nil {
+		return m.Success
+	}
+	return nil
+}
+
+func (m *OutputTestResponse) GetMessage() *wrappers.StringValue {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *OutputTestResponse) GetRemoteAddress() *wrappers.StringValue {
+	if m != nil {
+		return m.RemoteAddress
+	}
+	return nil
+}
+
+func (m *OutputTestResponse) GetTls() *OutputTLSTrace {
+	if m != nil {
+		return m.Tls
+	}
+	return nil
+}
+
+func (m *OutputTestResponse) GetHttpStatusCode() *wrappers.Int32Value {
+	if m != nil {
+		return m.HttpStatusCode
+	}
+	return nil
+}
+
+func (m *OutputTestResponse) GetHttpResponseBody() *wrappers.StringValue {
+	if m != nil {
+		return m.HttpResponseBody
+	}
+	return nil
+}
+
+func (m *OutputTestResponse) GetMqttReturnCode() *wrappers.Int32Value {
+	if m != nil {
+		return m.MqttReturnCode
+	}
+	return nil
+}
+
+func (m *OutputTestResponse) GetTiming() *OutputTestTiming {
+	if m != nil {
+		return m.Timing
+	}
+	return nil
+}
+
+func (m *OutputTestResponse) GetData() *OutputDataMessage {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// Field mask settings
+type FieldMask struct {
+	Imsi                 *wrappers.BoolValue `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
 	Imei                 *wrappers.BoolValue `protobuf:"bytes,2,opt,name=imei,proto3" json:"imei,omitempty"`
 	Msisdn               *wrappers.BoolValue `protobuf:"bytes,3,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
 	Location             *wrappers.BoolValue `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
@@ -3151,503 +5249,1488 @@ type FieldMask struct {
 	XXX_sizecache        int32               `json:"-"`
 }
 
-func (m *FieldMask) Reset()         { *m = FieldMask{} }
-func (m *FieldMask) String() string { return proto.CompactTextString(m) }
-func (*FieldMask) ProtoMessage()    {}
-func (*FieldMask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
+func (m *FieldMask) Reset()         { *m = FieldMask{} }
+func (m *FieldMask) String() string { return proto.CompactTextString(m) }
+func (*FieldMask) ProtoMessage()    {}
+func (*FieldMask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{73}
+}
+
+func (m *FieldMask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldMask.Unmarshal(m, b)
+}
+func (m *FieldMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldMask.Marshal(b, m, deterministic)
+}
+func (m *FieldMask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldMask.Merge(m, src)
+}
+func (m *FieldMask) XXX_Size() int {
+	return xxx_messageInfo_FieldMask.Size(m)
+}
+func (m *FieldMask) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldMask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldMask proto.InternalMessageInfo
+
+func (m *FieldMask) GetImsi() *wrappers.BoolValue {
+	if m != nil {
+		return m.Imsi
+	}
+	return nil
+}
+
+func (m *FieldMask) GetImei() *wrappers.BoolValue {
+	if m != nil {
+		return m.Imei
+	}
+	return nil
+}
+
+func (m *FieldMask) GetMsisdn() *wrappers.BoolValue {
+	if m != nil {
+		return m.Msisdn
+	}
+	return nil
+}
+
+func (m *FieldMask) GetLocation() *wrappers.BoolValue {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+type SystemInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SystemInfoRequest) Reset()         { *m = SystemInfoRequest{} }
+func (m *SystemInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*SystemInfoRequest) ProtoMessage()    {}
+func (*SystemInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{74}
+}
+
+func (m *SystemInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SystemInfoRequest.Unmarshal(m, b)
+}
+func (m *SystemInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SystemInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *SystemInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SystemInfoRequest.Merge(m, src)
+}
+func (m *SystemInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_SystemInfoRequest.Size(m)
+}
+func (m *SystemInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SystemInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SystemInfoRequest proto.InternalMessageInfo
+
+// System information structure
+type SystemInfoResponse struct {
+	Version              *wrappers.StringValue `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	BuildDate            *wrappers.StringValue `protobuf:"bytes,2,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
+	ReleaseName          *wrappers.StringValue `protobuf:"bytes,3,opt,name=release_name,json=releaseName,proto3" json:"release_name,omitempty"`
+	DefaultFieldMask     *FieldMask            `protobuf:"bytes,4,opt,name=default_field_mask,json=defaultFieldMask,proto3" json:"default_field_mask,omitempty"`
+	ForcedFieldMask      *FieldMask            `protobuf:"bytes,5,opt,name=forced_field_mask,json=forcedFieldMask,proto3" json:"forced_field_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *SystemInfoResponse) Reset()         { *m = SystemInfoResponse{} }
+func (m *SystemInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*SystemInfoResponse) ProtoMessage()    {}
+func (*SystemInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{75}
+}
+
+func (m *SystemInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SystemInfoResponse.Unmarshal(m, b)
+}
+func (m *SystemInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SystemInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *SystemInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SystemInfoResponse.Merge(m, src)
+}
+func (m *SystemInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_SystemInfoResponse.Size(m)
+}
+func (m *SystemInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SystemInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SystemInfoResponse proto.InternalMessageInfo
+
+func (m *SystemInfoResponse) GetVersion() *wrappers.StringValue {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *SystemInfoResponse) GetBuildDate() *wrappers.StringValue {
+	if m != nil {
+		return m.BuildDate
+	}
+	return nil
+}
+
+func (m *SystemInfoResponse) GetReleaseName() *wrappers.StringValue {
+	if m != nil {
+		return m.ReleaseName
+	}
+	return nil
+}
+
+func (m *SystemInfoResponse) GetDefaultFieldMask() *FieldMask {
+	if m != nil {
+		return m.DefaultFieldMask
+	}
+	return nil
+}
+
+func (m *SystemInfoResponse) GetForcedFieldMask() *FieldMask {
+	if m != nil {
+		return m.ForcedFieldMask
+	}
+	return nil
+}
+
+// The collection dump
+type DumpedCollection struct {
+	Collection *Collection     `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Devices    []*DumpedDevice `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	Outputs    []*Output       `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// The firmware images in the collection. Only the metadata is included, not
+	// the images themselves.
+	Firmware             []*Firmware `protobuf:"bytes,4,rep,name=firmware,proto3" json:"firmware,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DumpedCollection) Reset()         { *m = DumpedCollection{} }
+func (m *DumpedCollection) String() string { return proto.CompactTextString(m) }
+func (*DumpedCollection) ProtoMessage()    {}
+func (*DumpedCollection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{76}
+}
+
+func (m *DumpedCollection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpedCollection.Unmarshal(m, b)
+}
+func (m *DumpedCollection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpedCollection.Marshal(b, m, deterministic)
+}
+func (m *DumpedCollection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpedCollection.Merge(m, src)
+}
+func (m *DumpedCollection) XXX_Size() int {
+	return xxx_messageInfo_DumpedCollection.Size(m)
+}
+func (m *DumpedCollection) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpedCollection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpedCollection proto.InternalMessageInfo
+
+func (m *DumpedCollection) GetCollection() *Collection {
+	if m != nil {
+		return m.Collection
+	}
+	return nil
+}
+
+func (m *DumpedCollection) GetDevices() []*DumpedDevice {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *DumpedCollection) GetOutputs() []*Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *DumpedCollection) GetFirmware() []*Firmware {
+	if m != nil {
+		return m.Firmware
+	}
+	return nil
+}
+
+// The device dump
+type DumpedDevice struct {
+	// The device itself
+	Device *Device `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	// The data for the device
+	Data                 []*OutputDataMessage `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DumpedDevice) Reset()         { *m = DumpedDevice{} }
+func (m *DumpedDevice) String() string { return proto.CompactTextString(m) }
+func (*DumpedDevice) ProtoMessage()    {}
+func (*DumpedDevice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{77}
+}
+
+func (m *DumpedDevice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpedDevice.Unmarshal(m, b)
+}
+func (m *DumpedDevice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpedDevice.Marshal(b, m, deterministic)
+}
+func (m *DumpedDevice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpedDevice.Merge(m, src)
+}
+func (m *DumpedDevice) XXX_Size() int {
+	return xxx_messageInfo_DumpedDevice.Size(m)
+}
+func (m *DumpedDevice) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpedDevice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpedDevice proto.InternalMessageInfo
+
+func (m *DumpedDevice) GetDevice() *Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+func (m *DumpedDevice) GetData() []*OutputDataMessage {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type DataDumpRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataDumpRequest) Reset()         { *m = DataDumpRequest{} }
+func (m *DataDumpRequest) String() string { return proto.CompactTextString(m) }
+func (*DataDumpRequest) ProtoMessage()    {}
+func (*DataDumpRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{78}
+}
+
+func (m *DataDumpRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataDumpRequest.Unmarshal(m, b)
+}
+func (m *DataDumpRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataDumpRequest.Marshal(b, m, deterministic)
+}
+func (m *DataDumpRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataDumpRequest.Merge(m, src)
+}
+func (m *DataDumpRequest) XXX_Size() int {
+	return xxx_messageInfo_DataDumpRequest.Size(m)
+}
+func (m *DataDumpRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataDumpRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataDumpRequest proto.InternalMessageInfo
+
+// This is the documentation for the data dump response
+type DataDumpResponse struct {
+	// This is the array of collections
+	Collections []*DumpedCollection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	// This is your user profile
+	Profile *UserProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	// This is your teams
+	Teams []*Team `protobuf:"bytes,3,rep,name=teams,proto3" json:"teams,omitempty"`
+	// This is your tokens
+	Tokens               []*Token `protobuf:"bytes,4,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataDumpResponse) Reset()         { *m = DataDumpResponse{} }
+func (m *DataDumpResponse) String() string { return proto.CompactTextString(m) }
+func (*DataDumpResponse) ProtoMessage()    {}
+func (*DataDumpResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{79}
+}
+
+func (m *DataDumpResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataDumpResponse.Unmarshal(m, b)
+}
+func (m *DataDumpResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataDumpResponse.Marshal(b, m, deterministic)
+}
+func (m *DataDumpResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataDumpResponse.Merge(m, src)
+}
+func (m *DataDumpResponse) XXX_Size() int {
+	return xxx_messageInfo_DataDumpResponse.Size(m)
+}
+func (m *DataDumpResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataDumpResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataDumpResponse proto.InternalMessageInfo
+
+func (m *DataDumpResponse) GetCollections() []*DumpedCollection {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *DataDumpResponse) GetProfile() *UserProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+func (m *DataDumpResponse) GetTeams() []*Team {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+func (m *DataDumpResponse) GetTokens() []*Token {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+// Request for a streamed data dump
+type StreamDataDumpRequest struct {
+	// Include the device data. The default is to include the data.
+	IncludeData *wrappers.BoolValue `protobuf:"bytes,1,opt,name=include_data,json=includeData,proto3" json:"include_data,omitempty"`
+	// Start time for the device data (in milliseconds since epoch)
+	Since *wrappers.Int64Value `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// End time for the device data (in milliseconds since epoch)
+	Until *wrappers.Int64Value `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	// The maximum number of messages in each message chunk. The default is 100
+	// and the maximum is 1000.
+	PageSize             *wrappers.Int32Value `protobuf:"bytes,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *StreamDataDumpRequest) Reset()         { *m = StreamDataDumpRequest{} }
+func (m *StreamDataDumpRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamDataDumpRequest) ProtoMessage()    {}
+func (*StreamDataDumpRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{80}
+}
+
+func (m *StreamDataDumpRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamDataDumpRequest.Unmarshal(m, b)
+}
+func (m *StreamDataDumpRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamDataDumpRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamDataDumpRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamDataDumpRequest.Merge(m, src)
+}
+func (m *StreamDataDumpRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamDataDumpRequest.Size(m)
+}
+func (m *StreamDataDumpRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamDataDumpRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamDataDumpRequest proto.InternalMessageInfo
+
+func (m *StreamDataDumpRequest) GetIncludeData() *wrappers.BoolValue {
+	if m != nil {
+		return m.IncludeData
+	}
+	return nil
+}
+
+func (m *StreamDataDumpRequest) GetSince() *wrappers.Int64Value {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *StreamDataDumpRequest) GetUntil() *wrappers.Int64Value {
+	if m != nil {
+		return m.Until
+	}
+	return nil
+}
+
+func (m *StreamDataDumpRequest) GetPageSize() *wrappers.Int32Value {
+	if m != nil {
+		return m.PageSize
+	}
+	return nil
+}
+
+// A page of messages for a device in a streamed data dump
+type DumpedMessages struct {
+	CollectionId         *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	DeviceId             *wrappers.StringValue `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Messages             []*OutputDataMessage  `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *DumpedMessages) Reset()         { *m = DumpedMessages{} }
+func (m *DumpedMessages) String() string { return proto.CompactTextString(m) }
+func (*DumpedMessages) ProtoMessage()    {}
+func (*DumpedMessages) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{81}
+}
+
+func (m *DumpedMessages) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpedMessages.Unmarshal(m, b)
+}
+func (m *DumpedMessages) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpedMessages.Marshal(b, m, deterministic)
+}
+func (m *DumpedMessages) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpedMessages.Merge(m, src)
+}
+func (m *DumpedMessages) XXX_Size() int {
+	return xxx_messageInfo_DumpedMessages.Size(m)
+}
+func (m *DumpedMessages) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpedMessages.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpedMessages proto.InternalMessageInfo
+
+func (m *DumpedMessages) GetCollectionId() *wrappers.StringValue {
+	if m != nil {
+		return m.CollectionId
+	}
+	return nil
+}
+
+func (m *DumpedMessages) GetDeviceId() *wrappers.StringValue {
+	if m != nil {
+		return m.DeviceId
+	}
+	return nil
+}
+
+func (m *DumpedMessages) GetMessages() []*OutputDataMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+// The last chunk in a downloaded data dump. The error is set if the dump is
+// incomplete.
+type DataDumpEnd struct {
+	Error                *wrappers.StringValue `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *DataDumpEnd) Reset()         { *m = DataDumpEnd{} }
+func (m *DataDumpEnd) String() string { return proto.CompactTextString(m) }
+func (*DataDumpEnd) ProtoMessage()    {}
+func (*DataDumpEnd) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{82}
+}
+
+func (m *DataDumpEnd) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataDumpEnd.Unmarshal(m, b)
+}
+func (m *DataDumpEnd) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataDumpEnd.Marshal(b, m, deterministic)
+}
+func (m *DataDumpEnd) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataDumpEnd.Merge(m, src)
+}
+func (m *DataDumpEnd) XXX_Size() int {
+	return xxx_messageInfo_DataDumpEnd.Size(m)
+}
+func (m *DataDumpEnd) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataDumpEnd.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataDumpEnd proto.InternalMessageInfo
+
+func (m *DataDumpEnd) GetError() *wrappers.StringValue {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+// A chunk of a streamed data dump. Only one of the fields is set in each
+// chunk. The profile is streamed first, then the teams and tokens and finally
+// the collections. Each collection is followed by its firmware, outputs and
+// devices and each device is followed by its messages. Downloaded dumps
+// (NDJSON and ZIP) end with a chunk that marks the end of the dump.
+type DataDumpChunk struct {
+	Profile              *UserProfile    `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	Team                 *Team           `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`
+	Token                *Token          `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Collection           *Collection     `protobuf:"bytes,4,opt,name=collection,proto3" json:"collection,omitempty"`
+	Firmware             *Firmware       `protobuf:"bytes,5,opt,name=firmware,proto3" json:"firmware,omitempty"`
+	Output               *Output         `protobuf:"bytes,6,opt,name=output,proto3" json:"output,omitempty"`
+	Device               *Device         `protobuf:"bytes,7,opt,name=device,proto3" json:"device,omitempty"`
+	Messages             *DumpedMessages `protobuf:"bytes,8,opt,name=messages,proto3" json:"messages,omitempty"`
+	End                  *DataDumpEnd    `protobuf:"bytes,9,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *DataDumpChunk) Reset()         { *m = DataDumpChunk{} }
+func (m *DataDumpChunk) String() string { return proto.CompactTextString(m) }
+func (*DataDumpChunk) ProtoMessage()    {}
+func (*DataDumpChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{83}
+}
+
+func (m *DataDumpChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataDumpChunk.Unmarshal(m, b)
+}
+func (m *DataDumpChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataDumpChunk.Marshal(b, m, deterministic)
+}
+func (m *DataDumpChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataDumpChunk.Merge(m, src)
+}
+func (m *DataDumpChunk) XXX_Size() int {
+	return xxx_messageInfo_DataDumpChunk.Size(m)
+}
+func (m *DataDumpChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataDumpChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataDumpChunk proto.InternalMessageInfo
+
+func (m *DataDumpChunk) GetProfile() *UserProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+func (m *DataDumpChunk) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+func (m *DataDumpChunk) GetToken() *Token {
+	if m != nil {
+		return m.Token
+	}
+	return nil
+}
+
+func (m *DataDumpChunk) GetCollection() *Collection {
+	if m != nil {
+		return m.Collection
+	}
+	return nil
+}
+
+func (m *DataDumpChunk) GetFirmware() *Firmware {
+	if m != nil {
+		return m.Firmware
+	}
+	return nil
+}
+
+func (m *DataDumpChunk) GetOutput() *Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *DataDumpChunk) GetDevice() *Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+func (m *DataDumpChunk) GetMessages() *DumpedMessages {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *DataDumpChunk) GetEnd() *DataDumpEnd {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+// Restore a data dump into a team
+type DataRestoreRequest struct {
+	// The team that will own the restored collections.
+	TeamId *wrappers.StringValue `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// The data dump to restore. Teams, tokens and the profile in the dump are
+	// ignored.
+	Dump *DataDumpResponse `protobuf:"bytes,2,opt,name=dump,proto3" json:"dump,omitempty"`
+	// Store the data in the dump with the original timestamps. The default is
+	// to skip the data.
+	IncludeData          *wrappers.BoolValue `protobuf:"bytes,3,opt,name=include_data,json=includeData,proto3" json:"include_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DataRestoreRequest) Reset()         { *m = DataRestoreRequest{} }
+func (m *DataRestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*DataRestoreRequest) ProtoMessage()    {}
+func (*DataRestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{84}
+}
+
+func (m *DataRestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataRestoreRequest.Unmarshal(m, b)
+}
+func (m *DataRestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataRestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *DataRestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRestoreRequest.Merge(m, src)
+}
+func (m *DataRestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_DataRestoreRequest.Size(m)
+}
+func (m *DataRestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRestoreRequest.DiscardUnknown(m)
 }
 
-func (m *FieldMask) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FieldMask.Unmarshal(m, b)
+var xxx_messageInfo_DataRestoreRequest proto.InternalMessageInfo
+
+func (m *DataRestoreRequest) GetTeamId() *wrappers.StringValue {
+	if m != nil {
+		return m.TeamId
+	}
+	return nil
 }
-func (m *FieldMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FieldMask.Marshal(b, m, deterministic)
+
+func (m *DataRestoreRequest) GetDump() *DataDumpResponse {
+	if m != nil {
+		return m.Dump
+	}
+	return nil
 }
-func (m *FieldMask) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FieldMask.Merge(m, src)
+
+func (m *DataRestoreRequest) GetIncludeData() *wrappers.BoolValue {
+	if m != nil {
+		return m.IncludeData
+	}
+	return nil
 }
-func (m *FieldMask) XXX_Size() int {
-	return xxx_messageInfo_FieldMask.Size(m)
+
+// A collection restored from a data dump
+type RestoredCollection struct {
+	// The collection ID in the data dump
+	SourceCollectionId string `protobuf:"bytes,1,opt,name=source_collection_id,json=sourceCollectionId,proto3" json:"source_collection_id,omitempty"`
+	// The new collection
+	Collection *Collection `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	// The number of devices created
+	Devices int32 `protobuf:"varint,3,opt,name=devices,proto3" json:"devices,omitempty"`
+	// The number of outputs created. Outputs are disabled when they are
+	// restored.
+	Outputs int32 `protobuf:"varint,4,opt,name=outputs,proto3" json:"outputs,omitempty"`
+	// The number of firmware images created. Only the metadata is restored.
+	Firmware int32 `protobuf:"varint,5,opt,name=firmware,proto3" json:"firmware,omitempty"`
+	// The number of data messages stored.
+	Messages             int32    `protobuf:"varint,6,opt,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *FieldMask) XXX_DiscardUnknown() {
-	xxx_messageInfo_FieldMask.DiscardUnknown(m)
+
+func (m *RestoredCollection) Reset()         { *m = RestoredCollection{} }
+func (m *RestoredCollection) String() string { return proto.CompactTextString(m) }
+func (*RestoredCollection) ProtoMessage()    {}
+func (*RestoredCollection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{85}
 }
 
-var xxx_messageInfo_FieldMask proto.InternalMessageInfo
+func (m *RestoredCollection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoredCollection.Unmarshal(m, b)
+}
+func (m *RestoredCollection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoredCollection.Marshal(b, m, deterministic)
+}
+func (m *RestoredCollection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoredCollection.Merge(m, src)
+}
+func (m *RestoredCollection) XXX_Size() int {
+	return xxx_messageInfo_RestoredCollection.Size(m)
+}
+func (m *RestoredCollection) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoredCollection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoredCollection proto.InternalMessageInfo
+
+func (m *RestoredCollection) GetSourceCollectionId() string {
+	if m != nil {
+		return m.SourceCollectionId
+	}
+	return ""
+}
+
+func (m *RestoredCollection) GetCollection() *Collection {
+	if m != nil {
+		return m.Collection
+	}
+	return nil
+}
+
+func (m *RestoredCollection) GetDevices() int32 {
+	if m != nil {
+		return m.Devices
+	}
+	return 0
+}
+
+func (m *RestoredCollection) GetOutputs() int32 {
+	if m != nil {
+		return m.Outputs
+	}
+	return 0
+}
+
+func (m *RestoredCollection) GetFirmware() int32 {
+	if m != nil {
+		return m.Firmware
+	}
+	return 0
+}
+
+func (m *RestoredCollection) GetMessages() int32 {
+	if m != nil {
+		return m.Messages
+	}
+	return 0
+}
+
+type DataRestoreResponse struct {
+	// The restored collections. This is empty if there are conflicts.
+	Collections []*RestoredCollection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	// Devices in the dump that can't be restored, typically because the IMSI is
+	// already in use. Nothing is restored if there are conflicts.
+	Conflicts            []string `protobuf:"bytes,2,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataRestoreResponse) Reset()         { *m = DataRestoreResponse{} }
+func (m *DataRestoreResponse) String() string { return proto.CompactTextString(m) }
+func (*DataRestoreResponse) ProtoMessage()    {}
+func (*DataRestoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{86}
+}
+
+func (m *DataRestoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataRestoreResponse.Unmarshal(m, b)
+}
+func (m *DataRestoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataRestoreResponse.Marshal(b, m, deterministic)
+}
+func (m *DataRestoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRestoreResponse.Merge(m, src)
+}
+func (m *DataRestoreResponse) XXX_Size() int {
+	return xxx_messageInfo_DataRestoreResponse.Size(m)
+}
+func (m *DataRestoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRestoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataRestoreResponse proto.InternalMessageInfo
+
+func (m *DataRestoreResponse) GetCollections() []*RestoredCollection {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *DataRestoreResponse) GetConflicts() []string {
+	if m != nil {
+		return m.Conflicts
+	}
+	return nil
+}
+
+type UserProfileRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserProfileRequest) Reset()         { *m = UserProfileRequest{} }
+func (m *UserProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*UserProfileRequest) ProtoMessage()    {}
+func (*UserProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{87}
+}
+
+func (m *UserProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserProfileRequest.Unmarshal(m, b)
+}
+func (m *UserProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserProfileRequest.Marshal(b, m, deterministic)
+}
+func (m *UserProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserProfileRequest.Merge(m, src)
+}
+func (m *UserProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_UserProfileRequest.Size(m)
+}
+func (m *UserProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserProfileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserProfileRequest proto.InternalMessageInfo
+
+type TeamRequest struct {
+	TeamId               *wrappers.StringValue `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *TeamRequest) Reset()         { *m = TeamRequest{} }
+func (m *TeamRequest) String() string { return proto.CompactTextString(m) }
+func (*TeamRequest) ProtoMessage()    {}
+func (*TeamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{88}
+}
+
+func (m *TeamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamRequest.Unmarshal(m, b)
+}
+func (m *TeamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamRequest.Marshal(b, m, deterministic)
+}
+func (m *TeamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamRequest.Merge(m, src)
+}
+func (m *TeamRequest) XXX_Size() int {
+	return xxx_messageInfo_TeamRequest.Size(m)
+}
+func (m *TeamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamRequest proto.InternalMessageInfo
+
+func (m *TeamRequest) GetTeamId() *wrappers.StringValue {
+	if m != nil {
+		return m.TeamId
+	}
+	return nil
+}
+
+type ListTeamRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTeamRequest) Reset()         { *m = ListTeamRequest{} }
+func (m *ListTeamRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTeamRequest) ProtoMessage()    {}
+func (*ListTeamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{89}
+}
+
+func (m *ListTeamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTeamRequest.Unmarshal(m, b)
+}
+func (m *ListTeamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTeamRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTeamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTeamRequest.Merge(m, src)
+}
+func (m *ListTeamRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTeamRequest.Size(m)
+}
+func (m *ListTeamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTeamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTeamRequest proto.InternalMessageInfo
+
+type TeamList struct {
+	Teams                []*Team  `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamList) Reset()         { *m = TeamList{} }
+func (m *TeamList) String() string { return proto.CompactTextString(m) }
+func (*TeamList) ProtoMessage()    {}
+func (*TeamList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{90}
+}
+
+func (m *TeamList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamList.Unmarshal(m, b)
+}
+func (m *TeamList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamList.Marshal(b, m, deterministic)
+}
+func (m *TeamList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamList.Merge(m, src)
+}
+func (m *TeamList) XXX_Size() int {
+	return xxx_messageInfo_TeamList.Size(m)
+}
+func (m *TeamList) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamList proto.InternalMessageInfo
+
+func (m *TeamList) GetTeams() []*Team {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+// Request for metered usage. The usage is aggregated in hourly buckets.
+type UsageRequest struct {
+	TeamId *wrappers.StringValue `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// Only include usage for this collection
+	CollectionId *wrappers.StringValue `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Start time (in milliseconds since epoch). The default is 30 days before
+	// the end time.
+	Since *wrappers.Int64Value `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	// End time (in milliseconds since epoch). The default is the current time.
+	Until                *wrappers.Int64Value `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UsageRequest) Reset()         { *m = UsageRequest{} }
+func (m *UsageRequest) String() string { return proto.CompactTextString(m) }
+func (*UsageRequest) ProtoMessage()    {}
+func (*UsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{91}
+}
+
+func (m *UsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsageRequest.Unmarshal(m, b)
+}
+func (m *UsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsageRequest.Marshal(b, m, deterministic)
+}
+func (m *UsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsageRequest.Merge(m, src)
+}
+func (m *UsageRequest) XXX_Size() int {
+	return xxx_messageInfo_UsageRequest.Size(m)
+}
+func (m *UsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UsageRequest proto.InternalMessageInfo
 
-func (m *FieldMask) GetImsi() *wrappers.BoolValue {
+func (m *UsageRequest) GetTeamId() *wrappers.StringValue {
 	if m != nil {
-		return m.Imsi
+		return m.TeamId
 	}
 	return nil
 }
 
-func (m *FieldMask) GetImei() *wrappers.BoolValue {
+func (m *UsageRequest) GetCollectionId() *wrappers.StringValue {
 	if m != nil {
-		return m.Imei
+		return m.CollectionId
 	}
 	return nil
 }
 
-func (m *FieldMask) GetMsisdn() *wrappers.BoolValue {
+func (m *UsageRequest) GetSince() *wrappers.Int64Value {
 	if m != nil {
-		return m.Msisdn
+		return m.Since
 	}
 	return nil
 }
 
-func (m *FieldMask) GetLocation() *wrappers.BoolValue {
+func (m *UsageRequest) GetUntil() *wrappers.Int64Value {
 	if m != nil {
-		return m.Location
+		return m.Until
 	}
 	return nil
 }
 
-type SystemInfoRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *SystemInfoRequest) Reset()         { *m = SystemInfoRequest{} }
-func (m *SystemInfoRequest) String() string { return proto.CompactTextString(m) }
-func (*SystemInfoRequest) ProtoMessage()    {}
-func (*SystemInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
-}
-
-func (m *SystemInfoRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SystemInfoRequest.Unmarshal(m, b)
-}
-func (m *SystemInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SystemInfoRequest.Marshal(b, m, deterministic)
-}
-func (m *SystemInfoRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SystemInfoRequest.Merge(m, src)
-}
-func (m *SystemInfoRequest) XXX_Size() int {
-	return xxx_messageInfo_SystemInfoRequest.Size(m)
-}
-func (m *SystemInfoRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SystemInfoRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_SystemInfoRequest proto.InternalMessageInfo
-
-// System information structure
-type SystemInfoResponse struct {
-	Version              *wrappers.StringValue `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	BuildDate            *wrappers.StringValue `protobuf:"bytes,2,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
-	ReleaseName          *wrappers.StringValue `protobuf:"bytes,3,opt,name=release_name,json=releaseName,proto3" json:"release_name,omitempty"`
-	DefaultFieldMask     *FieldMask            `protobuf:"bytes,4,opt,name=default_field_mask,json=defaultFieldMask,proto3" json:"default_field_mask,omitempty"`
-	ForcedFieldMask      *FieldMask            `protobuf:"bytes,5,opt,name=forced_field_mask,json=forcedFieldMask,proto3" json:"forced_field_mask,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+// Metered usage for a collection in a one hour period. Message counts are
+// split by transport. RADIUS sessions, firmware and output deliveries have
+// an empty transport.
+type UsageRecord struct {
+	CollectionId *wrappers.StringValue `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Start of the hour (in milliseconds since epoch)
+	Hour             *wrappers.Int64Value  `protobuf:"bytes,2,opt,name=hour,proto3" json:"hour,omitempty"`
+	Transport        *wrappers.StringValue `protobuf:"bytes,3,opt,name=transport,proto3" json:"transport,omitempty"`
+	UplinkMessages   *wrappers.Int64Value  `protobuf:"bytes,4,opt,name=uplink_messages,json=uplinkMessages,proto3" json:"uplink_messages,omitempty"`
+	UplinkBytes      *wrappers.Int64Value  `protobuf:"bytes,5,opt,name=uplink_bytes,json=uplinkBytes,proto3" json:"uplink_bytes,omitempty"`
+	DownlinkMessages *wrappers.Int64Value  `protobuf:"bytes,6,opt,name=downlink_messages,json=downlinkMessages,proto3" json:"downlink_messages,omitempty"`
+	DownlinkBytes    *wrappers.Int64Value  `protobuf:"bytes,7,opt,name=downlink_bytes,json=downlinkBytes,proto3" json:"downlink_bytes,omitempty"`
+	RadiusSessions   *wrappers.Int64Value  `protobuf:"bytes,8,opt,name=radius_sessions,json=radiusSessions,proto3" json:"radius_sessions,omitempty"`
+	// Bytes of firmware images delivered to devices
+	FirmwareBytes *wrappers.Int64Value `protobuf:"bytes,9,opt,name=firmware_bytes,json=firmwareBytes,proto3" json:"firmware_bytes,omitempty"`
+	// Messages delivered by outputs
+	OutputDeliveries     *wrappers.Int64Value `protobuf:"bytes,10,opt,name=output_deliveries,json=outputDeliveries,proto3" json:"output_deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *SystemInfoResponse) Reset()         { *m = SystemInfoResponse{} }
-func (m *SystemInfoResponse) String() string { return proto.CompactTextString(m) }
-func (*SystemInfoResponse) ProtoMessage()    {}
-func (*SystemInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{51}
+func (m *UsageRecord) Reset()         { *m = UsageRecord{} }
+func (m *UsageRecord) String() string { return proto.CompactTextString(m) }
+func (*UsageRecord) ProtoMessage()    {}
+func (*UsageRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{92}
 }
 
-func (m *SystemInfoResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SystemInfoResponse.Unmarshal(m, b)
+func (m *UsageRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsageRecord.Unmarshal(m, b)
 }
-func (m *SystemInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SystemInfoResponse.Marshal(b, m, deterministic)
+func (m *UsageRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsageRecord.Marshal(b, m, deterministic)
 }
-func (m *SystemInfoResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SystemInfoResponse.Merge(m, src)
+func (m *UsageRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsageRecord.Merge(m, src)
 }
-func (m *SystemInfoResponse) XXX_Size() int {
-	return xxx_messageInfo_SystemInfoResponse.Size(m)
+func (m *UsageRecord) XXX_Size() int {
+	return xxx_messageInfo_UsageRecord.Size(m)
 }
-func (m *SystemInfoResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SystemInfoResponse.DiscardUnknown(m)
+func (m *UsageRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsageRecord.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SystemInfoResponse proto.InternalMessageInfo
+var xxx_messageInfo_UsageRecord proto.InternalMessageInfo
 
-func (m *SystemInfoResponse) GetVersion() *wrappers.StringValue {
+func (m *UsageRecord) GetCollectionId() *wrappers.StringValue {
 	if m != nil {
-		return m.Version
+		return m.CollectionId
 	}
 	return nil
 }
 
-func (m *SystemInfoResponse) GetBuildDate() *wrappers.StringValue {
+func (m *UsageRecord) GetHour() *wrappers.Int64Value {
 	if m != nil {
-		return m.BuildDate
+		return m.Hour
 	}
 	return nil
 }
 
-func (m *SystemInfoResponse) GetReleaseName() *wrappers.StringValue {
+func (m *UsageRecord) GetTransport() *wrappers.StringValue {
 	if m != nil {
-		return m.ReleaseName
+		return m.Transport
 	}
 	return nil
 }
 
-func (m *SystemInfoResponse) GetDefaultFieldMask() *FieldMask {
+func (m *UsageRecord) GetUplinkMessages() *wrappers.Int64Value {
 	if m != nil {
-		return m.DefaultFieldMask
+		return m.UplinkMessages
 	}
 	return nil
 }
 
-func (m *SystemInfoResponse) GetForcedFieldMask() *FieldMask {
+func (m *UsageRecord) GetUplinkBytes() *wrappers.Int64Value {
 	if m != nil {
-		return m.ForcedFieldMask
+		return m.UplinkBytes
 	}
 	return nil
 }
 
-// The collection dump
-type DumpedCollection struct {
-	Collection           *Collection     `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
-	Devices              []*DumpedDevice `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
-	Outputs              []*Output       `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
-}
-
-func (m *DumpedCollection) Reset()         { *m = DumpedCollection{} }
-func (m *DumpedCollection) String() string { return proto.CompactTextString(m) }
-func (*DumpedCollection) ProtoMessage()    {}
-func (*DumpedCollection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{52}
+func (m *UsageRecord) GetDownlinkMessages() *wrappers.Int64Value {
+	if m != nil {
+		return m.DownlinkMessages
+	}
+	return nil
 }
 
-func (m *DumpedCollection) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DumpedCollection.Unmarshal(m, b)
-}
-func (m *DumpedCollection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DumpedCollection.Marshal(b, m, deterministic)
-}
-func (m *DumpedCollection) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DumpedCollection.Merge(m, src)
-}
-func (m *DumpedCollection) XXX_Size() int {
-	return xxx_messageInfo_DumpedCollection.Size(m)
-}
-func (m *DumpedCollection) XXX_DiscardUnknown() {
-	xxx_messageInfo_DumpedCollection.DiscardUnknown(m)
+func (m *UsageRecord) GetDownlinkBytes() *wrappers.Int64Value {
+	if m != nil {
+		return m.DownlinkBytes
+	}
+	return nil
 }
 
-var xxx_messageInfo_DumpedCollection proto.InternalMessageInfo
-
-func (m *DumpedCollection) GetCollection() *Collection {
+func (m *UsageRecord) GetRadiusSessions() *wrappers.Int64Value {
 	if m != nil {
-		return m.Collection
+		return m.RadiusSessions
 	}
 	return nil
 }
 
-func (m *DumpedCollection) GetDevices() []*DumpedDevice {
+func (m *UsageRecord) GetFirmwareBytes() *wrappers.Int64Value {
 	if m != nil {
-		return m.Devices
+		return m.FirmwareBytes
 	}
 	return nil
 }
 
-func (m *DumpedCollection) GetOutputs() []*Output {
+func (m *UsageRecord) GetOutputDeliveries() *wrappers.Int64Value {
 	if m != nil {
-		return m.Outputs
+		return m.OutputDeliveries
 	}
 	return nil
 }
 
-// The device dump
-type DumpedDevice struct {
-	// The device itself
-	Device *Device `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
-	// The data for the device
-	Data                 []*OutputDataMessage `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type UsageResponse struct {
+	TeamId  *wrappers.StringValue `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Records []*UsageRecord        `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	// The sum of all of the records. The collection, hour and transport fields
+	// are not set.
+	Total                *UsageRecord `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *DumpedDevice) Reset()         { *m = DumpedDevice{} }
-func (m *DumpedDevice) String() string { return proto.CompactTextString(m) }
-func (*DumpedDevice) ProtoMessage()    {}
-func (*DumpedDevice) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{53}
+func (m *UsageResponse) Reset()         { *m = UsageResponse{} }
+func (m *UsageResponse) String() string { return proto.CompactTextString(m) }
+func (*UsageResponse) ProtoMessage()    {}
+func (*UsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{93}
 }
 
-func (m *DumpedDevice) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DumpedDevice.Unmarshal(m, b)
+func (m *UsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsageResponse.Unmarshal(m, b)
 }
-func (m *DumpedDevice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DumpedDevice.Marshal(b, m, deterministic)
+func (m *UsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsageResponse.Marshal(b, m, deterministic)
 }
-func (m *DumpedDevice) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DumpedDevice.Merge(m, src)
+func (m *UsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsageResponse.Merge(m, src)
 }
-func (m *DumpedDevice) XXX_Size() int {
-	return xxx_messageInfo_DumpedDevice.Size(m)
+func (m *UsageResponse) XXX_Size() int {
+	return xxx_messageInfo_UsageResponse.Size(m)
 }
-func (m *DumpedDevice) XXX_DiscardUnknown() {
-	xxx_messageInfo_DumpedDevice.DiscardUnknown(m)
+func (m *UsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsageResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DumpedDevice proto.InternalMessageInfo
+var xxx_messageInfo_UsageResponse proto.InternalMessageInfo
 
-func (m *DumpedDevice) GetDevice() *Device {
+func (m *UsageResponse) GetTeamId() *wrappers.StringValue {
 	if m != nil {
-		return m.Device
+		return m.TeamId
 	}
 	return nil
 }
 
-func (m *DumpedDevice) GetData() []*OutputDataMessage {
+func (m *UsageResponse) GetRecords() []*UsageRecord {
 	if m != nil {
-		return m.Data
+		return m.Records
 	}
 	return nil
 }
 
-type DataDumpRequest struct {
+func (m *UsageResponse) GetTotal() *UsageRecord {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+// A change made through the API or the management service
+type AuditEvent struct {
+	EventId *wrappers.StringValue `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Time of the change (in milliseconds since epoch)
+	Time   *wrappers.Int64Value  `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	TeamId *wrappers.StringValue `protobuf:"bytes,3,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// The service the change was made through, either "api" or "management"
+	Source *wrappers.StringValue `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// The user making the change. This isn't set for the management service.
+	UserId *wrappers.StringValue `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The ID of the API token used to make the change, if any
+	TokenId    *wrappers.StringValue `protobuf:"bytes,6,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	AuthMethod *wrappers.StringValue `protobuf:"bytes,7,opt,name=auth_method,json=authMethod,proto3" json:"auth_method,omitempty"`
+	// The API method, ie "UpdateDevice"
+	Action       *wrappers.StringValue `protobuf:"bytes,8,opt,name=action,proto3" json:"action,omitempty"`
+	ResourceType *wrappers.StringValue `protobuf:"bytes,9,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	// The resource path, ie /collections/{collection_id}/devices/{device_id}
+	ResourceId *wrappers.StringValue `protobuf:"bytes,10,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// JSON snapshots of the resource before and after the change. Secrets are
+	// removed from the snapshots.
+	Before *wrappers.StringValue `protobuf:"bytes,11,opt,name=before,proto3" json:"before,omitempty"`
+	After  *wrappers.StringValue `protobuf:"bytes,12,opt,name=after,proto3" json:"after,omitempty"`
+	// The fields that are changed
+	ChangedFields        []string `protobuf:"bytes,13,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DataDumpRequest) Reset()         { *m = DataDumpRequest{} }
-func (m *DataDumpRequest) String() string { return proto.CompactTextString(m) }
-func (*DataDumpRequest) ProtoMessage()    {}
-func (*DataDumpRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{54}
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{94}
 }
 
-func (m *DataDumpRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DataDumpRequest.Unmarshal(m, b)
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
 }
-func (m *DataDumpRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DataDumpRequest.Marshal(b, m, deterministic)
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
 }
-func (m *DataDumpRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DataDumpRequest.Merge(m, src)
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
 }
-func (m *DataDumpRequest) XXX_Size() int {
-	return xxx_messageInfo_DataDumpRequest.Size(m)
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
 }
-func (m *DataDumpRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DataDumpRequest.DiscardUnknown(m)
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DataDumpRequest proto.InternalMessageInfo
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
 
-// This is the documentation for the data dump response
-type DataDumpResponse struct {
-	// This is the array of collections
-	Collections []*DumpedCollection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
-	// This is your user profile
-	Profile *UserProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
-	// This is your teams
-	Teams []*Team `protobuf:"bytes,3,rep,name=teams,proto3" json:"teams,omitempty"`
-	// This is your tokens
-	Tokens               []*Token `protobuf:"bytes,4,rep,name=tokens,proto3" json:"tokens,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *AuditEvent) GetEventId() *wrappers.StringValue {
+	if m != nil {
+		return m.EventId
+	}
+	return nil
 }
 
-func (m *DataDumpResponse) Reset()         { *m = DataDumpResponse{} }
-func (m *DataDumpResponse) String() string { return proto.CompactTextString(m) }
-func (*DataDumpResponse) ProtoMessage()    {}
-func (*DataDumpResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{55}
+func (m *AuditEvent) GetTime() *wrappers.Int64Value {
+	if m != nil {
+		return m.Time
+	}
+	return nil
 }
 
-func (m *DataDumpResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DataDumpResponse.Unmarshal(m, b)
-}
-func (m *DataDumpResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DataDumpResponse.Marshal(b, m, deterministic)
-}
-func (m *DataDumpResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DataDumpResponse.Merge(m, src)
-}
-func (m *DataDumpResponse) XXX_Size() int {
-	return xxx_messageInfo_DataDumpResponse.Size(m)
-}
-func (m *DataDumpResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DataDumpResponse.DiscardUnknown(m)
+func (m *AuditEvent) GetTeamId() *wrappers.StringValue {
+	if m != nil {
+		return m.TeamId
+	}
+	return nil
 }
 
-var xxx_messageInfo_DataDumpResponse proto.InternalMessageInfo
-
-func (m *DataDumpResponse) GetCollections() []*DumpedCollection {
+func (m *AuditEvent) GetSource() *wrappers.StringValue {
 	if m != nil {
-		return m.Collections
+		return m.Source
 	}
 	return nil
 }
 
-func (m *DataDumpResponse) GetProfile() *UserProfile {
+func (m *AuditEvent) GetUserId() *wrappers.StringValue {
 	if m != nil {
-		return m.Profile
+		return m.UserId
 	}
 	return nil
 }
 
-func (m *DataDumpResponse) GetTeams() []*Team {
+func (m *AuditEvent) GetTokenId() *wrappers.StringValue {
 	if m != nil {
-		return m.Teams
+		return m.TokenId
 	}
 	return nil
 }
 
-func (m *DataDumpResponse) GetTokens() []*Token {
+func (m *AuditEvent) GetAuthMethod() *wrappers.StringValue {
 	if m != nil {
-		return m.Tokens
+		return m.AuthMethod
 	}
 	return nil
 }
 
-type UserProfileRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *AuditEvent) GetAction() *wrappers.StringValue {
+	if m != nil {
+		return m.Action
+	}
+	return nil
 }
 
-func (m *UserProfileRequest) Reset()         { *m = UserProfileRequest{} }
-func (m *UserProfileRequest) String() string { return proto.CompactTextString(m) }
-func (*UserProfileRequest) ProtoMessage()    {}
-func (*UserProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{56}
+func (m *AuditEvent) GetResourceType() *wrappers.StringValue {
+	if m != nil {
+		return m.ResourceType
+	}
+	return nil
 }
 
-func (m *UserProfileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UserProfileRequest.Unmarshal(m, b)
-}
-func (m *UserProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UserProfileRequest.Marshal(b, m, deterministic)
-}
-func (m *UserProfileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserProfileRequest.Merge(m, src)
+func (m *AuditEvent) GetResourceId() *wrappers.StringValue {
+	if m != nil {
+		return m.ResourceId
+	}
+	return nil
 }
-func (m *UserProfileRequest) XXX_Size() int {
-	return xxx_messageInfo_UserProfileRequest.Size(m)
+
+func (m *AuditEvent) GetBefore() *wrappers.StringValue {
+	if m != nil {
+		return m.Before
+	}
+	return nil
 }
-func (m *UserProfileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserProfileRequest.DiscardUnknown(m)
+
+func (m *AuditEvent) GetAfter() *wrappers.StringValue {
+	if m != nil {
+		return m.After
+	}
+	return nil
 }
 
-var xxx_messageInfo_UserProfileRequest proto.InternalMessageInfo
+func (m *AuditEvent) GetChangedFields() []string {
+	if m != nil {
+		return m.ChangedFields
+	}
+	return nil
+}
 
-type TeamRequest struct {
-	TeamId               *wrappers.StringValue `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+type ListAuditEventsRequest struct {
+	TeamId *wrappers.StringValue `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// Maximum number of events to return. The default is 100.
+	Limit *wrappers.Int32Value `protobuf:"bytes,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Only return events older than this event. Use the next field in the
+	// response to get the next page.
+	Before               *wrappers.StringValue `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *TeamRequest) Reset()         { *m = TeamRequest{} }
-func (m *TeamRequest) String() string { return proto.CompactTextString(m) }
-func (*TeamRequest) ProtoMessage()    {}
-func (*TeamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{57}
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{95}
 }
 
-func (m *TeamRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TeamRequest.Unmarshal(m, b)
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
 }
-func (m *TeamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TeamRequest.Marshal(b, m, deterministic)
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
 }
-func (m *TeamRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TeamRequest.Merge(m, src)
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
 }
-func (m *TeamRequest) XXX_Size() int {
-	return xxx_messageInfo_TeamRequest.Size(m)
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
 }
-func (m *TeamRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TeamRequest.DiscardUnknown(m)
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TeamRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
 
-func (m *TeamRequest) GetTeamId() *wrappers.StringValue {
+func (m *ListAuditEventsRequest) GetTeamId() *wrappers.StringValue {
 	if m != nil {
 		return m.TeamId
 	}
 	return nil
 }
 
-type ListTeamRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ListAuditEventsRequest) GetLimit() *wrappers.Int32Value {
+	if m != nil {
+		return m.Limit
+	}
+	return nil
 }
 
-func (m *ListTeamRequest) Reset()         { *m = ListTeamRequest{} }
-func (m *ListTeamRequest) String() string { return proto.CompactTextString(m) }
-func (*ListTeamRequest) ProtoMessage()    {}
-func (*ListTeamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{58}
+func (m *ListAuditEventsRequest) GetBefore() *wrappers.StringValue {
+	if m != nil {
+		return m.Before
+	}
+	return nil
 }
 
-func (m *ListTeamRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListTeamRequest.Unmarshal(m, b)
-}
-func (m *ListTeamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListTeamRequest.Marshal(b, m, deterministic)
-}
-func (m *ListTeamRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListTeamRequest.Merge(m, src)
-}
-func (m *ListTeamRequest) XXX_Size() int {
-	return xxx_messageInfo_ListTeamRequest.Size(m)
-}
-func (m *ListTeamRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListTeamRequest.DiscardUnknown(m)
+type ListAuditEventsResponse struct {
+	TeamId *wrappers.StringValue `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// The events, newest first
+	Events []*AuditEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	// The value for the before field for the next page. This isn't set if
+	// there are no more events.
+	Next                 *wrappers.StringValue `protobuf:"bytes,3,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-var xxx_messageInfo_ListTeamRequest proto.InternalMessageInfo
-
-type TeamList struct {
-	Teams                []*Team  `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ListAuditEventsResponse) Reset()         { *m = ListAuditEventsResponse{} }
+func (m *ListAuditEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsResponse) ProtoMessage()    {}
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{96}
 }
 
-func (m *TeamList) Reset()         { *m = TeamList{} }
-func (m *TeamList) String() string { return proto.CompactTextString(m) }
-func (*TeamList) ProtoMessage()    {}
-func (*TeamList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{59}
+func (m *ListAuditEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsResponse.Unmarshal(m, b)
 }
-
-func (m *TeamList) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TeamList.Unmarshal(m, b)
+func (m *ListAuditEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsResponse.Marshal(b, m, deterministic)
 }
-func (m *TeamList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TeamList.Marshal(b, m, deterministic)
+func (m *ListAuditEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsResponse.Merge(m, src)
 }
-func (m *TeamList) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TeamList.Merge(m, src)
+func (m *ListAuditEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsResponse.Size(m)
 }
-func (m *TeamList) XXX_Size() int {
-	return xxx_messageInfo_TeamList.Size(m)
+func (m *ListAuditEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsResponse.DiscardUnknown(m)
 }
-func (m *TeamList) XXX_DiscardUnknown() {
-	xxx_messageInfo_TeamList.DiscardUnknown(m)
+
+var xxx_messageInfo_ListAuditEventsResponse proto.InternalMessageInfo
+
+func (m *ListAuditEventsResponse) GetTeamId() *wrappers.StringValue {
+	if m != nil {
+		return m.TeamId
+	}
+	return nil
 }
 
-var xxx_messageInfo_TeamList proto.InternalMessageInfo
+func (m *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
 
-func (m *TeamList) GetTeams() []*Team {
+func (m *ListAuditEventsResponse) GetNext() *wrappers.StringValue {
 	if m != nil {
-		return m.Teams
+		return m.Next
 	}
 	return nil
 }
@@ -3664,7 +6747,7 @@ func (m *MemberRequest) Reset()         { *m = MemberRequest{} }
 func (m *MemberRequest) String() string { return proto.CompactTextString(m) }
 func (*MemberRequest) ProtoMessage()    {}
 func (*MemberRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{60}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{97}
 }
 
 func (m *MemberRequest) XXX_Unmarshal(b []byte) error {
@@ -3711,7 +6794,7 @@ func (m *Invite) Reset()         { *m = Invite{} }
 func (m *Invite) String() string { return proto.CompactTextString(m) }
 func (*Invite) ProtoMessage()    {}
 func (*Invite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{61}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{98}
 }
 
 func (m *Invite) XXX_Unmarshal(b []byte) error {
@@ -3757,7 +6840,7 @@ func (m *InviteList) Reset()         { *m = InviteList{} }
 func (m *InviteList) String() string { return proto.CompactTextString(m) }
 func (*InviteList) ProtoMessage()    {}
 func (*InviteList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{62}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{99}
 }
 
 func (m *InviteList) XXX_Unmarshal(b []byte) error {
@@ -3797,7 +6880,7 @@ func (m *InviteRequest) Reset()         { *m = InviteRequest{} }
 func (m *InviteRequest) String() string { return proto.CompactTextString(m) }
 func (*InviteRequest) ProtoMessage()    {}
 func (*InviteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{63}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{100}
 }
 
 func (m *InviteRequest) XXX_Unmarshal(b []byte) error {
@@ -3843,7 +6926,7 @@ func (m *AcceptInviteRequest) Reset()         { *m = AcceptInviteRequest{} }
 func (m *AcceptInviteRequest) String() string { return proto.CompactTextString(m) }
 func (*AcceptInviteRequest) ProtoMessage()    {}
 func (*AcceptInviteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{64}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{101}
 }
 
 func (m *AcceptInviteRequest) XXX_Unmarshal(b []byte) error {
@@ -3881,7 +6964,7 @@ func (m *DeleteInviteResponse) Reset()         { *m = DeleteInviteResponse{} }
 func (m *DeleteInviteResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteInviteResponse) ProtoMessage()    {}
 func (*DeleteInviteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{65}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{102}
 }
 
 func (m *DeleteInviteResponse) XXX_Unmarshal(b []byte) error {
@@ -3913,7 +6996,7 @@ func (m *DeleteTokenRequest) Reset()         { *m = DeleteTokenRequest{} }
 func (m *DeleteTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteTokenRequest) ProtoMessage()    {}
 func (*DeleteTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{66}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{103}
 }
 
 func (m *DeleteTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -3951,7 +7034,7 @@ func (m *DeleteTokenResponse) Reset()         { *m = DeleteTokenResponse{} }
 func (m *DeleteTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteTokenResponse) ProtoMessage()    {}
 func (*DeleteTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{67}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{104}
 }
 
 func (m *DeleteTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -3982,7 +7065,7 @@ func (m *ListTokenRequest) Reset()         { *m = ListTokenRequest{} }
 func (m *ListTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*ListTokenRequest) ProtoMessage()    {}
 func (*ListTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{68}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{105}
 }
 
 func (m *ListTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -4014,7 +7097,7 @@ func (m *TokenList) Reset()         { *m = TokenList{} }
 func (m *TokenList) String() string { return proto.CompactTextString(m) }
 func (*TokenList) ProtoMessage()    {}
 func (*TokenList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{69}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{106}
 }
 
 func (m *TokenList) XXX_Unmarshal(b []byte) error {
@@ -4053,7 +7136,7 @@ func (m *TokenRequest) Reset()         { *m = TokenRequest{} }
 func (m *TokenRequest) String() string { return proto.CompactTextString(m) }
 func (*TokenRequest) ProtoMessage()    {}
 func (*TokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{70}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{107}
 }
 
 func (m *TokenRequest) XXX_Unmarshal(b []byte) error {
@@ -4081,6 +7164,67 @@ func (m *TokenRequest) GetToken() *wrappers.StringValue {
 	return nil
 }
 
+type RotateTokenRequest struct {
+	// The token to rotate
+	Token *wrappers.StringValue `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The number of seconds the existing token is valid after the rotation.
+	// The default is 3600 seconds (one hour). The existing token expires
+	// immediately if this is 0.
+	GracePeriod *wrappers.Int64Value `protobuf:"bytes,2,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
+	// Expiry time for the new token (in milliseconds since epoch). The new
+	// token gets the same lifetime as the existing token if this isn't set.
+	Expires              *wrappers.Int64Value `protobuf:"bytes,3,opt,name=expires,proto3" json:"expires,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RotateTokenRequest) Reset()         { *m = RotateTokenRequest{} }
+func (m *RotateTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateTokenRequest) ProtoMessage()    {}
+func (*RotateTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{108}
+}
+
+func (m *RotateTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateTokenRequest.Unmarshal(m, b)
+}
+func (m *RotateTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *RotateTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateTokenRequest.Merge(m, src)
+}
+func (m *RotateTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_RotateTokenRequest.Size(m)
+}
+func (m *RotateTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateTokenRequest proto.InternalMessageInfo
+
+func (m *RotateTokenRequest) GetToken() *wrappers.StringValue {
+	if m != nil {
+		return m.Token
+	}
+	return nil
+}
+
+func (m *RotateTokenRequest) GetGracePeriod() *wrappers.Int64Value {
+	if m != nil {
+		return m.GracePeriod
+	}
+	return nil
+}
+
+func (m *RotateTokenRequest) GetExpires() *wrappers.Int64Value {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("apipb.CollectionFirmware_FirmwareManagement", CollectionFirmware_FirmwareManagement_name, CollectionFirmware_FirmwareManagement_value)
 	proto.RegisterEnum("apipb.FirmwareMetadata_FirmwareState", FirmwareMetadata_FirmwareState_name, FirmwareMetadata_FirmwareState_value)
@@ -4095,6 +7239,7 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "apipb.UpdateTagRequest.TagsEntry")
 	proto.RegisterType((*TagRequest)(nil), "apipb.TagRequest")
 	proto.RegisterType((*CollectionFirmware)(nil), "apipb.CollectionFirmware")
+	proto.RegisterType((*MaintenanceWindow)(nil), "apipb.MaintenanceWindow")
 	proto.RegisterType((*Collection)(nil), "apipb.Collection")
 	proto.RegisterMapType((map[string]string)(nil), "apipb.Collection.TagsEntry")
 	proto.RegisterType((*NetworkMetadata)(nil), "apipb.NetworkMetadata")
@@ -4106,6 +7251,7 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "apipb.UpdateDeviceRequest.TagsEntry")
 	proto.RegisterType((*UDPMetadata)(nil), "apipb.UDPMetadata")
 	proto.RegisterType((*CoAPMetadata)(nil), "apipb.CoAPMetadata")
+	proto.RegisterType((*ResourceEvent)(nil), "apipb.ResourceEvent")
 	proto.RegisterType((*OutputDataMessage)(nil), "apipb.OutputDataMessage")
 	proto.RegisterType((*OutputConfig)(nil), "apipb.OutputConfig")
 	proto.RegisterType((*Output)(nil), "apipb.Output")
@@ -4114,10 +7260,13 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "apipb.Token.TagsEntry")
 	proto.RegisterType((*Member)(nil), "apipb.Member")
 	proto.RegisterType((*MemberList)(nil), "apipb.MemberList")
+	proto.RegisterType((*TeamQuota)(nil), "apipb.TeamQuota")
+	proto.RegisterType((*TeamUsage)(nil), "apipb.TeamUsage")
 	proto.RegisterType((*Team)(nil), "apipb.Team")
 	proto.RegisterMapType((map[string]string)(nil), "apipb.Team.TagsEntry")
 	proto.RegisterType((*Firmware)(nil), "apipb.Firmware")
 	proto.RegisterMapType((map[string]string)(nil), "apipb.Firmware.TagsEntry")
+	proto.RegisterType((*FirmwareCompatibility)(nil), "apipb.FirmwareCompatibility")
 	proto.RegisterType((*ListMessagesRequest)(nil), "apipb.ListMessagesRequest")
 	proto.RegisterType((*ListMessagesResponse)(nil), "apipb.ListMessagesResponse")
 	proto.RegisterType((*UserProfile)(nil), "apipb.UserProfile")
@@ -4129,6 +7278,11 @@ func init() {
 	proto.RegisterType((*DeviceRequest)(nil), "apipb.DeviceRequest")
 	proto.RegisterType((*ListDevicesRequest)(nil), "apipb.ListDevicesRequest")
 	proto.RegisterType((*ListDevicesResponse)(nil), "apipb.ListDevicesResponse")
+	proto.RegisterType((*ImportDevicesRequest)(nil), "apipb.ImportDevicesRequest")
+	proto.RegisterType((*ImportError)(nil), "apipb.ImportError")
+	proto.RegisterType((*ImportDevicesResponse)(nil), "apipb.ImportDevicesResponse")
+	proto.RegisterType((*ExportDevicesRequest)(nil), "apipb.ExportDevicesRequest")
+	proto.RegisterType((*ExportDevicesResponse)(nil), "apipb.ExportDevicesResponse")
 	proto.RegisterType((*ClearFirmwareErrorResponse)(nil), "apipb.ClearFirmwareErrorResponse")
 	proto.RegisterType((*SendMessageRequest)(nil), "apipb.SendMessageRequest")
 	proto.RegisterType((*SendMessageResponse)(nil), "apipb.SendMessageResponse")
@@ -4138,14 +7292,29 @@ func init() {
 	proto.RegisterType((*ListFirmwareRequest)(nil), "apipb.ListFirmwareRequest")
 	proto.RegisterType((*ListFirmwareResponse)(nil), "apipb.ListFirmwareResponse")
 	proto.RegisterType((*FirmwareUsageResponse)(nil), "apipb.FirmwareUsageResponse")
+	proto.RegisterType((*FirmwareStatistics)(nil), "apipb.FirmwareStatistics")
+	proto.RegisterType((*FirmwareHistoryEntry)(nil), "apipb.FirmwareHistoryEntry")
+	proto.RegisterType((*ListDeviceFirmwareHistoryRequest)(nil), "apipb.ListDeviceFirmwareHistoryRequest")
+	proto.RegisterType((*ListFirmwareHistoryRequest)(nil), "apipb.ListFirmwareHistoryRequest")
+	proto.RegisterType((*ListFirmwareHistoryResponse)(nil), "apipb.ListFirmwareHistoryResponse")
+	proto.RegisterType((*FirmwareCompatibilityResponse)(nil), "apipb.FirmwareCompatibilityResponse")
+	proto.RegisterType((*FirmwareCompatibilityResponse_IncompatibleDevice)(nil), "apipb.FirmwareCompatibilityResponse.IncompatibleDevice")
 	proto.RegisterType((*CreateFirmwareRequest)(nil), "apipb.CreateFirmwareRequest")
 	proto.RegisterMapType((map[string]string)(nil), "apipb.CreateFirmwareRequest.TagsEntry")
+	proto.RegisterType((*SigningKey)(nil), "apipb.SigningKey")
+	proto.RegisterType((*SigningKeyRequest)(nil), "apipb.SigningKeyRequest")
+	proto.RegisterType((*ListSigningKeysRequest)(nil), "apipb.ListSigningKeysRequest")
+	proto.RegisterType((*ListSigningKeysResponse)(nil), "apipb.ListSigningKeysResponse")
 	proto.RegisterType((*ListOutputResponse)(nil), "apipb.ListOutputResponse")
 	proto.RegisterType((*ListOutputRequest)(nil), "apipb.ListOutputRequest")
 	proto.RegisterType((*OutputRequest)(nil), "apipb.OutputRequest")
 	proto.RegisterType((*OutputLogEntry)(nil), "apipb.OutputLogEntry")
 	proto.RegisterType((*OutputLogs)(nil), "apipb.OutputLogs")
 	proto.RegisterType((*OutputStatus)(nil), "apipb.OutputStatus")
+	proto.RegisterType((*OutputTestRequest)(nil), "apipb.OutputTestRequest")
+	proto.RegisterType((*OutputTLSTrace)(nil), "apipb.OutputTLSTrace")
+	proto.RegisterType((*OutputTestTiming)(nil), "apipb.OutputTestTiming")
+	proto.RegisterType((*OutputTestResponse)(nil), "apipb.OutputTestResponse")
 	proto.RegisterType((*FieldMask)(nil), "apipb.FieldMask")
 	proto.RegisterType((*SystemInfoRequest)(nil), "apipb.SystemInfoRequest")
 	proto.RegisterType((*SystemInfoResponse)(nil), "apipb.SystemInfoResponse")
@@ -4153,10 +7322,23 @@ func init() {
 	proto.RegisterType((*DumpedDevice)(nil), "apipb.DumpedDevice")
 	proto.RegisterType((*DataDumpRequest)(nil), "apipb.DataDumpRequest")
 	proto.RegisterType((*DataDumpResponse)(nil), "apipb.DataDumpResponse")
+	proto.RegisterType((*StreamDataDumpRequest)(nil), "apipb.StreamDataDumpRequest")
+	proto.RegisterType((*DumpedMessages)(nil), "apipb.DumpedMessages")
+	proto.RegisterType((*DataDumpEnd)(nil), "apipb.DataDumpEnd")
+	proto.RegisterType((*DataDumpChunk)(nil), "apipb.DataDumpChunk")
+	proto.RegisterType((*DataRestoreRequest)(nil), "apipb.DataRestoreRequest")
+	proto.RegisterType((*RestoredCollection)(nil), "apipb.RestoredCollection")
+	proto.RegisterType((*DataRestoreResponse)(nil), "apipb.DataRestoreResponse")
 	proto.RegisterType((*UserProfileRequest)(nil), "apipb.UserProfileRequest")
 	proto.RegisterType((*TeamRequest)(nil), "apipb.TeamRequest")
 	proto.RegisterType((*ListTeamRequest)(nil), "apipb.ListTeamRequest")
 	proto.RegisterType((*TeamList)(nil), "apipb.TeamList")
+	proto.RegisterType((*UsageRequest)(nil), "apipb.UsageRequest")
+	proto.RegisterType((*UsageRecord)(nil), "apipb.UsageRecord")
+	proto.RegisterType((*UsageResponse)(nil), "apipb.UsageResponse")
+	proto.RegisterType((*AuditEvent)(nil), "apipb.AuditEvent")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "apipb.ListAuditEventsRequest")
+	proto.RegisterType((*ListAuditEventsResponse)(nil), "apipb.ListAuditEventsResponse")
 	proto.RegisterType((*MemberRequest)(nil), "apipb.MemberRequest")
 	proto.RegisterType((*Invite)(nil), "apipb.Invite")
 	proto.RegisterType((*InviteList)(nil), "apipb.InviteList")
@@ -4168,339 +7350,530 @@ func init() {
 	proto.RegisterType((*ListTokenRequest)(nil), "apipb.ListTokenRequest")
 	proto.RegisterType((*TokenList)(nil), "apipb.TokenList")
 	proto.RegisterType((*TokenRequest)(nil), "apipb.TokenRequest")
+	proto.RegisterType((*RotateTokenRequest)(nil), "apipb.RotateTokenRequest")
 }
 
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 5226 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x7c, 0x5b, 0x73, 0x1b, 0x47,
-	0x76, 0xb0, 0x07, 0x37, 0x12, 0x07, 0x00, 0x09, 0xb6, 0x28, 0x89, 0x82, 0x64, 0x1b, 0x1a, 0x6b,
-	0x2d, 0x9b, 0x6b, 0x11, 0x14, 0x74, 0x97, 0x2c, 0x59, 0x14, 0xa9, 0x0b, 0xf7, 0x93, 0x6c, 0x19,
-	0x92, 0xec, 0x5d, 0x7f, 0x59, 0xa3, 0x9a, 0x98, 0x26, 0x38, 0xd1, 0x60, 0x06, 0x9e, 0xe9, 0x21,
-	0x2d, 0x2b, 0xaa, 0x24, 0x8e, 0x37, 0x5b, 0x95, 0x6c, 0x92, 0xaa, 0x24, 0x95, 0x87, 0x54, 0x65,
-	0x7f, 0x41, 0x5e, 0x52, 0xfb, 0x90, 0xca, 0x53, 0x5e, 0xf2, 0x90, 0xa4, 0x2a, 0x4f, 0x9b, 0x2a,
-	0xe7, 0x07, 0x6c, 0xe5, 0x0f, 0xe4, 0x0f, 0xa4, 0xfa, 0x36, 0x98, 0xc1, 0x8d, 0x0d, 0x90, 0xce,
-	0xfa, 0x89, 0x98, 0x99, 0x73, 0xeb, 0xd3, 0xe7, 0xd6, 0xe7, 0xcc, 0x10, 0xf2, 0xb8, 0x6b, 0xaf,
-	0x74, 0x7d, 0x8f, 0x7a, 0x28, 0x8b, 0xbb, 0x76, 0x77, 0xab, 0x72, 0xaa, 0xed, 0x79, 0x6d, 0x87,
-	0xd4, 0x70, 0xd7, 0xae, 0x61, 0xd7, 0xf5, 0x28, 0xa6, 0xb6, 0xe7, 0x06, 0x02, 0xa8, 0xf2, 0x1e,
-	0xff, 0xd3, 0x3a, 0xd7, 0x26, 0xee, 0xb9, 0x60, 0x0f, 0xb7, 0xdb, 0xc4, 0xaf, 0x79, 0x5d, 0x0e,
-	0x31, 0x04, 0xfa, 0x0d, 0x49, 0x8b, 0x5f, 0x6d, 0x85, 0xdb, 0xb5, 0x3d, 0x1f, 0x77, 0xbb, 0xc4,
-	0x97, 0xcf, 0xcd, 0x3f, 0x31, 0xa0, 0x78, 0xd7, 0xf7, 0x3d, 0x7f, 0x83, 0x50, 0x6c, 0x3b, 0x01,
-	0xba, 0x09, 0xb3, 0x1d, 0x12, 0x04, 0xb8, 0x4d, 0x82, 0x25, 0xa3, 0x9a, 0x7e, 0xa7, 0x50, 0x3f,
-	0xbd, 0xc2, 0xc5, 0x5a, 0x89, 0x83, 0xad, 0x3c, 0x92, 0x30, 0x77, 0x5d, 0xea, 0xbf, 0x68, 0x44,
-	0x28, 0x95, 0x1b, 0x50, 0x4a, 0x3c, 0x42, 0x65, 0x48, 0x3f, 0x27, 0x2f, 0x96, 0x8c, 0xaa, 0xf1,
-	0x4e, 0xbe, 0xc1, 0x7e, 0xa2, 0x45, 0xc8, 0xee, 0x62, 0x27, 0x24, 0x4b, 0x29, 0x7e, 0x4f, 0x5c,
-	0x5c, 0x4f, 0x5d, 0x35, 0xcc, 0x2f, 0xa1, 0xf0, 0x14, 0xb7, 0x1b, 0x24, 0xe8, 0x7a, 0x6e, 0x40,
-	0xd0, 0x2a, 0x64, 0x28, 0x6e, 0x2b, 0x31, 0x4e, 0x49, 0x31, 0x62, 0x10, 0xec, 0xb7, 0x94, 0x80,
-	0x43, 0x56, 0xae, 0x40, 0x3e, 0xba, 0x35, 0x11, 0xe7, 0x7b, 0x50, 0x7e, 0x8a, 0xdb, 0x9f, 0xb0,
-	0xeb, 0x88, 0x7d, 0x5d, 0x41, 0x33, 0x0a, 0x8c, 0xbf, 0x50, 0xe5, 0x8a, 0x52, 0xe5, 0xca, 0x13,
-	0xea, 0xdb, 0xae, 0x44, 0x12, 0xa0, 0xe6, 0x1f, 0xa5, 0xa0, 0xfc, 0xac, 0x6b, 0x61, 0x4a, 0xb8,
-	0x98, 0x5f, 0x84, 0x24, 0xa0, 0xe8, 0x7d, 0x00, 0xdb, 0x22, 0x2e, 0xb5, 0xb7, 0x6d, 0xe2, 0x6b,
-	0x51, 0x8b, 0xc1, 0xa3, 0x4b, 0x52, 0x0b, 0xa9, 0xc4, 0x66, 0xf4, 0x33, 0xe9, 0x57, 0x05, 0x5a,
-	0x83, 0x52, 0xcb, 0x73, 0x1c, 0xd2, 0x62, 0xd6, 0xd0, 0xb4, 0xad, 0xa5, 0xb4, 0x06, 0xdf, 0x62,
-	0x0f, 0x65, 0xd3, 0x9a, 0x5e, 0x9b, 0xff, 0x63, 0x00, 0x1c, 0xda, 0xfa, 0x57, 0x21, 0xe3, 0xe2,
-	0x8e, 0xe0, 0xb2, 0x1f, 0x1e, 0x87, 0xec, 0x6d, 0x5c, 0x5a, 0x7b, 0xe3, 0x06, 0xd5, 0x95, 0x99,
-	0x54, 0x5d, 0xe6, 0x7f, 0xa4, 0x00, 0xad, 0x47, 0x37, 0xee, 0xd9, 0x7e, 0x67, 0x0f, 0xfb, 0x04,
-	0x3d, 0x84, 0x23, 0xad, 0xd0, 0xf7, 0x89, 0x4b, 0x9b, 0xdb, 0xf2, 0x1e, 0xa3, 0xaf, 0xa3, 0x86,
-	0x05, 0x89, 0xa8, 0x68, 0x6d, 0x5a, 0xe8, 0x47, 0x80, 0x28, 0xf6, 0xdb, 0x24, 0x49, 0x4c, 0x47,
-	0x37, 0x65, 0x81, 0x17, 0xa3, 0xf5, 0x10, 0xa0, 0x83, 0x5d, 0xdc, 0x26, 0x1d, 0xe2, 0x52, 0xae,
-	0xac, 0xb9, 0xfa, 0x7b, 0xd2, 0xbe, 0x06, 0x17, 0xb2, 0xa2, 0x7e, 0x3c, 0x8a, 0x70, 0x1a, 0x31,
-	0x7c, 0xf3, 0x23, 0x40, 0x83, 0x10, 0x68, 0x1e, 0x0a, 0xa1, 0x1b, 0x74, 0x49, 0x8b, 0x6d, 0xa6,
-	0x55, 0x7e, 0x0d, 0x15, 0x61, 0xd6, 0xb2, 0x03, 0xbc, 0xe5, 0x10, 0xab, 0x6c, 0xa0, 0x39, 0x80,
-	0x9e, 0x0e, 0xcb, 0x29, 0x04, 0x90, 0xb3, 0xc8, 0xae, 0xdd, 0x22, 0xe5, 0xb4, 0xf9, 0x5f, 0x29,
-	0x80, 0x9e, 0x18, 0x83, 0x3b, 0x64, 0x4c, 0xba, 0x43, 0xe8, 0x12, 0xcc, 0x50, 0x82, 0x3b, 0xba,
-	0x1a, 0xcb, 0x31, 0xe0, 0x4d, 0x0b, 0xd5, 0x00, 0xb6, 0x6d, 0xe2, 0x58, 0xcd, 0x0e, 0x0e, 0x9e,
-	0x4b, 0xa3, 0x2a, 0x4b, 0x3d, 0xdd, 0x63, 0x0f, 0x1e, 0xe1, 0xe0, 0x79, 0x23, 0xbf, 0xad, 0x7e,
-	0xa2, 0x4b, 0x30, 0xab, 0x76, 0x47, 0xda, 0xd1, 0x89, 0x91, 0x6a, 0x6d, 0x44, 0xa0, 0xa8, 0x26,
-	0x3d, 0x3d, 0xcb, 0x3d, 0xfd, 0xe4, 0x00, 0xca, 0xe1, 0x85, 0xbb, 0x7f, 0x33, 0x60, 0xfe, 0x43,
-	0x42, 0xf7, 0x3c, 0xff, 0xf9, 0x23, 0x42, 0xb1, 0x85, 0x29, 0x46, 0x1f, 0x40, 0x11, 0x3b, 0x8e,
-	0xd7, 0xc2, 0x94, 0x58, 0x4d, 0xbb, 0xab, 0xa5, 0xde, 0x42, 0x84, 0xb1, 0xd9, 0x4d, 0x12, 0xc0,
-	0x74, 0xa4, 0x8a, 0x37, 0xbc, 0x70, 0xcb, 0x21, 0xfd, 0x04, 0xd6, 0x28, 0xba, 0x08, 0x33, 0x2d,
-	0xe2, 0x38, 0xbd, 0x60, 0x75, 0x72, 0x00, 0x77, 0xd3, 0xa5, 0x97, 0x2f, 0xca, 0xdd, 0x61, 0xb0,
-	0x9b, 0x96, 0xf9, 0xdf, 0x59, 0x28, 0x47, 0x86, 0xa7, 0x16, 0xf3, 0xfd, 0x75, 0xba, 0xfb, 0x50,
-	0x8e, 0x88, 0xec, 0x12, 0x3f, 0xb0, 0x3d, 0x57, 0x2b, 0x4e, 0xcd, 0x2b, 0xac, 0x4f, 0x04, 0x12,
-	0xf3, 0x87, 0x80, 0xf8, 0x36, 0x76, 0x9a, 0x6e, 0xd8, 0xd9, 0x22, 0xbe, 0x5e, 0xc4, 0x12, 0x28,
-	0x1f, 0x72, 0x0c, 0xb6, 0x63, 0x1d, 0xcf, 0x22, 0x11, 0x85, 0xac, 0xce, 0x96, 0x73, 0x0c, 0x49,
-	0xe0, 0x36, 0x14, 0x3b, 0xd8, 0x0d, 0xb7, 0x71, 0x8b, 0x86, 0x3e, 0xf1, 0x97, 0x72, 0x3a, 0x22,
-	0xc4, 0x31, 0x58, 0xac, 0x0e, 0x28, 0xa6, 0x64, 0x69, 0x46, 0x27, 0x56, 0x73, 0x50, 0xbe, 0x72,
-	0xf6, 0xa3, 0x29, 0xab, 0x8e, 0xa5, 0x59, 0xad, 0x95, 0x33, 0x14, 0x59, 0x9b, 0x98, 0xff, 0x60,
-	0x40, 0x49, 0x6d, 0xca, 0x13, 0x4e, 0xb4, 0x00, 0x33, 0xcf, 0xdc, 0xe7, 0xae, 0xb7, 0xe7, 0x96,
-	0x5f, 0x63, 0x17, 0xeb, 0xc2, 0x0a, 0xca, 0x06, 0xbb, 0x78, 0x4c, 0x5c, 0xcb, 0x76, 0xdb, 0xe5,
-	0x14, 0x2a, 0x43, 0x71, 0xd3, 0xb5, 0xa9, 0x8d, 0x1d, 0xfb, 0x2b, 0x76, 0x27, 0xcd, 0x02, 0xda,
-	0x53, 0xbb, 0x43, 0xac, 0x8f, 0x42, 0x5a, 0xce, 0xa0, 0x3c, 0x64, 0x79, 0x9d, 0x54, 0xce, 0xb2,
-	0xd0, 0xb7, 0xe1, 0xed, 0xb9, 0x8e, 0x87, 0x39, 0x6e, 0x8e, 0x05, 0x3b, 0x75, 0x83, 0x58, 0xe5,
-	0x19, 0x86, 0xd9, 0x20, 0xbb, 0xc4, 0xa7, 0xc4, 0x2a, 0xcf, 0x32, 0xca, 0x22, 0xa9, 0xdf, 0xc3,
-	0x36, 0x0b, 0x8e, 0x79, 0x54, 0x82, 0xfc, 0xba, 0xd7, 0xe9, 0x3a, 0x84, 0x01, 0x80, 0x59, 0x86,
-	0xb9, 0x0d, 0x1e, 0x1b, 0x95, 0x95, 0x9b, 0xff, 0x98, 0x86, 0x9c, 0xb8, 0x85, 0xae, 0x41, 0x5e,
-	0x04, 0x4e, 0x5d, 0x33, 0x9f, 0x15, 0xe0, 0x9b, 0xd6, 0x60, 0x60, 0x4d, 0x4d, 0x1c, 0x58, 0x57,
-	0x21, 0x63, 0x77, 0x02, 0x5b, 0xcb, 0x90, 0x39, 0xa4, 0xc0, 0x20, 0xb6, 0x96, 0xd1, 0x72, 0x48,
-	0xf4, 0xc3, 0x44, 0x74, 0x3c, 0x2e, 0xa3, 0xa3, 0x58, 0xfe, 0x40, 0xf5, 0xb3, 0x0a, 0x33, 0xae,
-	0x88, 0x6f, 0xd2, 0x26, 0x8f, 0x49, 0xf8, 0xbe, 0xa8, 0xd7, 0x50, 0x60, 0xe8, 0x42, 0x2c, 0x66,
-	0x0b, 0x5b, 0x3c, 0x1e, 0x85, 0xf8, 0x64, 0x70, 0xe9, 0x45, 0xec, 0x03, 0x54, 0x48, 0x69, 0x38,
-	0x22, 0x76, 0x5b, 0x2c, 0x40, 0x95, 0x4a, 0x0d, 0x38, 0x46, 0xbe, 0xb4, 0x03, 0x6a, 0xbb, 0xed,
-	0xe6, 0xe4, 0xd9, 0x6e, 0x51, 0xe1, 0xae, 0xc7, 0x37, 0x27, 0x61, 0x1a, 0xa9, 0x83, 0x99, 0x46,
-	0x7a, 0x6a, 0xd3, 0xc8, 0x4c, 0x6c, 0x1a, 0x59, 0x6d, 0xd3, 0xb8, 0x2a, 0x4d, 0x23, 0xc7, 0x4d,
-	0xe3, 0x4c, 0xa2, 0x44, 0x4e, 0xe8, 0x77, 0xc0, 0x4e, 0xfe, 0x6f, 0x77, 0xfd, 0xe7, 0x06, 0x14,
-	0x9e, 0x6d, 0x3c, 0x8e, 0xb2, 0xd4, 0x75, 0x00, 0x96, 0xfd, 0x9c, 0x66, 0xd7, 0xf3, 0xa9, 0xdc,
-	0xe1, 0xa1, 0x39, 0xef, 0x42, 0x5d, 0x2c, 0x37, 0xcf, 0xc1, 0x1f, 0x7b, 0x3e, 0x2b, 0xaa, 0x0b,
-	0x3e, 0xe9, 0x78, 0x94, 0x08, 0xe4, 0xd4, 0xfe, 0xc8, 0x20, 0xe0, 0x19, 0xb6, 0xe9, 0x43, 0x71,
-	0xdd, 0x5b, 0xeb, 0x49, 0xb2, 0x0a, 0x99, 0x96, 0x67, 0xe9, 0x1d, 0x75, 0x38, 0x24, 0xc3, 0xe8,
-	0x62, 0xba, 0xa3, 0x57, 0x96, 0x33, 0x48, 0xf3, 0x6f, 0xd3, 0xb0, 0xf0, 0x51, 0x48, 0xbb, 0x21,
-	0xdd, 0xc0, 0x14, 0xcb, 0x48, 0x8c, 0x6e, 0x41, 0x86, 0xbe, 0xe8, 0x0a, 0xce, 0x73, 0xf5, 0x65,
-	0xa9, 0xfd, 0x01, 0x38, 0x79, 0x47, 0x5e, 0x3d, 0x7d, 0xd1, 0x25, 0x0d, 0x8e, 0x87, 0x7e, 0xa0,
-	0x2a, 0x46, 0x29, 0x49, 0x29, 0x11, 0x18, 0x1a, 0xf2, 0x21, 0x5a, 0x82, 0x99, 0x2e, 0x7e, 0xc1,
-	0x42, 0x2f, 0xb7, 0xe1, 0x62, 0x43, 0x5d, 0xa2, 0xab, 0x30, 0xeb, 0x93, 0x16, 0xb1, 0x77, 0xc9,
-	0xe8, 0xa2, 0x3f, 0x5e, 0xb2, 0x44, 0xd0, 0xe8, 0x14, 0xe4, 0xa9, 0x8f, 0xdd, 0x80, 0x6f, 0x40,
-	0x96, 0x6f, 0x76, 0xef, 0x06, 0xba, 0x0c, 0xa5, 0xd0, 0xea, 0x36, 0x3b, 0x84, 0xe2, 0x26, 0xd3,
-	0xb1, 0x0c, 0x44, 0x48, 0x59, 0x67, 0xcf, 0x0e, 0x1a, 0x85, 0xd0, 0xea, 0xb2, 0x0b, 0xb6, 0x5e,
-	0x74, 0x0d, 0xe6, 0x5a, 0x1e, 0x8e, 0x23, 0x0a, 0xc3, 0x3c, 0x12, 0xd5, 0x83, 0xbd, 0x7d, 0x63,
-	0xbe, 0x86, 0x23, 0x54, 0xf3, 0x9a, 0x52, 0x70, 0x4c, 0x4d, 0x2c, 0x7d, 0x85, 0x51, 0x62, 0x2b,
-	0x41, 0xfe, 0x39, 0x21, 0x5d, 0xec, 0xd8, 0xbb, 0xa4, 0x6c, 0xa0, 0x59, 0xc8, 0x30, 0x32, 0xe5,
-	0x94, 0xf9, 0x9b, 0x19, 0x28, 0x0a, 0xdc, 0x75, 0xcf, 0xdd, 0xb6, 0xdb, 0x68, 0x05, 0xd2, 0xa1,
-	0xef, 0x68, 0x19, 0x04, 0x03, 0x44, 0x1b, 0x30, 0xbf, 0x85, 0x03, 0xbb, 0xd5, 0xc4, 0x21, 0xdd,
-	0x69, 0x86, 0x01, 0xf1, 0xb5, 0x4c, 0xa3, 0xc4, 0x91, 0xd6, 0x42, 0xba, 0xf3, 0x2c, 0x20, 0x7e,
-	0x1f, 0x95, 0x2e, 0x0e, 0x02, 0xad, 0x90, 0xd3, 0xa3, 0xf2, 0x18, 0x07, 0x01, 0xab, 0xd7, 0x5a,
-	0x61, 0x40, 0xbd, 0x4e, 0x73, 0x87, 0x60, 0x8b, 0xf8, 0x4d, 0x7e, 0x80, 0xd4, 0x89, 0x40, 0x65,
-	0x81, 0xf7, 0x80, 0xa3, 0x7d, 0xc8, 0x0e, 0x93, 0xbc, 0x92, 0x8c, 0xd3, 0x12, 0xbe, 0x9d, 0xd5,
-	0xab, 0x24, 0x7b, 0xc4, 0xf8, 0x2d, 0xe6, 0x35, 0x3b, 0x5e, 0x40, 0xb5, 0x0a, 0x25, 0x0e, 0xc9,
-	0x0e, 0x05, 0xdc, 0xbe, 0x66, 0xf6, 0x77, 0x70, 0x0e, 0xc8, 0x36, 0x8e, 0x05, 0x24, 0x9d, 0x9a,
-	0x88, 0x87, 0xab, 0x1b, 0x00, 0x64, 0x97, 0x15, 0xca, 0x5c, 0x49, 0x79, 0x0d, 0xb4, 0x3c, 0x87,
-	0xe7, 0xda, 0xb9, 0x05, 0x25, 0x1c, 0x34, 0xed, 0xa0, 0xa9, 0x9c, 0x0b, 0x38, 0x7e, 0x65, 0x00,
-	0xff, 0x8e, 0xe7, 0x39, 0xaa, 0xe4, 0x0f, 0x36, 0x83, 0xc7, 0x3d, 0xe7, 0x23, 0xae, 0xd5, 0xf5,
-	0x6c, 0x97, 0x2e, 0x15, 0x74, 0x52, 0x93, 0x82, 0x46, 0x0f, 0x00, 0xc9, 0x73, 0x64, 0xb3, 0x45,
-	0x7c, 0xda, 0x6c, 0xed, 0x90, 0xd6, 0xf3, 0xa5, 0xe2, 0xbe, 0xec, 0xcb, 0x12, 0x6b, 0x9d, 0xf8,
-	0x74, 0x9d, 0xe1, 0x30, 0x19, 0x98, 0xb9, 0xf2, 0xe5, 0x97, 0x74, 0x64, 0x50, 0xd0, 0x0c, 0x93,
-	0x99, 0xe8, 0x9e, 0xe7, 0x5b, 0x4b, 0x73, 0x3a, 0x98, 0x0a, 0x9a, 0xe5, 0xe4, 0x96, 0x63, 0x33,
-	0xad, 0xdb, 0xd6, 0xd2, 0xbc, 0x0e, 0xaa, 0x00, 0xdf, 0xb4, 0xd8, 0x7e, 0x51, 0xaf, 0x6b, 0xb7,
-	0xc4, 0x7e, 0x95, 0x75, 0xf6, 0x8b, 0xc3, 0xb3, 0xfd, 0x32, 0xff, 0x29, 0x0d, 0x39, 0xe1, 0xe6,
-	0x4c, 0x04, 0x8f, 0xff, 0xd2, 0xae, 0x18, 0x05, 0xf8, 0xe1, 0x54, 0x8c, 0x6f, 0xcb, 0xb0, 0x2f,
-	0xba, 0x0e, 0x28, 0x11, 0xf6, 0x57, 0x62, 0xe1, 0xfd, 0x87, 0x90, 0x6b, 0xf1, 0x80, 0x24, 0xdd,
-	0xf7, 0x48, 0x02, 0x52, 0xc4, 0xaa, 0x86, 0x04, 0x61, 0x07, 0x48, 0xe2, 0xf2, 0xd6, 0x82, 0xf4,
-	0xcf, 0x71, 0x86, 0xa0, 0x40, 0xa3, 0xc2, 0x32, 0x97, 0x28, 0x2c, 0x95, 0x28, 0x87, 0x75, 0xe4,
-	0xbe, 0x0d, 0x19, 0x1e, 0x8e, 0x4b, 0x90, 0x0f, 0x5d, 0x8b, 0x6c, 0xdb, 0x2e, 0x6f, 0x87, 0x14,
-	0x60, 0x66, 0x8f, 0x6c, 0xed, 0x78, 0xde, 0xf3, 0xb2, 0x81, 0x66, 0x20, 0x1d, 0x5a, 0xdd, 0x72,
-	0x8a, 0xc5, 0xe5, 0xce, 0x17, 0x94, 0x96, 0xd3, 0xec, 0x3c, 0x61, 0x6f, 0x53, 0x4a, 0xcb, 0x19,
-	0xf3, 0x17, 0x29, 0xc8, 0x3e, 0xf5, 0x9e, 0x13, 0x57, 0xa4, 0xac, 0xc0, 0x0b, 0xfd, 0x96, 0x5e,
-	0xc6, 0x8e, 0xa0, 0xd1, 0x2a, 0x64, 0xf7, 0x7c, 0x9b, 0xaa, 0x64, 0x39, 0x4e, 0x3f, 0x02, 0x90,
-	0x1d, 0xd0, 0x28, 0x63, 0xaa, 0xd7, 0x4c, 0xe3, 0xa0, 0x68, 0x59, 0x6a, 0x34, 0xc3, 0x35, 0xaa,
-	0x4a, 0x6f, 0x2e, 0xfb, 0xe1, 0x29, 0xf4, 0x9f, 0xb3, 0x90, 0x7b, 0x44, 0xf8, 0x31, 0xf4, 0x12,
-	0xcc, 0x30, 0x9f, 0xd4, 0x35, 0xe4, 0x1c, 0x03, 0x9e, 0xbe, 0x1d, 0xb4, 0x0a, 0x19, 0xdf, 0x73,
-	0xf4, 0xba, 0x8b, 0x1c, 0x32, 0x6a, 0x61, 0x66, 0x26, 0x69, 0x61, 0x92, 0x0e, 0xb6, 0x1d, 0xad,
-	0x3c, 0x23, 0x40, 0x19, 0x4e, 0x77, 0xc7, 0x73, 0x89, 0x56, 0x72, 0x11, 0xa0, 0x2c, 0x98, 0xe0,
-	0x5d, 0x4c, 0xb1, 0xdf, 0x64, 0xc9, 0x5e, 0xe7, 0x0c, 0x9e, 0x17, 0xf0, 0xcf, 0x7c, 0x87, 0x21,
-	0xb7, 0x3c, 0xd7, 0x25, 0x2d, 0x1e, 0x42, 0x74, 0x12, 0x4e, 0x5e, 0xc2, 0x6f, 0x5a, 0xe8, 0x36,
-	0x94, 0xda, 0x36, 0x6d, 0xee, 0x84, 0x5b, 0x4d, 0xc7, 0x6b, 0xdb, 0xae, 0x56, 0xe6, 0x29, 0xb4,
-	0x6d, 0xfa, 0x20, 0xdc, 0x7a, 0xc8, 0x10, 0xd0, 0x1a, 0xcc, 0xed, 0x12, 0x9f, 0xf7, 0x15, 0x9b,
-	0x42, 0x59, 0xfb, 0x27, 0x9f, 0x92, 0xc2, 0xb8, 0xcb, 0x55, 0x16, 0x27, 0x21, 0x74, 0x57, 0xd0,
-	0x27, 0xf1, 0x98, 0x6b, 0xf0, 0x1a, 0xe4, 0x79, 0xad, 0xc2, 0xa3, 0x59, 0x51, 0xc7, 0x19, 0x19,
-	0x38, 0x0b, 0x05, 0xe6, 0x25, 0x00, 0x61, 0xc0, 0x0f, 0xed, 0x80, 0xa2, 0xb3, 0x30, 0xd3, 0xe1,
-	0x57, 0x6a, 0xe0, 0xa1, 0x2a, 0x59, 0x01, 0xd3, 0x50, 0x4f, 0xcd, 0x7f, 0x37, 0x20, 0xf3, 0x94,
-	0xe0, 0x4e, 0xdc, 0x7e, 0x8d, 0x09, 0xec, 0xf7, 0xdd, 0xc4, 0x40, 0xe1, 0xa8, 0xf2, 0x4e, 0x82,
-	0x3b, 0x03, 0xc7, 0xa3, 0x98, 0x4c, 0xe9, 0x71, 0x32, 0x4d, 0xef, 0xc5, 0x5f, 0x67, 0x60, 0x36,
-	0x6a, 0x95, 0x5f, 0x81, 0x59, 0xbb, 0x83, 0xdb, 0xda, 0x3d, 0x8c, 0x19, 0x0e, 0xbd, 0x69, 0xa1,
-	0xcb, 0x30, 0xa3, 0x7a, 0x69, 0x3a, 0x9e, 0xac, 0x80, 0x59, 0x20, 0xdd, 0xb6, 0x1d, 0xc2, 0x9d,
-	0x53, 0xc7, 0x9d, 0x23, 0x68, 0x74, 0x11, 0x72, 0xc1, 0x0e, 0xae, 0x5f, 0xba, 0xac, 0xe5, 0xd4,
-	0x12, 0x16, 0x5d, 0x80, 0x9c, 0x43, 0xdc, 0x36, 0xdd, 0x91, 0x7e, 0x3d, 0xb6, 0x9c, 0x93, 0xa0,
-	0x83, 0xd9, 0x36, 0x37, 0x4d, 0xe3, 0xbb, 0xe5, 0x13, 0x4c, 0x89, 0x35, 0xae, 0x8e, 0x54, 0x9d,
-	0x55, 0x05, 0x8b, 0xce, 0x49, 0x4b, 0x99, 0xe5, 0x7b, 0x7f, 0xa2, 0xef, 0x64, 0x7c, 0x78, 0xa1,
-	0xfc, 0xef, 0x53, 0x70, 0x84, 0xf9, 0x80, 0x9a, 0x1c, 0xaa, 0x6e, 0xc8, 0x21, 0xb4, 0xfc, 0x0f,
-	0xd0, 0xfc, 0x38, 0x0f, 0x59, 0xc7, 0xee, 0xd8, 0x74, 0x5c, 0x33, 0x5a, 0xed, 0x95, 0x80, 0x64,
-	0x28, 0x81, 0xed, 0xb6, 0x54, 0xa4, 0x1f, 0xab, 0x65, 0x01, 0xc9, 0x50, 0x42, 0x97, 0x46, 0x91,
-	0x7e, 0x3c, 0x0a, 0x87, 0x34, 0x1f, 0xc2, 0x62, 0x52, 0x5b, 0x72, 0x60, 0x79, 0x71, 0x60, 0x74,
-	0xbb, 0x34, 0xea, 0x38, 0xdd, 0x9b, 0xd8, 0x9a, 0xbf, 0xcc, 0x42, 0x81, 0x9d, 0xbd, 0x1e, 0xfb,
-	0x1e, 0xb3, 0xee, 0x5e, 0xea, 0x31, 0xa6, 0x48, 0x3d, 0x29, 0xfd, 0xd4, 0x33, 0x18, 0xbe, 0xd3,
-	0x07, 0x0f, 0xdf, 0x99, 0x49, 0xc3, 0x77, 0x32, 0x01, 0x66, 0x27, 0x4b, 0x80, 0x2a, 0xaf, 0xe7,
-	0xb4, 0xf3, 0xfa, 0x4d, 0x28, 0x74, 0x85, 0x9e, 0xb5, 0x13, 0x2e, 0x48, 0x04, 0xc6, 0xf0, 0x03,
-	0x28, 0xb6, 0x6d, 0xda, 0xcb, 0x99, 0x0d, 0xcd, 0x9c, 0xb9, 0xa3, 0x72, 0x26, 0x3b, 0xb1, 0xf8,
-	0xde, 0xae, 0x6d, 0x11, 0x5f, 0x2b, 0xe1, 0x46, 0xd0, 0x4c, 0x51, 0x8e, 0xd7, 0xf6, 0x42, 0xca,
-	0x05, 0x07, 0x1d, 0x45, 0x09, 0xf8, 0xc1, 0x4a, 0xa1, 0x30, 0x51, 0xa5, 0x60, 0xfe, 0x0e, 0x1c,
-	0xdf, 0x20, 0x0e, 0xa1, 0xa4, 0xd7, 0xd5, 0x3c, 0xbc, 0x00, 0x61, 0x1e, 0x87, 0xa3, 0xcc, 0x99,
-	0x06, 0x68, 0x9b, 0x8f, 0xe0, 0x58, 0xff, 0x03, 0xe9, 0x67, 0x17, 0xa0, 0xd0, 0x23, 0xa1, 0x5c,
-	0x6d, 0x61, 0x60, 0x5c, 0xd7, 0x88, 0x43, 0x99, 0x9f, 0xc3, 0x89, 0x06, 0xa1, 0xbe, 0x4d, 0x76,
-	0xbf, 0x9b, 0x75, 0xfc, 0xb5, 0x01, 0x8b, 0xd2, 0xb9, 0x9f, 0x50, 0x9f, 0xe0, 0xce, 0xf7, 0x22,
-	0x88, 0x9a, 0x7f, 0x66, 0x40, 0x29, 0xd9, 0xe2, 0xfe, 0xed, 0xca, 0xf3, 0x29, 0x20, 0xb6, 0xab,
-	0x42, 0xa4, 0x43, 0x4c, 0x34, 0xe6, 0x2d, 0x91, 0xc2, 0x22, 0xc2, 0xd2, 0x56, 0xce, 0xc2, 0x8c,
-	0xe0, 0xdd, 0x5f, 0xd5, 0x49, 0xa5, 0xa8, 0xa7, 0xe6, 0x29, 0xa8, 0xac, 0x3b, 0x04, 0xfb, 0x2a,
-	0xbb, 0xf2, 0x29, 0x92, 0x22, 0x63, 0xfe, 0x67, 0x0a, 0xd0, 0x13, 0xe2, 0x5a, 0x2a, 0x7c, 0x7f,
-	0x2f, 0x12, 0xa4, 0x6a, 0x4d, 0xa5, 0x75, 0x5b, 0x53, 0xb1, 0x26, 0x6c, 0x26, 0xd9, 0x84, 0xbd,
-	0xde, 0xdf, 0x4a, 0xdd, 0xbf, 0xa7, 0x11, 0x35, 0x5a, 0xaf, 0x41, 0x9e, 0x37, 0x4c, 0x79, 0x3b,
-	0x3a, 0xa7, 0xd5, 0x4b, 0xf1, 0x70, 0xf7, 0x31, 0xa6, 0x3b, 0xe6, 0x51, 0x38, 0x92, 0xd0, 0xaa,
-	0xd4, 0xf6, 0x1f, 0x1b, 0xb0, 0xa0, 0x7c, 0x89, 0xb8, 0x56, 0x83, 0x04, 0xa1, 0x43, 0x0f, 0x32,
-	0x62, 0xbb, 0xcc, 0xea, 0x68, 0x31, 0xab, 0xd4, 0xaa, 0x4f, 0x25, 0xb0, 0xf9, 0x25, 0x2c, 0x3d,
-	0x0a, 0x1d, 0x6a, 0x0f, 0x11, 0x12, 0xad, 0x42, 0x8e, 0x30, 0x1b, 0xe9, 0xcf, 0xf5, 0x03, 0x82,
-	0x37, 0x24, 0x1c, 0x42, 0x90, 0x09, 0x88, 0x2b, 0x66, 0x05, 0xd9, 0x06, 0xff, 0x8d, 0x8e, 0x41,
-	0x6e, 0x9b, 0xcf, 0x1b, 0xf9, 0x2e, 0x66, 0x1b, 0xf2, 0x8a, 0xf9, 0xed, 0x7c, 0xf4, 0x8a, 0xc2,
-	0xe1, 0x59, 0x5b, 0xbc, 0xc2, 0x4f, 0x4d, 0x50, 0xe1, 0x9b, 0x3f, 0x16, 0xee, 0x75, 0xf8, 0x22,
-	0x99, 0x1f, 0x88, 0x6a, 0xaa, 0x47, 0x39, 0xf2, 0xdc, 0x1c, 0x67, 0xae, 0xf4, 0x3b, 0xdf, 0x57,
-	0xfe, 0x36, 0xe4, 0x63, 0x66, 0x2d, 0x47, 0xd5, 0xcd, 0x67, 0x89, 0x2d, 0x9a, 0xfa, 0x3c, 0x53,
-	0x81, 0x59, 0xf1, 0xe2, 0x00, 0xb1, 0xf8, 0x31, 0x2d, 0xdf, 0x88, 0xae, 0x99, 0x13, 0xc9, 0x37,
-	0x14, 0xf8, 0x99, 0x2c, 0xdf, 0x50, 0x97, 0xe6, 0xb7, 0x29, 0x38, 0xba, 0xce, 0x4b, 0xf7, 0xef,
-	0x60, 0xe7, 0x16, 0x21, 0xcb, 0xa5, 0xe3, 0xdb, 0x56, 0x6c, 0x88, 0x8b, 0xf8, 0xc1, 0x2b, 0x3d,
-	0xed, 0xc1, 0x2b, 0x33, 0xd1, 0xc1, 0xeb, 0x7a, 0x62, 0x0c, 0xfc, 0xb6, 0xca, 0xba, 0xc3, 0x96,
-	0x7d, 0x78, 0x07, 0x94, 0x3f, 0x30, 0x44, 0xda, 0x10, 0x75, 0x74, 0xb4, 0xbf, 0x87, 0xa0, 0xd6,
-	0xb3, 0x30, 0x23, 0xda, 0xaa, 0xea, 0x3c, 0x5e, 0x4a, 0x94, 0xec, 0x0d, 0xf5, 0xd4, 0xfc, 0x04,
-	0x16, 0xe2, 0x12, 0x1c, 0x9a, 0xf9, 0xb3, 0x04, 0x7d, 0xd8, 0x44, 0x93, 0xbd, 0xe5, 0xd4, 0x24,
-	0xbd, 0x65, 0xf3, 0x57, 0x06, 0xcc, 0x09, 0x79, 0x1e, 0x7a, 0x6d, 0xb1, 0x53, 0xab, 0x90, 0xa1,
-	0x76, 0x67, 0x74, 0xab, 0x33, 0x3e, 0x9d, 0xe3, 0x90, 0xd3, 0xc6, 0x5b, 0xe6, 0xb0, 0x3e, 0xe9,
-	0x8a, 0x83, 0xb2, 0x46, 0x56, 0x8b, 0x80, 0xcd, 0x2b, 0x00, 0x91, 0xd0, 0x01, 0x7a, 0x17, 0x32,
-	0x8e, 0x17, 0xbd, 0xb8, 0x7a, 0x34, 0xb1, 0xa3, 0x6a, 0x55, 0x0d, 0x0e, 0x62, 0xfe, 0x2a, 0xad,
-	0xe6, 0x6e, 0x4f, 0x28, 0xa6, 0x61, 0xf0, 0xdb, 0xd5, 0x7e, 0xbc, 0x83, 0x9e, 0xd6, 0xef, 0xa0,
-	0xbf, 0x0f, 0x05, 0x9e, 0x62, 0x9a, 0x2d, 0x2f, 0x74, 0xe9, 0xb8, 0xc3, 0x6f, 0x34, 0x8b, 0xe6,
-	0xf0, 0xeb, 0x0c, 0x9c, 0x89, 0xbb, 0xed, 0xf9, 0x7b, 0xd8, 0xb7, 0xa2, 0xbe, 0xfd, 0xf8, 0x21,
-	0x78, 0x04, 0x2d, 0xf6, 0x4b, 0xce, 0x6e, 0x73, 0x5a, 0xfb, 0x25, 0x47, 0xb7, 0x37, 0xa1, 0xe0,
-	0x13, 0x5e, 0x42, 0x74, 0x6c, 0x1a, 0xe8, 0x0c, 0xd7, 0xe2, 0xf0, 0xe6, 0xb7, 0x06, 0xe4, 0xa3,
-	0x37, 0xff, 0xd0, 0x8a, 0x7c, 0xc5, 0xc1, 0xd8, 0x57, 0x63, 0xe2, 0x05, 0x87, 0x15, 0xf9, 0x82,
-	0x43, 0x4a, 0x07, 0x9e, 0xd8, 0xa8, 0x0e, 0xb9, 0x4e, 0x60, 0x07, 0x96, 0xab, 0xb1, 0x27, 0x12,
-	0x12, 0x5d, 0x86, 0x59, 0xfe, 0x62, 0x1d, 0x8b, 0xcc, 0xfb, 0x1f, 0x8a, 0x23, 0x58, 0xf3, 0x08,
-	0x2c, 0x3c, 0x79, 0x11, 0x50, 0xd2, 0xd9, 0x74, 0xb7, 0x3d, 0x75, 0x14, 0xfa, 0x57, 0x56, 0x7d,
-	0xc6, 0xee, 0xca, 0xf0, 0x17, 0x0b, 0xfe, 0xc6, 0x24, 0xc1, 0xff, 0x06, 0xc0, 0x56, 0x68, 0x3b,
-	0x56, 0xd3, 0xc2, 0x54, 0xcf, 0x41, 0xf3, 0x1c, 0x7e, 0x03, 0x53, 0xc2, 0x8e, 0xc0, 0x3e, 0x71,
-	0x08, 0x0e, 0x48, 0x53, 0xbb, 0x6d, 0x57, 0x90, 0x18, 0x72, 0x64, 0x89, 0x2c, 0xb2, 0x8d, 0x43,
-	0x87, 0x36, 0x63, 0x6f, 0x75, 0x66, 0x46, 0xbc, 0xd5, 0x59, 0x96, 0xb0, 0xbd, 0xdd, 0x7e, 0x1f,
-	0x16, 0xb6, 0x3d, 0xbf, 0x45, 0xac, 0x38, 0x7a, 0x76, 0x04, 0xfa, 0xbc, 0x00, 0x8d, 0x6e, 0x98,
-	0x7f, 0x67, 0x40, 0x79, 0x23, 0xec, 0x74, 0x89, 0x15, 0x7b, 0xb5, 0xf5, 0x7c, 0xfc, 0x2d, 0x58,
-	0xa9, 0xcb, 0x21, 0xe7, 0xc9, 0x18, 0x10, 0x3a, 0xd7, 0x3b, 0x57, 0x88, 0xbc, 0xa1, 0x06, 0x63,
-	0x82, 0x78, 0xdf, 0xe9, 0x22, 0x9e, 0x66, 0xd2, 0x63, 0xd3, 0x4c, 0x0b, 0x8a, 0x71, 0x0a, 0xb1,
-	0xd7, 0x2b, 0x8c, 0x71, 0xaf, 0x57, 0xbc, 0x27, 0x5e, 0x24, 0x90, 0xb2, 0x8c, 0x6e, 0x3b, 0x71,
-	0x28, 0x73, 0x01, 0xe6, 0xd9, 0x4d, 0xc6, 0x48, 0x99, 0xd8, 0xbf, 0x30, 0xbd, 0x44, 0xf7, 0xa4,
-	0x81, 0x5d, 0x1b, 0x76, 0xd0, 0x3e, 0x9e, 0x58, 0xe8, 0x88, 0xe3, 0x36, 0x7a, 0x0f, 0x66, 0x64,
-	0xdf, 0x44, 0x1a, 0x58, 0xf4, 0xde, 0x45, 0xaf, 0xd5, 0xd5, 0x50, 0x20, 0xe8, 0x34, 0x64, 0x29,
-	0xc1, 0x1d, 0xa5, 0x9c, 0x42, 0xac, 0x27, 0xde, 0x10, 0x4f, 0xd0, 0x19, 0xc8, 0xf1, 0xe1, 0x96,
-	0x9a, 0x6a, 0x15, 0xe3, 0x53, 0xad, 0x86, 0x7c, 0x66, 0x2e, 0x02, 0x8a, 0x33, 0x90, 0x8b, 0xdb,
-	0x80, 0xc2, 0xd3, 0xd8, 0x89, 0x7c, 0xba, 0xbe, 0x3d, 0xd3, 0x1a, 0xab, 0x00, 0x62, 0x94, 0xcc,
-	0x73, 0x30, 0xcb, 0x2e, 0xf9, 0xfc, 0x20, 0x5a, 0x83, 0x31, 0x6a, 0x0d, 0xe6, 0x2b, 0x28, 0xc9,
-	0xc6, 0xfd, 0x81, 0x24, 0x89, 0xcf, 0xdb, 0x52, 0xfa, 0xf3, 0x36, 0x73, 0x0f, 0x72, 0x9b, 0xee,
-	0xae, 0x4d, 0xc9, 0x14, 0xaf, 0x1b, 0xdd, 0x00, 0x90, 0x5d, 0x69, 0xdd, 0x57, 0x8b, 0xf3, 0x12,
-	0x7e, 0x8d, 0x9a, 0x97, 0x00, 0x04, 0x63, 0x35, 0x68, 0xb1, 0xf9, 0x55, 0xff, 0x91, 0x5c, 0xc0,
-	0x34, 0xd4, 0x53, 0xf3, 0x4b, 0x28, 0xc9, 0x5b, 0x07, 0x53, 0x97, 0x5a, 0x6d, 0x4a, 0x77, 0xb5,
-	0xe6, 0x7d, 0x38, 0xb2, 0xd6, 0x6a, 0x91, 0x2e, 0x4d, 0xf2, 0x9f, 0x58, 0x6d, 0xe6, 0x31, 0x58,
-	0x14, 0xbd, 0x33, 0x45, 0x48, 0x9e, 0x70, 0x1f, 0x00, 0x12, 0xf7, 0x85, 0xf9, 0x4a, 0xfa, 0xd1,
-	0xac, 0xd7, 0xd0, 0x9e, 0xf5, 0xb2, 0x23, 0x74, 0x82, 0x92, 0x64, 0x80, 0xa0, 0xcc, 0x8d, 0x35,
-	0x46, 0xde, 0x3c, 0x0f, 0x79, 0x7e, 0xcd, 0x77, 0xa1, 0xe7, 0x4f, 0xc6, 0x18, 0x7f, 0xba, 0x03,
-	0xc5, 0x83, 0x4a, 0x58, 0xff, 0xe5, 0x06, 0x64, 0x1f, 0x78, 0xbe, 0x45, 0xd0, 0xc7, 0x50, 0x16,
-	0x07, 0x85, 0x58, 0xec, 0x1d, 0x8c, 0xb3, 0x95, 0xc1, 0x5b, 0xe6, 0xf1, 0xaf, 0x7f, 0xfd, 0x9b,
-	0xbf, 0x4a, 0x2d, 0x98, 0xc5, 0x5a, 0x2c, 0xc8, 0x5c, 0x37, 0x96, 0x11, 0x56, 0xdf, 0xfb, 0x4c,
-	0x4c, 0xf2, 0x2c, 0x27, 0x79, 0xba, 0x7e, 0x2a, 0x4e, 0xb2, 0xf6, 0x32, 0x51, 0xfc, 0xbd, 0x62,
-	0x2c, 0x9e, 0x43, 0xb9, 0xbf, 0xff, 0x89, 0xde, 0x88, 0xc2, 0xf0, 0xd0, 0xc6, 0xe8, 0x30, 0x7e,
-	0x67, 0x38, 0xbf, 0x37, 0x96, 0xc7, 0xf2, 0x43, 0x96, 0x08, 0x32, 0xeb, 0xb1, 0x50, 0xaa, 0x3e,
-	0xbc, 0x1a, 0xda, 0x26, 0xad, 0xbc, 0x3e, 0xe2, 0xa9, 0xb4, 0x83, 0x45, 0xce, 0x75, 0x0e, 0x25,
-	0x14, 0x87, 0x3c, 0x40, 0x83, 0xcd, 0x50, 0x54, 0x95, 0xa4, 0x46, 0xf6, 0x49, 0xc7, 0x2c, 0x0b,
-	0x8d, 0x5f, 0xd6, 0xef, 0xf5, 0x37, 0x73, 0xd5, 0xf0, 0x04, 0x55, 0x62, 0xf2, 0xf7, 0xcd, 0x9f,
-	0x2a, 0x27, 0x87, 0x3e, 0x93, 0x2b, 0x7b, 0x97, 0x33, 0x7e, 0x0b, 0x9d, 0x1e, 0xc7, 0xb8, 0xc6,
-	0xdf, 0xae, 0xfc, 0x0a, 0xca, 0x77, 0x7c, 0x0f, 0x5b, 0x2d, 0x1c, 0xd1, 0x41, 0x6a, 0x9a, 0x36,
-	0xd8, 0xd5, 0xab, 0xbc, 0xa9, 0x3a, 0x39, 0x23, 0x5a, 0x3f, 0xe6, 0x32, 0x67, 0x7d, 0xc6, 0x7c,
-	0x73, 0x2c, 0x6b, 0xea, 0x31, 0xeb, 0xf9, 0x51, 0xf4, 0x41, 0x9e, 0x68, 0x0b, 0xa3, 0x93, 0x7d,
-	0x7d, 0xa2, 0x78, 0xb3, 0xb8, 0x32, 0x32, 0x73, 0x9b, 0xaf, 0xad, 0x1a, 0x68, 0x5b, 0x9c, 0x82,
-	0x7b, 0x5a, 0x64, 0xa7, 0xe9, 0xc8, 0xdc, 0x7b, 0x5f, 0x7c, 0x55, 0xd0, 0xe0, 0xb7, 0x7a, 0x9a,
-	0xfa, 0xe2, 0x63, 0xe7, 0x2f, 0x60, 0xb1, 0xdf, 0xa9, 0x38, 0xa7, 0xe3, 0x23, 0x3e, 0x7e, 0x1b,
-	0xca, 0xef, 0x3d, 0xce, 0xef, 0xed, 0xfa, 0xfe, 0xfc, 0x98, 0x9a, 0xba, 0x50, 0xbe, 0x4f, 0x92,
-	0x2b, 0x1b, 0xb6, 0xb0, 0xe3, 0xbd, 0x5b, 0x89, 0x8f, 0x05, 0xcd, 0x55, 0xce, 0x6d, 0x19, 0xbd,
-	0xb3, 0x2f, 0xb7, 0xda, 0x4b, 0x56, 0xb7, 0xbe, 0x42, 0x81, 0x0a, 0x9c, 0x07, 0x66, 0xba, 0xac,
-	0xcf, 0xf4, 0x2b, 0xf5, 0xda, 0xf9, 0xf4, 0x4c, 0xaf, 0x70, 0xa6, 0xe7, 0xeb, 0xda, 0x4c, 0xaf,
-	0xcb, 0x4f, 0xec, 0x7e, 0x0a, 0x45, 0x11, 0x7d, 0x65, 0x69, 0x99, 0x2c, 0x25, 0x2b, 0xc9, 0x4b,
-	0xb3, 0xc6, 0xd9, 0xbc, 0x6b, 0x9e, 0x19, 0xef, 0x5e, 0xa2, 0xbe, 0x65, 0x3b, 0xe8, 0xc1, 0x9c,
-	0x0a, 0x1c, 0x92, 0xc1, 0x62, 0xb2, 0x56, 0x95, 0x0b, 0xeb, 0xe3, 0x73, 0x95, 0xf3, 0xa9, 0xa3,
-	0x55, 0x1d, 0x3e, 0xb5, 0x97, 0x51, 0x07, 0xf8, 0x15, 0xfa, 0x7d, 0xf5, 0xc1, 0x86, 0x64, 0x57,
-	0x19, 0xfd, 0xde, 0x79, 0x3f, 0xd3, 0x0d, 0xce, 0xf4, 0x56, 0xfd, 0x5a, 0x92, 0xe9, 0xf0, 0x57,
-	0xff, 0x87, 0x72, 0x67, 0x2b, 0xee, 0x40, 0x51, 0x58, 0xd0, 0x14, 0xeb, 0x5d, 0x9e, 0x7c, 0xbd,
-	0x3e, 0x14, 0x62, 0x13, 0x8e, 0x28, 0x80, 0x0d, 0x8e, 0x53, 0x2a, 0x95, 0x61, 0x8f, 0x92, 0x6e,
-	0x89, 0xb4, 0xf6, 0x15, 0xfd, 0xc2, 0x88, 0xcf, 0x6b, 0x0e, 0x1e, 0xb4, 0x6f, 0x72, 0xee, 0x57,
-	0xd0, 0xa5, 0x49, 0x57, 0x2f, 0x02, 0xf9, 0x37, 0x06, 0x14, 0x62, 0x01, 0x79, 0x5c, 0x10, 0xaf,
-	0x0c, 0x7b, 0x24, 0xa5, 0xb8, 0xc5, 0xa5, 0xb8, 0x6a, 0x5e, 0x98, 0x58, 0x0a, 0x11, 0xd3, 0xff,
-	0xc2, 0x00, 0x34, 0x38, 0x2c, 0x1a, 0xb1, 0xff, 0xea, 0x8b, 0xe1, 0x31, 0xd3, 0xa5, 0xdb, 0x5c,
-	0x9e, 0xeb, 0xcb, 0x57, 0x27, 0x96, 0x67, 0x7b, 0x8f, 0x37, 0x72, 0xd0, 0x1e, 0xcc, 0xf5, 0xb6,
-	0x69, 0x92, 0xac, 0x20, 0x55, 0x81, 0x2e, 0xeb, 0xb1, 0xee, 0x7d, 0x17, 0x2c, 0x53, 0xc5, 0xd7,
-	0x86, 0x2a, 0xc0, 0x62, 0xbc, 0x27, 0xca, 0x13, 0x6b, 0x5c, 0x82, 0x1b, 0xf5, 0x29, 0x25, 0x60,
-	0xfb, 0xf1, 0x87, 0x06, 0x14, 0xef, 0x93, 0xde, 0xea, 0x27, 0x8a, 0xa7, 0x77, 0x39, 0xff, 0x0f,
-	0xd0, 0xcd, 0xe9, 0xf8, 0xab, 0xc8, 0xfe, 0x8d, 0x01, 0xf3, 0xf1, 0x68, 0x30, 0xa5, 0x18, 0xcb,
-	0x07, 0x14, 0xe3, 0x4f, 0x0d, 0x98, 0xef, 0xdb, 0x8f, 0x89, 0xc4, 0x78, 0xc8, 0xc5, 0xb8, 0x57,
-	0x3f, 0x98, 0x18, 0x2a, 0xe5, 0x7c, 0x01, 0x73, 0xc9, 0xc9, 0x40, 0x54, 0xcc, 0x0e, 0x1d, 0x18,
-	0x54, 0xfa, 0x67, 0x3c, 0x2a, 0xc3, 0x9a, 0x3f, 0x18, 0x2b, 0x8e, 0xfa, 0x32, 0x88, 0xd9, 0x42,
-	0x08, 0x65, 0x95, 0x86, 0x22, 0xa6, 0xc7, 0xfa, 0x47, 0x47, 0xa3, 0xd8, 0xe9, 0x25, 0x23, 0xc5,
-	0xae, 0xf6, 0x52, 0x4d, 0x97, 0x5e, 0xb1, 0xec, 0x27, 0xbf, 0x1e, 0x54, 0x4c, 0xfb, 0x89, 0x0f,
-	0x72, 0xbb, 0xc1, 0xb9, 0x5d, 0xaa, 0x4f, 0xcc, 0x8d, 0xad, 0x33, 0x80, 0x39, 0x61, 0x6e, 0x53,
-	0xaf, 0x72, 0x79, 0xf2, 0x55, 0xee, 0x42, 0x31, 0x3e, 0xab, 0x4b, 0xe4, 0x81, 0x7e, 0xb6, 0x27,
-	0x87, 0x3e, 0x93, 0x66, 0x76, 0x8e, 0x8b, 0x70, 0x16, 0xe9, 0xed, 0x2b, 0xfa, 0x59, 0xec, 0x73,
-	0x51, 0x3e, 0xe2, 0x1b, 0xb9, 0xd8, 0x53, 0x7d, 0xf7, 0x9f, 0x0d, 0x0b, 0xfc, 0xfb, 0xc4, 0x9a,
-	0x21, 0x2b, 0xaf, 0x85, 0x9c, 0xeb, 0x57, 0xe2, 0x54, 0xad, 0x88, 0x4f, 0x12, 0x68, 0x3f, 0xe0,
-	0xac, 0xaf, 0xa1, 0x2b, 0xba, 0xac, 0xfb, 0x23, 0xed, 0xcf, 0x0c, 0x40, 0x49, 0x13, 0x9b, 0x3c,
-	0xd6, 0xde, 0xe1, 0x42, 0xbc, 0x5f, 0x9f, 0x56, 0x08, 0x66, 0x78, 0xdf, 0x18, 0x30, 0x77, 0x9f,
-	0xc4, 0x75, 0x30, 0x51, 0x80, 0xb9, 0xc7, 0x45, 0xb8, 0x8d, 0x6e, 0x4d, 0x29, 0x82, 0x0a, 0x74,
-	0x3f, 0x37, 0x60, 0x21, 0xe9, 0x00, 0x53, 0x4a, 0xb2, 0x7c, 0x50, 0x49, 0xfe, 0xdc, 0x80, 0x85,
-	0x81, 0x8d, 0x99, 0x48, 0x92, 0x47, 0x5c, 0x92, 0xfb, 0xf5, 0x03, 0x4a, 0x32, 0x50, 0xe8, 0xcb,
-	0x2f, 0x4d, 0x92, 0xbd, 0xe6, 0x4a, 0xf2, 0x52, 0xb3, 0xd0, 0x97, 0xfd, 0xe9, 0xbe, 0x42, 0x5f,
-	0x32, 0x58, 0x4c, 0x36, 0xb3, 0xfb, 0x0a, 0x5f, 0xc9, 0x47, 0x2f, 0xb6, 0x4a, 0x3e, 0xb5, 0x97,
-	0xd1, 0x04, 0xed, 0x15, 0xb2, 0x55, 0xa1, 0xaf, 0xb5, 0x1e, 0xbd, 0xa8, 0x3a, 0x84, 0x4f, 0xa2,
-	0xa4, 0x9f, 0x62, 0x65, 0xcb, 0x93, 0xaf, 0xac, 0x2b, 0x4a, 0x7a, 0x41, 0x27, 0x40, 0x4b, 0xb1,
-	0x90, 0x99, 0xe4, 0x78, 0x62, 0xc8, 0x93, 0x89, 0x0a, 0x7a, 0xc9, 0x1d, 0xb9, 0x90, 0xe1, 0x23,
-	0xd2, 0xe1, 0x0b, 0x5b, 0xe8, 0x1f, 0x95, 0x06, 0x9a, 0x15, 0xfb, 0x90, 0xc5, 0xd5, 0x1c, 0xc6,
-	0x87, 0x42, 0x4e, 0x0e, 0x56, 0x87, 0x73, 0x4c, 0x7e, 0x4f, 0x24, 0x40, 0x35, 0x63, 0xe5, 0x30,
-	0x9e, 0x81, 0xe0, 0x25, 0xcb, 0x61, 0x41, 0xf4, 0xf0, 0xcb, 0xe1, 0x88, 0xf3, 0x98, 0x72, 0x38,
-	0xc6, 0xfb, 0x3b, 0x28, 0x87, 0x47, 0x4a, 0x10, 0x2b, 0x87, 0x23, 0x09, 0xbe, 0x83, 0x72, 0x78,
-	0x24, 0xff, 0xc1, 0x72, 0xf8, 0x40, 0x62, 0x2c, 0x1f, 0x50, 0x8c, 0x5e, 0x39, 0x3c, 0x9d, 0x18,
-	0x7a, 0xe5, 0xf0, 0x7e, 0x62, 0xa8, 0xc0, 0xfc, 0x0c, 0x4a, 0xf7, 0x09, 0xed, 0x4d, 0x72, 0x23,
-	0x87, 0x1f, 0x18, 0xf9, 0x46, 0x0e, 0x3f, 0x38, 0xf6, 0x35, 0xe7, 0xb9, 0x4c, 0x79, 0x34, 0x53,
-	0x0b, 0xf8, 0x43, 0xf4, 0x31, 0xcc, 0xaa, 0xd1, 0x5d, 0x54, 0x17, 0xf5, 0xcd, 0xf7, 0xa2, 0x15,
-	0xf6, 0xcf, 0xf8, 0x54, 0x83, 0xd8, 0xcc, 0xf3, 0x13, 0xb6, 0x15, 0x76, 0xba, 0xcc, 0x84, 0x3e,
-	0xe6, 0x39, 0x3e, 0xfe, 0x5a, 0xfa, 0x89, 0x21, 0xf3, 0xbb, 0x3e, 0x33, 0x8e, 0x3d, 0x32, 0xcb,
-	0x9c, 0x2c, 0xa0, 0xd9, 0x9a, 0x9a, 0xf1, 0x5d, 0x03, 0x10, 0x59, 0x89, 0x7f, 0x3b, 0x13, 0x1f,
-	0x8f, 0x55, 0xe2, 0x17, 0xe6, 0x02, 0xc7, 0x2c, 0x98, 0xb9, 0x1a, 0x1f, 0x9a, 0x31, 0x69, 0x36,
-	0xa1, 0xa8, 0x32, 0x0e, 0x47, 0x46, 0xf1, 0xd9, 0x9a, 0x14, 0x22, 0x41, 0x63, 0x89, 0xd3, 0x40,
-	0xa8, 0x2c, 0x68, 0xd4, 0x5e, 0xca, 0xb1, 0xd1, 0x2b, 0xf4, 0x39, 0x1c, 0x89, 0x93, 0x12, 0xe3,
-	0xb8, 0x60, 0x28, 0xc5, 0x85, 0xc4, 0xb7, 0x36, 0x2c, 0x9e, 0x98, 0x55, 0x4e, 0xb7, 0x82, 0x96,
-	0xfa, 0xe9, 0xd6, 0xe4, 0x87, 0x38, 0x08, 0xf7, 0x92, 0xa3, 0xfc, 0x38, 0x6e, 0x31, 0xf9, 0xc9,
-	0x4e, 0x5f, 0x0a, 0x11, 0x77, 0x55, 0x47, 0x19, 0x99, 0xa3, 0x08, 0xd7, 0x5e, 0xca, 0x89, 0xdf,
-	0x2b, 0xf4, 0xff, 0x55, 0x3a, 0x94, 0x0c, 0x92, 0xa4, 0xfa, 0x29, 0xcb, 0x4a, 0xbb, 0xae, 0x41,
-	0x99, 0xa9, 0xba, 0xa9, 0x12, 0xe0, 0x14, 0xd2, 0x2f, 0xeb, 0x48, 0xbf, 0x0e, 0x20, 0xe3, 0xe0,
-	0x78, 0x33, 0x38, 0xc9, 0x69, 0x1e, 0xad, 0x0f, 0x6c, 0x21, 0x93, 0xf2, 0x3e, 0x80, 0x1c, 0x7a,
-	0x4d, 0x62, 0x0e, 0xcb, 0x83, 0xe6, 0xb0, 0x01, 0x79, 0x35, 0xd3, 0x0d, 0x22, 0xdf, 0xe9, 0x9b,
-	0xf2, 0x46, 0x07, 0x28, 0x35, 0xea, 0x35, 0xe7, 0x38, 0xbd, 0x59, 0x24, 0x4d, 0x14, 0x7d, 0xc6,
-	0xbc, 0xc5, 0x25, 0x3e, 0x56, 0x73, 0xbe, 0x48, 0x6d, 0x89, 0xf9, 0x61, 0x25, 0x39, 0xe8, 0x34,
-	0xdf, 0xe2, 0x64, 0x5e, 0x37, 0x07, 0xad, 0x49, 0x4e, 0x40, 0xd9, 0x52, 0x3f, 0x11, 0x25, 0x82,
-	0x40, 0x19, 0x6f, 0xa8, 0xbd, 0x19, 0xeb, 0x18, 0x43, 0x95, 0xa4, 0xd1, 0xe7, 0x3d, 0x43, 0x9d,
-	0x44, 0x66, 0x39, 0x35, 0x43, 0x6f, 0x8e, 0x22, 0xcc, 0x82, 0xa3, 0x45, 0x5e, 0xa1, 0x8f, 0xa1,
-	0x18, 0x1f, 0xa1, 0x46, 0x47, 0xc5, 0x21, 0x73, 0xd5, 0xa1, 0x9b, 0x65, 0x96, 0x24, 0x07, 0xcc,
-	0x11, 0x98, 0x2a, 0x7e, 0x57, 0xd9, 0xe6, 0x58, 0x81, 0x4f, 0x26, 0x46, 0x73, 0x7d, 0x73, 0x57,
-	0x29, 0xfe, 0xf2, 0xbe, 0xe2, 0x7f, 0x2a, 0x4e, 0xba, 0x4c, 0xa2, 0x49, 0xea, 0x87, 0x01, 0xbd,
-	0x0f, 0x54, 0x08, 0x5b, 0xaa, 0x51, 0x10, 0x91, 0x9e, 0xa8, 0x3c, 0x90, 0x36, 0x53, 0x1f, 0xc9,
-	0x40, 0x0c, 0x45, 0xe1, 0x3e, 0x51, 0xb2, 0x4f, 0x94, 0xef, 0x06, 0xb6, 0x77, 0x54, 0x62, 0xb5,
-	0xa0, 0xd4, 0xf3, 0xc0, 0x29, 0xb9, 0x2c, 0xef, 0xcb, 0xe5, 0x39, 0x94, 0x12, 0xca, 0x9a, 0x88,
-	0xcb, 0x0a, 0xe7, 0xf2, 0x4e, 0x7d, 0x3f, 0x2e, 0x2a, 0x3b, 0xdf, 0x84, 0x82, 0x4c, 0x50, 0xfc,
-	0x23, 0xea, 0xc4, 0x40, 0xbc, 0x92, 0xb8, 0x32, 0x11, 0x27, 0x5d, 0x34, 0x67, 0x6a, 0x62, 0x4e,
-	0xce, 0x94, 0xfe, 0x53, 0x28, 0xc4, 0x06, 0xf1, 0x51, 0xbe, 0x1c, 0x1c, 0xf3, 0x47, 0xad, 0xe9,
-	0x61, 0x73, 0x7b, 0x39, 0xe8, 0x5e, 0x9e, 0x97, 0x94, 0x6b, 0x2f, 0xf9, 0xdf, 0x57, 0xe8, 0x01,
-	0x40, 0x34, 0xd0, 0xef, 0xd9, 0x4c, 0xff, 0x8c, 0xbf, 0x52, 0x8e, 0xcb, 0xc9, 0x43, 0x41, 0xaf,
-	0x5c, 0x10, 0x14, 0xd1, 0xff, 0x83, 0x52, 0x94, 0x02, 0xb9, 0xa8, 0x47, 0x12, 0xa3, 0x7f, 0x49,
-	0x28, 0xb9, 0x60, 0x29, 0x16, 0x1a, 0x10, 0xeb, 0x2e, 0x14, 0xe4, 0x0e, 0xed, 0xab, 0xb4, 0x0a,
-	0xa7, 0xb1, 0x58, 0xef, 0xa7, 0xc1, 0x94, 0xf7, 0x13, 0x28, 0x45, 0x4b, 0x99, 0xc4, 0xdf, 0x4e,
-	0x73, 0x9a, 0x27, 0xd1, 0x89, 0x88, 0xe6, 0x80, 0xc3, 0x59, 0xaa, 0x02, 0xec, 0x11, 0x9f, 0xc8,
-	0xe3, 0xe4, 0x80, 0xbb, 0x3e, 0x9a, 0x05, 0x5b, 0x40, 0x0b, 0x0a, 0xcc, 0xe5, 0x24, 0x8b, 0x89,
-	0xec, 0xf4, 0x1d, 0xce, 0xc0, 0x44, 0xd5, 0x91, 0x0c, 0x94, 0x3b, 0x6c, 0xab, 0x9e, 0xdf, 0x41,
-	0xf8, 0x2c, 0xef, 0xcf, 0xa7, 0x13, 0xc5, 0xa8, 0x69, 0xf8, 0xc8, 0x86, 0x42, 0x7d, 0x5f, 0x3e,
-	0xd2, 0xf1, 0xee, 0x7c, 0x9b, 0xfe, 0xcb, 0xb5, 0x5f, 0xa7, 0xd1, 0xdf, 0x18, 0x50, 0x7a, 0xba,
-	0x43, 0xaa, 0xfc, 0x5d, 0x91, 0xea, 0xda, 0xe3, 0x4d, 0xb4, 0x7c, 0x87, 0xb4, 0x70, 0x18, 0x90,
-	0xea, 0xa6, 0xf7, 0xb4, 0x7a, 0x1f, 0x53, 0xb2, 0x87, 0x5f, 0x54, 0xed, 0xa0, 0x8a, 0xdd, 0x2a,
-	0xd9, 0x25, 0x6e, 0x75, 0xcf, 0xf3, 0x03, 0x52, 0x65, 0xb4, 0x56, 0xcc, 0x06, 0x1c, 0xbf, 0xfb,
-	0x65, 0xd7, 0xf1, 0x7c, 0x4c, 0x3d, 0xff, 0x45, 0xf5, 0xae, 0xdb, 0xb6, 0x5d, 0x42, 0x7c, 0xdb,
-	0x6d, 0xa3, 0xea, 0x0e, 0xa5, 0xdd, 0xe0, 0x7a, 0xad, 0x46, 0x7a, 0x00, 0x2b, 0xa4, 0x07, 0x50,
-	0xab, 0x1c, 0x25, 0xe4, 0x36, 0x25, 0x0e, 0x71, 0x3d, 0xdf, 0xb2, 0xdb, 0x36, 0xc5, 0xce, 0x4a,
-	0xcb, 0xeb, 0xd4, 0xb3, 0xf5, 0x95, 0xd5, 0x95, 0xd5, 0x86, 0x09, 0xe9, 0x8b, 0xab, 0xab, 0xe8,
-	0x24, 0x9c, 0x60, 0xe2, 0xf9, 0x62, 0xfd, 0xd5, 0x1d, 0x2c, 0x04, 0xf1, 0x7d, 0xcf, 0x5f, 0x69,
-	0x9c, 0x61, 0x30, 0x17, 0xd1, 0xeb, 0x70, 0x72, 0xdd, 0x0b, 0x1d, 0xcb, 0x3d, 0x4b, 0xab, 0xdb,
-	0xb6, 0x6b, 0x55, 0x29, 0xc7, 0x10, 0xff, 0xfd, 0x61, 0xa5, 0xb1, 0xcc, 0xa0, 0xae, 0xa1, 0xb7,
-	0xe0, 0xf4, 0xd3, 0x1d, 0xe2, 0x93, 0xb3, 0x41, 0x15, 0x47, 0x4f, 0xab, 0x2d, 0xcf, 0xdd, 0x76,
-	0xec, 0x16, 0xad, 0xb2, 0x47, 0x2b, 0x8d, 0x63, 0x90, 0xae, 0xaf, 0x9e, 0x47, 0xf3, 0x50, 0xda,
-	0xa4, 0x67, 0x83, 0xaa, 0x7c, 0x9b, 0x6a, 0xa5, 0xf1, 0x3a, 0xa3, 0x71, 0x1e, 0x1d, 0x83, 0xc5,
-	0x9f, 0x78, 0x61, 0xb5, 0x85, 0x19, 0x2b, 0xea, 0x85, 0xad, 0x9d, 0x2a, 0xdd, 0xb1, 0x83, 0xc6,
-	0x69, 0x48, 0x5f, 0x5a, 0x5d, 0x45, 0x15, 0x58, 0xda, 0x3c, 0xdb, 0xa9, 0x06, 0x9e, 0xef, 0xbf,
-	0x58, 0xa9, 0x7e, 0x4a, 0xaa, 0xd8, 0x27, 0xd5, 0x2d, 0x9f, 0x6d, 0xc8, 0x67, 0x3b, 0xb0, 0x0d,
-	0xb3, 0x6b, 0x5d, 0x5b, 0xb8, 0xec, 0x67, 0xb3, 0x29, 0x74, 0x7f, 0xed, 0xf1, 0x66, 0x95, 0xef,
-	0x56, 0x95, 0xee, 0x60, 0x5a, 0xed, 0x84, 0x01, 0xad, 0x6e, 0x91, 0xaa, 0xed, 0xb6, 0x9c, 0xd0,
-	0x22, 0x56, 0xd5, 0x76, 0xf9, 0x4a, 0xc4, 0xbf, 0xe0, 0x09, 0xaa, 0xa1, 0xeb, 0x90, 0x20, 0xa8,
-	0xbe, 0xf0, 0x42, 0x4e, 0xd7, 0xf1, 0xda, 0x6d, 0x0e, 0x54, 0x29, 0xfc, 0xf8, 0xdc, 0xda, 0xe3,
-	0xcd, 0x73, 0x9c, 0x72, 0x35, 0xb5, 0x95, 0xe3, 0xef, 0x05, 0x5d, 0xf8, 0xdf, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x65, 0x10, 0x9e, 0xa8, 0x64, 0x59, 0x00, 0x00,
+	// 8261 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x7d, 0x5b, 0x6c, 0x1b, 0x59,
+	0x96, 0xd8, 0x14, 0x49, 0x51, 0xe2, 0x21, 0x29, 0x51, 0xd7, 0xb2, 0x4d, 0xd3, 0xfd, 0x60, 0xd7,
+	0x74, 0xb7, 0xbb, 0xd5, 0x6d, 0x49, 0x2d, 0xdb, 0xed, 0x47, 0x3f, 0x65, 0xc9, 0x6d, 0x6b, 0xd6,
+	0x9e, 0x71, 0xd3, 0xf6, 0x4c, 0x76, 0x36, 0x3b, 0x44, 0x89, 0x75, 0x45, 0x55, 0x44, 0x56, 0xb1,
+	0xab, 0x2e, 0x2d, 0xa9, 0xbd, 0x46, 0xb2, 0x9b, 0xd9, 0x5d, 0xec, 0x23, 0x59, 0x20, 0x09, 0xf2,
+	0x91, 0x8f, 0x41, 0xb0, 0x40, 0x80, 0x0d, 0x90, 0x00, 0x41, 0xf6, 0x63, 0x13, 0xec, 0xc7, 0x22,
+	0x8b, 0x00, 0x79, 0x21, 0x48, 0x82, 0x0d, 0x30, 0x41, 0xf2, 0x11, 0x20, 0x0f, 0x20, 0x08, 0x82,
+	0xfc, 0x04, 0xc8, 0x5f, 0x80, 0xe0, 0xdc, 0x47, 0x3d, 0xf8, 0xbc, 0x45, 0xb9, 0x7b, 0x7a, 0xb1,
+	0x5f, 0x52, 0x55, 0x9d, 0xd7, 0x7d, 0x9c, 0x7b, 0xce, 0x3d, 0xf7, 0x9c, 0x4b, 0x28, 0x58, 0x3d,
+	0x67, 0xad, 0xe7, 0x7b, 0xcc, 0x23, 0x73, 0x56, 0xcf, 0xe9, 0xed, 0xd5, 0x5e, 0x6a, 0x7b, 0x5e,
+	0xbb, 0x43, 0xd7, 0xad, 0x9e, 0xb3, 0x6e, 0xb9, 0xae, 0xc7, 0x2c, 0xe6, 0x78, 0x6e, 0x20, 0x80,
+	0x6a, 0xef, 0xf2, 0x3f, 0xad, 0xcb, 0x6d, 0xea, 0x5e, 0x0e, 0x8e, 0xac, 0x76, 0x9b, 0xfa, 0xeb,
+	0x5e, 0x8f, 0x43, 0x8c, 0x80, 0x7e, 0x45, 0xd2, 0xe2, 0x4f, 0x7b, 0xfd, 0xfd, 0xf5, 0x23, 0xdf,
+	0xea, 0xf5, 0xa8, 0x2f, 0xbf, 0x9b, 0xbf, 0x69, 0x40, 0xe9, 0x8e, 0xef, 0x7b, 0xfe, 0x0e, 0x65,
+	0x96, 0xd3, 0x09, 0xc8, 0x47, 0xb0, 0xd0, 0xa5, 0x41, 0x60, 0xb5, 0x69, 0x50, 0x35, 0xea, 0xd9,
+	0xb7, 0x8a, 0x9b, 0xaf, 0xad, 0x71, 0xb1, 0xd6, 0xe2, 0x60, 0x6b, 0x0f, 0x24, 0xcc, 0x1d, 0x97,
+	0xf9, 0x27, 0x8d, 0x10, 0xa5, 0xf6, 0x01, 0x94, 0x13, 0x9f, 0x48, 0x05, 0xb2, 0x87, 0xf4, 0xa4,
+	0x6a, 0xd4, 0x8d, 0xb7, 0x0a, 0x0d, 0xfc, 0x97, 0xac, 0xc0, 0xdc, 0x53, 0xab, 0xd3, 0xa7, 0xd5,
+	0x0c, 0x7f, 0x27, 0x1e, 0x6e, 0x65, 0x6e, 0x18, 0xe6, 0x31, 0x14, 0x1f, 0x5b, 0xed, 0x06, 0x0d,
+	0x7a, 0x9e, 0x1b, 0x50, 0xb2, 0x01, 0x39, 0x66, 0xb5, 0x95, 0x18, 0x2f, 0x49, 0x31, 0x62, 0x10,
+	0xf8, 0xbf, 0x94, 0x80, 0x43, 0xd6, 0xae, 0x43, 0x21, 0x7c, 0x95, 0x8a, 0xf3, 0x67, 0x50, 0x79,
+	0x6c, 0xb5, 0xbf, 0x8f, 0xcf, 0x21, 0xfb, 0x4d, 0x05, 0x8d, 0x14, 0x90, 0xbf, 0xe8, 0xca, 0x35,
+	0xd5, 0x95, 0x6b, 0x8f, 0x98, 0xef, 0xb8, 0x12, 0x49, 0x80, 0x9a, 0x7f, 0x39, 0x03, 0x95, 0x27,
+	0x3d, 0xdb, 0x62, 0x94, 0x8b, 0xf9, 0x45, 0x9f, 0x06, 0x8c, 0x7c, 0x08, 0xe0, 0xd8, 0xd4, 0x65,
+	0xce, 0xbe, 0x43, 0x7d, 0x2d, 0x6a, 0x31, 0x78, 0x72, 0x4d, 0xf6, 0x42, 0x26, 0x31, 0x18, 0x83,
+	0x4c, 0x06, 0xbb, 0x82, 0x6c, 0x41, 0xb9, 0xe5, 0x75, 0x3a, 0xb4, 0x85, 0xb3, 0xa1, 0xe9, 0xd8,
+	0xd5, 0xac, 0x06, 0xdf, 0x52, 0x84, 0xb2, 0x6b, 0xcf, 0xde, 0x9b, 0xff, 0xc7, 0x00, 0x78, 0x61,
+	0xed, 0xdf, 0x80, 0x9c, 0x6b, 0x75, 0x05, 0x97, 0x69, 0x78, 0x1c, 0x32, 0x1a, 0xb8, 0xac, 0xf6,
+	0xc0, 0x0d, 0x77, 0x57, 0x2e, 0x6d, 0x77, 0x99, 0xff, 0x39, 0x0b, 0x64, 0x3b, 0x7c, 0xf1, 0x99,
+	0xe3, 0x77, 0x8f, 0x2c, 0x9f, 0x92, 0xfb, 0x70, 0xa6, 0xd5, 0xf7, 0x7d, 0xea, 0xb2, 0xe6, 0xbe,
+	0x7c, 0x87, 0xf4, 0x75, 0xba, 0x61, 0x59, 0x22, 0x2a, 0x5a, 0xbb, 0x36, 0xf9, 0x0e, 0x10, 0x66,
+	0xf9, 0x6d, 0x9a, 0x24, 0xa6, 0xd3, 0x37, 0x15, 0x81, 0x17, 0xa3, 0x75, 0x1f, 0xa0, 0x6b, 0xb9,
+	0x56, 0x9b, 0x76, 0xa9, 0xcb, 0x78, 0x67, 0x2d, 0x6e, 0xbe, 0x2b, 0xe7, 0xd7, 0x70, 0x43, 0xd6,
+	0xd4, 0x3f, 0x0f, 0x42, 0x9c, 0x46, 0x0c, 0x9f, 0xdc, 0x85, 0x65, 0x9f, 0x7e, 0xd1, 0x77, 0x7c,
+	0xda, 0x0c, 0x9c, 0xb6, 0x6b, 0xb1, 0xbe, 0x4f, 0x65, 0x2f, 0xd6, 0x86, 0x04, 0xbb, 0xed, 0x79,
+	0x1d, 0x29, 0x96, 0x44, 0x7a, 0xa4, 0x70, 0xc8, 0x5d, 0x20, 0x5d, 0xcb, 0x71, 0x19, 0x75, 0x2d,
+	0xb7, 0x45, 0x9b, 0x47, 0x8e, 0x6b, 0x7b, 0x47, 0xd5, 0x39, 0x4e, 0xa9, 0x2a, 0xc5, 0x7b, 0x10,
+	0x01, 0xfc, 0x80, 0x7f, 0x6f, 0x2c, 0x77, 0x07, 0x5f, 0x99, 0xdf, 0x03, 0x32, 0x2c, 0x33, 0x59,
+	0x82, 0x62, 0xdf, 0x0d, 0x7a, 0xb4, 0x85, 0xd3, 0xcb, 0xae, 0x7c, 0x8b, 0x94, 0x60, 0xc1, 0x76,
+	0x02, 0x6b, 0xaf, 0x43, 0xed, 0x8a, 0x41, 0x16, 0x01, 0xa2, 0x51, 0xad, 0x64, 0x08, 0x40, 0xde,
+	0xa6, 0x4f, 0x9d, 0x16, 0xad, 0x64, 0xcd, 0x7f, 0x6b, 0xc0, 0xf2, 0x10, 0x67, 0x72, 0x03, 0x16,
+	0x82, 0xd6, 0x01, 0xb5, 0xfb, 0x1d, 0xbd, 0xa5, 0x22, 0x84, 0x26, 0x9f, 0x41, 0xc5, 0xee, 0xfb,
+	0x7c, 0xbd, 0x6e, 0x76, 0x1d, 0xb7, 0xcf, 0x68, 0x20, 0x87, 0xf2, 0xe2, 0x10, 0x85, 0x5d, 0x97,
+	0x5d, 0xd9, 0x14, 0x04, 0x96, 0x14, 0xd2, 0x03, 0x81, 0x43, 0x6e, 0x42, 0x81, 0x39, 0x5d, 0xda,
+	0xfc, 0xd2, 0x73, 0xf5, 0x26, 0xfd, 0x02, 0x82, 0xff, 0xd0, 0x73, 0xa9, 0xf9, 0x1f, 0x32, 0x00,
+	0xd1, 0x58, 0x0f, 0xab, 0x81, 0x91, 0x56, 0x0d, 0xc8, 0x35, 0x98, 0x67, 0xd4, 0xea, 0xea, 0x4e,
+	0xcb, 0x3c, 0x02, 0xef, 0xda, 0x64, 0x1d, 0x60, 0xdf, 0xa1, 0x1d, 0xbb, 0xd9, 0xb5, 0x82, 0x43,
+	0xd9, 0x88, 0x8a, 0x1c, 0xed, 0xcf, 0xf0, 0xc3, 0x03, 0x2b, 0x38, 0x6c, 0x14, 0xf6, 0xd5, 0xbf,
+	0xe4, 0x1a, 0x2c, 0x28, 0x15, 0x90, 0xd3, 0xec, 0xc2, 0xd8, 0xb9, 0xdb, 0x08, 0x41, 0xc9, 0xba,
+	0x5c, 0x4e, 0xe7, 0xf8, 0x72, 0x7a, 0x71, 0x08, 0xe5, 0xc5, 0xd9, 0x94, 0x7f, 0x61, 0xc0, 0xd2,
+	0x77, 0x29, 0x3b, 0xf2, 0xfc, 0xc3, 0x07, 0x94, 0x59, 0xb6, 0xc5, 0x2c, 0xf2, 0x09, 0x94, 0xac,
+	0x4e, 0xc7, 0x6b, 0x59, 0x8c, 0xda, 0x4d, 0xa7, 0xa7, 0xd5, 0xbd, 0xc5, 0x10, 0x63, 0xb7, 0x97,
+	0x24, 0x60, 0xb1, 0xb1, 0x5d, 0xbc, 0xe3, 0xf5, 0xf7, 0x3a, 0x74, 0x90, 0xc0, 0x16, 0x23, 0x57,
+	0x61, 0xbe, 0x45, 0x3b, 0x9d, 0xc8, 0x22, 0x8c, 0x9c, 0x6a, 0xef, 0x5f, 0x95, 0xa3, 0x83, 0xb0,
+	0xbb, 0xb6, 0xf9, 0x6f, 0xf2, 0x50, 0x09, 0x75, 0x49, 0x35, 0xe6, 0x9b, 0xbb, 0xb2, 0xdd, 0x85,
+	0x4a, 0x48, 0xe4, 0x29, 0xf5, 0x03, 0xc7, 0x73, 0xb5, 0xf4, 0x62, 0x49, 0x61, 0x7d, 0x5f, 0x20,
+	0xa1, 0x3e, 0x04, 0xd4, 0x77, 0xac, 0x4e, 0xd3, 0xed, 0x77, 0xf7, 0xa8, 0xaf, 0x67, 0x16, 0x04,
+	0xca, 0x77, 0x39, 0x06, 0x8e, 0x58, 0xd7, 0xb3, 0x69, 0x48, 0x61, 0x4e, 0x67, 0xc8, 0x39, 0x86,
+	0x24, 0xf0, 0x29, 0x94, 0xba, 0x96, 0xdb, 0xdf, 0xb7, 0x5a, 0xb8, 0x3c, 0xfa, 0xd5, 0xbc, 0x8e,
+	0x08, 0x71, 0x0c, 0x34, 0x88, 0x01, 0xb3, 0x18, 0xad, 0xce, 0xeb, 0x18, 0x44, 0x0e, 0xca, 0x5b,
+	0x8e, 0xff, 0x34, 0xa5, 0x6b, 0x57, 0x5d, 0xd0, 0x6a, 0x39, 0xa2, 0x48, 0x07, 0x70, 0xcc, 0x42,
+	0x5e, 0x48, 0xbf, 0x90, 0xff, 0x3b, 0x03, 0xca, 0x6a, 0x74, 0x1f, 0x71, 0xe9, 0x8a, 0x30, 0xff,
+	0xc4, 0x3d, 0x74, 0xbd, 0x23, 0xb7, 0xf2, 0x2d, 0x7c, 0xd8, 0x16, 0xd3, 0xa9, 0x62, 0xe0, 0xc3,
+	0x43, 0xea, 0xda, 0x8e, 0xdb, 0xae, 0x64, 0x48, 0x05, 0x4a, 0xbb, 0xae, 0xc3, 0x1c, 0xab, 0xe3,
+	0x7c, 0x89, 0x6f, 0xb2, 0xb8, 0xd8, 0x3f, 0x76, 0xba, 0xd4, 0xfe, 0x5e, 0x9f, 0x55, 0x72, 0xa4,
+	0x00, 0x73, 0xdc, 0xab, 0xad, 0xcc, 0xa1, 0x59, 0xd8, 0xf1, 0x8e, 0xdc, 0x8e, 0x67, 0x71, 0xdc,
+	0x3c, 0x1a, 0x02, 0xf5, 0x82, 0xda, 0x95, 0x79, 0xc4, 0x6c, 0xd0, 0xa7, 0xd4, 0x67, 0xd4, 0xae,
+	0x2c, 0x20, 0x65, 0xe1, 0x82, 0x7d, 0x66, 0x39, 0x68, 0x38, 0x0a, 0xa4, 0x0c, 0x85, 0x6d, 0xaf,
+	0xdb, 0xeb, 0x50, 0x04, 0x00, 0xc1, 0xba, 0xe5, 0x75, 0x7b, 0x16, 0x73, 0xf6, 0x3a, 0xb4, 0x52,
+	0x44, 0x02, 0x3b, 0x74, 0x9f, 0xfa, 0x3e, 0xb5, 0x2b, 0x25, 0xb3, 0x02, 0x8b, 0x3b, 0xdc, 0xae,
+	0x28, 0x75, 0x32, 0xff, 0x20, 0x0b, 0x79, 0xf1, 0x0a, 0x17, 0x74, 0x61, 0x74, 0x74, 0xf5, 0x69,
+	0x41, 0x80, 0xef, 0xda, 0xc3, 0x2b, 0x78, 0x26, 0xf5, 0x0a, 0xbe, 0x01, 0x39, 0xa7, 0x1b, 0x38,
+	0x5a, 0x1a, 0xc3, 0x21, 0x05, 0x06, 0x75, 0xb4, 0xb4, 0x83, 0x43, 0x92, 0x77, 0x12, 0xcb, 0xf0,
+	0x79, 0x39, 0x1b, 0x44, 0xf3, 0x87, 0x7c, 0xd9, 0x0d, 0x98, 0x77, 0xc5, 0x42, 0x2a, 0x27, 0xff,
+	0x39, 0x09, 0x3f, 0xb0, 0xbc, 0x36, 0x14, 0x18, 0xb9, 0x12, 0x33, 0x0e, 0x62, 0xd2, 0x9f, 0x0f,
+	0x6d, 0x49, 0x72, 0x15, 0x8b, 0x4c, 0xc3, 0x29, 0xfc, 0xdd, 0x2c, 0x9c, 0x11, 0xb3, 0x41, 0x34,
+	0x40, 0x39, 0xbe, 0x0d, 0x38, 0x47, 0x8f, 0x9d, 0x80, 0x39, 0x6e, 0xbb, 0x99, 0xde, 0xac, 0xae,
+	0x28, 0xdc, 0xed, 0xf8, 0xe0, 0x24, 0xa6, 0x46, 0xe6, 0x74, 0x53, 0x23, 0x3b, 0xf3, 0xd4, 0xc8,
+	0xa5, 0x9e, 0x1a, 0x73, 0xda, 0x53, 0xe3, 0x86, 0x9c, 0x1a, 0x79, 0x3e, 0x35, 0x5e, 0x4f, 0x6c,
+	0x78, 0x12, 0xfd, 0x3b, 0x34, 0x4f, 0xbe, 0xde, 0x51, 0xff, 0x75, 0x03, 0x8a, 0x4f, 0x76, 0x1e,
+	0x86, 0xe6, 0xf0, 0x16, 0x00, 0x9a, 0xd9, 0x4e, 0xb3, 0xe7, 0xf9, 0xac, 0x6a, 0x8c, 0x37, 0xae,
+	0xca, 0x8f, 0x2b, 0x70, 0xf0, 0x87, 0x9e, 0x8f, 0x5b, 0xa4, 0xa2, 0x4f, 0xbb, 0x1e, 0xa3, 0x02,
+	0x59, 0xc3, 0x09, 0x04, 0x01, 0x8f, 0xd8, 0xa6, 0x0f, 0xa5, 0x6d, 0x6f, 0x2b, 0x92, 0x64, 0x03,
+	0x72, 0x2d, 0xcf, 0xd6, 0xf3, 0x46, 0x39, 0x24, 0x62, 0xf4, 0x2c, 0x76, 0xa0, 0xb7, 0xc9, 0x42,
+	0x48, 0xf3, 0x7f, 0x65, 0xa0, 0xdc, 0xa0, 0x81, 0xd7, 0xf7, 0x5b, 0xf4, 0xce, 0x53, 0x74, 0xac,
+	0x09, 0xe4, 0xd8, 0x49, 0x8f, 0xca, 0xce, 0xe3, 0xff, 0x73, 0x6f, 0xcb, 0xe9, 0xd2, 0x49, 0x0d,
+	0x52, 0xae, 0x06, 0x07, 0x7c, 0x11, 0x73, 0xb4, 0x01, 0xe7, 0x7a, 0x3e, 0x7d, 0xea, 0x78, 0xfd,
+	0xa0, 0x99, 0x7e, 0x4f, 0xb7, 0xa2, 0x70, 0x13, 0x5a, 0xf7, 0x86, 0xda, 0x05, 0xc8, 0x79, 0x5c,
+	0x4e, 0x2c, 0x58, 0x0d, 0xf9, 0x91, 0xbc, 0x17, 0xdf, 0x3c, 0xc8, 0xb5, 0x6a, 0x79, 0xc8, 0xc5,
+	0x6c, 0xc4, 0x80, 0x90, 0xb2, 0xd7, 0x67, 0xbd, 0x3e, 0xab, 0xce, 0x27, 0x28, 0x7f, 0x8f, 0xbf,
+	0x6c, 0xc8, 0x8f, 0xe6, 0x7f, 0xc9, 0xc2, 0xb2, 0x78, 0xb5, 0x63, 0x31, 0x4b, 0x59, 0xd8, 0x8f,
+	0x63, 0x5d, 0xbe, 0xb8, 0xb9, 0x9a, 0x40, 0x8d, 0xc1, 0xc9, 0x37, 0xf2, 0xe9, 0xf1, 0x49, 0x8f,
+	0xca, 0xe1, 0x89, 0x9a, 0x95, 0x99, 0xd4, 0xac, 0x2a, 0xcc, 0xf7, 0xac, 0x13, 0xb4, 0x84, 0x7c,
+	0x38, 0x4a, 0x0d, 0xf5, 0x88, 0x7b, 0x1f, 0x9f, 0xb6, 0xa8, 0xf3, 0x94, 0x8e, 0xef, 0xdd, 0xb8,
+	0x2b, 0x1a, 0x42, 0x93, 0x97, 0xa0, 0xc0, 0x7c, 0xcb, 0x0d, 0xf8, 0x7c, 0x9f, 0xe3, 0x53, 0x26,
+	0x7a, 0x41, 0xde, 0x87, 0x72, 0xdf, 0xee, 0x35, 0xbb, 0x94, 0x59, 0x4d, 0x9c, 0xd2, 0xb2, 0x2f,
+	0x89, 0x5a, 0x0c, 0x22, 0xb5, 0x6b, 0x14, 0xfb, 0x76, 0x0f, 0x1f, 0xb0, 0xbd, 0xe4, 0x26, 0x2c,
+	0xb6, 0x3c, 0x2b, 0x8e, 0x28, 0x7a, 0xf5, 0x4c, 0x38, 0x08, 0x91, 0x9a, 0xe0, 0xb4, 0xb1, 0x22,
+	0xd4, 0x0f, 0x60, 0xd1, 0x97, 0xf3, 0xb9, 0x49, 0x71, 0x42, 0x4b, 0x8f, 0x67, 0x45, 0xa2, 0x26,
+	0x26, 0x7b, 0xa3, 0xec, 0xc7, 0x1f, 0xcd, 0x1d, 0x58, 0x1e, 0xea, 0x63, 0x74, 0x45, 0xfa, 0xa1,
+	0x93, 0x52, 0x86, 0xc2, 0x21, 0xa5, 0x3d, 0xab, 0xe3, 0x3c, 0xa5, 0x15, 0x83, 0x2c, 0x40, 0x0e,
+	0x65, 0xa8, 0x64, 0xd0, 0x07, 0xe1, 0xec, 0x2a, 0x59, 0xf3, 0x1f, 0x02, 0x94, 0x04, 0x99, 0x6d,
+	0xcf, 0xdd, 0x77, 0xda, 0x64, 0x0d, 0xb2, 0x7d, 0xbf, 0xa3, 0xa5, 0xc7, 0x08, 0x48, 0x76, 0x60,
+	0x69, 0xcf, 0x0a, 0x9c, 0x56, 0xd3, 0xea, 0xb3, 0x83, 0x66, 0x3f, 0xa0, 0xbe, 0x96, 0x46, 0x97,
+	0x39, 0xd2, 0x56, 0x9f, 0x1d, 0x3c, 0x09, 0xa8, 0x3f, 0x40, 0xa5, 0x67, 0x05, 0x41, 0x35, 0x9b,
+	0x8a, 0xca, 0x43, 0x2b, 0x08, 0xd0, 0x9f, 0x6f, 0xf5, 0x03, 0xe6, 0x75, 0x9b, 0x07, 0xd4, 0xb2,
+	0xa9, 0xdf, 0xe4, 0x51, 0x1c, 0x1d, 0x15, 0xac, 0x08, 0xbc, 0x7b, 0x1c, 0xed, 0xbb, 0x18, 0xd1,
+	0xe1, 0x3b, 0x8d, 0x38, 0x2d, 0xb1, 0x24, 0xcf, 0xe9, 0xed, 0x34, 0x22, 0x62, 0xfc, 0x15, 0x2e,
+	0x76, 0x07, 0x5e, 0xc0, 0xb4, 0x1c, 0x69, 0x0e, 0x89, 0xcb, 0x18, 0x9f, 0xa7, 0xf3, 0xd3, 0xd7,
+	0x65, 0x0e, 0x48, 0xd6, 0x84, 0x1d, 0xd1, 0xf1, 0x99, 0xb9, 0x95, 0xf9, 0x00, 0x80, 0x4f, 0x02,
+	0xd1, 0x49, 0x05, 0x0d, 0xb4, 0x02, 0x87, 0xe7, 0xbd, 0xf3, 0x31, 0x94, 0xad, 0xa0, 0xe9, 0x04,
+	0x4d, 0xa5, 0xa4, 0x30, 0x35, 0xea, 0x52, 0xb4, 0x82, 0xdd, 0xe0, 0x61, 0xa4, 0xc4, 0xd4, 0xb5,
+	0x7b, 0x9e, 0xe3, 0xb2, 0x6a, 0x51, 0xc7, 0xa3, 0x50, 0xd0, 0xe4, 0x1e, 0x10, 0x19, 0x3a, 0x69,
+	0xb6, 0xa8, 0xcf, 0x9a, 0xad, 0x03, 0xda, 0x3a, 0xac, 0x96, 0xa6, 0x07, 0x7d, 0x24, 0xd6, 0x36,
+	0xf5, 0xd9, 0x36, 0xe2, 0xa0, 0x0c, 0x38, 0x5d, 0x79, 0xf3, 0xcb, 0x3a, 0x32, 0x28, 0x68, 0xc4,
+	0xc4, 0x29, 0x7a, 0xe4, 0xf9, 0x76, 0x75, 0x51, 0x07, 0x53, 0x41, 0xa3, 0x2b, 0xd5, 0xea, 0x38,
+	0xd8, 0xeb, 0x8e, 0x5d, 0x5d, 0xd2, 0x41, 0x15, 0xe0, 0xbb, 0x36, 0x8e, 0x17, 0xf3, 0x7a, 0x4e,
+	0x4b, 0x8c, 0x57, 0x45, 0x67, 0xbc, 0x38, 0x3c, 0x1f, 0xaf, 0x2d, 0x58, 0xec, 0x5a, 0xc7, 0xcd,
+	0x3d, 0x8b, 0xb5, 0x0e, 0x9a, 0x81, 0xf3, 0x25, 0xad, 0x2e, 0x4f, 0x9f, 0x57, 0xa5, 0xae, 0x75,
+	0x7c, 0x1b, 0x31, 0x1e, 0x39, 0x5f, 0x52, 0xf2, 0x09, 0x94, 0x91, 0x44, 0xc7, 0x71, 0xdb, 0xd4,
+	0x6f, 0x76, 0x83, 0x2a, 0x99, 0x4e, 0xa1, 0xd8, 0xb5, 0x8e, 0xef, 0x73, 0x84, 0x07, 0x01, 0x69,
+	0xc0, 0x79, 0x24, 0xe0, 0x0b, 0x4f, 0x2a, 0x68, 0xf6, 0xa8, 0xdf, 0x0c, 0x68, 0xcb, 0x73, 0xed,
+	0xea, 0x99, 0xe9, 0xa4, 0x56, 0xba, 0xd6, 0xb1, 0x74, 0xc2, 0x82, 0x87, 0xd4, 0x7f, 0xc4, 0x11,
+	0xc9, 0x23, 0x41, 0xb3, 0xe5, 0xb9, 0x2a, 0x2c, 0xa0, 0xc8, 0x57, 0x57, 0xa6, 0xd3, 0x3c, 0xdb,
+	0xb5, 0x8e, 0xb7, 0x43, 0x54, 0x45, 0x9d, 0xbc, 0x0a, 0x45, 0xa1, 0x19, 0x68, 0xb0, 0x82, 0xea,
+	0xd9, 0x7a, 0xf6, 0xad, 0x42, 0x43, 0x28, 0x0b, 0x2e, 0xb2, 0x81, 0xf9, 0x8f, 0xb3, 0x90, 0x17,
+	0x8b, 0x26, 0x0e, 0xa8, 0x30, 0x97, 0xda, 0xdb, 0x26, 0x01, 0xfe, 0x62, 0xb6, 0x4d, 0x6f, 0x4a,
+	0x63, 0x2c, 0x02, 0xa9, 0x24, 0x61, 0x8c, 0xd7, 0x62, 0x46, 0xf7, 0x1d, 0xc8, 0xb7, 0xf8, 0xf2,
+	0x5e, 0xcd, 0x25, 0x6c, 0x53, 0x7c, 0xe5, 0x6f, 0x48, 0x10, 0x0c, 0xd7, 0x50, 0x97, 0xc7, 0x26,
+	0xab, 0x73, 0x53, 0xd5, 0x4a, 0x81, 0x92, 0x77, 0x12, 0x2e, 0xf4, 0xf9, 0x01, 0x51, 0x5e, 0x54,
+	0x80, 0xeb, 0x53, 0xc8, 0x71, 0x3b, 0x57, 0x86, 0x42, 0xdf, 0xb5, 0xe9, 0xbe, 0xe3, 0xf2, 0x78,
+	0x6a, 0x11, 0xe6, 0x8f, 0xe8, 0xde, 0x81, 0xe7, 0x1d, 0x56, 0x0c, 0x32, 0x0f, 0xd9, 0xbe, 0xdd,
+	0xab, 0x64, 0xd0, 0xe0, 0x75, 0xbf, 0x60, 0xac, 0x92, 0x45, 0x83, 0xe7, 0xec, 0x33, 0xc6, 0x2a,
+	0x39, 0xf3, 0x77, 0x73, 0x30, 0xf7, 0xd8, 0x3b, 0xa4, 0xae, 0x70, 0x24, 0x84, 0x45, 0xd5, 0x1b,
+	0x39, 0x05, 0x4d, 0x36, 0x60, 0xee, 0xc8, 0x77, 0x98, 0x72, 0x61, 0x26, 0xf5, 0x8f, 0x00, 0xc4,
+	0x70, 0x08, 0x43, 0xa6, 0x7a, 0xe7, 0x03, 0x1c, 0x94, 0xac, 0xca, 0x1e, 0xcd, 0xd5, 0xb3, 0xb1,
+	0xfd, 0x27, 0x97, 0x7d, 0x68, 0x1b, 0xf2, 0x2e, 0x64, 0x1c, 0x5b, 0xcb, 0x38, 0x65, 0x1c, 0x1e,
+	0x2f, 0x6d, 0xf9, 0xd4, 0x62, 0xd4, 0xae, 0xe6, 0xc7, 0x6b, 0x89, 0xf2, 0x92, 0x15, 0x2c, 0xa2,
+	0xd1, 0xe3, 0x9e, 0xe3, 0xd3, 0xa0, 0x3a, 0xaf, 0x81, 0x26, 0x61, 0xc9, 0x0d, 0x28, 0x74, 0xac,
+	0x80, 0xa1, 0x6f, 0x60, 0x57, 0x17, 0xa6, 0x23, 0x2e, 0x20, 0xf4, 0x93, 0x80, 0xda, 0xe4, 0x63,
+	0x28, 0x85, 0x98, 0x18, 0xba, 0xd4, 0x31, 0x52, 0xa0, 0xb0, 0x77, 0x7b, 0xb3, 0x4f, 0xb3, 0x3f,
+	0x9a, 0x83, 0xfc, 0x03, 0xca, 0x43, 0x61, 0xd7, 0x60, 0x1e, 0xd7, 0x7d, 0x5d, 0xf5, 0xce, 0x23,
+	0xf0, 0xec, 0x21, 0xe9, 0x0d, 0xc8, 0xf9, 0x5e, 0x47, 0x2f, 0xa2, 0xce, 0x21, 0xc3, 0xb3, 0xaa,
+	0x5c, 0x9a, 0xb3, 0x2a, 0xda, 0xb5, 0x9c, 0x8e, 0xd6, 0x74, 0x11, 0xa0, 0x88, 0xd3, 0x3b, 0xc0,
+	0x50, 0xbf, 0x8e, 0x03, 0x23, 0x40, 0xd1, 0x60, 0x59, 0x4f, 0x2d, 0x66, 0xf9, 0x4d, 0x74, 0x28,
+	0x75, 0xe2, 0x80, 0x05, 0x01, 0xff, 0xc4, 0xef, 0x20, 0x72, 0xcb, 0x73, 0x5d, 0xda, 0xe2, 0x0b,
+	0xab, 0x8e, 0x53, 0x53, 0x90, 0xf0, 0xbb, 0x36, 0xf9, 0x14, 0xca, 0x6d, 0x87, 0x35, 0x0f, 0xfa,
+	0x7b, 0xcd, 0x8e, 0xd7, 0x76, 0x5c, 0xad, 0x89, 0x53, 0x6c, 0x3b, 0xec, 0x5e, 0x7f, 0xef, 0x3e,
+	0x22, 0xa0, 0xbd, 0x7c, 0x4a, 0x7d, 0x7e, 0x5c, 0xd3, 0x14, 0x9d, 0x35, 0xdd, 0xc1, 0x29, 0x2b,
+	0x8c, 0x3b, 0xbc, 0xcb, 0xe2, 0x24, 0x44, 0xdf, 0x15, 0xf5, 0x49, 0x3c, 0xe4, 0x3d, 0x78, 0x13,
+	0x0a, 0xdc, 0x1f, 0xe6, 0x6b, 0x7c, 0x49, 0x67, 0x89, 0x42, 0x70, 0x5c, 0x20, 0xcd, 0x6b, 0x00,
+	0x62, 0x02, 0xdf, 0x77, 0x02, 0x46, 0x2e, 0xc1, 0x7c, 0x97, 0x3f, 0xa9, 0x93, 0x6d, 0xb5, 0xeb,
+	0x12, 0x30, 0x0d, 0xf5, 0xd5, 0xfc, 0x07, 0x59, 0x28, 0x3c, 0xa6, 0x56, 0xf7, 0xf3, 0xbe, 0xc7,
+	0x2c, 0x0c, 0x11, 0xa0, 0x75, 0x15, 0x5b, 0xb2, 0x40, 0x27, 0xbe, 0x00, 0x5d, 0xeb, 0x58, 0xec,
+	0xe4, 0x02, 0xf4, 0xe9, 0x85, 0x6d, 0x56, 0x06, 0x4b, 0xeb, 0xa4, 0x69, 0x91, 0xdb, 0xe4, 0x10,
+	0x45, 0xc9, 0x20, 0xac, 0x66, 0x50, 0xcd, 0x4e, 0xa7, 0x80, 0x32, 0x08, 0xbb, 0x13, 0x90, 0x5d,
+	0x8c, 0x07, 0x1f, 0x47, 0xe1, 0xfd, 0xbd, 0x13, 0x3c, 0xf0, 0xca, 0x4d, 0x5f, 0x84, 0x2a, 0x5d,
+	0xeb, 0x58, 0x45, 0x70, 0x6e, 0x23, 0x12, 0xb9, 0x27, 0x48, 0xf5, 0x7b, 0x1d, 0xc7, 0x3d, 0xe4,
+	0xce, 0x8b, 0x6d, 0x9d, 0x54, 0xe7, 0xc6, 0x93, 0x0a, 0xcf, 0xce, 0xba, 0xd6, 0xf1, 0x13, 0x8e,
+	0xf5, 0x90, 0xfa, 0x3b, 0xd6, 0x09, 0xb9, 0x0f, 0x2b, 0xbc, 0x5b, 0x31, 0xd4, 0x1b, 0xa7, 0x95,
+	0x9f, 0x4e, 0x6b, 0x19, 0xfb, 0x57, 0xe2, 0x09, 0x6a, 0xe6, 0x5f, 0x92, 0x43, 0xf6, 0x84, 0x6f,
+	0xcf, 0xaf, 0xc1, 0x7c, 0x8a, 0xe1, 0x52, 0xb0, 0xe4, 0x23, 0x28, 0xa6, 0x1c, 0xa7, 0x38, 0x3c,
+	0x72, 0x4d, 0x31, 0x40, 0x0a, 0x96, 0xdc, 0x86, 0xc5, 0xf4, 0x23, 0x53, 0xde, 0x4f, 0x0c, 0xcb,
+	0xc7, 0x50, 0x92, 0x43, 0xc2, 0x3c, 0xcd, 0x01, 0x29, 0x0a, 0x84, 0xc7, 0x08, 0x8f, 0x32, 0x84,
+	0x03, 0xc1, 0x3c, 0xcd, 0x61, 0x28, 0x2b, 0x14, 0x4e, 0xc3, 0xfc, 0x5b, 0x19, 0xc8, 0xe1, 0x10,
+	0xc4, 0x57, 0x7d, 0x23, 0xc5, 0xaa, 0xff, 0x76, 0x22, 0xdf, 0xe2, 0xac, 0xb2, 0xf4, 0xd4, 0xea,
+	0x0e, 0x19, 0xfa, 0x98, 0x26, 0x67, 0x27, 0x69, 0x32, 0x79, 0x13, 0xe6, 0xbe, 0x40, 0x25, 0xae,
+	0xe6, 0x12, 0xe7, 0x9a, 0xa1, 0x72, 0x37, 0xc4, 0x67, 0x84, 0xeb, 0xf3, 0xc3, 0x96, 0xb9, 0x21,
+	0x38, 0x3e, 0xa3, 0x1a, 0xe2, 0xf3, 0xec, 0xb6, 0xf4, 0xf7, 0xe6, 0x60, 0x21, 0xcc, 0x4c, 0xb8,
+	0x0e, 0x0b, 0x4e, 0xd7, 0x6a, 0x6b, 0x1f, 0x32, 0xcc, 0x73, 0xe8, 0x5d, 0x9b, 0xbc, 0x0f, 0xf3,
+	0xea, 0x54, 0x4d, 0xc7, 0x9e, 0x2a, 0x60, 0x74, 0xf2, 0xf6, 0x9d, 0x0e, 0xe5, 0x26, 0x52, 0xeb,
+	0x98, 0x5a, 0x41, 0x93, 0xab, 0x90, 0x0f, 0x0e, 0xac, 0xcd, 0x6b, 0xef, 0x6b, 0x99, 0x56, 0x09,
+	0x4b, 0xae, 0x40, 0xbe, 0x43, 0xdd, 0x36, 0x3b, 0xd0, 0x99, 0x88, 0x12, 0x74, 0x78, 0x27, 0x90,
+	0x9f, 0xe5, 0x08, 0x5c, 0xb9, 0x74, 0xf3, 0x29, 0x5c, 0xba, 0xcb, 0x72, 0xe6, 0x2d, 0xd4, 0xb3,
+	0xb1, 0xd3, 0x6c, 0x35, 0x5c, 0x43, 0xb3, 0xef, 0x25, 0x28, 0x44, 0x89, 0x16, 0x05, 0x1e, 0x97,
+	0x8b, 0x5e, 0xa0, 0x2a, 0xe1, 0x03, 0x1e, 0x3d, 0x1c, 0xd2, 0x13, 0x6c, 0x07, 0xe8, 0xb4, 0x43,
+	0xe2, 0xfc, 0x1c, 0x3d, 0xd9, 0xb5, 0xc9, 0x6d, 0x28, 0xab, 0x13, 0x2c, 0xa7, 0xe3, 0xb0, 0x93,
+	0x30, 0x3a, 0x90, 0x94, 0x6c, 0x3b, 0x0e, 0xd3, 0x48, 0xa2, 0xcc, 0x3e, 0x55, 0xff, 0xab, 0x01,
+	0x67, 0x47, 0x72, 0x18, 0x3a, 0x10, 0x35, 0x52, 0x1f, 0x88, 0x6e, 0x41, 0x59, 0x9c, 0xc9, 0xf6,
+	0x2c, 0xc6, 0xa8, 0xaf, 0x37, 0x8d, 0xc5, 0x31, 0xee, 0x43, 0x81, 0x41, 0xee, 0xc0, 0x52, 0xd7,
+	0x71, 0x9d, 0x6e, 0xbf, 0x9b, 0xea, 0x84, 0x79, 0x51, 0x22, 0xc9, 0x03, 0x66, 0xf3, 0xef, 0x65,
+	0xe0, 0x0c, 0x7a, 0x05, 0x2a, 0x69, 0x4e, 0x1d, 0x1d, 0xbd, 0x80, 0x44, 0x8c, 0x53, 0x9c, 0x14,
+	0xbd, 0x07, 0x73, 0x1d, 0xa7, 0xeb, 0x30, 0x1d, 0x03, 0x22, 0x20, 0x11, 0x25, 0x70, 0xdc, 0x16,
+	0xd5, 0xb1, 0x1a, 0x02, 0x12, 0x51, 0xfa, 0x2e, 0x0b, 0x7d, 0xdf, 0xc9, 0x28, 0x1c, 0xd2, 0xbc,
+	0x0f, 0x2b, 0xc9, 0xde, 0x92, 0xb9, 0x7a, 0x57, 0x87, 0xb2, 0x16, 0xab, 0xe3, 0x82, 0xe1, 0x51,
+	0xb2, 0xa2, 0xf9, 0x93, 0x39, 0x28, 0x62, 0xc4, 0xf3, 0xa1, 0xef, 0xe1, 0x4a, 0x13, 0x39, 0xe3,
+	0xc6, 0x0c, 0xce, 0x78, 0x46, 0xdf, 0x19, 0x1f, 0x76, 0x68, 0xb3, 0xa7, 0x77, 0x68, 0x73, 0x69,
+	0x1d, 0xda, 0xe4, 0x96, 0x60, 0x2e, 0xdd, 0x96, 0x40, 0xed, 0x74, 0xf2, 0xda, 0x3b, 0x9d, 0x8f,
+	0xa0, 0xd8, 0x13, 0xfd, 0xac, 0xbd, 0x05, 0x01, 0x89, 0x80, 0x0c, 0x3f, 0x81, 0x52, 0xdb, 0x61,
+	0xd1, 0x2e, 0xa2, 0xa1, 0xb9, 0x8b, 0x38, 0x50, 0xbb, 0x08, 0x8c, 0x13, 0xfa, 0xde, 0x53, 0xc7,
+	0xa6, 0xbe, 0xd6, 0x16, 0x24, 0x84, 0xc6, 0x8e, 0xea, 0x78, 0x6d, 0xaf, 0xcf, 0xb8, 0xe0, 0x3a,
+	0xcb, 0x68, 0x41, 0xc0, 0x0f, 0xef, 0x9d, 0x8a, 0xa9, 0xf6, 0x4e, 0xe6, 0x9f, 0x87, 0xf3, 0x3b,
+	0xb4, 0x43, 0x19, 0x8d, 0x1d, 0x1e, 0xbd, 0xb0, 0x05, 0xc2, 0xfc, 0x57, 0x06, 0x9c, 0x45, 0x6d,
+	0x1a, 0x26, 0x7e, 0x03, 0x0a, 0x3d, 0x74, 0x0c, 0x78, 0x70, 0x52, 0xc3, 0x75, 0x5d, 0x40, 0x68,
+	0x1e, 0x98, 0xfc, 0x00, 0x80, 0x63, 0x8a, 0x00, 0x8b, 0x8e, 0x4e, 0x70, 0x4e, 0x22, 0x08, 0x84,
+	0x51, 0x55, 0xab, 0xdd, 0xdc, 0x77, 0x3a, 0x8c, 0xfa, 0x5a, 0xcb, 0x69, 0x81, 0x59, 0xed, 0xcf,
+	0x38, 0xb8, 0xd9, 0x87, 0x73, 0x83, 0x8d, 0x91, 0x8b, 0xc3, 0x95, 0xa4, 0x3f, 0x2d, 0xd6, 0x87,
+	0x11, 0xc7, 0x72, 0x71, 0x28, 0xf2, 0x26, 0x2c, 0xb9, 0xf4, 0x98, 0x35, 0x07, 0x5a, 0x53, 0x68,
+	0x94, 0xf1, 0xf5, 0x43, 0x25, 0xb3, 0xf9, 0x23, 0xb8, 0xd0, 0xa0, 0xcc, 0x77, 0xe8, 0xd3, 0xaf,
+	0x66, 0x90, 0xfe, 0x86, 0x01, 0x2b, 0x72, 0xe5, 0x7a, 0xc4, 0x7c, 0x6a, 0x75, 0xbf, 0x11, 0x16,
+	0xc2, 0xfc, 0x2b, 0x06, 0x94, 0x93, 0xc9, 0x0e, 0x3f, 0x5b, 0x79, 0xfe, 0x38, 0x03, 0x04, 0x87,
+	0x5f, 0xee, 0x77, 0x5f, 0xa0, 0x50, 0x09, 0x5d, 0xc8, 0xcc, 0xae, 0x0b, 0xd9, 0xd3, 0xe8, 0x42,
+	0x2e, 0x95, 0x2e, 0xa0, 0x03, 0x1a, 0x78, 0x3e, 0x6b, 0xee, 0x9d, 0x68, 0xad, 0xeb, 0x79, 0x04,
+	0xbe, 0x7d, 0x62, 0xee, 0xc3, 0x99, 0x44, 0x1f, 0x4a, 0xfd, 0xb9, 0x14, 0xdf, 0xc6, 0x66, 0x87,
+	0x8f, 0x89, 0xd5, 0x57, 0x6d, 0x9d, 0xf9, 0xa9, 0x01, 0x2b, 0xbb, 0xdd, 0x9e, 0xe7, 0x7f, 0x05,
+	0xc3, 0x75, 0x15, 0xf2, 0xfb, 0x9e, 0xdf, 0x9d, 0x90, 0x1a, 0x99, 0x68, 0xb9, 0x80, 0x25, 0x44,
+	0x1c, 0xc7, 0xca, 0xe3, 0x6d, 0xfe, 0x3f, 0xd9, 0x84, 0x7c, 0xbf, 0x17, 0x50, 0x9f, 0x69, 0x98,
+	0x56, 0x09, 0x69, 0xde, 0x84, 0xa2, 0x68, 0x18, 0x4f, 0x2b, 0x43, 0x7f, 0xd7, 0xf7, 0x8e, 0x78,
+	0x2b, 0xe6, 0x1a, 0xf8, 0x2f, 0x1e, 0xa5, 0xab, 0x84, 0x3a, 0xd1, 0x35, 0xea, 0xd1, 0x3c, 0x82,
+	0xb3, 0x03, 0x7d, 0x22, 0xbb, 0xbf, 0x1a, 0xed, 0x26, 0x04, 0x21, 0xf5, 0x88, 0x5f, 0xfa, 0x3c,
+	0x2d, 0x46, 0x68, 0xcb, 0x5c, 0x43, 0x3d, 0x92, 0x55, 0xc8, 0x53, 0x94, 0x40, 0x6d, 0x4c, 0xd5,
+	0x69, 0x44, 0x4c, 0xb8, 0x86, 0x84, 0x30, 0x7f, 0xc7, 0x80, 0x95, 0x3b, 0xc7, 0xdf, 0xa0, 0xd1,
+	0x30, 0xdf, 0x81, 0xb3, 0x77, 0x8e, 0x47, 0x75, 0x85, 0x1a, 0x26, 0x23, 0x1a, 0x26, 0xf3, 0x25,
+	0xa8, 0x6d, 0x77, 0xa8, 0xe5, 0xab, 0xbd, 0x82, 0x68, 0x9c, 0xc4, 0x30, 0xff, 0x7d, 0x06, 0xc8,
+	0x23, 0xea, 0xda, 0xca, 0xf9, 0xfb, 0x46, 0xb8, 0xd7, 0xea, 0x38, 0x39, 0xab, 0x7b, 0x9c, 0x1c,
+	0x4b, 0xc0, 0xc8, 0x25, 0x13, 0x30, 0x6e, 0x0d, 0xa6, 0x51, 0x4c, 0x5f, 0x25, 0x14, 0x38, 0xb6,
+	0x80, 0x27, 0x4b, 0xf0, 0xcc, 0x1f, 0x1d, 0x47, 0x6e, 0x01, 0xc1, 0x1f, 0x62, 0xf6, 0xcf, 0x59,
+	0x38, 0x93, 0xe8, 0x55, 0xd9, 0xdb, 0xbf, 0x86, 0x09, 0xf2, 0xd2, 0x58, 0x51, 0xd7, 0x6e, 0xd0,
+	0xa0, 0xdf, 0x61, 0xa7, 0xc9, 0x66, 0x7c, 0x3f, 0xa9, 0x2e, 0x53, 0x23, 0x0d, 0x4a, 0x99, 0x8e,
+	0xa1, 0xfa, 0xa0, 0xdf, 0x61, 0xce, 0x08, 0x21, 0xc9, 0x46, 0xa8, 0x1b, 0xc9, 0x9d, 0xc2, 0x90,
+	0xe0, 0x4a, 0x43, 0x70, 0xda, 0x05, 0xd4, 0x65, 0x52, 0xc9, 0xf8, 0xff, 0xe4, 0x1c, 0xe4, 0xf7,
+	0x79, 0xea, 0x27, 0x1f, 0xc5, 0xb9, 0x86, 0x7c, 0x42, 0xc3, 0xb8, 0x14, 0xa6, 0x9d, 0xbf, 0xb8,
+	0xd9, 0x16, 0x8f, 0xd5, 0x64, 0x52, 0xc4, 0x6a, 0xcc, 0xdf, 0x96, 0x1b, 0xcc, 0xaf, 0x40, 0xa6,
+	0x3f, 0x85, 0x96, 0xd1, 0x6c, 0xc3, 0x4a, 0xb2, 0x37, 0x42, 0x1b, 0x97, 0xe7, 0x3d, 0xa6, 0x26,
+	0xc5, 0xd2, 0x40, 0x8c, 0xa3, 0x21, 0x3f, 0x6b, 0xdb, 0xb8, 0x7f, 0x12, 0x0b, 0x5f, 0x3c, 0x49,
+	0xcc, 0xbf, 0x99, 0xc3, 0x6e, 0x35, 0x58, 0x10, 0x99, 0xee, 0x7c, 0xbd, 0xc7, 0x73, 0xf0, 0xf0,
+	0x99, 0x1b, 0x09, 0x71, 0x72, 0xce, 0x57, 0xfc, 0x42, 0x43, 0x3d, 0x92, 0x9b, 0x00, 0x01, 0xb3,
+	0x98, 0x13, 0x30, 0xa7, 0x15, 0x0c, 0x54, 0x4a, 0xc4, 0x93, 0xaa, 0x05, 0x40, 0x23, 0x06, 0x6c,
+	0xfe, 0xa1, 0x01, 0x64, 0x18, 0x04, 0x03, 0x4f, 0xb6, 0xcc, 0x8c, 0x0e, 0xa4, 0x49, 0x8a, 0x5e,
+	0xe0, 0xd7, 0x96, 0xca, 0x83, 0x96, 0x1a, 0x13, 0xbd, 0x88, 0x9b, 0xac, 0x6c, 0xd2, 0x64, 0x45,
+	0x0a, 0x95, 0x8b, 0x2b, 0x14, 0xb9, 0x28, 0x8a, 0x5b, 0x6c, 0x3c, 0x75, 0xe0, 0x2b, 0xdc, 0x9c,
+	0x28, 0x5f, 0xc1, 0x14, 0x6e, 0xec, 0x12, 0x5f, 0x26, 0x65, 0xf3, 0x15, 0x6c, 0xae, 0x11, 0x3e,
+	0x9b, 0xff, 0x23, 0x07, 0x2b, 0x4a, 0xfa, 0x7b, 0x4e, 0xc0, 0x3c, 0xff, 0x44, 0x44, 0xa1, 0xae,
+	0x63, 0xbe, 0x0b, 0xf3, 0x4f, 0xb4, 0x07, 0x80, 0x43, 0x8b, 0x75, 0xfb, 0x6b, 0xcf, 0x66, 0x4c,
+	0x2c, 0x9e, 0xb9, 0x54, 0x8b, 0xe7, 0xa7, 0x50, 0xde, 0xf7, 0xbd, 0x6e, 0x33, 0x9c, 0x6d, 0x5a,
+	0xa5, 0x07, 0x88, 0xb2, 0x2b, 0x67, 0xdc, 0x87, 0x50, 0x64, 0x5e, 0x84, 0x9f, 0xd7, 0xd2, 0x35,
+	0x4f, 0x61, 0x6f, 0xc3, 0x62, 0x98, 0x88, 0xa9, 0x5f, 0x7f, 0x50, 0x56, 0x38, 0x22, 0xd3, 0x3f,
+	0xac, 0x5d, 0x58, 0xd0, 0xaf, 0x5d, 0x88, 0x59, 0x8d, 0x42, 0x0a, 0xab, 0x81, 0x13, 0x43, 0x95,
+	0x56, 0x55, 0x61, 0xfa, 0x18, 0x87, 0xc0, 0xe6, 0x6f, 0x64, 0xa0, 0x1e, 0x79, 0xce, 0x03, 0x93,
+	0xee, 0x4f, 0x6d, 0x48, 0xef, 0x2a, 0xe4, 0xf7, 0xe8, 0xbe, 0xe7, 0xeb, 0x9d, 0x67, 0x4b, 0x58,
+	0xf3, 0x97, 0x33, 0x50, 0x8b, 0x2f, 0xb1, 0x2f, 0xbe, 0x17, 0x66, 0xb5, 0x85, 0x5f, 0x5f, 0x1f,
+	0xfc, 0x9a, 0x01, 0x17, 0x47, 0xf6, 0x81, 0x34, 0x01, 0x98, 0xbc, 0xe1, 0x32, 0xdf, 0x09, 0xcd,
+	0xcd, 0xc5, 0x81, 0x05, 0x39, 0xbe, 0x5e, 0x35, 0x14, 0x2c, 0x0f, 0xba, 0xd1, 0x63, 0xa6, 0x59,
+	0x0a, 0x4b, 0x8f, 0x99, 0xf9, 0xb7, 0x33, 0xf0, 0xf2, 0xe8, 0x30, 0xfd, 0xa9, 0xad, 0xd1, 0x2b,
+	0x18, 0xd8, 0x52, 0xe5, 0x2d, 0xd2, 0x1e, 0xc5, 0xde, 0x90, 0x5f, 0x80, 0x92, 0x13, 0x2b, 0x80,
+	0x91, 0x1b, 0x91, 0xeb, 0x13, 0xcf, 0x0e, 0xa4, 0x50, 0x6b, 0xf1, 0xca, 0x19, 0xb9, 0xc9, 0x4c,
+	0x10, 0xab, 0xed, 0x02, 0x19, 0x86, 0x41, 0x53, 0x91, 0x74, 0x34, 0x0b, 0x31, 0x05, 0x38, 0x07,
+	0x79, 0x9f, 0x5a, 0x81, 0xa7, 0xec, 0xb5, 0x7c, 0x32, 0xff, 0x5f, 0x16, 0xce, 0x6e, 0xf3, 0x0d,
+	0xd5, 0x57, 0xe0, 0x22, 0xad, 0xc0, 0x1c, 0xef, 0x2f, 0xce, 0xb3, 0xd4, 0x10, 0x0f, 0xf1, 0xf3,
+	0xb3, 0xec, 0xac, 0xe7, 0x67, 0xb9, 0x54, 0xe7, 0x67, 0xb7, 0x12, 0xe5, 0x36, 0x6f, 0xaa, 0xd8,
+	0xd7, 0xa8, 0x66, 0x4f, 0x3e, 0x67, 0xca, 0x4f, 0x3f, 0x67, 0x9a, 0x3f, 0xfd, 0x39, 0xd3, 0xc2,
+	0xd7, 0x78, 0xce, 0xf4, 0xaf, 0x33, 0x00, 0x8f, 0x42, 0x69, 0x5e, 0xc4, 0xa0, 0x5f, 0x81, 0xbc,
+	0xec, 0x0a, 0xad, 0x33, 0x81, 0x43, 0xde, 0x07, 0xb7, 0xa0, 0x60, 0x75, 0xda, 0x9e, 0xef, 0xb0,
+	0x83, 0xae, 0x9e, 0x47, 0x1c, 0x82, 0x93, 0x97, 0x01, 0x7a, 0xfd, 0xbd, 0x8e, 0xd3, 0xc2, 0x21,
+	0x90, 0x3b, 0xc4, 0x82, 0x78, 0x83, 0x4d, 0xba, 0x06, 0x0b, 0x2d, 0xcb, 0xe5, 0x55, 0xd9, 0x3a,
+	0x49, 0x84, 0x2d, 0xcb, 0xc5, 0xfe, 0x98, 0x31, 0x31, 0xcd, 0xfc, 0x2d, 0x03, 0x96, 0xa3, 0xfe,
+	0x7c, 0x81, 0xba, 0x34, 0x4b, 0xb7, 0x9a, 0xbf, 0x20, 0xa2, 0xc2, 0x91, 0x40, 0x2f, 0x30, 0xba,
+	0x61, 0x7e, 0x0a, 0xe7, 0x87, 0x88, 0xcb, 0x65, 0xf5, 0x0d, 0xc8, 0x1d, 0xd2, 0x93, 0xc1, 0x60,
+	0x73, 0xac, 0x5f, 0xf8, 0x67, 0xf3, 0xf7, 0x0c, 0x11, 0xb6, 0x94, 0xd5, 0x1e, 0x0a, 0xfb, 0x05,
+	0xf4, 0xd6, 0xa5, 0x28, 0x0b, 0x24, 0x93, 0x08, 0xda, 0x49, 0x56, 0xea, 0xeb, 0xa8, 0x0d, 0x4d,
+	0x76, 0xd4, 0x86, 0xe6, 0x37, 0x33, 0xb0, 0x1c, 0x17, 0xf5, 0xcf, 0xf4, 0x36, 0x12, 0xc3, 0xdf,
+	0x2f, 0xbc, 0x23, 0x12, 0xe9, 0xcb, 0x99, 0x34, 0xe9, 0xcb, 0xe6, 0xef, 0x1b, 0xb0, 0x28, 0xe4,
+	0xb9, 0xef, 0xb5, 0xc5, 0x1a, 0xb8, 0x21, 0x37, 0x2b, 0x86, 0x46, 0x59, 0x0e, 0x87, 0x9c, 0x35,
+	0xd8, 0x82, 0x2e, 0x84, 0x4f, 0x7b, 0x34, 0xdc, 0xd4, 0x4d, 0x1b, 0x3f, 0x05, 0x6c, 0x5e, 0x07,
+	0x08, 0x85, 0x0e, 0x30, 0xf1, 0xa6, 0xe3, 0x85, 0xd7, 0xbd, 0x9c, 0x4d, 0x4c, 0x57, 0xd5, 0xaa,
+	0x06, 0x07, 0x31, 0xff, 0x63, 0x4e, 0x15, 0xca, 0xe0, 0x26, 0xa1, 0x1f, 0xfc, 0x6c, 0x7b, 0x3f,
+	0x9e, 0xa4, 0x9d, 0xd5, 0x4f, 0xd2, 0xfe, 0x10, 0x8a, 0x3c, 0xbe, 0xd4, 0x6c, 0x79, 0x7d, 0x97,
+	0x55, 0x73, 0xd3, 0x7b, 0x0e, 0x38, 0xfc, 0x36, 0x82, 0xa3, 0xb8, 0xfb, 0x9e, 0x7f, 0x64, 0xf9,
+	0x76, 0x98, 0x1a, 0x3e, 0x11, 0x37, 0x82, 0x16, 0xe3, 0x25, 0x8b, 0xb6, 0xf2, 0x5a, 0xe3, 0x25,
+	0x80, 0xf1, 0x08, 0xd7, 0xa7, 0x3c, 0x7e, 0xd8, 0x75, 0x58, 0xa0, 0x53, 0x0d, 0x13, 0x87, 0x47,
+	0x91, 0xd9, 0x81, 0xef, 0x31, 0xd6, 0x99, 0x9c, 0x7b, 0x1c, 0x8a, 0x1c, 0x42, 0xe3, 0xda, 0xff,
+	0x45, 0x9f, 0xf6, 0xa9, 0x5d, 0x2d, 0x4c, 0xc7, 0x93, 0xa0, 0x3c, 0xfd, 0xce, 0xf7, 0x7a, 0x3d,
+	0x6a, 0x57, 0x61, 0x3a, 0x96, 0x82, 0x35, 0xff, 0x67, 0x46, 0x15, 0x73, 0x3d, 0xa6, 0xc1, 0x37,
+	0x43, 0xbf, 0x63, 0x55, 0x82, 0xd9, 0x09, 0x55, 0x82, 0xa7, 0x09, 0x16, 0x7c, 0x0c, 0x25, 0xa9,
+	0xcf, 0x4d, 0xbe, 0x6c, 0x68, 0xe4, 0x64, 0x14, 0x25, 0x02, 0x16, 0xd4, 0x63, 0x67, 0xab, 0x10,
+	0xf5, 0xb8, 0x39, 0xc5, 0x73, 0x04, 0x65, 0x67, 0x4b, 0x58, 0xf3, 0x9f, 0x65, 0xd4, 0xc2, 0xf5,
+	0xf8, 0xfe, 0xa3, 0xc7, 0xbe, 0xd5, 0xa2, 0x98, 0x26, 0x7a, 0x60, 0xb9, 0x76, 0x70, 0x60, 0x1d,
+	0xd2, 0xa6, 0x8a, 0x1c, 0x55, 0x8d, 0xa9, 0x8a, 0xb5, 0x1c, 0x62, 0xa9, 0x3a, 0xfc, 0x99, 0x13,
+	0xd5, 0x3e, 0x81, 0x52, 0xcb, 0xe9, 0x1d, 0x60, 0x4d, 0x4c, 0xdf, 0x61, 0x7a, 0xc9, 0x6a, 0x45,
+	0x81, 0xf1, 0x08, 0x11, 0x50, 0x53, 0x02, 0xea, 0x3f, 0x4d, 0x53, 0xf5, 0x06, 0x02, 0xe1, 0xbb,
+	0x2a, 0x2b, 0x1c, 0x55, 0x5d, 0x33, 0x2b, 0x1c, 0x41, 0xcd, 0x3f, 0xcc, 0x40, 0x25, 0x9a, 0xb6,
+	0x8f, 0x9d, 0xae, 0xe3, 0xb6, 0xd1, 0xc8, 0xd9, 0x6e, 0xd0, 0xec, 0x78, 0xde, 0x61, 0xbf, 0xa7,
+	0x65, 0x0a, 0x0a, 0xb6, 0x1b, 0xdc, 0xe7, 0xe0, 0xd8, 0x7b, 0x32, 0x15, 0x41, 0xeb, 0x9a, 0x11,
+	0x05, 0x8c, 0xaa, 0xc2, 0x3a, 0x41, 0x33, 0x1c, 0x8e, 0x6a, 0x56, 0x03, 0xbb, 0xc4, 0x3a, 0xc1,
+	0x3d, 0x85, 0x81, 0x72, 0xef, 0x3b, 0x7e, 0xc0, 0x78, 0x26, 0xaa, 0x56, 0x65, 0x69, 0x81, 0xc3,
+	0xe3, 0x14, 0x13, 0xf5, 0x1d, 0xcc, 0x1a, 0x9f, 0xd3, 0x12, 0xc7, 0x13, 0xa0, 0xe6, 0xdf, 0xcd,
+	0x01, 0x89, 0x2b, 0x7d, 0x98, 0x57, 0x34, 0x1f, 0xf4, 0x5b, 0x2d, 0x1a, 0x04, 0x1a, 0x13, 0x50,
+	0x81, 0xce, 0x6c, 0x48, 0xb7, 0x61, 0x51, 0x54, 0x75, 0x37, 0x2d, 0xdb, 0xf6, 0xa9, 0x6e, 0xdd,
+	0xa5, 0xc0, 0xd9, 0x12, 0x28, 0xe4, 0x12, 0x64, 0x59, 0x47, 0x05, 0x7a, 0x93, 0x56, 0x54, 0xa9,
+	0x58, 0x03, 0x21, 0xc8, 0x1d, 0xa8, 0x1c, 0x30, 0xd6, 0xe3, 0xa1, 0x39, 0x5e, 0x2a, 0x6d, 0x53,
+	0x1d, 0x43, 0xb2, 0x88, 0x48, 0xc2, 0xec, 0x6e, 0x63, 0xe9, 0xf8, 0x77, 0x80, 0x70, 0x32, 0xbe,
+	0xec, 0xb3, 0xe6, 0x9e, 0x67, 0x9f, 0x68, 0x85, 0x0a, 0x39, 0x7b, 0xd5, 0xd5, 0xb7, 0x3d, 0xfb,
+	0x04, 0x45, 0xc2, 0x22, 0xa1, 0xa6, 0x4f, 0x59, 0xdf, 0x77, 0x85, 0x48, 0x1a, 0x56, 0x66, 0x11,
+	0x91, 0x1a, 0x1c, 0x87, 0x8b, 0xb4, 0x0e, 0x79, 0xc6, 0xe7, 0xbf, 0xb4, 0x32, 0xc9, 0x02, 0xa8,
+	0x48, 0x3d, 0x1a, 0x12, 0x8c, 0xbc, 0x2b, 0xcf, 0x15, 0x93, 0x77, 0x93, 0x0c, 0xa7, 0x8e, 0x89,
+	0x13, 0xc7, 0x9f, 0x1a, 0x50, 0x08, 0xaf, 0x24, 0x22, 0x6b, 0xf2, 0x4a, 0x84, 0xe9, 0xf3, 0x83,
+	0xc3, 0x09, 0x78, 0xea, 0x68, 0x94, 0x2b, 0x71, 0x38, 0x3c, 0x86, 0xee, 0x06, 0x4e, 0x60, 0xbb,
+	0x1a, 0xbe, 0x85, 0x84, 0x24, 0xef, 0xc3, 0x02, 0xbf, 0xf1, 0x07, 0x17, 0xbe, 0xe9, 0x87, 0xd7,
+	0x21, 0xac, 0x79, 0x06, 0x96, 0x1f, 0x9d, 0x04, 0x8c, 0x76, 0x77, 0xdd, 0x7d, 0x4f, 0x5a, 0x3e,
+	0xf3, 0x9f, 0xe3, 0x11, 0x6a, 0xec, 0xad, 0x54, 0x8d, 0xd8, 0xda, 0x6a, 0xa4, 0x59, 0x5b, 0x3f,
+	0x00, 0xd8, 0xeb, 0x3b, 0x1d, 0x1b, 0xcb, 0xb3, 0xf5, 0xf4, 0xa3, 0xc0, 0xe1, 0x77, 0x2c, 0x86,
+	0x75, 0x8f, 0x25, 0x9f, 0x76, 0xa8, 0x15, 0xd0, 0xa6, 0x76, 0x16, 0x71, 0x51, 0x62, 0xc8, 0x5a,
+	0x59, 0x62, 0xd3, 0x7d, 0xab, 0xdf, 0x61, 0xcd, 0xd8, 0x75, 0x53, 0xb9, 0x31, 0xd7, 0x4d, 0x55,
+	0x24, 0x6c, 0x34, 0xda, 0x1f, 0xc2, 0xf2, 0xbe, 0xe7, 0xb7, 0xa8, 0x1d, 0x47, 0x9f, 0x1b, 0x83,
+	0xbe, 0x24, 0x40, 0xc3, 0x17, 0xe6, 0xbf, 0x34, 0xa0, 0xb2, 0xd3, 0xef, 0xf6, 0xa8, 0x1d, 0xbb,
+	0x73, 0x2b, 0x79, 0x69, 0x80, 0xa1, 0x73, 0x69, 0xc0, 0xe5, 0x28, 0x23, 0x43, 0x6c, 0xee, 0x54,
+	0x0d, 0xa1, 0x20, 0x3e, 0x98, 0x97, 0x71, 0x29, 0x5e, 0x11, 0x30, 0x69, 0x2f, 0xf8, 0x4e, 0xe2,
+	0x4e, 0xad, 0x91, 0xe7, 0x60, 0x21, 0x80, 0xd9, 0x82, 0x52, 0x9c, 0x5d, 0xec, 0x32, 0x01, 0x63,
+	0xd2, 0x65, 0x02, 0x4a, 0xd7, 0x32, 0x53, 0xd2, 0x34, 0x85, 0xae, 0x2d, 0xc3, 0x12, 0xbe, 0x44,
+	0x46, 0x6a, 0x3e, 0xfe, 0x53, 0xec, 0xc4, 0xf0, 0x9d, 0x9c, 0x8d, 0x37, 0x47, 0xe5, 0x78, 0x9d,
+	0x4f, 0xf4, 0xca, 0xb8, 0x4c, 0xaf, 0x77, 0x61, 0x5e, 0xe6, 0x19, 0xca, 0xd9, 0x18, 0xde, 0x32,
+	0x10, 0xa5, 0x86, 0x36, 0x14, 0x08, 0x79, 0x0d, 0xe6, 0x18, 0xb5, 0xba, 0xaa, 0x27, 0x8b, 0xb1,
+	0x14, 0xfd, 0x86, 0xf8, 0x42, 0x5e, 0x87, 0x3c, 0xdf, 0x91, 0xaa, 0x6a, 0xc1, 0x52, 0xbc, 0x5a,
+	0xb0, 0x21, 0xbf, 0x99, 0xff, 0xd7, 0x80, 0xb3, 0x22, 0xa3, 0x6b, 0xa0, 0x81, 0xe4, 0x23, 0x1e,
+	0x39, 0xed, 0xf4, 0x6d, 0xda, 0x0c, 0xb3, 0x1d, 0xa6, 0x94, 0x73, 0x4b, 0x78, 0xa4, 0x14, 0x65,
+	0xe2, 0x66, 0xd2, 0x67, 0xe2, 0x66, 0x75, 0x33, 0x71, 0x93, 0xbb, 0xf6, 0x5c, 0x8a, 0x5d, 0x3b,
+	0x8e, 0xdf, 0xa2, 0x18, 0x11, 0x39, 0xd4, 0xc1, 0xcf, 0xf8, 0x68, 0x24, 0x9e, 0x3c, 0x9c, 0xd5,
+	0x4e, 0x1e, 0xde, 0x82, 0xa2, 0x1a, 0xb8, 0x3b, 0xae, 0x1d, 0xb9, 0x6c, 0x86, 0xbe, 0xcb, 0xf6,
+	0x1b, 0x59, 0x28, 0x2b, 0x1a, 0xdb, 0x07, 0x7d, 0xf7, 0x30, 0x3e, 0x17, 0x8d, 0xe9, 0x73, 0xf1,
+	0x55, 0xc8, 0xe1, 0x8c, 0x93, 0xcd, 0x4d, 0x4c, 0x45, 0xfe, 0x81, 0x98, 0xc9, 0x4a, 0xd7, 0xe4,
+	0x44, 0x14, 0x9f, 0x06, 0x96, 0x9f, 0x9c, 0xce, 0xf2, 0x13, 0x5f, 0x26, 0xc4, 0xda, 0x37, 0x7e,
+	0x99, 0x88, 0x6d, 0x5d, 0xf2, 0x93, 0xb6, 0x2e, 0xd1, 0xea, 0x31, 0x3f, 0xf9, 0x86, 0x95, 0x68,
+	0xac, 0x16, 0x12, 0x2e, 0x4e, 0x72, 0x4a, 0x45, 0x03, 0x45, 0x5e, 0x87, 0x2c, 0x75, 0xd5, 0xc6,
+	0x51, 0xf5, 0x67, 0x6c, 0xe8, 0x1a, 0xf8, 0xd9, 0xfc, 0x03, 0x03, 0x08, 0xbe, 0x6c, 0xd0, 0x80,
+	0x79, 0xd1, 0x19, 0xc0, 0x8c, 0x45, 0x44, 0xef, 0x40, 0xce, 0xee, 0x77, 0x7b, 0x72, 0x64, 0xce,
+	0x0f, 0x30, 0x55, 0xab, 0x56, 0x83, 0x03, 0x0d, 0xe9, 0x7b, 0x36, 0x95, 0xbe, 0x9b, 0xff, 0xdd,
+	0x00, 0x22, 0xa5, 0x8e, 0x9b, 0x95, 0x0d, 0x58, 0x91, 0xb7, 0x99, 0x0c, 0xab, 0x56, 0xa1, 0x41,
+	0xc4, 0xb7, 0xc4, 0x25, 0x37, 0xc9, 0x99, 0x90, 0xd1, 0x99, 0x09, 0xd5, 0xc8, 0x10, 0xc9, 0xe3,
+	0x7c, 0xf9, 0x88, 0x5f, 0x94, 0xcd, 0x11, 0xe7, 0xf9, 0xea, 0x11, 0xcf, 0xec, 0x13, 0xb3, 0x67,
+	0x2e, 0x36, 0x59, 0x6a, 0xb1, 0xe1, 0x95, 0xe7, 0xf9, 0xa1, 0xc2, 0xf5, 0xe0, 0x4c, 0x62, 0x80,
+	0xe4, 0xca, 0xff, 0xc1, 0xa8, 0x95, 0xff, 0x42, 0x74, 0x69, 0xcb, 0x40, 0xbf, 0x24, 0xd7, 0x7e,
+	0x9e, 0xac, 0xe0, 0xee, 0x77, 0x9c, 0x96, 0x8c, 0x93, 0x16, 0x1a, 0xd1, 0x0b, 0x73, 0x05, 0x48,
+	0x5c, 0xef, 0xa4, 0xfd, 0xd9, 0x81, 0xe2, 0xe3, 0x58, 0x1e, 0xee, 0x6c, 0x33, 0x04, 0x0d, 0x1b,
+	0x46, 0x53, 0x63, 0x94, 0xcc, 0xcb, 0xb0, 0x80, 0x8f, 0xf8, 0x3a, 0x32, 0x33, 0xc6, 0x38, 0x33,
+	0x63, 0xfe, 0x6f, 0x03, 0x4a, 0x4f, 0xe2, 0x49, 0x6d, 0x33, 0xce, 0xd5, 0x17, 0x70, 0xf5, 0x41,
+	0x68, 0x72, 0xb2, 0xe9, 0x4d, 0x4e, 0x4e, 0xbb, 0xf8, 0xe3, 0xef, 0xf0, 0x72, 0x0d, 0xde, 0xe0,
+	0x96, 0xe7, 0x8f, 0x10, 0x3c, 0xbd, 0xd5, 0x58, 0xc7, 0xab, 0x60, 0xfa, 0xbe, 0x56, 0x46, 0x07,
+	0x02, 0x26, 0xf3, 0xed, 0xb2, 0xe9, 0xf2, 0xed, 0x76, 0x60, 0x49, 0x56, 0x47, 0x86, 0x73, 0x5c,
+	0xa3, 0xf1, 0x8b, 0x02, 0x27, 0xb4, 0x95, 0x51, 0x8d, 0xa5, 0xa8, 0xd2, 0xd4, 0x09, 0xd4, 0x08,
+	0x04, 0x55, 0x3a, 0xbb, 0x1c, 0xd6, 0x58, 0x26, 0x74, 0x6d, 0x5a, 0x11, 0xae, 0xc2, 0x0a, 0x25,
+	0x89, 0x57, 0x6b, 0x0a, 0x59, 0x34, 0xaa, 0xdd, 0xc2, 0x6a, 0x4d, 0x21, 0xcd, 0x0e, 0x2c, 0xf9,
+	0x96, 0xed, 0x60, 0x86, 0x08, 0x0d, 0x02, 0xae, 0xc1, 0x1a, 0xb7, 0x12, 0x2c, 0x0a, 0x9c, 0x47,
+	0x12, 0x65, 0x44, 0xed, 0x6a, 0x21, 0x75, 0xed, 0xea, 0x3d, 0x58, 0x96, 0xd1, 0x39, 0x9b, 0x76,
+	0x1c, 0x2c, 0x95, 0xa1, 0x81, 0x4e, 0x16, 0x48, 0x45, 0x60, 0xed, 0x84, 0x48, 0xe6, 0x4f, 0x0c,
+	0x28, 0x27, 0x53, 0xbe, 0x66, 0xd4, 0xcc, 0x77, 0x61, 0xde, 0xe7, 0x53, 0x5d, 0xb9, 0xf9, 0x91,
+	0x37, 0x10, 0x6a, 0x41, 0x43, 0x81, 0x90, 0xb7, 0x54, 0xd8, 0x23, 0x5b, 0x37, 0xc6, 0xc0, 0xca,
+	0x60, 0xc7, 0x7f, 0x9a, 0x03, 0xd8, 0xea, 0xdb, 0x0e, 0x13, 0x17, 0xb7, 0x61, 0x3e, 0xd4, 0x53,
+	0x79, 0x0d, 0x8e, 0x5e, 0x3e, 0xd4, 0x53, 0x71, 0x0b, 0x4e, 0xea, 0x7c, 0xa8, 0x58, 0x3f, 0x64,
+	0x53, 0xf4, 0x03, 0x56, 0x7f, 0x8a, 0xab, 0x41, 0xf4, 0xaa, 0x3f, 0x39, 0x6c, 0xfc, 0xb2, 0x88,
+	0xb9, 0x14, 0x97, 0x45, 0x5c, 0x87, 0x05, 0xee, 0x18, 0xe9, 0x26, 0x3c, 0xcd, 0x73, 0xe8, 0x5d,
+	0x1e, 0x1d, 0xe7, 0x17, 0x04, 0x74, 0x29, 0x3b, 0xf0, 0xf4, 0x8e, 0xc1, 0x01, 0x11, 0x1e, 0x70,
+	0x78, 0x6c, 0xa4, 0x25, 0x2c, 0xaf, 0x4e, 0xa6, 0x93, 0x84, 0xc5, 0x35, 0x30, 0xbc, 0xb5, 0x8c,
+	0xdf, 0x4c, 0xa0, 0x93, 0xf0, 0x54, 0x52, 0x28, 0xfc, 0xfa, 0x16, 0x1e, 0xd5, 0x97, 0x24, 0x34,
+	0xcb, 0x44, 0x41, 0x21, 0x88, 0xc1, 0x91, 0x19, 0x32, 0x45, 0xfd, 0x0c, 0x19, 0x74, 0x97, 0xad,
+	0x7d, 0x3c, 0x78, 0xd3, 0xb9, 0x49, 0x41, 0x80, 0x92, 0x37, 0x60, 0xb1, 0x75, 0x60, 0xb9, 0x6d,
+	0xb5, 0xf9, 0x0e, 0xaa, 0x65, 0x6e, 0xb1, 0xcb, 0xf2, 0x2d, 0xdf, 0x67, 0x07, 0xe6, 0x3f, 0x32,
+	0xc4, 0x99, 0x6f, 0x34, 0xc3, 0x83, 0x53, 0x5a, 0xc8, 0x30, 0x6f, 0x28, 0x33, 0x43, 0xde, 0x50,
+	0x36, 0x45, 0xde, 0xd0, 0xdf, 0x37, 0xe0, 0xfc, 0x90, 0xe8, 0xa7, 0x5b, 0x43, 0xde, 0x86, 0x3c,
+	0x57, 0x57, 0xb5, 0x84, 0x28, 0x87, 0x2e, 0x62, 0xd1, 0x90, 0x00, 0x61, 0x7a, 0x51, 0x56, 0x3b,
+	0xbd, 0xe8, 0x39, 0xfe, 0xda, 0x03, 0x2f, 0x75, 0x3f, 0x5d, 0x07, 0xc7, 0x54, 0x35, 0xa3, 0xaf,
+	0xaa, 0xe6, 0x11, 0xe4, 0x77, 0xdd, 0xa7, 0x0e, 0xa3, 0x33, 0xdc, 0x78, 0x89, 0x75, 0x79, 0x3e,
+	0x4d, 0x73, 0x8d, 0x76, 0x41, 0xc2, 0x6f, 0x31, 0xbc, 0xd0, 0x43, 0x30, 0x56, 0x17, 0x7a, 0x38,
+	0xfc, 0x69, 0xb0, 0x3e, 0x46, 0xc0, 0x34, 0xd4, 0x57, 0xf3, 0x18, 0xca, 0xf2, 0xd5, 0xe9, 0xba,
+	0x4b, 0xb5, 0x36, 0xa3, 0xdb, 0x5a, 0xf3, 0x2e, 0x9c, 0xd9, 0x6a, 0xb5, 0x68, 0x8f, 0x25, 0xf9,
+	0xa7, 0xee, 0x36, 0xf3, 0x1c, 0xac, 0x88, 0x8a, 0x44, 0x45, 0x48, 0x66, 0xfe, 0xdf, 0x03, 0x22,
+	0xde, 0x8b, 0xbd, 0xa5, 0xa4, 0x1f, 0xde, 0xb4, 0x64, 0x68, 0xdf, 0xb4, 0x84, 0xa5, 0x05, 0x09,
+	0x4a, 0x92, 0x01, 0x81, 0x0a, 0xf7, 0x97, 0x63, 0xe4, 0xcd, 0xf7, 0xa0, 0xc0, 0x9f, 0xf9, 0x28,
+	0x44, 0x51, 0x17, 0x63, 0x42, 0xd4, 0xe5, 0x36, 0x94, 0x4e, 0x2d, 0xe1, 0x1f, 0xe1, 0x86, 0xcb,
+	0x63, 0xd6, 0xe9, 0x1b, 0x8b, 0xce, 0x5c, 0xdb, 0xb7, 0x5a, 0x14, 0xaf, 0x1d, 0x71, 0x3c, 0x5b,
+	0xc7, 0x92, 0x16, 0x39, 0xc2, 0x43, 0x0e, 0x1f, 0xbf, 0x05, 0x2a, 0xab, 0x7f, 0x0b, 0xd4, 0xe6,
+	0x1f, 0x37, 0x60, 0xee, 0x9e, 0xe7, 0xdb, 0x94, 0x7c, 0x0e, 0x15, 0x91, 0x05, 0x16, 0xdb, 0x39,
+	0x0e, 0xef, 0xf9, 0x6a, 0xc3, 0xaf, 0xcc, 0xf3, 0xbf, 0xf2, 0x27, 0xff, 0xed, 0xaf, 0x67, 0x96,
+	0xcd, 0xd2, 0x7a, 0x6c, 0x43, 0x75, 0xcb, 0x58, 0x25, 0x96, 0xfa, 0x09, 0x94, 0xd4, 0x24, 0x2f,
+	0x71, 0x92, 0xaf, 0x6d, 0xbe, 0x14, 0x27, 0xb9, 0xfe, 0x2c, 0xe1, 0xe4, 0x3f, 0x47, 0x16, 0x87,
+	0x50, 0x19, 0xac, 0x8b, 0x25, 0xaf, 0x84, 0x01, 0x83, 0x91, 0x05, 0xb3, 0xa3, 0xf8, 0xbd, 0xce,
+	0xf9, 0xbd, 0xb2, 0x3a, 0x91, 0x1f, 0xb1, 0xc5, 0x4e, 0x2d, 0x7e, 0x0f, 0x8e, 0xca, 0x4c, 0x1b,
+	0x59, 0x3d, 0x5b, 0x7b, 0x79, 0xcc, 0x57, 0x39, 0x93, 0x57, 0x38, 0xd7, 0x45, 0x92, 0xe8, 0x38,
+	0xe2, 0xe1, 0x26, 0x7e, 0xb0, 0x8e, 0x94, 0xd4, 0xc3, 0x7d, 0xec, 0x98, 0x12, 0xd3, 0x09, 0xcd,
+	0x22, 0x93, 0x9b, 0xf5, 0x4b, 0x83, 0xf5, 0xb2, 0xa1, 0x5f, 0x5f, 0x8b, 0xc9, 0x3f, 0x70, 0x2f,
+	0x41, 0xed, 0xe2, 0xc8, 0x6f, 0xb2, 0x65, 0x6f, 0x73, 0xc6, 0xdf, 0x26, 0xaf, 0x4d, 0x62, 0xbc,
+	0xce, 0x8b, 0xeb, 0xbe, 0x84, 0xca, 0x6d, 0xdf, 0xb3, 0xec, 0x96, 0x15, 0xd2, 0x21, 0x6a, 0xd3,
+	0x3e, 0x5c, 0xaf, 0x55, 0x7b, 0x55, 0x7e, 0x1a, 0x57, 0xd4, 0x63, 0xae, 0x72, 0xd6, 0xaf, 0x9b,
+	0xaf, 0x4e, 0x64, 0xcd, 0x3c, 0x9c, 0x3d, 0xdf, 0x09, 0x7f, 0xa3, 0x48, 0xc4, 0x5f, 0xc9, 0xc5,
+	0x81, 0x0a, 0xa0, 0x78, 0x9d, 0x6d, 0x6d, 0x6c, 0x2c, 0xd0, 0xfc, 0xd6, 0x86, 0x41, 0xf6, 0x81,
+	0x24, 0x7b, 0x11, 0x93, 0x10, 0xc3, 0xe9, 0x1e, 0xfd, 0x08, 0x4e, 0x8d, 0x0c, 0xff, 0x7c, 0x91,
+	0x66, 0x7f, 0xf1, 0x24, 0xcc, 0x2f, 0x60, 0x65, 0x50, 0xa9, 0x38, 0xa7, 0xf3, 0x63, 0x7e, 0x0f,
+	0x68, 0x24, 0xbf, 0x77, 0x39, 0xbf, 0x37, 0x37, 0xa7, 0xf3, 0xc3, 0x6e, 0xea, 0x41, 0xe5, 0x2e,
+	0x4d, 0xb6, 0x6c, 0x54, 0xc3, 0xce, 0x47, 0xaf, 0x12, 0xbf, 0x9f, 0x64, 0x6e, 0x70, 0x6e, 0xab,
+	0xe4, 0xad, 0xa9, 0xdc, 0xd6, 0x9f, 0xe1, 0x61, 0xce, 0x73, 0x12, 0xa8, 0xa5, 0xff, 0xd4, 0x4c,
+	0x57, 0xf5, 0x99, 0x7e, 0xa9, 0xee, 0x6e, 0x9f, 0x9d, 0xe9, 0x75, 0xce, 0xf4, 0xbd, 0x4d, 0x6d,
+	0xa6, 0xb7, 0xe4, 0xaf, 0x0e, 0xfd, 0x22, 0x94, 0xc4, 0xea, 0x2b, 0x8f, 0x50, 0x92, 0x41, 0xcf,
+	0x5a, 0xf2, 0xd1, 0x5c, 0xe7, 0x6c, 0xde, 0x36, 0x5f, 0x9f, 0xac, 0x5e, 0x1c, 0x98, 0x8f, 0xa0,
+	0x07, 0x8b, 0x6a, 0xe1, 0x90, 0x0c, 0x56, 0x12, 0x14, 0x55, 0xc3, 0x06, 0xf8, 0xdc, 0xe0, 0x7c,
+	0x36, 0xc9, 0x86, 0x0e, 0x9f, 0xf5, 0x67, 0x61, 0xd8, 0xfd, 0x39, 0xf9, 0x8b, 0xea, 0x57, 0x11,
+	0x24, 0xbb, 0xda, 0xf8, 0xcb, 0xdb, 0x07, 0x99, 0xee, 0x70, 0xa6, 0x1f, 0x6f, 0xde, 0x4c, 0x32,
+	0x1d, 0x7d, 0x7f, 0xfe, 0x48, 0xee, 0xd8, 0xe2, 0x2e, 0x94, 0xc4, 0x0c, 0x9a, 0xa1, 0xbd, 0xab,
+	0xe9, 0xdb, 0xeb, 0x43, 0x31, 0x56, 0x30, 0x1d, 0x2e, 0x60, 0xc3, 0x85, 0xe8, 0xb5, 0xda, 0xa8,
+	0x4f, 0x49, 0xb5, 0x24, 0x5a, 0xe3, 0x4a, 0xbe, 0x84, 0x72, 0xa2, 0x4e, 0x38, 0x5c, 0xbd, 0x46,
+	0x55, 0x54, 0xd7, 0x5e, 0x1a, 0xfd, 0x51, 0x72, 0x5e, 0xe3, 0x9c, 0xdf, 0x32, 0xbf, 0x3d, 0x91,
+	0xb3, 0xc3, 0x71, 0xb1, 0x7b, 0x4f, 0xa0, 0x7c, 0xe7, 0x78, 0x14, 0xef, 0x3b, 0xc7, 0x13, 0x78,
+	0x8f, 0xac, 0xe5, 0x35, 0xdf, 0xe1, 0xbc, 0xdf, 0x20, 0x93, 0x79, 0x53, 0x8e, 0xbb, 0x61, 0x90,
+	0xdf, 0x36, 0xe2, 0x05, 0xfe, 0xa7, 0xb7, 0x55, 0x1f, 0x71, 0xf6, 0xd7, 0xc9, 0xb5, 0xb4, 0x83,
+	0x2e, 0xec, 0xd7, 0x8f, 0x0d, 0x28, 0xc6, 0xec, 0xd0, 0x24, 0xdb, 0x55, 0x1b, 0xf5, 0x49, 0x4a,
+	0xf1, 0x31, 0x97, 0xe2, 0x86, 0x79, 0x25, 0xb5, 0x14, 0xc2, 0x94, 0xfd, 0x8e, 0x01, 0x64, 0xb8,
+	0xfa, 0x79, 0xcc, 0xb4, 0x57, 0xbf, 0x1d, 0x37, 0xa1, 0x5c, 0xfa, 0x53, 0x2e, 0xcf, 0xad, 0xd5,
+	0x1b, 0xa9, 0xe5, 0xd9, 0x3f, 0xe2, 0x67, 0x5a, 0xe4, 0xf7, 0x0d, 0xb8, 0x30, 0xb6, 0x14, 0x8a,
+	0x5c, 0x1a, 0x52, 0x83, 0xd1, 0x65, 0x42, 0x35, 0x33, 0x06, 0x38, 0xa6, 0x8a, 0xc6, 0xdc, 0xe5,
+	0xc2, 0x6e, 0x93, 0xad, 0xf4, 0xc2, 0x4a, 0x8a, 0xeb, 0x07, 0x52, 0xae, 0x23, 0x58, 0x8c, 0x44,
+	0x4a, 0x63, 0xc2, 0xe5, 0x00, 0x92, 0xf7, 0xf5, 0x64, 0x88, 0x7e, 0xd7, 0x4e, 0xda, 0xf5, 0x5f,
+	0x31, 0x94, 0xb7, 0x1c, 0xe3, 0x9d, 0xca, 0xa8, 0x6f, 0x71, 0x09, 0x3e, 0xd8, 0x9c, 0x51, 0x02,
+	0x9c, 0x45, 0xbf, 0x6c, 0x40, 0xe9, 0x2e, 0x8d, 0x5a, 0x9f, 0xca, 0xf8, 0xdd, 0xe1, 0xfc, 0x3f,
+	0x21, 0x1f, 0xcd, 0xc6, 0x5f, 0x99, 0xe1, 0x1f, 0x1b, 0xb0, 0x14, 0x5f, 0xba, 0x67, 0x14, 0x63,
+	0xf5, 0x94, 0x62, 0xfc, 0x96, 0x01, 0x4b, 0x03, 0xe3, 0x91, 0x4a, 0x8c, 0xfb, 0x5c, 0x8c, 0xcf,
+	0x36, 0x4f, 0x27, 0x86, 0xf2, 0x0f, 0xbe, 0x80, 0xc5, 0x64, 0x8d, 0x4e, 0xb8, 0xf3, 0x18, 0x59,
+	0xba, 0x53, 0x1b, 0x3c, 0x86, 0x55, 0xee, 0x90, 0xf9, 0xc6, 0x44, 0x71, 0x94, 0x3a, 0xe0, 0x5c,
+	0xe8, 0x43, 0x45, 0xf9, 0x0c, 0x21, 0xd3, 0x73, 0x03, 0x64, 0xc7, 0xb2, 0xd3, 0xf3, 0x1c, 0x42,
+	0xed, 0x7b, 0xa6, 0x2a, 0xcf, 0x9e, 0xa3, 0xab, 0x22, 0x7f, 0x4f, 0x49, 0x31, 0x1d, 0x24, 0x3e,
+	0xcc, 0xed, 0x03, 0xce, 0xed, 0xda, 0x66, 0x6a, 0x6e, 0xd8, 0xce, 0x00, 0x16, 0xc5, 0x74, 0x9b,
+	0xb9, 0x95, 0xab, 0xe9, 0x5b, 0xf9, 0x14, 0x4a, 0xf1, 0x05, 0x2d, 0x61, 0xbd, 0x06, 0xd9, 0x5e,
+	0x1c, 0xf9, 0x4d, 0x4e, 0xb3, 0xcb, 0x5c, 0x84, 0x4b, 0x44, 0x6f, 0x5c, 0xc9, 0xaf, 0xc6, 0x7e,
+	0x40, 0x4b, 0x5c, 0x4d, 0x3a, 0xae, 0xb1, 0x83, 0x35, 0x57, 0x4f, 0x46, 0x99, 0xab, 0xcd, 0xf7,
+	0xb5, 0xd8, 0xc6, 0x5a, 0xbe, 0xce, 0xef, 0xad, 0x24, 0x3f, 0x31, 0xe0, 0xcc, 0x88, 0x15, 0x9d,
+	0xbc, 0x36, 0x69, 0xb5, 0xd7, 0x37, 0x08, 0xd2, 0x7a, 0x91, 0x1b, 0xa9, 0xc5, 0x53, 0x76, 0xe0,
+	0x77, 0x0d, 0xa8, 0xf1, 0x1f, 0x24, 0x18, 0x7d, 0xf3, 0xe0, 0xb8, 0x5e, 0x7b, 0x5d, 0xa7, 0xaa,
+	0xd1, 0xfc, 0x8c, 0x8b, 0xf7, 0x29, 0xf9, 0x38, 0xb5, 0x78, 0x89, 0x52, 0x37, 0xd2, 0x55, 0x31,
+	0x9b, 0x58, 0xd9, 0xda, 0x70, 0x85, 0x51, 0x6d, 0xf8, 0x95, 0x79, 0x85, 0x4b, 0x70, 0xd9, 0x9c,
+	0xbc, 0x51, 0x91, 0xb5, 0x79, 0x58, 0xa0, 0x84, 0x9a, 0xf2, 0x4b, 0x51, 0x64, 0x22, 0xc6, 0xb0,
+	0x3a, 0x44, 0x7d, 0x30, 0x22, 0x11, 0xe3, 0x7b, 0x93, 0xf3, 0xbd, 0x42, 0xde, 0xd3, 0xe5, 0xbb,
+	0xfe, 0x4c, 0x94, 0x7a, 0x3d, 0x47, 0x03, 0xb9, 0x34, 0x50, 0x64, 0x45, 0xe2, 0x01, 0x96, 0xe1,
+	0xca, 0xae, 0xda, 0x2b, 0xe3, 0x3e, 0xa7, 0xda, 0x98, 0xc6, 0xa4, 0xc1, 0x68, 0x85, 0x58, 0x2c,
+	0x4e, 0xd9, 0x01, 0xab, 0x33, 0x74, 0xc0, 0x97, 0x22, 0xf0, 0xa9, 0xa6, 0x56, 0x1a, 0xe7, 0xe4,
+	0x13, 0xce, 0xf5, 0x26, 0xb9, 0xae, 0x3b, 0xe1, 0x06, 0xbd, 0x93, 0x5f, 0x35, 0x80, 0x24, 0x97,
+	0xe5, 0xf4, 0xfe, 0xc9, 0x6d, 0x2e, 0xc4, 0x87, 0x9b, 0xb3, 0x0a, 0x81, 0x53, 0xf0, 0xc7, 0x06,
+	0x2c, 0xde, 0xa5, 0xf1, 0x3e, 0x48, 0x65, 0x94, 0x53, 0x2b, 0xde, 0x18, 0xe7, 0xe0, 0xd7, 0x0d,
+	0x58, 0x4e, 0x1a, 0x8d, 0x19, 0x25, 0x59, 0x3d, 0xad, 0x24, 0x7f, 0xd5, 0x80, 0xe5, 0xa1, 0x81,
+	0x49, 0x25, 0xc9, 0x03, 0x2e, 0xc9, 0xdd, 0xcd, 0x53, 0x4a, 0x32, 0x14, 0xc9, 0x90, 0x3f, 0xc5,
+	0x91, 0xcc, 0xf2, 0xaa, 0x25, 0x1f, 0x35, 0x23, 0x19, 0x32, 0x61, 0x68, 0x20, 0x92, 0x21, 0x19,
+	0xac, 0x24, 0x28, 0x0e, 0xee, 0xec, 0x25, 0x1f, 0x3d, 0x7f, 0x44, 0xf2, 0x59, 0x7f, 0x16, 0x56,
+	0xe7, 0x3c, 0x27, 0x8e, 0x8a, 0x64, 0x68, 0xb5, 0x47, 0xcf, 0x13, 0x19, 0xc1, 0x27, 0x11, 0xb3,
+	0x98, 0xa1, 0x65, 0xab, 0xe9, 0x5b, 0xd6, 0x13, 0x31, 0x0b, 0x75, 0x29, 0x7b, 0x35, 0xb6, 0x58,
+	0x26, 0x39, 0x5e, 0x18, 0xf1, 0x25, 0x55, 0xc4, 0x42, 0x72, 0x27, 0x2e, 0xe4, 0x78, 0x81, 0xdf,
+	0xe8, 0x86, 0x2d, 0x0f, 0x16, 0xfa, 0x05, 0x9a, 0x7b, 0xf3, 0x11, 0x8d, 0x5b, 0xef, 0x20, 0x1f,
+	0x06, 0x79, 0x59, 0x16, 0x38, 0x9a, 0x63, 0xf2, 0x07, 0x57, 0x04, 0xa8, 0xe6, 0x5a, 0x39, 0x8a,
+	0xa7, 0xa8, 0x9f, 0x20, 0x47, 0x00, 0x58, 0x59, 0x20, 0x07, 0xb1, 0x3a, 0x54, 0x72, 0x30, 0xd8,
+	0xad, 0xc3, 0xd5, 0x26, 0xe6, 0x55, 0x2e, 0xc3, 0x9a, 0xf9, 0xb6, 0x96, 0x0c, 0x8c, 0x06, 0x3c,
+	0x28, 0x23, 0xf7, 0xae, 0x92, 0xde, 0x0b, 0xdf, 0xbb, 0x86, 0x4d, 0x9e, 0xb0, 0x77, 0x8d, 0xf1,
+	0xfe, 0x0a, 0xf6, 0xae, 0x63, 0x25, 0x88, 0xed, 0x5d, 0x43, 0x09, 0xbe, 0x82, 0xbd, 0xeb, 0x58,
+	0xfe, 0xc3, 0x7b, 0xd7, 0x53, 0x89, 0xb1, 0x7a, 0x4a, 0x31, 0xa2, 0xbd, 0xeb, 0x6c, 0x62, 0xe8,
+	0xed, 0x5d, 0xa7, 0x89, 0xa1, 0x2c, 0xc2, 0x13, 0x28, 0xdf, 0xa5, 0x2c, 0x2a, 0x1c, 0x89, 0x1c,
+	0xa6, 0xc1, 0x0a, 0x93, 0xda, 0x85, 0x11, 0x5f, 0xa4, 0x4c, 0x4b, 0x5c, 0xa6, 0x02, 0x99, 0x5f,
+	0x0f, 0xf8, 0x47, 0xf2, 0x39, 0x2c, 0xa8, 0x34, 0xda, 0xd0, 0x1d, 0x1f, 0x48, 0xa0, 0xaf, 0x8d,
+	0xcb, 0xb7, 0x55, 0x47, 0x6f, 0x66, 0x81, 0x07, 0xf1, 0x30, 0xf9, 0x16, 0xa7, 0xd0, 0x3d, 0x58,
+	0x4c, 0x26, 0xe2, 0x87, 0xbb, 0xec, 0x91, 0xf9, 0xf9, 0xb5, 0x95, 0x01, 0xf2, 0x3c, 0x75, 0x9b,
+	0x9f, 0x06, 0xfd, 0xa2, 0xc8, 0x09, 0x97, 0x59, 0xa7, 0x61, 0x50, 0x70, 0x38, 0xaf, 0xb8, 0x56,
+	0x1b, 0xf5, 0x49, 0x4a, 0x19, 0x9d, 0xac, 0xa2, 0x94, 0xbe, 0xf8, 0x8a, 0x82, 0x7e, 0xce, 0xbd,
+	0xa0, 0xf8, 0x8d, 0xd5, 0x17, 0x46, 0xa4, 0x87, 0x0f, 0xe8, 0x5b, 0xec, 0x93, 0x59, 0xe1, 0x94,
+	0x81, 0x2c, 0xac, 0xab, 0x14, 0xf2, 0x9b, 0x00, 0xc2, 0x6e, 0xf3, 0x9f, 0x4c, 0x88, 0xa7, 0x99,
+	0xd6, 0xe2, 0x0f, 0xe6, 0x32, 0xc7, 0x2c, 0x9a, 0xf9, 0x75, 0x9e, 0x7c, 0x8a, 0xd2, 0xec, 0x42,
+	0x49, 0xd9, 0x64, 0x8e, 0x4c, 0x62, 0xf0, 0x4a, 0x88, 0x04, 0x8d, 0x2a, 0xa7, 0x41, 0x48, 0x45,
+	0xd0, 0x58, 0x7f, 0x26, 0x73, 0x1f, 0x9e, 0x93, 0x1f, 0xc1, 0x99, 0x38, 0xa9, 0x07, 0xf2, 0x67,
+	0x13, 0x46, 0x51, 0x5c, 0x4e, 0xfc, 0xc4, 0x02, 0x2e, 0x7c, 0x66, 0x9d, 0xd3, 0xad, 0x91, 0xea,
+	0x20, 0xdd, 0x75, 0xf5, 0xfb, 0x0b, 0x56, 0xe4, 0x3e, 0x08, 0xbc, 0xd0, 0x32, 0x24, 0xd2, 0x57,
+	0x6a, 0xc9, 0xdf, 0x6f, 0x50, 0x87, 0x8a, 0xc4, 0x1c, 0x47, 0x78, 0xfd, 0x99, 0x4c, 0x5b, 0x79,
+	0x8e, 0xd7, 0xdd, 0x08, 0xdd, 0x93, 0x0c, 0x92, 0xa4, 0x06, 0x29, 0xcb, 0xfd, 0xfb, 0xa6, 0x06,
+	0x65, 0xec, 0xea, 0xa6, 0x72, 0x11, 0x66, 0x90, 0x7e, 0x55, 0x47, 0xfa, 0x6d, 0x00, 0xb9, 0x60,
+	0x4f, 0x9e, 0x06, 0x17, 0x39, 0xcd, 0xb3, 0x9b, 0x43, 0x43, 0x88, 0x52, 0xde, 0x05, 0x90, 0x99,
+	0x1b, 0x69, 0xa6, 0xc3, 0xea, 0xf0, 0x74, 0xd8, 0x81, 0x82, 0xca, 0x8d, 0x0e, 0x42, 0x25, 0x1f,
+	0xc8, 0x96, 0x0e, 0xc3, 0x32, 0x2a, 0x65, 0xda, 0x5c, 0xe4, 0xf4, 0x16, 0x88, 0x9c, 0xa2, 0xa4,
+	0x01, 0x73, 0x22, 0xd6, 0x71, 0x26, 0x99, 0x09, 0x99, 0x54, 0xe2, 0x64, 0x80, 0xe3, 0x15, 0x4e,
+	0xa3, 0x4a, 0xce, 0x0d, 0xf5, 0x99, 0x08, 0x60, 0xf4, 0xc4, 0x66, 0x34, 0x96, 0x9f, 0x95, 0xd8,
+	0x8c, 0x0e, 0xa7, 0x9c, 0xd5, 0x5e, 0x19, 0xf7, 0x79, 0x2a, 0x47, 0x0b, 0xa1, 0xc9, 0x0f, 0x51,
+	0xe7, 0x5d, 0xea, 0x5b, 0x2a, 0xe5, 0x26, 0x1c, 0xfc, 0x44, 0x2a, 0x4f, 0x2d, 0x99, 0x73, 0x64,
+	0x7e, 0x9b, 0x93, 0x7d, 0xd9, 0x1c, 0xd6, 0x09, 0x99, 0x8c, 0x84, 0x03, 0xf6, 0x7d, 0xe1, 0x0a,
+	0x0a, 0x94, 0xc9, 0xea, 0x16, 0xa5, 0x3b, 0x4d, 0x50, 0x37, 0x49, 0x9a, 0xfc, 0x28, 0x52, 0xb7,
+	0x34, 0x32, 0xcb, 0xf4, 0x0f, 0xf2, 0xea, 0x38, 0xc2, 0x68, 0x8b, 0x6c, 0xfa, 0x9c, 0x7c, 0x0e,
+	0xa5, 0x78, 0x36, 0x53, 0x18, 0x46, 0x1b, 0x91, 0xe2, 0x34, 0x72, 0xca, 0x99, 0x65, 0xc9, 0xc1,
+	0xe2, 0x08, 0xd8, 0x15, 0x7f, 0x41, 0x69, 0xd8, 0x44, 0x81, 0x2f, 0x26, 0x72, 0x4c, 0x06, 0x52,
+	0xa0, 0xa4, 0xf8, 0xab, 0x53, 0xc5, 0xff, 0x81, 0x88, 0x02, 0xa2, 0x44, 0x69, 0xdc, 0xb5, 0xa1,
+	0x7e, 0x1f, 0x72, 0xc8, 0xf6, 0x54, 0x10, 0x35, 0x24, 0x9d, 0xca, 0x1b, 0x93, 0x73, 0x66, 0x73,
+	0x2c, 0x03, 0x91, 0xdd, 0x03, 0x77, 0xa9, 0x92, 0x3d, 0x95, 0x7b, 0x31, 0x34, 0xbc, 0xe3, 0xfc,
+	0x18, 0x1b, 0xca, 0xa2, 0x83, 0x4f, 0xc1, 0x65, 0x75, 0x2a, 0x97, 0x43, 0x28, 0x27, 0x3a, 0x2b,
+	0x15, 0x17, 0x79, 0x70, 0xba, 0x39, 0x8d, 0x8b, 0x72, 0x86, 0x3e, 0x82, 0xa2, 0x34, 0xb3, 0x3c,
+	0xed, 0x2b, 0x91, 0x9b, 0x56, 0x4b, 0x3c, 0x99, 0x84, 0x93, 0x2e, 0x99, 0xf3, 0xeb, 0x22, 0x65,
+	0x0d, 0x3b, 0x1d, 0xfd, 0x8a, 0x28, 0x27, 0x2e, 0xf2, 0x2b, 0x86, 0x32, 0xee, 0x6a, 0xb5, 0x51,
+	0x9f, 0x92, 0x7e, 0xc5, 0xea, 0x92, 0xa4, 0xbc, 0xfe, 0x8c, 0xff, 0x7d, 0x4e, 0xee, 0x01, 0x84,
+	0xb9, 0x75, 0xd1, 0x9c, 0x19, 0x4c, 0xb7, 0xab, 0x55, 0xe2, 0x72, 0xf2, 0xa5, 0x20, 0xf2, 0xce,
+	0x04, 0x45, 0xf2, 0x73, 0x50, 0x56, 0x9a, 0x2f, 0x44, 0x3d, 0x13, 0xc7, 0x51, 0x84, 0x92, 0x0d,
+	0x96, 0x62, 0x91, 0x21, 0xb1, 0xee, 0x40, 0x51, 0x8e, 0xd0, 0xd4, 0x4e, 0xab, 0x71, 0x1a, 0x2b,
+	0x9b, 0x83, 0x34, 0xb0, 0xf3, 0x7e, 0x1e, 0x8a, 0xb1, 0x6c, 0xbd, 0xb0, 0xf3, 0x86, 0x33, 0xf8,
+	0x06, 0x68, 0xbe, 0xc6, 0x69, 0x5e, 0x34, 0xcf, 0x0d, 0xd0, 0x5c, 0xf7, 0x39, 0xa6, 0x20, 0x5d,
+	0x0e, 0x7b, 0x29, 0x8d, 0x2a, 0x4b, 0xd2, 0xe4, 0x42, 0x48, 0x7a, 0x48, 0x97, 0x6d, 0xe5, 0xcb,
+	0x47, 0xc4, 0x53, 0x29, 0xb3, 0x4c, 0x02, 0xdb, 0x1c, 0xcf, 0x02, 0x1b, 0xd0, 0x82, 0x22, 0x6a,
+	0xb3, 0x64, 0x91, 0x4a, 0x05, 0xde, 0xe2, 0x0c, 0x4c, 0x52, 0x1f, 0xcb, 0x40, 0x69, 0xda, 0xbe,
+	0x3a, 0x6a, 0x39, 0x0d, 0x9f, 0xd5, 0xe9, 0x7c, 0xba, 0xe1, 0xf2, 0x37, 0x0b, 0x1f, 0x19, 0x93,
+	0xda, 0x9c, 0xca, 0x47, 0xea, 0xf4, 0xed, 0x9f, 0x66, 0xff, 0xda, 0xd6, 0x9f, 0x64, 0xc9, 0xdf,
+	0x34, 0xa0, 0xfc, 0xf8, 0x80, 0xd6, 0x79, 0x3e, 0x65, 0x7d, 0xeb, 0xe1, 0x2e, 0x59, 0xbd, 0x4d,
+	0x5b, 0x56, 0x3f, 0xa0, 0xf5, 0x5d, 0xef, 0x71, 0xfd, 0xae, 0xc5, 0xe8, 0x91, 0x75, 0x52, 0x77,
+	0x82, 0xba, 0xe5, 0xd6, 0x31, 0xd3, 0xba, 0x7e, 0xe4, 0xf9, 0x01, 0xad, 0x23, 0xad, 0x35, 0xb3,
+	0x01, 0xe7, 0xef, 0x1c, 0xf7, 0x3a, 0x9e, 0x6f, 0xe1, 0xf9, 0x43, 0xfd, 0x8e, 0xdb, 0x76, 0x5c,
+	0x4a, 0x7d, 0xc7, 0x6d, 0x93, 0x3a, 0x5e, 0x8f, 0x10, 0xdc, 0x5a, 0x5f, 0xa7, 0x11, 0xc0, 0x1a,
+	0x8d, 0x00, 0xd6, 0x6b, 0x67, 0x29, 0xfd, 0x94, 0xd1, 0x0e, 0x75, 0x3d, 0xdf, 0x76, 0xda, 0x0e,
+	0xb3, 0x3a, 0x6b, 0x2d, 0xaf, 0xbb, 0x39, 0xb7, 0xb9, 0xb6, 0xb1, 0xb6, 0xd1, 0x38, 0x07, 0xd9,
+	0xcd, 0x8d, 0xf7, 0xc8, 0x12, 0x94, 0x77, 0xd9, 0xa5, 0xa0, 0x2e, 0xb3, 0x97, 0xd7, 0x1a, 0x26,
+	0x64, 0xaf, 0x6e, 0x6c, 0x90, 0x8b, 0x70, 0x01, 0xc5, 0x96, 0x3f, 0xd1, 0x5b, 0x3f, 0xb0, 0x84,
+	0x80, 0x78, 0x88, 0xbf, 0xd6, 0x78, 0x19, 0x61, 0xde, 0x23, 0xe7, 0x60, 0xe5, 0xe7, 0xbd, 0x7e,
+	0xbd, 0x65, 0xb9, 0x97, 0x58, 0x9d, 0x79, 0xfd, 0xd6, 0x41, 0x9d, 0x1d, 0x38, 0x41, 0xe3, 0x75,
+	0xfc, 0x7c, 0x95, 0xbc, 0x0c, 0x17, 0xb7, 0xbd, 0x7e, 0xc7, 0xc6, 0xaf, 0xfb, 0x8e, 0x6b, 0xd7,
+	0x19, 0x27, 0x28, 0x4a, 0x03, 0xd6, 0x1a, 0xab, 0x08, 0x75, 0x93, 0x7c, 0x1b, 0x5e, 0x7b, 0x7c,
+	0x40, 0x7d, 0x7a, 0x29, 0xa8, 0x5b, 0xe1, 0xd7, 0xba, 0xaa, 0xb2, 0xab, 0xe3, 0xa7, 0xb5, 0xc6,
+	0x6b, 0x90, 0xbd, 0xb6, 0xb1, 0x41, 0x6a, 0x50, 0xdd, 0xbd, 0xd4, 0xad, 0x07, 0x9e, 0xef, 0x9f,
+	0xac, 0xd5, 0x7f, 0x40, 0xeb, 0x96, 0x4f, 0xeb, 0x7b, 0x3e, 0x0e, 0xc8, 0x0f, 0x0f, 0x60, 0x1f,
+	0x16, 0xb6, 0x7a, 0x8e, 0x50, 0xe3, 0x1f, 0x2e, 0x64, 0xc8, 0xdd, 0xad, 0x87, 0xbb, 0x75, 0x3e,
+	0x5a, 0x75, 0x76, 0x60, 0xb1, 0x7a, 0xb7, 0x1f, 0xb0, 0xfa, 0x1e, 0xad, 0xcb, 0xea, 0x48, 0xbb,
+	0xee, 0xb8, 0x5c, 0x24, 0xf1, 0xa3, 0xe1, 0x41, 0xbd, 0xef, 0x76, 0x68, 0x10, 0xd4, 0x4f, 0xbc,
+	0x3e, 0xa7, 0xdb, 0xf1, 0xda, 0x6d, 0x0e, 0x54, 0x2b, 0xfe, 0xb9, 0xcb, 0x5b, 0x0f, 0x77, 0x2f,
+	0x73, 0xca, 0xf5, 0xcc, 0x5e, 0x9e, 0x27, 0xcf, 0x5e, 0xf9, 0xff, 0x03, 0x00, 0xf9, 0xc8, 0x5d,
+	0xf6, 0x9b, 0x91, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4562,11 +7935,17 @@ type HordeClient interface {
 	DeleteDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error)
 	// List the devices
 	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// Import devices into a collection
+	ImportDevices(ctx context.Context, in *ImportDevicesRequest, opts ...grpc.CallOption) (*ImportDevicesResponse, error)
+	// Export all devices in a collection
+	ExportDevices(ctx context.Context, in *ExportDevicesRequest, opts ...grpc.CallOption) (Horde_ExportDevicesClient, error)
 	// List messages sent by the device
 	ListDeviceMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error)
 	// Send a message to the device
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
 	ClearFirmwareError(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*ClearFirmwareErrorResponse, error)
+	// List the firmware state transitions for the device, newest first
+	ListDeviceFirmwareHistory(ctx context.Context, in *ListDeviceFirmwareHistoryRequest, opts ...grpc.CallOption) (*ListFirmwareHistoryResponse, error)
 	// List tags on device.
 	ListDeviceTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*TagResponse, error)
 	// Update tags on device. This will add and update tags. Existing tags that
@@ -4588,6 +7967,17 @@ type HordeClient interface {
 	DeleteFirmware(ctx context.Context, in *FirmwareRequest, opts ...grpc.CallOption) (*Firmware, error)
 	ListFirmware(ctx context.Context, in *ListFirmwareRequest, opts ...grpc.CallOption) (*ListFirmwareResponse, error)
 	FirmwareUsage(ctx context.Context, in *FirmwareRequest, opts ...grpc.CallOption) (*FirmwareUsageResponse, error)
+	// List the firmware state transitions for updates from or to the image,
+	// newest first
+	ListFirmwareHistory(ctx context.Context, in *ListFirmwareHistoryRequest, opts ...grpc.CallOption) (*ListFirmwareHistoryResponse, error)
+	// Check which devices in the collection are compatible with the image
+	CheckFirmwareCompatibility(ctx context.Context, in *FirmwareRequest, opts ...grpc.CallOption) (*FirmwareCompatibilityResponse, error)
+	// Create a signing key for firmware images
+	CreateSigningKey(ctx context.Context, in *SigningKey, opts ...grpc.CallOption) (*SigningKey, error)
+	RetrieveSigningKey(ctx context.Context, in *SigningKeyRequest, opts ...grpc.CallOption) (*SigningKey, error)
+	ListSigningKeys(ctx context.Context, in *ListSigningKeysRequest, opts ...grpc.CallOption) (*ListSigningKeysResponse, error)
+	// Remove a signing key. Images signed with the key keep their signatures.
+	DeleteSigningKey(ctx context.Context, in *SigningKeyRequest, opts ...grpc.CallOption) (*SigningKey, error)
 	// List tags on firmware image.
 	ListFirmwareTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*TagResponse, error)
 	// Update tags on firmware images
@@ -4614,6 +8004,10 @@ type HordeClient interface {
 	Logs(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (*OutputLogs, error)
 	// Get output status
 	Status(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (*OutputStatus, error)
+	// Send a test message through an existing output or an unsaved output
+	// configuration and return the delivery trace. The running outputs are not
+	// affected.
+	TestOutput(ctx context.Context, in *OutputTestRequest, opts ...grpc.CallOption) (*OutputTestResponse, error)
 	// List tags on token.
 	ListOutputTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*TagResponse, error)
 	// Update tags on output.
@@ -4630,6 +8024,14 @@ type HordeClient interface {
 	// DataDump does a complete data dump of your data, devices, outputs and
 	// collections.
 	DataDump(ctx context.Context, in *DataDumpRequest, opts ...grpc.CallOption) (*DataDumpResponse, error)
+	// StreamDataDump streams a data dump in chunks. Use this instead of
+	// DataDump for large accounts. The REST API serves the stream as NDJSON or
+	// as a ZIP file at /datadump/stream.
+	StreamDataDump(ctx context.Context, in *StreamDataDumpRequest, opts ...grpc.CallOption) (Horde_StreamDataDumpClient, error)
+	// DataRestore recreates the collections, devices, outputs and firmware
+	// metadata in a data dump in a team. The request is rejected if any of the
+	// devices in the dump conflicts with an existing device.
+	DataRestore(ctx context.Context, in *DataRestoreRequest, opts ...grpc.CallOption) (*DataRestoreResponse, error)
 	// Get the profile of the logged in user.
 	GetUserProfile(ctx context.Context, in *UserProfileRequest, opts ...grpc.CallOption) (*UserProfile, error)
 	// Create a new team.
@@ -4652,6 +8054,12 @@ type HordeClient interface {
 	DeleteTeam(ctx context.Context, in *TeamRequest, opts ...grpc.CallOption) (*Team, error)
 	// List all teams that you are a member of.
 	ListTeams(ctx context.Context, in *ListTeamRequest, opts ...grpc.CallOption) (*TeamList, error)
+	// Metered usage for the team. The usage can also be exported as CSV from
+	// /teams/{team_id}/usage/csv with the same query parameters.
+	Usage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error)
+	// The audit trail for the team. Only team administrators can list the
+	// audit trail.
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 	// Genereate a new invite for the team
 	GenerateInvite(ctx context.Context, in *InviteRequest, opts ...grpc.CallOption) (*Invite, error)
 	// List the invites generated for the team.
@@ -4683,6 +8091,9 @@ type HordeClient interface {
 	ListTokens(ctx context.Context, in *ListTokenRequest, opts ...grpc.CallOption) (*TokenList, error)
 	RetrieveToken(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*Token, error)
 	UpdateToken(ctx context.Context, in *Token, opts ...grpc.CallOption) (*Token, error)
+	// Rotate a token. A new token with the same resource, access and tags is
+	// returned and the existing token expires when the grace period ends.
+	RotateToken(ctx context.Context, in *RotateTokenRequest, opts ...grpc.CallOption) (*Token, error)
 	// List tags on token.
 	ListTokenTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*TagResponse, error)
 	// Update tags on token. This will add and update tags. Existing tags that
@@ -4891,6 +8302,47 @@ func (c *hordeClient) ListDevices(ctx context.Context, in *ListDevicesRequest, o
 	return out, nil
 }
 
+func (c *hordeClient) ImportDevices(ctx context.Context, in *ImportDevicesRequest, opts ...grpc.CallOption) (*ImportDevicesResponse, error) {
+	out := new(ImportDevicesResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/ImportDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeClient) ExportDevices(ctx context.Context, in *ExportDevicesRequest, opts ...grpc.CallOption) (Horde_ExportDevicesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Horde_serviceDesc.Streams[1], "/apipb.Horde/ExportDevices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hordeExportDevicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Horde_ExportDevicesClient interface {
+	Recv() (*ExportDevicesResponse, error)
+	grpc.ClientStream
+}
+
+type hordeExportDevicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *hordeExportDevicesClient) Recv() (*ExportDevicesResponse, error) {
+	m := new(ExportDevicesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *hordeClient) ListDeviceMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error) {
 	out := new(ListMessagesResponse)
 	err := c.cc.Invoke(ctx, "/apipb.Horde/ListDeviceMessages", in, out, opts...)
@@ -4918,6 +8370,15 @@ func (c *hordeClient) ClearFirmwareError(ctx context.Context, in *DeviceRequest,
 	return out, nil
 }
 
+func (c *hordeClient) ListDeviceFirmwareHistory(ctx context.Context, in *ListDeviceFirmwareHistoryRequest, opts ...grpc.CallOption) (*ListFirmwareHistoryResponse, error) {
+	out := new(ListFirmwareHistoryResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/ListDeviceFirmwareHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hordeClient) ListDeviceTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*TagResponse, error) {
 	out := new(TagResponse)
 	err := c.cc.Invoke(ctx, "/apipb.Horde/ListDeviceTags", in, out, opts...)
@@ -5017,6 +8478,60 @@ func (c *hordeClient) FirmwareUsage(ctx context.Context, in *FirmwareRequest, op
 	return out, nil
 }
 
+func (c *hordeClient) ListFirmwareHistory(ctx context.Context, in *ListFirmwareHistoryRequest, opts ...grpc.CallOption) (*ListFirmwareHistoryResponse, error) {
+	out := new(ListFirmwareHistoryResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/ListFirmwareHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeClient) CheckFirmwareCompatibility(ctx context.Context, in *FirmwareRequest, opts ...grpc.CallOption) (*FirmwareCompatibilityResponse, error) {
+	out := new(FirmwareCompatibilityResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/CheckFirmwareCompatibility", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeClient) CreateSigningKey(ctx context.Context, in *SigningKey, opts ...grpc.CallOption) (*SigningKey, error) {
+	out := new(SigningKey)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/CreateSigningKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeClient) RetrieveSigningKey(ctx context.Context, in *SigningKeyRequest, opts ...grpc.CallOption) (*SigningKey, error) {
+	out := new(SigningKey)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/RetrieveSigningKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeClient) ListSigningKeys(ctx context.Context, in *ListSigningKeysRequest, opts ...grpc.CallOption) (*ListSigningKeysResponse, error) {
+	out := new(ListSigningKeysResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/ListSigningKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeClient) DeleteSigningKey(ctx context.Context, in *SigningKeyRequest, opts ...grpc.CallOption) (*SigningKey, error) {
+	out := new(SigningKey)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/DeleteSigningKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hordeClient) ListFirmwareTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*TagResponse, error) {
 	out := new(TagResponse)
 	err := c.cc.Invoke(ctx, "/apipb.Horde/ListFirmwareTags", in, out, opts...)
@@ -5125,6 +8640,15 @@ func (c *hordeClient) Status(ctx context.Context, in *OutputRequest, opts ...grp
 	return out, nil
 }
 
+func (c *hordeClient) TestOutput(ctx context.Context, in *OutputTestRequest, opts ...grpc.CallOption) (*OutputTestResponse, error) {
+	out := new(OutputTestResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/TestOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hordeClient) ListOutputTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*TagResponse, error) {
 	out := new(TagResponse)
 	err := c.cc.Invoke(ctx, "/apipb.Horde/ListOutputTags", in, out, opts...)
@@ -5176,12 +8700,53 @@ func (c *hordeClient) GetSystemInfo(ctx context.Context, in *SystemInfoRequest,
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *hordeClient) DataDump(ctx context.Context, in *DataDumpRequest, opts ...grpc.CallOption) (*DataDumpResponse, error) {
+	out := new(DataDumpResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/DataDump", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeClient) StreamDataDump(ctx context.Context, in *StreamDataDumpRequest, opts ...grpc.CallOption) (Horde_StreamDataDumpClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Horde_serviceDesc.Streams[2], "/apipb.Horde/StreamDataDump", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hordeStreamDataDumpClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Horde_StreamDataDumpClient interface {
+	Recv() (*DataDumpChunk, error)
+	grpc.ClientStream
+}
+
+type hordeStreamDataDumpClient struct {
+	grpc.ClientStream
+}
+
+func (x *hordeStreamDataDumpClient) Recv() (*DataDumpChunk, error) {
+	m := new(DataDumpChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (c *hordeClient) DataDump(ctx context.Context, in *DataDumpRequest, opts ...grpc.CallOption) (*DataDumpResponse, error) {
-	out := new(DataDumpResponse)
-	err := c.cc.Invoke(ctx, "/apipb.Horde/DataDump", in, out, opts...)
+func (c *hordeClient) DataRestore(ctx context.Context, in *DataRestoreRequest, opts ...grpc.CallOption) (*DataRestoreResponse, error) {
+	out := new(DataRestoreResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/DataRestore", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5278,6 +8843,24 @@ func (c *hordeClient) ListTeams(ctx context.Context, in *ListTeamRequest, opts .
 	return out, nil
 }
 
+func (c *hordeClient) Usage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error) {
+	out := new(UsageResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/Usage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hordeClient) GenerateInvite(ctx context.Context, in *InviteRequest, opts ...grpc.CallOption) (*Invite, error) {
 	out := new(Invite)
 	err := c.cc.Invoke(ctx, "/apipb.Horde/GenerateInvite", in, out, opts...)
@@ -5413,6 +8996,15 @@ func (c *hordeClient) UpdateToken(ctx context.Context, in *Token, opts ...grpc.C
 	return out, nil
 }
 
+func (c *hordeClient) RotateToken(ctx context.Context, in *RotateTokenRequest, opts ...grpc.CallOption) (*Token, error) {
+	out := new(Token)
+	err := c.cc.Invoke(ctx, "/apipb.Horde/RotateToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hordeClient) ListTokenTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*TagResponse, error) {
 	out := new(TagResponse)
 	err := c.cc.Invoke(ctx, "/apipb.Horde/ListTokenTags", in, out, opts...)
@@ -5507,11 +9099,17 @@ type HordeServer interface {
 	DeleteDevice(context.Context, *DeviceRequest) (*Device, error)
 	// List the devices
 	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// Import devices into a collection
+	ImportDevices(context.Context, *ImportDevicesRequest) (*ImportDevicesResponse, error)
+	// Export all devices in a collection
+	ExportDevices(*ExportDevicesRequest, Horde_ExportDevicesServer) error
 	// List messages sent by the device
 	ListDeviceMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error)
 	// Send a message to the device
 	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
 	ClearFirmwareError(context.Context, *DeviceRequest) (*ClearFirmwareErrorResponse, error)
+	// List the firmware state transitions for the device, newest first
+	ListDeviceFirmwareHistory(context.Context, *ListDeviceFirmwareHistoryRequest) (*ListFirmwareHistoryResponse, error)
 	// List tags on device.
 	ListDeviceTags(context.Context, *TagRequest) (*TagResponse, error)
 	// Update tags on device. This will add and update tags. Existing tags that
@@ -5533,6 +9131,17 @@ type HordeServer interface {
 	DeleteFirmware(context.Context, *FirmwareRequest) (*Firmware, error)
 	ListFirmware(context.Context, *ListFirmwareRequest) (*ListFirmwareResponse, error)
 	FirmwareUsage(context.Context, *FirmwareRequest) (*FirmwareUsageResponse, error)
+	// List the firmware state transitions for updates from or to the image,
+	// newest first
+	ListFirmwareHistory(context.Context, *ListFirmwareHistoryRequest) (*ListFirmwareHistoryResponse, error)
+	// Check which devices in the collection are compatible with the image
+	CheckFirmwareCompatibility(context.Context, *FirmwareRequest) (*FirmwareCompatibilityResponse, error)
+	// Create a signing key for firmware images
+	CreateSigningKey(context.Context, *SigningKey) (*SigningKey, error)
+	RetrieveSigningKey(context.Context, *SigningKeyRequest) (*SigningKey, error)
+	ListSigningKeys(context.Context, *ListSigningKeysRequest) (*ListSigningKeysResponse, error)
+	// Remove a signing key. Images signed with the key keep their signatures.
+	DeleteSigningKey(context.Context, *SigningKeyRequest) (*SigningKey, error)
 	// List tags on firmware image.
 	ListFirmwareTags(context.Context, *TagRequest) (*TagResponse, error)
 	// Update tags on firmware images
@@ -5559,6 +9168,10 @@ type HordeServer interface {
 	Logs(context.Context, *OutputRequest) (*OutputLogs, error)
 	// Get output status
 	Status(context.Context, *OutputRequest) (*OutputStatus, error)
+	// Send a test message through an existing output or an unsaved output
+	// configuration and return the delivery trace. The running outputs are not
+	// affected.
+	TestOutput(context.Context, *OutputTestRequest) (*OutputTestResponse, error)
 	// List tags on token.
 	ListOutputTags(context.Context, *TagRequest) (*TagResponse, error)
 	// Update tags on output.
@@ -5575,6 +9188,14 @@ type HordeServer interface {
 	// DataDump does a complete data dump of your data, devices, outputs and
 	// collections.
 	DataDump(context.Context, *DataDumpRequest) (*DataDumpResponse, error)
+	// StreamDataDump streams a data dump in chunks. Use this instead of
+	// DataDump for large accounts. The REST API serves the stream as NDJSON or
+	// as a ZIP file at /datadump/stream.
+	StreamDataDump(*StreamDataDumpRequest, Horde_StreamDataDumpServer) error
+	// DataRestore recreates the collections, devices, outputs and firmware
+	// metadata in a data dump in a team. The request is rejected if any of the
+	// devices in the dump conflicts with an existing device.
+	DataRestore(context.Context, *DataRestoreRequest) (*DataRestoreResponse, error)
 	// Get the profile of the logged in user.
 	GetUserProfile(context.Context, *UserProfileRequest) (*UserProfile, error)
 	// Create a new team.
@@ -5597,6 +9218,12 @@ type HordeServer interface {
 	DeleteTeam(context.Context, *TeamRequest) (*Team, error)
 	// List all teams that you are a member of.
 	ListTeams(context.Context, *ListTeamRequest) (*TeamList, error)
+	// Metered usage for the team. The usage can also be exported as CSV from
+	// /teams/{team_id}/usage/csv with the same query parameters.
+	Usage(context.Context, *UsageRequest) (*UsageResponse, error)
+	// The audit trail for the team. Only team administrators can list the
+	// audit trail.
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
 	// Genereate a new invite for the team
 	GenerateInvite(context.Context, *InviteRequest) (*Invite, error)
 	// List the invites generated for the team.
@@ -5628,6 +9255,9 @@ type HordeServer interface {
 	ListTokens(context.Context, *ListTokenRequest) (*TokenList, error)
 	RetrieveToken(context.Context, *TokenRequest) (*Token, error)
 	UpdateToken(context.Context, *Token) (*Token, error)
+	// Rotate a token. A new token with the same resource, access and tags is
+	// returned and the existing token expires when the grace period ends.
+	RotateToken(context.Context, *RotateTokenRequest) (*Token, error)
 	// List tags on token.
 	ListTokenTags(context.Context, *TagRequest) (*TagResponse, error)
 	// Update tags on token. This will add and update tags. Existing tags that
@@ -5974,6 +9604,45 @@ func _Horde_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Horde_ImportDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).ImportDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/ImportDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).ImportDevices(ctx, req.(*ImportDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Horde_ExportDevices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportDevicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HordeServer).ExportDevices(m, &hordeExportDevicesServer{stream})
+}
+
+type Horde_ExportDevicesServer interface {
+	Send(*ExportDevicesResponse) error
+	grpc.ServerStream
+}
+
+type hordeExportDevicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *hordeExportDevicesServer) Send(m *ExportDevicesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Horde_ListDeviceMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListMessagesRequest)
 	if err := dec(in); err != nil {
@@ -6028,6 +9697,24 @@ func _Horde_ClearFirmwareError_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Horde_ListDeviceFirmwareHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceFirmwareHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).ListDeviceFirmwareHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/ListDeviceFirmwareHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).ListDeviceFirmwareHistory(ctx, req.(*ListDeviceFirmwareHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Horde_ListDeviceTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TagRequest)
 	if err := dec(in); err != nil {
@@ -6226,6 +9913,114 @@ func _Horde_FirmwareUsage_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Horde_ListFirmwareHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFirmwareHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).ListFirmwareHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/ListFirmwareHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).ListFirmwareHistory(ctx, req.(*ListFirmwareHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Horde_CheckFirmwareCompatibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FirmwareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).CheckFirmwareCompatibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/CheckFirmwareCompatibility",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).CheckFirmwareCompatibility(ctx, req.(*FirmwareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Horde_CreateSigningKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SigningKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).CreateSigningKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/CreateSigningKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).CreateSigningKey(ctx, req.(*SigningKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Horde_RetrieveSigningKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SigningKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).RetrieveSigningKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/RetrieveSigningKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).RetrieveSigningKey(ctx, req.(*SigningKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Horde_ListSigningKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSigningKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).ListSigningKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/ListSigningKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).ListSigningKeys(ctx, req.(*ListSigningKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Horde_DeleteSigningKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SigningKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).DeleteSigningKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/DeleteSigningKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).DeleteSigningKey(ctx, req.(*SigningKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Horde_ListFirmwareTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TagRequest)
 	if err := dec(in); err != nil {
@@ -6442,6 +10237,24 @@ func _Horde_Status_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Horde_TestOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OutputTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).TestOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/TestOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).TestOutput(ctx, req.(*OutputTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Horde_ListOutputTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TagRequest)
 	if err := dec(in); err != nil {
@@ -6568,6 +10381,45 @@ func _Horde_DataDump_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Horde_StreamDataDump_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamDataDumpRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HordeServer).StreamDataDump(m, &hordeStreamDataDumpServer{stream})
+}
+
+type Horde_StreamDataDumpServer interface {
+	Send(*DataDumpChunk) error
+	grpc.ServerStream
+}
+
+type hordeStreamDataDumpServer struct {
+	grpc.ServerStream
+}
+
+func (x *hordeStreamDataDumpServer) Send(m *DataDumpChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Horde_DataRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).DataRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/DataRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).DataRestore(ctx, req.(*DataRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Horde_GetUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UserProfileRequest)
 	if err := dec(in); err != nil {
@@ -6748,6 +10600,42 @@ func _Horde_ListTeams_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Horde_Usage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).Usage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/Usage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).Usage(ctx, req.(*UsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Horde_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Horde_GenerateInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InviteRequest)
 	if err := dec(in); err != nil {
@@ -7018,6 +10906,24 @@ func _Horde_UpdateToken_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Horde_RotateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeServer).RotateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.Horde/RotateToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeServer).RotateToken(ctx, req.(*RotateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Horde_ListTokenTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TagRequest)
 	if err := dec(in); err != nil {
@@ -7180,6 +11086,10 @@ var _Horde_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListDevices",
 			Handler:    _Horde_ListDevices_Handler,
 		},
+		{
+			MethodName: "ImportDevices",
+			Handler:    _Horde_ImportDevices_Handler,
+		},
 		{
 			MethodName: "ListDeviceMessages",
 			Handler:    _Horde_ListDeviceMessages_Handler,
@@ -7192,6 +11102,10 @@ var _Horde_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ClearFirmwareError",
 			Handler:    _Horde_ClearFirmwareError_Handler,
 		},
+		{
+			MethodName: "ListDeviceFirmwareHistory",
+			Handler:    _Horde_ListDeviceFirmwareHistory_Handler,
+		},
 		{
 			MethodName: "ListDeviceTags",
 			Handler:    _Horde_ListDeviceTags_Handler,
@@ -7236,6 +11150,30 @@ var _Horde_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FirmwareUsage",
 			Handler:    _Horde_FirmwareUsage_Handler,
 		},
+		{
+			MethodName: "ListFirmwareHistory",
+			Handler:    _Horde_ListFirmwareHistory_Handler,
+		},
+		{
+			MethodName: "CheckFirmwareCompatibility",
+			Handler:    _Horde_CheckFirmwareCompatibility_Handler,
+		},
+		{
+			MethodName: "CreateSigningKey",
+			Handler:    _Horde_CreateSigningKey_Handler,
+		},
+		{
+			MethodName: "RetrieveSigningKey",
+			Handler:    _Horde_RetrieveSigningKey_Handler,
+		},
+		{
+			MethodName: "ListSigningKeys",
+			Handler:    _Horde_ListSigningKeys_Handler,
+		},
+		{
+			MethodName: "DeleteSigningKey",
+			Handler:    _Horde_DeleteSigningKey_Handler,
+		},
 		{
 			MethodName: "ListFirmwareTags",
 			Handler:    _Horde_ListFirmwareTags_Handler,
@@ -7284,6 +11222,10 @@ var _Horde_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _Horde_Status_Handler,
 		},
+		{
+			MethodName: "TestOutput",
+			Handler:    _Horde_TestOutput_Handler,
+		},
 		{
 			MethodName: "ListOutputTags",
 			Handler:    _Horde_ListOutputTags_Handler,
@@ -7312,6 +11254,10 @@ var _Horde_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DataDump",
 			Handler:    _Horde_DataDump_Handler,
 		},
+		{
+			MethodName: "DataRestore",
+			Handler:    _Horde_DataRestore_Handler,
+		},
 		{
 			MethodName: "GetUserProfile",
 			Handler:    _Horde_GetUserProfile_Handler,
@@ -7352,6 +11298,14 @@ var _Horde_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListTeams",
 			Handler:    _Horde_ListTeams_Handler,
 		},
+		{
+			MethodName: "Usage",
+			Handler:    _Horde_Usage_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Horde_ListAuditEvents_Handler,
+		},
 		{
 			MethodName: "GenerateInvite",
 			Handler:    _Horde_GenerateInvite_Handler,
@@ -7412,6 +11366,10 @@ var _Horde_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateToken",
 			Handler:    _Horde_UpdateToken_Handler,
 		},
+		{
+			MethodName: "RotateToken",
+			Handler:    _Horde_RotateToken_Handler,
+		},
 		{
 			MethodName: "ListTokenTags",
 			Handler:    _Horde_ListTokenTags_Handler,
@@ -7439,6 +11397,16 @@ var _Horde_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Horde_MessageStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportDevices",
+			Handler:       _Horde_ExportDevices_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamDataDump",
+			Handler:       _Horde_StreamDataDump_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
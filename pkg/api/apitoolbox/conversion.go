@@ -59,7 +59,8 @@ func NewFieldMaskFromModel(m model.FieldMask) *apipb.FieldMask {
 // value into apipb.CollectionFirmware
 func NewCollectionFirmwareConfigFromModel(m model.CollectionFirmwareMetadata) *apipb.CollectionFirmware {
 	ret := &apipb.CollectionFirmware{
-		Management: apipb.CollectionFirmware_disabled,
+		Management:       apipb.CollectionFirmware_disabled,
+		RequireSignature: &wrappers.BoolValue{Value: m.RequireSignature},
 	}
 	switch m.Management {
 	case model.CollectionManagement:
@@ -75,9 +76,46 @@ func NewCollectionFirmwareConfigFromModel(m model.CollectionFirmwareMetadata) *a
 	if m.TargetFirmwareID != 0 {
 		ret.TargetFirmwareId = &wrappers.StringValue{Value: m.TargetFirmwareID.String()}
 	}
+	ret.MaintenanceWindow = NewMaintenanceWindowFromModel(m.MaintenanceWindow)
 	return ret
 }
 
+// NewMaintenanceWindowFromModel converts model.MaintenanceWindow into
+// apipb.MaintenanceWindow. Empty windows are returned as nil.
+func NewMaintenanceWindowFromModel(m model.MaintenanceWindow) *apipb.MaintenanceWindow {
+	if m.IsEmpty() {
+		return nil
+	}
+	return &apipb.MaintenanceWindow{
+		Schedule:        &wrappers.StringValue{Value: m.Schedule},
+		DurationMinutes: &wrappers.Int32Value{Value: int32(m.Duration / time.Minute)},
+		TimeZone:        &wrappers.StringValue{Value: m.TimeZone},
+	}
+}
+
+// UpdateMaintenanceWindow applies the fields set in the API request to the
+// existing maintenance window. An empty schedule removes the window. The
+// error contains the appropriate status code if the window is invalid.
+func UpdateMaintenanceWindow(existing model.MaintenanceWindow, w *apipb.MaintenanceWindow) (model.MaintenanceWindow, error) {
+	ret := existing
+	if w.Schedule != nil {
+		ret.Schedule = strings.TrimSpace(w.Schedule.Value)
+	}
+	if ret.IsEmpty() {
+		return model.MaintenanceWindow{}, nil
+	}
+	if w.DurationMinutes != nil {
+		ret.Duration = time.Duration(w.DurationMinutes.Value) * time.Minute
+	}
+	if w.TimeZone != nil {
+		ret.TimeZone = w.TimeZone.Value
+	}
+	if err := ret.Validate(); err != nil {
+		return existing, status.Error(codes.InvalidArgument, "Invalid maintenance window")
+	}
+	return ret, nil
+}
+
 // NewDeviceFromModel converts model.Device into apipb.Device
 func NewDeviceFromModel(d model.Device, c model.Collection) *apipb.Device {
 	ret := &apipb.Device{
@@ -129,7 +167,7 @@ func NewNetworkMetadataFromModel(m model.DeviceNetworkMetadata, fieldMask model.
 
 // NewFirmwareFromModel converts a model.Firmware entity into the corresponding apipb.Firmware type
 func NewFirmwareFromModel(fw model.Firmware) *apipb.Firmware {
-	return &apipb.Firmware{
+	ret := &apipb.Firmware{
 		ImageId:      &wrappers.StringValue{Value: fw.ID.String()},
 		Version:      &wrappers.StringValue{Value: fw.Version},
 		Filename:     &wrappers.StringValue{Value: fw.Filename},
@@ -137,13 +175,36 @@ func NewFirmwareFromModel(fw model.Firmware) *apipb.Firmware {
 		Length:       &wrappers.Int32Value{Value: int32(fw.Length)},
 		CollectionId: &wrappers.StringValue{Value: fw.CollectionID.String()},
 		Tags:         fw.TagData(),
+		Compatibility: &apipb.FirmwareCompatibility{
+			Manufacturer:   &wrappers.StringValue{Value: fw.Compatibility.Manufacturer},
+			ModelPattern:   &wrappers.StringValue{Value: fw.Compatibility.ModelPattern},
+			MinimumVersion: &wrappers.StringValue{Value: fw.Compatibility.MinimumVersion},
+		},
+	}
+	if fw.IsSigned() {
+		ret.Signature = fw.Signature
+		ret.SigningKeyId = &wrappers.StringValue{Value: fw.SigningKeyID.String()}
 	}
+	return ret
 }
 
-// NewFirmwareMetadataFromModel converts model.DeviceFirmwareMetadata into apipb.FirmwareMetadata
-func NewFirmwareMetadataFromModel(m model.DeviceFirmwareMetadata) *apipb.FirmwareMetadata {
+// NewSigningKeyFromModel converts a model.SigningKey into apipb.SigningKey.
+// The private key is never included.
+func NewSigningKeyFromModel(key model.SigningKey) *apipb.SigningKey {
+	return &apipb.SigningKey{
+		CollectionId: &wrappers.StringValue{Value: key.CollectionID.String()},
+		KeyId:        &wrappers.StringValue{Value: key.ID.String()},
+		Algorithm:    &wrappers.StringValue{Value: key.Algorithm},
+		PublicKey:    key.PublicKey,
+		CanSign:      &wrappers.BoolValue{Value: key.CanSign()},
+		Created:      &wrappers.Int64Value{Value: optionalTimeToMillis(key.Created)},
+	}
+}
+
+// newFirmwareState converts model.DeviceFirmwareState into the API enum
+func newFirmwareState(s model.DeviceFirmwareState) apipb.FirmwareMetadata_FirmwareState {
 	state := apipb.FirmwareMetadata_Current
-	switch m.State {
+	switch s {
 	case model.Current:
 		state = apipb.FirmwareMetadata_Current
 	case model.Initializing:
@@ -160,10 +221,19 @@ func NewFirmwareMetadataFromModel(m model.DeviceFirmwareMetadata) *apipb.Firmwar
 		state = apipb.FirmwareMetadata_TimedOut
 	case model.Reverted:
 		state = apipb.FirmwareMetadata_Reverted
+	case model.Incompatible:
+		state = apipb.FirmwareMetadata_Incompatible
+	case model.Deferred:
+		state = apipb.FirmwareMetadata_Deferred
 	default:
 		// Unknown state - set to current
 		state = apipb.FirmwareMetadata_Current
 	}
+	return state
+}
+
+// NewFirmwareMetadataFromModel converts model.DeviceFirmwareMetadata into apipb.FirmwareMetadata
+func NewFirmwareMetadataFromModel(m model.DeviceFirmwareMetadata) *apipb.FirmwareMetadata {
 	return &apipb.FirmwareMetadata{
 		CurrentFirmwareId: &wrappers.StringValue{Value: m.CurrentFirmwareID.String()},
 		TargetFirmwareId:  &wrappers.StringValue{Value: m.TargetFirmwareID.String()},
@@ -172,7 +242,36 @@ func NewFirmwareMetadataFromModel(m model.DeviceFirmwareMetadata) *apipb.Firmwar
 		Manufacturer:      &wrappers.StringValue{Value: m.Manufacturer},
 		FirmwareVersion:   &wrappers.StringValue{Value: m.FirmwareVersion},
 		StateMessage:      &wrappers.StringValue{Value: m.StateMessage},
-		State:             &wrappers.StringValue{Value: state.String()},
+		State:             &wrappers.StringValue{Value: newFirmwareState(m.State).String()},
+		MaintenanceWindow: NewMaintenanceWindowFromModel(m.MaintenanceWindow),
+	}
+}
+
+// NewFirmwareHistoryEntryFromModel converts model.FirmwareHistoryEntry into apipb.FirmwareHistoryEntry
+func NewFirmwareHistoryEntryFromModel(e model.FirmwareHistoryEntry) *apipb.FirmwareHistoryEntry {
+	return &apipb.FirmwareHistoryEntry{
+		EntryId:       &wrappers.StringValue{Value: e.ID.String()},
+		Time:          &wrappers.Int64Value{Value: optionalTimeToMillis(e.Time)},
+		CollectionId:  &wrappers.StringValue{Value: e.CollectionID.String()},
+		DeviceId:      &wrappers.StringValue{Value: e.DeviceID.String()},
+		FromImageId:   &wrappers.StringValue{Value: e.FromFirmwareID.String()},
+		ToImageId:     &wrappers.StringValue{Value: e.ToFirmwareID.String()},
+		PreviousState: &wrappers.StringValue{Value: newFirmwareState(e.PreviousState).String()},
+		State:         &wrappers.StringValue{Value: newFirmwareState(e.State).String()},
+		Message:       &wrappers.StringValue{Value: e.Message},
+		Duration:      &wrappers.Int64Value{Value: int64(e.Duration / time.Millisecond)},
+	}
+}
+
+// NewFirmwareStatisticsFromModel converts model.FirmwareStatistics into apipb.FirmwareStatistics
+func NewFirmwareStatisticsFromModel(s model.FirmwareStatistics) *apipb.FirmwareStatistics {
+	return &apipb.FirmwareStatistics{
+		Downloads: int32(s.Downloads),
+		Completed: int32(s.Completed),
+		Updated:   int32(s.Updated),
+		Failed:    int32(s.Failed),
+		TimedOut:  int32(s.TimedOut),
+		Reverted:  int32(s.Reverted),
 	}
 }
 
@@ -238,6 +337,14 @@ func NewOutputFromModel(o model.Output) *apipb.Output {
 		if ok {
 			ret.Config.AsIsPayload = &wrappers.BoolValue{Value: tmp.(bool)}
 		}
+		tmp, ok = o.Config[outputconfig.MaxRequestsPerSecond]
+		if ok {
+			ret.Config.MaxRequestsPerSecond = &wrappers.Int32Value{Value: int32(tmp.(float64))}
+		}
+		tmp, ok = o.Config[outputconfig.MaxConcurrentRequests]
+		if ok {
+			ret.Config.MaxConcurrentRequests = &wrappers.Int32Value{Value: int32(tmp.(float64))}
+		}
 
 	default: // really "webhook":
 		ret.Type = apipb.Output_webhook
@@ -261,6 +368,25 @@ func NewOutputFromModel(o model.Output) *apipb.Output {
 		if ok {
 			ret.Config.CustomHeaderValue = &wrappers.StringValue{Value: tmp.(string)}
 		}
+		tmp, ok = o.Config[outputconfig.MaxBatchSize]
+		if ok {
+			ret.Config.MaxBatchSize = &wrappers.Int32Value{Value: int32(tmp.(float64))}
+		}
+		tmp, ok = o.Config[outputconfig.MaxLingerMs]
+		if ok {
+			ret.Config.MaxLingerMs = &wrappers.Int32Value{Value: int32(tmp.(float64))}
+		}
+		tmp, ok = o.Config[outputconfig.MaxRequestsPerSecond]
+		if ok {
+			ret.Config.MaxRequestsPerSecond = &wrappers.Int32Value{Value: int32(tmp.(float64))}
+		}
+		tmp, ok = o.Config[outputconfig.MaxConcurrentRequests]
+		if ok {
+			ret.Config.MaxConcurrentRequests = &wrappers.Int32Value{Value: int32(tmp.(float64))}
+		}
+	}
+	if types, ok := o.Config.StringList(outputconfig.EventTypes); ok {
+		ret.Config.EventTypes = types
 	}
 	return ret
 }
@@ -291,10 +417,44 @@ func NewOutputStatusFromModel(collectionID string, outputID string, enabled bool
 		Forwarded:    &wrappers.Int32Value{Value: int32(status.Forwarded)},
 		Received:     &wrappers.Int32Value{Value: int32(status.Received)},
 		Retransmits:  &wrappers.Int32Value{Value: int32(status.Retransmits)},
+		Throttled:    &wrappers.Int32Value{Value: int32(status.Throttled)},
+		Queued:       &wrappers.Int32Value{Value: int32(status.Queued)},
+		Dropped:      &wrappers.Int32Value{Value: int32(status.Dropped)},
 		Enabled:      &wrappers.BoolValue{Value: enabled},
 	}
 }
 
+// NewOutputTestResponseFromModel converts the test result for an output into
+// the apipb equivalent.
+func NewOutputTestResponseFromModel(result model.OutputTestResult, data *apipb.OutputDataMessage) *apipb.OutputTestResponse {
+	ret := &apipb.OutputTestResponse{
+		Success:          &wrappers.BoolValue{Value: result.Success},
+		Message:          &wrappers.StringValue{Value: result.Message},
+		RemoteAddress:    &wrappers.StringValue{Value: result.RemoteAddress},
+		HttpStatusCode:   &wrappers.Int32Value{Value: int32(result.HTTPStatusCode)},
+		HttpResponseBody: &wrappers.StringValue{Value: result.HTTPResponseBody},
+		MqttReturnCode:   &wrappers.Int32Value{Value: int32(result.MQTTReturnCode)},
+		Timing: &apipb.OutputTestTiming{
+			DnsLookup:    &wrappers.DoubleValue{Value: durationToMillis(result.DNSLookup)},
+			Connect:      &wrappers.DoubleValue{Value: durationToMillis(result.Connect)},
+			TlsHandshake: &wrappers.DoubleValue{Value: durationToMillis(result.TLSHandshake)},
+			FirstByte:    &wrappers.DoubleValue{Value: durationToMillis(result.FirstByte)},
+			Total:        &wrappers.DoubleValue{Value: durationToMillis(result.Total)},
+		},
+		Data: data,
+	}
+	if result.TLS != nil {
+		ret.Tls = &apipb.OutputTLSTrace{
+			HandshakeComplete: &wrappers.BoolValue{Value: result.TLS.HandshakeComplete},
+			Version:           &wrappers.StringValue{Value: result.TLS.Version},
+			CipherSuite:       &wrappers.StringValue{Value: result.TLS.CipherSuite},
+			ServerName:        &wrappers.StringValue{Value: result.TLS.ServerName},
+			Error:             &wrappers.StringValue{Value: result.TLS.Error},
+		}
+	}
+	return ret
+}
+
 // NewOutputConfigFromAPI generates a model config based on protobuffer request object
 func NewOutputConfigFromAPI(o *apipb.Output) model.OutputConfig {
 	ret := make(model.OutputConfig)
@@ -349,17 +509,46 @@ func NewOutputConfigFromAPI(o *apipb.Output) model.OutputConfig {
 	if o.Config.Port != nil {
 		ret[outputconfig.UDPPort] = float64(o.Config.Port.Value)
 	}
+	if o.Config.MaxBatchSize != nil {
+		ret[outputconfig.MaxBatchSize] = float64(o.Config.MaxBatchSize.Value)
+	}
+	if o.Config.MaxLingerMs != nil {
+		ret[outputconfig.MaxLingerMs] = float64(o.Config.MaxLingerMs.Value)
+	}
+	if o.Config.MaxRequestsPerSecond != nil {
+		ret[outputconfig.MaxRequestsPerSecond] = float64(o.Config.MaxRequestsPerSecond.Value)
+	}
+	if o.Config.MaxConcurrentRequests != nil {
+		ret[outputconfig.MaxConcurrentRequests] = float64(o.Config.MaxConcurrentRequests.Value)
+	}
+	if len(o.Config.EventTypes) > 0 {
+		// Use the same type as the JSON-encoded configuration
+		types := make([]interface{}, len(o.Config.EventTypes))
+		for i, v := range o.Config.EventTypes {
+			types[i] = v
+		}
+		ret[outputconfig.EventTypes] = types
+	}
 	return ret
 }
 
-// NewTokenFromModel converts a model.Token into apipb.Token
+// NewTokenFromModel converts a model.Token into apipb.Token. The token itself
+// is only included if it is set, ie when the token is generated.
 func NewTokenFromModel(token model.Token) *apipb.Token {
-	return &apipb.Token{
-		Resource: &wrappers.StringValue{Value: token.Resource},
-		Write:    &wrappers.BoolValue{Value: token.Write},
-		Token:    &wrappers.StringValue{Value: token.Token},
-		Tags:     token.TagData(),
+	ret := &apipb.Token{
+		Id:         &wrappers.StringValue{Value: token.ID},
+		Resource:   &wrappers.StringValue{Value: token.Resource},
+		Write:      &wrappers.BoolValue{Value: token.Write},
+		Tags:       token.TagData(),
+		Created:    &wrappers.Int64Value{Value: optionalTimeToMillis(token.Created)},
+		Expires:    &wrappers.Int64Value{Value: optionalTimeToMillis(token.Expires)},
+		LastUsed:   &wrappers.Int64Value{Value: optionalTimeToMillis(token.LastUsed)},
+		LastUsedIp: &wrappers.StringValue{Value: token.LastUsedIP},
+	}
+	if token.Token != "" {
+		ret.Token = &wrappers.StringValue{Value: token.Token}
 	}
+	return ret
 }
 
 // NewTeamFromModel converts a model.Team into apipb.Team
@@ -391,11 +580,16 @@ func NewMemberFromModel(teamID model.TeamKey, member model.Member) *apipb.Member
 		AvatarUrl:     &wrappers.StringValue{Value: member.User.AvatarURL},
 	}
 
-	if member.User.AuthType == model.AuthConnectID {
+	switch member.User.AuthType {
+	case model.AuthConnectID:
 		ret.AuthType = &wrappers.StringValue{Value: "connect"}
 		ret.ConnectId = &wrappers.StringValue{Value: member.User.ExternalID}
 		ret.GitHubLogin = nil
-	} else {
+	case model.AuthOIDC:
+		ret.AuthType = &wrappers.StringValue{Value: "oidc"}
+		ret.ConnectId = nil
+		ret.GitHubLogin = nil
+	default:
 		ret.AuthType = &wrappers.StringValue{Value: "github"}
 		ret.ConnectId = nil
 		ret.GitHubLogin = &wrappers.StringValue{Value: member.User.ExternalID}
@@ -408,10 +602,32 @@ func timeToMillis(t time.Time) float64 {
 	return math.Floor(float64(t.UnixNano()) / float64(time.Millisecond))
 }
 
+// optionalTimeToMillis converts a time value into milliseconds. The zero
+// value is converted to 0.
+func optionalTimeToMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// MillisToTime converts milliseconds since epoch into a time value. 0 is
+// converted into the zero value.
+func MillisToTime(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, milliToNano(ms))
+}
+
 func nanosToMillis(nanos int64) float64 {
 	return math.Floor(float64(nanos) / float64(time.Millisecond))
 }
 
+func durationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
 func milliToNano(ms int64) int64 {
 	return ms * int64(time.Millisecond)
 }
@@ -433,6 +649,56 @@ func ApplyDataFilter(req *apipb.ListMessagesRequest, dataFilter *datastore.DataF
 	}
 }
 
+// NewUsageRecordFromModel converts a model.Usage into an apipb.UsageRecord
+// type. The identifying fields are omitted for the zero values.
+func NewUsageRecordFromModel(usage model.Usage) *apipb.UsageRecord {
+	ret := &apipb.UsageRecord{
+		UplinkMessages:   &wrappers.Int64Value{Value: usage.UplinkMessages},
+		UplinkBytes:      &wrappers.Int64Value{Value: usage.UplinkBytes},
+		DownlinkMessages: &wrappers.Int64Value{Value: usage.DownlinkMessages},
+		DownlinkBytes:    &wrappers.Int64Value{Value: usage.DownlinkBytes},
+		RadiusSessions:   &wrappers.Int64Value{Value: usage.RADIUSSessions},
+		FirmwareBytes:    &wrappers.Int64Value{Value: usage.FirmwareBytes},
+		OutputDeliveries: &wrappers.Int64Value{Value: usage.OutputDeliveries},
+	}
+	if usage.CollectionID != 0 {
+		ret.CollectionId = &wrappers.StringValue{Value: usage.CollectionID.String()}
+	}
+	if !usage.Hour.IsZero() {
+		ret.Hour = &wrappers.Int64Value{Value: optionalTimeToMillis(usage.Hour)}
+		ret.Transport = &wrappers.StringValue{Value: usage.Transport}
+	}
+	return ret
+}
+
+// NewAuditEventFromModel converts a model.AuditEvent into an apipb.AuditEvent
+func NewAuditEventFromModel(event model.AuditEvent) *apipb.AuditEvent {
+	ret := &apipb.AuditEvent{
+		EventId:       &wrappers.StringValue{Value: event.ID.String()},
+		Time:          &wrappers.Int64Value{Value: optionalTimeToMillis(event.Time)},
+		TeamId:        &wrappers.StringValue{Value: event.TeamID.String()},
+		Source:        &wrappers.StringValue{Value: event.Source},
+		AuthMethod:    &wrappers.StringValue{Value: event.AuthMethod.String()},
+		Action:        &wrappers.StringValue{Value: event.Action},
+		ResourceType:  &wrappers.StringValue{Value: event.ResourceType},
+		ResourceId:    &wrappers.StringValue{Value: event.ResourceID},
+		ChangedFields: event.ChangedFields(),
+	}
+	if event.UserID != 0 {
+		ret.UserId = &wrappers.StringValue{Value: event.UserID.String()}
+	}
+	if event.TokenID != "" {
+		ret.TokenId = &wrappers.StringValue{Value: event.TokenID}
+	}
+	if event.Before != "" {
+		ret.Before = &wrappers.StringValue{Value: event.Before}
+	}
+	if event.After != "" {
+		ret.After = &wrappers.StringValue{Value: event.After}
+	}
+	return ret
+}
+
 // NewInviteFromModel converts a model.Invite into an apipb.Invite type
 func NewInviteFromModel(invite model.Invite) *apipb.Invite {
 	return &apipb.Invite{
@@ -465,6 +731,10 @@ func NewUserProfileFromUser(user model.User) *apipb.UserProfile {
 		ret.Provider = &wrappers.StringValue{Value: "github"}
 		ret.LogoutUrl = &wrappers.StringValue{Value: "/github/logout"}
 
+	case model.AuthOIDC:
+		ret.Provider = &wrappers.StringValue{Value: "oidc"}
+		ret.LogoutUrl = &wrappers.StringValue{Value: "/oidc/logout"}
+
 	case model.AuthInternal:
 		ret.Provider = &wrappers.StringValue{Value: "internal"}
 
@@ -560,3 +830,31 @@ func NewOutputDataMessageFromModel(msg model.DataMessage, collection model.Colle
 		UdpMetaData:  udpMetadata,
 	}
 }
+
+// NewOutputEventMessageFromModel converts a resource event into an
+// apipb.OutputDataMessage with the event type. The collection's field mask is
+// applied to devices.
+func NewOutputEventMessageFromModel(ev model.ResourceEvent, collection model.Collection) *apipb.OutputDataMessage {
+	ret := &apipb.ResourceEvent{
+		Type:         string(ev.Type),
+		Time:         &wrappers.Int64Value{Value: optionalTimeToMillis(ev.Time)},
+		CollectionId: &wrappers.StringValue{Value: ev.CollectionID.String()},
+	}
+	if ev.PreviousCollectionID != 0 {
+		ret.PreviousCollectionId = &wrappers.StringValue{Value: ev.PreviousCollectionID.String()}
+	}
+	if ev.Device != nil {
+		ret.Device = NewDeviceFromModel(*ev.Device, collection)
+	}
+	if ev.Collection != nil {
+		ret.Collection = NewCollectionFromModel(*ev.Collection)
+	}
+	if ev.Output != nil {
+		ret.Output = NewOutputFromModel(*ev.Output)
+		ret.Output.Config = nil
+	}
+	return &apipb.OutputDataMessage{
+		Type:          apipb.OutputDataMessage_event,
+		ResourceEvent: ret,
+	}
+}
@@ -194,6 +194,10 @@ func TestOutputConversion(t *testing.T) {
 		outputconfig.WebhookBasicAuthPass:     "password",
 		outputconfig.WebhookCustomHeaderName:  "secrets-header",
 		outputconfig.WebhookCustomHeaderValue: "secret-value",
+		outputconfig.MaxBatchSize:             float64(10),
+		outputconfig.MaxLingerMs:              float64(500),
+		outputconfig.MaxRequestsPerSecond:     float64(2),
+		outputconfig.MaxConcurrentRequests:    float64(3),
 	}
 
 	n = NewOutputFromModel(o)
@@ -203,6 +207,10 @@ func TestOutputConversion(t *testing.T) {
 	assert.Equal(o.Config[outputconfig.WebhookBasicAuthPass], n.Config.BasicAuthPass.Value)
 	assert.Equal(o.Config[outputconfig.WebhookCustomHeaderName], n.Config.CustomHeaderName.Value)
 	assert.Equal(o.Config[outputconfig.WebhookCustomHeaderValue], n.Config.CustomHeaderValue.Value)
+	assert.Equal(int32(10), n.Config.MaxBatchSize.Value)
+	assert.Equal(int32(500), n.Config.MaxLingerMs.Value)
+	assert.Equal(int32(2), n.Config.MaxRequestsPerSecond.Value)
+	assert.Equal(int32(3), n.Config.MaxConcurrentRequests.Value)
 
 	o.Type = "mqtt"
 	o.Config = map[string]interface{}{
@@ -277,6 +285,16 @@ func TestTokenConversion(t *testing.T) {
 	assert.Equal("something", apiToken.Resource.Value)
 	assert.Contains(apiToken.Tags, "name")
 	assert.Equal("value", apiToken.Tags["name"])
+	assert.Equal(token.Token, apiToken.Token.Value)
+	assert.Equal(token.ID, apiToken.Id.Value)
+	assert.Equal(int64(0), apiToken.Expires.Value)
+
+	token.Token = ""
+	token.Expires = time.Unix(1000, 0)
+	apiToken = NewTokenFromModel(token)
+	assert.Nil(apiToken.Token)
+	assert.Equal(int64(1000000), apiToken.Expires.Value)
+	assert.True(token.Expires.Equal(MillisToTime(apiToken.Expires.Value)))
 }
 func TestInviteConversion(t *testing.T) {
 	assert := require.New(t)
@@ -408,7 +426,7 @@ func TestLogConversion(t *testing.T) {
 
 func TestStatusConversion(t *testing.T) {
 	assert := require.New(t)
-	ret := NewOutputStatusFromModel("1", "2", true, model.OutputStatus{Forwarded: 1, Received: 2, ErrorCount: 3, Retransmits: 4})
+	ret := NewOutputStatusFromModel("1", "2", true, model.OutputStatus{Forwarded: 1, Received: 2, ErrorCount: 3, Retransmits: 4, Throttled: 5, Queued: 6, Dropped: 7})
 	assert.Equal(ret.CollectionId.Value, "1")
 	assert.Equal(ret.OutputId.Value, "2")
 	assert.Equal(ret.Enabled.Value, true)
@@ -416,6 +434,9 @@ func TestStatusConversion(t *testing.T) {
 	assert.Equal(ret.Received.Value, int32(2))
 	assert.Equal(ret.ErrorCount.Value, int32(3))
 	assert.Equal(ret.Retransmits.Value, int32(4))
+	assert.Equal(ret.Throttled.Value, int32(5))
+	assert.Equal(ret.Queued.Value, int32(6))
+	assert.Equal(ret.Dropped.Value, int32(7))
 }
 
 func TestConfigFromAPIConversion(t *testing.T) {
@@ -443,6 +464,8 @@ func TestConfigFromAPIConversion(t *testing.T) {
 			BasicAuthUser:     &wrappers.StringValue{Value: "bau"},
 			CustomHeaderName:  &wrappers.StringValue{Value: "chn"},
 			CustomHeaderValue: &wrappers.StringValue{Value: "chv"},
+			MaxBatchSize:      &wrappers.Int32Value{Value: 10},
+			MaxLingerMs:       &wrappers.Int32Value{Value: 500},
 		},
 	})
 	assert.Equal(cfg[outputconfig.WebhookBasicAuthPass].(string), "bap")
@@ -450,6 +473,8 @@ func TestConfigFromAPIConversion(t *testing.T) {
 	assert.Equal(cfg[outputconfig.WebhookURLField].(string), "url")
 	assert.Equal(cfg[outputconfig.WebhookCustomHeaderName].(string), "chn")
 	assert.Equal(cfg[outputconfig.WebhookCustomHeaderValue].(string), "chv")
+	assert.Equal(cfg[outputconfig.MaxBatchSize].(float64), float64(10))
+	assert.Equal(cfg[outputconfig.MaxLingerMs].(float64), float64(500))
 
 	cfg = NewOutputConfigFromAPI(&apipb.Output{
 		Type: apipb.Output_udp,
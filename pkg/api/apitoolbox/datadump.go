@@ -0,0 +1,112 @@
+package apitoolbox
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// ErrIncompleteDataDump is returned by ReadDataDump when a downloaded data
+// dump doesn't end with the end of dump chunk.
+var ErrIncompleteDataDump = errors.New("the data dump is incomplete")
+
+// ReadDataDump reads a data dump. The dump is either a single JSON document
+// (from the DataDump method) or newline-delimited JSON chunks (downloaded
+// from the streamed data dump). The chunked dumps must end with the end of
+// dump chunk.
+func ReadDataDump(buf []byte) (*apipb.DataDumpResponse, error) {
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	var first json.RawMessage
+	if err := dec.Decode(&first); err != nil {
+		return nil, err
+	}
+	ret := &apipb.DataDumpResponse{}
+	if !dec.More() {
+		if err := unmarshaler.Unmarshal(bytes.NewReader(first), ret); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+
+	dec = json.NewDecoder(bytes.NewReader(buf))
+	for {
+		chunk := &apipb.DataDumpChunk{}
+		if err := unmarshaler.UnmarshalNext(dec, chunk); err != nil {
+			if err == io.EOF {
+				return nil, ErrIncompleteDataDump
+			}
+			return nil, err
+		}
+		if chunk.End != nil {
+			if chunk.End.Error != nil {
+				return nil, fmt.Errorf("%v: %s", ErrIncompleteDataDump, chunk.End.Error.Value)
+			}
+			if dec.More() {
+				return nil, errors.New("chunks after the end of the data dump")
+			}
+			return ret, nil
+		}
+		if err := addDataDumpChunk(ret, chunk); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// addDataDumpChunk adds a chunk to the data dump. Collections are followed
+// by their firmware, outputs and devices and devices are followed by their
+// messages.
+func addDataDumpChunk(dump *apipb.DataDumpResponse, chunk *apipb.DataDumpChunk) error {
+	var collection *apipb.DumpedCollection
+	if len(dump.Collections) > 0 {
+		collection = dump.Collections[len(dump.Collections)-1]
+	}
+	switch {
+	case chunk.Profile != nil:
+		dump.Profile = chunk.Profile
+	case chunk.Team != nil:
+		dump.Teams = append(dump.Teams, chunk.Team)
+	case chunk.Token != nil:
+		dump.Tokens = append(dump.Tokens, chunk.Token)
+	case chunk.Collection != nil:
+		dump.Collections = append(dump.Collections, &apipb.DumpedCollection{Collection: chunk.Collection})
+	case collection == nil:
+		return errors.New("collection resources before the collection in the data dump")
+	case chunk.Firmware != nil:
+		collection.Firmware = append(collection.Firmware, chunk.Firmware)
+	case chunk.Output != nil:
+		collection.Outputs = append(collection.Outputs, chunk.Output)
+	case chunk.Device != nil:
+		collection.Devices = append(collection.Devices, &apipb.DumpedDevice{Device: chunk.Device})
+	case chunk.Messages != nil:
+		if len(collection.Devices) == 0 {
+			return errors.New("messages before the device in the data dump")
+		}
+		device := collection.Devices[len(collection.Devices)-1]
+		if device.Device.GetDeviceId().GetValue() != chunk.Messages.GetDeviceId().GetValue() {
+			return errors.New("messages for the wrong device in the data dump")
+		}
+		device.Data = append(device.Data, chunk.Messages.Messages...)
+	}
+	return nil
+}
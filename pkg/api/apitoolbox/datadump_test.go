@@ -0,0 +1,71 @@
+package apitoolbox
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDataDump(t *testing.T) {
+	assert := require.New(t)
+
+	// Single JSON documents are read as is
+	dump, err := ReadDataDump([]byte(`{"collections": [{"collection": {"collectionId": "1"}}], "profile": {"name": "user"}}`))
+	assert.NoError(err)
+	assert.Len(dump.Collections, 1)
+	assert.Equal("user", dump.Profile.Name.Value)
+
+	chunks := []string{
+		`{"profile": {"name": "user"}}`,
+		`{"team": {"teamId": "1"}}`,
+		`{"collection": {"collectionId": "2"}}`,
+		`{"firmware": {"imageId": "3"}}`,
+		`{"output": {"outputId": "4"}}`,
+		`{"device": {"deviceId": "5"}}`,
+		`{"messages": {"collectionId": "2", "deviceId": "5", "messages": [{"payload": "AQ=="}, {"payload": "Ag=="}]}}`,
+		`{"messages": {"collectionId": "2", "deviceId": "5", "messages": [{"payload": "Aw=="}]}}`,
+		`{"collection": {"collectionId": "6"}}`,
+	}
+	dump, err = ReadDataDump([]byte(strings.Join(append(chunks, `{"end": {}}`), "\n")))
+	assert.NoError(err)
+	assert.Equal("user", dump.Profile.Name.Value)
+	assert.Len(dump.Teams, 1)
+	assert.Len(dump.Collections, 2)
+	assert.Len(dump.Collections[0].Firmware, 1)
+	assert.Len(dump.Collections[0].Outputs, 1)
+	assert.Len(dump.Collections[0].Devices, 1)
+	assert.Len(dump.Collections[0].Devices[0].Data, 3)
+
+	// Chunked dumps must be complete
+	_, err = ReadDataDump([]byte(strings.Join(chunks, "\n")))
+	assert.Equal(ErrIncompleteDataDump, err)
+	_, err = ReadDataDump([]byte(strings.Join(append(chunks, `{"end": {"error": "Error reading contents of collection"}}`), "\n")))
+	assert.Error(err)
+	assert.Contains(err.Error(), "Error reading contents of collection")
+	_, err = ReadDataDump([]byte(strings.Join(append(chunks, `{"end": {}}`, `{"team": {}}`), "\n")))
+	assert.Error(err)
+
+	// Resources must follow their collection and device
+	_, err = ReadDataDump([]byte(`{"device": {"deviceId": "5"}}` + "\n" + `{"end": {}}`))
+	assert.Error(err)
+	_, err = ReadDataDump([]byte(`{"collection": {}}` + "\n" + `{"messages": {"deviceId": "5"}}` + "\n" + `{"end": {}}`))
+	assert.Error(err)
+	_, err = ReadDataDump([]byte("not json"))
+	assert.Error(err)
+}
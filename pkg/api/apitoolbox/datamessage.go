@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -110,6 +111,19 @@ func fixMetadata(metadata []byte) ([]byte, error) {
 	return json.Marshal(data)
 }
 
+// MarshalDataStoreMetadata converts the message's device into the metadata
+// kept in the data store. The metadata is empty if the message can't be
+// marshaled.
+func MarshalDataStoreMetadata(msg model.DataMessage) []byte {
+	ma := JSONMarshaler()
+	odm := NewOutputDataMessageFromModel(msg, model.Collection{})
+	buf, err := ma.MarshalToString(odm)
+	if err != nil {
+		return []byte{}
+	}
+	return []byte(buf)
+}
+
 // UnmarshalDataStoreMetadata unmarshals a data store message from a binary
 func UnmarshalDataStoreMetadata(metadata []byte, fieldMask model.FieldMask, payload []byte, created int64) (*apipb.OutputDataMessage, error) {
 	ret := &apipb.OutputDataMessage{}
@@ -131,3 +145,37 @@ func UnmarshalDataStoreMetadata(metadata []byte, fieldMask model.FieldMask, payl
 	ret.Received = &wrappers.DoubleValue{Value: nanosToMillis(created)}
 	return ret, nil
 }
+
+// NewDataMessageFromOutputDataMessage converts a message retrieved from the
+// data store back into a model.DataMessage. The stored metadata only holds a
+// (masked) snapshot of the device so the device must be supplied by the
+// caller.
+func NewDataMessageFromOutputDataMessage(msg *apipb.OutputDataMessage, device model.Device) model.DataMessage {
+	ret := model.DataMessage{
+		Device:    device,
+		Payload:   msg.Payload,
+		Transport: model.MessageTransportFromString(msg.Transport),
+	}
+	if msg.Received != nil {
+		ret.Received = time.Unix(0, milliToNano(int64(msg.Received.Value)))
+	}
+	if msg.UdpMetaData != nil {
+		ret.Transport = model.UDPTransport
+		if msg.UdpMetaData.LocalPort != nil {
+			ret.UDP.LocalPort = int(msg.UdpMetaData.LocalPort.Value)
+		}
+		if msg.UdpMetaData.RemotePort != nil {
+			ret.UDP.RemotePort = int(msg.UdpMetaData.RemotePort.Value)
+		}
+	}
+	if msg.CoapMetaData != nil {
+		ret.Transport = model.CoAPTransport
+		if msg.CoapMetaData.Code != nil {
+			ret.CoAP.Code = msg.CoapMetaData.Code.Value
+		}
+		if msg.CoapMetaData.Path != nil {
+			ret.CoAP.Path = msg.CoapMetaData.Path.Value
+		}
+	}
+	return ret
+}
@@ -0,0 +1,61 @@
+package apitoolbox
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"fmt"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewListOptions creates list options from the paging, sorting and filter
+// parameters in list requests. All of the parameters are optional. Page sizes
+// above model.MaxPageSize are capped.
+func NewListOptions(pageSize *wrappers.Int32Value, pageToken, sortBy, tagFilter *wrappers.StringValue) (model.ListOptions, error) {
+	ret := model.ListOptions{SortBy: model.SortByCreated}
+	if pageSize != nil {
+		if pageSize.Value < 0 {
+			return ret, status.Error(codes.InvalidArgument, "Page size can't be negative")
+		}
+		ret.PageSize = int(pageSize.Value)
+		if ret.PageSize > model.MaxPageSize {
+			ret.PageSize = model.MaxPageSize
+		}
+	}
+	if pageToken != nil && pageToken.Value != "" {
+		if _, _, err := model.ParsePageToken(pageToken.Value); err != nil {
+			return ret, status.Error(codes.InvalidArgument, "Invalid page token")
+		}
+		ret.PageToken = pageToken.Value
+	}
+	if sortBy != nil && sortBy.Value != "" {
+		ret.SortBy = model.ListSort(sortBy.Value)
+		if !ret.SortBy.IsValid() {
+			return ret, status.Error(codes.InvalidArgument, fmt.Sprintf("Unknown sort order: %s", sortBy.Value))
+		}
+	}
+	if tagFilter != nil {
+		filter, err := model.ParseTagFilter(tagFilter.Value)
+		if err != nil {
+			return ret, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid tag filter: %v", err))
+		}
+		ret.Filter = filter
+	}
+	return ret, nil
+}
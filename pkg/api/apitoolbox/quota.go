@@ -0,0 +1,131 @@
+package apitoolbox
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QuotaCheck checks a team's quota against the current usage. The checks are
+// the methods on model.Quota.
+type QuotaCheck func(quota model.Quota, usage model.QuotaUsage) error
+
+// EnsureQuota ensures that a new resource won't exceed the team's quota. If
+// the quota is exceeded a ResourceExhausted error is returned.
+func EnsureQuota(store storage.DataStore, teamID model.TeamKey, check QuotaCheck) error {
+	quota, err := store.RetrieveTeamQuota(teamID)
+	if err != nil {
+		logging.Warning("Unable to retrieve quota for team %d: %v", teamID, err)
+		return status.Error(codes.Internal, "Unable to check team quota")
+	}
+	if quota == (model.Quota{}) {
+		return nil
+	}
+	usage, err := store.RetrieveTeamUsage(teamID, time.Now())
+	if err != nil {
+		logging.Warning("Unable to retrieve resource usage for team %d: %v", teamID, err)
+		return status.Error(codes.Internal, "Unable to check team quota")
+	}
+	if err := check(quota, usage); err != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+// EnsureCollectionQuota ensures that a new resource in the collection won't
+// exceed the quota for the team owning the collection.
+func EnsureCollectionQuota(userID model.UserKey, collectionID model.CollectionKey, store storage.DataStore, check QuotaCheck) error {
+	coll, err := store.RetrieveCollection(userID, collectionID)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return status.Error(codes.NotFound, "Unknown collection")
+		}
+		logging.Warning("Error retrieving collection %d: %v", collectionID, err)
+		return status.Error(codes.Internal, "Unable to read collection")
+	}
+	return EnsureQuota(store, coll.TeamID, check)
+}
+
+// NewDeviceImportCheck returns a check for bulk imports that ensures the new
+// devices won't exceed the team's quota. The usage is read before the import
+// starts.
+func NewDeviceImportCheck(store storage.DataStore, teamID model.TeamKey) (model.DeviceImportCheck, error) {
+	quota, err := store.RetrieveTeamQuota(teamID)
+	if err != nil {
+		logging.Warning("Unable to retrieve quota for team %d: %v", teamID, err)
+		return nil, status.Error(codes.Internal, "Unable to check team quota")
+	}
+	if quota.MaxDevices == 0 {
+		return nil, nil
+	}
+	usage, err := store.RetrieveTeamUsage(teamID, time.Now())
+	if err != nil {
+		logging.Warning("Unable to retrieve resource usage for team %d: %v", teamID, err)
+		return nil, status.Error(codes.Internal, "Unable to check team quota")
+	}
+	return func(created int) error {
+		if err := quota.CheckNewDevices(usage, created); err != nil {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil
+	}, nil
+}
+
+// NewTeamQuotaFromModel creates an apipb.TeamQuota instance from a model.Quota
+// instance. Unlimited values are omitted.
+func NewTeamQuotaFromModel(quota model.Quota) *apipb.TeamQuota {
+	ret := &apipb.TeamQuota{}
+	if quota.MaxDevices > 0 {
+		ret.MaxDevices = &wrappers.Int32Value{Value: int32(quota.MaxDevices)}
+	}
+	if quota.MaxCollections > 0 {
+		ret.MaxCollections = &wrappers.Int32Value{Value: int32(quota.MaxCollections)}
+	}
+	if quota.MaxOutputs > 0 {
+		ret.MaxOutputs = &wrappers.Int32Value{Value: int32(quota.MaxOutputs)}
+	}
+	if quota.MaxFirmwareBytes > 0 {
+		ret.MaxFirmwareBytes = &wrappers.Int64Value{Value: quota.MaxFirmwareBytes}
+	}
+	if quota.MaxUplinkPerDay > 0 {
+		ret.MaxUplinkPerDay = &wrappers.Int32Value{Value: int32(quota.MaxUplinkPerDay)}
+	}
+	if quota.MaxDownlinkPerDay > 0 {
+		ret.MaxDownlinkPerDay = &wrappers.Int32Value{Value: int32(quota.MaxDownlinkPerDay)}
+	}
+	return ret
+}
+
+// NewTeamUsageFromModel creates an apipb.TeamUsage instance from a
+// model.QuotaUsage instance
+func NewTeamUsageFromModel(usage model.QuotaUsage) *apipb.TeamUsage {
+	return &apipb.TeamUsage{
+		Devices:       &wrappers.Int32Value{Value: int32(usage.Devices)},
+		Collections:   &wrappers.Int32Value{Value: int32(usage.Collections)},
+		Outputs:       &wrappers.Int32Value{Value: int32(usage.Outputs)},
+		FirmwareBytes: &wrappers.Int64Value{Value: usage.FirmwareBytes},
+		UplinkToday:   &wrappers.Int32Value{Value: int32(usage.UplinkToday)},
+		DownlinkToday: &wrappers.Int32Value{Value: int32(usage.DownlinkToday)},
+	}
+}
@@ -30,12 +30,68 @@ import (
 // NotFound if the team doesn't exist or PermissionDenied if the user isn't an
 // admin of the team)
 func EnsureTeamAdmin(userID model.UserKey, id string, store storage.DataStore) (model.Team, error) {
-	// Team ID is included. Ensure it is valid and that the logged in user
-	// is an administrator of that team.
+	return EnsureTeamPermission(userID, id, store, model.ManageTeamPermission)
+}
+
+// EnsureTeamPermission ensures that the user's role in the team grants the
+// permission. The errors are the same as for EnsureTeamAdmin.
+func EnsureTeamPermission(userID model.UserKey, id string, store storage.DataStore, p model.Permission) (model.Team, error) {
 	teamID, err := model.NewTeamKeyFromString(id)
 	if err != nil {
 		return model.Team{}, status.Error(codes.InvalidArgument, "Team ID is invalid")
 	}
+	team, err := retrieveTeam(userID, teamID, store)
+	if err != nil {
+		return model.Team{}, err
+	}
+	if err := CheckPermission(team, userID, p); err != nil {
+		return model.Team{}, err
+	}
+	return team, nil
+}
+
+// EnsurePermission ensures that the user's role in the team grants the
+// permission. This is the check the services use for resources that belongs
+// to a team, ie collections and everything in them. NotFound is returned if
+// the team doesn't exist and PermissionDenied if the permission isn't
+// granted.
+func EnsurePermission(userID model.UserKey, teamID model.TeamKey, store storage.DataStore, p model.Permission) error {
+	team, err := retrieveTeam(userID, teamID, store)
+	if err != nil {
+		return err
+	}
+	return CheckPermission(team, userID, p)
+}
+
+// EnsureCollectionPermission ensures that the user's role in the team that
+// owns the collection grants the permission. NotFound is returned if the
+// collection doesn't exist.
+func EnsureCollectionPermission(userID model.UserKey, collectionID model.CollectionKey, store storage.DataStore, p model.Permission) error {
+	coll, err := store.RetrieveCollection(userID, collectionID)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return status.Error(codes.NotFound, "Unknown collection")
+		}
+		logging.Warning("Error retrieving collection %d: %v", collectionID, err)
+		return status.Error(codes.Internal, "Unable to read collection")
+	}
+	return EnsurePermission(userID, coll.TeamID, store, p)
+}
+
+// CheckPermission checks the user's role in an already loaded team against
+// the policy table in the model package. A PermissionDenied error is
+// returned if the permission isn't granted.
+func CheckPermission(team model.Team, userID model.UserKey, p model.Permission) error {
+	if team.HasPermission(userID, p) {
+		return nil
+	}
+	if p == model.ManageTeamPermission {
+		return status.Error(codes.PermissionDenied, "Must be administrator of team")
+	}
+	return status.Errorf(codes.PermissionDenied, "Your role in the team does not allow you to %s", p)
+}
+
+func retrieveTeam(userID model.UserKey, teamID model.TeamKey, store storage.DataStore) (model.Team, error) {
 	team, err := store.RetrieveTeam(userID, teamID)
 	if err != nil {
 		if err == storage.ErrNotFound {
@@ -44,9 +100,5 @@ func EnsureTeamAdmin(userID model.UserKey, id string, store storage.DataStore) (
 		logging.Warning("Error retrieving team %d: %v", teamID, err)
 		return model.Team{}, status.Error(codes.Internal, "Unable to read team")
 	}
-	if !team.IsAdmin(userID) {
-		return model.Team{}, status.Error(codes.PermissionDenied, "Must be administrator of team")
-	}
-
 	return team, nil
 }
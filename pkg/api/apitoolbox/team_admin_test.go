@@ -64,4 +64,12 @@ func TestTestAdmin(t *testing.T) {
 	_, err = EnsureTeamAdmin(u1.ID, "foo bar baz", store)
 	assert.Error(err)
 	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+
+	// Members can send messages but not manage collections
+	_, err = EnsureTeamPermission(u2.ID, team.ID.String(), store, model.SendMessagePermission)
+	assert.NoError(err)
+	_, err = EnsureTeamPermission(u2.ID, team.ID.String(), store, model.ManageCollectionsPermission)
+	assert.Equal(codes.PermissionDenied.String(), status.Code(err).String())
+	assert.NoError(EnsurePermission(u1.ID, team.ID, store, model.ManageCollectionsPermission))
+	assert.Equal(codes.NotFound.String(), status.Code(EnsurePermission(u2.ID, t1.ID, store, model.ReadDataPermission)).String())
 }
@@ -0,0 +1,711 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/api/apitoolbox"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/golang/protobuf/proto"
+)
+
+// auditSnapshot is the source of the resource snapshots in the audit trail
+type auditSnapshot int
+
+const (
+	noSnapshot       auditSnapshot = iota // No snapshot
+	responseSnapshot                      // The response is the resource
+	retrieveSnapshot                      // The resource is retrieved with the retrieve method
+)
+
+// auditRule is how changes are recorded in the audit trail. The retrieve
+// method is used for snapshots when the response isn't the resource itself.
+// Its request is built from the fields in the original request. The
+// path is the resource ID and the path parameters are filled in from the
+// response, the snapshots and the request (in that order).
+type auditRule struct {
+	ResourceType string
+	Path         string
+	Retrieve     string
+	Before       auditSnapshot
+	After        auditSnapshot
+}
+
+// auditRules are the methods in the Horde service that are recorded in the
+// audit trail. Every write method in rpcScopes must either be in this list
+// or in unauditedMethods.
+var auditRules = map[string]auditRule{
+	"CreateCollection":     {"collection", "/collections/{collection_id}", "", noSnapshot, responseSnapshot},
+	"UpdateCollection":     {"collection", "/collections/{collection_id}", "RetrieveCollection", retrieveSnapshot, responseSnapshot},
+	"DeleteCollection":     {"collection", "/collections/{collection_id}", "", responseSnapshot, noSnapshot},
+	"UpdateCollectionTags": {"collection", "/collections/{collection_id}", "ListCollectionTags", retrieveSnapshot, retrieveSnapshot},
+	"DeleteCollectionTag":  {"collection", "/collections/{collection_id}", "ListCollectionTags", retrieveSnapshot, retrieveSnapshot},
+	"UpdateCollectionTag":  {"collection", "/collections/{collection_id}", "ListCollectionTags", retrieveSnapshot, retrieveSnapshot},
+
+	"CreateDevice":       {"device", "/collections/{collection_id}/devices/{device_id}", "", noSnapshot, responseSnapshot},
+	"UpdateDevice":       {"device", "/collections/{collection_id}/devices/{device_id}", "RetrieveDevice", retrieveSnapshot, responseSnapshot},
+	"DeleteDevice":       {"device", "/collections/{collection_id}/devices/{device_id}", "", responseSnapshot, noSnapshot},
+	"ClearFirmwareError": {"device", "/collections/{collection_id}/devices/{device_id}", "RetrieveDevice", retrieveSnapshot, retrieveSnapshot},
+	"UpdateDeviceTags":   {"device", "/collections/{collection_id}/devices/{identifier}", "ListDeviceTags", retrieveSnapshot, retrieveSnapshot},
+	"DeleteDeviceTag":    {"device", "/collections/{collection_id}/devices/{identifier}", "ListDeviceTags", retrieveSnapshot, retrieveSnapshot},
+	"UpdateDeviceTag":    {"device", "/collections/{collection_id}/devices/{identifier}", "ListDeviceTags", retrieveSnapshot, retrieveSnapshot},
+	"ImportDevices":      {"device", "/collections/{collection_id}/import", "", noSnapshot, responseSnapshot},
+
+	"CreateFirmware":     {"firmware", "/collections/{collection_id}/firmware/{image_id}", "", noSnapshot, responseSnapshot},
+	"UpdateFirmware":     {"firmware", "/collections/{collection_id}/firmware/{image_id}", "RetrieveFirmware", retrieveSnapshot, responseSnapshot},
+	"DeleteFirmware":     {"firmware", "/collections/{collection_id}/firmware/{image_id}", "", responseSnapshot, noSnapshot},
+	"UpdateFirmwareTags": {"firmware", "/collections/{collection_id}/firmware/{identifier}", "ListFirmwareTags", retrieveSnapshot, retrieveSnapshot},
+	"DeleteFirmwareTag":  {"firmware", "/collections/{collection_id}/firmware/{identifier}", "ListFirmwareTags", retrieveSnapshot, retrieveSnapshot},
+	"UpdateFirmwareTag":  {"firmware", "/collections/{collection_id}/firmware/{identifier}", "ListFirmwareTags", retrieveSnapshot, retrieveSnapshot},
+	"CreateSigningKey":   {"signingkey", "/collections/{collection_id}/signingkeys/{key_id}", "", noSnapshot, responseSnapshot},
+	"DeleteSigningKey":   {"signingkey", "/collections/{collection_id}/signingkeys/{key_id}", "", responseSnapshot, noSnapshot},
+
+	"CreateOutput":     {"output", "/collections/{collection_id}/outputs/{output_id}", "", noSnapshot, responseSnapshot},
+	"UpdateOutput":     {"output", "/collections/{collection_id}/outputs/{output_id}", "RetrieveOutput", retrieveSnapshot, responseSnapshot},
+	"DeleteOutput":     {"output", "/collections/{collection_id}/outputs/{output_id}", "", responseSnapshot, noSnapshot},
+	"UpdateOutputTags": {"output", "/collections/{collection_id}/outputs/{identifier}", "ListOutputTags", retrieveSnapshot, retrieveSnapshot},
+	"DeleteOutputTag":  {"output", "/collections/{collection_id}/outputs/{identifier}", "ListOutputTags", retrieveSnapshot, retrieveSnapshot},
+	"UpdateOutputTag":  {"output", "/collections/{collection_id}/outputs/{identifier}", "ListOutputTags", retrieveSnapshot, retrieveSnapshot},
+
+	"CreateTeam":     {"team", "/teams/{team_id}", "", noSnapshot, responseSnapshot},
+	"UpdateTeam":     {"team", "/teams/{team_id}", "RetrieveTeam", retrieveSnapshot, responseSnapshot},
+	"DeleteTeam":     {"team", "/teams/{team_id}", "", responseSnapshot, noSnapshot},
+	"UpdateMember":   {"member", "/teams/{team_id}/members/{user_id}", "RetrieveMember", retrieveSnapshot, responseSnapshot},
+	"DeleteMember":   {"member", "/teams/{team_id}/members/{user_id}", "", responseSnapshot, noSnapshot},
+	"GenerateInvite": {"invite", "/teams/{team_id}/invites/{code}", "", noSnapshot, responseSnapshot},
+	"AcceptInvite":   {"team", "/teams/{team_id}", "", noSnapshot, responseSnapshot},
+	"DeleteInvite":   {"invite", "/teams/{team_id}/invites/{code}", "RetrieveInvite", retrieveSnapshot, noSnapshot},
+	"UpdateTeamTags": {"team", "/teams/{identifier}", "ListTeamTags", retrieveSnapshot, retrieveSnapshot},
+	"DeleteTeamTag":  {"team", "/teams/{identifier}", "ListTeamTags", retrieveSnapshot, retrieveSnapshot},
+	"UpdateTeamTag":  {"team", "/teams/{identifier}", "ListTeamTags", retrieveSnapshot, retrieveSnapshot},
+
+	"CreateToken":     {"token", "/tokens/{id}", "", noSnapshot, responseSnapshot},
+	"UpdateToken":     {"token", "/tokens/{id}", "RetrieveToken", retrieveSnapshot, responseSnapshot},
+	"DeleteToken":     {"token", "/tokens/{id}", "RetrieveToken", retrieveSnapshot, noSnapshot},
+	"RotateToken":     {"token", "/tokens/{id}", "RetrieveToken", retrieveSnapshot, responseSnapshot},
+	"UpdateTokenTags": {"token", "/tokens/{id}", "RetrieveToken", retrieveSnapshot, retrieveSnapshot},
+	"DeleteTokenTag":  {"token", "/tokens/{id}", "RetrieveToken", retrieveSnapshot, retrieveSnapshot},
+	"UpdateTokenTag":  {"token", "/tokens/{id}", "RetrieveToken", retrieveSnapshot, retrieveSnapshot},
+
+	"DataRestore": {"team", "/teams/{team_id}", "", noSnapshot, responseSnapshot},
+}
+
+// unauditedMethods are the write methods that don't change any resources.
+var unauditedMethods = []string{"BroadcastMessage", "SendMessage", "TestOutput", "FirmwareUsage", "DataDump"}
+
+// auditFieldAliases are the fields in the original request that are used
+// for the fields in the retrieve requests. The aliases are checked before
+// the field itself.
+var auditFieldAliases = map[string][]string{
+	"collection_id": {"existing_collection_id"},
+	"token":         {"identifier"},
+}
+
+// secretFields are the fields in the snapshots that contain secrets. The
+// names are compared in lower case.
+var secretFields = map[string]bool{
+	"token":             true,
+	"apitoken":          true,
+	"password":          true,
+	"basicauthpass":     true,
+	"key":               true,
+	"customheadervalue": true,
+}
+
+const redactedValue = "********"
+
+// AuditSnapshot returns a JSON snapshot of a resource for the audit trail.
+// Secrets like passwords and API tokens are redacted. Nil messages have an
+// empty snapshot.
+func AuditSnapshot(msg proto.Message) string {
+	if msg == nil || reflect.ValueOf(msg).IsNil() {
+		return ""
+	}
+	m := apitoolbox.JSONMarshaler()
+	buf, err := m.MarshalToString(msg)
+	if err != nil {
+		logging.Warning("Unable to marshal audit snapshot: %v", err)
+		return ""
+	}
+	var v interface{}
+	decoder := json.NewDecoder(strings.NewReader(buf))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		logging.Warning("Unable to decode audit snapshot: %v", err)
+		return ""
+	}
+	redactSecrets(v)
+	out := &bytes.Buffer{}
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		logging.Warning("Unable to encode audit snapshot: %v", err)
+		return ""
+	}
+	return strings.TrimSpace(out.String())
+}
+
+func redactSecrets(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, field := range val {
+			if s, ok := field.(string); ok && s != "" && secretFields[strings.ToLower(k)] {
+				val[k] = redactedValue
+				continue
+			}
+			redactSecrets(field)
+		}
+	case []interface{}:
+		for _, field := range val {
+			redactSecrets(field)
+		}
+	}
+}
+
+// auditServer records changes made through the Horde service in the audit
+// trail. Methods with rules in auditRules are wrapped; the rest are passed
+// on to the service as is.
+type auditServer struct {
+	apipb.HordeServer
+	store storage.DataStore
+}
+
+// newAuditServer wraps a Horde service with audit recording
+func newAuditServer(server apipb.HordeServer, store storage.DataStore) apipb.HordeServer {
+	return &auditServer{HordeServer: server, store: store}
+}
+
+// auditCall is a call in progress. It is created before the call is passed
+// on to the service since the authentication, the team and the snapshot
+// might be unavailable after the change.
+type auditCall struct {
+	server *auditServer
+	ctx    context.Context
+	method string
+	rule   auditRule
+	req    interface{}
+	auth   *authResult
+	teamID model.TeamKey
+	before proto.Message
+}
+
+// begin starts recording a call. Nil is returned if the call can't be
+// recorded, ie the request isn't authenticated.
+func (a *auditServer) begin(ctx context.Context, method string, req interface{}) *auditCall {
+	rule, ok := auditRules[method]
+	if !ok {
+		return nil
+	}
+	auth := gRPCAuth(ctx, a.store)
+	if auth == nil {
+		return nil
+	}
+	ret := &auditCall{server: a, ctx: ctx, method: method, rule: rule, req: req, auth: auth}
+
+	// Look up the team while the collection still exists
+	collectionID := requestField(req, "existing_collection_id")
+	if collectionID == "" {
+		collectionID = requestField(req, "collection_id")
+	}
+	if collectionID != "" {
+		if id, err := model.NewCollectionKeyFromString(collectionID); err == nil {
+			if coll, err := a.store.RetrieveCollection(auth.User.ID, id); err == nil {
+				ret.teamID = coll.TeamID
+			}
+		}
+	}
+	if rule.Before == retrieveSnapshot {
+		ret.before = a.retrieve(ctx, rule.Retrieve, req)
+	}
+	return ret
+}
+
+// end records the call in the audit trail. Failed calls aren't recorded.
+func (c *auditCall) end(resp proto.Message, err error) {
+	if c == nil || err != nil {
+		return
+	}
+	var before, after proto.Message
+	switch c.rule.Before {
+	case responseSnapshot:
+		before = resp
+	case retrieveSnapshot:
+		before = c.before
+	}
+	switch c.rule.After {
+	case responseSnapshot:
+		after = resp
+	case retrieveSnapshot:
+		after = c.server.retrieve(c.ctx, c.rule.Retrieve, c.req)
+	}
+
+	sources := []interface{}{resp, after, before, c.req}
+	teamID := c.teamID
+	if teamID == 0 {
+		for _, s := range sources {
+			if v := requestField(s, "team_id"); v != "" {
+				teamID, _ = model.NewTeamKeyFromString(v)
+				break
+			}
+		}
+	}
+	if teamID == 0 && c.rule.ResourceType == "team" {
+		teamID, _ = model.NewTeamKeyFromString(requestField(c.req, "identifier"))
+	}
+
+	event := model.AuditEvent{
+		ID:           c.server.store.NewAuditEventID(),
+		Time:         time.Now(),
+		TeamID:       teamID,
+		Source:       model.AuditSourceAPI,
+		UserID:       c.auth.User.ID,
+		TokenID:      c.auth.TokenID,
+		AuthMethod:   c.auth.Method,
+		Action:       c.method,
+		ResourceType: c.rule.ResourceType,
+		ResourceID:   auditResourcePath(c.rule.Path, sources...),
+		Before:       AuditSnapshot(before),
+		After:        AuditSnapshot(after),
+	}
+	if err := c.server.store.CreateAuditEvent(event); err != nil {
+		logging.Warning("Unable to store audit event for %s %s: %v", event.Action, event.ResourceID, err)
+	}
+}
+
+// retrieve retrieves a resource through the service. The request for the
+// retrieve method is built from the original request. Nil is returned if
+// the resource can't be retrieved.
+func (a *auditServer) retrieve(ctx context.Context, method string, req interface{}) proto.Message {
+	m := reflect.ValueOf(a.HordeServer).MethodByName(method)
+	if !m.IsValid() {
+		logging.Error("Unknown retrieve method for audit trail: %s", method)
+		return nil
+	}
+	r := reflect.New(m.Type().In(1).Elem())
+	copyRequestFields(r.Elem(), req)
+	out := m.Call([]reflect.Value{reflect.ValueOf(ctx), r})
+	if !out[1].IsNil() {
+		return nil
+	}
+	ret, _ := out[0].Interface().(proto.Message)
+	return ret
+}
+
+// protoName returns the protobuf name of a struct field
+func protoName(f reflect.StructField) string {
+	for _, v := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(v, "name=") {
+			return strings.TrimPrefix(v, "name=")
+		}
+	}
+	return ""
+}
+
+// protoField returns a field in a protobuf message. The field is identified
+// by its protobuf name.
+func protoField(msg interface{}, name string) (reflect.Value, bool) {
+	v := reflect.ValueOf(msg)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if protoName(v.Type().Field(i)) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// copyRequestFields sets the fields in a request struct to the fields with
+// the same name (or one of its aliases) in the source request
+func copyRequestFields(dst reflect.Value, src interface{}) {
+	for i := 0; i < dst.NumField(); i++ {
+		name := protoName(dst.Type().Field(i))
+		if name == "" {
+			continue
+		}
+		for _, n := range append(auditFieldAliases[name], name) {
+			f, ok := protoField(src, n)
+			if ok && f.Type() == dst.Field(i).Type() && !f.IsZero() {
+				dst.Field(i).Set(f)
+				break
+			}
+		}
+	}
+}
+
+// auditResourcePath fills in the path parameters in the template. The
+// parameters are the first field with the name that is set in the messages.
+func auditResourcePath(template string, msgs ...interface{}) string {
+	var parts []string
+	for _, p := range strings.Split(template, "/") {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			name := p[1 : len(p)-1]
+			p = ""
+			for _, m := range msgs {
+				if p = requestField(m, name); p != "" {
+					break
+				}
+			}
+		}
+		parts = append(parts, p)
+	}
+	return strings.Join(parts, "/")
+}
+
+func (a *auditServer) CreateCollection(ctx context.Context, req *apipb.Collection) (*apipb.Collection, error) {
+	c := a.begin(ctx, "CreateCollection", req)
+	ret, err := a.HordeServer.CreateCollection(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateCollection(ctx context.Context, req *apipb.Collection) (*apipb.Collection, error) {
+	c := a.begin(ctx, "UpdateCollection", req)
+	ret, err := a.HordeServer.UpdateCollection(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteCollection(ctx context.Context, req *apipb.DeleteCollectionRequest) (*apipb.Collection, error) {
+	c := a.begin(ctx, "DeleteCollection", req)
+	ret, err := a.HordeServer.DeleteCollection(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateCollectionTags(ctx context.Context, req *apipb.UpdateTagRequest) (*apipb.TagResponse, error) {
+	c := a.begin(ctx, "UpdateCollectionTags", req)
+	ret, err := a.HordeServer.UpdateCollectionTags(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteCollectionTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "DeleteCollectionTag", req)
+	ret, err := a.HordeServer.DeleteCollectionTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateCollectionTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "UpdateCollectionTag", req)
+	ret, err := a.HordeServer.UpdateCollectionTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) CreateDevice(ctx context.Context, req *apipb.Device) (*apipb.Device, error) {
+	c := a.begin(ctx, "CreateDevice", req)
+	ret, err := a.HordeServer.CreateDevice(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) ImportDevices(ctx context.Context, req *apipb.ImportDevicesRequest) (*apipb.ImportDevicesResponse, error) {
+	c := a.begin(ctx, "ImportDevices", req)
+	ret, err := a.HordeServer.ImportDevices(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateDevice(ctx context.Context, req *apipb.UpdateDeviceRequest) (*apipb.Device, error) {
+	c := a.begin(ctx, "UpdateDevice", req)
+	ret, err := a.HordeServer.UpdateDevice(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteDevice(ctx context.Context, req *apipb.DeviceRequest) (*apipb.Device, error) {
+	c := a.begin(ctx, "DeleteDevice", req)
+	ret, err := a.HordeServer.DeleteDevice(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) ClearFirmwareError(ctx context.Context, req *apipb.DeviceRequest) (*apipb.ClearFirmwareErrorResponse, error) {
+	c := a.begin(ctx, "ClearFirmwareError", req)
+	ret, err := a.HordeServer.ClearFirmwareError(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateDeviceTags(ctx context.Context, req *apipb.UpdateTagRequest) (*apipb.TagResponse, error) {
+	c := a.begin(ctx, "UpdateDeviceTags", req)
+	ret, err := a.HordeServer.UpdateDeviceTags(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteDeviceTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "DeleteDeviceTag", req)
+	ret, err := a.HordeServer.DeleteDeviceTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateDeviceTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "UpdateDeviceTag", req)
+	ret, err := a.HordeServer.UpdateDeviceTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) CreateFirmware(ctx context.Context, req *apipb.CreateFirmwareRequest) (*apipb.Firmware, error) {
+	c := a.begin(ctx, "CreateFirmware", req)
+	ret, err := a.HordeServer.CreateFirmware(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateFirmware(ctx context.Context, req *apipb.Firmware) (*apipb.Firmware, error) {
+	c := a.begin(ctx, "UpdateFirmware", req)
+	ret, err := a.HordeServer.UpdateFirmware(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteFirmware(ctx context.Context, req *apipb.FirmwareRequest) (*apipb.Firmware, error) {
+	c := a.begin(ctx, "DeleteFirmware", req)
+	ret, err := a.HordeServer.DeleteFirmware(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateFirmwareTags(ctx context.Context, req *apipb.UpdateTagRequest) (*apipb.TagResponse, error) {
+	c := a.begin(ctx, "UpdateFirmwareTags", req)
+	ret, err := a.HordeServer.UpdateFirmwareTags(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteFirmwareTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "DeleteFirmwareTag", req)
+	ret, err := a.HordeServer.DeleteFirmwareTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateFirmwareTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "UpdateFirmwareTag", req)
+	ret, err := a.HordeServer.UpdateFirmwareTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) CreateSigningKey(ctx context.Context, req *apipb.SigningKey) (*apipb.SigningKey, error) {
+	c := a.begin(ctx, "CreateSigningKey", req)
+	ret, err := a.HordeServer.CreateSigningKey(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteSigningKey(ctx context.Context, req *apipb.SigningKeyRequest) (*apipb.SigningKey, error) {
+	c := a.begin(ctx, "DeleteSigningKey", req)
+	ret, err := a.HordeServer.DeleteSigningKey(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) CreateOutput(ctx context.Context, req *apipb.Output) (*apipb.Output, error) {
+	c := a.begin(ctx, "CreateOutput", req)
+	ret, err := a.HordeServer.CreateOutput(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateOutput(ctx context.Context, req *apipb.Output) (*apipb.Output, error) {
+	c := a.begin(ctx, "UpdateOutput", req)
+	ret, err := a.HordeServer.UpdateOutput(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteOutput(ctx context.Context, req *apipb.OutputRequest) (*apipb.Output, error) {
+	c := a.begin(ctx, "DeleteOutput", req)
+	ret, err := a.HordeServer.DeleteOutput(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateOutputTags(ctx context.Context, req *apipb.UpdateTagRequest) (*apipb.TagResponse, error) {
+	c := a.begin(ctx, "UpdateOutputTags", req)
+	ret, err := a.HordeServer.UpdateOutputTags(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteOutputTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "DeleteOutputTag", req)
+	ret, err := a.HordeServer.DeleteOutputTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateOutputTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "UpdateOutputTag", req)
+	ret, err := a.HordeServer.UpdateOutputTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) CreateTeam(ctx context.Context, req *apipb.Team) (*apipb.Team, error) {
+	c := a.begin(ctx, "CreateTeam", req)
+	ret, err := a.HordeServer.CreateTeam(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateTeam(ctx context.Context, req *apipb.Team) (*apipb.Team, error) {
+	c := a.begin(ctx, "UpdateTeam", req)
+	ret, err := a.HordeServer.UpdateTeam(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteTeam(ctx context.Context, req *apipb.TeamRequest) (*apipb.Team, error) {
+	c := a.begin(ctx, "DeleteTeam", req)
+	ret, err := a.HordeServer.DeleteTeam(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateMember(ctx context.Context, req *apipb.Member) (*apipb.Member, error) {
+	c := a.begin(ctx, "UpdateMember", req)
+	ret, err := a.HordeServer.UpdateMember(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteMember(ctx context.Context, req *apipb.MemberRequest) (*apipb.Member, error) {
+	c := a.begin(ctx, "DeleteMember", req)
+	ret, err := a.HordeServer.DeleteMember(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) GenerateInvite(ctx context.Context, req *apipb.InviteRequest) (*apipb.Invite, error) {
+	c := a.begin(ctx, "GenerateInvite", req)
+	ret, err := a.HordeServer.GenerateInvite(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) AcceptInvite(ctx context.Context, req *apipb.AcceptInviteRequest) (*apipb.Team, error) {
+	c := a.begin(ctx, "AcceptInvite", req)
+	ret, err := a.HordeServer.AcceptInvite(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteInvite(ctx context.Context, req *apipb.InviteRequest) (*apipb.DeleteInviteResponse, error) {
+	c := a.begin(ctx, "DeleteInvite", req)
+	ret, err := a.HordeServer.DeleteInvite(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateTeamTags(ctx context.Context, req *apipb.UpdateTagRequest) (*apipb.TagResponse, error) {
+	c := a.begin(ctx, "UpdateTeamTags", req)
+	ret, err := a.HordeServer.UpdateTeamTags(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteTeamTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "DeleteTeamTag", req)
+	ret, err := a.HordeServer.DeleteTeamTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateTeamTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "UpdateTeamTag", req)
+	ret, err := a.HordeServer.UpdateTeamTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) CreateToken(ctx context.Context, req *apipb.Token) (*apipb.Token, error) {
+	c := a.begin(ctx, "CreateToken", req)
+	ret, err := a.HordeServer.CreateToken(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateToken(ctx context.Context, req *apipb.Token) (*apipb.Token, error) {
+	c := a.begin(ctx, "UpdateToken", req)
+	ret, err := a.HordeServer.UpdateToken(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteToken(ctx context.Context, req *apipb.DeleteTokenRequest) (*apipb.DeleteTokenResponse, error) {
+	c := a.begin(ctx, "DeleteToken", req)
+	ret, err := a.HordeServer.DeleteToken(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) RotateToken(ctx context.Context, req *apipb.RotateTokenRequest) (*apipb.Token, error) {
+	c := a.begin(ctx, "RotateToken", req)
+	ret, err := a.HordeServer.RotateToken(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateTokenTags(ctx context.Context, req *apipb.UpdateTagRequest) (*apipb.TagResponse, error) {
+	c := a.begin(ctx, "UpdateTokenTags", req)
+	ret, err := a.HordeServer.UpdateTokenTags(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DeleteTokenTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "DeleteTokenTag", req)
+	ret, err := a.HordeServer.DeleteTokenTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) UpdateTokenTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
+	c := a.begin(ctx, "UpdateTokenTag", req)
+	ret, err := a.HordeServer.UpdateTokenTag(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
+
+func (a *auditServer) DataRestore(ctx context.Context, req *apipb.DataRestoreRequest) (*apipb.DataRestoreResponse, error) {
+	c := a.begin(ctx, "DataRestore", req)
+	ret, err := a.HordeServer.DataRestore(ctx, req)
+	c.end(ret, err)
+	return ret, err
+}
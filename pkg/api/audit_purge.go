@@ -0,0 +1,90 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// DefaultAuditPurgeInterval is the default interval for removing expired
+// events from the audit trail.
+const DefaultAuditPurgeInterval = time.Hour
+
+// AuditTrailPurger removes events from the audit trail when they are older
+// than the retention time.
+type AuditTrailPurger struct {
+	store     storage.DataStore
+	retention time.Duration
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewAuditTrailPurger creates a new purger for the audit trail. Expired
+// events are removed at the specified interval until Stop is called. If the
+// retention time is 0 the events are kept forever.
+func NewAuditTrailPurger(store storage.DataStore, retention time.Duration, interval time.Duration) *AuditTrailPurger {
+	ret := &AuditTrailPurger{
+		store:     store,
+		retention: retention,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if retention <= 0 {
+		close(ret.done)
+		return ret
+	}
+	go ret.purgeLoop(interval)
+	return ret
+}
+
+// Purge removes the expired events from the audit trail
+func (p *AuditTrailPurger) Purge() {
+	if p.retention <= 0 {
+		return
+	}
+	count, err := p.store.DeleteAuditEvents(time.Now().Add(-p.retention))
+	if err != nil {
+		logging.Warning("Unable to remove expired audit events: %v", err)
+		return
+	}
+	if count > 0 {
+		logging.Debug("Removed %d expired audit events", count)
+	}
+}
+
+// Stop stops the purger
+func (p *AuditTrailPurger) Stop() {
+	close(p.stop)
+	<-p.done
+}
+
+func (p *AuditTrailPurger) purgeLoop(interval time.Duration) {
+	defer close(p.done)
+	p.Purge()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.Purge()
+		case <-p.stop:
+			return
+		}
+	}
+}
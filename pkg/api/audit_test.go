@@ -0,0 +1,220 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestAuditRules ensures every write method in the Horde service is either
+// recorded in the audit trail or explicitly excluded.
+func TestAuditRules(t *testing.T) {
+	assert := require.New(t)
+
+	service := reflect.TypeOf((*apipb.HordeServer)(nil)).Elem()
+	unaudited := make(map[string]bool)
+	for _, m := range unauditedMethods {
+		unaudited[m] = true
+	}
+	for method, scope := range rpcScopes {
+		_, audited := auditRules[method]
+		if !scope.Write {
+			assert.False(audited, "%s is a read method", method)
+			continue
+		}
+		assert.True(audited != unaudited[method], "%s must be either audited or excluded", method)
+	}
+	for method, rule := range auditRules {
+		assert.NotEmpty(rule.ResourceType, method)
+		assert.NotEmpty(rule.Path, method)
+		if rule.Before == retrieveSnapshot || rule.After == retrieveSnapshot {
+			_, ok := service.MethodByName(rule.Retrieve)
+			assert.True(ok, "Unknown retrieve method %s for %s", rule.Retrieve, method)
+		}
+		// The method must be implemented by the audit server. Methods that
+		// are promoted from the embedded service are autogenerated.
+		m, ok := reflect.TypeOf(&auditServer{}).MethodByName(method)
+		assert.True(ok, method)
+		fn := runtime.FuncForPC(m.Func.Pointer())
+		file, _ := fn.FileLine(fn.Entry())
+		assert.True(strings.HasSuffix(file, "audit.go"), "%s isn't wrapped by the audit server (%s)", method, file)
+	}
+}
+
+func TestAuditSnapshot(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal("", AuditSnapshot(nil))
+	assert.Equal("", AuditSnapshot((*apipb.Token)(nil)))
+
+	snapshot := AuditSnapshot(&apipb.Output{
+		OutputId: &wrappers.StringValue{Value: "1"},
+		Config: &apipb.OutputConfig{
+			Url:           &wrappers.StringValue{Value: "http://example.com/"},
+			BasicAuthUser: &wrappers.StringValue{Value: "user"},
+			BasicAuthPass: &wrappers.StringValue{Value: "secret"},
+		},
+	})
+	assert.NotContains(snapshot, "secret")
+	v := make(map[string]interface{})
+	assert.NoError(json.Unmarshal([]byte(snapshot), &v))
+	config := v["config"].(map[string]interface{})
+	assert.Equal(redactedValue, config["basicAuthPass"])
+	assert.Equal("user", config["basicAuthUser"])
+	assert.Equal("http://example.com/", config["url"])
+}
+
+func TestAuditTrail(t *testing.T) {
+	assert := require.New(t)
+	store := sqlstore.NewMemoryStore()
+	fm := model.FieldMaskParameters{Default: "msisdn", Forced: "msisdn"}
+	svc := NewHordeAPIService(store, fm, output.NewDummyManager(), newDummyDataStoreClient(), newDummyMessageSender(), nil)
+
+	user, _, ctx := createAuthenticatedContext(assert, store)
+	teamID := &wrappers.StringValue{Value: user.PrivateTeamID.String()}
+
+	coll, err := svc.CreateCollection(ctx, &apipb.Collection{TeamId: teamID})
+	assert.NoError(err)
+	_, err = svc.UpdateCollectionTag(ctx, &apipb.TagRequest{
+		CollectionId: coll.CollectionId,
+		Name:         &wrappers.StringValue{Value: "name"},
+		Value:        &wrappers.StringValue{Value: "my collection"},
+	})
+	assert.NoError(err)
+
+	device, err := svc.CreateDevice(ctx, &apipb.Device{
+		CollectionId: coll.CollectionId,
+		Imsi:         &wrappers.StringValue{Value: "1"},
+		Imei:         &wrappers.StringValue{Value: "2"},
+	})
+	assert.NoError(err)
+	_, err = svc.UpdateDevice(ctx, &apipb.UpdateDeviceRequest{
+		ExistingCollectionId: coll.CollectionId,
+		DeviceId:             device.DeviceId,
+		Imei:                 &wrappers.StringValue{Value: "3"},
+	})
+	assert.NoError(err)
+
+	// Failed calls aren't recorded
+	_, err = svc.UpdateDevice(ctx, &apipb.UpdateDeviceRequest{
+		ExistingCollectionId: coll.CollectionId,
+		DeviceId:             &wrappers.StringValue{Value: "0"},
+	})
+	assert.Error(err)
+
+	_, err = svc.CreateOutput(ctx, &apipb.Output{
+		CollectionId: coll.CollectionId,
+		Type:         apipb.Output_ifttt,
+		Config: &apipb.OutputConfig{
+			EventName: &wrappers.StringValue{Value: "event"},
+			Key:       &wrappers.StringValue{Value: "secret"},
+		},
+	})
+	assert.NoError(err)
+
+	_, err = svc.DeleteDevice(ctx, &apipb.DeviceRequest{CollectionId: coll.CollectionId, DeviceId: device.DeviceId})
+	assert.NoError(err)
+
+	// Tokens aren't owned by teams so they're not in the team's trail
+	token, err := svc.CreateToken(ctx, &apipb.Token{Resource: &wrappers.StringValue{Value: "/"}, Write: &wrappers.BoolValue{Value: true}})
+	assert.NoError(err)
+	_, err = svc.DeleteToken(ctx, &apipb.DeleteTokenRequest{Token: token.Token})
+	assert.NoError(err)
+
+	tokenEvents, err := store.ListAuditEvents(0, 0, 10)
+	assert.NoError(err)
+	assert.Len(tokenEvents, 2)
+	assert.Equal("DeleteToken", tokenEvents[0].Action)
+	assert.Equal("CreateToken", tokenEvents[1].Action)
+	for _, e := range tokenEvents {
+		assert.Equal("/tokens/"+token.Id.Value, e.ResourceID)
+		assert.NotContains(e.Before+e.After, token.Token.Value)
+	}
+
+	res, err := svc.ListAuditEvents(ctx, &apipb.ListAuditEventsRequest{TeamId: teamID})
+	assert.NoError(err)
+	assert.Nil(res.Next)
+
+	var actions []string
+	for _, e := range res.Events {
+		actions = append(actions, e.Action.Value)
+		assert.Equal(user.PrivateTeamID.String(), e.TeamId.Value)
+		assert.Equal(user.ID.String(), e.UserId.Value)
+		assert.Equal("github", e.AuthMethod.Value)
+		assert.Equal(model.AuditSourceAPI, e.Source.Value)
+	}
+	assert.Equal([]string{"DeleteDevice", "CreateOutput", "UpdateDevice", "CreateDevice", "UpdateCollectionTag", "CreateCollection"}, actions)
+
+	deviceID := "/collections/" + coll.CollectionId.Value + "/devices/" + device.DeviceId.Value
+	deleteDevice := res.Events[0]
+	assert.Equal("device", deleteDevice.ResourceType.Value)
+	assert.Equal(deviceID, deleteDevice.ResourceId.Value)
+	assert.NotNil(deleteDevice.Before)
+	assert.Nil(deleteDevice.After)
+
+	createOutput := res.Events[1]
+	assert.Equal("output", createOutput.ResourceType.Value)
+	assert.True(strings.HasPrefix(createOutput.ResourceId.Value, "/collections/"+coll.CollectionId.Value+"/outputs/"))
+	assert.Nil(createOutput.Before)
+	assert.NotContains(createOutput.After.Value, "secret")
+
+	updateDevice := res.Events[2]
+	assert.Equal(deviceID, updateDevice.ResourceId.Value)
+	assert.Contains(updateDevice.Before.Value, `"imei":"2"`)
+	assert.Contains(updateDevice.After.Value, `"imei":"3"`)
+	assert.Equal([]string{"imei"}, updateDevice.ChangedFields)
+
+	updateTag := res.Events[4]
+	assert.Equal("/collections/"+coll.CollectionId.Value, updateTag.ResourceId.Value)
+	assert.Equal([]string{"tags"}, updateTag.ChangedFields)
+
+	// Paging
+	res, err = svc.ListAuditEvents(ctx, &apipb.ListAuditEventsRequest{TeamId: teamID, Limit: &wrappers.Int32Value{Value: 4}})
+	assert.NoError(err)
+	assert.Len(res.Events, 4)
+	assert.NotNil(res.Next)
+	res, err = svc.ListAuditEvents(ctx, &apipb.ListAuditEventsRequest{TeamId: teamID, Limit: &wrappers.Int32Value{Value: 4}, Before: res.Next})
+	assert.NoError(err)
+	assert.Len(res.Events, 2)
+	assert.Nil(res.Next)
+
+	_, err = svc.ListAuditEvents(ctx, &apipb.ListAuditEventsRequest{TeamId: teamID, Limit: &wrappers.Int32Value{Value: 0}})
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+	_, err = svc.ListAuditEvents(ctx, &apipb.ListAuditEventsRequest{})
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+
+	// Members without the manage team permission can't see the trail
+	other, _, otherCtx := createAuthenticatedContext(assert, store)
+	team, err := store.RetrieveTeam(user.ID, user.PrivateTeamID)
+	assert.NoError(err)
+	team.AddMember(model.NewMember(other, model.MemberRole))
+	assert.NoError(store.UpdateTeam(user.ID, team))
+	_, err = svc.ListAuditEvents(otherCtx, &apipb.ListAuditEventsRequest{TeamId: teamID})
+	assert.Equal(codes.PermissionDenied.String(), status.Code(err).String())
+}
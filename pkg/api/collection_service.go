@@ -57,7 +57,7 @@ type collectionService struct {
 	defaultGrpcAuth
 }
 
-func (s *collectionService) getFirmwareManagement(setting *apipb.CollectionFirmware) (model.FirmwareManagementSetting, error) {
+func getFirmwareManagement(setting *apipb.CollectionFirmware) (model.FirmwareManagementSetting, error) {
 	switch setting.Management {
 	case apipb.CollectionFirmware_collection:
 		return model.CollectionManagement, nil
@@ -84,18 +84,27 @@ func (s *collectionService) CreateCollection(ctx context.Context, req *apipb.Col
 	collection.TeamID = auth.User.PrivateTeamID
 	collection.FieldMask = s.fieldMask.DefaultFields()
 	if req.TeamId != nil {
-		team, err := apitoolbox.EnsureTeamAdmin(auth.User.ID, req.TeamId.Value, s.store)
+		team, err := apitoolbox.EnsureTeamPermission(auth.User.ID, req.TeamId.Value, s.store, model.ManageCollectionsPermission)
 		if err != nil {
 			return nil, err
 		}
 		collection.TeamID = team.ID
 	}
 	if req.Firmware != nil {
-		newManagement, err := s.getFirmwareManagement(req.Firmware)
+		newManagement, err := getFirmwareManagement(req.Firmware)
 		if err != nil {
 			return nil, err
 		}
 		collection.Firmware.Management = newManagement
+		if req.Firmware.RequireSignature != nil {
+			collection.Firmware.RequireSignature = req.Firmware.RequireSignature.Value
+		}
+		if req.Firmware.MaintenanceWindow != nil {
+			collection.Firmware.MaintenanceWindow, err = apitoolbox.UpdateMaintenanceWindow(collection.Firmware.MaintenanceWindow, req.Firmware.MaintenanceWindow)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if req.FieldMask != nil {
@@ -111,12 +120,17 @@ func (s *collectionService) CreateCollection(ctx context.Context, req *apipb.Col
 	// Note that any other fields are ignored if they're supplied. If f.e. the collection id
 	// is set in the request it will be ignore.
 
+	if err := apitoolbox.EnsureQuota(s.store, collection.TeamID, model.Quota.CheckCollections); err != nil {
+		return nil, err
+	}
+
 	// Now store the new collection.
 	collection.ID = s.store.NewCollectionID()
 	if err := s.store.CreateCollection(auth.User.ID, collection); err != nil {
 		logging.Warning("Error storing new collection for user %d: %v", auth.User.ID, err)
 		return nil, status.Error(codes.Internal, "Unable to store the collection")
 	}
+	s.outputManager.PublishEvent(model.NewCollectionEvent(model.CollectionCreated, collection))
 	return apitoolbox.NewCollectionFromModel(collection), nil
 }
 
@@ -147,11 +161,15 @@ func (s *collectionService) DeleteCollection(ctx context.Context, req *apipb.Del
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, s.store, model.ManageCollectionsPermission); err != nil {
+		return nil, err
+	}
 	if err := s.store.DeleteCollection(auth.User.ID, coll.ID); err != nil {
 		// There shouldn't be a not found error here unless there's two concurrent requests.
 		logging.Warning("Unable to remove collection %d: %v", coll.ID, err)
 		return nil, status.Error(codes.Internal, "Unable to remove collection")
 	}
+	s.outputManager.PublishEvent(model.NewCollectionEvent(model.CollectionDeleted, coll))
 	return apitoolbox.NewCollectionFromModel(coll), nil
 }
 
@@ -168,10 +186,23 @@ func (s *collectionService) UpdateCollection(ctx context.Context, req *apipb.Col
 		return nil, err
 	}
 
+	// Firmware settings can be changed by firmware managers, everything else
+	// requires the collection permission.
+	if req.Firmware != nil {
+		if err := apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, s.store, model.ManageFirmwarePermission); err != nil {
+			return nil, err
+		}
+	}
+	if req.TeamId != nil || req.Tags != nil || req.FieldMask != nil {
+		if err := apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, s.store, model.ManageCollectionsPermission); err != nil {
+			return nil, err
+		}
+	}
+
 	// If the team ID is set in the request and different from the old make sure
-	// the user is an admin of the new team
+	// the user is allowed to manage collections in the new team
 	if req.TeamId != nil {
-		team, err := apitoolbox.EnsureTeamAdmin(auth.User.ID, req.TeamId.Value, s.store)
+		team, err := apitoolbox.EnsureTeamPermission(auth.User.ID, req.TeamId.Value, s.store, model.ManageCollectionsPermission)
 		if err != nil {
 			return nil, err
 		}
@@ -188,6 +219,8 @@ func (s *collectionService) UpdateCollection(ctx context.Context, req *apipb.Col
 	}
 
 	if req.Firmware != nil {
+		requiredBefore := coll.Firmware.RequireSignature
+		targetBefore := coll.Firmware.TargetFirmwareID
 		checkFirmware := false
 		if req.Firmware.CurrentFirmwareId != nil {
 			// Check if firmware ID is valid, check if it exists, assign
@@ -211,12 +244,32 @@ func (s *collectionService) UpdateCollection(ctx context.Context, req *apipb.Col
 			}
 		}
 		if req.Firmware.Management != apipb.CollectionFirmware_unspecified {
-			newManagement, err := s.getFirmwareManagement(req.Firmware)
+			newManagement, err := getFirmwareManagement(req.Firmware)
 			if err != nil {
 				return nil, err
 			}
 			coll.Firmware.Management = newManagement
 		}
+		if req.Firmware.RequireSignature != nil {
+			coll.Firmware.RequireSignature = req.Firmware.RequireSignature.Value
+		}
+		if req.Firmware.MaintenanceWindow != nil {
+			coll.Firmware.MaintenanceWindow, err = apitoolbox.UpdateMaintenanceWindow(coll.Firmware.MaintenanceWindow, req.Firmware.MaintenanceWindow)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// The target image must be signed when the requirement is turned on
+		// or the target changes.
+		if coll.Firmware.RequireSignature && (!requiredBefore || coll.Firmware.TargetFirmwareID != targetBefore) {
+			_, target, err := s.store.RetrieveCurrentAndTargetFirmware(coll.ID, 0, coll.Firmware.TargetFirmwareID)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "Unknown firmware ID")
+			}
+			if err := ensureSignedTarget(coll.Firmware.RequireSignature, target); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	fieldMaskChange := false
@@ -228,6 +281,7 @@ func (s *collectionService) UpdateCollection(ctx context.Context, req *apipb.Col
 		logging.Warning("Error updating collection %d: %v", coll.ID, err)
 		return nil, status.Error(codes.Internal, "Error updating collection")
 	}
+	s.outputManager.PublishEvent(model.NewCollectionEvent(model.CollectionUpdated, coll))
 
 	if fieldMaskChange {
 		outputs, err := s.store.ListOutputs(auth.User.ID, coll.ID)
@@ -255,13 +309,21 @@ func (s *collectionService) ListCollections(ctx context.Context, req *apipb.List
 	if err != nil {
 		return nil, err
 	}
-	list, err := s.store.ListCollections(auth.User.ID)
+	opts, err := apitoolbox.NewListOptions(req.PageSize, req.PageToken, nil, req.TagFilter)
+	if err != nil {
+		return nil, err
+	}
+	list, next, err := s.store.ListCollectionsPage(auth.User.ID, opts)
 	if err != nil {
+		if err == storage.ErrInvalidListOptions {
+			return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+		}
 		return nil, status.Error(codes.Internal, "Unable to list collections")
 	}
 
 	ret := &apipb.ListCollectionResponse{
-		Collections: make([]*apipb.Collection, 0),
+		Collections:   make([]*apipb.Collection, 0),
+		NextPageToken: next,
 	}
 	for _, v := range list {
 		ret.Collections = append(ret.Collections, apitoolbox.NewCollectionFromModel(v))
@@ -282,6 +344,9 @@ func (s *collectionService) ListCollectionMessages(ctx context.Context, req *api
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsurePermission(auth.User.ID, collection.TeamID, s.store, model.ReadDataPermission); err != nil {
+		return nil, err
+	}
 
 	dataFilter := &datastore.DataFilter{
 		CollectionId: req.CollectionId.Value,
@@ -325,6 +390,9 @@ func (s *collectionService) BroadcastMessage(ctx context.Context, req *apipb.Sen
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsurePermission(auth.User.ID, collection.TeamID, s.store, model.SendMessagePermission); err != nil {
+		return nil, err
+	}
 
 	msg, err := apitoolbox.NewDownstreamMessage(req)
 	if err != nil {
@@ -366,6 +434,9 @@ func (s *collectionService) MessageStream(req *apipb.MessageStreamRequest, svr a
 	if err != nil {
 		return err
 	}
+	if err := apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, s.store, model.ReadDataPermission); err != nil {
+		return err
+	}
 
 	deviceID := model.DeviceKey(0)
 	if req.DeviceId != nil {
@@ -379,16 +450,26 @@ func (s *collectionService) MessageStream(req *apipb.MessageStreamRequest, svr a
 
 		defer s.outputManager.Unsubscribe(ch)
 		for msg := range ch {
-			data, ok := msg.(model.DataMessage)
-			if !ok {
+			var out *apipb.OutputDataMessage
+			switch v := msg.(type) {
+			case model.DataMessage:
+				if deviceID != 0 && deviceID != v.Device.ID {
+					continue
+				}
+				out = apitoolbox.NewOutputDataMessageFromModel(v, coll)
+			case model.ResourceEvent:
+				// Device streams only get the events for the device
+				if deviceID != 0 && (v.Device == nil || deviceID != v.Device.ID) {
+					continue
+				}
+				out = apitoolbox.NewOutputEventMessageFromModel(v, coll)
+			default:
 				logging.Error("Did not get model.DataMessage from channel. Got %T (%+v)", msg, msg)
 				continue
 			}
-			if deviceID == 0 || deviceID == data.Device.ID {
-				if err := svr.Send(apitoolbox.NewOutputDataMessageFromModel(data, coll)); err != nil {
-					logging.Debug("Got error %v sending data message to client", err)
-					return
-				}
+			if err := svr.Send(out); err != nil {
+				logging.Debug("Got error %v sending data message to client", err)
+				return
 			}
 		}
 	}()
@@ -421,9 +502,18 @@ func (s *collectionService) loadCollection(auth *authResult, identifier string)
 	return coll, nil
 }
 
+func (s *collectionService) EnsureTagUpdate(auth *authResult, res taggedResource) error {
+	coll := res.(*model.Collection)
+	return apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, s.store, model.ManageCollectionsPermission)
+}
+
 func (s *collectionService) UpdateResourceTags(id model.UserKey, collectionID, identifier string, res interface{}) error {
 	coll := res.(*model.Collection)
-	return s.store.UpdateCollectionTags(id, collectionID, coll.Tags)
+	if err := s.store.UpdateCollectionTags(id, collectionID, coll.Tags); err != nil {
+		return err
+	}
+	s.outputManager.PublishEvent(model.NewCollectionEvent(model.CollectionUpdated, *coll))
+	return nil
 }
 
 func (s *collectionService) ListCollectionTags(ctx context.Context, req *apipb.TagRequest) (*apipb.TagResponse, error) {
@@ -414,6 +414,59 @@ func TestUpdateCollection(t *testing.T) {
 	assert.Error(err)
 	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
 
+	// Set the maintenance window for the collection
+	res, err = cs.UpdateCollection(ctx, &apipb.Collection{
+		CollectionId: &wrappers.StringValue{Value: tmpColl.ID.String()},
+		Firmware: &apipb.CollectionFirmware{
+			MaintenanceWindow: &apipb.MaintenanceWindow{
+				Schedule:        &wrappers.StringValue{Value: "0 22 * * 1-5"},
+				DurationMinutes: &wrappers.Int32Value{Value: 480},
+				TimeZone:        &wrappers.StringValue{Value: "Europe/Oslo"},
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.Equal("0 22 * * 1-5", res.Firmware.MaintenanceWindow.Schedule.Value)
+	assert.Equal(int32(480), res.Firmware.MaintenanceWindow.DurationMinutes.Value)
+	assert.Equal("Europe/Oslo", res.Firmware.MaintenanceWindow.TimeZone.Value)
+
+	// Fields that aren't set keep their value
+	res, err = cs.UpdateCollection(ctx, &apipb.Collection{
+		CollectionId: &wrappers.StringValue{Value: tmpColl.ID.String()},
+		Firmware: &apipb.CollectionFirmware{
+			MaintenanceWindow: &apipb.MaintenanceWindow{
+				DurationMinutes: &wrappers.Int32Value{Value: 60},
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.Equal("0 22 * * 1-5", res.Firmware.MaintenanceWindow.Schedule.Value)
+	assert.Equal(int32(60), res.Firmware.MaintenanceWindow.DurationMinutes.Value)
+
+	// Invalid windows are rejected
+	_, err = cs.UpdateCollection(ctx, &apipb.Collection{
+		CollectionId: &wrappers.StringValue{Value: tmpColl.ID.String()},
+		Firmware: &apipb.CollectionFirmware{
+			MaintenanceWindow: &apipb.MaintenanceWindow{
+				TimeZone: &wrappers.StringValue{Value: "Nowhere/Special"},
+			},
+		},
+	})
+	assert.Error(err)
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+
+	// An empty schedule removes the window
+	res, err = cs.UpdateCollection(ctx, &apipb.Collection{
+		CollectionId: &wrappers.StringValue{Value: tmpColl.ID.String()},
+		Firmware: &apipb.CollectionFirmware{
+			MaintenanceWindow: &apipb.MaintenanceWindow{
+				Schedule: &wrappers.StringValue{Value: ""},
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.Nil(res.Firmware.MaintenanceWindow)
+
 	// Create an output for the collection. This isn't used but changes in the
 	// field mask requires interaction with existing collections.
 	output := model.NewOutput()
@@ -0,0 +1,440 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ExploratoryEngineering/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eesrc/horde/pkg/addons/magpie/datastore"
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/api/apitoolbox"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// DataRestorer recreates the collections, devices, outputs and firmware
+// metadata in a data dump in a team. Everything gets new IDs when it is
+// restored. Teams, tokens and the user profile in the dump are ignored.
+// Firmware images aren't included in the dumps so only the metadata is
+// restored and the outputs are disabled to avoid delivering the same messages
+// from two servers.
+type DataRestorer struct {
+	store     storage.DataStore
+	dataStore datastore.DataStoreClient
+	fieldMask model.FieldMaskParameters
+	events    output.EventPublisher
+}
+
+// NewDataRestorer creates a new DataRestorer. The data store client and the
+// event publisher are optional. Data can't be restored without the data store
+// client.
+func NewDataRestorer(store storage.DataStore, dataStore datastore.DataStoreClient, fieldMask model.FieldMaskParameters, events output.EventPublisher) *DataRestorer {
+	return &DataRestorer{
+		store:     store,
+		dataStore: dataStore,
+		fieldMask: fieldMask,
+		events:    events,
+	}
+}
+
+// restoreCollection is a collection in the dump while it is restored
+type restoreCollection struct {
+	source     *apipb.DumpedCollection
+	collection model.Collection
+	firmware   []model.Firmware
+	devices    []restoreDevice
+	outputs    []model.Output
+	messages   int
+	created    bool
+}
+
+// restoreDevice is a device in the dump while it is restored
+type restoreDevice struct {
+	source *apipb.DumpedDevice
+	device model.Device
+}
+
+// Restore restores the dump in the team. Nothing is restored if one or more
+// of the devices in the dump conflicts with existing devices. The conflicts
+// are returned in the response. The data in the dump is stored with the
+// original timestamps if includeData is set.
+func (r *DataRestorer) Restore(ctx context.Context, userID model.UserKey, teamID model.TeamKey, dump *apipb.DataDumpResponse, includeData bool) (*apipb.DataRestoreResponse, error) {
+	if dump == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing data dump")
+	}
+	for _, p := range []model.Permission{
+		model.ManageCollectionsPermission,
+		model.ManageDevicesPermission,
+		model.ManageOutputsPermission,
+		model.ManageFirmwarePermission} {
+		if err := apitoolbox.EnsurePermission(userID, teamID, r.store, p); err != nil {
+			return nil, err
+		}
+	}
+	if includeData && r.dataStore == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Data can't be restored without a data store")
+	}
+
+	collections, conflicts, err := r.prepare(teamID, dump)
+	if err != nil {
+		return nil, err
+	}
+	ret := &apipb.DataRestoreResponse{
+		Collections: make([]*apipb.RestoredCollection, 0),
+		Conflicts:   conflicts,
+	}
+	if len(conflicts) > 0 {
+		return ret, nil
+	}
+	if err := r.checkQuota(teamID, collections); err != nil {
+		return nil, err
+	}
+
+	for _, c := range collections {
+		conflicts, err := r.restoreCollection(userID, c)
+		if err != nil || len(conflicts) > 0 {
+			r.remove(userID, collections)
+			ret.Conflicts = conflicts
+			return ret, err
+		}
+	}
+	if includeData {
+		for _, c := range collections {
+			if err := r.restoreData(ctx, c); err != nil {
+				r.remove(userID, collections)
+				return nil, err
+			}
+		}
+	}
+
+	for _, c := range collections {
+		r.publish(model.NewCollectionEvent(model.CollectionCreated, c.collection))
+		for _, d := range c.devices {
+			r.publish(model.NewDeviceEvent(model.DeviceCreated, d.device))
+		}
+		for _, o := range c.outputs {
+			r.publish(model.NewOutputEvent(model.OutputCreated, o))
+		}
+		ret.Collections = append(ret.Collections, &apipb.RestoredCollection{
+			SourceCollectionId: c.source.Collection.GetCollectionId().GetValue(),
+			Collection:         apitoolbox.NewCollectionFromModel(c.collection),
+			Devices:            int32(len(c.devices)),
+			Outputs:            int32(len(c.outputs)),
+			Firmware:           int32(len(c.firmware)),
+			Messages:           int32(c.messages),
+		})
+	}
+	return ret, nil
+}
+
+func (r *DataRestorer) publish(ev model.ResourceEvent) {
+	if r.events != nil {
+		r.events.PublishEvent(ev)
+	}
+}
+
+// prepare converts the collections in the dump into model types and checks
+// the devices for conflicts. Nothing is written to the store.
+func (r *DataRestorer) prepare(teamID model.TeamKey, dump *apipb.DataDumpResponse) ([]*restoreCollection, []string, error) {
+	var conflicts []string
+	imsis := make(map[int64]bool)
+	imeis := make(map[int64]bool)
+
+	var ret []*restoreCollection
+	for _, dc := range dump.Collections {
+		if dc == nil || dc.Collection == nil {
+			return nil, nil, status.Error(codes.InvalidArgument, "Collection is missing from data dump")
+		}
+		sourceID := dc.Collection.GetCollectionId().GetValue()
+
+		c := &restoreCollection{
+			source:     dc,
+			collection: model.NewCollection(),
+		}
+		c.collection.TeamID = teamID
+		c.collection.FieldMask = r.fieldMask.DefaultFields()
+		apitoolbox.SetFieldMask(&c.collection.FieldMask, dc.Collection.FieldMask, r.fieldMask)
+		if dc.Collection.Firmware != nil {
+			management, err := getFirmwareManagement(dc.Collection.Firmware)
+			if err != nil {
+				return nil, nil, err
+			}
+			c.collection.Firmware.Management = management
+		}
+		for k, v := range dc.Collection.Tags {
+			if !c.collection.IsValidTag(k, v) {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "Invalid tag/name combination in collection %s", sourceID)
+			}
+			c.collection.SetTag(k, v)
+		}
+
+		for _, f := range dc.Firmware {
+			fw := model.NewFirmware()
+			fw.Version = f.GetVersion().GetValue()
+			fw.Filename = f.GetFilename().GetValue()
+			fw.SHA256 = f.GetSha256().GetValue()
+			fw.Length = int(f.GetLength().GetValue())
+			fw.Created = apitoolbox.MillisToTime(f.GetCreated().GetValue())
+			for k, v := range f.Tags {
+				if !fw.IsValidTag(k, v) {
+					return nil, nil, status.Errorf(codes.InvalidArgument, "Invalid tag/name combination for firmware %s", f.GetImageId().GetValue())
+				}
+				fw.SetTag(k, v)
+			}
+			c.firmware = append(c.firmware, fw)
+		}
+
+		for _, dd := range dc.Devices {
+			if dd == nil || dd.Device == nil {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "Device is missing from collection %s", sourceID)
+			}
+			d, conflict, err := r.prepareDevice(dd.Device, imsis, imeis)
+			if err != nil {
+				return nil, nil, err
+			}
+			if conflict != "" {
+				conflicts = append(conflicts, conflict)
+				continue
+			}
+			c.devices = append(c.devices, restoreDevice{source: dd, device: d})
+		}
+
+		for _, o := range dc.Outputs {
+			if o == nil {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "Output is missing from collection %s", sourceID)
+			}
+			op := model.NewOutput()
+			op.Type = o.Type.String()
+			op.Config = apitoolbox.NewOutputConfigFromAPI(o)
+			op.Enabled = false
+			for k, v := range o.Tags {
+				if !op.IsValidTag(k, v) {
+					return nil, nil, status.Errorf(codes.InvalidArgument, "Invalid tag/name combination for output %s", o.GetOutputId().GetValue())
+				}
+				op.SetTag(k, v)
+			}
+			c.outputs = append(c.outputs, op)
+		}
+		ret = append(ret, c)
+	}
+	return ret, conflicts, nil
+}
+
+// prepareDevice converts a device in the dump into a model.Device. A
+// description of the conflict is returned if the device can't be restored.
+func (r *DataRestorer) prepareDevice(device *apipb.Device, imsis, imeis map[int64]bool) (model.Device, string, error) {
+	sourceID := device.GetDeviceId().GetValue()
+	d := model.NewDevice()
+	if device.Imsi == nil || device.Imei == nil {
+		return d, fmt.Sprintf("Device %s: IMSI and IMEI are masked in the data dump", sourceID), nil
+	}
+	var err error
+	if d.IMSI, err = strconv.ParseInt(device.Imsi.Value, 10, 63); err != nil || d.IMSI <= 0 {
+		return d, fmt.Sprintf("Device %s: invalid IMSI %q", sourceID, device.Imsi.Value), nil
+	}
+	if d.IMEI, err = strconv.ParseInt(device.Imei.Value, 10, 63); err != nil || d.IMEI <= 0 {
+		return d, fmt.Sprintf("Device %s: invalid IMEI %q", sourceID, device.Imei.Value), nil
+	}
+	if imsis[d.IMSI] {
+		return d, fmt.Sprintf("Device %s: IMSI %d is used by more than one device in the data dump", sourceID, d.IMSI), nil
+	}
+	if imeis[d.IMEI] {
+		return d, fmt.Sprintf("Device %s: IMEI %d is used by more than one device in the data dump", sourceID, d.IMEI), nil
+	}
+	imsis[d.IMSI] = true
+	imeis[d.IMEI] = true
+
+	// The existing device might belong to another team so only the IMSI is
+	// reported.
+	_, err = r.store.RetrieveDeviceByIMSI(d.IMSI)
+	switch err {
+	case nil:
+		return d, fmt.Sprintf("Device %s: IMSI %d is already in use", sourceID, d.IMSI), nil
+	case storage.ErrNotFound:
+	default:
+		logging.Warning("Unable to look up IMSI %d: %v", d.IMSI, err)
+		return d, "", status.Error(codes.Internal, "Unable to check devices for conflicts")
+	}
+
+	for k, v := range device.Tags {
+		if !d.IsValidTag(k, v) {
+			return d, "", status.Errorf(codes.InvalidArgument, "Invalid tag/name combination for device %s", sourceID)
+		}
+		d.SetTag(k, v)
+	}
+	if fw := device.Firmware; fw != nil {
+		d.Firmware.SerialNumber = fw.GetSerialNumber().GetValue()
+		d.Firmware.ModelNumber = fw.GetModelNumber().GetValue()
+		d.Firmware.Manufacturer = fw.GetManufacturer().GetValue()
+		d.Firmware.FirmwareVersion = fw.GetFirmwareVersion().GetValue()
+	}
+	return d, "", nil
+}
+
+// checkQuota checks the new resources against the team's quota
+func (r *DataRestorer) checkQuota(teamID model.TeamKey, collections []*restoreCollection) error {
+	devices, outputs := 0, 0
+	firmwareBytes := int64(0)
+	for _, c := range collections {
+		devices += len(c.devices)
+		outputs += len(c.outputs)
+		for _, fw := range c.firmware {
+			firmwareBytes += int64(fw.Length)
+		}
+	}
+	return apitoolbox.EnsureQuota(r.store, teamID, func(quota model.Quota, usage model.QuotaUsage) error {
+		if err := quota.CheckNewCollections(usage, len(collections)); err != nil {
+			return err
+		}
+		if err := quota.CheckNewDevices(usage, devices); err != nil {
+			return err
+		}
+		if err := quota.CheckNewOutputs(usage, outputs); err != nil {
+			return err
+		}
+		return quota.CheckFirmware(usage, firmwareBytes)
+	})
+}
+
+// restoreCollection creates the collection, the firmware, the devices and the
+// outputs in the store. Devices that conflict with existing devices are
+// returned as conflicts.
+func (r *DataRestorer) restoreCollection(userID model.UserKey, c *restoreCollection) ([]string, error) {
+	c.collection.ID = r.store.NewCollectionID()
+	if err := r.store.CreateCollection(userID, c.collection); err != nil {
+		logging.Warning("Unable to create collection for restore: %v", err)
+		return nil, status.Error(codes.Internal, "Unable to create collection")
+	}
+	c.created = true
+
+	for i, source := range c.source.Firmware {
+		c.firmware[i].ID = r.store.NewFirmwareID()
+		c.firmware[i].CollectionID = c.collection.ID
+		if err := r.store.CreateFirmware(userID, c.firmware[i]); err != nil {
+			c.firmware = c.firmware[:i]
+			logging.Warning("Unable to create firmware for restore: %v", err)
+			return nil, status.Errorf(codes.Internal, "Unable to create firmware %s", source.GetVersion().GetValue())
+		}
+	}
+
+	// The firmware images aren't included in the dump so the current and
+	// target firmware for the collection and the devices are left unset.
+	// FOTA would otherwise send the devices to images that don't exist.
+	devices := make([]model.Device, len(c.devices))
+	for i, d := range c.devices {
+		devices[i] = d.device
+		devices[i].CollectionID = c.collection.ID
+	}
+	result, err := r.store.ImportDevices(userID, c.collection.ID, devices, false, nil)
+	if err != nil {
+		logging.Warning("Unable to import devices for restored collection %d: %v", c.collection.ID, err)
+		c.devices = nil
+		return nil, status.Error(codes.Internal, "Unable to create devices")
+	}
+	if len(result.Errors) > 0 {
+		var conflicts []string
+		for _, e := range result.Errors {
+			conflicts = append(conflicts, fmt.Sprintf("Device %s: %s", c.devices[e.Index].source.Device.GetDeviceId().GetValue(), e.Message))
+		}
+		c.devices = nil
+		return conflicts, nil
+	}
+	created := make(map[int64]model.Device)
+	for _, d := range result.Created {
+		created[d.IMSI] = d
+	}
+	for i := range c.devices {
+		c.devices[i].device = created[c.devices[i].device.IMSI]
+	}
+
+	for i := range c.outputs {
+		c.outputs[i].ID = r.store.NewOutputID()
+		c.outputs[i].CollectionID = c.collection.ID
+		if err := r.store.CreateOutput(userID, c.outputs[i]); err != nil {
+			c.outputs = c.outputs[:i]
+			logging.Warning("Unable to create output for restored collection %d: %v", c.collection.ID, err)
+			return nil, status.Error(codes.Internal, "Unable to create output")
+		}
+	}
+	return nil, nil
+}
+
+// restoreData stores the data for the restored devices in the data store. The
+// messages keep their original timestamps.
+func (r *DataRestorer) restoreData(ctx context.Context, c *restoreCollection) error {
+	sequence := int64(1)
+	for _, d := range c.devices {
+		for _, msg := range d.source.Data {
+			if msg == nil || msg.Received == nil {
+				continue
+			}
+			dataMsg := apitoolbox.NewDataMessageFromOutputDataMessage(msg, d.device)
+			if _, err := r.dataStore.StoreData(ctx, &datastore.DataMessage{
+				Sequence:     sequence,
+				CollectionId: d.device.CollectionID.String(),
+				DeviceId:     d.device.ID.String(),
+				Payload:      dataMsg.Payload,
+				Created:      dataMsg.Received.UnixNano(),
+				Metadata:     apitoolbox.MarshalDataStoreMetadata(dataMsg),
+			}); err != nil {
+				logging.Warning("Unable to store data for restored device %d: %v", d.device.ID, err)
+				return status.Error(codes.Internal, "Unable to store data")
+			}
+			sequence++
+			c.messages++
+		}
+	}
+	return nil
+}
+
+// remove removes the restored resources from the store if the restore fails.
+// Data that is stored in the data store isn't removed but it won't be
+// reachable since the IDs aren't reused.
+func (r *DataRestorer) remove(userID model.UserKey, collections []*restoreCollection) {
+	for _, c := range collections {
+		if !c.created {
+			continue
+		}
+		for _, o := range c.outputs {
+			if err := r.store.DeleteOutput(userID, c.collection.ID, o.ID); err != nil {
+				logging.Warning("Unable to remove output %d after failed restore: %v", o.ID, err)
+			}
+		}
+		for _, d := range c.devices {
+			if err := r.store.DeleteDevice(userID, c.collection.ID, d.device.ID); err != nil {
+				logging.Warning("Unable to remove device %d after failed restore: %v", d.device.ID, err)
+			}
+		}
+		for _, fw := range c.firmware {
+			if fw.ID == 0 {
+				continue
+			}
+			if err := r.store.DeleteFirmware(userID, c.collection.ID, fw.ID); err != nil {
+				logging.Warning("Unable to remove firmware %d after failed restore: %v", fw.ID, err)
+			}
+		}
+		if err := r.store.DeleteCollection(userID, c.collection.ID); err != nil {
+			logging.Warning("Unable to remove collection %d after failed restore: %v", c.collection.ID, err)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eesrc/horde/pkg/addons/magpie"
+	"github.com/eesrc/horde/pkg/addons/magpie/datastore"
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/eesrc/horde/pkg/utils/grpcutil"
+)
+
+// newTestDataStore starts a separate data store. The IDs in the memory stores
+// overlap so the data would otherwise be mixed with the data in the other
+// tests.
+func newTestDataStore(assert *require.Assertions, name string) datastore.DataStoreClient {
+	server, err := magpie.NewDataServer(sqlstore.Parameters{
+		ConnectionString: "file:" + name + "?mode=memory&cache=shared",
+		Type:             "sqlite3",
+		CreateSchema:     true,
+	})
+	assert.NoError(err)
+	ep, err := magpie.StartServer(server, grpcutil.GRPCServerParam{
+		Endpoint: "127.0.0.1:0",
+	})
+	assert.NoError(err)
+	conn, err := grpcutil.NewGRPCClientConnection(grpcutil.GRPCClientParam{
+		ServerEndpoint: ep,
+	})
+	assert.NoError(err)
+	return datastore.NewDataStoreClient(conn)
+}
+
+func TestDataRestore(t *testing.T) {
+	assert := require.New(t)
+	dataStoreClient := newTestDataStore(assert, "restore")
+
+	// Populate the source with a collection with firmware, devices, an output
+	// and some data
+	source := sqlstore.NewMemoryStore()
+	sourceUser, _, sourceCtx := createAuthenticatedContext(assert, source)
+
+	c := model.NewCollection()
+	c.ID = source.NewCollectionID()
+	c.TeamID = sourceUser.PrivateTeamID
+	c.SetTag("name", "restore me")
+	c.Firmware.Management = model.CollectionManagement
+	assert.NoError(source.CreateCollection(sourceUser.ID, c))
+
+	fw := model.NewFirmware()
+	fw.ID = source.NewFirmwareID()
+	fw.CollectionID = c.ID
+	fw.Version = "1.0.0"
+	fw.SHA256 = "abc123"
+	fw.Length = 1024
+	fw.SetTag("name", "first")
+	assert.NoError(source.CreateFirmware(sourceUser.ID, fw))
+	c.Firmware.TargetFirmwareID = fw.ID
+	assert.NoError(source.UpdateCollection(sourceUser.ID, c))
+
+	o := model.NewOutput()
+	o.ID = source.NewOutputID()
+	o.CollectionID = c.ID
+	o.Type = "udp"
+	o.Config[outputconfig.UDPHost] = "example.com"
+	o.Config[outputconfig.UDPPort] = 4711
+	o.Enabled = true
+	assert.NoError(source.CreateOutput(sourceUser.ID, o))
+
+	received := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	var devices []model.Device
+	for i := 0; i < 3; i++ {
+		d := model.NewDevice()
+		d.ID = source.NewDeviceID()
+		d.CollectionID = c.ID
+		d.IMSI = int64(7100 + i)
+		d.IMEI = int64(7200 + i)
+		d.SetTag("name", "device")
+		assert.NoError(source.CreateDevice(sourceUser.ID, d))
+		devices = append(devices, d)
+
+		_, err := dataStoreClient.StoreData(context.Background(), &datastore.DataMessage{
+			Sequence:     int64(i),
+			CollectionId: c.ID.String(),
+			DeviceId:     d.ID.String(),
+			Created:      received.UnixNano(),
+			Metadata:     []byte(`{"transport":"udp"}`),
+			Payload:      []byte("restored payload"),
+		})
+		assert.NoError(err)
+	}
+
+	sourceSvc := newSystemService(model.FieldMaskParameters{}, source, dataStoreClient, nil)
+	dump, err := sourceSvc.DataDump(sourceCtx, &apipb.DataDumpRequest{})
+	assert.NoError(err)
+	assert.Len(dump.Collections, 1)
+	assert.Len(dump.Collections[0].Firmware, 1)
+
+	// Restore into a new store
+	target := sqlstore.NewMemoryStore()
+	targetUser, _, targetCtx := createAuthenticatedContext(assert, target)
+	svc := newSystemService(model.FieldMaskParameters{}, target, dataStoreClient, nil)
+
+	_, err = svc.DataRestore(context.Background(), &apipb.DataRestoreRequest{
+		TeamId: &wrappers.StringValue{Value: targetUser.PrivateTeamID.String()},
+		Dump:   dump,
+	})
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+
+	_, err = svc.DataRestore(targetCtx, &apipb.DataRestoreRequest{Dump: dump})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = svc.DataRestore(targetCtx, &apipb.DataRestoreRequest{
+		TeamId: &wrappers.StringValue{Value: targetUser.PrivateTeamID.String()},
+	})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Unknown teams are not found
+	_, err = svc.DataRestore(targetCtx, &apipb.DataRestoreRequest{
+		TeamId: &wrappers.StringValue{Value: model.TeamKey(4711).String()},
+		Dump:   dump,
+	})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	res, err := svc.DataRestore(targetCtx, &apipb.DataRestoreRequest{
+		TeamId:      &wrappers.StringValue{Value: targetUser.PrivateTeamID.String()},
+		Dump:        dump,
+		IncludeData: &wrappers.BoolValue{Value: true},
+	})
+	assert.NoError(err)
+	assert.Len(res.Conflicts, 0)
+	assert.Len(res.Collections, 1)
+	rc := res.Collections[0]
+	assert.Equal(c.ID.String(), rc.SourceCollectionId)
+	assert.Equal(int32(3), rc.Devices)
+	assert.Equal(int32(1), rc.Outputs)
+	assert.Equal(int32(1), rc.Firmware)
+	assert.Equal(int32(3), rc.Messages)
+
+	collectionID, err := model.NewCollectionKeyFromString(rc.Collection.CollectionId.Value)
+	assert.NoError(err)
+	coll, err := target.RetrieveCollection(targetUser.ID, collectionID)
+	assert.NoError(err)
+	assert.Equal(targetUser.PrivateTeamID, coll.TeamID)
+	assert.Equal("restore me", coll.GetTag("name"))
+	assert.Equal(model.CollectionManagement, coll.Firmware.Management)
+
+	firmware, err := target.ListFirmware(targetUser.ID, collectionID)
+	assert.NoError(err)
+	assert.Len(firmware, 1)
+	assert.Equal("1.0.0", firmware[0].Version)
+	assert.Equal("first", firmware[0].GetTag("name"))
+	// There's no image for the firmware so it isn't used as the target
+	assert.Equal(model.FirmwareKey(0), coll.Firmware.TargetFirmwareID)
+
+	outputs, err := target.ListOutputs(targetUser.ID, collectionID)
+	assert.NoError(err)
+	assert.Len(outputs, 1)
+	assert.False(outputs[0].Enabled)
+	assert.Equal("example.com", outputs[0].Config[outputconfig.UDPHost])
+
+	restored, err := target.ListDevices(targetUser.ID, collectionID)
+	assert.NoError(err)
+	assert.Len(restored, 3)
+	for _, d := range restored {
+		assert.Equal("device", d.GetTag("name"))
+		assert.Equal(model.FirmwareKey(0), d.Firmware.TargetFirmwareID)
+
+		stream, err := dataStoreClient.GetData(context.Background(), &datastore.DataFilter{
+			CollectionId: collectionID.String(),
+			DeviceId:     d.ID.String(),
+			From:         received.UnixNano(),
+			To:           received.UnixNano(),
+		})
+		assert.NoError(err)
+		msg, err := stream.Recv()
+		assert.NoError(err)
+		assert.Equal(received.UnixNano(), msg.Created)
+		assert.Equal([]byte("restored payload"), msg.Payload)
+	}
+
+	// Restoring the same dump again conflicts with the restored devices and
+	// nothing is created
+	res, err = svc.DataRestore(targetCtx, &apipb.DataRestoreRequest{
+		TeamId: &wrappers.StringValue{Value: targetUser.PrivateTeamID.String()},
+		Dump:   dump,
+	})
+	assert.NoError(err)
+	assert.Len(res.Conflicts, 3)
+	// The conflicting devices might belong to other teams so their IDs
+	// aren't reported
+	for _, c := range res.Conflicts {
+		assert.True(strings.HasSuffix(c, "is already in use"), c)
+	}
+	assert.Len(res.Collections, 0)
+	collections, err := target.ListCollections(targetUser.ID)
+	assert.NoError(err)
+	assert.Len(collections, 1)
+}
+
+func TestDataRestoreRollback(t *testing.T) {
+	assert := require.New(t)
+
+	store := sqlstore.NewMemoryStore()
+	user, _, ctx := createAuthenticatedContext(assert, store)
+
+	existing := model.NewCollection()
+	existing.ID = store.NewCollectionID()
+	existing.TeamID = user.PrivateTeamID
+	assert.NoError(store.CreateCollection(user.ID, existing))
+	d := model.NewDevice()
+	d.ID = store.NewDeviceID()
+	d.CollectionID = existing.ID
+	d.IMSI = 8100
+	d.IMEI = 8200
+	assert.NoError(store.CreateDevice(user.ID, d))
+
+	// The first collection is fine but the IMEI in the second collection is
+	// already in use. The conflict is detected when the devices are created
+	// and the first collection is removed.
+	newDevice := func(imsi, imei string) *apipb.DumpedDevice {
+		return &apipb.DumpedDevice{Device: &apipb.Device{
+			DeviceId: &wrappers.StringValue{Value: "1"},
+			Imsi:     &wrappers.StringValue{Value: imsi},
+			Imei:     &wrappers.StringValue{Value: imei},
+		}}
+	}
+	dump := &apipb.DataDumpResponse{
+		Collections: []*apipb.DumpedCollection{
+			{
+				Collection: &apipb.Collection{CollectionId: &wrappers.StringValue{Value: "1"}},
+				Devices:    []*apipb.DumpedDevice{newDevice("8101", "8201")},
+				Firmware: []*apipb.Firmware{{
+					ImageId: &wrappers.StringValue{Value: "2"},
+					Version: &wrappers.StringValue{Value: "1.0"},
+					Sha256:  &wrappers.StringValue{Value: "abc"},
+				}},
+				Outputs: []*apipb.Output{{Type: apipb.Output_udp, Config: &apipb.OutputConfig{}}},
+			},
+			{
+				Collection: &apipb.Collection{CollectionId: &wrappers.StringValue{Value: "3"}},
+				Devices:    []*apipb.DumpedDevice{newDevice("8102", "8200")},
+			},
+		},
+	}
+
+	restorer := NewDataRestorer(store, nil, model.FieldMaskParameters{}, nil)
+	res, err := restorer.Restore(ctx, user.ID, user.PrivateTeamID, dump, false)
+	assert.NoError(err)
+	assert.Len(res.Conflicts, 1)
+	assert.Len(res.Collections, 0)
+
+	collections, err := store.ListCollections(user.ID)
+	assert.NoError(err)
+	assert.Len(collections, 1)
+	_, err = store.RetrieveDeviceByIMSI(8101)
+	assert.Error(err)
+
+	// Data can't be restored without a data store
+	_, err = restorer.Restore(ctx, user.ID, user.PrivateTeamID, dump, true)
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// The quota is checked before anything is created
+	assert.NoError(store.UpdateTeamQuota(user.PrivateTeamID, model.Quota{MaxCollections: 2}))
+	dump.Collections[1].Devices = nil
+	_, err = restorer.Restore(ctx, user.ID, user.PrivateTeamID, dump, false)
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+
+	assert.NoError(store.UpdateTeamQuota(user.PrivateTeamID, model.Quota{}))
+	res, err = restorer.Restore(ctx, user.ID, user.PrivateTeamID, dump, false)
+	assert.NoError(err)
+	assert.Len(res.Collections, 2)
+}
@@ -0,0 +1,423 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/api/apitoolbox"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	formatCSV       = "csv"
+	formatNDJSON    = "ndjson"
+	maxImportRows   = 100000 // Maximum number of devices in a single import
+	exportChunkSize = 500    // Number of devices in each export message
+	csvColumnID     = "id"
+	csvColumnIMSI   = "imsi"
+	csvColumnIMEI   = "imei"
+	maxImportErrors = 100 // Maximum number of errors in the import response
+)
+
+// importDevice is a device in the NDJSON import and export format. The IMSI
+// and IMEI can be either strings or numbers when importing.
+type importDevice struct {
+	ID   string            `json:"id,omitempty"`
+	IMSI json.Number       `json:"imsi"`
+	IMEI json.Number       `json:"imei"`
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// deviceImport holds the parsed devices and the row numbers for each device
+type deviceImport struct {
+	devices []model.Device
+	rows    []int
+	errors  []*apipb.ImportError
+	imsi    map[int64]int
+	imei    map[int64]int
+	ids     map[model.DeviceKey]int
+}
+
+func newDeviceImport() *deviceImport {
+	return &deviceImport{
+		imsi: make(map[int64]int),
+		imei: make(map[int64]int),
+		ids:  make(map[model.DeviceKey]int),
+	}
+}
+
+func (d *deviceImport) addError(row int, format string, args ...interface{}) {
+	d.errors = append(d.errors, &apipb.ImportError{Row: int32(row), Message: fmt.Sprintf(format, args...)})
+}
+
+// add validates and adds a device to the import
+func (d *deviceImport) add(row int, id, imsi, imei string, tags map[string]string) {
+	device := model.NewDevice()
+	var err error
+	if id = strings.TrimSpace(id); id != "" {
+		if device.ID, err = model.NewDeviceKeyFromString(id); err != nil {
+			d.addError(row, "Invalid device ID: %s", id)
+			return
+		}
+	}
+	if device.IMSI, err = strconv.ParseInt(strings.TrimSpace(imsi), 10, 63); err != nil || device.IMSI <= 0 {
+		d.addError(row, "Invalid IMSI: %s", imsi)
+		return
+	}
+	if device.IMEI, err = strconv.ParseInt(strings.TrimSpace(imei), 10, 63); err != nil || device.IMEI <= 0 {
+		d.addError(row, "Invalid IMEI: %s", imei)
+		return
+	}
+	for k, v := range tags {
+		if !device.IsValidTag(k, v) {
+			d.addError(row, "Invalid tag name: %q", k)
+			return
+		}
+		device.SetTag(k, v)
+	}
+	if other, exists := d.imsi[device.IMSI]; exists {
+		d.addError(row, "IMSI %d is also in row %d", device.IMSI, other)
+		return
+	}
+	if other, exists := d.imei[device.IMEI]; exists {
+		d.addError(row, "IMEI %d is also in row %d", device.IMEI, other)
+		return
+	}
+	if other, exists := d.ids[device.ID]; exists && device.ID != 0 {
+		d.addError(row, "Device ID %s is also in row %d", id, other)
+		return
+	}
+	d.imsi[device.IMSI] = row
+	d.imei[device.IMEI] = row
+	d.ids[device.ID] = row
+	d.devices = append(d.devices, device)
+	d.rows = append(d.rows, row)
+}
+
+func (d *deviceImport) tooLarge() bool {
+	return len(d.devices)+len(d.errors) > maxImportRows
+}
+
+// parseCSV parses CSV data. The first row is the header.
+func (d *deviceImport) parseCSV(data []byte) error {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "Missing CSV header")
+	}
+	columns := make(map[string]int)
+	for i, v := range header {
+		name := strings.ToLower(strings.TrimSpace(v))
+		if _, exists := columns[name]; exists || name == "" {
+			return status.Errorf(codes.InvalidArgument, "Empty or duplicate column %q in CSV header", v)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns[csvColumnIMSI]; !ok {
+		return status.Error(codes.InvalidArgument, "The CSV header must have an imsi column")
+	}
+	if _, ok := columns[csvColumnIMEI]; !ok {
+		return status.Error(codes.InvalidArgument, "The CSV header must have an imei column")
+	}
+	for row := 2; ; row++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			d.addError(row, "Invalid CSV: %v", err)
+			continue
+		}
+		tags := make(map[string]string)
+		id := ""
+		for name, i := range columns {
+			switch name {
+			case csvColumnID:
+				id = record[i]
+			case csvColumnIMSI, csvColumnIMEI:
+			default:
+				tags[name] = record[i]
+			}
+		}
+		d.add(row, id, record[columns[csvColumnIMSI]], record[columns[csvColumnIMEI]], tags)
+		if d.tooLarge() {
+			return status.Errorf(codes.InvalidArgument, "Imports are limited to %d devices", maxImportRows)
+		}
+	}
+}
+
+// parseNDJSON parses newline delimited JSON data. Empty lines are ignored.
+func (d *deviceImport) parseNDJSON(data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for row := 1; scanner.Scan(); row++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var dev importDevice
+		if err := json.Unmarshal(line, &dev); err != nil {
+			d.addError(row, "Invalid JSON: %v", err)
+			continue
+		}
+		d.add(row, dev.ID, dev.IMSI.String(), dev.IMEI.String(), dev.Tags)
+		if d.tooLarge() {
+			return status.Errorf(codes.InvalidArgument, "Imports are limited to %d devices", maxImportRows)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Unable to read data: %v", err)
+	}
+	return nil
+}
+
+func importFormat(format *wrappers.StringValue) (string, error) {
+	if format == nil || format.Value == "" {
+		return formatCSV, nil
+	}
+	f := strings.ToLower(format.Value)
+	if f != formatCSV && f != formatNDJSON {
+		return "", status.Errorf(codes.InvalidArgument, "Unknown format %q. Use csv or ndjson", format.Value)
+	}
+	return f, nil
+}
+
+func (d *deviceService) ImportDevices(ctx context.Context, req *apipb.ImportDevicesRequest) (*apipb.ImportDevicesResponse, error) {
+	if req == nil || req.CollectionId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing collection ID")
+	}
+	auth, err := d.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	format, err := importFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+	coll, err := d.loadCollection(auth, req.CollectionId.Value)
+	if err != nil {
+		return nil, err
+	}
+	if err := apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, d.store, model.ManageDevicesPermission); err != nil {
+		return nil, err
+	}
+
+	imp := newDeviceImport()
+	if format == formatNDJSON {
+		err = imp.parseNDJSON(req.Data)
+	} else {
+		err = imp.parseCSV(req.Data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret := &apipb.ImportDevicesResponse{}
+	if len(imp.errors) > 0 {
+		ret.Errors = truncateImportErrors(imp.errors)
+		return ret, nil
+	}
+	if len(imp.devices) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No devices to import")
+	}
+
+	if coll.Firmware.Management == model.CollectionManagement {
+		for i := range imp.devices {
+			imp.devices[i].Firmware.TargetFirmwareID = coll.Firmware.TargetFirmwareID
+		}
+	}
+	check, err := apitoolbox.NewDeviceImportCheck(d.store, coll.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	upsert := req.Upsert != nil && req.Upsert.Value
+	result, err := d.store.ImportDevices(auth.User.ID, coll.ID, imp.devices, upsert, check)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		if err == storage.ErrAccess {
+			return nil, status.Error(codes.PermissionDenied, "Must be administrator to import devices")
+		}
+		logging.Warning("Unable to import devices to collection %d: %v", coll.ID, err)
+		return nil, status.Error(codes.Internal, "Unable to import devices")
+	}
+	for _, v := range result.Errors {
+		ret.Errors = append(ret.Errors, &apipb.ImportError{Row: int32(imp.rows[v.Index]), Message: v.Message})
+	}
+	if len(ret.Errors) > 0 {
+		ret.Errors = truncateImportErrors(ret.Errors)
+		return ret, nil
+	}
+	for _, v := range result.Created {
+		d.events.PublishEvent(model.NewDeviceEvent(model.DeviceCreated, v))
+	}
+	for _, v := range result.Updated {
+		d.events.PublishEvent(model.NewDeviceEvent(model.DeviceUpdated, v))
+	}
+	ret.Created = int32(len(result.Created))
+	ret.Updated = int32(len(result.Updated))
+	return ret, nil
+}
+
+// truncateImportErrors limits the number of errors in the response. The
+// last error says how many errors were omitted.
+func truncateImportErrors(errs []*apipb.ImportError) []*apipb.ImportError {
+	if len(errs) <= maxImportErrors {
+		return errs
+	}
+	ret := errs[:maxImportErrors]
+	return append(ret, &apipb.ImportError{Message: fmt.Sprintf("%d more errors omitted", len(errs)-maxImportErrors)})
+}
+
+func (d *deviceService) ExportDevices(req *apipb.ExportDevicesRequest, svr apipb.Horde_ExportDevicesServer) error {
+	if req == nil || req.CollectionId == nil {
+		return status.Error(codes.InvalidArgument, "Missing collection ID")
+	}
+	auth, err := d.EnsureAuth(svr.Context())
+	if err != nil {
+		return err
+	}
+	format, err := importFormat(req.Format)
+	if err != nil {
+		return err
+	}
+	coll, err := d.loadCollection(auth, req.CollectionId.Value)
+	if err != nil {
+		return err
+	}
+	// The CSV header has a column for every tag name so the CSV export reads
+	// the devices twice; first for the tag names and then for the export.
+	var tagNames []string
+	if format == formatCSV {
+		names := make(map[string]bool)
+		if err := d.forEachDevicePage(auth.User.ID, coll.ID, func(devices []model.Device, first bool) error {
+			addExportTagNames(names, devices)
+			return nil
+		}); err != nil {
+			return err
+		}
+		tagNames = sortedExportTagNames(names)
+	}
+	return d.forEachDevicePage(auth.User.ID, coll.ID, func(devices []model.Device, first bool) error {
+		var buf bytes.Buffer
+		var err error
+		if format == formatCSV {
+			err = writeExportCSV(&buf, devices, tagNames, first)
+		} else {
+			err = writeExportNDJSON(&buf, devices)
+		}
+		if err != nil {
+			logging.Warning("Unable to encode device export for collection %d: %v", coll.ID, err)
+			return status.Error(codes.Internal, "Unable to export devices")
+		}
+		return svr.Send(&apipb.ExportDevicesResponse{Data: buf.Bytes()})
+	})
+}
+
+// forEachDevicePage reads the devices in the collection one page at a time
+// and calls the function for each page. The function is called at least once,
+// even if the collection is empty.
+func (d *deviceService) forEachDevicePage(userID model.UserKey, collectionID model.CollectionKey, fn func(devices []model.Device, first bool) error) error {
+	opts := model.ListOptions{PageSize: exportChunkSize}
+	for first := true; ; first = false {
+		devices, next, err := d.store.ListDevicesPage(userID, collectionID, opts)
+		if err != nil {
+			logging.Warning("Unable to read device list for collection %d: %v", collectionID, err)
+			return status.Error(codes.Internal, "Unable to read device list")
+		}
+		if err := fn(devices, first); err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		opts.PageToken = next
+	}
+}
+
+// addExportTagNames adds the tag names for the CSV columns. Tags with the
+// same name as the fixed columns can't be exported.
+func addExportTagNames(names map[string]bool, devices []model.Device) {
+	for _, d := range devices {
+		for k := range d.TagMap {
+			if k != csvColumnID && k != csvColumnIMSI && k != csvColumnIMEI {
+				names[k] = true
+			}
+		}
+	}
+}
+
+// sortedExportTagNames returns the sorted tag names for the CSV columns
+func sortedExportTagNames(names map[string]bool) []string {
+	var ret []string
+	for k := range names {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func writeExportCSV(w io.Writer, devices []model.Device, tagNames []string, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		if err := cw.Write(append([]string{csvColumnID, csvColumnIMSI, csvColumnIMEI}, tagNames...)); err != nil {
+			return err
+		}
+	}
+	for _, d := range devices {
+		record := []string{d.ID.String(), strconv.FormatInt(d.IMSI, 10), strconv.FormatInt(d.IMEI, 10)}
+		for _, name := range tagNames {
+			record = append(record, d.GetTag(name))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeExportNDJSON(w io.Writer, devices []model.Device) error {
+	enc := json.NewEncoder(w)
+	for _, d := range devices {
+		if err := enc.Encode(importDevice{
+			ID:   d.ID.String(),
+			IMSI: json.Number(strconv.FormatInt(d.IMSI, 10)),
+			IMEI: json.Number(strconv.FormatInt(d.IMEI, 10)),
+			Tags: d.TagData(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
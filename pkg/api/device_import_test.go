@@ -0,0 +1,214 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type exportStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	data bytes.Buffer
+}
+
+func (e *exportStream) Context() context.Context {
+	return e.ctx
+}
+
+func (e *exportStream) Send(msg *apipb.ExportDevicesResponse) error {
+	e.data.Write(msg.Data)
+	return nil
+}
+
+func TestImportDevices(t *testing.T) {
+	dt := newDeviceTest(t)
+	collectionID := &wrappers.StringValue{Value: dt.collection.ID.String()}
+
+	_, err := dt.deviceService.ImportDevices(dt.ctx, nil)
+	dt.assert.Error(err)
+	_, err = dt.deviceService.ImportDevices(dt.ctx, &apipb.ImportDevicesRequest{
+		CollectionId: collectionID,
+		Format:       &wrappers.StringValue{Value: "xml"},
+	})
+	dt.assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+
+	// Rows with errors are reported and nothing is imported
+	res, err := dt.deviceService.ImportDevices(dt.ctx, &apipb.ImportDevicesRequest{
+		CollectionId: collectionID,
+		Data: []byte(`IMSI,imei,Site
+1001,2001,oslo
+x,2002,oslo
+1003,2001,bergen
+1004,2004
+`),
+	})
+	dt.assert.NoError(err)
+	dt.assert.Len(res.Errors, 3)
+	dt.assert.Equal(int32(3), res.Errors[0].Row)
+	dt.assert.Equal(int32(4), res.Errors[1].Row)
+	dt.assert.Equal(int32(5), res.Errors[2].Row)
+	dt.assert.Equal(int32(0), res.Created)
+
+	res, err = dt.deviceService.ImportDevices(dt.ctx, &apipb.ImportDevicesRequest{
+		CollectionId: collectionID,
+		Data: []byte(`imsi,imei,site
+1001,2001,oslo
+1002,2002,
+`),
+	})
+	dt.assert.NoError(err)
+	dt.assert.Empty(res.Errors)
+	dt.assert.Equal(int32(2), res.Created)
+
+	d, err := dt.store.RetrieveDeviceByIMSI(1001)
+	dt.assert.NoError(err)
+	dt.assert.Equal("oslo", d.GetTag("site"))
+
+	// Existing devices fail without upsert
+	ndjson := []byte(`{"imsi": 1001, "imei": "2001", "tags": {"site": "bergen"}}
+
+{"imsi": "1005", "imei": 2005}
+`)
+	res, err = dt.deviceService.ImportDevices(dt.ctx, &apipb.ImportDevicesRequest{
+		CollectionId: collectionID,
+		Format:       &wrappers.StringValue{Value: "ndjson"},
+		Data:         ndjson,
+	})
+	dt.assert.NoError(err)
+	dt.assert.Len(res.Errors, 1)
+	dt.assert.Equal(int32(1), res.Errors[0].Row)
+
+	res, err = dt.deviceService.ImportDevices(dt.ctx, &apipb.ImportDevicesRequest{
+		CollectionId: collectionID,
+		Format:       &wrappers.StringValue{Value: "ndjson"},
+		Data:         ndjson,
+		Upsert:       &wrappers.BoolValue{Value: true},
+	})
+	dt.assert.NoError(err)
+	dt.assert.Empty(res.Errors)
+	dt.assert.Equal(int32(1), res.Created)
+	dt.assert.Equal(int32(1), res.Updated)
+
+	d, err = dt.store.RetrieveDeviceByIMSI(1001)
+	dt.assert.NoError(err)
+	dt.assert.Equal("bergen", d.GetTag("site"))
+
+	// The quota includes all of the new devices
+	dt.assert.NoError(dt.store.UpdateTeamQuota(dt.user.PrivateTeamID, model.Quota{MaxDevices: 5}))
+	_, err = dt.deviceService.ImportDevices(dt.ctx, &apipb.ImportDevicesRequest{
+		CollectionId: collectionID,
+		Data:         []byte("imsi,imei\n1006,2006\n1007,2007\n"),
+	})
+	dt.assert.Equal(codes.ResourceExhausted.String(), status.Code(err).String())
+	_, err = dt.store.RetrieveDeviceByIMSI(1006)
+	dt.assert.Error(err)
+}
+
+func TestExportDevices(t *testing.T) {
+	dt := newDeviceTest(t)
+	collectionID := &wrappers.StringValue{Value: dt.collection.ID.String()}
+
+	res, err := dt.deviceService.ImportDevices(dt.ctx, &apipb.ImportDevicesRequest{
+		CollectionId: collectionID,
+		Data:         []byte("imsi,imei,name,id\n1001,2001,\"a, b\",\n1002,2002,,\n"),
+	})
+	dt.assert.NoError(err)
+	dt.assert.Equal(int32(2), res.Created)
+
+	dt.assert.Error(dt.deviceService.ExportDevices(nil, &exportStream{ctx: dt.ctx}))
+
+	// The export can be imported into another collection after the devices
+	// are removed.
+	for _, format := range []string{"csv", "ndjson"} {
+		stream := &exportStream{ctx: dt.ctx}
+		dt.assert.NoError(dt.deviceService.ExportDevices(&apipb.ExportDevicesRequest{
+			CollectionId: collectionID,
+			Format:       &wrappers.StringValue{Value: format},
+		}, stream))
+		data := stream.data.String()
+		dt.assert.Contains(data, "1001")
+		dt.assert.Contains(data, "4711")
+		if format == "csv" {
+			dt.assert.True(strings.HasPrefix(data, "id,imsi,imei,name\n"), data)
+			dt.assert.Contains(data, "\"a, b\"")
+		}
+
+		list, err := dt.store.ListDevices(dt.user.ID, dt.collection.ID)
+		dt.assert.NoError(err)
+		for _, d := range list {
+			dt.assert.NoError(dt.store.DeleteDevice(dt.user.ID, dt.collection.ID, d.ID))
+		}
+		res, err := dt.deviceService.ImportDevices(dt.ctx, &apipb.ImportDevicesRequest{
+			CollectionId: collectionID,
+			Format:       &wrappers.StringValue{Value: format},
+			Data:         stream.data.Bytes(),
+		})
+		dt.assert.NoError(err)
+		dt.assert.Empty(res.Errors)
+		dt.assert.Equal(int32(3), res.Created)
+
+		d, err := dt.store.RetrieveDeviceByIMSI(1001)
+		dt.assert.NoError(err)
+		dt.assert.Equal("a, b", d.GetTag("name"))
+
+		// The device IDs are kept
+		_, err = dt.store.RetrieveDevice(dt.user.ID, dt.collection.ID, dt.device.ID)
+		dt.assert.NoError(err)
+	}
+}
+
+func TestExportDevicesPages(t *testing.T) {
+	dt := newDeviceTest(t)
+	collectionID := &wrappers.StringValue{Value: dt.collection.ID.String()}
+
+	// The tag on the last device is included in the CSV header even if the
+	// device isn't on the first page.
+	var data strings.Builder
+	data.WriteString("imsi,imei,last\n")
+	for i := 0; i <= exportChunkSize; i++ {
+		last := ""
+		if i == exportChunkSize {
+			last = "yes"
+		}
+		fmt.Fprintf(&data, "%d,%d,%s\n", 10000+i, 20000+i, last)
+	}
+	res, err := dt.deviceService.ImportDevices(dt.ctx, &apipb.ImportDevicesRequest{
+		CollectionId: collectionID,
+		Data:         []byte(data.String()),
+	})
+	dt.assert.NoError(err)
+	dt.assert.Empty(res.Errors)
+
+	stream := &exportStream{ctx: dt.ctx}
+	dt.assert.NoError(dt.deviceService.ExportDevices(&apipb.ExportDevicesRequest{CollectionId: collectionID}, stream))
+	lines := strings.Split(strings.TrimSpace(stream.data.String()), "\n")
+	dt.assert.Equal("id,imsi,imei,last", lines[0])
+	// The header, the imported devices and the device in the test collection
+	dt.assert.Len(lines, exportChunkSize+3)
+	dt.assert.Contains(stream.data.String(), ",yes\n")
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/api/apitoolbox"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
 	"github.com/eesrc/horde/pkg/storage"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -34,12 +35,14 @@ import (
 func newDeviceService(
 	store storage.DataStore,
 	dataStoreClient datastore.DataStoreClient,
-	sender DownstreamMessageSender) deviceService {
+	sender DownstreamMessageSender,
+	events output.EventPublisher) deviceService {
 	return deviceService{
 		store:           store,
 		dataStoreClient: dataStoreClient,
 		defaultGrpcAuth: defaultGrpcAuth{Store: store},
 		sender:          sender,
+		events:          events,
 	}
 }
 
@@ -47,6 +50,7 @@ type deviceService struct {
 	store           storage.DataStore
 	dataStoreClient datastore.DataStoreClient
 	sender          DownstreamMessageSender
+	events          output.EventPublisher
 
 	defaultGrpcAuth
 }
@@ -106,6 +110,12 @@ func (d *deviceService) CreateDevice(ctx context.Context, req *apipb.Device) (*a
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, d.store, model.ManageDevicesPermission); err != nil {
+		return nil, err
+	}
+	if err := apitoolbox.EnsureQuota(d.store, coll.TeamID, model.Quota.CheckDevices); err != nil {
+		return nil, err
+	}
 
 	device.CollectionID = coll.ID
 	device.ID = d.store.NewDeviceID()
@@ -124,6 +134,7 @@ func (d *deviceService) CreateDevice(ctx context.Context, req *apipb.Device) (*a
 		logging.Warning("Unable to create device on collection %d: %v", coll.ID, err)
 		return nil, status.Error(codes.Internal, "Unable to create device")
 	}
+	d.events.PublishEvent(model.NewDeviceEvent(model.DeviceCreated, device))
 
 	return apitoolbox.NewDeviceFromModel(device, coll), nil
 }
@@ -165,11 +176,29 @@ func (d *deviceService) UpdateDevice(ctx context.Context, req *apipb.UpdateDevic
 	if err != nil {
 		return nil, err
 	}
+	before := device
+	before.TagMap = device.TagData()
 
-	if req.Firmware != nil && coll.Firmware.Management == model.CollectionManagement {
+	// The maintenance window can be set on devices regardless of the firmware
+	// management setting for the collection.
+	if req.Firmware != nil && coll.Firmware.Management == model.CollectionManagement &&
+		(req.Firmware.CurrentFirmwareId != nil || req.Firmware.TargetFirmwareId != nil) {
 		return nil, status.Error(codes.InvalidArgument, "Firmware is managed by the collection")
 	}
 
+	// Firmware settings can be changed by firmware managers, everything else
+	// requires the device permission.
+	if req.Firmware != nil {
+		if err := apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, d.store, model.ManageFirmwarePermission); err != nil {
+			return nil, err
+		}
+	}
+	if req.Imei != nil || req.Imsi != nil || req.Tags != nil || req.CollectionId != nil {
+		if err := apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, d.store, model.ManageDevicesPermission); err != nil {
+			return nil, err
+		}
+	}
+
 	update := false
 	if req.Imei != nil {
 		imeiV, err := strconv.ParseInt(req.Imei.Value, 10, 63)
@@ -210,6 +239,9 @@ func (d *deviceService) UpdateDevice(ctx context.Context, req *apipb.UpdateDevic
 			return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
 		}
 		if newCollID != coll.ID {
+			if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, newCollID, d.store, model.ManageDevicesPermission); err != nil {
+				return nil, err
+			}
 			// update collection ID
 			device.CollectionID = newCollID
 			// reset firmware state if the collection changes
@@ -243,12 +275,20 @@ func (d *deviceService) UpdateDevice(ctx context.Context, req *apipb.UpdateDevic
 			update = true
 			checkFW = true
 		}
+		if req.Firmware.MaintenanceWindow != nil {
+			device.Firmware.MaintenanceWindow, err = apitoolbox.UpdateMaintenanceWindow(device.Firmware.MaintenanceWindow, req.Firmware.MaintenanceWindow)
+			if err != nil {
+				return nil, err
+			}
+			update = true
+		}
 		if checkFW {
 			device.Firmware.State = model.Pending
 			if device.Firmware.TargetFirmwareID == device.Firmware.CurrentFirmwareID || device.Firmware.TargetFirmwareID == 0 {
 				device.Firmware.State = model.Current
 			}
-			if _, _, err := d.store.RetrieveCurrentAndTargetFirmware(device.CollectionID, device.Firmware.CurrentFirmwareID, device.Firmware.TargetFirmwareID); err != nil {
+			_, target, err := d.store.RetrieveCurrentAndTargetFirmware(device.CollectionID, device.Firmware.CurrentFirmwareID, device.Firmware.TargetFirmwareID)
+			if err != nil {
 				if err == storage.ErrNotFound {
 					return nil, status.Error(codes.NotFound, "Unknown firmware ID")
 				}
@@ -256,6 +296,20 @@ func (d *deviceService) UpdateDevice(ctx context.Context, req *apipb.UpdateDevic
 					device.Firmware.CurrentFirmwareID, device.Firmware.TargetFirmwareID, device.CollectionID, err)
 				return nil, status.Error(codes.Internal, "Unable to check firmware")
 			}
+			if req.Firmware.TargetFirmwareId != nil {
+				requireSignature := coll.Firmware.RequireSignature
+				if device.CollectionID != coll.ID {
+					newColl, err := d.store.RetrieveCollection(auth.User.ID, device.CollectionID)
+					if err != nil {
+						logging.Warning("Unable to read collection %d: %v", device.CollectionID, err)
+						return nil, status.Error(codes.Internal, "Unable to check firmware")
+					}
+					requireSignature = newColl.Firmware.RequireSignature
+				}
+				if err := ensureSignedTarget(requireSignature, target); err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 
@@ -271,6 +325,9 @@ func (d *deviceService) UpdateDevice(ctx context.Context, req *apipb.UpdateDevic
 			logging.Warning("Error updating device %d (collection ID=%d): %v", device.ID, coll.ID, err)
 			return nil, status.Error(codes.Internal, "Unable to update device")
 		}
+		for _, ev := range model.DeviceChangeEvents(before, device) {
+			d.events.PublishEvent(ev)
+		}
 	}
 	// update the device
 	return apitoolbox.NewDeviceFromModel(device, coll), nil
@@ -293,6 +350,9 @@ func (d *deviceService) DeleteDevice(ctx context.Context, req *apipb.DeviceReque
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsurePermission(auth.User.ID, coll.TeamID, d.store, model.ManageDevicesPermission); err != nil {
+		return nil, err
+	}
 	if err := d.store.DeleteDevice(auth.User.ID, coll.ID, device.ID); err != nil {
 		if err == storage.ErrAccess {
 			return nil, status.Error(codes.PermissionDenied, "Must be administrator to delete device")
@@ -300,6 +360,7 @@ func (d *deviceService) DeleteDevice(ctx context.Context, req *apipb.DeviceReque
 		logging.Warning("Unable to remove device %d (collection ID = %d): %v", device.ID, coll.ID, err)
 		return nil, status.Error(codes.Internal, "Unable to remove device")
 	}
+	d.events.PublishEvent(model.NewDeviceEvent(model.DeviceDeleted, device))
 	return apitoolbox.NewDeviceFromModel(device, coll), nil
 }
 
@@ -316,11 +377,18 @@ func (d *deviceService) ClearFirmwareError(ctx context.Context, req *apipb.Devic
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, device.CollectionID, d.store, model.ManageFirmwarePermission); err != nil {
+		return nil, err
+	}
+	previousState := device.Firmware.State
 	device.Firmware.State = model.Pending
 	device.Firmware.StateMessage = ""
 	if err := d.store.UpdateDevice(auth.User.ID, device.CollectionID, device); err != nil {
 		return nil, status.Error(codes.Internal, "Unable to update state on device")
 	}
+	if previousState != device.Firmware.State {
+		d.events.PublishEvent(model.NewDeviceEvent(model.DeviceFirmwareChanged, device))
+	}
 	return &apipb.ClearFirmwareErrorResponse{}, nil
 }
 
@@ -336,13 +404,21 @@ func (d *deviceService) ListDevices(ctx context.Context, req *apipb.ListDevicesR
 	if err != nil {
 		return nil, err
 	}
-	devices, err := d.store.ListDevices(auth.User.ID, collection.ID)
+	opts, err := apitoolbox.NewListOptions(req.PageSize, req.PageToken, req.SortBy, req.TagFilter)
 	if err != nil {
+		return nil, err
+	}
+	devices, next, err := d.store.ListDevicesPage(auth.User.ID, collection.ID, opts)
+	if err != nil {
+		if err == storage.ErrInvalidListOptions {
+			return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+		}
 		logging.Warning("Unable to read device list for collection %d: %v", collection.ID, err)
 		return nil, status.Error(codes.Internal, "Unable to read device list")
 	}
 	ret := &apipb.ListDevicesResponse{
-		Devices: make([]*apipb.Device, 0),
+		Devices:       make([]*apipb.Device, 0),
+		NextPageToken: next,
 	}
 	for _, v := range devices {
 		ret.Devices = append(ret.Devices, apitoolbox.NewDeviceFromModel(v, collection))
@@ -367,6 +443,9 @@ func (d *deviceService) ListDeviceMessages(ctx context.Context, req *apipb.ListM
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsurePermission(auth.User.ID, collection.TeamID, d.store, model.ReadDataPermission); err != nil {
+		return nil, err
+	}
 
 	dataFilter := &datastore.DataFilter{
 		CollectionId: req.CollectionId.Value,
@@ -411,12 +490,18 @@ func (d *deviceService) SendMessage(ctx context.Context, req *apipb.SendMessageR
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, device.CollectionID, d.store, model.SendMessagePermission); err != nil {
+		return nil, err
+	}
 
 	msg, err := apitoolbox.NewDownstreamMessage(req)
 	if err != nil {
 		return nil, err
 	}
 	if err := d.sender.Send(device, msg); err != nil {
+		if _, ok := err.(*model.QuotaExceededError); ok {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
 		// There are multiple alternatives here. We're returning 409 conflict
 		// which *technically* isn't correct but the device is in a state that
 		// we have no control over so it's the closes. Another alternative
@@ -459,9 +544,18 @@ func (d *deviceService) loadDevice(auth *authResult, collID, identifier string)
 	return device, nil
 }
 
+func (d *deviceService) EnsureTagUpdate(auth *authResult, res taggedResource) error {
+	dev := res.(*model.Device)
+	return apitoolbox.EnsureCollectionPermission(auth.User.ID, dev.CollectionID, d.store, model.ManageDevicesPermission)
+}
+
 func (d *deviceService) UpdateResourceTags(id model.UserKey, collectionID, identifier string, res interface{}) error {
 	dev := res.(*model.Device)
-	return d.store.UpdateDeviceTags(id, identifier, dev.Tags)
+	if err := d.store.UpdateDeviceTags(id, identifier, dev.Tags); err != nil {
+		return err
+	}
+	d.events.PublishEvent(model.NewDeviceEvent(model.DeviceRetagged, *dev))
+	return nil
 }
 
 func (d *deviceService) ListDeviceTags(ctx context.Context, req *apipb.TagRequest) (*apipb.TagResponse, error) {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
 	"github.com/eesrc/horde/pkg/storage"
 	"github.com/eesrc/horde/pkg/storage/sqlstore"
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -40,6 +41,7 @@ type deviceTestSetup struct {
 	collection    model.Collection
 	device        model.Device
 	sender        *dummySender
+	events        output.Manager
 }
 
 func newDeviceTest(t *testing.T) deviceTestSetup {
@@ -48,7 +50,8 @@ func newDeviceTest(t *testing.T) deviceTestSetup {
 
 	ret.store = sqlstore.NewMemoryStore()
 	ret.sender = newDummyMessageSender()
-	ret.deviceService = newDeviceService(ret.store, newDummyDataStoreClient(), ret.sender)
+	ret.events = output.NewDummyManager()
+	ret.deviceService = newDeviceService(ret.store, newDummyDataStoreClient(), ret.sender, ret.events)
 	ret.assert.NotNil(ret.deviceService)
 
 	ret.user, _, ret.ctx = createAuthenticatedContext(ret.assert, ret.store)
@@ -206,6 +209,63 @@ func TestListDevices(t *testing.T) {
 
 }
 
+func TestListDevicesPaging(t *testing.T) {
+	dt := newDeviceTest(t)
+
+	for i := 0; i < 4; i++ {
+		d := model.NewDevice()
+		d.ID = dt.store.NewDeviceID()
+		d.IMSI = int64(4700 - i)
+		d.IMEI = int64(4700 - i)
+		d.CollectionID = dt.collection.ID
+		d.SetTag("site", "oslo")
+		if i%2 == 0 {
+			d.SetTag("type", "gateway")
+		}
+		dt.assert.NoError(dt.store.CreateDevice(dt.user.ID, d))
+	}
+
+	var imsis []string
+	r := &apipb.ListDevicesRequest{
+		CollectionId: &wrappers.StringValue{Value: dt.collection.ID.String()},
+		PageSize:     &wrappers.Int32Value{Value: 2},
+		SortBy:       &wrappers.StringValue{Value: "imsi"},
+	}
+	for {
+		res, err := dt.deviceService.ListDevices(dt.ctx, r)
+		dt.assert.NoError(err)
+		dt.assert.True(len(res.Devices) <= 2)
+		for _, v := range res.Devices {
+			imsis = append(imsis, v.Imsi.Value)
+		}
+		if res.NextPageToken == "" {
+			break
+		}
+		r.PageToken = &wrappers.StringValue{Value: res.NextPageToken}
+	}
+	dt.assert.Equal([]string{"4697", "4698", "4699", "4700", "4711"}, imsis)
+
+	res, err := dt.deviceService.ListDevices(dt.ctx, &apipb.ListDevicesRequest{
+		CollectionId: &wrappers.StringValue{Value: dt.collection.ID.String()},
+		TagFilter:    &wrappers.StringValue{Value: "site=oslo AND type!=gateway"},
+	})
+	dt.assert.NoError(err)
+	dt.assert.Len(res.Devices, 2)
+	dt.assert.Empty(res.NextPageToken)
+
+	for _, req := range []*apipb.ListDevicesRequest{
+		{PageSize: &wrappers.Int32Value{Value: -1}},
+		{PageToken: &wrappers.StringValue{Value: "not a token"}},
+		{SortBy: &wrappers.StringValue{Value: "name"}},
+		{TagFilter: &wrappers.StringValue{Value: "site"}},
+	} {
+		req.CollectionId = &wrappers.StringValue{Value: dt.collection.ID.String()}
+		_, err := dt.deviceService.ListDevices(dt.ctx, req)
+		dt.assert.Error(err)
+		dt.assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+	}
+}
+
 type deviceRequestFactory struct {
 }
 
@@ -352,6 +412,7 @@ func TestCreateDevice(t *testing.T) {
 	// 2nd collection => duplicate IMSI/IMEI => error
 	u2, _, ctx2 := createAuthenticatedContext(dt.assert, dt.store)
 	t1 := model.NewTeam()
+	t1.ID = dt.store.NewTeamID()
 	t1.AddMember(model.NewMember(dt.user, model.MemberRole))
 	t1.AddMember(model.NewMember(u2, model.AdminRole))
 	dt.assert.NoError(dt.store.CreateTeam(t1))
@@ -394,7 +455,7 @@ func TestDeviceUpdate(t *testing.T) {
 	assert := require.New(t)
 	store := sqlstore.NewMemoryStore()
 	sender := newDummyMessageSender()
-	deviceService := newDeviceService(store, newDummyDataStoreClient(), sender)
+	deviceService := newDeviceService(store, newDummyDataStoreClient(), sender, output.NewDummyManager())
 	assert.NotNil(deviceService)
 
 	user, _, ctx := createAuthenticatedContext(assert, store)
@@ -465,6 +526,32 @@ func TestDeviceUpdate(t *testing.T) {
 	assert.Error(err)
 	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
 
+	// ...but the maintenance window can be set
+	r.Firmware = &apipb.FirmwareMetadata{
+		MaintenanceWindow: &apipb.MaintenanceWindow{
+			Schedule:        &wrappers.StringValue{Value: "0 2 * * *"},
+			DurationMinutes: &wrappers.Int32Value{Value: 90},
+			TimeZone:        &wrappers.StringValue{Value: model.DeviceTimeZone},
+		},
+	}
+	res, err := deviceService.UpdateDevice(ctx, r)
+	assert.NoError(err)
+	assert.Equal("0 2 * * *", res.Firmware.MaintenanceWindow.Schedule.Value)
+	assert.Equal(int32(90), res.Firmware.MaintenanceWindow.DurationMinutes.Value)
+	assert.Equal(model.DeviceTimeZone, res.Firmware.MaintenanceWindow.TimeZone.Value)
+
+	// Invalid windows are rejected
+	r.Firmware.MaintenanceWindow = &apipb.MaintenanceWindow{Schedule: &wrappers.StringValue{Value: "0 25 * * *"}}
+	_, err = deviceService.UpdateDevice(ctx, r)
+	assert.Error(err)
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+
+	// An empty schedule removes the window
+	r.Firmware.MaintenanceWindow = &apipb.MaintenanceWindow{Schedule: &wrappers.StringValue{Value: ""}}
+	res, err = deviceService.UpdateDevice(ctx, r)
+	assert.NoError(err)
+	assert.Nil(res.Firmware.MaintenanceWindow)
+
 	// Invalid IMSI, IMEI => error
 	r.Firmware = nil
 	r.Imsi = &wrappers.StringValue{Value: fmt.Sprintf("%d", d.IMSI)}
@@ -507,6 +594,7 @@ func TestDeviceUpdate(t *testing.T) {
 
 	// Update collection to a new one
 	t1 := model.NewTeam()
+	t1.ID = store.NewTeamID()
 	t1.AddMember(model.NewMember(user, model.AdminRole))
 	assert.NoError(store.CreateTeam(t1))
 
@@ -572,7 +660,7 @@ func TestDeviceUpdate(t *testing.T) {
 		CurrentFirmwareId: &wrappers.StringValue{Value: fw1.ID.String()},
 		TargetFirmwareId:  &wrappers.StringValue{Value: fw2.ID.String()},
 	}
-	res, err := deviceService.UpdateDevice(ctx, r)
+	res, err = deviceService.UpdateDevice(ctx, r)
 	assert.NoError(err)
 	assert.Equal("Pending", res.Firmware.State.Value)
 
@@ -619,7 +707,7 @@ func TestClearFirmwareError(t *testing.T) {
 	assert := require.New(t)
 	store := sqlstore.NewMemoryStore()
 	sender := newDummyMessageSender()
-	deviceService := newDeviceService(store, newDummyDataStoreClient(), sender)
+	deviceService := newDeviceService(store, newDummyDataStoreClient(), sender, output.NewDummyManager())
 	assert.NotNil(deviceService)
 
 	user, _, ctx := createAuthenticatedContext(assert, store)
@@ -684,3 +772,70 @@ func TestClearFirmwareError(t *testing.T) {
 	assert.Error(err)
 	assert.Equal(codes.NotFound.String(), status.Code(err).String())
 }
+
+func TestDeviceEvents(t *testing.T) {
+	dt := newDeviceTest(t)
+	ch := dt.events.Subscribe(dt.collection.ID)
+	defer dt.events.Unsubscribe(ch)
+
+	nextEvent := func() model.ResourceEvent {
+		select {
+		case msg := <-ch:
+			ev, ok := msg.(model.ResourceEvent)
+			dt.assert.True(ok)
+			return ev
+		case <-time.After(time.Second):
+			dt.assert.Fail("No event received")
+		}
+		return model.ResourceEvent{}
+	}
+
+	collectionID := &wrappers.StringValue{Value: dt.collection.ID.String()}
+	created, err := dt.deviceService.CreateDevice(dt.ctx, &apipb.Device{
+		CollectionId: collectionID,
+		Imsi:         &wrappers.StringValue{Value: "1"},
+		Imei:         &wrappers.StringValue{Value: "2"},
+	})
+	dt.assert.NoError(err)
+	ev := nextEvent()
+	dt.assert.Equal(model.DeviceCreated, ev.Type)
+	dt.assert.Equal(created.DeviceId.Value, ev.Device.ID.String())
+
+	_, err = dt.deviceService.UpdateDevice(dt.ctx, &apipb.UpdateDeviceRequest{
+		ExistingCollectionId: collectionID,
+		DeviceId:             created.DeviceId,
+		Tags:                 map[string]string{"name": "value"},
+	})
+	dt.assert.NoError(err)
+	dt.assert.Equal(model.DeviceRetagged, nextEvent().Type)
+
+	_, err = dt.deviceService.ClearFirmwareError(dt.ctx, &apipb.DeviceRequest{
+		CollectionId: collectionID,
+		DeviceId:     created.DeviceId,
+	})
+	dt.assert.NoError(err)
+	dt.assert.Equal(model.DeviceFirmwareChanged, nextEvent().Type)
+
+	// Moved devices are published on the previous collection
+	coll := model.NewCollection()
+	coll.ID = dt.store.NewCollectionID()
+	coll.TeamID = dt.user.PrivateTeamID
+	dt.assert.NoError(dt.store.CreateCollection(dt.user.ID, coll))
+	_, err = dt.deviceService.UpdateDevice(dt.ctx, &apipb.UpdateDeviceRequest{
+		ExistingCollectionId: collectionID,
+		DeviceId:             created.DeviceId,
+		CollectionId:         &wrappers.StringValue{Value: coll.ID.String()},
+	})
+	dt.assert.NoError(err)
+	ev = nextEvent()
+	dt.assert.Equal(model.DeviceMoved, ev.Type)
+	dt.assert.Equal(coll.ID, ev.CollectionID)
+	dt.assert.Equal(dt.collection.ID, ev.PreviousCollectionID)
+
+	_, err = dt.deviceService.DeleteDevice(dt.ctx, &apipb.DeviceRequest{
+		CollectionId: collectionID,
+		DeviceId:     &wrappers.StringValue{Value: dt.device.ID.String()},
+	})
+	dt.assert.NoError(err)
+	dt.assert.Equal(model.DeviceDeleted, nextEvent().Type)
+}
@@ -18,6 +18,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"strings"
 	"time"
 
@@ -94,11 +95,30 @@ func (fs *firmwareService) CreateFirmware(ctx context.Context, req *apipb.Create
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, collectionID, fs.store, model.ManageFirmwarePermission); err != nil {
+		return nil, err
+	}
+	imageLength := int64(len(req.Image))
+	if err := apitoolbox.EnsureCollectionQuota(auth.User.ID, collectionID, fs.store, func(q model.Quota, u model.QuotaUsage) error {
+		return q.CheckFirmware(u, imageLength)
+	}); err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(req.Image)
+	signature, signingKeyID, err := fs.signImage(auth, collectionID, req, digest[:])
+	if err != nil {
+		return nil, err
+	}
 
 	// Set versions and validate tags before we do a roundtrip to the
 	// store.
 	firmware := model.NewFirmware()
+	firmware.Signature = signature
+	firmware.SigningKeyID = signingKeyID
 	firmware.Filename = req.Filename.Value
+	if err := updateCompatibility(&firmware, req.Compatibility); err != nil {
+		return nil, err
+	}
 	for k, v := range req.Tags {
 		if !firmware.IsValidTag(k, v) {
 			return nil, status.Error(codes.InvalidArgument, "Invalid tag name/value")
@@ -167,13 +187,19 @@ func (fs *firmwareService) UpdateFirmware(ctx context.Context, req *apipb.Firmwa
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, firmware.CollectionID, fs.store, model.ManageFirmwarePermission); err != nil {
+		return nil, err
+	}
 	// Return error if one of the read-only fields are modified
-	if req.Filename != nil || req.Sha256 != nil || req.Length != nil {
-		return nil, status.Error(codes.InvalidArgument, "Only version and tags can be modified for firmware images")
+	if req.Filename != nil || req.Sha256 != nil || req.Length != nil || len(req.Signature) > 0 || req.SigningKeyId != nil {
+		return nil, status.Error(codes.InvalidArgument, "Only version, tags and compatibility rules can be modified for firmware images")
 	}
-	if req.Version == nil && req.Tags == nil {
+	if req.Version == nil && req.Tags == nil && req.Compatibility == nil {
 		return nil, status.Error(codes.InvalidArgument, "Nothing to update")
 	}
+	if err := updateCompatibility(&firmware, req.Compatibility); err != nil {
+		return nil, err
+	}
 	if req.Version != nil {
 		firmware.Version = strings.TrimSpace(req.Version.Value)
 		if len(firmware.Version) == 0 {
@@ -210,6 +236,9 @@ func (fs *firmwareService) DeleteFirmware(ctx context.Context, req *apipb.Firmwa
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, firmware.CollectionID, fs.store, model.ManageFirmwarePermission); err != nil {
+		return nil, err
+	}
 	if err := fs.store.DeleteFirmware(auth.User.ID, firmware.CollectionID, firmware.ID); err != nil {
 		if err == storage.ErrAccess {
 			return nil, status.Error(codes.PermissionDenied, "Must be an administrator to remove firmware")
@@ -244,14 +273,25 @@ func (fs *firmwareService) ListFirmware(ctx context.Context, req *apipb.ListFirm
 	if err != nil {
 		return nil, err
 	}
-	list, err := fs.store.ListFirmware(auth.User.ID, collectionID)
+	opts, err := apitoolbox.NewListOptions(req.PageSize, req.PageToken, nil, req.TagFilter)
 	if err != nil {
+		return nil, err
+	}
+	list, next, err := fs.store.ListFirmwarePage(auth.User.ID, collectionID, opts)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Unknown collection")
+		}
+		if err == storage.ErrInvalidListOptions {
+			return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+		}
 		logging.Warning("Unable to list firmware for collection %d: %v", collectionID, err)
 		return nil, status.Error(codes.Internal, "Unable to read collection list")
 	}
 
 	ret := &apipb.ListFirmwareResponse{
-		Images: make([]*apipb.Firmware, 0),
+		Images:        make([]*apipb.Firmware, 0),
+		NextPageToken: next,
 	}
 	for _, v := range list {
 		ret.Images = append(ret.Images, apitoolbox.NewFirmwareFromModel(v))
@@ -290,6 +330,167 @@ func (fs *firmwareService) FirmwareUsage(ctx context.Context, req *apipb.Firmwar
 	for _, v := range use.Current {
 		ret.Current = append(ret.Current, v.String())
 	}
+	stats, err := fs.store.RetrieveFirmwareStatistics(auth.User.ID, fw.CollectionID, fw.ID)
+	if err != nil {
+		logging.Warning("Unable to retrieve firmware statistics (firmwareID=%d collection ID=%d): %v", fw.ID, fw.CollectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to query firmware statistics")
+	}
+	ret.Statistics = apitoolbox.NewFirmwareStatisticsFromModel(stats)
+	return ret, nil
+}
+
+// historyPage returns the limit and the before key for the firmware history
+// requests. The limits are the same as for the audit trail.
+func historyPage(limitValue *wrappers.Int32Value, beforeValue *wrappers.StringValue) (int, model.FirmwareHistoryKey, error) {
+	limit := defaultAuditLimit
+	if limitValue != nil {
+		limit = int(limitValue.Value)
+		if limit <= 0 || limit > maxAuditLimit {
+			return 0, 0, status.Errorf(codes.InvalidArgument, "Limit must be between 1 and %d", maxAuditLimit)
+		}
+	}
+	var before model.FirmwareHistoryKey
+	if beforeValue != nil {
+		var err error
+		if before, err = model.NewFirmwareHistoryKeyFromString(beforeValue.Value); err != nil {
+			return 0, 0, status.Error(codes.InvalidArgument, "Invalid entry ID")
+		}
+	}
+	return limit, before, nil
+}
+
+// newHistoryResponse converts the history entries into the response type
+func newHistoryResponse(entries []model.FirmwareHistoryEntry, limit int) *apipb.ListFirmwareHistoryResponse {
+	ret := &apipb.ListFirmwareHistoryResponse{
+		Entries: make([]*apipb.FirmwareHistoryEntry, 0),
+	}
+	for _, v := range entries {
+		ret.Entries = append(ret.Entries, apitoolbox.NewFirmwareHistoryEntryFromModel(v))
+	}
+	if len(entries) == limit {
+		ret.Next = &wrappers.StringValue{Value: entries[len(entries)-1].ID.String()}
+	}
+	return ret
+}
+
+func (fs *firmwareService) ListDeviceFirmwareHistory(ctx context.Context, req *apipb.ListDeviceFirmwareHistoryRequest) (*apipb.ListFirmwareHistoryResponse, error) {
+	if req == nil || req.CollectionId == nil || req.DeviceId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing collection/device ID")
+	}
+	auth, err := fs.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collectionID, err := model.NewCollectionKeyFromString(req.CollectionId.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
+	}
+	deviceID, err := model.NewDeviceKeyFromString(req.DeviceId.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid device ID")
+	}
+	limit, before, err := historyPage(req.Limit, req.Before)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fs.store.RetrieveDevice(auth.User.ID, collectionID, deviceID); err != nil {
+		if err == storage.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Unknown device")
+		}
+		logging.Warning("Unable to retrieve device %d (collection ID = %d): %v", deviceID, collectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to retrieve device")
+	}
+	entries, err := fs.store.ListDeviceFirmwareHistory(auth.User.ID, collectionID, deviceID, before, limit)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Unknown entry")
+		}
+		logging.Warning("Error listing firmware history for device %d (collection ID = %d): %v", deviceID, collectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to list firmware history")
+	}
+	return newHistoryResponse(entries, limit), nil
+}
+
+func (fs *firmwareService) ListFirmwareHistory(ctx context.Context, req *apipb.ListFirmwareHistoryRequest) (*apipb.ListFirmwareHistoryResponse, error) {
+	if req == nil || req.CollectionId == nil || req.ImageId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing collection/firmware ID")
+	}
+	auth, err := fs.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit, before, err := historyPage(req.Limit, req.Before)
+	if err != nil {
+		return nil, err
+	}
+	fw, err := fs.loadFirmware(auth, req.CollectionId.Value, req.ImageId.Value)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fs.store.ListFirmwareHistory(auth.User.ID, fw.CollectionID, fw.ID, before, limit)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Unknown entry")
+		}
+		logging.Warning("Error listing firmware history (firmwareID=%d collection ID=%d): %v", fw.ID, fw.CollectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to list firmware history")
+	}
+	return newHistoryResponse(entries, limit), nil
+}
+
+// updateCompatibility sets the compatibility rules for the image. Fields that
+// aren't set in the request are left as is.
+func updateCompatibility(firmware *model.Firmware, compat *apipb.FirmwareCompatibility) error {
+	if compat == nil {
+		return nil
+	}
+	if compat.Manufacturer != nil {
+		firmware.Compatibility.Manufacturer = strings.TrimSpace(compat.Manufacturer.Value)
+	}
+	if compat.ModelPattern != nil {
+		firmware.Compatibility.ModelPattern = strings.TrimSpace(compat.ModelPattern.Value)
+	}
+	if compat.MinimumVersion != nil {
+		firmware.Compatibility.MinimumVersion = strings.TrimSpace(compat.MinimumVersion.Value)
+	}
+	if err := firmware.Compatibility.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, "Invalid model number pattern")
+	}
+	return nil
+}
+
+func (fs *firmwareService) CheckFirmwareCompatibility(ctx context.Context, req *apipb.FirmwareRequest) (*apipb.FirmwareCompatibilityResponse, error) {
+	if req == nil || req.CollectionId == nil || req.ImageId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing collection/firmware ID")
+	}
+	auth, err := fs.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fw, err := fs.loadFirmware(auth, req.CollectionId.Value, req.ImageId.Value)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := fs.store.ListDevices(auth.User.ID, fw.CollectionID)
+	if err != nil {
+		logging.Warning("Unable to list devices for firmware compatibility (firmwareID=%d collection ID=%d): %v", fw.ID, fw.CollectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to list devices")
+	}
+	ret := &apipb.FirmwareCompatibilityResponse{
+		ImageId:      &wrappers.StringValue{Value: fw.ID.String()},
+		Compatible:   make([]string, 0),
+		Incompatible: make([]*apipb.FirmwareCompatibilityResponse_IncompatibleDevice, 0),
+	}
+	for _, d := range devices {
+		if err := fw.Compatibility.Check(d.Firmware); err != nil {
+			ret.Incompatible = append(ret.Incompatible, &apipb.FirmwareCompatibilityResponse_IncompatibleDevice{
+				DeviceId: d.ID.String(),
+				Reason:   err.Error(),
+			})
+			continue
+		}
+		ret.Compatible = append(ret.Compatible, d.ID.String())
+	}
 	return ret, nil
 }
 
@@ -302,6 +503,11 @@ func (fs *firmwareService) LoadTaggedResource(auth *authResult, collectionID str
 	return &fw, err
 }
 
+func (fs *firmwareService) EnsureTagUpdate(auth *authResult, res taggedResource) error {
+	fw := res.(*model.Firmware)
+	return apitoolbox.EnsureCollectionPermission(auth.User.ID, fw.CollectionID, fs.store, model.ManageFirmwarePermission)
+}
+
 func (fs *firmwareService) UpdateResourceTags(id model.UserKey, collectionID, identifier string, res interface{}) error {
 	fw := res.(*model.Firmware)
 	return fs.store.UpdateFirmwareTags(id, identifier, fw.Tags)
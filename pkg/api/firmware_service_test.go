@@ -109,6 +109,21 @@ func (frf *firmwareRequestFactory) SetIdentifier(req interface{}, oid *wrappers.
 	req.(*apipb.FirmwareRequest).ImageId = oid
 }
 
+type firmwareHistoryRequestFactory struct {
+}
+
+func (f *firmwareHistoryRequestFactory) ValidRequest() interface{} {
+	return &apipb.ListFirmwareHistoryRequest{}
+}
+
+func (f *firmwareHistoryRequestFactory) SetCollection(req interface{}, cid *wrappers.StringValue) {
+	req.(*apipb.ListFirmwareHistoryRequest).CollectionId = cid
+}
+
+func (f *firmwareHistoryRequestFactory) SetIdentifier(req interface{}, oid *wrappers.StringValue) {
+	req.(*apipb.ListFirmwareHistoryRequest).ImageId = oid
+}
+
 func TestRetrieveFirmware(t *testing.T) {
 	ft := newFirmwareTest(t)
 
@@ -176,6 +191,197 @@ func TestFirmwareUsage(t *testing.T) {
 	ft.assert.Len(res.Targeted, 1)
 }
 
+func TestFirmwareCompatibility(t *testing.T) {
+	ft := newFirmwareTest(t)
+
+	d1 := model.NewDevice()
+	d1.ID = ft.store.NewDeviceID()
+	d1.IMSI = 4711
+	d1.IMEI = 4711
+	d1.Firmware.ModelNumber = "EE01"
+	d1.CollectionID = ft.collection.ID
+	ft.assert.NoError(ft.store.CreateDevice(ft.user.ID, d1))
+
+	d2 := model.NewDevice()
+	d2.ID = ft.store.NewDeviceID()
+	d2.IMSI = 4712
+	d2.IMEI = 4712
+	d2.Firmware.ModelNumber = "EE02"
+	d2.CollectionID = ft.collection.ID
+	ft.assert.NoError(ft.store.CreateDevice(ft.user.ID, d2))
+
+	genericRequestTests(
+		tparam{
+			AuthenticatedContext:      ft.ctx,
+			Assert:                    ft.assert,
+			CollectionID:              ft.collection.ID.String(),
+			TestWithInvalidIdentifier: true,
+			IdentifierID:              ft.firmware.ID.String(),
+			RequestFactory:            &firmwareRequestFactory{},
+			RequestFunc: func(ctx context.Context, req interface{}) (interface{}, error) {
+				if req == nil {
+					return ft.firmwareService.CheckFirmwareCompatibility(ctx, nil)
+				}
+				return ft.firmwareService.CheckFirmwareCompatibility(ctx, req.(*apipb.FirmwareRequest))
+			}})
+
+	collectionID := &wrappers.StringValue{Value: ft.collection.ID.String()}
+	imageID := &wrappers.StringValue{Value: ft.firmware.ID.String()}
+	req := &apipb.FirmwareRequest{CollectionId: collectionID, ImageId: imageID}
+
+	// All devices are compatible when there are no rules
+	res, err := ft.firmwareService.CheckFirmwareCompatibility(ft.ctx, req)
+	ft.assert.NoError(err)
+	ft.assert.Len(res.Compatible, 2)
+	ft.assert.Len(res.Incompatible, 0)
+
+	_, err = ft.firmwareService.UpdateFirmware(ft.ctx, &apipb.Firmware{
+		CollectionId:  collectionID,
+		ImageId:       imageID,
+		Compatibility: &apipb.FirmwareCompatibility{ModelPattern: &wrappers.StringValue{Value: "EE0["}},
+	})
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	fw, err := ft.firmwareService.UpdateFirmware(ft.ctx, &apipb.Firmware{
+		CollectionId:  collectionID,
+		ImageId:       imageID,
+		Compatibility: &apipb.FirmwareCompatibility{ModelPattern: &wrappers.StringValue{Value: "EE02"}},
+	})
+	ft.assert.NoError(err)
+	ft.assert.Equal("EE02", fw.Compatibility.ModelPattern.Value)
+	ft.assert.Equal("", fw.Compatibility.Manufacturer.Value)
+
+	res, err = ft.firmwareService.CheckFirmwareCompatibility(ft.ctx, req)
+	ft.assert.NoError(err)
+	ft.assert.Equal([]string{d2.ID.String()}, res.Compatible)
+	ft.assert.Len(res.Incompatible, 1)
+	ft.assert.Equal(d1.ID.String(), res.Incompatible[0].DeviceId)
+	ft.assert.Contains(res.Incompatible[0].Reason, "EE01")
+
+	// Rules can be set when the image is created
+	created, err := ft.firmwareService.CreateFirmware(ft.ctx, &apipb.CreateFirmwareRequest{
+		CollectionId:  collectionID,
+		Image:         []byte("compatible image"),
+		Filename:      &wrappers.StringValue{Value: "compatible.bin"},
+		Version:       &wrappers.StringValue{Value: "2.0"},
+		Compatibility: &apipb.FirmwareCompatibility{MinimumVersion: &wrappers.StringValue{Value: "1.5"}},
+	})
+	ft.assert.NoError(err)
+	ft.assert.Equal("1.5", created.Compatibility.MinimumVersion.Value)
+}
+
+func TestFirmwareHistory(t *testing.T) {
+	ft := newFirmwareTest(t)
+
+	d1 := model.NewDevice()
+	d1.ID = ft.store.NewDeviceID()
+	d1.IMSI = 4711
+	d1.IMEI = 4711
+	d1.CollectionID = ft.collection.ID
+	ft.assert.NoError(ft.store.CreateDevice(ft.user.ID, d1))
+
+	start := time.Now().Add(-time.Hour)
+	states := []model.DeviceFirmwareState{model.Pending, model.Downloading, model.Completed, model.Current}
+	previous := model.Current
+	for i, state := range states {
+		ft.assert.NoError(ft.store.CreateFirmwareHistory(model.FirmwareHistoryEntry{
+			ID:            ft.store.NewFirmwareHistoryID(),
+			Time:          start.Add(time.Duration(i) * time.Minute),
+			CollectionID:  ft.collection.ID,
+			DeviceID:      d1.ID,
+			ToFirmwareID:  ft.firmware.ID,
+			PreviousState: previous,
+			State:         state,
+			Duration:      time.Minute,
+		}))
+		previous = state
+	}
+
+	collectionID := &wrappers.StringValue{Value: ft.collection.ID.String()}
+	deviceID := &wrappers.StringValue{Value: d1.ID.String()}
+	imageID := &wrappers.StringValue{Value: ft.firmware.ID.String()}
+
+	_, err := ft.firmwareService.ListDeviceFirmwareHistory(ft.ctx, nil)
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = ft.firmwareService.ListDeviceFirmwareHistory(ft.ctx, &apipb.ListDeviceFirmwareHistoryRequest{CollectionId: collectionID})
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = ft.firmwareService.ListDeviceFirmwareHistory(ft.ctx, &apipb.ListDeviceFirmwareHistoryRequest{
+		CollectionId: collectionID,
+		DeviceId:     &wrappers.StringValue{Value: ft.store.NewDeviceID().String()},
+	})
+	ft.assert.Equal(codes.NotFound, status.Code(err))
+	_, err = ft.firmwareService.ListDeviceFirmwareHistory(context.Background(), &apipb.ListDeviceFirmwareHistoryRequest{CollectionId: collectionID, DeviceId: deviceID})
+	ft.assert.Equal(codes.Unauthenticated, status.Code(err))
+	_, err = ft.firmwareService.ListDeviceFirmwareHistory(ft.ctx, &apipb.ListDeviceFirmwareHistoryRequest{
+		CollectionId: collectionID,
+		DeviceId:     deviceID,
+		Limit:        &wrappers.Int32Value{Value: 0},
+	})
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Page through the device history
+	res, err := ft.firmwareService.ListDeviceFirmwareHistory(ft.ctx, &apipb.ListDeviceFirmwareHistoryRequest{
+		CollectionId: collectionID,
+		DeviceId:     deviceID,
+		Limit:        &wrappers.Int32Value{Value: 3},
+	})
+	ft.assert.NoError(err)
+	ft.assert.Len(res.Entries, 3)
+	ft.assert.NotNil(res.Next)
+	ft.assert.Equal("Current", res.Entries[0].State.Value)
+	ft.assert.Equal("Completed", res.Entries[0].PreviousState.Value)
+	ft.assert.Equal(ft.firmware.ID.String(), res.Entries[0].ToImageId.Value)
+	ft.assert.Equal(int64(60000), res.Entries[0].Duration.Value)
+
+	res, err = ft.firmwareService.ListDeviceFirmwareHistory(ft.ctx, &apipb.ListDeviceFirmwareHistoryRequest{
+		CollectionId: collectionID,
+		DeviceId:     deviceID,
+		Limit:        &wrappers.Int32Value{Value: 3},
+		Before:       res.Next,
+	})
+	ft.assert.NoError(err)
+	ft.assert.Len(res.Entries, 1)
+	ft.assert.Nil(res.Next)
+	ft.assert.Equal("Pending", res.Entries[0].State.Value)
+
+	_, err = ft.firmwareService.ListDeviceFirmwareHistory(ft.ctx, &apipb.ListDeviceFirmwareHistoryRequest{
+		CollectionId: collectionID,
+		DeviceId:     deviceID,
+		Before:       &wrappers.StringValue{Value: "-"},
+	})
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	// The image history includes the same entries
+	genericRequestTests(
+		tparam{
+			AuthenticatedContext:      ft.ctx,
+			Assert:                    ft.assert,
+			CollectionID:              ft.collection.ID.String(),
+			TestWithInvalidIdentifier: true,
+			IdentifierID:              ft.firmware.ID.String(),
+			RequestFactory:            &firmwareHistoryRequestFactory{},
+			RequestFunc: func(ctx context.Context, req interface{}) (interface{}, error) {
+				if req == nil {
+					return ft.firmwareService.ListFirmwareHistory(ctx, nil)
+				}
+				return ft.firmwareService.ListFirmwareHistory(ctx, req.(*apipb.ListFirmwareHistoryRequest))
+			}})
+
+	fwRes, err := ft.firmwareService.ListFirmwareHistory(ft.ctx, &apipb.ListFirmwareHistoryRequest{CollectionId: collectionID, ImageId: imageID})
+	ft.assert.NoError(err)
+	ft.assert.Len(fwRes.Entries, 4)
+	ft.assert.Nil(fwRes.Next)
+
+	// The statistics are included in the usage
+	usage, err := ft.firmwareService.FirmwareUsage(ft.ctx, &apipb.FirmwareRequest{CollectionId: collectionID, ImageId: imageID})
+	ft.assert.NoError(err)
+	ft.assert.NotNil(usage.Statistics)
+	ft.assert.Equal(int32(1), usage.Statistics.Downloads)
+	ft.assert.Equal(int32(1), usage.Statistics.Completed)
+	ft.assert.Equal(int32(1), usage.Statistics.Updated)
+	ft.assert.Equal(int32(0), usage.Statistics.Failed)
+}
+
 func TestDeleteFirmware(t *testing.T) {
 	ft := newFirmwareTest(t)
 
@@ -228,6 +434,7 @@ func TestDeleteFirmware(t *testing.T) {
 	// Attempt to remove firmware when not administrator => error
 	u2, _, _ := createAuthenticatedContext(ft.assert, ft.store)
 	t2 := model.NewTeam()
+	t2.ID = ft.store.NewTeamID()
 	t2.AddMember(model.NewMember(ft.user, model.MemberRole))
 	t2.AddMember(model.NewMember(u2, model.AdminRole))
 	ft.assert.NoError(ft.store.CreateTeam(t2))
@@ -350,6 +557,7 @@ func TestCreateFirmware(t *testing.T) {
 	// Not the owner => error
 	u2, _, _ := createAuthenticatedContext(ft.assert, ft.store)
 	t2 := model.NewTeam()
+	t2.ID = ft.store.NewTeamID()
 	t2.AddMember(model.NewMember(ft.user, model.MemberRole))
 	t2.AddMember(model.NewMember(u2, model.AdminRole))
 	ft.assert.NoError(ft.store.CreateTeam(t2))
@@ -481,6 +689,7 @@ func TestUpdateFirmware(t *testing.T) {
 	// Update firmware when not admin => error
 	u2, _, _ := createAuthenticatedContext(ft.assert, ft.store)
 	t2 := model.NewTeam()
+	t2.ID = ft.store.NewTeamID()
 	t2.AddMember(model.NewMember(ft.user, model.MemberRole))
 	t2.AddMember(model.NewMember(u2, model.AdminRole))
 	ft.assert.NoError(ft.store.CreateTeam(t2))
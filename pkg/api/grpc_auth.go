@@ -22,6 +22,7 @@ import (
 	"github.com/TelenorDigital/goconnect"
 	"github.com/eesrc/horde/pkg/ghlogin"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/oidclogin"
 	"github.com/eesrc/horde/pkg/storage"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -39,6 +40,9 @@ const UserKey = contextKey("user")
 // AuthKey is the context key for the authentication method in the context
 const AuthKey = contextKey("auth")
 
+// TokenKey is the context key for the API token ID in the context
+const TokenKey = contextKey("token")
+
 // TODO(stalehd): Move dependency. Remove context use.
 
 // The default gRPC auth implementation for tags
@@ -57,51 +61,62 @@ func (d *defaultGrpcAuth) EnsureAuth(ctx context.Context) (*authResult, error) {
 type authResult struct {
 	User           model.User
 	Method         model.AuthMethod
-	Token          string
+	TokenID        string
 	ConnectSession goconnect.Session
 	GitHubProfile  ghlogin.Profile
+	OIDCProfile    oidclogin.Profile
 }
 
 // gRPCAuth authenticates a request through the gRPC API.
 func gRPCAuth(ctx context.Context, store storage.DataStore) *authResult {
-	// Token might be part of the metadata if it is a request received via the
-	// gRPC gateway. If not this might be the context itself.
-	//
+	// The user is in the context if the request is received via the gRPC
+	// gateway and the REST API has authenticated the request. The REST API
+	// checks API tokens before the request is passed on to the gateway.
+	am := ctx.Value(AuthKey)
+	au := ctx.Value(UserKey)
+	if am != nil && au != nil {
+		user, uok := au.(*model.User)
+		meth, mok := am.(model.AuthMethod)
+		if uok && mok {
+			tokenID, _ := ctx.Value(TokenKey).(string)
+			return &authResult{
+				User:    *user,
+				Method:  meth,
+				TokenID: tokenID,
+			}
+		}
+	}
+
+	// Token might be part of the metadata if the request is received through
+	// the gRPC API. The token must grant access to the RPC call, ie the
+	// interceptors from GRPCServerOptions must be used.
 	md, ok := metadata.FromIncomingContext(ctx)
 	if ok {
 		t := md.Get(tokenHeaderName)
 		if len(t) == 1 {
 			// ok - there's a token. Find the corresponding user
-			token, err := store.RetrieveToken(t[0])
+			token, err := LookupToken(store, t[0])
 			if err != nil {
-				if err != storage.ErrNotFound {
-					logging.Warning("Error retrieving token %s: %v", t[0], err)
+				if err != storage.ErrNotFound && err != ErrTokenExpired {
+					logging.Warning("Error retrieving token %s: %v", model.TokenID(t[0]), err)
 				}
 				return nil
 			}
+			call, ok := rpcCallFromContext(ctx)
+			if !ok || !tokenAllowsCall(token, call.Method, call.Request) {
+				return nil
+			}
 			user, err := store.RetrieveUser(token.UserID)
 			if err != nil {
 				if err != storage.ErrNotFound {
-					logging.Warning("Error retrieving user for token %s (user id=%d): %v", token.Token, token.UserID, err)
+					logging.Warning("Error retrieving user for token %s (user id=%d): %v", token.ID, token.UserID, err)
 				}
 				return nil
 			}
 			return &authResult{
-				User:   user,
-				Method: model.AuthToken,
-				Token:  token.Token,
-			}
-		}
-	}
-	am := ctx.Value(AuthKey)
-	au := ctx.Value(UserKey)
-	if am != nil && au != nil {
-		user, uok := au.(*model.User)
-		meth, mok := am.(model.AuthMethod)
-		if uok && mok {
-			return &authResult{
-				User:   *user,
-				Method: meth,
+				User:    user,
+				Method:  model.AuthToken,
+				TokenID: token.ID,
 			}
 		}
 	}
@@ -149,6 +164,27 @@ func gRPCAuth(ctx context.Context, store storage.DataStore) *authResult {
 		}
 	}
 
+	sc = ctx.Value(oidclogin.OIDCSessionProfile)
+	if sc != nil {
+		profile, ok := sc.(*oidclogin.Profile)
+		if !ok {
+			// Not the type we expected - just return
+			return nil
+		}
+		user, err := store.RetrieveUserByExternalID(profile.Subject, model.AuthOIDC)
+		if err != nil {
+			if err != storage.ErrNotFound {
+				logging.Warning("Error retrieving user for OIDC subject %s: %v", profile.Subject, err)
+			}
+			return nil
+		}
+		return &authResult{
+			User:        *user,
+			Method:      model.AuthOIDC,
+			OIDCProfile: *profile,
+		}
+	}
+
 	// No authentication token or sessions found
 	return nil
 }
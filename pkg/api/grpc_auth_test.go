@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/TelenorDigital/goconnect"
+	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/ghlogin"
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage/sqlstore"
@@ -67,6 +68,9 @@ func TestGRPCAuth(t *testing.T) {
 	assert.NoError(store.CreateToken(token))
 
 	ctx = metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{tokenHeaderName: token.Token}))
+	// Tokens are only accepted when the RPC call is known
+	assert.Nil(gRPCAuth(ctx, store))
+	ctx = withRPCCall(ctx, "/apipb.Horde/ListCollections", &apipb.ListCollectionRequest{})
 	auth = gRPCAuth(ctx, store)
 	assert.NotNil(auth)
 	assert.Equal(user.ID, auth.User.ID)
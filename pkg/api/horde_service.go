@@ -33,19 +33,20 @@ type apiServer struct {
 	systemService
 }
 
-// NewHordeAPIService creates a new HordeAPIServer
+// NewHordeAPIService creates a new HordeAPIServer. Changes made through the
+// service are recorded in the audit trail.
 func NewHordeAPIService(store storage.DataStore,
 	fieldMask model.FieldMaskParameters,
 	outputManager output.Manager,
 	dataStoreClient datastore.DataStoreClient,
 	messageSender DownstreamMessageSender, firmwareImageStore storage.FirmwareImageStore) apipb.HordeServer {
-	return &apiServer{
+	return newAuditServer(&apiServer{
 		collectionService: newCollectionService(store, fieldMask, outputManager, dataStoreClient, messageSender),
-		deviceService:     newDeviceService(store, dataStoreClient, messageSender),
+		deviceService:     newDeviceService(store, dataStoreClient, messageSender, outputManager),
 		firmwareService:   newFirmwareService(store, firmwareImageStore),
 		tokenService:      newTokenService(store),
 		teamService:       newTeamService(store),
-		outputService:     newOutputService(store, outputManager, fieldMask),
-		systemService:     newSystemService(fieldMask, store, dataStoreClient),
-	}
+		outputService:     newOutputService(store, outputManager, fieldMask, dataStoreClient),
+		systemService:     newSystemService(fieldMask, store, dataStoreClient, outputManager),
+	}, store)
 }
@@ -17,11 +17,13 @@ package api
 //
 import (
 	"context"
+	"time"
 
 	"github.com/ExploratoryEngineering/logging"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/eesrc/horde/pkg/addons/magpie/datastore"
 	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/api/apitoolbox"
 	"github.com/eesrc/horde/pkg/model"
@@ -33,19 +35,22 @@ import (
 func newOutputService(
 	store storage.DataStore,
 	manager output.Manager,
-	fieldMask model.FieldMaskParameters) outputService {
+	fieldMask model.FieldMaskParameters,
+	dataStoreClient datastore.DataStoreClient) outputService {
 	return outputService{
 		store:           store,
 		manager:         manager,
 		fieldMask:       fieldMask,
+		dataStoreClient: dataStoreClient,
 		defaultGrpcAuth: defaultGrpcAuth{Store: store},
 	}
 }
 
 type outputService struct {
-	store     storage.DataStore
-	manager   output.Manager
-	fieldMask model.FieldMaskParameters
+	store           storage.DataStore
+	manager         output.Manager
+	fieldMask       model.FieldMaskParameters
+	dataStoreClient datastore.DataStoreClient
 
 	defaultGrpcAuth
 }
@@ -99,6 +104,9 @@ func (s *outputService) DeleteOutput(ctx context.Context, req *apipb.OutputReque
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, output.CollectionID, s.store, model.ManageOutputsPermission); err != nil {
+		return nil, err
+	}
 	if err := s.store.DeleteOutput(auth.User.ID, output.CollectionID, output.ID); err != nil {
 		if err == storage.ErrAccess {
 			return nil, status.Error(codes.PermissionDenied, "Must be administrator to remove output")
@@ -110,6 +118,7 @@ func (s *outputService) DeleteOutput(ctx context.Context, req *apipb.OutputReque
 	if err := s.manager.Stop(output.ID); err != nil {
 		logging.Warning("Unable to stop output %d: %v", output.ID, err)
 	}
+	s.manager.PublishEvent(model.NewOutputEvent(model.OutputDeleted, output))
 	return apitoolbox.NewOutputFromModel(output), nil
 }
 
@@ -130,6 +139,12 @@ func (s *outputService) CreateOutput(ctx context.Context, req *apipb.Output) (*a
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
 	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, collectionID, s.store, model.ManageOutputsPermission); err != nil {
+		return nil, err
+	}
+	if err := apitoolbox.EnsureCollectionQuota(auth.User.ID, collectionID, s.store, model.Quota.CheckOutputs); err != nil {
+		return nil, err
+	}
 
 	newOutput := model.NewOutput()
 	newOutput.Type = req.Type.String()
@@ -174,6 +189,7 @@ func (s *outputService) CreateOutput(ctx context.Context, req *apipb.Output) (*a
 			return nil, status.Error(codes.Internal, "Unable to start output")
 		}
 	}
+	s.manager.PublishEvent(model.NewOutputEvent(model.OutputCreated, newOutput))
 	return apitoolbox.NewOutputFromModel(newOutput), nil
 }
 
@@ -189,6 +205,9 @@ func (s *outputService) UpdateOutput(ctx context.Context, req *apipb.Output) (*a
 	if err != nil {
 		return nil, err
 	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, output.CollectionID, s.store, model.ManageOutputsPermission); err != nil {
+		return nil, err
+	}
 	update := false
 	if req.Enabled != nil {
 		if req.Enabled.Value != output.Enabled {
@@ -235,17 +254,13 @@ func (s *outputService) UpdateOutput(ctx context.Context, req *apipb.Output) (*a
 			logging.Warning("Unable to update output %d (collection ID = %d): %v", output.ID, output.CollectionID, err)
 			return nil, status.Error(codes.Internal, "Unable to update output")
 		}
-		// Restart output regardless of config change.
-		if err := s.manager.Stop(output.ID); err != nil {
-			// Won't report back to the client since the output might be stopped or invalid.
-			// Wait and see what happens when we update it.
-			logging.Info("Got error stopping output %d (collection ID=%d): %v", output.ID, output.CollectionID, err)
-		}
-		// The output manager will check if the output is enabled or not before starting so
-		// no need to check if we should start it.
+		// The output manager applies the new configuration to the running
+		// output and handles enabling and disabling the output so there's no
+		// need to stop it first.
 		if err := s.manager.Update(output, s.fieldMask.ForcedFields()); err != nil {
 			return nil, status.Error(codes.Internal, "Unable to update output")
 		}
+		s.manager.PublishEvent(model.NewOutputEvent(model.OutputUpdated, output))
 	}
 
 	return apitoolbox.NewOutputFromModel(output), nil
@@ -265,13 +280,21 @@ func (s *outputService) ListOutputs(ctx context.Context, req *apipb.ListOutputRe
 		return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
 	}
 
-	list, err := s.store.ListOutputs(auth.User.ID, collectionID)
+	opts, err := apitoolbox.NewListOptions(req.PageSize, req.PageToken, nil, req.TagFilter)
+	if err != nil {
+		return nil, err
+	}
+	list, next, err := s.store.ListOutputsPage(auth.User.ID, collectionID, opts)
 	if err != nil {
+		if err == storage.ErrInvalidListOptions {
+			return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+		}
 		logging.Warning("Unable to list outputs for user %d: %v", auth.User.ID, err)
 		return nil, status.Error(codes.Internal, "Unable to read list of outputs")
 	}
 	ret := &apipb.ListOutputResponse{
-		Outputs: make([]*apipb.Output, 0),
+		Outputs:       make([]*apipb.Output, 0),
+		NextPageToken: next,
 	}
 	for _, v := range list {
 		ret.Outputs = append(ret.Outputs, apitoolbox.NewOutputFromModel(v))
@@ -320,6 +343,144 @@ func (s *outputService) Status(ctx context.Context, req *apipb.OutputRequest) (*
 	return apitoolbox.NewOutputStatusFromModel(output.CollectionID.String(), output.ID.String(), output.Enabled, op.Status()), nil
 }
 
+// defaultTestPayload is the payload used for synthetic test messages
+const defaultTestPayload = "Test message from Horde"
+
+func (s *outputService) TestOutput(ctx context.Context, req *apipb.OutputTestRequest) (*apipb.OutputTestResponse, error) {
+	if req == nil || req.CollectionId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing request object")
+	}
+	if req.OutputId == nil && req.Output == nil {
+		return nil, status.Error(codes.InvalidArgument, "Must specify output ID or output configuration")
+	}
+	auth, err := s.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collectionID, err := model.NewCollectionKeyFromString(req.CollectionId.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
+	}
+	collection, err := s.store.RetrieveCollection(auth.User.ID, collectionID)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Unknown collection")
+		}
+		logging.Warning("Error retrieving collection %d: %v", collectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to retrieve collection")
+	}
+	if err := apitoolbox.EnsurePermission(auth.User.ID, collection.TeamID, s.store, model.ManageOutputsPermission); err != nil {
+		return nil, err
+	}
+
+	var testOutput model.Output
+	if req.OutputId != nil {
+		testOutput, err = s.loadOutput(auth, req.CollectionId.Value, req.OutputId.Value)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		testOutput = model.NewOutput()
+		testOutput.Type = req.Output.Type.String()
+		testOutput.Config = apitoolbox.NewOutputConfigFromAPI(req.Output)
+		testOutput.CollectionID = collection.ID
+		testOutput.CollectionFieldMask = collection.FieldMask
+		testOutput.Enabled = true
+	}
+	if messages, err := s.manager.Verify(testOutput); err != nil {
+		s, err := status.New(codes.InvalidArgument, "Output configuration is invalid").WithDetails(&apipb.ErrorDetails{Messages: messages})
+		if err != nil {
+			logging.Error("Could not send error: %v", err)
+			return nil, status.Error(codes.InvalidArgument, "Config error")
+		}
+		return nil, s.Err()
+	}
+
+	var msg model.DataMessage
+	if req.DeviceId != nil || req.MessageTime != nil {
+		if err := apitoolbox.EnsurePermission(auth.User.ID, collection.TeamID, s.store, model.ReadDataPermission); err != nil {
+			return nil, err
+		}
+		msg, err = s.loadStoredMessage(ctx, auth, collection, req)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		msg = s.newSyntheticMessage(collection, req)
+	}
+
+	result, err := s.manager.Test(testOutput, s.fieldMask.ForcedFields(), msg)
+	if err != nil {
+		logging.Warning("Unable to test output (collection ID = %d, type = %s): %v", collection.ID, testOutput.Type, err)
+		return nil, status.Error(codes.Internal, "Unable to test output")
+	}
+	return apitoolbox.NewOutputTestResponseFromModel(result, apitoolbox.NewOutputDataMessageFromModel(msg, collection)), nil
+}
+
+// newSyntheticMessage creates a test message from a device that doesn't exist
+func (s *outputService) newSyntheticMessage(collection model.Collection, req *apipb.OutputTestRequest) model.DataMessage {
+	device := model.NewDevice()
+	device.CollectionID = collection.ID
+	device.SetTag("name", "Test device")
+	payload := []byte(defaultTestPayload)
+	if req.Payload != nil {
+		payload = req.Payload.Value
+	}
+	return model.NewDataMessage(device, payload, model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{})
+}
+
+// loadStoredMessage retrieves the most recent message matching the device ID
+// and message time from the data store.
+func (s *outputService) loadStoredMessage(ctx context.Context, auth *authResult, collection model.Collection, req *apipb.OutputTestRequest) (model.DataMessage, error) {
+	filter := &datastore.DataFilter{
+		CollectionId: collection.ID.String(),
+		Limit:        1,
+	}
+	if req.DeviceId != nil {
+		deviceID, err := model.NewDeviceKeyFromString(req.DeviceId.Value)
+		if err != nil {
+			return model.DataMessage{}, status.Error(codes.InvalidArgument, "Invalid device ID")
+		}
+		filter.DeviceId = deviceID.String()
+	}
+	if req.MessageTime != nil {
+		// The data store uses nanoseconds with inclusive limits
+		filter.From = req.MessageTime.Value * int64(time.Millisecond)
+		filter.To = filter.From + int64(time.Millisecond) - 1
+	}
+
+	result, err := s.dataStoreClient.GetData(ctx, filter)
+	if err != nil {
+		logging.Warning("Error retrieving data from data store for collection %d: %v", collection.ID, err)
+		return model.DataMessage{}, status.Error(codes.Internal, "Error loading data from store")
+	}
+	defer result.CloseSend()
+
+	var stored datastore.DataMessage
+	if err := result.RecvMsg(&stored); err != nil {
+		return model.DataMessage{}, status.Error(codes.NotFound, "No matching message found")
+	}
+	deviceID, err := model.NewDeviceKeyFromString(stored.DeviceId)
+	if err != nil {
+		logging.Warning("Invalid device ID in data store (collection ID = %d): %s", collection.ID, stored.DeviceId)
+		return model.DataMessage{}, status.Error(codes.Internal, "Error loading data from store")
+	}
+	device, err := s.store.RetrieveDevice(auth.User.ID, collection.ID, deviceID)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return model.DataMessage{}, status.Error(codes.NotFound, "The device for the message doesn't exist")
+		}
+		logging.Warning("Unable to retrieve device %d (collection ID = %d): %v", deviceID, collection.ID, err)
+		return model.DataMessage{}, status.Error(codes.Internal, "Unable to retrieve device")
+	}
+	dataMessage, err := apitoolbox.UnmarshalDataStoreMetadata(stored.Metadata, collection.FieldMask, stored.Payload, stored.Created)
+	if err != nil {
+		logging.Warning("Error unmarshaling metadata: %v", err)
+		return model.DataMessage{}, status.Error(codes.Internal, "Error loading data from store")
+	}
+	return apitoolbox.NewDataMessageFromOutputDataMessage(dataMessage, device), nil
+}
+
 // Tag implementation. This is going to be a bit different since it uses both
 // a collection ID and a device ID to retrieve the device as opposed to the
 // team/collection/token tag updates that uses a single identifier for the
@@ -332,9 +493,18 @@ func (s *outputService) LoadTaggedResource(auth *authResult, collectionID string
 	return &o, err
 }
 
+func (s *outputService) EnsureTagUpdate(auth *authResult, res taggedResource) error {
+	o := res.(*model.Output)
+	return apitoolbox.EnsureCollectionPermission(auth.User.ID, o.CollectionID, s.store, model.ManageOutputsPermission)
+}
+
 func (s *outputService) UpdateResourceTags(id model.UserKey, collectionID, identifier string, res interface{}) error {
 	o := res.(*model.Output)
-	return s.store.UpdateOutputTags(id, identifier, o.Tags)
+	if err := s.store.UpdateOutputTags(id, identifier, o.Tags); err != nil {
+		return err
+	}
+	s.manager.PublishEvent(model.NewOutputEvent(model.OutputUpdated, *o))
+	return nil
 }
 
 func (s *outputService) ListOutputTags(ctx context.Context, req *apipb.TagRequest) (*apipb.TagResponse, error) {
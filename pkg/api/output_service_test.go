@@ -18,7 +18,10 @@ package api
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ExploratoryEngineering/pubsub"
 	"github.com/eesrc/horde/pkg/api/apipb"
@@ -49,7 +52,7 @@ func newOutputTest(t *testing.T) outputTestSetup {
 	ret.assert = require.New(t)
 	ret.store = sqlstore.NewMemoryStore()
 	ret.mgr = newDummyManager()
-	ret.outputService = newOutputService(ret.store, ret.mgr, model.FieldMaskParameters{})
+	ret.outputService = newOutputService(ret.store, ret.mgr, model.FieldMaskParameters{}, newDummyDataStoreClient())
 	ret.assert.NotNil(ret.outputService)
 
 	ret.user, _, ret.ctx = createAuthenticatedContext(ret.assert, ret.store)
@@ -145,6 +148,7 @@ func TestDeleteOutput(t *testing.T) {
 	// Create a new output where the user is a non-admin
 	u2, _, _ := createAuthenticatedContext(ot.assert, ot.store)
 	t2 := model.NewTeam()
+	t2.ID = ot.store.NewTeamID()
 	t2.AddMember(model.NewMember(ot.user, model.MemberRole))
 	t2.AddMember(model.NewMember(u2, model.AdminRole))
 	ot.assert.NoError(ot.store.CreateTeam(t2))
@@ -222,6 +226,106 @@ func TestOutputStatus(t *testing.T) {
 	ot.assert.Equal(codes.FailedPrecondition.String(), status.Code(err).String())
 }
 
+func TestTestOutput(t *testing.T) {
+	ot := newOutputTest(t)
+	assert := ot.assert
+
+	received := make(chan string, 10)
+	hookserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	defer hookserver.Close()
+
+	_, err := ot.outputService.TestOutput(ot.ctx, nil)
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = ot.outputService.TestOutput(ot.ctx, &apipb.OutputTestRequest{
+		CollectionId: &wrappers.StringValue{Value: ot.collection.ID.String()},
+	})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = ot.outputService.TestOutput(context.Background(), &apipb.OutputTestRequest{
+		CollectionId: &wrappers.StringValue{Value: ot.collection.ID.String()},
+		OutputId:     &wrappers.StringValue{Value: ot.output.ID.String()},
+	})
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+
+	_, err = ot.outputService.TestOutput(ot.ctx, &apipb.OutputTestRequest{
+		CollectionId: &wrappers.StringValue{Value: "0"},
+		OutputId:     &wrappers.StringValue{Value: ot.output.ID.String()},
+	})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	// Invalid configurations are rejected
+	_, err = ot.outputService.TestOutput(ot.ctx, &apipb.OutputTestRequest{
+		CollectionId: &wrappers.StringValue{Value: ot.collection.ID.String()},
+		Output: &apipb.Output{
+			Type:   apipb.Output_webhook,
+			Config: &apipb.OutputConfig{},
+		},
+	})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Unsaved output with a synthetic message
+	unsaved := &apipb.Output{
+		Type: apipb.Output_webhook,
+		Config: &apipb.OutputConfig{
+			Url: &wrappers.StringValue{Value: hookserver.URL + "/unsaved"},
+		},
+	}
+	res, err := ot.outputService.TestOutput(ot.ctx, &apipb.OutputTestRequest{
+		CollectionId: &wrappers.StringValue{Value: ot.collection.ID.String()},
+		Output:       unsaved,
+		Payload:      &wrappers.BytesValue{Value: []byte("test")},
+	})
+	assert.NoError(err)
+	assert.True(res.Success.Value)
+	assert.Equal(int32(http.StatusOK), res.HttpStatusCode.Value)
+	assert.Equal("ok", res.HttpResponseBody.Value)
+	assert.Equal(hookserver.Listener.Addr().String(), res.RemoteAddress.Value)
+	assert.Equal([]byte("test"), res.Data.Payload)
+	assert.NotNil(res.Timing)
+	assert.Equal("/unsaved", <-received)
+
+	// Stored message from the data store. The device must exist.
+	_, err = ot.outputService.TestOutput(ot.ctx, &apipb.OutputTestRequest{
+		CollectionId: &wrappers.StringValue{Value: ot.collection.ID.String()},
+		Output:       unsaved,
+		DeviceId:     &wrappers.StringValue{Value: model.DeviceKey(1).String()},
+	})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	device := model.NewDevice()
+	device.ID = model.DeviceKey(1)
+	device.IMSI = 1
+	device.IMEI = 1
+	device.CollectionID = ot.collection.ID
+	assert.NoError(ot.store.CreateDevice(ot.user.ID, device))
+
+	res, err = ot.outputService.TestOutput(ot.ctx, &apipb.OutputTestRequest{
+		CollectionId: &wrappers.StringValue{Value: ot.collection.ID.String()},
+		Output:       unsaved,
+		DeviceId:     &wrappers.StringValue{Value: device.ID.String()},
+	})
+	assert.NoError(err)
+	assert.True(res.Success.Value)
+	assert.Equal([]byte("hello there"), res.Data.Payload)
+	assert.Equal(device.ID.String(), res.Data.Device.DeviceId.Value)
+	assert.Equal("/unsaved", <-received)
+
+	// Existing output. Nothing listens on the configured port so this will fail
+	// but the error is reported in the response.
+	res, err = ot.outputService.TestOutput(ot.ctx, &apipb.OutputTestRequest{
+		CollectionId: &wrappers.StringValue{Value: ot.collection.ID.String()},
+		OutputId:     &wrappers.StringValue{Value: ot.output.ID.String()},
+	})
+	assert.NoError(err)
+	assert.False(res.Success.Value)
+	assert.NotEmpty(res.Message.Value)
+}
+
 type outputFactory struct {
 }
 
@@ -312,6 +416,7 @@ func TestCreateOutput(t *testing.T) {
 	// Collection owned by someone else => error
 	u2, _, _ := createAuthenticatedContext(ot.assert, ot.store)
 	t2 := model.NewTeam()
+	t2.ID = ot.store.NewTeamID()
 	t2.AddMember(model.NewMember(ot.user, model.MemberRole))
 	t2.AddMember(model.NewMember(u2, model.AdminRole))
 	ot.assert.NoError(ot.store.CreateTeam(t2))
@@ -452,6 +557,7 @@ func TestUpdateOutput(t *testing.T) {
 	// Collection owned by someone else => error
 	u2, _, _ := createAuthenticatedContext(ot.assert, ot.store)
 	t2 := model.NewTeam()
+	t2.ID = ot.store.NewTeamID()
 	t2.AddMember(model.NewMember(ot.user, model.MemberRole))
 	t2.AddMember(model.NewMember(u2, model.AdminRole))
 	ot.assert.NoError(ot.store.CreateTeam(t2))
@@ -535,6 +641,10 @@ func (m *dummyManager) Publish(msg model.DataMessage) {
 	m.router.Publish(msg.Device.CollectionID, msg)
 }
 
+func (m *dummyManager) PublishEvent(ev model.ResourceEvent) {
+	m.router.Publish(ev.CollectionID, ev)
+}
+
 func (m *dummyManager) Subscribe(collectionID model.CollectionKey) <-chan interface{} {
 	return m.router.Subscribe(collectionID)
 }
@@ -543,6 +653,14 @@ func (m *dummyManager) Unsubscribe(ch <-chan interface{}) {
 	m.router.Unsubscribe(ch)
 }
 
+func (m *dummyManager) Test(config model.Output, systemFieldMask model.FieldMask, msg model.DataMessage) (model.OutputTestResult, error) {
+	op, err := output.NewOutput(config.Type)
+	if err != nil {
+		return model.OutputTestResult{}, err
+	}
+	return op.Test(config.Config, config.CollectionFieldMask, systemFieldMask, msg, time.Second), nil
+}
+
 // NewDummyManager For testing: Return a dummy manager
 func newDummyManager() output.Manager {
 	return &dummyManager{
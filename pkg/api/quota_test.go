@@ -0,0 +1,78 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"fmt"
+	"testing"
+
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQuotaEnforcement(t *testing.T) {
+	assert := require.New(t)
+	store := sqlstore.NewMemoryStore()
+	fm := model.FieldMaskParameters{Default: "msisdn", Forced: "msisdn"}
+
+	cs := newCollectionService(store, fm, output.NewDummyManager(), newDummyDataStoreClient(), newDummyMessageSender())
+	ds := newDeviceService(store, newDummyDataStoreClient(), newDummyMessageSender(), output.NewDummyManager())
+	ts := newTeamService(store)
+
+	user, _, ctx := createAuthenticatedContext(assert, store)
+	teamID := &wrappers.StringValue{Value: user.PrivateTeamID.String()}
+
+	// No quota means no limits
+	coll, err := cs.CreateCollection(ctx, &apipb.Collection{TeamId: teamID})
+	assert.NoError(err)
+
+	assert.NoError(store.UpdateTeamQuota(user.PrivateTeamID, model.Quota{MaxCollections: 1, MaxDevices: 2}))
+
+	_, err = cs.CreateCollection(ctx, &apipb.Collection{TeamId: teamID})
+	assert.Error(err)
+	assert.Equal(codes.ResourceExhausted.String(), status.Code(err).String())
+
+	newDevice := func(i int) *apipb.Device {
+		return &apipb.Device{
+			CollectionId: coll.CollectionId,
+			Imsi:         &wrappers.StringValue{Value: fmt.Sprintf("%d", 1000+i)},
+			Imei:         &wrappers.StringValue{Value: fmt.Sprintf("%d", 2000+i)},
+		}
+	}
+	for i := 0; i < 2; i++ {
+		_, err = ds.CreateDevice(ctx, newDevice(i))
+		assert.NoError(err)
+	}
+	_, err = ds.CreateDevice(ctx, newDevice(2))
+	assert.Error(err)
+	assert.Equal(codes.ResourceExhausted.String(), status.Code(err).String())
+
+	// The quota and usage is included when the team is retrieved
+	team, err := ts.RetrieveTeam(ctx, &apipb.TeamRequest{TeamId: teamID})
+	assert.NoError(err)
+	assert.NotNil(team.Quota)
+	assert.Equal(int32(1), team.Quota.MaxCollections.GetValue())
+	assert.Equal(int32(2), team.Quota.MaxDevices.GetValue())
+	assert.Nil(team.Quota.MaxOutputs)
+	assert.Equal(int32(1), team.Usage.Collections.GetValue())
+	assert.Equal(int32(2), team.Usage.Devices.GetValue())
+}
@@ -0,0 +1,220 @@
+package api
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"context"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/api/apitoolbox"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (fs *firmwareService) loadSigningKey(auth *authResult, collectionID model.CollectionKey, keyID string) (model.SigningKey, error) {
+	kID, err := model.NewSigningKeyKeyFromString(keyID)
+	if err != nil {
+		return model.SigningKey{}, status.Error(codes.InvalidArgument, "Invalid signing key ID")
+	}
+	key, err := fs.store.RetrieveSigningKey(auth.User.ID, collectionID, kID)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return model.SigningKey{}, status.Error(codes.NotFound, "Unknown signing key")
+		}
+		logging.Warning("Unable to retrieve signing key %d (collection ID = %d): %v", kID, collectionID, err)
+		return model.SigningKey{}, status.Error(codes.Internal, "Unable to retrieve signing key")
+	}
+	return key, nil
+}
+
+// signImage verifies the detached signature in the request or signs the
+// image with the signing key if there's no signature. Unsigned images are
+// refused if the collection requires signatures.
+func (fs *firmwareService) signImage(auth *authResult, collectionID model.CollectionKey, req *apipb.CreateFirmwareRequest, digest []byte) ([]byte, model.SigningKeyKey, error) {
+	if req.SigningKeyId == nil {
+		if len(req.Signature) > 0 {
+			return nil, 0, status.Error(codes.InvalidArgument, "Signing key ID required for signatures")
+		}
+		coll, err := fs.store.RetrieveCollection(auth.User.ID, collectionID)
+		if err != nil {
+			if err == storage.ErrNotFound {
+				return nil, 0, status.Error(codes.NotFound, "Unknown collection")
+			}
+			logging.Warning("Unable to retrieve collection %d: %v", collectionID, err)
+			return nil, 0, status.Error(codes.Internal, "Unable to retrieve collection")
+		}
+		if coll.Firmware.RequireSignature {
+			return nil, 0, status.Error(codes.FailedPrecondition, "Collection requires signed firmware images")
+		}
+		return nil, 0, nil
+	}
+	key, err := fs.loadSigningKey(auth, collectionID, req.SigningKeyId.Value)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(req.Signature) == 0 {
+		if !key.CanSign() {
+			return nil, 0, status.Error(codes.InvalidArgument, "Signature required for registered signing keys")
+		}
+		signature, err := key.Sign(digest)
+		if err != nil {
+			logging.Warning("Unable to sign image with key %d (collection ID = %d): %v", key.ID, collectionID, err)
+			return nil, 0, status.Error(codes.Internal, "Unable to sign image")
+		}
+		return signature, key.ID, nil
+	}
+	if !key.Verify(digest, req.Signature) {
+		return nil, 0, status.Error(codes.InvalidArgument, "Signature does not match image")
+	}
+	return req.Signature, key.ID, nil
+}
+
+// ensureSignedTarget refuses unsigned target images for collections that
+// require signed images.
+func ensureSignedTarget(requireSignature bool, target model.Firmware) error {
+	if requireSignature && target.ID != 0 && !target.IsSigned() {
+		return status.Error(codes.FailedPrecondition, "Collection requires signed firmware images")
+	}
+	return nil
+}
+
+func (fs *firmwareService) CreateSigningKey(ctx context.Context, req *apipb.SigningKey) (*apipb.SigningKey, error) {
+	if req == nil || req.CollectionId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing collection ID")
+	}
+	if req.Algorithm == nil {
+		return nil, status.Error(codes.InvalidArgument, "Algorithm required")
+	}
+	collectionID, err := model.NewCollectionKeyFromString(req.CollectionId.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
+	}
+	auth, err := fs.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, collectionID, fs.store, model.ManageFirmwarePermission); err != nil {
+		return nil, err
+	}
+
+	var key model.SigningKey
+	if len(req.PublicKey) == 0 {
+		key, err = model.NewSigningKey(req.Algorithm.Value)
+	} else {
+		key, err = model.NewSigningKeyFromPublicKey(req.Algorithm.Value, req.PublicKey)
+	}
+	if err != nil {
+		if err == model.ErrInvalidSigningKey {
+			return nil, status.Error(codes.InvalidArgument, "Invalid algorithm or public key")
+		}
+		logging.Warning("Unable to generate signing key: %v", err)
+		return nil, status.Error(codes.Internal, "Unable to generate signing key")
+	}
+	key.ID = fs.store.NewSigningKeyID()
+	key.CollectionID = collectionID
+	key.Created = time.Now()
+	if err := fs.store.CreateSigningKey(auth.User.ID, key); err != nil {
+		if err == storage.ErrAccess {
+			return nil, status.Error(codes.PermissionDenied, "Must be administrator to create signing keys")
+		}
+		logging.Warning("Unable to create signing key %d (collection ID = %d): %v", key.ID, collectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to create signing key")
+	}
+	return apitoolbox.NewSigningKeyFromModel(key), nil
+}
+
+func (fs *firmwareService) RetrieveSigningKey(ctx context.Context, req *apipb.SigningKeyRequest) (*apipb.SigningKey, error) {
+	if req == nil || req.CollectionId == nil || req.KeyId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing collection/signing key ID")
+	}
+	collectionID, err := model.NewCollectionKeyFromString(req.CollectionId.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
+	}
+	auth, err := fs.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := fs.loadSigningKey(auth, collectionID, req.KeyId.Value)
+	if err != nil {
+		return nil, err
+	}
+	return apitoolbox.NewSigningKeyFromModel(key), nil
+}
+
+func (fs *firmwareService) ListSigningKeys(ctx context.Context, req *apipb.ListSigningKeysRequest) (*apipb.ListSigningKeysResponse, error) {
+	if req == nil || req.CollectionId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing collection ID")
+	}
+	collectionID, err := model.NewCollectionKeyFromString(req.CollectionId.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
+	}
+	auth, err := fs.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fs.store.RetrieveCollection(auth.User.ID, collectionID); err != nil {
+		if err == storage.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Unknown collection")
+		}
+		logging.Warning("Unable to retrieve collection %d: %v", collectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to retrieve collection")
+	}
+	list, err := fs.store.ListSigningKeys(auth.User.ID, collectionID)
+	if err != nil {
+		logging.Warning("Unable to list signing keys for collection %d: %v", collectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to list signing keys")
+	}
+	ret := &apipb.ListSigningKeysResponse{Keys: make([]*apipb.SigningKey, 0)}
+	for _, v := range list {
+		ret.Keys = append(ret.Keys, apitoolbox.NewSigningKeyFromModel(v))
+	}
+	return ret, nil
+}
+
+func (fs *firmwareService) DeleteSigningKey(ctx context.Context, req *apipb.SigningKeyRequest) (*apipb.SigningKey, error) {
+	if req == nil || req.CollectionId == nil || req.KeyId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing collection/signing key ID")
+	}
+	collectionID, err := model.NewCollectionKeyFromString(req.CollectionId.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
+	}
+	auth, err := fs.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := fs.loadSigningKey(auth, collectionID, req.KeyId.Value)
+	if err != nil {
+		return nil, err
+	}
+	if err := apitoolbox.EnsureCollectionPermission(auth.User.ID, collectionID, fs.store, model.ManageFirmwarePermission); err != nil {
+		return nil, err
+	}
+	if err := fs.store.DeleteSigningKey(auth.User.ID, collectionID, key.ID); err != nil {
+		if err == storage.ErrAccess {
+			return nil, status.Error(codes.PermissionDenied, "Must be an administrator to remove signing keys")
+		}
+		logging.Warning("Unable to remove signing key %d (collection ID = %d): %v", key.ID, collectionID, err)
+		return nil, status.Error(codes.Internal, "Unable to remove signing key")
+	}
+	return apitoolbox.NewSigningKeyFromModel(key), nil
+}
@@ -0,0 +1,238 @@
+package api
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSigningKeys(t *testing.T) {
+	ft := newFirmwareTest(t)
+	collectionID := &wrappers.StringValue{Value: ft.collection.ID.String()}
+
+	_, err := ft.firmwareService.CreateSigningKey(ft.ctx, nil)
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = ft.firmwareService.CreateSigningKey(ft.ctx, &apipb.SigningKey{CollectionId: collectionID})
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = ft.firmwareService.CreateSigningKey(ft.ctx, &apipb.SigningKey{
+		CollectionId: collectionID,
+		Algorithm:    &wrappers.StringValue{Value: "rsa"},
+	})
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = ft.firmwareService.CreateSigningKey(ft.ctx, &apipb.SigningKey{
+		CollectionId: collectionID,
+		Algorithm:    &wrappers.StringValue{Value: model.ECDSAP256Signature},
+		PublicKey:    []byte("not a key"),
+	})
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = ft.firmwareService.CreateSigningKey(ft.ctx, &apipb.SigningKey{
+		CollectionId: &wrappers.StringValue{Value: "0"},
+		Algorithm:    &wrappers.StringValue{Value: model.Ed25519Signature},
+	})
+	ft.assert.Error(err)
+
+	// Generated keys can sign
+	generated, err := ft.firmwareService.CreateSigningKey(ft.ctx, &apipb.SigningKey{
+		CollectionId: collectionID,
+		Algorithm:    &wrappers.StringValue{Value: model.ECDSAP256Signature},
+	})
+	ft.assert.NoError(err)
+	ft.assert.True(generated.CanSign.Value)
+	ft.assert.NotEmpty(generated.PublicKey)
+
+	// Registered keys can't
+	external, err := model.NewSigningKey(model.Ed25519Signature)
+	ft.assert.NoError(err)
+	registered, err := ft.firmwareService.CreateSigningKey(ft.ctx, &apipb.SigningKey{
+		CollectionId: collectionID,
+		Algorithm:    &wrappers.StringValue{Value: model.Ed25519Signature},
+		PublicKey:    external.PublicKey,
+	})
+	ft.assert.NoError(err)
+	ft.assert.False(registered.CanSign.Value)
+	ft.assert.Equal(external.PublicKey, registered.PublicKey)
+
+	key, err := ft.firmwareService.RetrieveSigningKey(ft.ctx, &apipb.SigningKeyRequest{CollectionId: collectionID, KeyId: generated.KeyId})
+	ft.assert.NoError(err)
+	ft.assert.Equal(generated.PublicKey, key.PublicKey)
+	_, err = ft.firmwareService.RetrieveSigningKey(ft.ctx, &apipb.SigningKeyRequest{CollectionId: collectionID, KeyId: &wrappers.StringValue{Value: "0"}})
+	ft.assert.Equal(codes.NotFound, status.Code(err))
+	_, err = ft.firmwareService.RetrieveSigningKey(ft.ctx, &apipb.SigningKeyRequest{CollectionId: collectionID})
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	list, err := ft.firmwareService.ListSigningKeys(ft.ctx, &apipb.ListSigningKeysRequest{CollectionId: collectionID})
+	ft.assert.NoError(err)
+	ft.assert.Len(list.Keys, 2)
+	_, err = ft.firmwareService.ListSigningKeys(ft.ctx, &apipb.ListSigningKeysRequest{CollectionId: &wrappers.StringValue{Value: "0"}})
+	ft.assert.Equal(codes.NotFound, status.Code(err))
+
+	// Images signed with the generated key
+	image := []byte("image signed by the service")
+	digest := sha256.Sum256(image)
+	fw, err := ft.firmwareService.CreateFirmware(ft.ctx, &apipb.CreateFirmwareRequest{
+		CollectionId: collectionID,
+		Image:        image,
+		Filename:     &wrappers.StringValue{Value: "signed.bin"},
+		Version:      &wrappers.StringValue{Value: "signed-1"},
+		SigningKeyId: generated.KeyId,
+	})
+	ft.assert.NoError(err)
+	ft.assert.Equal(generated.KeyId.Value, fw.SigningKeyId.Value)
+	verifier, err := model.NewSigningKeyFromPublicKey(model.ECDSAP256Signature, generated.PublicKey)
+	ft.assert.NoError(err)
+	ft.assert.True(verifier.Verify(digest[:], fw.Signature))
+
+	// Detached signatures are verified against the registered key
+	image = []byte("image signed by the user")
+	digest = sha256.Sum256(image)
+	signature, err := external.Sign(digest[:])
+	ft.assert.NoError(err)
+	req := &apipb.CreateFirmwareRequest{
+		CollectionId: collectionID,
+		Image:        image,
+		Filename:     &wrappers.StringValue{Value: "detached.bin"},
+		Version:      &wrappers.StringValue{Value: "signed-2"},
+		SigningKeyId: registered.KeyId,
+	}
+	_, err = ft.firmwareService.CreateFirmware(ft.ctx, req)
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err), "Registered keys require a signature")
+	req.Signature = []byte("invalid signature")
+	_, err = ft.firmwareService.CreateFirmware(ft.ctx, req)
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err), "Invalid signatures are refused")
+	req.SigningKeyId = nil
+	req.Signature = signature
+	_, err = ft.firmwareService.CreateFirmware(ft.ctx, req)
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err), "Signatures require a key ID")
+	req.SigningKeyId = registered.KeyId
+	fw, err = ft.firmwareService.CreateFirmware(ft.ctx, req)
+	ft.assert.NoError(err)
+	ft.assert.Equal(signature, fw.Signature)
+
+	// The signature can't be changed
+	_, err = ft.firmwareService.UpdateFirmware(ft.ctx, &apipb.Firmware{
+		CollectionId: collectionID,
+		ImageId:      fw.ImageId,
+		Signature:    []byte("other"),
+	})
+	ft.assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Unsigned images are refused when the collection requires signatures
+	coll := ft.collection
+	coll.Firmware.RequireSignature = true
+	ft.assert.NoError(ft.store.UpdateCollection(ft.user.ID, coll))
+	unsigned := &apipb.CreateFirmwareRequest{
+		CollectionId: collectionID,
+		Image:        []byte("unsigned image"),
+		Filename:     &wrappers.StringValue{Value: "unsigned.bin"},
+		Version:      &wrappers.StringValue{Value: "unsigned"},
+	}
+	_, err = ft.firmwareService.CreateFirmware(ft.ctx, unsigned)
+	ft.assert.Equal(codes.FailedPrecondition, status.Code(err))
+	unsigned.SigningKeyId = generated.KeyId
+	_, err = ft.firmwareService.CreateFirmware(ft.ctx, unsigned)
+	ft.assert.NoError(err)
+
+	deleted, err := ft.firmwareService.DeleteSigningKey(ft.ctx, &apipb.SigningKeyRequest{CollectionId: collectionID, KeyId: registered.KeyId})
+	ft.assert.NoError(err)
+	ft.assert.Equal(registered.KeyId.Value, deleted.KeyId.Value)
+	_, err = ft.firmwareService.DeleteSigningKey(ft.ctx, &apipb.SigningKeyRequest{CollectionId: collectionID, KeyId: registered.KeyId})
+	ft.assert.Equal(codes.NotFound, status.Code(err))
+
+	// Signatures are kept when the key is removed
+	kept, err := ft.firmwareService.RetrieveFirmware(ft.ctx, &apipb.FirmwareRequest{CollectionId: collectionID, ImageId: fw.ImageId})
+	ft.assert.NoError(err)
+	ft.assert.Equal(signature, kept.Signature)
+}
+
+func TestRequireSignatureTargets(t *testing.T) {
+	ft := newFirmwareTest(t)
+	collectionID := &wrappers.StringValue{Value: ft.collection.ID.String()}
+	cs := newCollectionService(ft.store, model.FieldMaskParameters{}, output.NewDummyManager(), newDummyDataStoreClient(), newDummyMessageSender())
+	ds := newDeviceService(ft.store, newDummyDataStoreClient(), newDummyMessageSender(), output.NewDummyManager())
+
+	key, err := model.NewSigningKey(model.Ed25519Signature)
+	ft.assert.NoError(err)
+	digest := sha256.Sum256([]byte("signed"))
+	signed := model.NewFirmware()
+	signed.ID = ft.store.NewFirmwareID()
+	signed.CollectionID = ft.collection.ID
+	signed.SHA256 = "signed"
+	signed.Version = "2.0"
+	signed.Signature, err = key.Sign(digest[:])
+	ft.assert.NoError(err)
+	ft.assert.NoError(ft.store.CreateFirmware(ft.user.ID, signed))
+
+	device := model.NewDevice()
+	device.ID = ft.store.NewDeviceID()
+	device.IMSI = 1
+	device.IMEI = 1
+	device.CollectionID = ft.collection.ID
+	ft.assert.NoError(ft.store.CreateDevice(ft.user.ID, device))
+
+	// The requirement can't be turned on when the target is unsigned
+	_, err = cs.UpdateCollection(ft.ctx, &apipb.Collection{
+		CollectionId: collectionID,
+		Firmware: &apipb.CollectionFirmware{
+			Management:       apipb.CollectionFirmware_device,
+			TargetFirmwareId: &wrappers.StringValue{Value: ft.firmware.ID.String()},
+		},
+	})
+	ft.assert.NoError(err)
+	_, err = cs.UpdateCollection(ft.ctx, &apipb.Collection{
+		CollectionId: collectionID,
+		Firmware:     &apipb.CollectionFirmware{RequireSignature: &wrappers.BoolValue{Value: true}},
+	})
+	ft.assert.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = cs.UpdateCollection(ft.ctx, &apipb.Collection{
+		CollectionId: collectionID,
+		Firmware: &apipb.CollectionFirmware{
+			TargetFirmwareId: &wrappers.StringValue{Value: signed.ID.String()},
+			RequireSignature: &wrappers.BoolValue{Value: true},
+		},
+	})
+	ft.assert.NoError(err)
+
+	// ...and the target can't be changed to an unsigned image
+	_, err = cs.UpdateCollection(ft.ctx, &apipb.Collection{
+		CollectionId: collectionID,
+		Firmware:     &apipb.CollectionFirmware{TargetFirmwareId: &wrappers.StringValue{Value: ft.firmware.ID.String()}},
+	})
+	ft.assert.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// The same applies to the device targets
+	deviceID := &wrappers.StringValue{Value: device.ID.String()}
+	_, err = ds.UpdateDevice(ft.ctx, &apipb.UpdateDeviceRequest{
+		ExistingCollectionId: collectionID,
+		DeviceId:             deviceID,
+		Firmware:             &apipb.FirmwareMetadata{TargetFirmwareId: &wrappers.StringValue{Value: ft.firmware.ID.String()}},
+	})
+	ft.assert.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = ds.UpdateDevice(ft.ctx, &apipb.UpdateDeviceRequest{
+		ExistingCollectionId: collectionID,
+		DeviceId:             deviceID,
+		Firmware:             &apipb.FirmwareMetadata{TargetFirmwareId: &wrappers.StringValue{Value: signed.ID.String()}},
+	})
+	ft.assert.NoError(err)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/api/apitoolbox"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
 	"github.com/eesrc/horde/pkg/storage"
 	"github.com/eesrc/horde/pkg/version"
 	"google.golang.org/grpc/codes"
@@ -37,17 +38,21 @@ type systemService struct {
 	fieldMask       model.FieldMaskParameters
 	store           storage.DataStore
 	deviceDataStore datastore.DataStoreClient
+	restorer        *DataRestorer
 }
 
-// newSystemService creates a new system service instance.
+// newSystemService creates a new system service instance. The events are
+// published when data dumps are restored.
 func newSystemService(
 	fieldMask model.FieldMaskParameters,
 	store storage.DataStore,
-	dataStore datastore.DataStoreClient) systemService {
+	dataStore datastore.DataStoreClient,
+	events output.EventPublisher) systemService {
 	return systemService{
 		fieldMask:       fieldMask,
 		store:           store,
 		deviceDataStore: dataStore,
+		restorer:        NewDataRestorer(store, dataStore, fieldMask, events),
 	}
 }
 
@@ -67,8 +72,10 @@ func (s *systemService) DataDump(ctx context.Context, req *apipb.DataDumpRequest
 		return nil, status.Error(codes.Internal, "Error reading team list")
 	}
 	ret.Teams = make([]*apipb.Team, 0)
+	teamMap := make(map[model.TeamKey]model.Team)
 	for _, v := range teams {
 		ret.Teams = append(ret.Teams, apitoolbox.NewTeamFromModel(v, true))
+		teamMap[v.ID] = v
 	}
 
 	tokens, err := s.store.ListTokens(auth.User.ID)
@@ -89,7 +96,10 @@ func (s *systemService) DataDump(ctx context.Context, req *apipb.DataDumpRequest
 
 	ret.Collections = make([]*apipb.DumpedCollection, 0)
 	for _, v := range collections {
-		coll, err := s.readCollection(ctx, auth.User, v)
+		// The device data is only included if the role in the team permits it
+		team := teamMap[v.TeamID]
+		includeData := team.HasPermission(auth.User.ID, model.ReadDataPermission)
+		coll, err := s.readCollection(ctx, auth.User, v, includeData)
 		if err != nil {
 			logging.Warning("Unable to read contents of collection %d: %v", v.ID, err)
 			return nil, status.Error(codes.Internal, "Error reading contents of collection")
@@ -100,7 +110,7 @@ func (s *systemService) DataDump(ctx context.Context, req *apipb.DataDumpRequest
 }
 
 // Read devices, device data and outputs from collection
-func (s *systemService) readCollection(ctx context.Context, user model.User, c model.Collection) (*apipb.DumpedCollection, error) {
+func (s *systemService) readCollection(ctx context.Context, user model.User, c model.Collection, includeData bool) (*apipb.DumpedCollection, error) {
 	ret := &apipb.DumpedCollection{}
 	ret.Collection = apitoolbox.NewCollectionFromModel(c)
 
@@ -111,10 +121,13 @@ func (s *systemService) readCollection(ctx context.Context, user model.User, c m
 	ret.Devices = make([]*apipb.DumpedDevice, 0)
 	for _, v := range devices {
 		device := apitoolbox.NewDeviceFromModel(v, c)
-		data, err := s.loadDataForDevice(ctx, c.ID, c.FieldMask, v.ID)
-		if err != nil {
-			logging.Warning("Unable to load data for device %d in collection %d: %v. Skipping device.", v.ID, c.ID, err)
-			continue
+		data := make([]*apipb.OutputDataMessage, 0)
+		if includeData {
+			data, err = s.loadDataForDevice(ctx, c.ID, c.FieldMask, v.ID)
+			if err != nil {
+				logging.Warning("Unable to load data for device %d in collection %d: %v. Skipping device.", v.ID, c.ID, err)
+				continue
+			}
 		}
 		dd := &apipb.DumpedDevice{
 			Device: device,
@@ -131,30 +144,229 @@ func (s *systemService) readCollection(ctx context.Context, user model.User, c m
 		output := apitoolbox.NewOutputFromModel(v)
 		ret.Outputs = append(ret.Outputs, output)
 	}
+	firmware, err := s.store.ListFirmware(user.ID, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	ret.Firmware = make([]*apipb.Firmware, 0)
+	for _, v := range firmware {
+		ret.Firmware = append(ret.Firmware, apitoolbox.NewFirmwareFromModel(v))
+	}
 	return ret, nil
 }
 
+func (s *systemService) DataRestore(ctx context.Context, req *apipb.DataRestoreRequest) (*apipb.DataRestoreResponse, error) {
+	if req == nil || req.TeamId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing team ID")
+	}
+	auth := gRPCAuth(ctx, s.store)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "Must authenticate")
+	}
+	teamID, err := model.NewTeamKeyFromString(req.TeamId.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid team ID")
+	}
+	includeData := false
+	if req.IncludeData != nil {
+		includeData = req.IncludeData.Value
+	}
+	return s.restorer.Restore(ctx, auth.User.ID, teamID, req.Dump, includeData)
+}
+
 func (s *systemService) loadDataForDevice(ctx context.Context, collectionID model.CollectionKey, fieldMask model.FieldMask, deviceID model.DeviceKey) ([]*apipb.OutputDataMessage, error) {
-	stream, err := s.deviceDataStore.GetData(ctx, &datastore.DataFilter{
+	ret := make([]*apipb.OutputDataMessage, 0)
+	err := s.readDataForDevice(ctx, &datastore.DataFilter{
 		CollectionId: collectionID.String(),
 		DeviceId:     deviceID.String(),
+	}, fieldMask, func(msg *apipb.OutputDataMessage) error {
+		ret = append(ret, msg)
+		return nil
 	})
+	return ret, err
+}
+
+// readDataForDevice reads the data matching the filter from the data store
+// and calls the function for each message. Messages that can't be decoded are
+// skipped.
+func (s *systemService) readDataForDevice(ctx context.Context, filter *datastore.DataFilter, fieldMask model.FieldMask, fn func(*apipb.OutputDataMessage) error) error {
+	stream, err := s.deviceDataStore.GetData(ctx, filter)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	ret := make([]*apipb.OutputDataMessage, 0)
 	for {
 		msg, err := stream.Recv()
 		if err != nil {
-			return ret, nil
+			return nil
 		}
 		dataMessage, err := apitoolbox.UnmarshalDataStoreMetadata(msg.Metadata, fieldMask, msg.Payload, msg.Created)
 		if err != nil {
 			continue
 		}
-		ret = append(ret, dataMessage)
+		if err := fn(dataMessage); err != nil {
+			return err
+		}
+	}
+}
+
+const (
+	defaultDumpPageSize = 100
+	maxDumpPageSize     = 1000
+	dumpDevicePageSize  = 500
+)
+
+func (s *systemService) StreamDataDump(req *apipb.StreamDataDumpRequest, svr apipb.Horde_StreamDataDumpServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "Missing request")
+	}
+	auth := gRPCAuth(svr.Context(), s.store)
+	if auth == nil {
+		return status.Error(codes.Unauthenticated, "Must authenticate")
+	}
+	includeData := true
+	if req.IncludeData != nil {
+		includeData = req.IncludeData.Value
+	}
+	if includeData && s.deviceDataStore == nil {
+		return status.Error(codes.FailedPrecondition, "Data store is not available")
+	}
+	pageSize := defaultDumpPageSize
+	if req.PageSize != nil {
+		pageSize = int(req.PageSize.Value)
+		if pageSize < 1 || pageSize > maxDumpPageSize {
+			return status.Errorf(codes.InvalidArgument, "Page size must be between 1 and %d", maxDumpPageSize)
+		}
+	}
+	filter := &datastore.DataFilter{}
+	apitoolbox.ApplyDataFilter(&apipb.ListMessagesRequest{Since: req.Since, Until: req.Until}, filter)
+	filter.Limit = 0
+
+	if err := svr.Send(&apipb.DataDumpChunk{Profile: apitoolbox.NewUserProfileFromUser(auth.User)}); err != nil {
+		return err
+	}
+
+	teams, err := s.store.ListTeams(auth.User.ID)
+	if err != nil {
+		logging.Warning("Unable to read teams for user %d: %v", auth.User.ID, err)
+		return status.Error(codes.Internal, "Error reading team list")
+	}
+	teamMap := make(map[model.TeamKey]model.Team)
+	for _, v := range teams {
+		if err := svr.Send(&apipb.DataDumpChunk{Team: apitoolbox.NewTeamFromModel(v, true)}); err != nil {
+			return err
+		}
+		teamMap[v.ID] = v
+	}
+
+	tokens, err := s.store.ListTokens(auth.User.ID)
+	if err != nil {
+		logging.Warning("Unable to read tokens for user %d: %v", auth.User.ID, err)
+		return status.Error(codes.Internal, "Error reading token list")
+	}
+	for _, v := range tokens {
+		if err := svr.Send(&apipb.DataDumpChunk{Token: apitoolbox.NewTokenFromModel(v)}); err != nil {
+			return err
+		}
+	}
+
+	collections, err := s.store.ListCollections(auth.User.ID)
+	if err != nil {
+		logging.Warning("Unable to read collections for user: %d: %v", auth.User.ID, err)
+		return status.Error(codes.Internal, "Error reading collection list")
+	}
+	for _, v := range collections {
+		// The device data is only included if the role in the team permits it
+		team := teamMap[v.TeamID]
+		collFilter := *filter
+		collFilter.CollectionId = v.ID.String()
+		if err := s.streamCollection(svr, auth.User, v, includeData && team.HasPermission(auth.User.ID, model.ReadDataPermission), &collFilter, pageSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamCollection streams the collection followed by the firmware, the
+// outputs and the devices in the collection. The devices are read one page at
+// a time.
+func (s *systemService) streamCollection(svr apipb.Horde_StreamDataDumpServer, user model.User, c model.Collection, includeData bool, filter *datastore.DataFilter, pageSize int) error {
+	if err := svr.Send(&apipb.DataDumpChunk{Collection: apitoolbox.NewCollectionFromModel(c)}); err != nil {
+		return err
+	}
+	firmware, err := s.store.ListFirmware(user.ID, c.ID)
+	if err != nil {
+		logging.Warning("Unable to read firmware for collection %d: %v", c.ID, err)
+		return status.Error(codes.Internal, "Error reading contents of collection")
+	}
+	for _, v := range firmware {
+		if err := svr.Send(&apipb.DataDumpChunk{Firmware: apitoolbox.NewFirmwareFromModel(v)}); err != nil {
+			return err
+		}
+	}
+	outputs, err := s.store.ListOutputs(user.ID, c.ID)
+	if err != nil {
+		logging.Warning("Unable to read outputs for collection %d: %v", c.ID, err)
+		return status.Error(codes.Internal, "Error reading contents of collection")
+	}
+	for _, v := range outputs {
+		if err := svr.Send(&apipb.DataDumpChunk{Output: apitoolbox.NewOutputFromModel(v)}); err != nil {
+			return err
+		}
+	}
+
+	opts := model.ListOptions{PageSize: dumpDevicePageSize}
+	for {
+		devices, next, err := s.store.ListDevicesPage(user.ID, c.ID, opts)
+		if err != nil {
+			logging.Warning("Unable to read devices for collection %d: %v", c.ID, err)
+			return status.Error(codes.Internal, "Error reading contents of collection")
+		}
+		for _, v := range devices {
+			if err := svr.Send(&apipb.DataDumpChunk{Device: apitoolbox.NewDeviceFromModel(v, c)}); err != nil {
+				return err
+			}
+			if !includeData {
+				continue
+			}
+			filter.DeviceId = v.ID.String()
+			if err := s.streamDeviceData(svr, c, v, filter, pageSize); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		opts.PageToken = next
+	}
+}
+
+// streamDeviceData streams the device data in pages
+func (s *systemService) streamDeviceData(svr apipb.Horde_StreamDataDumpServer, c model.Collection, d model.Device, filter *datastore.DataFilter, pageSize int) error {
+	newPage := func() *apipb.DumpedMessages {
+		return &apipb.DumpedMessages{
+			CollectionId: &wrappers.StringValue{Value: c.ID.String()},
+			DeviceId:     &wrappers.StringValue{Value: d.ID.String()},
+		}
+	}
+	page := newPage()
+	err := s.readDataForDevice(svr.Context(), filter, c.FieldMask, func(msg *apipb.OutputDataMessage) error {
+		page.Messages = append(page.Messages, msg)
+		if len(page.Messages) < pageSize {
+			return nil
+		}
+		if err := svr.Send(&apipb.DataDumpChunk{Messages: page}); err != nil {
+			return err
+		}
+		page = newPage()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(page.Messages) == 0 {
+		return nil
 	}
+	return svr.Send(&apipb.DataDumpChunk{Messages: page})
 }
 
 func (s *systemService) GetSystemInfo(ctx context.Context, req *apipb.SystemInfoRequest) (*apipb.SystemInfoResponse, error) {
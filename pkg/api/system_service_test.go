@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/TelenorDigital/goconnect"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/eesrc/horde/pkg/addons/magpie"
 	"github.com/eesrc/horde/pkg/addons/magpie/datastore"
 	"github.com/eesrc/horde/pkg/api/apipb"
@@ -33,6 +34,7 @@ import (
 	"github.com/eesrc/horde/pkg/utils/grpcutil"
 	"github.com/eesrc/horde/pkg/version"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -75,7 +77,7 @@ func TestSystemServiceInfo(t *testing.T) {
 
 	store := sqlstore.NewMemoryStore()
 
-	svc := newSystemService(model.FieldMaskParameters{Forced: "location", Default: ""}, store, dataStoreClient)
+	svc := newSystemService(model.FieldMaskParameters{Forced: "location", Default: ""}, store, dataStoreClient, nil)
 	assert.NotNil(svc)
 
 	resp, err := svc.GetSystemInfo(context.Background(), &apipb.SystemInfoRequest{})
@@ -92,7 +94,7 @@ func TestSystemServiceDump(t *testing.T) {
 
 	store := sqlstore.NewMemoryStore()
 
-	svc := newSystemService(model.FieldMaskParameters{Forced: "", Default: ""}, store, dataStoreClient)
+	svc := newSystemService(model.FieldMaskParameters{Forced: "", Default: ""}, store, dataStoreClient, nil)
 	assert.NotNil(svc)
 
 	// Attempt unauthenticated request - should return error
@@ -181,7 +183,7 @@ func TestSystemServiceDump(t *testing.T) {
 	assert.Len(resp.Collections, 10)
 	for _, v := range resp.Collections {
 		assert.Len(v.Outputs, 1)
-		//assert.Len(v.Firmwares, 10)
+		assert.Len(v.Firmware, 1)
 		assert.Len(v.Devices, 5)
 		for _, d := range v.Devices {
 			assert.Len(d.Data, 5)
@@ -194,7 +196,7 @@ func TestSystemServiceProfile(t *testing.T) {
 	assert := require.New(t)
 	store := sqlstore.NewMemoryStore()
 
-	svc := newSystemService(model.FieldMaskParameters{Forced: "", Default: ""}, store, dataStoreClient)
+	svc := newSystemService(model.FieldMaskParameters{Forced: "", Default: ""}, store, dataStoreClient, nil)
 	assert.NotNil(svc)
 
 	// Retrieve unauthenticated
@@ -206,6 +208,7 @@ func TestSystemServiceProfile(t *testing.T) {
 	user, token := createUserAndToken(assert, model.AuthInternal, store)
 	md := metadata.New(map[string]string{tokenHeaderName: token})
 	ctxInternal := metadata.NewIncomingContext(context.Background(), md)
+	ctxInternal = withRPCCall(ctxInternal, "/apipb.Horde/GetUserProfile", &apipb.UserProfileRequest{})
 
 	res, err := svc.GetUserProfile(ctxInternal, &apipb.UserProfileRequest{})
 	assert.NoError(err)
@@ -236,3 +239,122 @@ func TestSystemServiceProfile(t *testing.T) {
 	assert.Equal("github", res.Provider.Value)
 
 }
+
+type dumpStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*apipb.DataDumpChunk
+}
+
+func (d *dumpStream) Context() context.Context {
+	return d.ctx
+}
+
+func (d *dumpStream) Send(chunk *apipb.DataDumpChunk) error {
+	d.chunks = append(d.chunks, chunk)
+	return nil
+}
+
+func TestSystemServiceStreamDump(t *testing.T) {
+	assert := require.New(t)
+
+	store := sqlstore.NewMemoryStore()
+	dataStoreClient := newTestDataStore(assert, "streamdump")
+	svc := newSystemService(model.FieldMaskParameters{}, store, dataStoreClient, nil)
+
+	assert.Equal(codes.Unauthenticated, status.Code(svc.StreamDataDump(&apipb.StreamDataDumpRequest{}, &dumpStream{ctx: context.Background()})))
+
+	user, _, ctx := createAuthenticatedContext(assert, store)
+
+	c := model.NewCollection()
+	c.ID = store.NewCollectionID()
+	c.TeamID = user.PrivateTeamID
+	assert.NoError(store.CreateCollection(user.ID, c))
+
+	o := model.NewOutput()
+	o.ID = store.NewOutputID()
+	o.CollectionID = c.ID
+	o.Type = "udp"
+	assert.NoError(store.CreateOutput(user.ID, o))
+
+	d := model.NewDevice()
+	d.ID = store.NewDeviceID()
+	d.CollectionID = c.ID
+	d.IMSI = 4711
+	d.IMEI = 4712
+	assert.NoError(store.CreateDevice(user.ID, d))
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 25; i++ {
+		_, err := dataStoreClient.StoreData(context.Background(), &datastore.DataMessage{
+			Sequence:     int64(i),
+			CollectionId: c.ID.String(),
+			DeviceId:     d.ID.String(),
+			Created:      start.Add(time.Duration(i) * time.Minute).UnixNano(),
+			Metadata:     []byte(`{"transport":"udp"}`),
+			Payload:      []byte(fmt.Sprintf("message %d", i)),
+		})
+		assert.NoError(err)
+	}
+
+	assert.Equal(codes.InvalidArgument, status.Code(svc.StreamDataDump(&apipb.StreamDataDumpRequest{
+		PageSize: &wrappers.Int32Value{Value: 0},
+	}, &dumpStream{ctx: ctx})))
+
+	// Count the chunks of each type
+	count := func(chunks []*apipb.DataDumpChunk) (profiles, teams, collections, outputs, devices, pages, messages int) {
+		for _, v := range chunks {
+			switch {
+			case v.Profile != nil:
+				profiles++
+			case v.Team != nil:
+				teams++
+			case v.Collection != nil:
+				collections++
+			case v.Output != nil:
+				outputs++
+			case v.Device != nil:
+				devices++
+			case v.Messages != nil:
+				pages++
+				messages += len(v.Messages.Messages)
+			}
+		}
+		return
+	}
+
+	stream := &dumpStream{ctx: ctx}
+	assert.NoError(svc.StreamDataDump(&apipb.StreamDataDumpRequest{
+		PageSize: &wrappers.Int32Value{Value: 10},
+	}, stream))
+	assert.NotNil(stream.chunks[0].Profile)
+	profiles, teams, collections, outputs, devices, pages, messages := count(stream.chunks)
+	assert.Equal(1, profiles)
+	assert.Equal(1, teams)
+	assert.Equal(1, collections)
+	assert.Equal(1, outputs)
+	assert.Equal(1, devices)
+	assert.Equal(3, pages)
+	assert.Equal(25, messages)
+	assert.NotNil(stream.chunks[len(stream.chunks)-1].Messages)
+	assert.Equal(d.ID.String(), stream.chunks[len(stream.chunks)-1].Messages.DeviceId.Value)
+
+	// Limit the data to a time window
+	stream = &dumpStream{ctx: ctx}
+	assert.NoError(svc.StreamDataDump(&apipb.StreamDataDumpRequest{
+		Since: &wrappers.Int64Value{Value: start.Add(10*time.Minute).UnixNano() / int64(time.Millisecond)},
+		Until: &wrappers.Int64Value{Value: start.Add(14*time.Minute).UnixNano() / int64(time.Millisecond)},
+	}, stream))
+	_, _, _, _, _, pages, messages = count(stream.chunks)
+	assert.Equal(1, pages)
+	assert.Equal(5, messages)
+
+	// Skip the data
+	stream = &dumpStream{ctx: ctx}
+	assert.NoError(svc.StreamDataDump(&apipb.StreamDataDumpRequest{
+		IncludeData: &wrappers.BoolValue{Value: false},
+	}, stream))
+	_, _, _, _, devices, pages, _ = count(stream.chunks)
+	assert.Equal(1, devices)
+	assert.Equal(0, pages)
+}
@@ -47,6 +47,10 @@ type taggedService interface {
 	LoadTaggedResource(auth *authResult, collectionID string, identifier string) (taggedResource, error)
 
 	UpdateResourceTags(id model.UserKey, collectionID string, identifier string, res interface{}) error
+
+	// Ensure the user is allowed to change the tags on a resource loaded by
+	// LoadTaggedResource. The error is returned as is.
+	EnsureTagUpdate(auth *authResult, res taggedResource) error
 }
 
 func listTags(ctx context.Context, req *apipb.TagRequest, svc taggedService) (*apipb.TagResponse, error) {
@@ -94,6 +98,9 @@ func updateTags(ctx context.Context, req *apipb.UpdateTagRequest, svc taggedServ
 	if err != nil {
 		return nil, err
 	}
+	if err := svc.EnsureTagUpdate(auth, res); err != nil {
+		return nil, err
+	}
 	for k, v := range req.Tags {
 		if !res.IsValidTag(k, v) {
 			return nil, status.Error(codes.InvalidArgument, "Invalid key/value for tag")
@@ -163,6 +170,9 @@ func updateTag(ctx context.Context, req *apipb.TagRequest, svc taggedService) (*
 	if err != nil {
 		return nil, err
 	}
+	if err := svc.EnsureTagUpdate(auth, res); err != nil {
+		return nil, err
+	}
 	newVal := strings.TrimSpace(req.Value.Value)
 	if !res.IsValidTag(strings.TrimSpace(req.Name.Value), newVal) {
 		return nil, status.Error(codes.InvalidArgument, "Invalid key/value for tag")
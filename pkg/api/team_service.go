@@ -17,6 +17,7 @@ package api
 //
 import (
 	"context"
+	"time"
 
 	"github.com/ExploratoryEngineering/logging"
 	"github.com/eesrc/horde/pkg/api/apipb"
@@ -85,6 +86,11 @@ func (s *teamService) LoadTaggedResource(auth *authResult, collectionID, identif
 	return &team, nil
 }
 
+func (s *teamService) EnsureTagUpdate(auth *authResult, res taggedResource) error {
+	team := res.(*model.Team)
+	return apitoolbox.CheckPermission(*team, auth.User.ID, model.ManageTeamPermission)
+}
+
 func (s *teamService) UpdateResourceTags(id model.UserKey, collectionID, identifier string, res interface{}) error {
 	team := res.(*model.Team)
 	return s.store.UpdateTeamTags(id, identifier, team.Tags)
@@ -127,7 +133,21 @@ func (s *teamService) RetrieveTeam(ctx context.Context, req *apipb.TeamRequest)
 	if err != nil {
 		return nil, err
 	}
-	return apitoolbox.NewTeamFromModel(team, true), nil
+	ret := apitoolbox.NewTeamFromModel(team, true)
+
+	quota, err := s.store.RetrieveTeamQuota(team.ID)
+	if err != nil {
+		logging.Warning("Error retrieving quota for team %d: %v", team.ID, err)
+		return nil, status.Error(codes.Internal, "Unable to retrieve team quota")
+	}
+	usage, err := s.store.RetrieveTeamUsage(team.ID, time.Now())
+	if err != nil {
+		logging.Warning("Error retrieving resource usage for team %d: %v", team.ID, err)
+		return nil, status.Error(codes.Internal, "Unable to retrieve team usage")
+	}
+	ret.Quota = apitoolbox.NewTeamQuotaFromModel(quota)
+	ret.Usage = apitoolbox.NewTeamUsageFromModel(usage)
+	return ret, nil
 }
 
 func (s *teamService) RetrieveTeamMembers(ctx context.Context, req *apipb.TeamRequest) (*apipb.MemberList, error) {
@@ -183,6 +203,9 @@ func (s *teamService) UpdateMember(ctx context.Context, req *apipb.Member) (*api
 		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
 	}
 	roleID := model.NewRoleIDFromString(req.Role.Value)
+	if err := apitoolbox.CheckPermission(team, auth.User.ID, model.ManageTeamPermission); err != nil {
+		return nil, err
+	}
 
 	if userID == auth.User.ID {
 		return nil, status.Error(codes.PermissionDenied, "You are not allowed to change your own membershi")
@@ -212,6 +235,9 @@ func (s *teamService) DeleteMember(ctx context.Context, req *apipb.MemberRequest
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
 	}
+	if err := apitoolbox.CheckPermission(team, auth.User.ID, model.ManageTeamPermission); err != nil {
+		return nil, err
+	}
 	if userID == auth.User.ID {
 		return nil, status.Error(codes.PermissionDenied, "You are not allowed to change your own membershi")
 	}
@@ -237,6 +263,10 @@ func (s *teamService) UpdateTeam(ctx context.Context, req *apipb.Team) (*apipb.T
 		return nil, err
 	}
 
+	if err := apitoolbox.CheckPermission(team, auth.User.ID, model.ManageTeamPermission); err != nil {
+		return nil, err
+	}
+
 	// Team update is basically just the tags
 	if req.Tags != nil {
 		for k, v := range req.Tags {
@@ -269,6 +299,9 @@ func (s *teamService) DeleteTeam(ctx context.Context, req *apipb.TeamRequest) (*
 	if team.ID == auth.User.PrivateTeamID {
 		return nil, status.Error(codes.PermissionDenied, "You can't remove your private team")
 	}
+	if err := apitoolbox.CheckPermission(team, auth.User.ID, model.ManageTeamPermission); err != nil {
+		return nil, err
+	}
 
 	if err := s.store.DeleteTeam(auth.User.ID, team.ID); err != nil {
 		return nil, status.Error(codes.Internal, "Unable to remove team")
@@ -312,6 +345,9 @@ func (s *teamService) GenerateInvite(ctx context.Context, req *apipb.InviteReque
 	if auth.User.PrivateTeamID == team.ID {
 		return nil, status.Error(codes.InvalidArgument, "Can't invite people to private team")
 	}
+	if err := apitoolbox.CheckPermission(team, auth.User.ID, model.ManageTeamPermission); err != nil {
+		return nil, err
+	}
 
 	invite, err := model.NewInvite(auth.User.ID, team.ID)
 	if err != nil {
@@ -364,8 +400,8 @@ func (s *teamService) ListInvites(ctx context.Context, req *apipb.TeamRequest) (
 		return nil, err
 	}
 
-	if !team.IsAdmin(auth.User.ID) {
-		return nil, status.Error(codes.PermissionDenied, "Must be administrator to list invites")
+	if err := apitoolbox.CheckPermission(team, auth.User.ID, model.ManageTeamPermission); err != nil {
+		return nil, err
 	}
 
 	invites, err := s.store.ListInvites(team.ID, auth.User.ID)
@@ -392,8 +428,8 @@ func (s *teamService) RetrieveInvite(ctx context.Context, req *apipb.InviteReque
 	if err != nil {
 		return nil, err
 	}
-	if !team.IsAdmin(auth.User.ID) {
-		return nil, status.Error(codes.PermissionDenied, "Must be administrator to see invites")
+	if err := apitoolbox.CheckPermission(team, auth.User.ID, model.ManageTeamPermission); err != nil {
+		return nil, err
 	}
 
 	invites, err := s.store.ListInvites(team.ID, auth.User.ID)
@@ -419,8 +455,8 @@ func (s *teamService) DeleteInvite(ctx context.Context, req *apipb.InviteRequest
 	if err != nil {
 		return nil, err
 	}
-	if !team.IsAdmin(auth.User.ID) {
-		return nil, status.Error(codes.PermissionDenied, "Must be administrator to see invites")
+	if err := apitoolbox.CheckPermission(team, auth.User.ID, model.ManageTeamPermission); err != nil {
+		return nil, err
 	}
 
 	// Return error unless there's no error or ErrNotFound is returned.
@@ -452,3 +488,117 @@ func (s *teamService) DeleteTeamTag(ctx context.Context, req *apipb.TagRequest)
 func (s *teamService) UpdateTeamTag(ctx context.Context, req *apipb.TagRequest) (*apipb.TagValueResponse, error) {
 	return updateTag(ctx, req, s)
 }
+
+const (
+	// defaultUsageRange is the default time range for usage requests
+	defaultUsageRange = 30 * 24 * time.Hour
+	// maxUsageRange is the longest time range for usage requests
+	maxUsageRange = 366 * 24 * time.Hour
+)
+
+func (s *teamService) Usage(ctx context.Context, req *apipb.UsageRequest) (*apipb.UsageResponse, error) {
+	if req == nil || req.TeamId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing team ID")
+	}
+	_, team, err := s.authAndLoadTeam(ctx, req.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	var collectionID model.CollectionKey
+	if req.CollectionId != nil {
+		if collectionID, err = model.NewCollectionKeyFromString(req.CollectionId.Value); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid collection ID")
+		}
+	}
+
+	until := time.Now()
+	if req.Until != nil {
+		until = apitoolbox.MillisToTime(req.Until.Value)
+	}
+	since := until.Add(-defaultUsageRange)
+	if req.Since != nil {
+		since = apitoolbox.MillisToTime(req.Since.Value)
+	}
+	if !since.Before(until) {
+		return nil, status.Error(codes.InvalidArgument, "Start time must be before end time")
+	}
+	if until.Sub(since) > maxUsageRange {
+		return nil, status.Error(codes.InvalidArgument, "Time range can't exceed 366 days")
+	}
+
+	records, err := s.store.RetrieveUsage(team.ID, model.UsageHour(since), until)
+	if err != nil {
+		logging.Warning("Error retrieving usage for team %d: %v", team.ID, err)
+		return nil, status.Error(codes.Internal, "Unable to retrieve usage")
+	}
+
+	ret := &apipb.UsageResponse{
+		TeamId:  &wrappers.StringValue{Value: team.ID.String()},
+		Records: make([]*apipb.UsageRecord, 0),
+	}
+	total := model.Usage{}
+	for _, v := range records {
+		if req.CollectionId != nil && v.CollectionID != collectionID {
+			continue
+		}
+		total.Add(v)
+		ret.Records = append(ret.Records, apitoolbox.NewUsageRecordFromModel(v))
+	}
+	ret.Total = apitoolbox.NewUsageRecordFromModel(total)
+	return ret, nil
+}
+
+const (
+	// defaultAuditLimit is the default number of audit events returned
+	defaultAuditLimit = 100
+	// maxAuditLimit is the maximum number of audit events returned
+	maxAuditLimit = 1000
+)
+
+func (s *teamService) ListAuditEvents(ctx context.Context, req *apipb.ListAuditEventsRequest) (*apipb.ListAuditEventsResponse, error) {
+	if req == nil || req.TeamId == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing team ID")
+	}
+	auth, team, err := s.authAndLoadTeam(ctx, req.TeamId)
+	if err != nil {
+		return nil, err
+	}
+	if err := apitoolbox.CheckPermission(team, auth.User.ID, model.ManageTeamPermission); err != nil {
+		return nil, err
+	}
+
+	limit := defaultAuditLimit
+	if req.Limit != nil {
+		limit = int(req.Limit.Value)
+		if limit <= 0 || limit > maxAuditLimit {
+			return nil, status.Errorf(codes.InvalidArgument, "Limit must be between 1 and %d", maxAuditLimit)
+		}
+	}
+	var before model.AuditKey
+	if req.Before != nil {
+		if before, err = model.NewAuditKeyFromString(req.Before.Value); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid event ID")
+		}
+	}
+
+	events, err := s.store.ListAuditEvents(team.ID, before, limit)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Unknown event")
+		}
+		logging.Warning("Error listing audit events for team %d: %v", team.ID, err)
+		return nil, status.Error(codes.Internal, "Unable to list audit events")
+	}
+	ret := &apipb.ListAuditEventsResponse{
+		TeamId: &wrappers.StringValue{Value: team.ID.String()},
+		Events: make([]*apipb.AuditEvent, 0),
+	}
+	for _, v := range events {
+		ret.Events = append(ret.Events, apitoolbox.NewAuditEventFromModel(v))
+	}
+	if len(events) == limit {
+		ret.Next = &wrappers.StringValue{Value: events[len(events)-1].ID.String()}
+	}
+	return ret, nil
+}
@@ -18,9 +18,12 @@ package api
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
+	"github.com/eesrc/horde/pkg/storage/fwimage"
 	"github.com/eesrc/horde/pkg/storage/sqlstore"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/require"
@@ -651,3 +654,188 @@ func TestTeamTags(t *testing.T) {
 		UpdateTags: teamService.UpdateTeamTags,
 	})
 }
+
+// Test the viewer, operator and firmware manager roles across the services.
+func TestTeamRoles(t *testing.T) {
+	assert := require.New(t)
+	store := sqlstore.NewMemoryStore()
+	imageStore, err := fwimage.NewSQLStore(sqlstore.Parameters{
+		Type:             "sqlite3",
+		ConnectionString: "file::memory:",
+		CreateSchema:     true,
+	})
+	assert.NoError(err)
+
+	collectionService := newCollectionService(store, model.FieldMaskParameters{}, output.NewDummyManager(), newDummyDataStoreClient(), newDummyMessageSender())
+	deviceService := newDeviceService(store, newDummyDataStoreClient(), newDummyMessageSender(), output.NewDummyManager())
+	outputService := newOutputService(store, output.NewDummyManager(), model.FieldMaskParameters{}, newDummyDataStoreClient())
+	firmwareService := newFirmwareService(store, imageStore)
+	tokenService := newTokenService(store)
+	teamService := newTeamService(store)
+
+	admin, _, adminCtx := createAuthenticatedContext(assert, store)
+	viewer, _, viewerCtx := createAuthenticatedContext(assert, store)
+	operator, _, operatorCtx := createAuthenticatedContext(assert, store)
+	fwManager, _, fwManagerCtx := createAuthenticatedContext(assert, store)
+
+	team := model.NewTeam()
+	team.ID = store.NewTeamID()
+	team.AddMember(model.NewMember(admin, model.AdminRole))
+	team.AddMember(model.NewMember(viewer, model.ViewerRole))
+	team.AddMember(model.NewMember(operator, model.OperatorRole))
+	team.AddMember(model.NewMember(fwManager, model.FirmwareManagerRole))
+	assert.NoError(store.CreateTeam(team))
+
+	collection := model.NewCollection()
+	collection.ID = store.NewCollectionID()
+	collection.TeamID = team.ID
+	assert.NoError(store.CreateCollection(admin.ID, collection))
+
+	device := model.NewDevice()
+	device.ID = store.NewDeviceID()
+	device.CollectionID = collection.ID
+	device.IMSI = 1
+	device.IMEI = 1
+	assert.NoError(store.CreateDevice(admin.ID, device))
+
+	cid := &wrappers.StringValue{Value: collection.ID.String()}
+	did := &wrappers.StringValue{Value: device.ID.String()}
+
+	assertDenied := func(err error) {
+		assert.Error(err)
+		assert.Equal(codes.PermissionDenied.String(), status.Code(err).String())
+	}
+
+	// Everyone can see the resources
+	for _, ctx := range []context.Context{viewerCtx, operatorCtx, fwManagerCtx} {
+		_, err := deviceService.RetrieveDevice(ctx, &apipb.DeviceRequest{CollectionId: cid, DeviceId: did})
+		assert.NoError(err)
+	}
+
+	// The viewer can't read the payloads, send messages or change anything
+	_, err = deviceService.ListDeviceMessages(viewerCtx, &apipb.ListMessagesRequest{CollectionId: cid, DeviceId: did})
+	assertDenied(err)
+	_, err = collectionService.ListCollectionMessages(viewerCtx, &apipb.ListMessagesRequest{CollectionId: cid})
+	assertDenied(err)
+	_, err = deviceService.SendMessage(viewerCtx, &apipb.SendMessageRequest{CollectionId: cid, DeviceId: did, Port: &wrappers.Int32Value{Value: 4711}, Payload: []byte("hello")})
+	assertDenied(err)
+	_, err = deviceService.UpdateDeviceTag(viewerCtx, &apipb.TagRequest{CollectionId: cid, Identifier: did,
+		Name: &wrappers.StringValue{Value: "name"}, Value: &wrappers.StringValue{Value: "value"}})
+	assertDenied(err)
+
+	// The operator can manage devices and send messages...
+	_, err = deviceService.ListDeviceMessages(operatorCtx, &apipb.ListMessagesRequest{CollectionId: cid, DeviceId: did})
+	assert.NoError(err)
+	_, err = deviceService.SendMessage(operatorCtx, &apipb.SendMessageRequest{CollectionId: cid, DeviceId: did, Port: &wrappers.Int32Value{Value: 4711}, Payload: []byte("hello")})
+	assert.NoError(err)
+	newDevice, err := deviceService.CreateDevice(operatorCtx, &apipb.Device{CollectionId: cid,
+		Imsi: &wrappers.StringValue{Value: "2"}, Imei: &wrappers.StringValue{Value: "2"}})
+	assert.NoError(err)
+	_, err = deviceService.UpdateDeviceTag(operatorCtx, &apipb.TagRequest{CollectionId: cid, Identifier: newDevice.DeviceId,
+		Name: &wrappers.StringValue{Value: "name"}, Value: &wrappers.StringValue{Value: "value"}})
+	assert.NoError(err)
+
+	// ...but not outputs, tokens, collections or firmware
+	_, err = outputService.CreateOutput(operatorCtx, &apipb.Output{CollectionId: cid, Type: apipb.Output_webhook, Config: &apipb.OutputConfig{}})
+	assertDenied(err)
+	_, err = tokenService.CreateToken(operatorCtx, &apipb.Token{
+		Resource: &wrappers.StringValue{Value: "/collections/" + collection.ID.String()},
+		Write:    &wrappers.BoolValue{Value: true},
+	})
+	assertDenied(err)
+	_, err = collectionService.UpdateCollection(operatorCtx, &apipb.Collection{CollectionId: cid, Tags: map[string]string{"name": "op"}})
+	assertDenied(err)
+	_, err = firmwareService.CreateFirmware(operatorCtx, &apipb.CreateFirmwareRequest{CollectionId: cid,
+		Image: []byte("image"), Filename: &wrappers.StringValue{Value: "image.bin"}})
+	assertDenied(err)
+	_, err = teamService.GenerateInvite(operatorCtx, &apipb.InviteRequest{TeamId: &wrappers.StringValue{Value: team.ID.String()}})
+	assertDenied(err)
+
+	// The firmware manager can upload images and change the firmware
+	// settings but not the devices
+	_, err = firmwareService.CreateFirmware(fwManagerCtx, &apipb.CreateFirmwareRequest{CollectionId: cid,
+		Image: []byte("image"), Filename: &wrappers.StringValue{Value: "image.bin"}})
+	assert.NoError(err)
+	_, err = collectionService.UpdateCollection(fwManagerCtx, &apipb.Collection{CollectionId: cid,
+		Firmware: &apipb.CollectionFirmware{Management: apipb.CollectionFirmware_device}})
+	assert.NoError(err)
+	_, err = collectionService.UpdateCollection(fwManagerCtx, &apipb.Collection{CollectionId: cid,
+		Firmware: &apipb.CollectionFirmware{Management: apipb.CollectionFirmware_device}, Tags: map[string]string{"name": "fw"}})
+	assertDenied(err)
+	_, err = deviceService.DeleteDevice(fwManagerCtx, &apipb.DeviceRequest{CollectionId: cid, DeviceId: did})
+	assertDenied(err)
+
+	// The administrator can do everything
+	_, err = tokenService.CreateToken(adminCtx, &apipb.Token{
+		Resource: &wrappers.StringValue{Value: "/collections/" + collection.ID.String()},
+		Write:    &wrappers.BoolValue{Value: true},
+	})
+	assert.NoError(err)
+	_, err = deviceService.DeleteDevice(adminCtx, &apipb.DeviceRequest{CollectionId: cid, DeviceId: did})
+	assert.NoError(err)
+}
+
+func TestTeamUsage(t *testing.T) {
+	assert := require.New(t)
+	store := sqlstore.NewMemoryStore()
+	teamService := newTeamService(store)
+	user, _, ctx := createAuthenticatedContext(assert, store)
+
+	c1 := model.NewCollection()
+	c1.ID = store.NewCollectionID()
+	c1.TeamID = user.PrivateTeamID
+	assert.NoError(store.CreateCollection(user.ID, c1))
+	c2 := model.NewCollection()
+	c2.ID = store.NewCollectionID()
+	c2.TeamID = user.PrivateTeamID
+	assert.NoError(store.CreateCollection(user.ID, c2))
+
+	hour := model.UsageHour(time.Now())
+	assert.NoError(store.AddUsage([]model.Usage{
+		{CollectionID: c1.ID, TeamID: user.PrivateTeamID, Hour: hour, Transport: "udp", UplinkMessages: 2, UplinkBytes: 20},
+		{CollectionID: c2.ID, TeamID: user.PrivateTeamID, Hour: hour, Transport: "udp", DownlinkMessages: 1, DownlinkBytes: 5},
+		{CollectionID: c1.ID, TeamID: user.PrivateTeamID, Hour: hour.Add(-48 * time.Hour), Transport: "", OutputDeliveries: 3},
+	}))
+
+	teamID := &wrappers.StringValue{Value: user.PrivateTeamID.String()}
+
+	_, err := teamService.Usage(context.Background(), &apipb.UsageRequest{TeamId: teamID})
+	assert.Equal(codes.Unauthenticated.String(), status.Code(err).String())
+
+	_, err = teamService.Usage(ctx, &apipb.UsageRequest{})
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+
+	// The default range is the last 30 days
+	res, err := teamService.Usage(ctx, &apipb.UsageRequest{TeamId: teamID})
+	assert.NoError(err)
+	assert.Len(res.Records, 3)
+	assert.Equal(int64(2), res.Total.UplinkMessages.Value)
+	assert.Equal(int64(5), res.Total.DownlinkBytes.Value)
+	assert.Equal(int64(3), res.Total.OutputDeliveries.Value)
+	assert.Nil(res.Total.CollectionId)
+
+	// Filter on collection and time
+	since := hour.Add(-time.Hour).UnixNano() / int64(time.Millisecond)
+	res, err = teamService.Usage(ctx, &apipb.UsageRequest{
+		TeamId:       teamID,
+		CollectionId: &wrappers.StringValue{Value: c1.ID.String()},
+		Since:        &wrappers.Int64Value{Value: since},
+	})
+	assert.NoError(err)
+	assert.Len(res.Records, 1)
+	assert.Equal(c1.ID.String(), res.Records[0].CollectionId.Value)
+	assert.Equal(hour.UnixNano()/int64(time.Millisecond), res.Records[0].Hour.Value)
+
+	// Invalid ranges are rejected
+	_, err = teamService.Usage(ctx, &apipb.UsageRequest{
+		TeamId: teamID,
+		Since:  &wrappers.Int64Value{Value: since},
+		Until:  &wrappers.Int64Value{Value: since},
+	})
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+	_, err = teamService.Usage(ctx, &apipb.UsageRequest{
+		TeamId: teamID,
+		Since:  &wrappers.Int64Value{Value: 1},
+	})
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+}
@@ -0,0 +1,391 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ErrTokenExpired is returned by LookupToken when the token has expired
+var ErrTokenExpired = errors.New("token has expired")
+
+// LookupToken retrieves an API token from the store. The token is looked up
+// by its hash. If the token has expired ErrTokenExpired is returned.
+func LookupToken(store storage.DataStore, value string) (model.Token, error) {
+	token, err := store.RetrieveToken(model.TokenID(value))
+	if err != nil {
+		return token, err
+	}
+	if token.Expired(time.Now()) {
+		return token, ErrTokenExpired
+	}
+	return token, nil
+}
+
+// TokenAllows checks if the token grants access to a resource. Resources are
+// the paths in the REST API and the token's resource is a path prefix, ie
+// a token for /collections/1 grants access to /collections/1/devices but not
+// to /collections/10. Read-only tokens can't be used for writes.
+func TokenAllows(token model.Token, resource string, write bool) bool {
+	if write && !token.Write {
+		return false
+	}
+	prefix := strings.TrimSuffix(token.Resource, "/")
+	return prefix == "" || resource == prefix || strings.HasPrefix(resource, prefix+"/")
+}
+
+// rpcScope is the resource and access for a RPC method. The resources are
+// the REST API paths for the method so tokens are evaluated the same way for
+// the REST API and the gRPC API. The path parameters are the field names in
+// the request. If a method has more than one resource the first one where all
+// of the fields are set is used.
+type rpcScope struct {
+	Write     bool
+	Resources []string
+}
+
+// hordeServiceName is the full name of the Horde gRPC service
+const hordeServiceName = "/apipb.Horde/"
+
+// rpcScopes is the scope for every method in the Horde service. Methods that
+// aren't in this list can't be used with API tokens.
+var rpcScopes = map[string]rpcScope{
+	"CreateCollection":       {true, []string{"/collections"}},
+	"UpdateCollection":       {true, []string{"/collections/{collection_id}"}},
+	"DeleteCollection":       {true, []string{"/collections/{collection_id}"}},
+	"ListCollections":        {false, []string{"/collections"}},
+	"RetrieveCollection":     {false, []string{"/collections/{collection_id}"}},
+	"ListCollectionMessages": {false, []string{"/collections/{collection_id}/data"}},
+	"BroadcastMessage":       {true, []string{"/collections/{collection_id}/to"}},
+	"MessageStream":          {false, []string{"/collections/{collection_id}/devices/{device_id}/from", "/collections/{collection_id}/from"}},
+	"ListCollectionTags":     {false, []string{"/collections/{collection_id}/tags"}},
+	"UpdateCollectionTags":   {true, []string{"/collections/{collection_id}/tags"}},
+	"GetCollectionTag":       {false, []string{"/collections/{collection_id}/tags/{name}"}},
+	"DeleteCollectionTag":    {true, []string{"/collections/{collection_id}/tags/{name}"}},
+	"UpdateCollectionTag":    {true, []string{"/collections/{collection_id}/tags/{name}"}},
+
+	"CreateDevice":       {true, []string{"/collections/{collection_id}/devices"}},
+	"RetrieveDevice":     {false, []string{"/collections/{collection_id}/devices/{device_id}"}},
+	"UpdateDevice":       {true, []string{"/collections/{existing_collection_id}/devices/{device_id}"}},
+	"DeleteDevice":       {true, []string{"/collections/{collection_id}/devices/{device_id}"}},
+	"ListDevices":        {false, []string{"/collections/{collection_id}/devices"}},
+	"ImportDevices":      {true, []string{"/collections/{collection_id}/import"}},
+	"ExportDevices":      {false, []string{"/collections/{collection_id}/export"}},
+	"ListDeviceMessages": {false, []string{"/collections/{collection_id}/devices/{device_id}/data"}},
+	"SendMessage":        {true, []string{"/collections/{collection_id}/devices/{device_id}/to"}},
+	"ClearFirmwareError": {true, []string{"/collections/{collection_id}/devices/{device_id}/fwerror"}},
+	"ListDeviceTags":     {false, []string{"/collections/{collection_id}/devices/{identifier}/tags"}},
+	"UpdateDeviceTags":   {true, []string{"/collections/{collection_id}/devices/{identifier}/tags"}},
+	"GetDeviceTag":       {false, []string{"/collections/{collection_id}/devices/{identifier}/tags/{name}"}},
+	"DeleteDeviceTag":    {true, []string{"/collections/{collection_id}/devices/{identifier}/tags/{name}"}},
+	"UpdateDeviceTag":    {true, []string{"/collections/{collection_id}/devices/{identifier}/tags/{name}"}},
+
+	"CreateFirmware":             {true, []string{"/collections/{collection_id}/firmware"}},
+	"RetrieveFirmware":           {false, []string{"/collections/{collection_id}/firmware/{image_id}"}},
+	"UpdateFirmware":             {true, []string{"/collections/{collection_id}/firmware/{image_id}"}},
+	"DeleteFirmware":             {true, []string{"/collections/{collection_id}/firmware/{image_id}"}},
+	"ListFirmware":               {false, []string{"/collections/{collection_id}/firmware"}},
+	"FirmwareUsage":              {true, []string{"/collections/{collection_id}/firmware/{image_id}/usage"}},
+	"CheckFirmwareCompatibility": {false, []string{"/collections/{collection_id}/firmware/{image_id}/compatibility"}},
+	"ListFirmwareHistory":        {false, []string{"/collections/{collection_id}/firmware/{image_id}/history"}},
+	"ListDeviceFirmwareHistory":  {false, []string{"/collections/{collection_id}/devices/{device_id}/firmware/history"}},
+	"ListFirmwareTags":           {false, []string{"/collections/{collection_id}/firmware/{identifier}/tags"}},
+	"UpdateFirmwareTags":         {true, []string{"/collections/{collection_id}/firmware/{identifier}/tags"}},
+	"GetFirmwareTag":             {false, []string{"/collections/{collection_id}/firmware/{identifier}/tags/{name}"}},
+	"DeleteFirmwareTag":          {true, []string{"/collections/{collection_id}/firmware/{identifier}/tags/{name}"}},
+	"UpdateFirmwareTag":          {true, []string{"/collections/{collection_id}/firmware/{identifier}/tags/{name}"}},
+	"CreateSigningKey":           {true, []string{"/collections/{collection_id}/signingkeys"}},
+	"RetrieveSigningKey":         {false, []string{"/collections/{collection_id}/signingkeys/{key_id}"}},
+	"ListSigningKeys":            {false, []string{"/collections/{collection_id}/signingkeys"}},
+	"DeleteSigningKey":           {true, []string{"/collections/{collection_id}/signingkeys/{key_id}"}},
+
+	"CreateOutput":     {true, []string{"/collections/{collection_id}/outputs"}},
+	"RetrieveOutput":   {false, []string{"/collections/{collection_id}/outputs/{output_id}"}},
+	"UpdateOutput":     {true, []string{"/collections/{collection_id}/outputs/{output_id}"}},
+	"DeleteOutput":     {true, []string{"/collections/{collection_id}/outputs/{output_id}"}},
+	"ListOutputs":      {false, []string{"/collections/{collection_id}/outputs"}},
+	"Logs":             {false, []string{"/collections/{collection_id}/outputs/{output_id}/logs"}},
+	"Status":           {false, []string{"/collections/{collection_id}/outputs/{output_id}/status"}},
+	"TestOutput":       {true, []string{"/collections/{collection_id}/outputs/test"}},
+	"ListOutputTags":   {false, []string{"/collections/{collection_id}/outputs/{identifier}/tags"}},
+	"UpdateOutputTags": {true, []string{"/collections/{collection_id}/outputs/{identifier}/tags"}},
+	"GetOutputTag":     {false, []string{"/collections/{collection_id}/outputs/{identifier}/tags/{name}"}},
+	"DeleteOutputTag":  {true, []string{"/collections/{collection_id}/outputs/{identifier}/tags/{name}"}},
+	"UpdateOutputTag":  {true, []string{"/collections/{collection_id}/outputs/{identifier}/tags/{name}"}},
+
+	"GetSystemInfo":  {false, []string{"/system"}},
+	"DataDump":       {true, []string{"/datadump"}},
+	"DataRestore":    {true, []string{"/datarestore"}},
+	"StreamDataDump": {false, []string{"/datadump/stream"}},
+	"GetUserProfile": {false, []string{"/profile"}},
+
+	"CreateTeam":          {true, []string{"/teams"}},
+	"RetrieveTeam":        {false, []string{"/teams/{team_id}"}},
+	"RetrieveTeamMembers": {false, []string{"/teams/{team_id}/members"}},
+	"RetrieveMember":      {false, []string{"/teams/{team_id}/members/{user_id}"}},
+	"UpdateMember":        {true, []string{"/teams/{team_id}/members/{user_id}"}},
+	"DeleteMember":        {true, []string{"/teams/{team_id}/members/{user_id}"}},
+	"UpdateTeam":          {true, []string{"/teams/{team_id}"}},
+	"DeleteTeam":          {true, []string{"/teams/{team_id}"}},
+	"ListTeams":           {false, []string{"/teams"}},
+	"Usage":               {false, []string{"/teams/{team_id}/usage"}},
+	"ListAuditEvents":     {false, []string{"/teams/{team_id}/audit"}},
+	"GenerateInvite":      {true, []string{"/teams/{team_id}/invites"}},
+	"ListInvites":         {false, []string{"/teams/{team_id}/invites"}},
+	"RetrieveInvite":      {false, []string{"/teams/{team_id}/invites/{code}"}},
+	"AcceptInvite":        {true, []string{"/teams/accept"}},
+	"DeleteInvite":        {true, []string{"/teams/{team_id}/invites/{code}"}},
+	"ListTeamTags":        {false, []string{"/teams/{identifier}/tags"}},
+	"UpdateTeamTags":      {true, []string{"/teams/{identifier}/tags"}},
+	"GetTeamTag":          {false, []string{"/teams/{identifier}/tags/{name}"}},
+	"DeleteTeamTag":       {true, []string{"/teams/{identifier}/tags/{name}"}},
+	"UpdateTeamTag":       {true, []string{"/teams/{identifier}/tags/{name}"}},
+
+	"CreateToken":     {true, []string{"/tokens"}},
+	"DeleteToken":     {true, []string{"/tokens/{token}"}},
+	"ListTokens":      {false, []string{"/tokens"}},
+	"RetrieveToken":   {false, []string{"/tokens/{token}"}},
+	"UpdateToken":     {true, []string{"/tokens/{token}"}},
+	"RotateToken":     {true, []string{"/tokens/{token}/rotate"}},
+	"ListTokenTags":   {false, []string{"/tokens/{identifier}/tags"}},
+	"UpdateTokenTags": {true, []string{"/tokens/{identifier}/tags"}},
+	"GetTokenTag":     {false, []string{"/tokens/{identifier}/tags/{name}"}},
+	"DeleteTokenTag":  {true, []string{"/tokens/{identifier}/tags/{name}"}},
+	"UpdateTokenTag":  {true, []string{"/tokens/{identifier}/tags/{name}"}},
+}
+
+// rpcDestinations are the resources that methods write to in addition to
+// the resource in rpcScopes. The token must grant write access to these
+// resources as well when the fields are set in the request. Devices are moved
+// to another collection when the collection ID is set in UpdateDevice.
+var rpcDestinations = map[string][]string{
+	"UpdateDevice": {"/collections/{collection_id}/devices/{device_id}"},
+}
+
+// requestField returns the value of a field in a protobuf request. The field
+// is identified by its protobuf name.
+func requestField(req interface{}, name string) string {
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("protobuf")
+		if !strings.Contains(","+tag+",", ",name="+name+",") {
+			continue
+		}
+		switch f := v.Field(i).Interface().(type) {
+		case *wrappers.StringValue:
+			return f.GetValue()
+		case string:
+			return f
+		default:
+			if v.Field(i).Kind() == reflect.Ptr && v.Field(i).IsNil() {
+				return ""
+			}
+			return fmt.Sprintf("%v", f)
+		}
+	}
+	return ""
+}
+
+// resource returns the resource for the request, ie the REST API path
+func (r rpcScope) resource(req interface{}) (string, bool) {
+	for _, template := range r.Resources {
+		var parts []string
+		complete := true
+		for _, p := range strings.Split(template, "/") {
+			if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+				p = requestField(req, p[1:len(p)-1])
+				if p == "" {
+					complete = false
+					break
+				}
+			}
+			parts = append(parts, p)
+		}
+		if complete {
+			return strings.Join(parts, "/"), true
+		}
+	}
+	return "", false
+}
+
+// tokenAllowsCall checks if the token grants access to a RPC call. The
+// method is the full gRPC method name.
+func tokenAllowsCall(token model.Token, method string, req interface{}) bool {
+	if !strings.HasPrefix(method, hordeServiceName) {
+		return false
+	}
+	name := strings.TrimPrefix(method, hordeServiceName)
+	scope, ok := rpcScopes[name]
+	if !ok {
+		return false
+	}
+	resource, ok := scope.resource(req)
+	if !ok {
+		return false
+	}
+	if !TokenAllows(token, resource, scope.Write) {
+		return false
+	}
+	for _, template := range rpcDestinations[name] {
+		destination, ok := rpcScope{Write: true, Resources: []string{template}}.resource(req)
+		if ok && !TokenAllows(token, destination, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// rpcCall is the gRPC method and request for a call. The call is added to
+// the context by the interceptors and is required for token authentication
+// through gRPC.
+type rpcCall struct {
+	Method  string
+	Request interface{}
+}
+
+const callKey = contextKey("call")
+
+func withRPCCall(ctx context.Context, method string, req interface{}) context.Context {
+	return context.WithValue(ctx, callKey, rpcCall{Method: method, Request: req})
+}
+
+func rpcCallFromContext(ctx context.Context) (rpcCall, bool) {
+	call, ok := ctx.Value(callKey).(rpcCall)
+	return call, ok
+}
+
+// peerIP returns the IP address of the client or an empty string if it is
+// unknown.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// authorizeCall checks the API token in the metadata (if there is one)
+// against the RPC call. Calls with valid tokens are recorded by the usage
+// recorder.
+func authorizeCall(ctx context.Context, store storage.DataStore, usage *TokenUsageRecorder, method string, req interface{}) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	t := md.Get(tokenHeaderName)
+	if len(t) != 1 {
+		return nil
+	}
+	token, err := LookupToken(store, t[0])
+	if err != nil {
+		if err == ErrTokenExpired {
+			return status.Error(codes.Unauthenticated, "API token has expired")
+		}
+		if err != storage.ErrNotFound {
+			logging.Warning("Error retrieving token %s: %v", model.TokenID(t[0]), err)
+		}
+		return status.Error(codes.Unauthenticated, "Unknown API token")
+	}
+	if !tokenAllowsCall(token, method, req) {
+		return status.Error(codes.PermissionDenied, "Access denied")
+	}
+	usage.Record(token.ID, peerIP(ctx))
+	return nil
+}
+
+// scopedServerStream adds the RPC call to the stream's context when the
+// request is received.
+type scopedServerStream struct {
+	grpc.ServerStream
+	store  storage.DataStore
+	usage  *TokenUsageRecorder
+	method string
+	ctx    context.Context
+}
+
+func (s *scopedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *scopedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := authorizeCall(s.ServerStream.Context(), s.store, s.usage, s.method, m); err != nil {
+		return err
+	}
+	s.ctx = withRPCCall(s.ServerStream.Context(), s.method, m)
+	return nil
+}
+
+// tokenUnaryInterceptor checks API tokens for unary calls
+func tokenUnaryInterceptor(store storage.DataStore, usage *TokenUsageRecorder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorizeCall(ctx, store, usage, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(withRPCCall(ctx, info.FullMethod, req), req)
+	}
+}
+
+// tokenStreamInterceptor checks API tokens for streaming calls. The token is
+// checked when the request is received.
+func tokenStreamInterceptor(store storage.DataStore, usage *TokenUsageRecorder) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &scopedServerStream{ServerStream: ss, store: store, usage: usage, method: info.FullMethod, ctx: ss.Context()})
+	}
+}
+
+// GRPCServerOptions returns the server options for a gRPC server with the
+// Horde service. The interceptors check the API tokens against the method
+// and the request. API tokens are rejected by the service if the call
+// isn't intercepted. Token usage is recorded if the usage recorder is set.
+func GRPCServerOptions(store storage.DataStore, usage *TokenUsageRecorder) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(tokenUnaryInterceptor(store, usage)),
+		grpc.StreamInterceptor(tokenStreamInterceptor(store, usage)),
+	}
+}
@@ -0,0 +1,390 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/eesrc/horde/pkg/storage/storetest"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenAllows(t *testing.T) {
+	assert := require.New(t)
+
+	tests := []struct {
+		Resource string
+		Write    bool
+		Path     string
+		Writing  bool
+		Allowed  bool
+	}{
+		{"/", true, "/collections/1", true, true},
+		{"/", false, "/collections/1", false, true},
+		{"/", false, "/collections/1", true, false},
+		{"", false, "/teams", false, true},
+		{"/collections/1", false, "/collections/1", false, true},
+		{"/collections/1", false, "/collections/1/devices/2", false, true},
+		{"/collections/1/", false, "/collections/1/devices/2", false, true},
+		{"/collections/1", false, "/collections/10", false, false},
+		{"/collections/1", false, "/collections", false, false},
+		{"/collections/1/devices/2", true, "/collections/1/devices/2/to", true, true},
+		{"/collections/1/devices/2", true, "/collections/1/devices/3", true, false},
+	}
+	for _, v := range tests {
+		token := model.Token{Resource: v.Resource, Write: v.Write}
+		assert.Equal(v.Allowed, TokenAllows(token, v.Path, v.Writing), "Token %s (write=%t) for %s (write=%t)", v.Resource, v.Write, v.Path, v.Writing)
+	}
+}
+
+// testFieldValues are the values for the path parameters in the requests
+var testFieldValues = map[string]string{
+	"collection_id":          "1",
+	"existing_collection_id": "1",
+	"device_id":              "2",
+	"identifier":             "2",
+	"output_id":              "2",
+	"image_id":               "2",
+	"key_id":                 "2",
+	"team_id":                "3",
+	"user_id":                "4",
+	"token":                  "tok",
+	"code":                   "code",
+	"name":                   "tag",
+}
+
+// newTestRequest creates a request with the path parameters set
+func newTestRequest(t reflect.Type) proto.Message {
+	req := reflect.New(t.Elem())
+	v := req.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		for _, s := range strings.Split(v.Type().Field(i).Tag.Get("protobuf"), ",") {
+			if !strings.HasPrefix(s, "name=") {
+				continue
+			}
+			value, ok := testFieldValues[strings.TrimPrefix(s, "name=")]
+			if ok && v.Field(i).Type() == reflect.TypeOf(&wrappers.StringValue{}) {
+				v.Field(i).Set(reflect.ValueOf(&wrappers.StringValue{Value: value}))
+			}
+		}
+	}
+	return req.Interface().(proto.Message)
+}
+
+// fakeServerStream is a server stream that returns a single request
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req proto.Message
+}
+
+func (f *fakeServerStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeServerStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), f.req)
+	return nil
+}
+
+// TestTokenScopeForAllMethods checks every method in the Horde service with
+// tokens with different scopes. The expected resources are the REST API
+// paths for the methods with the path parameters from testFieldValues.
+func TestTokenScopeForAllMethods(t *testing.T) {
+	assert := require.New(t)
+
+	methods := []struct {
+		Method   string
+		Write    bool
+		Resource string
+	}{
+		{"CreateCollection", true, "/collections"},
+		{"UpdateCollection", true, "/collections/1"},
+		{"DeleteCollection", true, "/collections/1"},
+		{"ListCollections", false, "/collections"},
+		{"RetrieveCollection", false, "/collections/1"},
+		{"ListCollectionMessages", false, "/collections/1/data"},
+		{"BroadcastMessage", true, "/collections/1/to"},
+		{"MessageStream", false, "/collections/1/devices/2/from"},
+		{"ListCollectionTags", false, "/collections/1/tags"},
+		{"UpdateCollectionTags", true, "/collections/1/tags"},
+		{"GetCollectionTag", false, "/collections/1/tags/tag"},
+		{"DeleteCollectionTag", true, "/collections/1/tags/tag"},
+		{"UpdateCollectionTag", true, "/collections/1/tags/tag"},
+		{"CreateDevice", true, "/collections/1/devices"},
+		{"RetrieveDevice", false, "/collections/1/devices/2"},
+		{"UpdateDevice", true, "/collections/1/devices/2"},
+		{"DeleteDevice", true, "/collections/1/devices/2"},
+		{"ListDevices", false, "/collections/1/devices"},
+		{"ImportDevices", true, "/collections/1/import"},
+		{"ExportDevices", false, "/collections/1/export"},
+		{"ListDeviceMessages", false, "/collections/1/devices/2/data"},
+		{"SendMessage", true, "/collections/1/devices/2/to"},
+		{"ClearFirmwareError", true, "/collections/1/devices/2/fwerror"},
+		{"ListDeviceTags", false, "/collections/1/devices/2/tags"},
+		{"UpdateDeviceTags", true, "/collections/1/devices/2/tags"},
+		{"GetDeviceTag", false, "/collections/1/devices/2/tags/tag"},
+		{"DeleteDeviceTag", true, "/collections/1/devices/2/tags/tag"},
+		{"UpdateDeviceTag", true, "/collections/1/devices/2/tags/tag"},
+		{"CreateFirmware", true, "/collections/1/firmware"},
+		{"RetrieveFirmware", false, "/collections/1/firmware/2"},
+		{"UpdateFirmware", true, "/collections/1/firmware/2"},
+		{"DeleteFirmware", true, "/collections/1/firmware/2"},
+		{"ListFirmware", false, "/collections/1/firmware"},
+		{"FirmwareUsage", true, "/collections/1/firmware/2/usage"},
+		{"CheckFirmwareCompatibility", false, "/collections/1/firmware/2/compatibility"},
+		{"ListFirmwareHistory", false, "/collections/1/firmware/2/history"},
+		{"ListDeviceFirmwareHistory", false, "/collections/1/devices/2/firmware/history"},
+		{"ListFirmwareTags", false, "/collections/1/firmware/2/tags"},
+		{"UpdateFirmwareTags", true, "/collections/1/firmware/2/tags"},
+		{"GetFirmwareTag", false, "/collections/1/firmware/2/tags/tag"},
+		{"DeleteFirmwareTag", true, "/collections/1/firmware/2/tags/tag"},
+		{"UpdateFirmwareTag", true, "/collections/1/firmware/2/tags/tag"},
+		{"CreateSigningKey", true, "/collections/1/signingkeys"},
+		{"RetrieveSigningKey", false, "/collections/1/signingkeys/2"},
+		{"ListSigningKeys", false, "/collections/1/signingkeys"},
+		{"DeleteSigningKey", true, "/collections/1/signingkeys/2"},
+		{"CreateOutput", true, "/collections/1/outputs"},
+		{"RetrieveOutput", false, "/collections/1/outputs/2"},
+		{"UpdateOutput", true, "/collections/1/outputs/2"},
+		{"DeleteOutput", true, "/collections/1/outputs/2"},
+		{"ListOutputs", false, "/collections/1/outputs"},
+		{"Logs", false, "/collections/1/outputs/2/logs"},
+		{"Status", false, "/collections/1/outputs/2/status"},
+		{"TestOutput", true, "/collections/1/outputs/test"},
+		{"ListOutputTags", false, "/collections/1/outputs/2/tags"},
+		{"UpdateOutputTags", true, "/collections/1/outputs/2/tags"},
+		{"GetOutputTag", false, "/collections/1/outputs/2/tags/tag"},
+		{"DeleteOutputTag", true, "/collections/1/outputs/2/tags/tag"},
+		{"UpdateOutputTag", true, "/collections/1/outputs/2/tags/tag"},
+		{"GetSystemInfo", false, "/system"},
+		{"DataDump", true, "/datadump"},
+		{"DataRestore", true, "/datarestore"},
+		{"StreamDataDump", false, "/datadump/stream"},
+		{"GetUserProfile", false, "/profile"},
+		{"CreateTeam", true, "/teams"},
+		{"RetrieveTeam", false, "/teams/3"},
+		{"RetrieveTeamMembers", false, "/teams/3/members"},
+		{"RetrieveMember", false, "/teams/3/members/4"},
+		{"UpdateMember", true, "/teams/3/members/4"},
+		{"DeleteMember", true, "/teams/3/members/4"},
+		{"UpdateTeam", true, "/teams/3"},
+		{"DeleteTeam", true, "/teams/3"},
+		{"ListTeams", false, "/teams"},
+		{"Usage", false, "/teams/3/usage"},
+		{"ListAuditEvents", false, "/teams/3/audit"},
+		{"GenerateInvite", true, "/teams/3/invites"},
+		{"ListInvites", false, "/teams/3/invites"},
+		{"RetrieveInvite", false, "/teams/3/invites/code"},
+		{"AcceptInvite", true, "/teams/accept"},
+		{"DeleteInvite", true, "/teams/3/invites/code"},
+		{"ListTeamTags", false, "/teams/2/tags"},
+		{"UpdateTeamTags", true, "/teams/2/tags"},
+		{"GetTeamTag", false, "/teams/2/tags/tag"},
+		{"DeleteTeamTag", true, "/teams/2/tags/tag"},
+		{"UpdateTeamTag", true, "/teams/2/tags/tag"},
+		{"CreateToken", true, "/tokens"},
+		{"DeleteToken", true, "/tokens/tok"},
+		{"ListTokens", false, "/tokens"},
+		{"RetrieveToken", false, "/tokens/tok"},
+		{"UpdateToken", true, "/tokens/tok"},
+		{"RotateToken", true, "/tokens/tok/rotate"},
+		{"ListTokenTags", false, "/tokens/2/tags"},
+		{"UpdateTokenTags", true, "/tokens/2/tags"},
+		{"GetTokenTag", false, "/tokens/2/tags/tag"},
+		{"DeleteTokenTag", true, "/tokens/2/tags/tag"},
+		{"UpdateTokenTag", true, "/tokens/2/tags/tag"},
+	}
+
+	// Every method in the service must be tested
+	service := reflect.TypeOf((*apipb.HordeServer)(nil)).Elem()
+	assert.Equal(service.NumMethod(), len(methods))
+	assert.Equal(service.NumMethod(), len(rpcScopes))
+
+	store := sqlstore.NewMemoryStore()
+	env := storetest.NewTestEnvironment(t, store)
+	tokens := []model.Token{
+		{Resource: "/", Write: true},
+		{Resource: "/", Write: false},
+		{Resource: "/collections/1", Write: true},
+		{Resource: "/collections/1", Write: false},
+		{Resource: "/collections/10", Write: true},
+		{Resource: "/collections/1/devices/2", Write: true},
+		{Resource: "/collections/1/outputs", Write: false},
+		{Resource: "/teams/3", Write: true},
+		{Resource: "/tokens", Write: false},
+	}
+	for i := range tokens {
+		tokens[i].UserID = env.U1.ID
+		tokens[i].Tags = model.NewTags()
+		assert.NoError(tokens[i].GenerateToken())
+		assert.NoError(store.CreateToken(tokens[i]))
+	}
+
+	unary := tokenUnaryInterceptor(store, nil)
+	stream := tokenStreamInterceptor(store, nil)
+
+	for _, m := range methods {
+		method, ok := service.MethodByName(m.Method)
+		assert.True(ok, "Unknown method %s", m.Method)
+		fullMethod := hordeServiceName + m.Method
+
+		for _, token := range tokens {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tokenHeaderName, token.Token))
+			expected := TokenAllows(token, m.Resource, m.Write)
+
+			// The handler is only invoked if the call is allowed and the
+			// call must be available to gRPCAuth.
+			invoked := false
+			var err error
+			if method.Type.In(0) == reflect.TypeOf((*context.Context)(nil)).Elem() {
+				req := newTestRequest(method.Type.In(1))
+				_, err = unary(ctx, req, &grpc.UnaryServerInfo{FullMethod: fullMethod}, func(ctx context.Context, req interface{}) (interface{}, error) {
+					invoked = true
+					auth := gRPCAuth(ctx, store)
+					assert.NotNil(auth, "%s should authenticate with token %s", m.Method, token.Resource)
+					return nil, nil
+				})
+			} else {
+				req := newTestRequest(method.Type.In(0))
+				err = stream(nil, &fakeServerStream{ctx: ctx, req: req}, &grpc.StreamServerInfo{FullMethod: fullMethod, IsServerStream: true}, func(srv interface{}, ss grpc.ServerStream) error {
+					r := reflect.New(method.Type.In(0).Elem()).Interface()
+					if err := ss.RecvMsg(r); err != nil {
+						return err
+					}
+					invoked = true
+					auth := gRPCAuth(ss.Context(), store)
+					assert.NotNil(auth, "%s should authenticate with token %s", m.Method, token.Resource)
+					return nil
+				})
+			}
+			assert.Equal(expected, invoked, "%s with token %s (write=%t)", m.Method, token.Resource, token.Write)
+			if expected {
+				assert.NoError(err)
+				continue
+			}
+			assert.Equal(codes.PermissionDenied.String(), status.Code(err).String(), "%s with token %s (write=%t)", m.Method, token.Resource, token.Write)
+		}
+
+		// Unknown tokens are rejected
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tokenHeaderName, "unknown"))
+		assert.Equal(codes.Unauthenticated, status.Code(authorizeCall(ctx, store, nil, fullMethod, nil)))
+	}
+
+	// Message streams for a collection require access to the collection
+	req := &apipb.MessageStreamRequest{CollectionId: &wrappers.StringValue{Value: "1"}}
+	assert.True(tokenAllowsCall(tokens[3], hordeServiceName+"MessageStream", req))
+	assert.False(tokenAllowsCall(tokens[5], hordeServiceName+"MessageStream", req))
+
+	// Devices can only be moved to collections in the token's scope
+	move := &apipb.UpdateDeviceRequest{
+		ExistingCollectionId: &wrappers.StringValue{Value: "1"},
+		DeviceId:             &wrappers.StringValue{Value: "2"},
+	}
+	assert.True(tokenAllowsCall(tokens[2], hordeServiceName+"UpdateDevice", move))
+	move.CollectionId = &wrappers.StringValue{Value: "10"}
+	assert.False(tokenAllowsCall(tokens[2], hordeServiceName+"UpdateDevice", move))
+	assert.False(tokenAllowsCall(tokens[4], hordeServiceName+"UpdateDevice", move))
+	assert.False(tokenAllowsCall(tokens[5], hordeServiceName+"UpdateDevice", move))
+	assert.True(tokenAllowsCall(tokens[0], hordeServiceName+"UpdateDevice", move))
+	move.CollectionId = &wrappers.StringValue{Value: "1"}
+	assert.True(tokenAllowsCall(tokens[5], hordeServiceName+"UpdateDevice", move))
+
+	// Requests without the path parameters are denied
+	assert.False(tokenAllowsCall(tokens[0], hordeServiceName+"RetrieveCollection", &apipb.RetrieveCollectionRequest{}))
+	// ...and so are unknown methods
+	assert.False(tokenAllowsCall(tokens[0], "/apipb.Other/RetrieveCollection", &apipb.RetrieveCollectionRequest{}))
+}
+
+// TestTokenScopeGRPCServer runs the service through a gRPC server with a
+// read-only token for a single collection.
+func TestTokenScopeGRPCServer(t *testing.T) {
+	assert := require.New(t)
+
+	store := sqlstore.NewMemoryStore()
+	env := storetest.NewTestEnvironment(t, store)
+
+	token := model.NewToken()
+	token.UserID = env.U1.ID
+	token.Resource = "/collections/" + env.C1.ID.String()
+	assert.NoError(token.GenerateToken())
+	assert.NoError(store.CreateToken(token))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	usage := NewTokenUsageRecorder(store, time.Hour)
+	defer usage.Stop()
+	server := grpc.NewServer(GRPCServerOptions(store, usage)...)
+	apipb.RegisterHordeServer(server, NewHordeAPIService(store, model.FieldMaskParameters{}, nil, nil, nil, nil))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	assert.NoError(err)
+	defer conn.Close()
+	client := apipb.NewHordeClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), tokenHeaderName, token.Token)
+
+	res, err := client.RetrieveCollection(ctx, &apipb.RetrieveCollectionRequest{CollectionId: &wrappers.StringValue{Value: env.C1.ID.String()}})
+	assert.NoError(err)
+	assert.Equal(env.C1.ID.String(), res.CollectionId.Value)
+
+	// Writes are denied for read-only tokens
+	_, err = client.UpdateCollection(ctx, &apipb.Collection{CollectionId: &wrappers.StringValue{Value: env.C1.ID.String()}})
+	assert.Equal(codes.PermissionDenied.String(), status.Code(err).String())
+
+	// ...and so are other collections
+	_, err = client.RetrieveCollection(ctx, &apipb.RetrieveCollectionRequest{CollectionId: &wrappers.StringValue{Value: env.C12.ID.String()}})
+	assert.Equal(codes.PermissionDenied.String(), status.Code(err).String())
+	_, err = client.ListCollections(ctx, &apipb.ListCollectionRequest{})
+	assert.Equal(codes.PermissionDenied.String(), status.Code(err).String())
+
+	// The last use of the token is recorded
+	usage.Flush()
+	stored, err := store.RetrieveToken(token.ID)
+	assert.NoError(err)
+	assert.False(stored.LastUsed.IsZero())
+	assert.Equal("127.0.0.1", stored.LastUsedIP)
+
+	// Expired tokens are rejected
+	stored.Expires = time.Now().Add(-time.Second)
+	assert.NoError(store.UpdateToken(stored))
+	_, err = client.RetrieveCollection(ctx, &apipb.RetrieveCollectionRequest{CollectionId: &wrappers.StringValue{Value: env.C1.ID.String()}})
+	assert.Equal(codes.Unauthenticated.String(), status.Code(err).String())
+	stored.Expires = time.Time{}
+	assert.NoError(store.UpdateToken(stored))
+
+	// The token is rejected when the service is called directly
+	svc := NewHordeAPIService(store, model.FieldMaskParameters{}, nil, nil, nil, nil)
+	_, err = svc.RetrieveCollection(metadata.NewIncomingContext(context.Background(), metadata.Pairs(tokenHeaderName, token.Token)),
+		&apipb.RetrieveCollectionRequest{CollectionId: &wrappers.StringValue{Value: env.C1.ID.String()}})
+	assert.Equal(codes.Unauthenticated.String(), status.Code(err).String())
+}
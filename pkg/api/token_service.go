@@ -18,6 +18,7 @@ package api
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/ExploratoryEngineering/logging"
 	"github.com/eesrc/horde/pkg/api/apipb"
@@ -56,14 +57,21 @@ func (t *tokenService) EnsureAuth(ctx context.Context) (*authResult, error) {
 	return auth, nil
 }
 
-// Utility method that retrieves a token. The error is ready to return to
+// defaultRotateGracePeriod is the default grace period for rotated tokens
+const defaultRotateGracePeriod = time.Hour
+
+// Utility method that retrieves a token. The token can be referenced either
+// by the token itself or the token ID. The error is ready to return to
 // the gRPC client, ie it uses errors from the codes package.
 func (t *tokenService) loadToken(auth *authResult, tv *wrappers.StringValue) (model.Token, error) {
 	if tv == nil || strings.TrimSpace(tv.Value) == "" {
 		return model.Token{}, status.Error(codes.InvalidArgument, "Missing token")
 	}
 
-	token, err := t.store.RetrieveToken(tv.Value)
+	token, err := t.store.RetrieveToken(model.TokenID(tv.Value))
+	if err == storage.ErrNotFound {
+		token, err = t.store.RetrieveToken(tv.Value)
+	}
 	if err != nil {
 		if err == storage.ErrNotFound {
 			return model.Token{}, status.Error(codes.NotFound, "Unknown token")
@@ -78,6 +86,32 @@ func (t *tokenService) loadToken(auth *authResult, tv *wrappers.StringValue) (mo
 	return token, nil
 }
 
+// ensureTokenPermission checks that the user is allowed to create tokens for
+// the resource. Tokens for a team or a collection (or anything below it)
+// require the token permission in the team. Other tokens are limited to what
+// the user can access anyway.
+func (t *tokenService) ensureTokenPermission(auth *authResult, resource string) error {
+	parts := strings.Split(strings.Trim(resource, "/"), "/")
+	if len(parts) < 2 {
+		return nil
+	}
+	switch parts[0] {
+	case "teams":
+		teamID, err := model.NewTeamKeyFromString(parts[1])
+		if err != nil {
+			return nil
+		}
+		return apitoolbox.EnsurePermission(auth.User.ID, teamID, t.store, model.ManageTokensPermission)
+	case "collections":
+		collectionID, err := model.NewCollectionKeyFromString(parts[1])
+		if err != nil {
+			return nil
+		}
+		return apitoolbox.EnsureCollectionPermission(auth.User.ID, collectionID, t.store, model.ManageTokensPermission)
+	}
+	return nil
+}
+
 // This is a wrapped loadToken for the tag functions
 func (t *tokenService) LoadTaggedResource(auth *authResult, collectionID, identifier string) (taggedResource, error) {
 	token, err := t.loadToken(auth, &wrappers.StringValue{Value: identifier})
@@ -102,10 +136,20 @@ func (t *tokenService) CreateToken(ctx context.Context, req *apipb.Token) (*apip
 	if req.Write == nil {
 		return nil, status.Error(codes.InvalidArgument, "Write must be specified")
 	}
+	if err := t.ensureTokenPermission(auth, req.Resource.Value); err != nil {
+		return nil, err
+	}
 	newToken := model.NewToken()
 	newToken.Resource = req.Resource.Value
 	newToken.Write = req.Write.Value
 	newToken.UserID = auth.User.ID
+	newToken.Created = time.Now()
+	if req.Expires != nil {
+		newToken.Expires = apitoolbox.MillisToTime(req.Expires.Value)
+		if newToken.Expired(newToken.Created) {
+			return nil, status.Error(codes.InvalidArgument, "Expiry time must be in the future")
+		}
+	}
 
 	if req.Tags != nil {
 		for k, v := range req.Tags {
@@ -152,11 +196,15 @@ func (t *tokenService) DeleteToken(ctx context.Context, req *apipb.DeleteTokenRe
 	if err != nil {
 		return nil, err
 	}
-	if req == nil || req.Token == nil {
+	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "Missing token")
 	}
+	token, err := t.loadToken(auth, req.Token)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := t.store.DeleteToken(auth.User.ID, req.Token.Value); err != nil {
+	if err := t.store.DeleteToken(auth.User.ID, token.ID); err != nil {
 		if err == storage.ErrNotFound {
 			return nil, status.Error(codes.NotFound, "Unknown token")
 		}
@@ -199,11 +247,17 @@ func (t *tokenService) UpdateToken(ctx context.Context, req *apipb.Token) (*apip
 		if strings.TrimSpace(req.Resource.Value) == "" {
 			return nil, status.Error(codes.InvalidArgument, "Invalid resource")
 		}
+		if err := t.ensureTokenPermission(auth, req.Resource.Value); err != nil {
+			return nil, err
+		}
 		token.Resource = req.Resource.Value
 	}
 	if req.Write != nil {
 		token.Write = req.Write.Value
 	}
+	if req.Expires != nil {
+		token.Expires = apitoolbox.MillisToTime(req.Expires.Value)
+	}
 	if req.Tags != nil {
 		for k, v := range req.Tags {
 			if !token.Tags.IsValidTag(k, v) {
@@ -219,13 +273,88 @@ func (t *tokenService) UpdateToken(ctx context.Context, req *apipb.Token) (*apip
 	return apitoolbox.NewTokenFromModel(token), nil
 }
 
+func (t *tokenService) RotateToken(ctx context.Context, req *apipb.RotateTokenRequest) (*apipb.Token, error) {
+	auth, err := t.EnsureAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing request object")
+	}
+
+	token, err := t.loadToken(auth, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if token.Expired(now) {
+		return nil, status.Error(codes.FailedPrecondition, "Token has expired")
+	}
+
+	gracePeriod := defaultRotateGracePeriod
+	if req.GracePeriod != nil {
+		if req.GracePeriod.Value < 0 {
+			return nil, status.Error(codes.InvalidArgument, "Grace period can't be negative")
+		}
+		gracePeriod = time.Duration(req.GracePeriod.Value) * time.Second
+	}
+
+	newToken := model.NewToken()
+	newToken.UserID = token.UserID
+	newToken.Resource = token.Resource
+	newToken.Write = token.Write
+	newToken.Created = now
+	for k, v := range token.TagMap {
+		newToken.SetTag(k, v)
+	}
+	switch {
+	case req.Expires != nil:
+		newToken.Expires = apitoolbox.MillisToTime(req.Expires.Value)
+		if newToken.Expired(now) {
+			return nil, status.Error(codes.InvalidArgument, "Expiry time must be in the future")
+		}
+	case !token.Expires.IsZero() && !token.Created.IsZero():
+		// Keep the same lifetime as the existing token
+		newToken.Expires = now.Add(token.Expires.Sub(token.Created))
+	}
+	if err := newToken.GenerateToken(); err != nil {
+		logging.Warning("Unable to generate token for user %d: %v", newToken.UserID, err)
+		return nil, status.Error(codes.Internal, "Could not generate token")
+	}
+	if err := t.store.CreateToken(newToken); err != nil {
+		logging.Warning("Error storing rotated token for user %d: %v", newToken.UserID, err)
+		return nil, status.Error(codes.Internal, "Error creating token")
+	}
+
+	// The existing token expires when the grace period ends unless it
+	// expires before that.
+	graceEnd := now.Add(gracePeriod)
+	if token.Expires.IsZero() || graceEnd.Before(token.Expires) {
+		token.Expires = graceEnd
+	}
+	if err := t.store.UpdateToken(token); err != nil {
+		logging.Warning("Error updating expiry for rotated token %s: %v", token.ID, err)
+		if err := t.store.DeleteToken(newToken.UserID, newToken.ID); err != nil {
+			logging.Warning("Unable to remove replacement token %s: %v", newToken.ID, err)
+		}
+		return nil, status.Error(codes.Internal, "Unable to rotate token")
+	}
+	return apitoolbox.NewTokenFromModel(newToken), nil
+}
+
 func (t *tokenService) ListTokenTags(ctx context.Context, req *apipb.TagRequest) (*apipb.TagResponse, error) {
 	return listTags(ctx, req, t)
 }
 
+// EnsureTagUpdate is a no-op for tokens since the tokens are owned by the
+// user.
+func (t *tokenService) EnsureTagUpdate(auth *authResult, res taggedResource) error {
+	return nil
+}
+
 func (t *tokenService) UpdateResourceTags(userID model.UserKey, collectionID, identifier string, res interface{}) error {
 	token := res.(*model.Token)
-	return t.store.UpdateTokenTags(userID, identifier, token.Tags)
+	return t.store.UpdateTokenTags(userID, token.ID, token.Tags)
 }
 
 func (t *tokenService) UpdateTokenTags(ctx context.Context, req *apipb.UpdateTagRequest) (*apipb.TagResponse, error) {
@@ -18,6 +18,7 @@ package api
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/TelenorDigital/goconnect"
 	"github.com/eesrc/horde/pkg/api/apipb"
@@ -51,6 +52,7 @@ func TestListToken(t *testing.T) {
 	// this method.
 	md := metadata.New(map[string]string{tokenHeaderName: token})
 	ctx := metadata.NewIncomingContext(context.Background(), md)
+	ctx = withRPCCall(ctx, "/apipb.Horde/ListTokens", &apipb.ListTokenRequest{})
 
 	res, err = tokenService.ListTokens(ctx, &apipb.ListTokenRequest{})
 	assert.Nil(res)
@@ -92,7 +94,9 @@ func TestListToken(t *testing.T) {
 	assert.NotNil(res)
 	assert.NoError(err)
 	assert.Len(res.Tokens, 1)
-	assert.Equal(token, res.Tokens[0].Token.Value)
+	// Only the hash is stored so listed tokens don't include the token
+	assert.Nil(res.Tokens[0].Token)
+	assert.Equal(model.TokenID(token), res.Tokens[0].Id.Value)
 }
 
 func TestCreateToken(t *testing.T) {
@@ -132,6 +136,28 @@ func TestCreateToken(t *testing.T) {
 	assert.NotNil(res)
 	assert.Equal("/", res.Resource.Value)
 	assert.True(res.Write.Value)
+	assert.Equal(model.TokenID(res.Token.Value), res.Id.Value)
+	assert.NotZero(res.Created.Value)
+	assert.Zero(res.Expires.Value)
+
+	// Tokens with expiry time
+	expires := time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond)
+	res, err = tokenService.CreateToken(ctx, &apipb.Token{
+		Write:    &wrappers.BoolValue{Value: false},
+		Resource: &wrappers.StringValue{Value: "/"},
+		Expires:  &wrappers.Int64Value{Value: expires},
+	})
+	assert.NoError(err)
+	assert.Equal(expires, res.Expires.Value)
+
+	// ...but it can't be in the past
+	res, err = tokenService.CreateToken(ctx, &apipb.Token{
+		Write:    &wrappers.BoolValue{Value: false},
+		Resource: &wrappers.StringValue{Value: "/"},
+		Expires:  &wrappers.Int64Value{Value: 1},
+	})
+	assert.Nil(res)
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
 
 	// Nil request fails
 	res, err = tokenService.CreateToken(ctx, nil)
@@ -198,7 +224,7 @@ func TestDeleteToken(t *testing.T) {
 	assert.NotNil(res)
 
 	// Token should be removed from database
-	_, err = store.RetrieveToken(token)
+	_, err = store.RetrieveToken(model.TokenID(token))
 	assert.Equal(storage.ErrNotFound, err)
 
 	// Deleting it twice should return Not Found error
@@ -251,7 +277,14 @@ func TestRetrieveToken(t *testing.T) {
 
 	assert.NoError(err)
 	assert.NotNil(res)
-	assert.Equal(token, res.Token.Value)
+	assert.Equal(model.TokenID(token), res.Id.Value)
+
+	// The token ID can be used instead of the token
+	res, err = tokenService.RetrieveToken(ctx, &apipb.TokenRequest{
+		Token: &wrappers.StringValue{Value: model.TokenID(token)},
+	})
+	assert.NoError(err)
+	assert.Equal(model.TokenID(token), res.Id.Value)
 
 	// Empty token or nil request returns error
 	res, err = tokenService.RetrieveToken(ctx, nil)
@@ -303,11 +336,26 @@ func TestUpdateToken(t *testing.T) {
 	assert.Equal("/something/else", res.Resource.Value)
 
 	// The token should be updated in the store
-	td, err := store.RetrieveToken(token)
+	td, err := store.RetrieveToken(model.TokenID(token))
 	assert.NoError(err)
 	assert.Equal(td.Resource, res.Resource.Value)
 	assert.Equal(td.Write, res.Write.Value)
 
+	// Set and clear the expiry time
+	expires := time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond)
+	res, err = tokenService.UpdateToken(ctx, &apipb.Token{
+		Token:   &wrappers.StringValue{Value: token},
+		Expires: &wrappers.Int64Value{Value: expires},
+	})
+	assert.NoError(err)
+	assert.Equal(expires, res.Expires.Value)
+	res, err = tokenService.UpdateToken(ctx, &apipb.Token{
+		Token:   &wrappers.StringValue{Value: token},
+		Expires: &wrappers.Int64Value{Value: 0},
+	})
+	assert.NoError(err)
+	assert.Equal(int64(0), res.Expires.Value)
+
 	// Use invalid resource
 	_, err = tokenService.UpdateToken(ctx, &apipb.Token{
 		Token:    &wrappers.StringValue{Value: token},
@@ -353,6 +401,74 @@ func TestUpdateToken(t *testing.T) {
 
 }
 
+func TestRotateToken(t *testing.T) {
+	assert := require.New(t)
+	store := sqlstore.NewMemoryStore()
+	tokenService := newTokenService(store)
+
+	// Ensure we authenticate
+	res, err := tokenService.RotateToken(context.Background(), &apipb.RotateTokenRequest{Token: &wrappers.StringValue{Value: "foo"}})
+	assert.Error(err)
+	assert.Nil(res)
+
+	user, token, _ := createAuthenticatedContext(assert, store)
+	ctx := context.WithValue(context.Background(), ghlogin.GitHubSessionProfile, ghlogin.Profile{Login: user.ExternalID})
+
+	// The old token is valid for the default grace period
+	before := time.Now()
+	res, err = tokenService.RotateToken(ctx, &apipb.RotateTokenRequest{
+		Token: &wrappers.StringValue{Value: token},
+	})
+	assert.NoError(err)
+	assert.NotEqual(token, res.Token.Value)
+	assert.Equal("/", res.Resource.Value)
+	assert.True(res.Write.Value)
+	assert.Zero(res.Expires.Value)
+
+	old, err := store.RetrieveToken(model.TokenID(token))
+	assert.NoError(err)
+	assert.False(old.Expired(before.Add(defaultRotateGracePeriod - time.Minute)))
+	assert.True(old.Expired(time.Now().Add(defaultRotateGracePeriod)))
+
+	newToken, err := store.RetrieveToken(res.Id.Value)
+	assert.NoError(err)
+	assert.Equal(user.ID, newToken.UserID)
+
+	// Rotate the new token without a grace period and with an expiry time.
+	// The token can be referenced through the ID.
+	expires := time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond)
+	second, err := tokenService.RotateToken(ctx, &apipb.RotateTokenRequest{
+		Token:       res.Id,
+		GracePeriod: &wrappers.Int64Value{Value: 0},
+		Expires:     &wrappers.Int64Value{Value: expires},
+	})
+	assert.NoError(err)
+	assert.Equal(expires, second.Expires.Value)
+	_, err = LookupToken(store, res.Token.Value)
+	assert.Equal(ErrTokenExpired, err)
+
+	// Expired tokens can't be rotated
+	_, err = tokenService.RotateToken(ctx, &apipb.RotateTokenRequest{Token: res.Token})
+	assert.Equal(codes.FailedPrecondition.String(), status.Code(err).String())
+
+	// Rotated tokens keep the lifetime of the existing token
+	third, err := tokenService.RotateToken(ctx, &apipb.RotateTokenRequest{Token: second.Token})
+	assert.NoError(err)
+	lifetime := time.Duration(third.Expires.Value-third.Created.Value) * time.Millisecond
+	assert.InDelta(float64(time.Hour), float64(lifetime), float64(time.Minute))
+
+	// Invalid requests
+	_, err = tokenService.RotateToken(ctx, nil)
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+	_, err = tokenService.RotateToken(ctx, &apipb.RotateTokenRequest{
+		Token:       third.Token,
+		GracePeriod: &wrappers.Int64Value{Value: -1},
+	})
+	assert.Equal(codes.InvalidArgument.String(), status.Code(err).String())
+	_, err = tokenService.RotateToken(ctx, &apipb.RotateTokenRequest{Token: &wrappers.StringValue{Value: "unknown"}})
+	assert.Equal(codes.NotFound.String(), status.Code(err).String())
+}
+
 func TestTokenTags(t *testing.T) {
 	assert := require.New(t)
 	store := sqlstore.NewMemoryStore()
@@ -0,0 +1,107 @@
+package api
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"sync"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// DefaultTokenUsageInterval is the default interval for writing token usage
+// to the store.
+const DefaultTokenUsageInterval = 5 * time.Second
+
+type tokenUse struct {
+	lastUsed time.Time
+	ip       string
+}
+
+// TokenUsageRecorder records when and from where API tokens are used. The
+// usage is kept in memory and written to the store at regular intervals so
+// requests aren't held up by the store. Only the last use of each token is
+// written.
+type TokenUsageRecorder struct {
+	store   storage.DataStore
+	mutex   *sync.Mutex
+	pending map[string]tokenUse
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewTokenUsageRecorder creates a new usage recorder. The store is updated
+// at the specified interval until Stop is called.
+func NewTokenUsageRecorder(store storage.DataStore, interval time.Duration) *TokenUsageRecorder {
+	ret := &TokenUsageRecorder{
+		store:   store,
+		mutex:   &sync.Mutex{},
+		pending: make(map[string]tokenUse),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go ret.writeLoop(interval)
+	return ret
+}
+
+// Record records the use of a token. This is a no-op if the recorder is nil.
+func (r *TokenUsageRecorder) Record(tokenID string, ip string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.pending[tokenID] = tokenUse{lastUsed: time.Now(), ip: ip}
+}
+
+// Flush writes the recorded usage to the store
+func (r *TokenUsageRecorder) Flush() {
+	r.mutex.Lock()
+	pending := r.pending
+	r.pending = make(map[string]tokenUse)
+	r.mutex.Unlock()
+
+	for id, use := range pending {
+		err := r.store.UpdateTokenUsage(id, use.lastUsed, use.ip)
+		// The token might have been removed in the meantime
+		if err != nil && err != storage.ErrNotFound {
+			logging.Warning("Unable to update usage for token %s: %v", id, err)
+		}
+	}
+}
+
+// Stop stops the recorder. Any pending usage is written to the store
+// before Stop returns.
+func (r *TokenUsageRecorder) Stop() {
+	close(r.stop)
+	<-r.done
+}
+
+func (r *TokenUsageRecorder) writeLoop(interval time.Duration) {
+	defer close(r.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.Flush()
+		case <-r.stop:
+			r.Flush()
+			return
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/eesrc/horde/pkg/apn/allocator"
 	"github.com/eesrc/horde/pkg/apn/radius"
 	"github.com/eesrc/horde/pkg/deviceio/rxtx"
+	"github.com/eesrc/horde/pkg/metering"
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
@@ -103,6 +104,7 @@ func (r *rxtxRADIUS) Access(ctx context.Context, req *rxtx.AccessRequest) (*rxtx
 		metrics.DefaultRADIUSCounters.IPReused(req.NasIdentifier)
 	}
 	metrics.DefaultRADIUSCounters.AcceptRequest(req.NasIdentifier)
+	metering.DefaultMeter.RADIUSSession(device.CollectionID)
 	logging.Debug("Device with IMSI %d has the IP address %s", req.Imsi, ip.String())
 	return &rxtx.AccessResponse{
 		Accepted:  true,
@@ -114,7 +116,7 @@ func (r *rxtxRADIUS) Access(ctx context.Context, req *rxtx.AccessRequest) (*rxtx
 // it will continue execution
 func (r *rxtxRADIUS) updateDeviceTags(device model.Device, nas model.NAS, ip net.IP, req *rxtx.AccessRequest) {
 	device.SetTag("3GPP-User-Location-Info", hex.EncodeToString(req.UserLocationInfo))
-	device.SetTag("3GPP-MS-TimeZone", hex.EncodeToString(req.MsTimezone))
+	device.SetTag(model.MSTimeZoneTag, hex.EncodeToString(req.MsTimezone))
 	device.SetTag("RADIUS-IP-address", ip.String())
 	device.SetTag("RADIUS-Allocated-At", time.Now().Format(time.RFC3339))
 	device.Network.ApnID = nas.ApnID
@@ -27,6 +27,7 @@ import (
 
 	"github.com/ExploratoryEngineering/logging"
 	"github.com/eesrc/horde/pkg/deviceio/rxtx"
+	"github.com/eesrc/horde/pkg/metering"
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
@@ -192,9 +193,20 @@ func (r *RxTxReceiver) PutMessage(ctx context.Context, req *rxtx.UpstreamRequest
 		}
 	}
 
+	switch req.Msg.Type {
+	case rxtx.MessageType_UDP, rxtx.MessageType_CoAPPush, rxtx.MessageType_CoAPPull, rxtx.MessageType_CoAPUpstream:
+		// Only messages that are handled are counted
+		if !r.withinMessageBudget(device, model.Uplink) {
+			metrics.DefaultAPNCounters.MessageRejected(nasranges)
+			logging.Info("Message budget for device %d (collection %d) is exhausted. Discarding message", device.ID, device.CollectionID)
+			return &rxtx.DownstreamResponse{}, nil
+		}
+	}
+
 	switch req.Msg.Type {
 	case rxtx.MessageType_UDP:
 		metrics.DefaultAPNCounters.In(nasranges.APN, nas, model.UDPTransport)
+		metering.DefaultMeter.Uplink(device.CollectionID, model.UDPTransport, len(req.Msg.Payload))
 		return r.udpHandler(nasranges.APN.ID, nas.ID, &device, req.Redelivery, req.ExpectDownstream, req.Msg)
 
 	case rxtx.MessageType_CoAPPush:
@@ -204,6 +216,7 @@ func (r *RxTxReceiver) PutMessage(ctx context.Context, req *rxtx.UpstreamRequest
 		metrics.DefaultAPNCounters.In(nasranges.APN, nas, model.CoAPPullTransport)
 		fallthrough
 	case rxtx.MessageType_CoAPUpstream:
+		metering.DefaultMeter.Uplink(device.CollectionID, coapTransport(req.Msg.Type), len(req.Msg.Payload))
 		resp, handler, err := r.coapHandler(nasranges.APN.ID, nas.ID, &device, req)
 		if handler != nil {
 			r.addCallbackListener(resp.Msg.Id, handler)
@@ -219,6 +232,14 @@ func (r *RxTxReceiver) PutMessage(ctx context.Context, req *rxtx.UpstreamRequest
 	}
 }
 
+// coapTransport returns the transport used when metering CoAP messages
+func coapTransport(msgType rxtx.MessageType) model.MessageTransport {
+	if msgType == rxtx.MessageType_CoAPPull {
+		return model.CoAPPullTransport
+	}
+	return model.CoAPTransport
+}
+
 func (r *RxTxReceiver) addCallbackListener(msgID int64, handler ResponseCallback) {
 	r.mutex.Lock()
 	r.callbacks[msgID] = handler
@@ -490,6 +511,15 @@ func (r *RxTxReceiver) Send(ctx context.Context, device model.Device, msg *rxtx.
 		msgID, transport, buf); err != nil {
 		return rxtx.ErrorCode_INTERNAL, err
 	}
+	// The message is counted when it is stored. Messages that exceed the
+	// budget are removed before the listeners pick them up.
+	if !r.withinMessageBudget(device, model.Downlink) {
+		if err := r.downstreamStore.Delete(msgID); err != nil && err != storage.ErrNotFound {
+			logging.Warning("Unable to remove message %d exceeding the message budget: %v", msgID, err)
+		}
+		return rxtx.ErrorCode_CLIENT_ERROR, &model.QuotaExceededError{Resource: "downstream messages per day"}
+	}
+	metering.DefaultMeter.Downlink(device.CollectionID, transport, len(msg.Payload))
 
 	if msg.Type == rxtx.MessageType_CoAPPull {
 		return rxtx.ErrorCode_PENDING, nil
@@ -526,6 +556,18 @@ func (r *RxTxReceiver) Send(ctx context.Context, device model.Device, msg *rxtx.
 	return rxtx.ErrorCode_PENDING, nil
 }
 
+// withinMessageBudget counts the message for the team owning the device. It
+// returns false if the team's daily message budget is exhausted. Messages are
+// let through if the usage can't be counted.
+func (r *RxTxReceiver) withinMessageBudget(device model.Device, direction model.MessageDirection) bool {
+	ok, err := r.store.AddMessageUsage(device.CollectionID, direction, time.Now())
+	if err != nil {
+		logging.Warning("Unable to count message usage for device %d (collection %d): %v", device.ID, device.CollectionID, err)
+		return true
+	}
+	return ok
+}
+
 func (r *RxTxReceiver) notifySendError(apnID int) {
 	nasrange, ok := r.apnConfig.FindAPN(apnID)
 	if !ok {
@@ -563,9 +605,6 @@ func (r *RxTxReceiver) Exchange(ctx context.Context, device *model.Device, msg *
 		return nil, errors.New("not a coap message")
 	}
 
-	msgID := r.downstreamStore.NewMessageID()
-	msg.Id = int64(msgID)
-
 	// Generate a downstream message and set the token. The token is unique
 	// for this instance.
 	token, err := r.createToken()
@@ -573,8 +612,11 @@ func (r *RxTxReceiver) Exchange(ctx context.Context, device *model.Device, msg *
 		logging.Error("Could not create token for exchange: %v", err)
 		return nil, err
 	}
-	msg.Coap.Token = token
+	return r.exchange(ctx, device, msg, token)
+}
 
+// exchange sends the message with the token and waits for the response
+func (r *RxTxReceiver) exchange(ctx context.Context, device *model.Device, msg *rxtx.Message, token int64) (*rxtx.Message, error) {
 	msgChan := make(chan *rxtx.Message)
 	defer close(msgChan)
 
@@ -593,23 +635,137 @@ func (r *RxTxReceiver) Exchange(ctx context.Context, device *model.Device, msg *
 		}
 	})
 
-	buf, err := proto.Marshal(msg)
+	msgID, err := r.pushMessage(device, msg, token)
 	if err != nil {
 		return nil, err
 	}
+	defer r.downstreamStore.Delete(msgID)
+
+	select {
+	case m := <-msgChan:
+		return m, nil
+	case <-ctx.Done():
+		// The message is removed since it timed out
+		return nil, errors.New("message timed out")
+	}
+}
+
+// pushMessage stores the message in the downstream store where the listener
+// picks it up. The message should be removed when the exchange is done.
+func (r *RxTxReceiver) pushMessage(device *model.Device, msg *rxtx.Message, token int64) (model.MessageKey, error) {
+	msgID := r.downstreamStore.NewMessageID()
+	msg.Id = int64(msgID)
+	msg.Coap.Token = token
+
+	buf, err := proto.Marshal(msg)
+	if err != nil {
+		return msgID, err
+	}
 
 	// Ship the message
 	if err := r.downstreamStore.Create(device.Network.ApnID, device.Network.NasID, device.ID, msgID, model.CoAPTransport, buf); err != nil {
 		logging.Error("Could not create downstream message for exchange: %v", err)
+		return msgID, err
+	}
+	return msgID, nil
+}
+
+// ErrObserveRejected is returned by Observe when the device doesn't accept
+// the observation, ie the response doesn't include the observe option.
+var ErrObserveRejected = errors.New("observation rejected by device")
+
+// observeBufferSize is the number of notifications that are buffered for
+// an observation. Notifications are dropped if the observer falls behind.
+const observeBufferSize = 10
+
+// Observation is an active observation of a resource on a device. The
+// observation must be cancelled when it is no longer needed.
+type Observation struct {
+	// Response is the initial response from the device.
+	Response *rxtx.Message
+	// Notifications are the notifications from the device. The channel
+	// won't be closed when the observation is cancelled.
+	Notifications <-chan *rxtx.Message
+
+	receiver *RxTxReceiver
+	device   model.Device
+	msg      *rxtx.Message
+	token    int64
+}
+
+// Observe registers an observation of a resource on the device. The message
+// is sent as a GET request with the observe option set. ErrObserveRejected is
+// returned if the device responds without the observe option or with an
+// error code. The same restrictions apply as for Exchange.
+func (r *RxTxReceiver) Observe(ctx context.Context, device *model.Device, msg *rxtx.Message) (*Observation, error) {
+	if msg == nil || msg.Coap == nil {
+		return nil, errors.New("not a coap message")
+	}
+	if msg.Type != rxtx.MessageType_CoAPPush {
+		return nil, errors.New("must use coap push messages")
+	}
+	token, err := r.createToken()
+	if err != nil {
+		logging.Error("Could not create token for observation: %v", err)
+		return nil, err
+	}
+	msg.Coap.Code = int32(codes.GET)
+	msg.Coap.Observe = rxtx.Observe_Register
+
+	responseChan := make(chan *rxtx.Message, 1)
+	notificationChan := make(chan *rxtx.Message, observeBufferSize)
+
+	// The listeners are invoked while the mutex is held so the flag
+	// doesn't need any additional locking.
+	first := true
+	r.addUpstreamListener(token, func(m *rxtx.Message) {
+		if first {
+			first = false
+			responseChan <- m
+			return
+		}
+		select {
+		case notificationChan <- m:
+			// empty
+		default:
+			logging.Warning("Dropping notification for %s from device %s", msg.Coap.Path, device.ID.String())
+		}
+	})
+
+	msgID, err := r.pushMessage(device, msg, token)
+	if err != nil {
+		r.removeUpstreamListener(token)
 		return nil, err
 	}
+	defer r.downstreamStore.Delete(msgID)
+
 	select {
-	case m := <-msgChan:
-		r.downstreamStore.Delete(msgID)
-		return m, nil
+	case m := <-responseChan:
+		if m.Coap == nil || m.Coap.Code >= int32(codes.BadRequest) || m.Coap.Observe != rxtx.Observe_Register {
+			r.removeUpstreamListener(token)
+			return nil, ErrObserveRejected
+		}
+		return &Observation{
+			Response:      m,
+			Notifications: notificationChan,
+			receiver:      r,
+			device:        *device,
+			msg:           msg,
+			token:         token,
+		}, nil
 	case <-ctx.Done():
-		// Remove the message since it timed out
-		r.downstreamStore.Delete(msgID)
+		r.removeUpstreamListener(token)
 		return nil, errors.New("message timed out")
 	}
 }
+
+// Cancel deregisters the observation on the device and stops the
+// notifications.
+func (o *Observation) Cancel(ctx context.Context) error {
+	msg := proto.Clone(o.msg).(*rxtx.Message)
+	msg.Coap.Observe = rxtx.Observe_Deregister
+	// The exchange replaces the notification listener for the token and
+	// removes it when the device responds.
+	_, err := o.receiver.exchange(ctx, &o.device, msg, o.token)
+	return err
+}
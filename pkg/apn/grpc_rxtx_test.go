@@ -744,3 +744,104 @@ func TestExchange(t *testing.T) {
 	t.Log("Wait for exchange to complete")
 	wg.Wait()
 }
+
+// waitForPush waits for a push message to appear in GetMessage
+func waitForPush(ctx context.Context, assert *require.Assertions, r *RxTxReceiver) *rxtx.Message {
+	for {
+		res, err := r.GetMessage(ctx, &rxtx.DownstreamRequest{
+			Origin: &rxtx.Origin{ApnId: 1, NasId: []int32{1}},
+			Type:   rxtx.MessageType_CoAPPush,
+		})
+		assert.NoError(err)
+		if res.Msg != nil {
+			r.Ack(ctx, &rxtx.AckRequest{MessageId: res.Msg.Id, Result: rxtx.ErrorCode_SUCCESS})
+			return res.Msg
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// putResponse sends a response (or notification) from the device
+func putResponse(ctx context.Context, r *RxTxReceiver, token int64, observe rxtx.Observe, payload string) {
+	r.PutMessage(ctx, &rxtx.UpstreamRequest{
+		Origin: &rxtx.Origin{ApnId: 1, NasId: []int32{1}},
+		Msg: &rxtx.Message{
+			Type: rxtx.MessageType_CoAPUpstream,
+			Coap: &rxtx.CoAPOptions{
+				Code:    int32(codes.Content),
+				Token:   token,
+				Observe: observe,
+			},
+			RemoteAddress: net.ParseIP("10.0.0.1"),
+			RemotePort:    5683,
+			Payload:       []byte(payload),
+		},
+	})
+}
+
+func TestObserve(t *testing.T) {
+	defer purgeMessages()
+	assert := require.New(t)
+	r, d := setupCoap(assert, t)
+
+	ctx, done := context.WithTimeout(context.Background(), 2*time.Second)
+	defer done()
+
+	newMsg := func() *rxtx.Message {
+		return &rxtx.Message{
+			Type:          rxtx.MessageType_CoAPPush,
+			RemoteAddress: net.ParseIP("10.0.0.1"),
+			RemotePort:    5683,
+			Coap:          &rxtx.CoAPOptions{Path: "/5/0/3"},
+		}
+	}
+
+	_, err := r.Observe(ctx, &d, &rxtx.Message{Type: rxtx.MessageType_CoAPPush})
+	assert.Error(err)
+
+	// Device responds without the observe option
+	errCh := make(chan error)
+	go func() {
+		_, err := r.Observe(ctx, &d, newMsg())
+		errCh <- err
+	}()
+	msg := waitForPush(ctx, assert, r)
+	assert.Equal(rxtx.Observe_Register, msg.Coap.Observe)
+	assert.Equal(int32(codes.GET), msg.Coap.Code)
+	putResponse(ctx, r, msg.Coap.Token, rxtx.Observe_NoObserve, "0")
+	assert.Equal(ErrObserveRejected, <-errCh)
+
+	// Device accepts the observation
+	obsCh := make(chan *Observation)
+	go func() {
+		obs, err := r.Observe(ctx, &d, newMsg())
+		assert.NoError(err)
+		obsCh <- obs
+	}()
+	msg = waitForPush(ctx, assert, r)
+	token := msg.Coap.Token
+	putResponse(ctx, r, token, rxtx.Observe_Register, "1")
+	obs := <-obsCh
+	assert.NotNil(obs)
+	assert.Equal([]byte("1"), obs.Response.Payload)
+
+	putResponse(ctx, r, token, rxtx.Observe_Register, "2")
+	select {
+	case n := <-obs.Notifications:
+		assert.Equal([]byte("2"), n.Payload)
+	case <-ctx.Done():
+		assert.Fail("No notification received")
+	}
+
+	// Cancel the observation. The device gets a deregistration with the
+	// same token.
+	go func() {
+		errCh <- obs.Cancel(ctx)
+	}()
+	msg = waitForPush(ctx, assert, r)
+	assert.Equal(token, msg.Coap.Token)
+	assert.Equal(rxtx.Observe_Deregister, msg.Coap.Observe)
+	assert.Equal("/5/0/3", msg.Coap.Path)
+	putResponse(ctx, r, token, rxtx.Observe_NoObserve, "2")
+	assert.NoError(<-errCh)
+}
@@ -37,7 +37,9 @@ type CommandList struct {
 	NAS   NASCommand   `kong:"cmd,help='NAS subcommands'"`
 	Alloc AllocCommand `kong:"cmd,help='Device IP address allocations'"`
 	Token TokenCommand `kong:"cmd,help='API token management'"`
+	Quota QuotaCommand `kong:"cmd,help='Team quota management'"`
 	User  UserCommand  `kong:"cmd,help='User management'"`
+	Dump  DumpCommand  `kong:"cmd,help='Data dump management'"`
 	Util  UtilCommand  `kong:"cmd,help='Misc utiltiies'"`
 }
 
@@ -0,0 +1,82 @@
+package ctrlh
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/eesrc/horde/pkg/api/apitoolbox"
+	"github.com/eesrc/horde/pkg/managementproto"
+)
+
+// DumpCommand is the data dump subcommand
+type DumpCommand struct {
+	Restore restoreDumpCommand `kong:"cmd,help='Restore a data dump from the API in a team'"`
+}
+
+type restoreDumpCommand struct {
+	TeamID      string        `kong:"required,help='Team ID',short='t'"`
+	UserID      string        `kong:"required,help='User ID for the user restoring the dump',short='u'"`
+	File        string        `kong:"required,help='Data dump file (JSON or NDJSON)',short='f',type='existingfile'"`
+	IncludeData bool          `kong:"help='Restore the device data with the original timestamps'"`
+	Timeout     time.Duration `kong:"help='Timeout for the restore',default='5m'"`
+}
+
+func (c *restoreDumpCommand) Run(rc RunContext) error {
+	params := rc.HordeCommands().Dump.Restore
+	buf, err := ioutil.ReadFile(params.File)
+	if err != nil {
+		fmt.Printf("Unable to read %s: %v\n", params.File, err)
+		return errStd
+	}
+	// Check the dump before it is sent. Downloaded dumps that are truncated
+	// are refused.
+	if _, err := apitoolbox.ReadDataDump(buf); err != nil {
+		fmt.Printf("Invalid data dump in %s: %v\n", params.File, err)
+		return errStd
+	}
+
+	service := connectToManagementServer(rc.HordeServer())
+	if service == nil {
+		return errStd
+	}
+	ctx, done := context.WithTimeout(context.Background(), params.Timeout)
+	defer done()
+
+	resp, err := service.DataRestore(ctx, &managementproto.DataRestoreRequest{
+		TeamId:      params.TeamID,
+		UserId:      params.UserID,
+		Dump:        buf,
+		IncludeData: params.IncludeData,
+	})
+	for _, v := range resp.GetConflicts() {
+		fmt.Println(v)
+	}
+	if err := checkServiceResponse(resp.GetResult(), err); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %d collections in team %s\n", len(resp.CollectionIds), params.TeamID)
+	for _, v := range resp.CollectionIds {
+		fmt.Printf("  %s\n", v)
+	}
+	fmt.Printf("%d devices, %d outputs (disabled), %d firmware images (metadata only), %d messages\n",
+		resp.Devices, resp.Outputs, resp.Firmware, resp.Messages)
+	return nil
+}
@@ -0,0 +1,106 @@
+package ctrlh
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"context"
+	"fmt"
+
+	"github.com/eesrc/horde/pkg/managementproto"
+)
+
+// QuotaCommand is the quota subcommand
+type QuotaCommand struct {
+	Set setQuotaCommand `kong:"cmd,help='Set the resource quota for a team'"`
+	Get getQuotaCommand `kong:"cmd,help='Show the resource quota and usage for a team'"`
+}
+
+type setQuotaCommand struct {
+	TeamID            string `kong:"required,help='Team ID',short='t'"`
+	MaxDevices        int32  `kong:"help='Maximum number of devices, 0 is unlimited'"`
+	MaxCollections    int32  `kong:"help='Maximum number of collections, 0 is unlimited'"`
+	MaxOutputs        int32  `kong:"help='Maximum number of outputs, 0 is unlimited'"`
+	MaxFirmwareBytes  int64  `kong:"help='Maximum total size of firmware images, 0 is unlimited'"`
+	MaxUplinkPerDay   int32  `kong:"help='Maximum number of upstream messages per day, 0 is unlimited'"`
+	MaxDownlinkPerDay int32  `kong:"help='Maximum number of downstream messages per day, 0 is unlimited'"`
+}
+
+func (c *setQuotaCommand) Run(rc RunContext) error {
+	service := connectToManagementServer(rc.HordeServer())
+	if service == nil {
+		return errStd
+	}
+	ctx, done := context.WithTimeout(context.Background(), grpcServerTimeout)
+	defer done()
+
+	params := rc.HordeCommands().Quota.Set
+	resp, err := service.SetTeamQuota(ctx, &managementproto.SetTeamQuotaRequest{
+		TeamId: params.TeamID,
+		Quota: &managementproto.TeamQuota{
+			MaxDevices:        params.MaxDevices,
+			MaxCollections:    params.MaxCollections,
+			MaxOutputs:        params.MaxOutputs,
+			MaxFirmwareBytes:  params.MaxFirmwareBytes,
+			MaxUplinkPerDay:   params.MaxUplinkPerDay,
+			MaxDownlinkPerDay: params.MaxDownlinkPerDay,
+		},
+	})
+	if err := checkServiceResponse(resp.GetResult(), err); err != nil {
+		return err
+	}
+
+	fmt.Printf("Quota updated for team %s\n", params.TeamID)
+	return nil
+}
+
+type getQuotaCommand struct {
+	TeamID string `kong:"required,help='Team ID',short='t'"`
+}
+
+// limitString formats a quota limit. Zero limits are unlimited.
+func limitString(limit int64) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", limit)
+}
+
+func (c *getQuotaCommand) Run(rc RunContext) error {
+	service := connectToManagementServer(rc.HordeServer())
+	if service == nil {
+		return errStd
+	}
+	ctx, done := context.WithTimeout(context.Background(), grpcServerTimeout)
+	defer done()
+
+	resp, err := service.GetTeamQuota(ctx, &managementproto.GetTeamQuotaRequest{
+		TeamId: rc.HordeCommands().Quota.Get.TeamID,
+	})
+	if err := checkServiceResponse(resp.GetResult(), err); err != nil {
+		return err
+	}
+
+	q := resp.Quota
+	u := resp.Usage
+	fmt.Printf("%-20s %-12s %s\n", "Resource", "Usage", "Limit")
+	fmt.Printf("%-20s %-12d %s\n", "Devices", u.Devices, limitString(int64(q.MaxDevices)))
+	fmt.Printf("%-20s %-12d %s\n", "Collections", u.Collections, limitString(int64(q.MaxCollections)))
+	fmt.Printf("%-20s %-12d %s\n", "Outputs", u.Outputs, limitString(int64(q.MaxOutputs)))
+	fmt.Printf("%-20s %-12d %s\n", "Firmware bytes", u.FirmwareBytes, limitString(q.MaxFirmwareBytes))
+	fmt.Printf("%-20s %-12d %s\n", "Uplink today", u.UplinkToday, limitString(int64(q.MaxUplinkPerDay)))
+	fmt.Printf("%-20s %-12d %s\n", "Downlink today", u.DownlinkToday, limitString(int64(q.MaxDownlinkPerDay)))
+	return nil
+}
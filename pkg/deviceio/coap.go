@@ -46,6 +46,11 @@ const (
 	// This is the error class code for CoAP (b01000000). Since all errors have
 	// the 2nd bit set all values above this is errors
 	coapErrorCode = codes.Code(0x80)
+
+	// These are the values for the observe option (6) when registering and
+	// deregistering observations (RFC 7641)
+	observeRegister   = 0
+	observeDeregister = 1
 )
 
 // CoAPServer is the CoAP listener and server. It proxies requests to the gRPC
@@ -158,6 +163,15 @@ func (c *CoAPServer) getToken(token []byte) int64 {
 	}
 }
 
+// getObserve returns Register if the observe option is set in the message,
+// ie when the device accepts an observation or sends a notification.
+func (c *CoAPServer) getObserve(msg coap.Message) rxtx.Observe {
+	if msg.Option(coap.Observe) != nil {
+		return rxtx.Observe_Register
+	}
+	return rxtx.Observe_NoObserve
+}
+
 // isRequest returns true if the message is a request initiated by the other
 // side. For some weird reason this isn't implemented by the coap library
 func (c *CoAPServer) isRequest(code codes.Code) bool {
@@ -197,6 +211,7 @@ func (c *CoAPServer) defaultHandler(w coap.ResponseWriter, r *coap.Request) {
 			Path:     r.Msg.PathString(),
 			UriQuery: r.Msg.Query(),
 			Token:    c.getToken(r.Msg.Token()),
+			Observe:  c.getObserve(r.Msg),
 		},
 	}
 	upstream := &upstreamData{Msg: msg}
@@ -384,6 +399,12 @@ func (c *CoAPServer) sendPushMessage(msg rxtx.Message) {
 		cm.SetOption(coap.URIQuery, msg.Coap.UriQuery)
 	}
 	cm.SetPathString(msg.Coap.Path)
+	switch msg.Coap.Observe {
+	case rxtx.Observe_Register:
+		cm.SetOption(coap.Observe, observeRegister)
+	case rxtx.Observe_Deregister:
+		cm.SetOption(coap.Observe, observeDeregister)
+	}
 	ctx, done := context.WithTimeout(context.Background(), timeout)
 	defer done()
 
@@ -412,9 +433,10 @@ func (c *CoAPServer) sendPushMessage(msg rxtx.Message) {
 			Type:          rxtx.MessageType_CoAPUpstream,
 			Payload:       res.Payload(),
 			Coap: &rxtx.CoAPOptions{
-				Token: c.getToken(res.Token()),
-				Path:  res.PathString(),
-				Code:  int32(res.Code()),
+				Token:   c.getToken(res.Token()),
+				Path:    res.PathString(),
+				Code:    int32(res.Code()),
+				Observe: c.getObserve(res),
 			},
 		},
 	})
@@ -517,7 +539,9 @@ func (c *CoAPServer) sendBlankResponse(w coap.ResponseWriter, r *coap.Request) {
 	case codes.DELETE:
 		responseCode = codes.Deleted
 	default:
-		responseCode = codes.Valid
+		// Notifications from observed resources are responses. They are
+		// acknowledged with an empty message.
+		responseCode = codes.Empty
 	}
 
 	msg := w.NewResponse(responseCode)
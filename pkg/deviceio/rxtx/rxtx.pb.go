@@ -62,6 +62,37 @@ func (MessageType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_718277bfb8eee15a, []int{0}
 }
 
+// Observe is the CoAP Observe option (6) in push messages. The listener sets
+// Register on responses and notifications from the device that include the
+// option, ie when the device has accepted the observation.
+type Observe int32
+
+const (
+	Observe_NoObserve  Observe = 0
+	Observe_Register   Observe = 1
+	Observe_Deregister Observe = 2
+)
+
+var Observe_name = map[int32]string{
+	0: "NoObserve",
+	1: "Register",
+	2: "Deregister",
+}
+
+var Observe_value = map[string]int32{
+	"NoObserve":  0,
+	"Register":   1,
+	"Deregister": 2,
+}
+
+func (x Observe) String() string {
+	return proto.EnumName(Observe_name, int32(x))
+}
+
+func (Observe) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_718277bfb8eee15a, []int{1}
+}
+
 type ErrorCode int32
 
 const (
@@ -105,7 +136,7 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_718277bfb8eee15a, []int{1}
+	return fileDescriptor_718277bfb8eee15a, []int{2}
 }
 
 type UDPOptions struct {
@@ -149,6 +180,7 @@ type CoAPOptions struct {
 	Accept               int32    `protobuf:"varint,7,opt,name=accept,proto3" json:"accept,omitempty"`
 	Token                int64    `protobuf:"varint,9,opt,name=token,proto3" json:"token,omitempty"`
 	TimeoutSeconds       int32    `protobuf:"varint,10,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	Observe              Observe  `protobuf:"varint,11,opt,name=observe,proto3,enum=rxtx.Observe" json:"observe,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -242,6 +274,13 @@ func (m *CoAPOptions) GetTimeoutSeconds() int32 {
 	return 0
 }
 
+func (m *CoAPOptions) GetObserve() Observe {
+	if m != nil {
+		return m.Observe
+	}
+	return Observe_NoObserve
+}
+
 type Message struct {
 	Id                   int64        `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Type                 MessageType  `protobuf:"varint,2,opt,name=type,proto3,enum=rxtx.MessageType" json:"type,omitempty"`
@@ -801,6 +840,7 @@ func (m *AccessResponse) GetMessage() string {
 
 func init() {
 	proto.RegisterEnum("rxtx.MessageType", MessageType_name, MessageType_value)
+	proto.RegisterEnum("rxtx.Observe", Observe_name, Observe_value)
 	proto.RegisterEnum("rxtx.ErrorCode", ErrorCode_name, ErrorCode_value)
 	proto.RegisterType((*UDPOptions)(nil), "rxtx.UDPOptions")
 	proto.RegisterType((*CoAPOptions)(nil), "rxtx.CoAPOptions")
@@ -818,71 +858,74 @@ func init() {
 func init() { proto.RegisterFile("rxtx.proto", fileDescriptor_718277bfb8eee15a) }
 
 var fileDescriptor_718277bfb8eee15a = []byte{
-	// 1024 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x41, 0x73, 0xdb, 0x44,
-	0x14, 0xae, 0x2d, 0x5b, 0xb6, 0x9e, 0xed, 0x44, 0x59, 0x5a, 0xd0, 0x04, 0x4a, 0x3d, 0xa2, 0x9d,
-	0x66, 0x0a, 0xd3, 0x83, 0x3b, 0x70, 0xeb, 0x30, 0x9a, 0x58, 0x04, 0x0f, 0x89, 0xed, 0x6e, 0xe4,
-	0xe1, 0x28, 0x84, 0xb4, 0x49, 0x34, 0xb1, 0xb4, 0xaa, 0x76, 0xdd, 0x26, 0xfc, 0x03, 0x2e, 0xfc,
-	0x0b, 0x2e, 0xdc, 0xe0, 0x17, 0x32, 0x6f, 0x77, 0xe5, 0x38, 0x03, 0x0c, 0x70, 0xd3, 0xfb, 0xbe,
-	0x7d, 0x6f, 0x77, 0xbf, 0xf7, 0xbe, 0x15, 0x40, 0x7d, 0x23, 0x6f, 0x5e, 0x56, 0x35, 0x97, 0x9c,
-	0x74, 0xf0, 0xdb, 0x1f, 0x02, 0xac, 0xa6, 0xcb, 0x45, 0x25, 0x73, 0x5e, 0x0a, 0xff, 0xe7, 0x36,
-	0x0c, 0x8e, 0x79, 0xd0, 0xc4, 0x84, 0x40, 0x27, 0xe5, 0x19, 0xf3, 0x5a, 0xe3, 0xd6, 0x51, 0x97,
-	0xaa, 0x6f, 0xc4, 0xe4, 0x6d, 0xc5, 0xbc, 0xb6, 0xc6, 0xf0, 0x9b, 0x7c, 0x06, 0xa3, 0x35, 0x4f,
-	0x13, 0x4c, 0x8a, 0xab, 0x44, 0x5e, 0x79, 0xd6, 0xd8, 0x3a, 0x72, 0xe8, 0xb0, 0x01, 0x97, 0x89,
-	0xbc, 0xc2, 0x44, 0xc5, 0x75, 0xc6, 0xad, 0x23, 0x87, 0xaa, 0x6f, 0xf2, 0x0c, 0xf6, 0x52, 0x5e,
-	0x4a, 0x56, 0xca, 0xf8, 0x82, 0xd7, 0x45, 0x22, 0xbd, 0xae, 0x2a, 0x3b, 0x32, 0xe8, 0x37, 0x0a,
-	0x24, 0x1f, 0x83, 0xb3, 0xa9, 0xf3, 0xf8, 0xed, 0x86, 0xd5, 0xb7, 0x9e, 0xad, 0x6a, 0xf7, 0x37,
-	0x75, 0xfe, 0x06, 0x63, 0xf2, 0x21, 0xd8, 0x49, 0x9a, 0xb2, 0x4a, 0x7a, 0x3d, 0x95, 0x6b, 0x22,
-	0xf2, 0x10, 0xba, 0x92, 0x5f, 0xb3, 0xd2, 0x73, 0xc6, 0xad, 0x23, 0x8b, 0xea, 0x80, 0x3c, 0x87,
-	0x7d, 0x99, 0x17, 0x8c, 0x6f, 0x64, 0x2c, 0x58, 0xca, 0xcb, 0x4c, 0x78, 0xa0, 0xd2, 0xf6, 0x0c,
-	0x7c, 0xae, 0x51, 0xff, 0xb7, 0x36, 0xf4, 0xce, 0x98, 0x10, 0xc9, 0x25, 0x23, 0x7b, 0xd0, 0xce,
-	0x33, 0xa5, 0x82, 0x45, 0xdb, 0x79, 0x46, 0x9e, 0xed, 0x68, 0xb0, 0x37, 0x39, 0x78, 0xa9, 0x64,
-	0x35, 0x8b, 0xa3, 0xdb, 0x8a, 0x19, 0x59, 0x3e, 0x01, 0x07, 0x8b, 0x0a, 0x99, 0x14, 0x95, 0x67,
-	0xa9, 0xec, 0x3b, 0x00, 0xef, 0x5e, 0xb3, 0x82, 0x4b, 0x16, 0x27, 0x59, 0x56, 0x33, 0x21, 0x94,
-	0x32, 0x43, 0x3a, 0xd2, 0x68, 0xa0, 0x41, 0xf2, 0x04, 0x06, 0x66, 0x59, 0xc5, 0xeb, 0x46, 0x1f,
-	0xd0, 0xd0, 0x92, 0xd7, 0x92, 0x3c, 0x06, 0x40, 0x9d, 0xd7, 0x9a, 0xb7, 0x15, 0xef, 0x28, 0x44,
-	0xd1, 0x1e, 0xf4, 0xaa, 0xe4, 0x76, 0xcd, 0x93, 0x4c, 0xe9, 0x33, 0xa4, 0x4d, 0x88, 0xb7, 0x48,
-	0x79, 0x52, 0x79, 0xfd, 0x71, 0xeb, 0x68, 0xd0, 0xdc, 0x62, 0xa7, 0xfd, 0x54, 0xd1, 0xc4, 0x07,
-	0x6b, 0x93, 0x55, 0x4a, 0xc5, 0xc1, 0xc4, 0xd5, 0xab, 0xee, 0x66, 0x86, 0x22, 0xe9, 0x7f, 0x05,
-	0xf6, 0xa2, 0xce, 0x2f, 0xf3, 0x92, 0x3c, 0x02, 0x3b, 0xa9, 0xca, 0xd8, 0xc8, 0xd5, 0xa5, 0xdd,
-	0xa4, 0x2a, 0x67, 0x19, 0xc2, 0x65, 0x22, 0x10, 0x6e, 0x8f, 0x2d, 0x84, 0xcb, 0x44, 0xcc, 0x32,
-	0xff, 0xd7, 0x16, 0xec, 0xaf, 0x2a, 0x21, 0x6b, 0x96, 0x14, 0x94, 0xbd, 0xdd, 0x30, 0x21, 0xc9,
-	0x53, 0xb0, 0xb9, 0xaa, 0xa5, 0x2a, 0x0c, 0x26, 0x43, 0xbd, 0xa5, 0xae, 0x4f, 0x0d, 0x47, 0x3e,
-	0x05, 0xa8, 0x59, 0xc6, 0xd6, 0xf9, 0x3b, 0x9c, 0x09, 0x6c, 0x44, 0x9f, 0xee, 0x20, 0xe4, 0x09,
-	0x58, 0x85, 0xb8, 0x54, 0x72, 0x0d, 0x26, 0xa3, 0x7b, 0x1d, 0xa2, 0xc8, 0x90, 0xcf, 0xe1, 0x80,
-	0xdd, 0x54, 0x2c, 0x95, 0x71, 0xc6, 0xdf, 0x97, 0xfa, 0x08, 0x4a, 0xbd, 0x3e, 0x75, 0x35, 0x31,
-	0xdd, 0xe2, 0xfe, 0x97, 0x40, 0xee, 0x22, 0xca, 0x44, 0xc5, 0x4b, 0xc1, 0x9a, 0x3d, 0xda, 0xff,
-	0xb4, 0x87, 0xff, 0x03, 0x1c, 0xec, 0xa6, 0xfd, 0x9f, 0xfb, 0xfd, 0xb7, 0x11, 0xf3, 0x05, 0x40,
-	0x90, 0x5e, 0x37, 0xa5, 0x1f, 0x03, 0x14, 0x7a, 0x49, 0xbc, 0x9d, 0x57, 0xc7, 0x20, 0xb3, 0x8c,
-	0x3c, 0x07, 0x9b, 0x32, 0xb1, 0x59, 0x4b, 0x53, 0x75, 0x5f, 0x57, 0x0d, 0xeb, 0x9a, 0xd7, 0xc7,
-	0x3c, 0x63, 0xd4, 0xd0, 0x58, 0x07, 0x5b, 0x1f, 0x6b, 0xff, 0x98, 0xc9, 0x45, 0x24, 0x42, 0xc0,
-	0x1f, 0xc1, 0x40, 0x6d, 0xaa, 0x65, 0xf0, 0x7f, 0x6f, 0xc3, 0x28, 0x48, 0x53, 0x26, 0x44, 0x73,
-	0x0e, 0x02, 0x9d, 0xbc, 0x10, 0xb9, 0x39, 0x81, 0xfa, 0xc6, 0x71, 0xd7, 0x13, 0xc0, 0x4a, 0x99,
-	0x5f, 0xe4, 0xac, 0x56, 0x87, 0x70, 0xe8, 0x48, 0x4d, 0x42, 0x03, 0x92, 0x43, 0xe8, 0x6f, 0x04,
-	0xab, 0xcb, 0xa4, 0x60, 0x6a, 0x63, 0x74, 0xba, 0x89, 0x91, 0xab, 0x12, 0x21, 0xde, 0xf3, 0x3a,
-	0x33, 0x5e, 0xd9, 0xc6, 0xe4, 0x0b, 0x20, 0xb8, 0x2e, 0xde, 0xbe, 0x43, 0x79, 0x79, 0xc1, 0x55,
-	0xfb, 0x87, 0xd4, 0x45, 0xe6, 0xd4, 0x10, 0xb3, 0xf2, 0x82, 0x93, 0x31, 0x0c, 0xf1, 0x50, 0x71,
-	0x91, 0xa6, 0x71, 0x51, 0xa6, 0xaa, 0xef, 0x0e, 0x05, 0xc4, 0xce, 0xd2, 0xf4, 0xac, 0x4c, 0xd1,
-	0x76, 0x85, 0x88, 0xd1, 0xad, 0x3f, 0xf1, 0x92, 0x19, 0xeb, 0x40, 0x21, 0x22, 0x83, 0xe0, 0xb3,
-	0x93, 0x17, 0x2c, 0x17, 0xef, 0x94, 0x7f, 0x1c, 0x6a, 0x22, 0xf2, 0xd4, 0xdc, 0xb3, 0xda, 0xda,
-	0xda, 0x51, 0xb9, 0x43, 0xbc, 0x67, 0x65, 0x5c, 0xed, 0x33, 0xd8, 0x6b, 0x24, 0x33, 0xc3, 0x74,
-	0x08, 0x7d, 0xfd, 0x70, 0x31, 0xdd, 0xb9, 0x3e, 0xdd, 0xc6, 0xd8, 0x8f, 0x9d, 0x7a, 0x6d, 0x55,
-	0xcf, 0xc9, 0x9b, 0x62, 0x68, 0x71, 0xd3, 0x64, 0x23, 0x59, 0x13, 0xbe, 0x78, 0x03, 0x83, 0x9d,
-	0x99, 0x21, 0x3d, 0xb0, 0x56, 0xd3, 0xa5, 0xfb, 0x80, 0xb8, 0x30, 0x44, 0xa3, 0x37, 0xd6, 0x73,
-	0x5b, 0x64, 0x08, 0x7d, 0x44, 0x96, 0x9b, 0xf5, 0xda, 0x6d, 0xdf, 0x45, 0xe2, 0xca, 0xb5, 0xc8,
-	0x00, 0x7a, 0xab, 0xa9, 0xa6, 0x3a, 0x2f, 0x7e, 0x69, 0x81, 0xb3, 0x9d, 0x18, 0xa4, 0xce, 0x57,
-	0xc7, 0xc7, 0xe1, 0xf9, 0xb9, 0xfb, 0x80, 0x8c, 0xc0, 0x89, 0x16, 0x8b, 0xf8, 0x34, 0xa0, 0x27,
-	0xa1, 0xdb, 0x42, 0x6e, 0x1e, 0x46, 0xdf, 0x2f, 0xe8, 0x77, 0x6e, 0x9b, 0xec, 0xc3, 0x60, 0xbe,
-	0x88, 0xe2, 0x6f, 0x83, 0xf9, 0xf4, 0x34, 0x9c, 0xba, 0x96, 0x3a, 0xc2, 0xe9, 0x2c, 0x9c, 0x47,
-	0x71, 0x48, 0xe9, 0x82, 0xba, 0x1d, 0x4c, 0x5f, 0x06, 0x34, 0x38, 0x0b, 0xa3, 0x90, 0xba, 0x5d,
-	0x3c, 0xc3, 0x6c, 0x1e, 0x85, 0x74, 0x1e, 0x9c, 0xba, 0x36, 0x16, 0x5b, 0x86, 0xf3, 0xe9, 0x6c,
-	0x7e, 0xe2, 0xf6, 0x30, 0x88, 0x66, 0x67, 0xe1, 0x62, 0x15, 0xb9, 0xfd, 0xc9, 0x1f, 0x2d, 0xe8,
-	0xd0, 0x1b, 0x79, 0x43, 0x5e, 0x03, 0x2c, 0x37, 0xb2, 0x79, 0xb3, 0x1f, 0x99, 0x97, 0xea, 0xfe,
-	0xeb, 0x72, 0xe8, 0x69, 0xf8, 0x6f, 0xdc, 0xfc, 0x35, 0xc0, 0x09, 0xdb, 0xa6, 0x7f, 0xf4, 0xd7,
-	0x75, 0xff, 0x56, 0xe0, 0x05, 0x58, 0x41, 0x7a, 0x4d, 0xcc, 0x13, 0x79, 0x67, 0xcb, 0xc3, 0x83,
-	0x1d, 0x44, 0xaf, 0x9d, 0xbc, 0x06, 0x9b, 0x06, 0xd3, 0xd9, 0xea, 0x9c, 0xbc, 0x02, 0x5b, 0x4f,
-	0x02, 0xf9, 0xa0, 0x59, 0xb6, 0x63, 0xa5, 0xc3, 0x87, 0xf7, 0x41, 0x9d, 0xfe, 0xa3, 0xad, 0xfe,
-	0xe1, 0xaf, 0xfe, 0x0c, 0x00, 0x00, 0xff, 0xff, 0x65, 0x05, 0xb5, 0x57, 0xd1, 0x07, 0x00, 0x00,
+	// 1068 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x5b, 0x73, 0xdb, 0x44,
+	0x14, 0xae, 0x24, 0xdf, 0x74, 0x7c, 0x89, 0xb2, 0xb4, 0xa0, 0x09, 0x94, 0x7a, 0x44, 0x3b, 0xf1,
+	0x04, 0xa6, 0x0f, 0xee, 0xd0, 0xb7, 0x0e, 0xe3, 0x89, 0x45, 0xf0, 0x90, 0xd8, 0xee, 0x46, 0x1e,
+	0x1e, 0x85, 0x2a, 0x6d, 0x12, 0x4d, 0x2c, 0xad, 0xba, 0xbb, 0x4e, 0x13, 0x7e, 0x04, 0xff, 0x82,
+	0x17, 0xde, 0xe8, 0x2f, 0x64, 0xf6, 0x22, 0xc7, 0x19, 0x60, 0x80, 0x37, 0x9d, 0xef, 0x3b, 0xe7,
+	0xdb, 0xdd, 0x73, 0x1b, 0x01, 0xb0, 0x5b, 0x71, 0xfb, 0xb2, 0x62, 0x54, 0x50, 0xd4, 0x90, 0xdf,
+	0x41, 0x0f, 0x60, 0x35, 0x5d, 0x2e, 0x2a, 0x91, 0xd3, 0x92, 0x07, 0x1f, 0x6d, 0xe8, 0x1e, 0xd3,
+	0x49, 0x6d, 0x23, 0x04, 0x8d, 0x94, 0x66, 0xc4, 0xb7, 0x86, 0xd6, 0xa8, 0x89, 0xd5, 0xb7, 0xc4,
+	0xc4, 0x5d, 0x45, 0x7c, 0x5b, 0x63, 0xf2, 0x1b, 0x7d, 0x05, 0xfd, 0x35, 0x4d, 0x13, 0x19, 0x14,
+	0x57, 0x89, 0xb8, 0xf2, 0x9d, 0xa1, 0x33, 0x72, 0x71, 0xaf, 0x06, 0x97, 0x89, 0xb8, 0x92, 0x81,
+	0x8a, 0x6b, 0x0c, 0xad, 0x91, 0x8b, 0xd5, 0x37, 0x7a, 0x01, 0x83, 0x94, 0x96, 0x82, 0x94, 0x22,
+	0xbe, 0xa0, 0xac, 0x48, 0x84, 0xdf, 0x54, 0xb2, 0x7d, 0x83, 0x7e, 0xaf, 0x40, 0xf4, 0x39, 0xb8,
+	0x1b, 0x96, 0xc7, 0xef, 0x37, 0x84, 0xdd, 0xf9, 0x2d, 0xa5, 0xdd, 0xd9, 0xb0, 0xfc, 0xad, 0xb4,
+	0xd1, 0xa7, 0xd0, 0x4a, 0xd2, 0x94, 0x54, 0xc2, 0x6f, 0xab, 0x58, 0x63, 0xa1, 0xc7, 0xd0, 0x14,
+	0xf4, 0x9a, 0x94, 0xbe, 0x3b, 0xb4, 0x46, 0x0e, 0xd6, 0x06, 0x3a, 0x84, 0x3d, 0x91, 0x17, 0x84,
+	0x6e, 0x44, 0xcc, 0x49, 0x4a, 0xcb, 0x8c, 0xfb, 0xa0, 0xc2, 0x06, 0x06, 0x3e, 0xd7, 0x28, 0x3a,
+	0x84, 0x36, 0x7d, 0xc7, 0x09, 0xbb, 0x21, 0x7e, 0x77, 0x68, 0x8d, 0x06, 0xe3, 0xfe, 0x4b, 0x95,
+	0xbd, 0x85, 0x06, 0x71, 0xcd, 0x06, 0xbf, 0xdb, 0xd0, 0x3e, 0x23, 0x9c, 0x27, 0x97, 0x04, 0x0d,
+	0xc0, 0xce, 0x33, 0x95, 0x2e, 0x07, 0xdb, 0x79, 0x86, 0x5e, 0xec, 0x24, 0x6b, 0x30, 0xde, 0xd7,
+	0x0a, 0xc6, 0x39, 0xba, 0xab, 0x88, 0xc9, 0xdf, 0x17, 0xe0, 0xca, 0xd3, 0xb9, 0x48, 0x8a, 0xca,
+	0x77, 0x54, 0xf4, 0x3d, 0x20, 0x93, 0xc4, 0x48, 0x41, 0x05, 0x89, 0x93, 0x2c, 0x63, 0x84, 0x73,
+	0x95, 0xc2, 0x1e, 0xee, 0x6b, 0x74, 0xa2, 0x41, 0xf4, 0x0c, 0xba, 0xc6, 0xad, 0xa2, 0xac, 0x4e,
+	0x24, 0x68, 0x68, 0x49, 0x99, 0x40, 0x4f, 0x01, 0x64, 0x41, 0xd6, 0x9a, 0x6f, 0x29, 0xde, 0x55,
+	0x88, 0xa2, 0x7d, 0x68, 0x57, 0xc9, 0xdd, 0x9a, 0x26, 0x99, 0x4a, 0x64, 0x0f, 0xd7, 0xa6, 0x7c,
+	0x45, 0x4a, 0x93, 0xca, 0xef, 0x0c, 0xad, 0x51, 0xb7, 0x7e, 0xc5, 0x4e, 0x9f, 0x60, 0x45, 0xa3,
+	0x00, 0x9c, 0x4d, 0x56, 0xa9, 0x74, 0x77, 0xc7, 0x9e, 0xf6, 0xba, 0x6f, 0x2e, 0x2c, 0xc9, 0xe0,
+	0x35, 0xb4, 0x16, 0x2c, 0xbf, 0xcc, 0x4b, 0xf4, 0x04, 0x5a, 0x49, 0x55, 0xc6, 0x26, 0x5d, 0x4d,
+	0xdc, 0x4c, 0xaa, 0x72, 0x96, 0x49, 0xb8, 0x4c, 0xb8, 0x84, 0xed, 0xa1, 0x23, 0xe1, 0x32, 0xe1,
+	0xb3, 0x2c, 0xf8, 0xcd, 0x82, 0xbd, 0x55, 0xc5, 0x05, 0x23, 0x49, 0x81, 0xc9, 0xfb, 0x0d, 0xe1,
+	0x02, 0x3d, 0x87, 0x16, 0x55, 0x5a, 0x4a, 0xa1, 0x3b, 0xee, 0x99, 0x02, 0x29, 0x0c, 0x1b, 0x0e,
+	0x7d, 0x09, 0xc0, 0x48, 0x46, 0xd6, 0xf9, 0x8d, 0x6c, 0x1e, 0x59, 0x88, 0x0e, 0xde, 0x41, 0xd0,
+	0x33, 0x70, 0x0a, 0x7e, 0xa9, 0xd2, 0xd5, 0x1d, 0xf7, 0x1f, 0x54, 0x08, 0x4b, 0x06, 0x7d, 0x0d,
+	0xfb, 0xe4, 0xb6, 0x22, 0xa9, 0x88, 0x33, 0xfa, 0xa1, 0xd4, 0x57, 0x50, 0xd9, 0xeb, 0x60, 0x4f,
+	0x13, 0xd3, 0x2d, 0x1e, 0x7c, 0x0b, 0xe8, 0xde, 0xc2, 0x84, 0x57, 0xb4, 0xe4, 0xa4, 0x3e, 0xc3,
+	0xfe, 0xa7, 0x33, 0x82, 0x9f, 0x61, 0x7f, 0x37, 0xec, 0xff, 0xbc, 0xef, 0xbf, 0xb5, 0x58, 0xc0,
+	0x01, 0x26, 0xe9, 0x75, 0x2d, 0xfd, 0x14, 0xa0, 0xd0, 0x2e, 0xf1, 0xb6, 0x5f, 0x5d, 0x83, 0xcc,
+	0x32, 0x74, 0x08, 0x2d, 0x4c, 0xf8, 0x66, 0x2d, 0x8c, 0xea, 0x9e, 0x56, 0x0d, 0x19, 0xa3, 0xec,
+	0x98, 0x66, 0x04, 0x1b, 0x5a, 0xea, 0xc8, 0xd2, 0xc7, 0x7a, 0xd0, 0x4c, 0xe7, 0x4a, 0x24, 0x92,
+	0x40, 0xd0, 0x87, 0xae, 0x3a, 0x54, 0xa7, 0x21, 0xf8, 0xc3, 0x86, 0xfe, 0x24, 0x4d, 0x09, 0xe7,
+	0xf5, 0x3d, 0x10, 0x34, 0xf2, 0x82, 0xe7, 0xe6, 0x06, 0xea, 0x5b, 0xb6, 0xbb, 0xee, 0x00, 0x52,
+	0x8a, 0xfc, 0x22, 0x27, 0x4c, 0x5d, 0xc2, 0xc5, 0x7d, 0xd5, 0x09, 0x35, 0x88, 0x0e, 0xa0, 0xb3,
+	0xe1, 0x84, 0x95, 0x49, 0x41, 0xd4, 0xc1, 0x2e, 0xde, 0xda, 0x92, 0xab, 0x12, 0xce, 0x3f, 0x50,
+	0x96, 0x99, 0x59, 0xd9, 0xda, 0xe8, 0x1b, 0x40, 0xd2, 0x2f, 0xde, 0x2e, 0xac, 0xbc, 0xbc, 0xa0,
+	0xaa, 0xfc, 0x3d, 0xec, 0x49, 0xe6, 0xd4, 0x10, 0xb3, 0xf2, 0x82, 0xa2, 0x21, 0xf4, 0xe4, 0xa5,
+	0xe2, 0x22, 0x4d, 0xe3, 0xa2, 0x4c, 0x55, 0xdd, 0x5d, 0x0c, 0x12, 0x3b, 0x4b, 0xd3, 0xb3, 0x32,
+	0x95, 0x63, 0x57, 0xf0, 0x58, 0x4e, 0xeb, 0x2f, 0xb4, 0x24, 0x66, 0x74, 0xa0, 0xe0, 0x91, 0x41,
+	0xe4, 0x7e, 0xca, 0x0b, 0x92, 0xf3, 0x1b, 0x35, 0x3f, 0x2e, 0x36, 0x16, 0x7a, 0x6e, 0xde, 0x59,
+	0x6d, 0xc7, 0xda, 0x55, 0xb1, 0x3d, 0xf9, 0xce, 0xca, 0x4c, 0x75, 0x40, 0x60, 0x50, 0xa7, 0xcc,
+	0x34, 0xd3, 0x01, 0x74, 0xf4, 0x86, 0x23, 0xba, 0x72, 0x1d, 0xbc, 0xb5, 0x65, 0x3d, 0x76, 0xf4,
+	0x6c, 0xa5, 0xe7, 0xe6, 0xb5, 0x98, 0x1c, 0x71, 0x53, 0x64, 0x93, 0xb2, 0xda, 0x3c, 0x7a, 0x0b,
+	0xdd, 0x9d, 0x9e, 0x41, 0x6d, 0x70, 0x56, 0xd3, 0xa5, 0xf7, 0x08, 0x79, 0xd0, 0x93, 0x83, 0x5e,
+	0x8f, 0x9e, 0x67, 0xa1, 0x1e, 0x74, 0x24, 0xb2, 0xdc, 0xac, 0xd7, 0x9e, 0x7d, 0x6f, 0xf1, 0x2b,
+	0xcf, 0x41, 0x5d, 0x68, 0xaf, 0xa6, 0x9a, 0x6a, 0x1c, 0xbd, 0x86, 0xb6, 0xd9, 0x95, 0xa8, 0x0f,
+	0xee, 0x9c, 0x1a, 0xc3, 0x7b, 0x24, 0x83, 0x30, 0xb9, 0xcc, 0xb9, 0x20, 0xcc, 0xb3, 0xd0, 0x00,
+	0x60, 0x4a, 0x58, 0x6d, 0xdb, 0x47, 0xbf, 0x5a, 0xe0, 0x6e, 0x3b, 0x4d, 0x4a, 0x9e, 0xaf, 0x8e,
+	0x8f, 0xc3, 0xf3, 0x73, 0xef, 0x91, 0xd4, 0x89, 0x16, 0x8b, 0xf8, 0x74, 0x82, 0x4f, 0x42, 0xcf,
+	0x92, 0xdc, 0x3c, 0x8c, 0x7e, 0x5a, 0xe0, 0x1f, 0x3d, 0x1b, 0xed, 0x41, 0x77, 0xbe, 0x88, 0xe2,
+	0x1f, 0x26, 0xf3, 0xe9, 0x69, 0x38, 0xf5, 0x1c, 0x75, 0xf5, 0xd3, 0x59, 0x38, 0x8f, 0xe2, 0x10,
+	0xe3, 0x05, 0xf6, 0x1a, 0x32, 0x7c, 0x39, 0xc1, 0x93, 0xb3, 0x30, 0x0a, 0xb1, 0xd7, 0x94, 0xd7,
+	0x98, 0xcd, 0xa3, 0x10, 0xcf, 0x27, 0xa7, 0x5e, 0x4b, 0x8a, 0x2d, 0xc3, 0xf9, 0x74, 0x36, 0x3f,
+	0xf1, 0xda, 0xd2, 0x88, 0x66, 0x67, 0xe1, 0x62, 0x15, 0x79, 0x9d, 0xf1, 0x47, 0x0b, 0x1a, 0xf8,
+	0x56, 0xdc, 0xa2, 0x37, 0x00, 0xcb, 0x8d, 0xa8, 0x77, 0xfd, 0x13, 0xb3, 0xe1, 0x1e, 0x6e, 0xa5,
+	0x03, 0x5f, 0xc3, 0x7f, 0xb3, 0x05, 0xbe, 0x03, 0x38, 0x21, 0xdb, 0xf0, 0xcf, 0xfe, 0xea, 0xf7,
+	0x6f, 0x02, 0x47, 0xe0, 0x4c, 0xd2, 0x6b, 0x64, 0x56, 0xeb, 0xfd, 0x38, 0x1f, 0xec, 0xef, 0x20,
+	0xda, 0x77, 0xfc, 0x06, 0x5a, 0x78, 0x32, 0x9d, 0xad, 0xce, 0xd1, 0x2b, 0x68, 0xe9, 0x0e, 0x42,
+	0x9f, 0xd4, 0x6e, 0x3b, 0x23, 0x78, 0xf0, 0xf8, 0x21, 0xa8, 0xc3, 0xdf, 0xb5, 0xd4, 0x4f, 0xc2,
+	0xab, 0x3f, 0x07, 0x00, 0x91, 0x5a, 0x46, 0xb4, 0x32, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
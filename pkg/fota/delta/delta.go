@@ -0,0 +1,361 @@
+package delta
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// The patch starts with a header followed by a DEFLATE stream with one or
+// more records:
+//
+//	header: magic (4 bytes) | new image size (uint32)
+//	record: diff length (uint32) | extra length (uint32) | seek (int32) |
+//	        diff bytes | extra bytes
+//
+// The diff bytes are added to the bytes in the old image at the current
+// position, the extra bytes are copied as is and the old image position is
+// adjusted with the seek offset after each record. All integers are big
+// endian. The diff bytes are mostly zeroes so the records compress well.
+const (
+	headerLength = 8
+	recordLength = 12
+)
+
+var magic = []byte("HDF1")
+
+// ErrCorrupt is returned when the patch can't be applied to the image.
+var ErrCorrupt = errors.New("corrupt delta")
+
+// Diff generates a delta that transforms the old image into the new image.
+func Diff(old, new []byte) []byte {
+	ret := &bytes.Buffer{}
+	ret.Write(magic)
+	writeUint32(ret, uint32(len(new)))
+
+	// The writer only returns errors from the underlying writer and
+	// bytes.Buffer doesn't return errors.
+	w, _ := flate.NewWriter(ret, flate.BestCompression)
+	records := &bytes.Buffer{}
+
+	I := qsufsort(old)
+	var scan, pos, length int
+	var lastScan, lastPos, lastOffset int
+	for scan < len(new) {
+		oldScore := 0
+		scan += length
+		for scsc := scan; scan < len(new); scan++ {
+			pos, length = search(I, old, new[scan:], 0, len(old))
+			for ; scsc < scan+length; scsc++ {
+				if scsc+lastOffset < len(old) && old[scsc+lastOffset] == new[scsc] {
+					oldScore++
+				}
+			}
+			if (length == oldScore && length != 0) || length > oldScore+8 {
+				break
+			}
+			if scan+lastOffset < len(old) && old[scan+lastOffset] == new[scan] {
+				oldScore--
+			}
+		}
+
+		if length == oldScore && scan != len(new) {
+			continue
+		}
+
+		// Extend the match forwards from the last position...
+		s, sf, lenf := 0, 0, 0
+		for i := 0; lastScan+i < scan && lastPos+i < len(old); {
+			if old[lastPos+i] == new[lastScan+i] {
+				s++
+			}
+			i++
+			if s*2-i > sf*2-lenf {
+				sf = s
+				lenf = i
+			}
+		}
+
+		// ...and backwards from the next match
+		lenb := 0
+		if scan < len(new) {
+			s, sb := 0, 0
+			for i := 1; scan >= lastScan+i && pos >= i; i++ {
+				if old[pos-i] == new[scan-i] {
+					s++
+				}
+				if s*2-i > sb*2-lenb {
+					sb = s
+					lenb = i
+				}
+			}
+		}
+
+		// Split the overlap between the two matches
+		if lastScan+lenf > scan-lenb {
+			overlap := (lastScan + lenf) - (scan - lenb)
+			s, ss, lens := 0, 0, 0
+			for i := 0; i < overlap; i++ {
+				if new[lastScan+lenf-overlap+i] == old[lastPos+lenf-overlap+i] {
+					s++
+				}
+				if new[scan-lenb+i] == old[pos-lenb+i] {
+					s--
+				}
+				if s > ss {
+					ss = s
+					lens = i + 1
+				}
+			}
+			lenf += lens - overlap
+			lenb -= lens
+		}
+
+		extra := (scan - lenb) - (lastScan + lenf)
+		records.Reset()
+		writeUint32(records, uint32(lenf))
+		writeUint32(records, uint32(extra))
+		writeUint32(records, uint32(int32((pos-lenb)-(lastPos+lenf))))
+		for i := 0; i < lenf; i++ {
+			records.WriteByte(new[lastScan+i] - old[lastPos+i])
+		}
+		records.Write(new[lastScan+lenf : lastScan+lenf+extra])
+		w.Write(records.Bytes())
+
+		lastScan = scan - lenb
+		lastPos = pos - lenb
+		lastOffset = pos - scan
+	}
+	w.Close()
+	return ret.Bytes()
+}
+
+// Patch applies a delta generated by Diff to the old image and returns the
+// new image.
+func Patch(old, patch []byte) ([]byte, error) {
+	if len(patch) < headerLength || !bytes.Equal(patch[:len(magic)], magic) {
+		return nil, ErrCorrupt
+	}
+	size := int(binary.BigEndian.Uint32(patch[len(magic):]))
+	r := flate.NewReader(bytes.NewReader(patch[headerLength:]))
+	defer r.Close()
+
+	ret := make([]byte, size)
+	newPos, oldPos := 0, 0
+	record := make([]byte, recordLength)
+	for newPos < size {
+		if _, err := io.ReadFull(r, record); err != nil {
+			return nil, ErrCorrupt
+		}
+		diffLen := int(binary.BigEndian.Uint32(record))
+		extraLen := int(binary.BigEndian.Uint32(record[4:]))
+		seek := int(int32(binary.BigEndian.Uint32(record[8:])))
+
+		if diffLen < 0 || extraLen < 0 || newPos+diffLen+extraLen > size ||
+			oldPos < 0 || oldPos+diffLen > len(old) {
+			return nil, ErrCorrupt
+		}
+		if _, err := io.ReadFull(r, ret[newPos:newPos+diffLen+extraLen]); err != nil {
+			return nil, ErrCorrupt
+		}
+		for i := 0; i < diffLen; i++ {
+			ret[newPos+i] += old[oldPos+i]
+		}
+		newPos += diffLen + extraLen
+		oldPos += diffLen + seek
+	}
+	if _, err := r.Read(record); err != io.EOF {
+		return nil, ErrCorrupt
+	}
+	return ret, nil
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
+
+// matchLen returns the length of the common prefix of a and b
+func matchLen(a, b []byte) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// search finds the longest match for buf in the old image with a binary
+// search in the suffix array.
+func search(I []int, old, buf []byte, st, en int) (int, int) {
+	for en-st >= 2 {
+		x := st + (en-st)/2
+		n := len(old) - I[x]
+		if len(buf) < n {
+			n = len(buf)
+		}
+		if bytes.Compare(old[I[x]:I[x]+n], buf[:n]) < 0 {
+			st = x
+		} else {
+			en = x
+		}
+	}
+	x := matchLen(old[I[st]:], buf)
+	y := matchLen(old[I[en]:], buf)
+	if x > y {
+		return I[st], x
+	}
+	return I[en], y
+}
+
+// qsufsort builds the suffix array for the buffer with the Larsson-Sadakane
+// algorithm.
+func qsufsort(buf []byte) []int {
+	var buckets [256]int
+	I := make([]int, len(buf)+1)
+	V := make([]int, len(buf)+1)
+
+	for _, c := range buf {
+		buckets[c]++
+	}
+	for i := 1; i < 256; i++ {
+		buckets[i] += buckets[i-1]
+	}
+	copy(buckets[1:], buckets[:255])
+	buckets[0] = 0
+
+	for i, c := range buf {
+		buckets[c]++
+		I[buckets[c]] = i
+	}
+	I[0] = len(buf)
+	for i, c := range buf {
+		V[i] = buckets[c]
+	}
+	V[len(buf)] = 0
+	for i := 1; i < 256; i++ {
+		if buckets[i] == buckets[i-1]+1 {
+			I[buckets[i]] = -1
+		}
+	}
+	I[0] = -1
+
+	for h := 1; I[0] != -(len(buf) + 1); h += h {
+		n := 0
+		i := 0
+		for i < len(buf)+1 {
+			if I[i] < 0 {
+				n -= I[i]
+				i -= I[i]
+				continue
+			}
+			if n != 0 {
+				I[i-n] = -n
+			}
+			n = V[I[i]] + 1 - i
+			split(I, V, i, n, h)
+			i += n
+			n = 0
+		}
+		if n != 0 {
+			I[i-n] = -n
+		}
+	}
+
+	for i := 0; i < len(buf)+1; i++ {
+		I[V[i]] = i
+	}
+	return I
+}
+
+func split(I, V []int, start, length, h int) {
+	if length < 16 {
+		for k := start; k < start+length; {
+			j := 1
+			x := V[I[k]+h]
+			for i := 1; k+i < start+length; i++ {
+				if V[I[k+i]+h] < x {
+					x = V[I[k+i]+h]
+					j = 0
+				}
+				if V[I[k+i]+h] == x {
+					I[k+i], I[k+j] = I[k+j], I[k+i]
+					j++
+				}
+			}
+			for i := 0; i < j; i++ {
+				V[I[k+i]] = k + j - 1
+			}
+			if j == 1 {
+				I[k] = -1
+			}
+			k += j
+		}
+		return
+	}
+
+	x := V[I[start+length/2]+h]
+	jj, kk := 0, 0
+	for i := start; i < start+length; i++ {
+		if V[I[i]+h] < x {
+			jj++
+		}
+		if V[I[i]+h] == x {
+			kk++
+		}
+	}
+	jj += start
+	kk += jj
+
+	i, j, k := start, 0, 0
+	for i < jj {
+		switch {
+		case V[I[i]+h] < x:
+			i++
+		case V[I[i]+h] == x:
+			I[i], I[jj+j] = I[jj+j], I[i]
+			j++
+		default:
+			I[i], I[kk+k] = I[kk+k], I[i]
+			k++
+		}
+	}
+	for jj+j < kk {
+		if V[I[jj+j]+h] == x {
+			j++
+		} else {
+			I[jj+j], I[kk+k] = I[kk+k], I[jj+j]
+			k++
+		}
+	}
+
+	if jj > start {
+		split(I, V, start, jj-start, h)
+	}
+	for i := 0; i < kk-jj; i++ {
+		V[I[jj+i]] = kk - 1
+	}
+	if jj == kk-1 {
+		I[jj] = -1
+	}
+	if start+length > kk {
+		split(I, V, kk, start+length-kk, h)
+	}
+}
@@ -0,0 +1,77 @@
+package delta
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPatch(t *testing.T) {
+	assert := require.New(t)
+
+	// Simulate a firmware update: mostly the same image with a few patched
+	// areas, a moved block, padding and an appended section.
+	old := make([]byte, 256*1024)
+	rand.Read(old[:200*1024])
+	for i := 200 * 1024; i < len(old); i++ {
+		old[i] = 0xFF
+	}
+	new := make([]byte, len(old))
+	copy(new, old)
+	for i := 0; i < 100; i++ {
+		new[rand.Intn(len(new))]++
+	}
+	copy(new[10000:], old[50000:60000])
+	extra := make([]byte, 4096)
+	rand.Read(extra)
+	new = append(new, extra...)
+
+	patch := Diff(old, new)
+	assert.True(len(patch) < len(new)/4, "Delta should be a lot smaller than the image (%d vs %d bytes)", len(patch), len(new))
+	patched, err := Patch(old, patch)
+	assert.NoError(err)
+	assert.Equal(new, patched)
+
+	// Edge cases
+	for _, v := range []struct {
+		old []byte
+		new []byte
+	}{
+		{nil, nil},
+		{nil, []byte("new image")},
+		{[]byte("old image"), nil},
+		{[]byte("same image"), []byte("same image")},
+		{[]byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"), []byte("aaaaaaaaaaaaabaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+	} {
+		patched, err := Patch(v.old, Diff(v.old, v.new))
+		assert.NoError(err)
+		assert.Equal(len(v.new), len(patched))
+		assert.Equal(string(v.new), string(patched))
+	}
+
+	// Corrupt patches
+	_, err = Patch(old, patch[:4])
+	assert.Equal(ErrCorrupt, err)
+	_, err = Patch(old, patch[:len(patch)-1])
+	assert.Equal(ErrCorrupt, err)
+	_, err = Patch(old[:100], patch)
+	assert.Equal(ErrCorrupt, err)
+	_, err = Patch(old, []byte("BSDIFF40 not a delta"))
+	assert.Equal(ErrCorrupt, err)
+}
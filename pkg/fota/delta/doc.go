@@ -0,0 +1,6 @@
+// Package delta creates and applies binary deltas between firmware images.
+// The deltas are generated with the bsdiff algorithm but the control, diff
+// and extra blocks are interleaved and compressed as a single DEFLATE stream
+// so the devices can apply the patch while it is downloaded without
+// buffering more than a single record.
+package delta
@@ -0,0 +1,101 @@
+package fota
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+)
+
+const (
+	// defaultDownloadTokenTTL is the validity for download tokens if it isn't
+	// configured. It must be longer than the download timeout.
+	defaultDownloadTokenTTL = 2 * time.Hour
+
+	// tokenPayloadLength is the length of the IMSI, firmware ID and expiry
+	tokenPayloadLength = 24
+
+	// tokenMACLength is the length of the (truncated) HMAC in the token
+	tokenMACLength = 16
+)
+
+var (
+	errInvalidToken = errors.New("invalid download token")
+	errExpiredToken = errors.New("download token has expired")
+)
+
+// downloadTokens issues and verifies the download tokens for the HTTP
+// firmware endpoint. The tokens are short-lived and bound to a single device
+// and firmware image. Tokens are signed with a HMAC so there's no state to
+// share between the servers as long as they use the same secret.
+type downloadTokens struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// newDownloadTokens creates a new token issuer
+func newDownloadTokens(secret []byte, ttl time.Duration) *downloadTokens {
+	if ttl == 0 {
+		ttl = defaultDownloadTokenTTL
+	}
+	return &downloadTokens{
+		secret: secret,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Token creates a new download token for the device and firmware image. The
+// token is URL safe.
+func (d *downloadTokens) Token(imsi int64, firmwareID model.FirmwareKey) string {
+	buf := make([]byte, tokenPayloadLength, tokenPayloadLength+tokenMACLength)
+	binary.BigEndian.PutUint64(buf[0:], uint64(imsi))
+	binary.BigEndian.PutUint64(buf[8:], uint64(firmwareID))
+	binary.BigEndian.PutUint64(buf[16:], uint64(d.now().Add(d.ttl).Unix()))
+	buf = append(buf, d.mac(buf)...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// Verify checks the token and returns the IMSI and firmware ID for the token
+func (d *downloadTokens) Verify(token string) (int64, model.FirmwareKey, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) != tokenPayloadLength+tokenMACLength {
+		return 0, 0, errInvalidToken
+	}
+	if !hmac.Equal(buf[tokenPayloadLength:], d.mac(buf[:tokenPayloadLength])) {
+		return 0, 0, errInvalidToken
+	}
+	expires := time.Unix(int64(binary.BigEndian.Uint64(buf[16:])), 0)
+	if d.now().After(expires) {
+		return 0, 0, errExpiredToken
+	}
+	imsi := int64(binary.BigEndian.Uint64(buf[0:]))
+	firmwareID := model.FirmwareKey(binary.BigEndian.Uint64(buf[8:]))
+	return imsi, firmwareID, nil
+}
+
+func (d *downloadTokens) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, d.secret)
+	h.Write(payload)
+	return h.Sum(nil)[:tokenMACLength]
+}
@@ -0,0 +1,57 @@
+package fota
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadTokens(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Now()
+	tokens := newDownloadTokens([]byte("secret"), time.Hour)
+	tokens.now = func() time.Time { return now }
+
+	token := tokens.Token(4711, model.FirmwareKey(42))
+	imsi, firmwareID, err := tokens.Verify(token)
+	assert.NoError(err)
+	assert.Equal(int64(4711), imsi)
+	assert.Equal(model.FirmwareKey(42), firmwareID)
+
+	// Tokens from other secrets are rejected
+	other := newDownloadTokens([]byte("other secret"), time.Hour)
+	_, _, err = other.Verify(token)
+	assert.Equal(errInvalidToken, err)
+
+	// ...and so are tokens that are modified
+	buf := []byte(token)
+	buf[3] ^= 1
+	_, _, err = tokens.Verify(string(buf))
+	assert.Equal(errInvalidToken, err)
+	_, _, err = tokens.Verify("")
+	assert.Equal(errInvalidToken, err)
+	_, _, err = tokens.Verify("not a token")
+	assert.Equal(errInvalidToken, err)
+
+	now = now.Add(time.Hour + time.Second)
+	_, _, err = tokens.Verify(token)
+	assert.Equal(errExpiredToken, err)
+}
@@ -0,0 +1,93 @@
+package fota
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// eventStore publishes an event and records the transition in the firmware
+// history when a firmware update changes the device's firmware state. The FOTA
+// handlers update the device state through the UpdateDeviceMetadata and
+// UpdateFirmwareStateForDevice methods.
+type eventStore struct {
+	storage.DataStore
+	events output.EventPublisher
+}
+
+func newEventStore(store storage.DataStore, events output.EventPublisher) storage.DataStore {
+	return &eventStore{DataStore: store, events: events}
+}
+
+func (e *eventStore) UpdateDeviceMetadata(device model.Device) error {
+	previous, lookupErr := e.DataStore.RetrieveDeviceByIMSI(device.IMSI)
+	if err := e.DataStore.UpdateDeviceMetadata(device); err != nil {
+		return err
+	}
+	if lookupErr == nil && previous.Firmware.State != device.Firmware.State {
+		e.events.PublishEvent(model.NewDeviceEvent(model.DeviceFirmwareChanged, device))
+		e.recordTransition(previous.Firmware.State, device)
+	}
+	return nil
+}
+
+func (e *eventStore) UpdateFirmwareStateForDevice(imsi int64, state model.DeviceFirmwareState, message string) error {
+	previous, lookupErr := e.DataStore.RetrieveDeviceByIMSI(imsi)
+	if err := e.DataStore.UpdateFirmwareStateForDevice(imsi, state, message); err != nil {
+		return err
+	}
+	if lookupErr == nil && previous.Firmware.State != state {
+		device := previous
+		device.Firmware.State = state
+		device.Firmware.StateMessage = message
+		e.events.PublishEvent(model.NewDeviceEvent(model.DeviceFirmwareChanged, device))
+		e.recordTransition(previous.Firmware.State, device)
+	}
+	return nil
+}
+
+// recordTransition adds the state transition to the firmware history. The
+// target image is the one in the device's firmware config and the duration is
+// the time since the previous transition. The state is already updated when
+// this is called so errors are just logged.
+func (e *eventStore) recordTransition(previous model.DeviceFirmwareState, device model.Device) {
+	now := time.Now()
+	entry := model.FirmwareHistoryEntry{
+		ID:             e.DataStore.NewFirmwareHistoryID(),
+		Time:           now,
+		CollectionID:   device.CollectionID,
+		DeviceID:       device.ID,
+		FromFirmwareID: device.Firmware.CurrentFirmwareID,
+		ToFirmwareID:   device.Firmware.TargetFirmwareID,
+		PreviousState:  previous,
+		State:          device.Firmware.State,
+		Message:        device.Firmware.StateMessage,
+	}
+	if config, err := e.DataStore.RetrieveFirmwareConfig(device.CollectionID, device.ID); err == nil {
+		entry.ToFirmwareID = config.TargetVersion()
+	}
+	if last, err := e.DataStore.RetrieveLatestFirmwareHistory(device.ID); err == nil {
+		entry.Duration = now.Sub(last.Time)
+	}
+	if err := e.DataStore.CreateFirmwareHistory(entry); err != nil {
+		logging.Warning("Unable to record firmware state transition for device with IMSI %d: %v", device.IMSI, err)
+	}
+}
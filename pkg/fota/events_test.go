@@ -0,0 +1,99 @@
+package fota
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/eesrc/horde/pkg/storage/storetest"
+	"github.com/stretchr/testify/require"
+)
+
+type eventRecorder struct {
+	events []model.ResourceEvent
+}
+
+func (e *eventRecorder) PublishEvent(ev model.ResourceEvent) {
+	e.events = append(e.events, ev)
+}
+
+func TestFirmwareHistoryRecording(t *testing.T) {
+	assert := require.New(t)
+
+	store := sqlstore.NewMemoryStore()
+	env := storetest.NewTestEnvironment(t, store)
+
+	newImage := func(version string) model.Firmware {
+		fw := model.NewFirmware()
+		fw.ID = store.NewFirmwareID()
+		fw.Version = version
+		fw.Filename = version + ".bin"
+		fw.SHA256 = version
+		fw.Created = time.Now()
+		fw.CollectionID = env.C1.ID
+		assert.NoError(store.CreateFirmware(env.U1.ID, fw))
+		return fw
+	}
+	v1 := newImage("1.0.0")
+	v2 := newImage("2.0.0")
+
+	coll := env.C1
+	coll.Firmware.Management = model.CollectionManagement
+	coll.Firmware.TargetFirmwareID = v2.ID
+	assert.NoError(store.UpdateCollection(env.U1.ID, coll))
+
+	device := model.NewDevice()
+	device.ID = store.NewDeviceID()
+	device.IMSI = 1
+	device.IMEI = 1
+	device.CollectionID = env.C1.ID
+	device.Firmware.CurrentFirmwareID = v1.ID
+	assert.NoError(store.CreateDevice(env.U1.ID, device))
+
+	events := &eventRecorder{}
+	es := newEventStore(store, events)
+
+	device.Firmware.State = model.Pending
+	assert.NoError(es.UpdateDeviceMetadata(device))
+	assert.Len(events.events, 1)
+
+	// Updates that don't change the state aren't recorded
+	assert.NoError(es.UpdateDeviceMetadata(device))
+	assert.Len(events.events, 1)
+
+	assert.NoError(es.UpdateFirmwareStateForDevice(device.IMSI, model.Completed, ""))
+	assert.Len(events.events, 2)
+	assert.Equal(model.DeviceFirmwareChanged, events.events[1].Type)
+
+	history, err := store.ListDeviceFirmwareHistory(env.U1.ID, env.C1.ID, device.ID, model.FirmwareHistoryKey(0), 10)
+	assert.NoError(err)
+	assert.Len(history, 2)
+
+	// Newest entry first
+	assert.Equal(model.Pending, history[0].PreviousState)
+	assert.Equal(model.Completed, history[0].State)
+	assert.Equal(v1.ID, history[0].FromFirmwareID)
+	assert.Equal(v2.ID, history[0].ToFirmwareID)
+	assert.True(history[0].Duration > 0)
+
+	assert.Equal(model.Unknown, history[1].PreviousState)
+	assert.Equal(model.Pending, history[1].State)
+	assert.Equal(v2.ID, history[1].ToFirmwareID)
+	assert.Equal(time.Duration(0), history[1].Duration)
+}
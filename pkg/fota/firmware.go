@@ -16,16 +16,26 @@ package fota
 // limitations under the License.
 //
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"sync"
+	"time"
 
 	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/fota/delta"
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
 )
 
-// Do an update check on the firmware
-func firmwareUpdateCheck(device *model.Device, data Report, firmwareStore storage.DataStore) (bool, model.FirmwareKey, error) {
+// errUnsignedImage is set on devices when the collection requires signed
+// images and the target image isn't signed.
+var errUnsignedImage = errors.New("the image is not signed")
+
+// Do an update check on the firmware. Devices are only updated when the
+// maintenance window is open at the time.
+func firmwareUpdateCheck(device *model.Device, data Report, now time.Time, firmwareStore storage.DataStore) (bool, model.FirmwareKey, error) {
 	updateDevice := false
 
 	if data.FirmwareVersion != "" {
@@ -115,23 +125,118 @@ func firmwareUpdateCheck(device *model.Device, data Report, firmwareStore storag
 		return false, 0, errors.New("device is in error state")
 	}
 
+	compatible, err := checkCompatibility(device, config.TargetVersion(), config.RequireSignature, firmwareStore)
+	if err != nil || !compatible {
+		return false, 0, err
+	}
+	open, err := checkMaintenanceWindow(device, config.MaintenanceWindow(), now, firmwareStore)
+	if err != nil || !open {
+		return false, 0, err
+	}
 	return true, config.TargetVersion(), nil
 }
 
-// findFirmware returns the latest firmware image for the device
-func findFirmware(device *model.Device, store storage.DataStore, firmwareStore storage.FirmwareImageStore) ([]byte, bool) {
+// checkMaintenanceWindow checks if the device's maintenance window is open.
+// Devices outside the window are flagged as deferred and the flag is cleared
+// when the window opens.
+func checkMaintenanceWindow(device *model.Device, window model.MaintenanceWindow, now time.Time, store storage.DataStore) (bool, error) {
+	open := window.IsOpen(now, *device)
+	if open {
+		if device.Firmware.State != model.Deferred {
+			return true, nil
+		}
+		device.Firmware.State = model.Pending
+		device.Firmware.StateMessage = ""
+	} else {
+		message := "Update is deferred until the maintenance window opens"
+		if next, ok := window.Next(now, *device); ok {
+			message = fmt.Sprintf("Update is deferred until the maintenance window opens at %s", next.Format(time.RFC3339))
+		}
+		if device.Firmware.State == model.Deferred && device.Firmware.StateMessage == message {
+			return false, nil
+		}
+		logging.Info("Device with IMSI %d is outside its maintenance window. Deferring update", device.IMSI)
+		device.Firmware.State = model.Deferred
+		device.Firmware.StateMessage = message
+	}
+	if err := store.UpdateDeviceMetadata(*device); err != nil {
+		logging.Warning("Unable to update device with IMSI %d: %v", device.IMSI, err)
+		return false, err
+	}
+	return open, nil
+}
 
+// checkCompatibility checks the compatibility rules for the firmware image
+// against the device. Unsigned images are incompatible when the collection
+// requires signatures. Incompatible devices are flagged with the reason and
+// the flag is cleared when the device is compatible with the image.
+func checkCompatibility(device *model.Device, firmwareID model.FirmwareKey, requireSignature bool, store storage.DataStore) (bool, error) {
+	_, fw, err := store.RetrieveCurrentAndTargetFirmware(device.CollectionID, 0, firmwareID)
+	if err != nil {
+		logging.Warning("Unable to retrieve firmware %s for device with IMSI %d: %v", firmwareID.String(), device.IMSI, err)
+		return false, err
+	}
+	compatErr := fw.Compatibility.Check(device.Firmware)
+	if compatErr == nil && requireSignature && !fw.IsSigned() {
+		compatErr = errUnsignedImage
+	}
+	if compatErr == nil {
+		if device.Firmware.State != model.Incompatible {
+			return true, nil
+		}
+		device.Firmware.State = model.Pending
+		device.Firmware.StateMessage = ""
+	} else {
+		logging.Info("Device with IMSI %d is incompatible with firmware %s: %v", device.IMSI, firmwareID.String(), compatErr)
+		message := fmt.Sprintf("Firmware %s is incompatible: %v", fw.Version, compatErr)
+		if device.Firmware.State == model.Incompatible && device.Firmware.StateMessage == message {
+			return false, nil
+		}
+		device.Firmware.State = model.Incompatible
+		device.Firmware.StateMessage = message
+	}
+	if err := store.UpdateDeviceMetadata(*device); err != nil {
+		logging.Warning("Unable to update device with IMSI %d: %v", device.IMSI, err)
+		return false, err
+	}
+	return compatErr == nil, nil
+}
+
+// firmwareSignature returns the signature for the firmware image the device
+// should upgrade to. Unsigned images have no signature.
+func firmwareSignature(device *model.Device, firmwareID model.FirmwareKey, store storage.DataStore) ([]byte, error) {
+	_, fw, err := store.RetrieveCurrentAndTargetFirmware(device.CollectionID, 0, firmwareID)
+	if err != nil {
+		logging.Warning("Unable to retrieve firmware %s for device with IMSI %d: %v", firmwareID.String(), device.IMSI, err)
+		return nil, err
+	}
+	return fw.Signature, nil
+}
+
+// scheduledFirmware returns the firmware image the device should download.
+// Devices that are incompatible with the image or outside their maintenance
+// window won't get the image.
+func scheduledFirmware(device *model.Device, store storage.DataStore) (model.FirmwareKey, bool) {
 	// Check the collection
 	config, err := store.RetrieveFirmwareConfig(device.CollectionID, device.ID)
 	if err != nil {
 		logging.Warning("Unable to locate firmware config for device with IMSI %d; %v", device.IMSI, err)
-		return nil, false
+		return 0, false
 	}
 	logging.Debug("Firmware config for device with IMSI %d: %+v", device.IMSI, config)
-	if !config.NeedsUpgrade() {
+	if !config.NeedsUpgrade() || device.Firmware.State == model.Incompatible || device.Firmware.State == model.Deferred {
+		return 0, false
+	}
+	return config.TargetVersion(), true
+}
+
+// findFirmware returns the latest firmware image for the device
+func findFirmware(device *model.Device, store storage.DataStore, firmwareStore storage.FirmwareImageStore) ([]byte, bool) {
+	target, ok := scheduledFirmware(device, store)
+	if !ok {
 		return nil, false
 	}
-	image, err := firmwareStore.Retrieve(config.TargetVersion())
+	image, err := firmwareStore.Retrieve(target)
 	if err != nil {
 		if err != storage.ErrNotFound {
 			logging.Warning("Unable to locate image %s for device with IMSI %d: %v", device.Firmware.TargetFirmwareID, device.IMSI, err)
@@ -146,3 +251,131 @@ func findFirmware(device *model.Device, store storage.DataStore, firmwareStore s
 	}
 	return buf, true
 }
+
+// readImage reads a firmware image from the image store
+func readImage(id model.FirmwareKey, firmwareStore storage.FirmwareImageStore) ([]byte, error) {
+	image, err := firmwareStore.Retrieve(id)
+	if err != nil {
+		return nil, err
+	}
+	defer image.Close()
+	return ioutil.ReadAll(image)
+}
+
+// deltaKey identifies a delta between two firmware images
+type deltaKey struct {
+	from, to model.FirmwareKey
+}
+
+// Deltas that are being generated. Generating a delta is expensive so there's
+// only one generator running for each pair of images.
+var (
+	deltaMutex      sync.Mutex
+	deltaInProgress = make(map[deltaKey]chan struct{})
+)
+
+// cachedDelta returns the delta between two firmware images if it has been
+// generated.
+func cachedDelta(from, to model.FirmwareKey, firmwareStore storage.FirmwareImageStore) ([]byte, error) {
+	cached, err := firmwareStore.RetrieveDelta(from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer cached.Close()
+	return ioutil.ReadAll(cached)
+}
+
+// createDelta generates the delta between two firmware images and stores it
+// in the image store.
+func createDelta(from, to model.FirmwareKey, firmwareStore storage.FirmwareImageStore) error {
+	old, err := readImage(from, firmwareStore)
+	if err != nil {
+		return err
+	}
+	new, err := readImage(to, firmwareStore)
+	if err != nil {
+		return err
+	}
+	buf := delta.Diff(old, new)
+	logging.Info("Generated delta from firmware %s to %s (%d bytes, image is %d bytes)", from.String(), to.String(), len(buf), len(new))
+	_, err = firmwareStore.CreateDelta(from, to, bytes.NewReader(buf))
+	return err
+}
+
+// generateDelta generates the delta between two firmware images in the
+// background. The returned channel is closed when the generator is done.
+// Requests for a delta that is already being generated share the generator.
+func generateDelta(from, to model.FirmwareKey, firmwareStore storage.FirmwareImageStore) <-chan struct{} {
+	key := deltaKey{from: from, to: to}
+
+	deltaMutex.Lock()
+	defer deltaMutex.Unlock()
+	if done, ok := deltaInProgress[key]; ok {
+		return done
+	}
+	done := make(chan struct{})
+	deltaInProgress[key] = done
+
+	go func() {
+		defer func() {
+			deltaMutex.Lock()
+			delete(deltaInProgress, key)
+			deltaMutex.Unlock()
+			close(done)
+		}()
+		// Another generator might have completed the delta in the meantime
+		if cached, err := firmwareStore.RetrieveDelta(from, to); err == nil {
+			cached.Close()
+			return
+		}
+		if err := createDelta(from, to, firmwareStore); err != nil {
+			logging.Warning("Unable to generate delta from firmware %s to %s: %v", from.String(), to.String(), err)
+		}
+	}()
+	return done
+}
+
+// deltaAvailable checks if there's a delta from the device's current
+// firmware image to the target image. Devices with an unknown firmware
+// version must download the full image. Missing deltas are generated in the
+// background and the device gets the full image until the delta is ready.
+func deltaAvailable(device *model.Device, firmwareID model.FirmwareKey, firmwareStore storage.FirmwareImageStore) bool {
+	if device.Firmware.CurrentFirmwareID == 0 || device.Firmware.CurrentFirmwareID == firmwareID {
+		return false
+	}
+	cached, err := firmwareStore.RetrieveDelta(device.Firmware.CurrentFirmwareID, firmwareID)
+	if err == nil {
+		cached.Close()
+		return true
+	}
+	if err != storage.ErrNotFound {
+		logging.Warning("Unable to get delta from firmware %s to %s for device with IMSI %d. Using full image: %v",
+			device.Firmware.CurrentFirmwareID.String(), firmwareID.String(), device.IMSI, err)
+		return false
+	}
+	logging.Debug("Generating delta from firmware %s to %s. Device with IMSI %d gets the full image",
+		device.Firmware.CurrentFirmwareID.String(), firmwareID.String(), device.IMSI)
+	generateDelta(device.Firmware.CurrentFirmwareID, firmwareID, firmwareStore)
+	return false
+}
+
+// findFirmwareDelta returns the delta from the device's current firmware
+// image to the latest firmware image for the device.
+func findFirmwareDelta(device *model.Device, store storage.DataStore, firmwareStore storage.FirmwareImageStore) ([]byte, bool) {
+	config, err := store.RetrieveFirmwareConfig(device.CollectionID, device.ID)
+	if err != nil {
+		logging.Warning("Unable to locate firmware config for device with IMSI %d; %v", device.IMSI, err)
+		return nil, false
+	}
+	if !config.NeedsUpgrade() || device.Firmware.CurrentFirmwareID == 0 ||
+		device.Firmware.State == model.Incompatible || device.Firmware.State == model.Deferred {
+		return nil, false
+	}
+	buf, err := cachedDelta(device.Firmware.CurrentFirmwareID, config.TargetVersion(), firmwareStore)
+	if err != nil {
+		logging.Warning("Unable to get delta from firmware %s to %s for device with IMSI %d: %v",
+			device.Firmware.CurrentFirmwareID.String(), config.TargetVersion().String(), device.IMSI, err)
+		return nil, false
+	}
+	return buf, true
+}
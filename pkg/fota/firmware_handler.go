@@ -16,20 +16,33 @@ package fota
 // limitations under the License.
 //
 import (
+	"strings"
 	"time"
 
 	"github.com/ExploratoryEngineering/logging"
 	"github.com/eesrc/horde/pkg/apn"
 	"github.com/eesrc/horde/pkg/deviceio/rxtx"
+	"github.com/eesrc/horde/pkg/metering"
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
 	"github.com/go-ocf/go-coap"
 	"github.com/go-ocf/go-coap/codes"
 )
 
+const (
+	// deltaPath is appended to the firmware endpoint path for deltas
+	deltaPath = "delta"
+
+	// deltaContentFormat is the content format for firmware deltas. There's
+	// no registered content format for binary deltas so it is in the range
+	// reserved for experimental use.
+	deltaContentFormat coap.MediaType = 65000
+)
+
 // firmwareHandler is the handler that returns the latest firmware image for the
-// device. If there's no firmware assigned to the device the handler returns
-// a not found response.
+// device. Devices that support deltas can request the delta from the current
+// image to the latest image by appending /delta to the path. If there's no
+// firmware assigned to the device the handler returns a not found response.
 func newFirmwareHandler(receiver *apn.RxTxReceiver, timeout time.Duration, store storage.DataStore, firmwareStore storage.FirmwareImageStore) apn.CoAPHandler {
 	return func(apnID int, nasID int, device *model.Device, req *rxtx.UpstreamRequest) (*rxtx.DownstreamResponse, apn.ResponseCallback, error) {
 		notFound := &rxtx.DownstreamResponse{
@@ -39,13 +52,21 @@ func newFirmwareHandler(receiver *apn.RxTxReceiver, timeout time.Duration, store
 				},
 			},
 		}
-		image, ok := findFirmware(device, store, firmwareStore)
+		var image []byte
+		var ok bool
+		contentFormat := coap.AppOctets
+		if strings.HasSuffix(req.Msg.Coap.Path, "/"+deltaPath) {
+			contentFormat = deltaContentFormat
+			image, ok = findFirmwareDelta(device, store, firmwareStore)
+		} else {
+			image, ok = findFirmware(device, store, firmwareStore)
+		}
 		if !ok {
-			logging.Info("Device with IMSI %d requested firmware image but none exists", device.IMSI)
+			logging.Info("Device with IMSI %d requested firmware image (%s) but none exists", device.IMSI, req.Msg.Coap.Path)
 			return notFound, nil, nil
 		}
 
-		logging.Debug("Sending firmware (%d bytes) to device with IMSI %d", len(image), device.IMSI)
+		logging.Debug("Sending firmware (%d bytes, content format %d) to device with IMSI %d", len(image), contentFormat, device.IMSI)
 		// success -- found firmware. Transfer to client
 		ret := &rxtx.DownstreamResponse{
 			Msg: &rxtx.Message{
@@ -53,7 +74,7 @@ func newFirmwareHandler(receiver *apn.RxTxReceiver, timeout time.Duration, store
 				Payload: image,
 				Coap: &rxtx.CoAPOptions{
 					Code:           int32(codes.Content),
-					ContentFormat:  int32(coap.AppOctets),
+					ContentFormat:  int32(contentFormat),
 					TimeoutSeconds: int32(timeout / time.Second),
 				},
 			},
@@ -67,6 +88,7 @@ func newFirmwareHandler(receiver *apn.RxTxReceiver, timeout time.Duration, store
 				return
 			}
 			logging.Debug("Device with IMSI %d has completed firmware download", device.IMSI)
+			metering.DefaultMeter.FirmwareDelivered(device.CollectionID, len(image))
 			store.UpdateFirmwareStateForDevice(device.IMSI, model.Completed, "Device has completed image download")
 		}
 		return ret, callback, nil
@@ -0,0 +1,184 @@
+package fota
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/fota/delta"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage/fwimage"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/eesrc/horde/pkg/storage/storetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirmwareDelta(t *testing.T) {
+	assert := require.New(t)
+
+	firmwareStore, err := fwimage.NewSQLStore(sqlstore.Parameters{Type: "sqlite3", ConnectionString: ":memory:"})
+	assert.NoError(err)
+
+	old := make([]byte, 64*1024)
+	rand.Read(old)
+	new := make([]byte, len(old))
+	copy(new, old)
+	copy(new[1000:], []byte("new version"))
+
+	_, err = firmwareStore.Create(model.FirmwareKey(1), bytes.NewReader(old))
+	assert.NoError(err)
+	_, err = firmwareStore.Create(model.FirmwareKey(2), bytes.NewReader(new))
+	assert.NoError(err)
+
+	device := &model.Device{IMSI: 1}
+	assert.False(deltaAvailable(device, model.FirmwareKey(2), firmwareStore), "No delta for unknown versions")
+	device.Firmware.CurrentFirmwareID = model.FirmwareKey(3)
+	assert.False(deltaAvailable(device, model.FirmwareKey(2), firmwareStore), "No delta when current image is missing")
+	device.Firmware.CurrentFirmwareID = model.FirmwareKey(1)
+	assert.False(deltaAvailable(device, model.FirmwareKey(2), firmwareStore), "Full image is used while the delta is generated")
+	<-generateDelta(model.FirmwareKey(1), model.FirmwareKey(2), firmwareStore)
+	assert.True(deltaAvailable(device, model.FirmwareKey(2), firmwareStore))
+
+	// The delta is cached in the image store
+	rc, err := firmwareStore.RetrieveDelta(model.FirmwareKey(1), model.FirmwareKey(2))
+	assert.NoError(err)
+	cached, err := ioutil.ReadAll(rc)
+	assert.NoError(err)
+	rc.Close()
+	assert.True(len(cached) < len(new)/10)
+
+	buf, err := cachedDelta(model.FirmwareKey(1), model.FirmwareKey(2), firmwareStore)
+	assert.NoError(err)
+	assert.Equal(cached, buf)
+
+	patched, err := delta.Patch(old, buf)
+	assert.NoError(err)
+	assert.Equal(new, patched)
+}
+
+func TestFirmwareCompatibilityCheck(t *testing.T) {
+	assert := require.New(t)
+
+	store := sqlstore.NewMemoryStore()
+	env := storetest.NewTestEnvironment(t, store)
+
+	newImage := func(version string, compat model.FirmwareCompatibility) model.Firmware {
+		fw := model.NewFirmware()
+		fw.ID = store.NewFirmwareID()
+		fw.Version = version
+		fw.Filename = version + ".bin"
+		fw.SHA256 = version
+		fw.Created = time.Now()
+		fw.CollectionID = env.C1.ID
+		fw.Compatibility = compat
+		assert.NoError(store.CreateFirmware(env.U1.ID, fw))
+		return fw
+	}
+	v1 := newImage("1.0.0", model.FirmwareCompatibility{})
+	v2 := newImage("2.0.0", model.FirmwareCompatibility{ModelPattern: "EE02"})
+
+	coll := env.C1
+	coll.Firmware.Management = model.CollectionManagement
+	coll.Firmware.TargetFirmwareID = v2.ID
+	assert.NoError(store.UpdateCollection(env.U1.ID, coll))
+
+	device := model.NewDevice()
+	device.ID = store.NewDeviceID()
+	device.IMSI = 1
+	device.IMEI = 1
+	device.CollectionID = env.C1.ID
+	assert.NoError(store.CreateDevice(env.U1.ID, device))
+
+	report := Report{FirmwareVersion: v1.Version, ManufacturerName: "EE", ModelNumber: "EE01"}
+	needsUpdate, _, err := firmwareUpdateCheck(&device, report, time.Now(), store)
+	assert.NoError(err)
+	assert.False(needsUpdate, "Incompatible devices should not be updated")
+
+	d, err := store.RetrieveDevice(env.U1.ID, env.C1.ID, device.ID)
+	assert.NoError(err)
+	assert.Equal(model.Incompatible, d.Firmware.State)
+	assert.Contains(d.Firmware.StateMessage, "EE01")
+
+	// Devices that report a compatible model are updated
+	report.ModelNumber = "EE02"
+	needsUpdate, firmwareID, err := firmwareUpdateCheck(&d, report, time.Now(), store)
+	assert.NoError(err)
+	assert.True(needsUpdate)
+	assert.Equal(v2.ID, firmwareID)
+
+	d, err = store.RetrieveDevice(env.U1.ID, env.C1.ID, device.ID)
+	assert.NoError(err)
+	assert.Equal(model.Pending, d.Firmware.State)
+	assert.Empty(d.Firmware.StateMessage)
+}
+
+func TestFirmwareSignatureCheck(t *testing.T) {
+	assert := require.New(t)
+
+	store := sqlstore.NewMemoryStore()
+	env := storetest.NewTestEnvironment(t, store)
+
+	newImage := func(version string, signature []byte) model.Firmware {
+		fw := model.NewFirmware()
+		fw.ID = store.NewFirmwareID()
+		fw.Version = version
+		fw.Filename = version + ".bin"
+		fw.SHA256 = version
+		fw.Created = time.Now()
+		fw.CollectionID = env.C1.ID
+		fw.Signature = signature
+		assert.NoError(store.CreateFirmware(env.U1.ID, fw))
+		return fw
+	}
+	v1 := newImage("1.0.0", nil)
+	v2 := newImage("2.0.0", nil)
+
+	coll := env.C1
+	coll.Firmware.Management = model.CollectionManagement
+	coll.Firmware.TargetFirmwareID = v2.ID
+	coll.Firmware.RequireSignature = true
+	assert.NoError(store.UpdateCollection(env.U1.ID, coll))
+
+	device := model.NewDevice()
+	device.ID = store.NewDeviceID()
+	device.IMSI = 1
+	device.IMEI = 1
+	device.CollectionID = env.C1.ID
+	assert.NoError(store.CreateDevice(env.U1.ID, device))
+
+	// Unsigned images are refused when the collection requires signatures
+	report := Report{FirmwareVersion: v1.Version}
+	needsUpdate, _, err := firmwareUpdateCheck(&device, report, time.Now(), store)
+	assert.NoError(err)
+	assert.False(needsUpdate, "Unsigned images should not be sent to the device")
+
+	d, err := store.RetrieveDevice(env.U1.ID, env.C1.ID, device.ID)
+	assert.NoError(err)
+	assert.Equal(model.Incompatible, d.Firmware.State)
+	assert.Contains(d.Firmware.StateMessage, "not signed")
+
+	// ...but are sent when the requirement is lifted
+	coll.Firmware.RequireSignature = false
+	assert.NoError(store.UpdateCollection(env.U1.ID, coll))
+	needsUpdate, firmwareID, err := firmwareUpdateCheck(&d, report, time.Now(), store)
+	assert.NoError(err)
+	assert.True(needsUpdate)
+	assert.Equal(v2.ID, firmwareID)
+}
@@ -0,0 +1,189 @@
+package fota
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/fota/lwm2m/objects"
+	"github.com/eesrc/horde/pkg/metering"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// httpFirmwareURI returns the HTTP(S) download URI for the device if the
+// device supports the scheme of the HTTP firmware endpoint. The URI contains
+// a download token for the device and firmware image.
+func httpFirmwareURI(config Parameters, tokens *downloadTokens, protocols []objects.UpdateProtocol, imsi int64, firmwareID model.FirmwareKey) (string, bool) {
+	if config.HTTPFirmwareEndpoint == "" {
+		return "", false
+	}
+	u, err := url.Parse(config.HTTPFirmwareEndpoint)
+	if err != nil {
+		logging.Warning("HTTP firmware endpoint (%s) is invalid: %v", config.HTTPFirmwareEndpoint, err)
+		return "", false
+	}
+	var required objects.UpdateProtocol
+	switch u.Scheme {
+	case "http":
+		required = objects.HTTP
+	case "https":
+		required = objects.HTTPS
+	default:
+		logging.Warning("HTTP firmware endpoint (%s) must use http or https", config.HTTPFirmwareEndpoint)
+		return "", false
+	}
+	for _, p := range protocols {
+		if p == required {
+			return strings.TrimSuffix(u.String(), "/") + "/" + tokens.Token(imsi, firmwareID), true
+		}
+	}
+	return "", false
+}
+
+// downloadWriter keeps track of the status code and the number of bytes
+// written in the response
+type downloadWriter struct {
+	http.ResponseWriter
+	status  int
+	written int
+}
+
+func (d *downloadWriter) WriteHeader(status int) {
+	d.status = status
+	d.ResponseWriter.WriteHeader(status)
+}
+
+func (d *downloadWriter) Write(buf []byte) (int, error) {
+	if d.status == 0 {
+		d.status = http.StatusOK
+	}
+	n, err := d.ResponseWriter.Write(buf)
+	d.written += n
+	return n, err
+}
+
+// complete returns true if the response included the last byte of the image
+func (d *downloadWriter) complete(size int) bool {
+	switch d.status {
+	case http.StatusOK:
+		return d.written == size
+	case http.StatusPartialContent:
+		var first, last, total int
+		if _, err := fmt.Sscanf(d.Header().Get("Content-Range"), "bytes %d-%d/%d", &first, &last, &total); err != nil {
+			// Multipart ranges don't set the header. Devices download
+			// sequentially so these won't complete the download.
+			return false
+		}
+		return last == total-1 && d.written == last-first+1
+	}
+	return false
+}
+
+// newHTTPFirmwareHandler creates the handler for the HTTP firmware endpoint.
+// The last path element is the download token. Range and If-Range requests
+// are supported and the image's SHA-256 checksum is used as the ETag. The
+// device is flagged as downloading when it starts the download and the
+// download is completed when the last byte of the image has been sent.
+func newHTTPFirmwareHandler(store storage.DataStore, firmwareStore storage.FirmwareImageStore, tokens *downloadTokens) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		imsi, firmwareID, err := tokens.Verify(path.Base(r.URL.Path))
+		if err != nil {
+			logging.Info("Rejected firmware download from %s: %v", r.RemoteAddr, err)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		device, err := store.RetrieveDeviceByIMSI(imsi)
+		if err != nil {
+			logging.Info("Unable to retrieve device with IMSI %d for firmware download: %v", imsi, err)
+			http.NotFound(w, r)
+			return
+		}
+		target, ok := scheduledFirmware(&device, store)
+		if !ok || target != firmwareID {
+			logging.Info("Device with IMSI %d requested firmware image %s but it isn't scheduled for it", device.IMSI, firmwareID.String())
+			http.NotFound(w, r)
+			return
+		}
+		_, fw, err := store.RetrieveCurrentAndTargetFirmware(device.CollectionID, 0, firmwareID)
+		if err != nil {
+			logging.Warning("Unable to retrieve firmware %s for device with IMSI %d: %v", firmwareID.String(), device.IMSI, err)
+			http.NotFound(w, r)
+			return
+		}
+		image, err := readImage(firmwareID, firmwareStore)
+		if err != nil {
+			logging.Warning("Unable to read firmware image %s for device with IMSI %d: %v", firmwareID.String(), device.IMSI, err)
+			http.NotFound(w, r)
+			return
+		}
+
+		logging.Debug("Sending firmware (%d bytes, range %q) to device with IMSI %d", len(image), r.Header.Get("Range"), device.IMSI)
+		dw := &downloadWriter{ResponseWriter: w}
+		dw.Header().Set("Content-Type", "application/octet-stream")
+		dw.Header().Set("ETag", `"`+fw.SHA256+`"`)
+		http.ServeContent(dw, r, fw.Filename, fw.Created, bytes.NewReader(image))
+
+		if r.Method == http.MethodHead || dw.written == 0 {
+			return
+		}
+		metering.DefaultMeter.FirmwareDelivered(device.CollectionID, dw.written)
+		if dw.complete(len(image)) {
+			logging.Debug("Device with IMSI %d has completed firmware download", device.IMSI)
+			store.UpdateFirmwareStateForDevice(device.IMSI, model.Completed, "Device has completed image download")
+			return
+		}
+		if device.Firmware.State != model.Downloading {
+			store.UpdateFirmwareStateForDevice(device.IMSI, model.Downloading, "Device is downloading firmware image")
+		}
+	})
+}
+
+// startHTTPFirmwareServer launches the HTTP firmware server. The listener is
+// created before the function returns so errors are reported right away.
+func startHTTPFirmwareServer(config Parameters, handler http.Handler) error {
+	listener, err := net.Listen("tcp", config.HTTPListenAddress)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: handler}
+	go func() {
+		var err error
+		if config.HTTPTLSCertFile != "" && config.HTTPTLSKeyFile != "" {
+			logging.Info("Serving firmware images with HTTPS on %s", listener.Addr().String())
+			err = srv.ServeTLS(listener, config.HTTPTLSCertFile, config.HTTPTLSKeyFile)
+		} else {
+			logging.Info("Serving firmware images with HTTP on %s", listener.Addr().String())
+			err = srv.Serve(listener)
+		}
+		if err != http.ErrServerClosed {
+			logging.Error("HTTP firmware server stopped: %v", err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,188 @@
+package fota
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/fota/lwm2m/objects"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage/fwimage"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/eesrc/horde/pkg/storage/storetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPFirmwareURI(t *testing.T) {
+	assert := require.New(t)
+
+	tokens := newDownloadTokens([]byte("secret"), time.Hour)
+	config := Parameters{FirmwareEndpoint: "coap://172.16.15.14:5683/fw"}
+
+	// Disabled when there's no endpoint
+	_, ok := httpFirmwareURI(config, tokens, []objects.UpdateProtocol{objects.CoAP, objects.HTTPS}, 1, 2)
+	assert.False(ok)
+
+	config.HTTPFirmwareEndpoint = "https://fw.example.com/fw/"
+	_, ok = httpFirmwareURI(config, tokens, []objects.UpdateProtocol{objects.CoAP, objects.HTTP}, 1, 2)
+	assert.False(ok, "Device must support the endpoint's scheme")
+
+	uri, ok := httpFirmwareURI(config, tokens, []objects.UpdateProtocol{objects.CoAP, objects.HTTPS}, 1, 2)
+	assert.True(ok)
+	assert.True(strings.HasPrefix(uri, "https://fw.example.com/fw/"))
+	imsi, firmwareID, err := tokens.Verify(strings.TrimPrefix(uri, "https://fw.example.com/fw/"))
+	assert.NoError(err)
+	assert.Equal(int64(1), imsi)
+	assert.Equal(model.FirmwareKey(2), firmwareID)
+
+	config.HTTPFirmwareEndpoint = "http://fw.example.com/fw"
+	uri, ok = httpFirmwareURI(config, tokens, []objects.UpdateProtocol{objects.HTTP}, 1, 2)
+	assert.True(ok)
+	assert.True(strings.HasPrefix(uri, "http://fw.example.com/fw/"))
+
+	config.HTTPFirmwareEndpoint = "ftp://fw.example.com/fw"
+	_, ok = httpFirmwareURI(config, tokens, []objects.UpdateProtocol{objects.HTTP, objects.HTTPS}, 1, 2)
+	assert.False(ok)
+}
+
+func TestHTTPFirmwareHandler(t *testing.T) {
+	assert := require.New(t)
+
+	store := sqlstore.NewMemoryStore()
+	env := storetest.NewTestEnvironment(t, store)
+	firmwareStore, err := fwimage.NewSQLStore(sqlstore.Parameters{Type: "sqlite3", ConnectionString: ":memory:"})
+	assert.NoError(err)
+
+	image := make([]byte, 16*1024)
+	rand.Read(image)
+
+	fw := model.NewFirmware()
+	fw.ID = store.NewFirmwareID()
+	fw.Version = "2.0.0"
+	fw.Filename = "image.bin"
+	fw.Created = time.Now()
+	fw.CollectionID = env.C1.ID
+	fw.SHA256, err = firmwareStore.Create(fw.ID, bytes.NewReader(image))
+	assert.NoError(err)
+	assert.NoError(store.CreateFirmware(env.U1.ID, fw))
+
+	device := model.NewDevice()
+	device.ID = store.NewDeviceID()
+	device.IMSI = 4711
+	device.IMEI = 4711
+	device.CollectionID = env.C1.ID
+	device.Firmware.TargetFirmwareID = fw.ID
+	assert.NoError(store.CreateDevice(env.U1.ID, device))
+
+	coll := env.C1
+	coll.Firmware.Management = model.DeviceManagement
+	assert.NoError(store.UpdateCollection(env.U1.ID, coll))
+
+	tokens := newDownloadTokens([]byte("secret"), time.Hour)
+	server := httptest.NewServer(newHTTPFirmwareHandler(store, firmwareStore, tokens))
+	defer server.Close()
+
+	get := func(token string, headers map[string]string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/fw/"+token, nil)
+		assert.NoError(err)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(err)
+		return res
+	}
+	body := func(res *http.Response) []byte {
+		defer res.Body.Close()
+		buf, err := ioutil.ReadAll(res.Body)
+		assert.NoError(err)
+		return buf
+	}
+	state := func() model.DeviceFirmwareState {
+		d, err := store.RetrieveDeviceByIMSI(device.IMSI)
+		assert.NoError(err)
+		return d.Firmware.State
+	}
+
+	// Invalid tokens are rejected
+	res := get("invalid", nil)
+	body(res)
+	assert.Equal(http.StatusForbidden, res.StatusCode)
+
+	// ...and so are tokens for other images
+	res = get(tokens.Token(device.IMSI, fw.ID+1), nil)
+	body(res)
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	// ...and unknown devices
+	res = get(tokens.Token(1, fw.ID), nil)
+	body(res)
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	token := tokens.Token(device.IMSI, fw.ID)
+	etag := `"` + fw.SHA256 + `"`
+
+	// Download the first part of the image
+	res = get(token, map[string]string{"Range": "bytes=0-1023"})
+	assert.Equal(http.StatusPartialContent, res.StatusCode)
+	assert.Equal(etag, res.Header.Get("ETag"))
+	assert.Equal(image[:1024], body(res))
+	assert.Equal(model.Downloading, state())
+
+	// Continue with a matching If-Range
+	res = get(token, map[string]string{"Range": "bytes=1024-8191", "If-Range": etag})
+	assert.Equal(http.StatusPartialContent, res.StatusCode)
+	assert.Equal(image[1024:8192], body(res))
+	assert.Equal(model.Downloading, state())
+
+	// A different ETag in If-Range returns the entire image
+	res = get(token, map[string]string{"Range": "bytes=8192-", "If-Range": `"other"`})
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal(image, body(res))
+	assert.Equal(model.Completed, state())
+
+	// The last part completes the download
+	assert.NoError(store.UpdateFirmwareStateForDevice(device.IMSI, model.Downloading, ""))
+	res = get(token, map[string]string{"Range": fmt.Sprintf("bytes=8192-%d", len(image)-1), "If-Range": etag})
+	assert.Equal(http.StatusPartialContent, res.StatusCode)
+	assert.Equal(image[8192:], body(res))
+	assert.Equal(model.Completed, state())
+
+	// Conditional requests
+	res = get(token, map[string]string{"If-None-Match": etag})
+	body(res)
+	assert.Equal(http.StatusNotModified, res.StatusCode)
+
+	// Devices that are deferred won't get the image
+	assert.NoError(store.UpdateFirmwareStateForDevice(device.IMSI, model.Deferred, ""))
+	res = get(token, nil)
+	body(res)
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	// Only GET and HEAD is supported
+	res, err = http.Post(server.URL+"/fw/"+token, "application/octet-stream", nil)
+	assert.NoError(err)
+	body(res)
+	assert.Equal(http.StatusMethodNotAllowed, res.StatusCode)
+}
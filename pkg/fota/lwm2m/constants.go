@@ -31,3 +31,12 @@ const (
 	SupportedDeliveryMethodsPath = "/5/0/9"
 	RebootPath                   = "/3/4"
 )
+
+// FirmwareSignatureResource is the vendor specific resource in the firmware
+// update object that holds the signature of the image's SHA-256 checksum.
+// It is written before the image URI is set so the device can verify the
+// image when the download completes.
+const (
+	FirmwareSignatureResource = 100
+	FirmwareSignaturePath     = "/5/0/100"
+)
@@ -35,6 +35,10 @@ import (
 	coap "github.com/go-ocf/go-coap"
 )
 
+// statePollInterval is the default polling interval for devices that don't
+// support observation of the firmware state.
+const statePollInterval = 5 * time.Second
+
 type checkData struct {
 	device        model.Device
 	remotePort    int32
@@ -51,6 +55,8 @@ type fwUpdater struct {
 	store      storage.DataStore
 	coapServer *apn.RxTxReceiver
 	inProgress map[int64]bool
+	now        func() time.Time // Clock for the maintenance windows
+	tokens     *downloadTokens  // Download tokens for the HTTP firmware endpoint
 }
 
 // newFirmwareUpdater creates a new updater
@@ -62,6 +68,8 @@ func newFirmwareUpdater(store storage.DataStore, coapServer *apn.RxTxReceiver, c
 		mutex:      &sync.Mutex{},
 		coapServer: coapServer,
 		inProgress: make(map[int64]bool),
+		now:        time.Now,
+		tokens:     newDownloadTokens([]byte(config.DownloadTokenSecret), config.DownloadTokenTTL),
 	}
 	go ret.checkLoop()
 	return ret
@@ -95,7 +103,8 @@ func (f *fwUpdater) removeCheck(imsi int64) {
 
 // CheckDeviceVersion retrieves the device version and checks if it requires
 // a firmware update. It will enqueue the check and return immediately unless another
-// check is already in progress.
+// check is already in progress. The update is deferred if the device is outside
+// its maintenance window.
 func (f *fwUpdater) CheckDeviceVersion(device model.Device, remotePort int32, remoteAddress net.IP) {
 	f.checkChan <- checkData{
 		device:        device,
@@ -183,7 +192,7 @@ func (f *fwUpdater) checkFirmwareOnDevice(device model.Device, remotePort int32,
 		ModelNumber:      di.ModelNumber,
 	}
 
-	needsUpdate, firmwareID, err := firmwareUpdateCheck(&device, report, f.store)
+	needsUpdate, firmwareID, err := firmwareUpdateCheck(&device, report, f.now(), f.store)
 	if err != nil {
 		logging.Warning("Unable to check firmware via gRPC for IMSI %d. Ignoring: %v", device.IMSI, err)
 		return
@@ -206,40 +215,20 @@ func (f *fwUpdater) checkFirmwareOnDevice(device model.Device, remotePort int32,
 // The LwM2M library doesn't work with push which is OK - just use pull delivery
 // It might be possible to get it to work but the delivery methods are quite
 // similar from our point of view. This method blocks until the firmware update
-// is complete (ie success or failure)
+// is complete (ie success or failure) or the download times out.
 func (f *fwUpdater) updateFirmware(currentState objects.FirmwareUpdateState, device model.Device, remotePort int32, remoteAddress net.IP, firmwareID model.FirmwareKey) {
 	f.addCheck(device.IMSI)
 	defer f.removeCheck(device.IMSI)
 	if currentState != objects.Downloaded {
-		if !f.startDownload(device, remoteAddress, remotePort) {
+		if !f.startDownload(device, remoteAddress, remotePort, firmwareID) {
 			// Couldn't initialize download. Stop
 			return
 		}
 	}
 
-	// We *could* use observation here but we'll just poll once every minute until it reports
-	// state "downloaded" or it times out. The Zephyr implementation reports every 30 seconds
-	// but once the observation is turned on it must be cancelled to release the connection.
 	start := time.Now()
-
-	const statePollInterval = 5 * time.Second
-
-	// The state is set implicitly by checking the download state. There's no
-	// point polling the device when we already know what it is doing.
-	state := model.Downloading
-	for state != model.Completed {
-		time.Sleep(statePollInterval)
-		dev, err := f.store.RetrieveDeviceByIMSI(device.IMSI)
-		if err != nil {
-			logging.Warning("Error retrieving device with IMSI %d: %v", device.IMSI, err)
-			continue
-		}
-		state = dev.Firmware.State
-		logging.Debug("Device with IMSI %d has state %s", device.IMSI, state.String())
-		if state.IsError() {
-			logging.Warning("Download error for device with IMSI %d (%s). Stopping upgrade.", device.IMSI, state.String())
-			return
-		}
+	if !f.waitForDownload(device, remotePort, remoteAddress) {
+		return
 	}
 
 	durationSec := float64(time.Since(start)) / float64(time.Second)
@@ -276,14 +265,207 @@ func (f *fwUpdater) updateFirmware(currentState objects.FirmwareUpdateState, dev
 
 }
 
+// waitForDownload waits until the device has downloaded the image. The
+// firmware state (/5/0/3) and update result (/5/0/5) resources are observed and
+// the device's firmware state is updated from the notifications. Devices that
+// reject the observation are polled via the device store instead. The
+// observations are cancelled when the download completes, fails or times out.
+// Returns true if the image is downloaded.
+func (f *fwUpdater) waitForDownload(device model.Device, remotePort int32, remoteAddress net.IP) bool {
+	timeout := f.config.DownloadTimeout
+	if timeout == 0 {
+		timeout = coapDownloadTimeout
+	}
+	ctx, done := context.WithTimeout(context.Background(), timeout)
+	defer done()
+
+	stateObs, err := f.observeResource(lwm2m.FirmwareStatePath, device, remotePort, remoteAddress)
+	if err != nil {
+		logging.Info("Unable to observe %s on device with IMSI %d (%v). Polling for state.", lwm2m.FirmwareStatePath, device.IMSI, err)
+		return f.pollDownload(ctx, device, timeout)
+	}
+	defer f.cancelObservation(lwm2m.FirmwareStatePath, device, stateObs)
+
+	// The update result is only used to get the reason when the download
+	// fails so the state notifications are sufficient if the device doesn't
+	// support observing it.
+	var resultNotifications <-chan *rxtx.Message
+	resultObs, err := f.observeResource(lwm2m.FirmwareUpdateResultPath, device, remotePort, remoteAddress)
+	if err != nil {
+		logging.Info("Unable to observe %s on device with IMSI %d: %v", lwm2m.FirmwareUpdateResultPath, device.IMSI, err)
+	} else {
+		defer f.cancelObservation(lwm2m.FirmwareUpdateResultPath, device, resultObs)
+		resultNotifications = resultObs.Notifications
+	}
+
+	fu := objects.FirmwareUpdate{}
+	downloading := false
+	payload := stateObs.Response.Payload
+	for {
+		fu.SetState(objects.NewTLVBuffer(payload))
+		logging.Debug("Device with IMSI %d reports state %s", device.IMSI, fu.State.String())
+		switch fu.State {
+		case objects.Downloading:
+			if !downloading {
+				f.flagDevice(device, model.Downloading, "Device is downloading firmware image")
+			}
+			downloading = true
+		case objects.Downloaded:
+			f.flagDevice(device, model.Completed, "Device has completed image download")
+			return true
+		case objects.Idle:
+			// The device goes back to idle if the download fails
+			if downloading {
+				reason := "Device stopped downloading firmware image"
+				if fu.UpdateResult > objects.Success {
+					reason = fmt.Sprintf("Device reported %s when downloading firmware image", fu.UpdateResult.String())
+				}
+				f.flagDevice(device, model.UpdateFailed, reason)
+				return false
+			}
+		}
+
+		select {
+		case msg := <-stateObs.Notifications:
+			payload = msg.Payload
+		case msg := <-resultNotifications:
+			fu.SetUpdateResult(objects.NewTLVBuffer(msg.Payload))
+			logging.Debug("Device with IMSI %d reports update result %s", device.IMSI, fu.UpdateResult.String())
+			if fu.UpdateResult > objects.Success {
+				f.flagDevice(device, model.UpdateFailed, fmt.Sprintf("Device reported %s when downloading firmware image", fu.UpdateResult.String()))
+				return false
+			}
+		case <-ctx.Done():
+			f.flagDevice(device, model.TimedOut, fmt.Sprintf("Device did not download firmware image in %v", timeout))
+			return false
+		}
+	}
+}
+
+// pollDownload polls the device store until the download completes, fails or
+// times out. The state is set by the firmware endpoint when the device
+// downloads the image. Returns true if the image is downloaded.
+func (f *fwUpdater) pollDownload(ctx context.Context, device model.Device, timeout time.Duration) bool {
+	pollInterval := f.config.LWM2MPollInterval
+	if pollInterval == 0 {
+		pollInterval = statePollInterval
+	}
+
+	// The state is set implicitly by checking the download state. There's no
+	// point polling the device when we already know what it is doing.
+	state := model.Downloading
+	for state != model.Completed {
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			f.flagDevice(device, model.TimedOut, fmt.Sprintf("Device did not download firmware image in %v", timeout))
+			return false
+		}
+		dev, err := f.store.RetrieveDeviceByIMSI(device.IMSI)
+		if err != nil {
+			logging.Warning("Error retrieving device with IMSI %d: %v", device.IMSI, err)
+			continue
+		}
+		state = dev.Firmware.State
+		logging.Debug("Device with IMSI %d has state %s", device.IMSI, state.String())
+		if state.IsError() {
+			logging.Warning("Download error for device with IMSI %d (%s). Stopping upgrade.", device.IMSI, state.String())
+			return false
+		}
+	}
+	return true
+}
+
+// observeResource registers an observation for a resource on the device
+func (f *fwUpdater) observeResource(path string, device model.Device, remotePort int32, remoteAddress net.IP) (*apn.Observation, error) {
+	ctx, done := context.WithTimeout(context.Background(), coapLwM2MTimeoutSeconds*time.Second)
+	defer done()
+	return f.coapServer.Observe(ctx, &device, &rxtx.Message{
+		RemotePort:    remotePort,
+		RemoteAddress: remoteAddress,
+		Type:          rxtx.MessageType_CoAPPush,
+		Coap: &rxtx.CoAPOptions{
+			Path:           path,
+			Accept:         int32(coap.AppLwm2mTLV),
+			TimeoutSeconds: coapLwM2MTimeoutSeconds,
+		},
+	})
+}
+
+// cancelObservation deregisters the observation on the device. This uses a
+// new context since the download might have timed out.
+func (f *fwUpdater) cancelObservation(path string, device model.Device, obs *apn.Observation) {
+	ctx, done := context.WithTimeout(context.Background(), coapLwM2MTimeoutSeconds*time.Second)
+	defer done()
+	if err := obs.Cancel(ctx); err != nil {
+		logging.Warning("Unable to cancel observation of %s for device with IMSI %d: %v", path, device.IMSI, err)
+	}
+}
+
+// writeSignature writes the image signature to the device. Devices that don't
+// support the signature resource will still get the image; verifying the
+// signature is up to the device.
+func (f *fwUpdater) writeSignature(device model.Device, remoteAddress net.IP, remotePort int32, firmwareID model.FirmwareKey) {
+	signature, err := firmwareSignature(&device, firmwareID, f.store)
+	if err != nil || len(signature) == 0 {
+		return
+	}
+	logging.Debug("Writing image signature to %s for device with IMSI %d", lwm2m.FirmwareSignaturePath, device.IMSI)
+	ctx, done := context.WithTimeout(context.Background(), coapLwM2MTimeoutSeconds*time.Second)
+	defer done()
+	res, err := f.coapServer.Exchange(ctx, &device, &rxtx.Message{
+		Payload:       objects.EncodeBytes(lwm2m.FirmwareSignatureResource, signature),
+		RemoteAddress: remoteAddress,
+		RemotePort:    remotePort,
+		Type:          rxtx.MessageType_CoAPPush,
+		Coap: &rxtx.CoAPOptions{
+			Code:           int32(codes.PUT),
+			Path:           lwm2m.FirmwareSignaturePath,
+			ContentFormat:  int32(coap.AppLwm2mTLV),
+			Accept:         int32(coap.AppLwm2mTLV),
+			TimeoutSeconds: coapLwM2MTimeoutSeconds,
+		},
+	})
+	if err != nil {
+		logging.Warning("Unable to write image signature to device with IMSI %d: %v", device.IMSI, err)
+		return
+	}
+	if codes.Code(res.Coap.Code) == codes.NotFound {
+		logging.Info("Device with IMSI %d does not support image signatures (%s)", device.IMSI, lwm2m.FirmwareSignaturePath)
+	}
+}
+
+// firmwareURI returns the image URI for the device. Devices that support the
+// scheme for the HTTP firmware endpoint (/5/0/8) download the image via HTTP
+// or HTTPS. Everyone else uses the CoAP endpoint.
+func (f *fwUpdater) firmwareURI(device model.Device, remoteAddress net.IP, remotePort int32, firmwareID model.FirmwareKey) string {
+	if f.config.HTTPFirmwareEndpoint == "" {
+		return f.config.FirmwareEndpoint
+	}
+	tlv, err := f.queryDevice(lwm2m.SupportedProtocolsPath, device, remotePort, remoteAddress)
+	if err != nil {
+		logging.Info("Unable to query supported protocols (%s) for device with IMSI %d. Using CoAP: %v", lwm2m.SupportedProtocolsPath, device.IMSI, err)
+		return f.config.FirmwareEndpoint
+	}
+	fu := objects.FirmwareUpdate{}
+	fu.SetSupportedProtocols(tlv)
+	logging.Debug("Device with IMSI %d supports protocols %v", device.IMSI, fu.SupportedProtocols)
+	if uri, ok := httpFirmwareURI(f.config, f.tokens, fu.SupportedProtocols, device.IMSI, firmwareID); ok {
+		return uri
+	}
+	return f.config.FirmwareEndpoint
+}
+
 // startDownload initiates a download on the device via the LwM2M update object
-func (f *fwUpdater) startDownload(device model.Device, remoteAddress net.IP, remotePort int32) bool {
-	logging.Debug("Pointing to firmware image at \"%s\"", f.config.FirmwareEndpoint)
+func (f *fwUpdater) startDownload(device model.Device, remoteAddress net.IP, remotePort int32, firmwareID model.FirmwareKey) bool {
+	f.writeSignature(device, remoteAddress, remotePort, firmwareID)
+	uri := f.firmwareURI(device, remoteAddress, remotePort, firmwareID)
+	logging.Debug("Pointing to firmware image at \"%s\"", uri)
 	// Flag the device with "downloading" in case it starts right away. If it
 	// fails it will be flagged with an error. There's an extra access here but
 	// only when in error mode.
 	f.flagDevice(device, model.Downloading, "Waiting for device to download firmware image")
-	buf := objects.EncodeString(1, f.config.FirmwareEndpoint)
+	buf := objects.EncodeString(1, uri)
 	ctx, done := context.WithTimeout(context.Background(), coapLwM2MTimeoutSeconds*time.Second)
 	defer done()
 	res, err := f.coapServer.Exchange(ctx, &device, &rxtx.Message{
@@ -44,7 +44,8 @@ type Parameters struct {
 	DownloadTimeout time.Duration `param:"desc=Firmware download timeout;default=60m"`
 
 	// LWM2MPollInterval is the polling interval for the firmware state during
-	// the download. The default is 30s which is about the regular observe
+	// the download for devices that reject observation of the firmware state
+	// resource. The default is 30s which is about the regular observe
 	// intervals found in Zephyr. Decrease to speed up checks (but more polling
 	// uses more power), lower to make the checks slower. A download over a
 	// slow NB-IoT link might be 4800bps or less, depending on the configuration
@@ -53,7 +54,32 @@ type Parameters struct {
 	// 2400 baud modem. Fortunately this link is quicker for nRF91 (more like
 	// a few hundred kbps but a firmware download can still be measured in minutes
 	// not seconds.)
-	LWM2MPollInterval time.Duration `param:"desc=Polling interval for firmware state during download;default=30s"`
+	LWM2MPollInterval time.Duration `param:"desc=Polling interval for firmware state during download when observation is unsupported;default=30s"`
+
+	// HTTPFirmwareEndpoint is the public HTTP or HTTPS URI for the firmware
+	// server. LwM2M devices that support the scheme are pointed to this
+	// endpoint instead of the CoAP endpoint. The download token for the
+	// device is appended to the path.
+	HTTPFirmwareEndpoint string `param:"desc=HTTP(S) firmware endpoint for LwM2M devices. Disabled if empty"`
+
+	// HTTPListenAddress is the listen address for the HTTP firmware server.
+	// The server is only started if the address is set. TLS is used if both
+	// the certificate and key files are set. The server might run behind a
+	// load balancer that terminates TLS so the scheme in the endpoint doesn't
+	// have to match.
+	HTTPListenAddress string `param:"desc=Listen address for HTTP(S) firmware server. Disabled if empty"`
+	HTTPTLSCertFile   string `param:"desc=TLS certificate file for HTTP firmware server;file"`
+	HTTPTLSKeyFile    string `param:"desc=TLS key file for HTTP firmware server;file"`
+
+	// DownloadTokenSecret is the secret used to sign the download tokens for
+	// the HTTP firmware endpoint. All servers must use the same secret. A
+	// random secret is generated at startup if it is empty, which only works
+	// when a single server hands out and serves the tokens.
+	DownloadTokenSecret string `param:"desc=Secret for HTTP firmware download tokens. Random if empty"`
+
+	// DownloadTokenTTL is the validity for the download tokens. This should be
+	// longer than the download timeout.
+	DownloadTokenTTL time.Duration `param:"desc=Validity for HTTP firmware download tokens;default=2h"`
 }
 
 // GetFirmwareHostPortPath splits the firmware endpoint into its separate components
@@ -64,4 +64,6 @@ func TestDefaultParameters(t *testing.T) {
 	assert.Equal(5683, port)
 	assert.Equal("fw", path)
 	assert.Equal(30*time.Second, p.LWM2MPollInterval)
+	assert.Equal("", p.HTTPFirmwareEndpoint)
+	assert.Equal(2*time.Hour, p.DownloadTokenTTL)
 }
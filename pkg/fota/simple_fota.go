@@ -16,6 +16,7 @@ package fota
 // limitations under the License.
 //
 import (
+	"crypto/rand"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -25,6 +26,7 @@ import (
 	"github.com/eesrc/horde/pkg/apn"
 	"github.com/eesrc/horde/pkg/deviceio/rxtx"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
 	"github.com/eesrc/horde/pkg/storage"
 	"github.com/go-ocf/go-coap/codes"
 )
@@ -33,12 +35,14 @@ import (
 // to use a simplified FOTA procedure since LwM2M can be a true PITA to get
 // up and running.
 
-// Report is the request body of the simple FOTA procedure
+// Report is the request body of the simple FOTA procedure. Devices that can
+// apply deltas set the DeltaSupported flag.
 type Report struct {
 	FirmwareVersion  string
 	ManufacturerName string
 	SerialNumber     string
 	ModelNumber      string
+	DeltaSupported   bool
 }
 
 const (
@@ -46,11 +50,14 @@ const (
 	manufacturerNameID = 2
 	serialNumberID     = 3
 	modelNumberID      = 4
+	deltaSupportedID   = 5
 
 	hostID      = 1
 	portID      = 2
 	pathID      = 3
 	availableID = 4
+	signatureID = 5
+	deltaID     = 6
 
 	tlvFieldHeaderLength = 2
 )
@@ -83,6 +90,30 @@ func decodeTLVString(idx *int, buf []byte, val *string) error {
 	return nil
 }
 
+// Decode TLV boolean value in buffer.
+func decodeTLVBool(idx *int, buf []byte, val *bool) error {
+	if *idx >= len(buf) || buf[*idx] != 1 || *idx+1 >= len(buf) {
+		return errors.New("invalid boolean field")
+	}
+	*idx++
+	*val = buf[*idx] != 0
+	*idx++
+	return nil
+}
+
+// Encode byte buffer into TLV buffer. The buffer can't be longer than 255 bytes.
+func encodeTLVBytes(id byte, idx *int, buf []byte, val []byte) error {
+	if len(val) > 255 {
+		return fmt.Errorf("buffer for id %d is too long (%d bytes)", id, len(val))
+	}
+	buf[*idx] = id
+	*idx++
+	buf[*idx] = byte(len(val))
+	*idx++
+	*idx += copy(buf[*idx:], val)
+	return nil
+}
+
 // Encode Uint32 into buffer. Big endian encoding.
 func encodeTLVUInt32(id byte, idx *int, buf []byte, val uint32) error {
 	buf[*idx] = id
@@ -117,6 +148,7 @@ func (r *Report) UnmarshalBinary(buf []byte) error {
 	manufacturerName := ""
 	serialNumber := ""
 	modelNumber := ""
+	deltaSupported := false
 
 	for idx < len(buf) {
 		id := buf[idx]
@@ -138,6 +170,10 @@ func (r *Report) UnmarshalBinary(buf []byte) error {
 			if err := decodeTLVString(&idx, buf, &modelNumber); err != nil {
 				return err
 			}
+		case deltaSupportedID:
+			if err := decodeTLVBool(&idx, buf, &deltaSupported); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("unable to unmarshal id %d", buf[idx])
 		}
@@ -147,29 +183,41 @@ func (r *Report) UnmarshalBinary(buf []byte) error {
 	r.ManufacturerName = manufacturerName
 	r.SerialNumber = serialNumber
 	r.ModelNumber = modelNumber
+	r.DeltaSupported = deltaSupported
 	return nil
 }
 
 // SimpleFOTAResponse is the response for the simple FOTA procedure. It is sent
 // to the device as a response to the client. The host, port and path is sent
 // as separate fields just to make it simpler to decode. The path is separated
-// by slashes.
+// by slashes. The signature of the image's SHA-256 checksum is only included
+// when the image is signed. The Delta flag is only included when the path
+// points to a delta from the device's current image. The signature is for the
+// full image, ie the image after the delta is applied.
 type SimpleFOTAResponse struct {
 	Host           string
 	Port           uint32
 	Path           string
 	ImageAvailable bool
+	Signature      []byte
+	Delta          bool
 }
 
 // MarshalBinary encodes the response into a byte buffer that is sent to the
 // client. The content is TLV encoded.
 func (r *SimpleFOTAResponse) MarshalBinary() ([]byte, error) {
 
-	buf := make([]byte,
-		tlvFieldHeaderLength+len(r.Host)+
-			tlvFieldHeaderLength+4+
-			tlvFieldHeaderLength+len(r.Path)+
-			tlvFieldHeaderLength+1)
+	length := tlvFieldHeaderLength + len(r.Host) +
+		tlvFieldHeaderLength + 4 +
+		tlvFieldHeaderLength + len(r.Path) +
+		tlvFieldHeaderLength + 1
+	if len(r.Signature) > 0 {
+		length += tlvFieldHeaderLength + len(r.Signature)
+	}
+	if r.Delta {
+		length += tlvFieldHeaderLength + 1
+	}
+	buf := make([]byte, length)
 	idx := 0
 	if err := encodeTLVString(hostID, &idx, buf, r.Host); err != nil {
 		return nil, err
@@ -183,18 +231,45 @@ func (r *SimpleFOTAResponse) MarshalBinary() ([]byte, error) {
 	if err := encodeTLVBool(availableID, &idx, buf, r.ImageAvailable); err != nil {
 		return nil, err
 	}
+	if len(r.Signature) > 0 {
+		if err := encodeTLVBytes(signatureID, &idx, buf, r.Signature); err != nil {
+			return nil, err
+		}
+	}
+	if r.Delta {
+		if err := encodeTLVBool(deltaID, &idx, buf, r.Delta); err != nil {
+			return nil, err
+		}
+	}
 	return buf, nil
 }
 
 var lwm2mHandler *LwM2MHandler
 
-// SetupFOTA sets up the simple FOTA endpoint
-func SetupFOTA(config Parameters, receiver *apn.RxTxReceiver, datastore storage.DataStore, firmwareStore storage.FirmwareImageStore) error {
+// SetupFOTA sets up the simple FOTA endpoint. Changes to the devices'
+// firmware state are published as resource events. The HTTP firmware server
+// is started if the listen address is set.
+func SetupFOTA(config Parameters, receiver *apn.RxTxReceiver, datastore storage.DataStore, firmwareStore storage.FirmwareImageStore, events output.EventPublisher) error {
 	if lwm2mHandler != nil {
 		return errors.New("already started FOTA")
 	}
+	datastore = newEventStore(datastore, events)
+	if config.DownloadTokenSecret == "" {
+		// Tokens are only valid for this server
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+		config.DownloadTokenSecret = string(secret)
+	}
+	if config.HTTPListenAddress != "" {
+		tokens := newDownloadTokens([]byte(config.DownloadTokenSecret), config.DownloadTokenTTL)
+		if err := startHTTPFirmwareServer(config, newHTTPFirmwareHandler(datastore, firmwareStore, tokens)); err != nil {
+			return err
+		}
+	}
 	logging.Info("Registering handler for /u /fw and /rd endpoints in CoAP server")
-	receiver.AddCoAPHandler("u", newSimpleCoAPHandler(config, datastore))
+	receiver.AddCoAPHandler("u", newSimpleCoAPHandler(config, datastore, firmwareStore, time.Now))
 	receiver.AddCoAPHandler("fw", newFirmwareHandler(receiver, config.DownloadTimeout, datastore, firmwareStore))
 
 	lwm2mHandler = NewLwM2MHandler(receiver, datastore, config)
@@ -215,7 +290,9 @@ func writeResponse(c codes.Code, payload []byte) (*rxtx.DownstreamResponse, apn.
 	}, nil, nil
 }
 
-func newSimpleCoAPHandler(config Parameters, datastore storage.DataStore) apn.CoAPHandler {
+// newSimpleCoAPHandler creates the handler for the simple FOTA endpoint. The
+// clock is used to check the maintenance windows.
+func newSimpleCoAPHandler(config Parameters, datastore storage.DataStore, firmwareStore storage.FirmwareImageStore, now func() time.Time) apn.CoAPHandler {
 	return func(apnID int, nasID int, device *model.Device, req *rxtx.UpstreamRequest) (*rxtx.DownstreamResponse, apn.ResponseCallback, error) {
 
 		// Device will send a buffer with TLV encoded parameters and the
@@ -233,13 +310,24 @@ func newSimpleCoAPHandler(config Parameters, datastore storage.DataStore) apn.Co
 			logging.Debug("        manuf ver: %s", data.ManufacturerName)
 			logging.Debug("            model: %s", data.ModelNumber)
 			logging.Debug("           serial: %s", data.SerialNumber)
+			logging.Debug("            delta: %t", data.DeltaSupported)
 
-			needsUpdate, _, err := firmwareUpdateCheck(device, data, datastore)
+			needsUpdate, firmwareID, err := firmwareUpdateCheck(device, data, now(), datastore)
 
 			if err != nil {
 				logging.Warning("Got error checking firmware for device with IMSI %d: %v", device.IMSI, err)
 				return writeResponse(codes.InternalServerError, nil)
 			}
+			var signature []byte
+			useDelta := false
+			if needsUpdate {
+				signature, err = firmwareSignature(device, firmwareID, datastore)
+				if err != nil {
+					return writeResponse(codes.InternalServerError, nil)
+				}
+				// Fall back to the full image if the delta can't be generated
+				useDelta = data.DeltaSupported && deltaAvailable(device, firmwareID, firmwareStore)
+			}
 
 			// Extract host, port, path from the firmware URL in the configuration
 			host, port, path, err := config.GetFirmwareHostPortPath()
@@ -248,6 +336,9 @@ func newSimpleCoAPHandler(config Parameters, datastore storage.DataStore) apn.Co
 
 				return writeResponse(codes.InternalServerError, nil)
 			}
+			if useDelta {
+				path += "/" + deltaPath
+			}
 
 			// The Zephyr CoAP library prefers 2.04 Created response to to POSTs
 			// but it should not make a difference as long as it is 2.xx.
@@ -256,14 +347,16 @@ func newSimpleCoAPHandler(config Parameters, datastore storage.DataStore) apn.Co
 				Port:           uint32(port),
 				Path:           path,
 				ImageAvailable: needsUpdate,
+				Signature:      signature,
+				Delta:          useDelta,
 			}
 			payload, err := resp.MarshalBinary()
 			if err != nil {
 				logging.Error("Error marshaling response: %v. Sending 5.00 internal server error", err)
 				return writeResponse(codes.InternalServerError, nil)
 			}
-			logging.Debug("Sending response Host:%s  Port:%d  Path:%s  Image:%t",
-				resp.Host, resp.Port, resp.Path, resp.ImageAvailable)
+			logging.Debug("Sending response Host:%s  Port:%d  Path:%s  Image:%t  Signed:%t  Delta:%t",
+				resp.Host, resp.Port, resp.Path, resp.ImageAvailable, len(resp.Signature) > 0, resp.Delta)
 			return writeResponse(codes.Created, payload)
 
 		default:
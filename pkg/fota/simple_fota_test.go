@@ -17,8 +17,14 @@ package fota
 //
 import (
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/eesrc/horde/pkg/deviceio/rxtx"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/eesrc/horde/pkg/storage/storetest"
+	"github.com/go-ocf/go-coap/codes"
 	"github.com/stretchr/testify/require"
 )
 
@@ -67,6 +73,13 @@ func TestReportEncoding(t *testing.T) {
 	assert.NoError(phd.UnmarshalBinary(buf[:idx]))
 
 	assert.Equal(original, phd)
+
+	original.DeltaSupported = true
+	assert.NoError(encodeTLVBool(deltaSupportedID, &idx, buf, original.DeltaSupported))
+	assert.NoError(phd.UnmarshalBinary(buf[:idx]))
+	assert.Equal(original, phd)
+
+	assert.Error(phd.UnmarshalBinary(buf[:idx-1]))
 }
 
 func TestFwEndpoint(t *testing.T) {
@@ -95,3 +108,143 @@ func TestFwEndpoint(t *testing.T) {
 	assert.Equal(fe.Path, outStr)
 
 }
+
+func TestFwEndpointSignature(t *testing.T) {
+	assert := require.New(t)
+
+	fe := SimpleFOTAResponse{
+		Host:           "172.16.15.14",
+		Port:           5683,
+		Path:           "fw",
+		ImageAvailable: true,
+	}
+	unsigned, err := fe.MarshalBinary()
+	assert.NoError(err)
+
+	fe.Signature = []byte{1, 2, 3, 4, 5}
+	buf, err := fe.MarshalBinary()
+	assert.NoError(err)
+	assert.Equal(unsigned, buf[:len(unsigned)])
+	idx := len(unsigned)
+	assert.Equal(byte(signatureID), buf[idx])
+	assert.Equal(byte(len(fe.Signature)), buf[idx+1])
+	assert.Equal(fe.Signature, buf[idx+2:])
+
+	fe.Signature = make([]byte, 256)
+	_, err = fe.MarshalBinary()
+	assert.Error(err)
+}
+
+func TestFwEndpointDelta(t *testing.T) {
+	assert := require.New(t)
+
+	fe := SimpleFOTAResponse{
+		Host:           "172.16.15.14",
+		Port:           5683,
+		Path:           "fw",
+		ImageAvailable: true,
+	}
+	full, err := fe.MarshalBinary()
+	assert.NoError(err)
+
+	fe.Path = "fw/delta"
+	fe.Delta = true
+	buf, err := fe.MarshalBinary()
+	assert.NoError(err)
+	assert.Equal(len(full)+len(deltaPath)+1+tlvFieldHeaderLength+1, len(buf))
+	idx := len(buf) - 3
+	assert.Equal(byte(deltaID), buf[idx])
+	idx++
+	val := false
+	assert.NoError(decodeTLVBool(&idx, buf, &val))
+	assert.True(val)
+}
+
+func TestSimpleFOTAMaintenanceWindow(t *testing.T) {
+	assert := require.New(t)
+
+	store := sqlstore.NewMemoryStore()
+	env := storetest.NewTestEnvironment(t, store)
+
+	newImage := func(version string) model.Firmware {
+		fw := model.NewFirmware()
+		fw.ID = store.NewFirmwareID()
+		fw.Version = version
+		fw.Filename = version + ".bin"
+		fw.SHA256 = version
+		fw.Created = time.Now()
+		fw.CollectionID = env.C1.ID
+		assert.NoError(store.CreateFirmware(env.U1.ID, fw))
+		return fw
+	}
+	v1 := newImage("1.0.0")
+	v2 := newImage("2.0.0")
+
+	// Weekdays from 22:00 to 06:00 UTC
+	coll := env.C1
+	coll.Firmware.Management = model.CollectionManagement
+	coll.Firmware.TargetFirmwareID = v2.ID
+	coll.Firmware.MaintenanceWindow = model.MaintenanceWindow{Schedule: "0 22 * * 1-5", Duration: 8 * time.Hour}
+	assert.NoError(store.UpdateCollection(env.U1.ID, coll))
+
+	device := model.NewDevice()
+	device.ID = store.NewDeviceID()
+	device.IMSI = 1
+	device.IMEI = 1
+	device.CollectionID = env.C1.ID
+	device.Firmware.CurrentFirmwareID = v1.ID
+	assert.NoError(store.CreateDevice(env.U1.ID, device))
+
+	// 2020-06-01 is a Monday
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	config := Parameters{FirmwareEndpoint: "coap://172.16.15.14:5683/fw"}
+	handler := newSimpleCoAPHandler(config, store, nil, func() time.Time { return now })
+
+	buf := make([]byte, 128)
+	idx := 0
+	assert.NoError(encodeTLVString(firmwareVersionID, &idx, buf, v1.Version))
+	req := &rxtx.UpstreamRequest{
+		Msg: &rxtx.Message{
+			Payload: buf[:idx],
+			Coap:    &rxtx.CoAPOptions{Code: int32(codes.POST)},
+		},
+	}
+
+	// The image availability flag follows the host, port and path fields
+	imageAvailable := func() bool {
+		d, err := store.RetrieveDevice(env.U1.ID, env.C1.ID, device.ID)
+		assert.NoError(err)
+		resp, _, err := handler(1, 1, &d, req)
+		assert.NoError(err)
+		assert.Equal(int32(codes.Created), resp.Msg.Coap.Code)
+		host, port, path, err := config.GetFirmwareHostPortPath()
+		assert.NoError(err)
+		idx := len(host) + 4 + len(path) + 3*tlvFieldHeaderLength
+		assert.Equal(byte(availableID), resp.Msg.Payload[idx])
+		idx++
+		available := false
+		assert.NoError(decodeTLVBool(&idx, resp.Msg.Payload, &available))
+		assert.NotZero(port)
+		return available
+	}
+
+	assert.False(imageAvailable(), "No update outside the window")
+	d, err := store.RetrieveDevice(env.U1.ID, env.C1.ID, device.ID)
+	assert.NoError(err)
+	assert.Equal(model.Deferred, d.Firmware.State)
+	assert.Contains(d.Firmware.StateMessage, "2020-06-01T22:00:00Z")
+
+	now = time.Date(2020, 6, 1, 23, 0, 0, 0, time.UTC)
+	assert.True(imageAvailable(), "Update inside the window")
+	d, err = store.RetrieveDevice(env.U1.ID, env.C1.ID, device.ID)
+	assert.NoError(err)
+	assert.Equal(model.Pending, d.Firmware.State)
+	assert.Empty(d.Firmware.StateMessage)
+
+	// The device's window overrides the collection's window
+	d.Firmware.MaintenanceWindow = model.MaintenanceWindow{Schedule: "0 2 * * *", Duration: time.Hour}
+	assert.NoError(store.UpdateDevice(env.U1.ID, env.C1.ID, d))
+	assert.False(imageAvailable())
+	now = time.Date(2020, 6, 6, 2, 30, 0, 0, time.UTC)
+	assert.True(imageAvailable())
+}
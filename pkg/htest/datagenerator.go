@@ -73,6 +73,7 @@ func createUsers(count int, settings GeneratorSettings, store storage.DataStore)
 			token.UserID = newUser.ID
 			token.Resource = "/"
 			token.Write = rand.Int() == 0
+			token.Created = time.Now()
 			if err := store.CreateToken(token); err != nil {
 				return ret, fmt.Errorf("error creating token: %v", err)
 			}
@@ -38,6 +38,7 @@ const (
 	ManufacturerID = 2
 	ModelID        = 4
 	SerialID       = 3
+	DeltaID        = 5
 )
 
 // Identifiers for the TLV buffer sent back to the device.
@@ -46,6 +47,8 @@ const (
 	portID      = 2
 	pathID      = 3
 	availableID = 4
+	signatureID = 5
+	deltaID     = 6
 )
 
 // TLVBuffer is a type to decode TLV payloads
@@ -82,6 +85,18 @@ func (t *TLVBuffer) EncodeTLVString(id byte, value string) {
 	}
 }
 
+// EncodeTLVBool encodes a boolean value into a TLV buffer
+func (t *TLVBuffer) EncodeTLVBool(id byte, value bool) {
+	t.buffer[t.idx] = id
+	t.idx++
+	t.buffer[t.idx] = 1
+	t.idx++
+	if value {
+		t.buffer[t.idx] = 1
+	}
+	t.idx++
+}
+
 // Buffer returns the encoded buffer
 func (t *TLVBuffer) Buffer() []byte {
 	return t.buffer
@@ -98,6 +113,14 @@ func decodeTLVString(buf []byte, idx *int) string {
 	return ret
 }
 
+func decodeTLVBytes(buf []byte, idx *int) []byte {
+	len := int(buf[*idx])
+	*idx++
+	ret := append([]byte{}, buf[*idx:*idx+len]...)
+	*idx += len
+	return ret
+}
+
 func decodeTLVUint32(buf []byte, idx *int) uint32 {
 	ret := uint32(0)
 	if buf[*idx] != 4 {
@@ -142,6 +165,12 @@ func DecodeSimpleFOTAResponse(buf []byte) (fota.SimpleFOTAResponse, error) {
 		case availableID:
 			idx++
 			ret.ImageAvailable = decodeTLVBool(buf, &idx)
+		case signatureID:
+			idx++
+			ret.Signature = decodeTLVBytes(buf, &idx)
+		case deltaID:
+			idx++
+			ret.Delta = decodeTLVBool(buf, &idx)
 		default:
 			return ret, fmt.Errorf("unknown id %d at pos %d", buf[idx], idx)
 		}
@@ -1346,6 +1346,496 @@ func (m *RemoveTokenResponse) GetResult() *Result {
 	return nil
 }
 
+type TeamQuota struct {
+	MaxDevices           int32    `protobuf:"varint,1,opt,name=MaxDevices,proto3" json:"MaxDevices,omitempty"`
+	MaxCollections       int32    `protobuf:"varint,2,opt,name=MaxCollections,proto3" json:"MaxCollections,omitempty"`
+	MaxOutputs           int32    `protobuf:"varint,3,opt,name=MaxOutputs,proto3" json:"MaxOutputs,omitempty"`
+	MaxFirmwareBytes     int64    `protobuf:"varint,4,opt,name=MaxFirmwareBytes,proto3" json:"MaxFirmwareBytes,omitempty"`
+	MaxUplinkPerDay      int32    `protobuf:"varint,5,opt,name=MaxUplinkPerDay,proto3" json:"MaxUplinkPerDay,omitempty"`
+	MaxDownlinkPerDay    int32    `protobuf:"varint,6,opt,name=MaxDownlinkPerDay,proto3" json:"MaxDownlinkPerDay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamQuota) Reset()         { *m = TeamQuota{} }
+func (m *TeamQuota) String() string { return proto.CompactTextString(m) }
+func (*TeamQuota) ProtoMessage()    {}
+func (*TeamQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edc174f991dc0a25, []int{29}
+}
+
+func (m *TeamQuota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamQuota.Unmarshal(m, b)
+}
+func (m *TeamQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamQuota.Marshal(b, m, deterministic)
+}
+func (m *TeamQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamQuota.Merge(m, src)
+}
+func (m *TeamQuota) XXX_Size() int {
+	return xxx_messageInfo_TeamQuota.Size(m)
+}
+func (m *TeamQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamQuota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamQuota proto.InternalMessageInfo
+
+func (m *TeamQuota) GetMaxDevices() int32 {
+	if m != nil {
+		return m.MaxDevices
+	}
+	return 0
+}
+
+func (m *TeamQuota) GetMaxCollections() int32 {
+	if m != nil {
+		return m.MaxCollections
+	}
+	return 0
+}
+
+func (m *TeamQuota) GetMaxOutputs() int32 {
+	if m != nil {
+		return m.MaxOutputs
+	}
+	return 0
+}
+
+func (m *TeamQuota) GetMaxFirmwareBytes() int64 {
+	if m != nil {
+		return m.MaxFirmwareBytes
+	}
+	return 0
+}
+
+func (m *TeamQuota) GetMaxUplinkPerDay() int32 {
+	if m != nil {
+		return m.MaxUplinkPerDay
+	}
+	return 0
+}
+
+func (m *TeamQuota) GetMaxDownlinkPerDay() int32 {
+	if m != nil {
+		return m.MaxDownlinkPerDay
+	}
+	return 0
+}
+
+type TeamUsage struct {
+	Devices              int32    `protobuf:"varint,1,opt,name=Devices,proto3" json:"Devices,omitempty"`
+	Collections          int32    `protobuf:"varint,2,opt,name=Collections,proto3" json:"Collections,omitempty"`
+	Outputs              int32    `protobuf:"varint,3,opt,name=Outputs,proto3" json:"Outputs,omitempty"`
+	FirmwareBytes        int64    `protobuf:"varint,4,opt,name=FirmwareBytes,proto3" json:"FirmwareBytes,omitempty"`
+	UplinkToday          int32    `protobuf:"varint,5,opt,name=UplinkToday,proto3" json:"UplinkToday,omitempty"`
+	DownlinkToday        int32    `protobuf:"varint,6,opt,name=DownlinkToday,proto3" json:"DownlinkToday,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamUsage) Reset()         { *m = TeamUsage{} }
+func (m *TeamUsage) String() string { return proto.CompactTextString(m) }
+func (*TeamUsage) ProtoMessage()    {}
+func (*TeamUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edc174f991dc0a25, []int{30}
+}
+
+func (m *TeamUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamUsage.Unmarshal(m, b)
+}
+func (m *TeamUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamUsage.Marshal(b, m, deterministic)
+}
+func (m *TeamUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamUsage.Merge(m, src)
+}
+func (m *TeamUsage) XXX_Size() int {
+	return xxx_messageInfo_TeamUsage.Size(m)
+}
+func (m *TeamUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamUsage proto.InternalMessageInfo
+
+func (m *TeamUsage) GetDevices() int32 {
+	if m != nil {
+		return m.Devices
+	}
+	return 0
+}
+
+func (m *TeamUsage) GetCollections() int32 {
+	if m != nil {
+		return m.Collections
+	}
+	return 0
+}
+
+func (m *TeamUsage) GetOutputs() int32 {
+	if m != nil {
+		return m.Outputs
+	}
+	return 0
+}
+
+func (m *TeamUsage) GetFirmwareBytes() int64 {
+	if m != nil {
+		return m.FirmwareBytes
+	}
+	return 0
+}
+
+func (m *TeamUsage) GetUplinkToday() int32 {
+	if m != nil {
+		return m.UplinkToday
+	}
+	return 0
+}
+
+func (m *TeamUsage) GetDownlinkToday() int32 {
+	if m != nil {
+		return m.DownlinkToday
+	}
+	return 0
+}
+
+type SetTeamQuotaRequest struct {
+	TeamId               string     `protobuf:"bytes,1,opt,name=TeamId,proto3" json:"TeamId,omitempty"`
+	Quota                *TeamQuota `protobuf:"bytes,2,opt,name=Quota,proto3" json:"Quota,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetTeamQuotaRequest) Reset()         { *m = SetTeamQuotaRequest{} }
+func (m *SetTeamQuotaRequest) String() string { return proto.CompactTextString(m) }
+func (*SetTeamQuotaRequest) ProtoMessage()    {}
+func (*SetTeamQuotaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edc174f991dc0a25, []int{31}
+}
+
+func (m *SetTeamQuotaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTeamQuotaRequest.Unmarshal(m, b)
+}
+func (m *SetTeamQuotaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTeamQuotaRequest.Marshal(b, m, deterministic)
+}
+func (m *SetTeamQuotaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTeamQuotaRequest.Merge(m, src)
+}
+func (m *SetTeamQuotaRequest) XXX_Size() int {
+	return xxx_messageInfo_SetTeamQuotaRequest.Size(m)
+}
+func (m *SetTeamQuotaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTeamQuotaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTeamQuotaRequest proto.InternalMessageInfo
+
+func (m *SetTeamQuotaRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *SetTeamQuotaRequest) GetQuota() *TeamQuota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+type SetTeamQuotaResponse struct {
+	Result               *Result  `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTeamQuotaResponse) Reset()         { *m = SetTeamQuotaResponse{} }
+func (m *SetTeamQuotaResponse) String() string { return proto.CompactTextString(m) }
+func (*SetTeamQuotaResponse) ProtoMessage()    {}
+func (*SetTeamQuotaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edc174f991dc0a25, []int{32}
+}
+
+func (m *SetTeamQuotaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTeamQuotaResponse.Unmarshal(m, b)
+}
+func (m *SetTeamQuotaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTeamQuotaResponse.Marshal(b, m, deterministic)
+}
+func (m *SetTeamQuotaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTeamQuotaResponse.Merge(m, src)
+}
+func (m *SetTeamQuotaResponse) XXX_Size() int {
+	return xxx_messageInfo_SetTeamQuotaResponse.Size(m)
+}
+func (m *SetTeamQuotaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTeamQuotaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTeamQuotaResponse proto.InternalMessageInfo
+
+func (m *SetTeamQuotaResponse) GetResult() *Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetTeamQuotaRequest struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=TeamId,proto3" json:"TeamId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTeamQuotaRequest) Reset()         { *m = GetTeamQuotaRequest{} }
+func (m *GetTeamQuotaRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTeamQuotaRequest) ProtoMessage()    {}
+func (*GetTeamQuotaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edc174f991dc0a25, []int{33}
+}
+
+func (m *GetTeamQuotaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTeamQuotaRequest.Unmarshal(m, b)
+}
+func (m *GetTeamQuotaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTeamQuotaRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTeamQuotaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTeamQuotaRequest.Merge(m, src)
+}
+func (m *GetTeamQuotaRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTeamQuotaRequest.Size(m)
+}
+func (m *GetTeamQuotaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTeamQuotaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTeamQuotaRequest proto.InternalMessageInfo
+
+func (m *GetTeamQuotaRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+type GetTeamQuotaResponse struct {
+	Result               *Result    `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	Quota                *TeamQuota `protobuf:"bytes,2,opt,name=Quota,proto3" json:"Quota,omitempty"`
+	Usage                *TeamUsage `protobuf:"bytes,3,opt,name=Usage,proto3" json:"Usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetTeamQuotaResponse) Reset()         { *m = GetTeamQuotaResponse{} }
+func (m *GetTeamQuotaResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTeamQuotaResponse) ProtoMessage()    {}
+func (*GetTeamQuotaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edc174f991dc0a25, []int{34}
+}
+
+func (m *GetTeamQuotaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTeamQuotaResponse.Unmarshal(m, b)
+}
+func (m *GetTeamQuotaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTeamQuotaResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTeamQuotaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTeamQuotaResponse.Merge(m, src)
+}
+func (m *GetTeamQuotaResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTeamQuotaResponse.Size(m)
+}
+func (m *GetTeamQuotaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTeamQuotaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTeamQuotaResponse proto.InternalMessageInfo
+
+func (m *GetTeamQuotaResponse) GetResult() *Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *GetTeamQuotaResponse) GetQuota() *TeamQuota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+func (m *GetTeamQuotaResponse) GetUsage() *TeamUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
+}
+
+type DataRestoreRequest struct {
+	TeamId string `protobuf:"bytes,1,opt,name=TeamId,proto3" json:"TeamId,omitempty"`
+	// The user that restores the dump. The user must be able to manage
+	// collections, devices, outputs and firmware in the team.
+	UserId               string   `protobuf:"bytes,2,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	Dump                 []byte   `protobuf:"bytes,3,opt,name=Dump,proto3" json:"Dump,omitempty"`
+	IncludeData          bool     `protobuf:"varint,4,opt,name=IncludeData,proto3" json:"IncludeData,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataRestoreRequest) Reset()         { *m = DataRestoreRequest{} }
+func (m *DataRestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*DataRestoreRequest) ProtoMessage()    {}
+func (*DataRestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edc174f991dc0a25, []int{35}
+}
+
+func (m *DataRestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataRestoreRequest.Unmarshal(m, b)
+}
+func (m *DataRestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataRestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *DataRestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRestoreRequest.Merge(m, src)
+}
+func (m *DataRestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_DataRestoreRequest.Size(m)
+}
+func (m *DataRestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataRestoreRequest proto.InternalMessageInfo
+
+func (m *DataRestoreRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *DataRestoreRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *DataRestoreRequest) GetDump() []byte {
+	if m != nil {
+		return m.Dump
+	}
+	return nil
+}
+
+func (m *DataRestoreRequest) GetIncludeData() bool {
+	if m != nil {
+		return m.IncludeData
+	}
+	return false
+}
+
+type DataRestoreResponse struct {
+	Result               *Result  `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	CollectionIds        []string `protobuf:"bytes,2,rep,name=CollectionIds,proto3" json:"CollectionIds,omitempty"`
+	Devices              int32    `protobuf:"varint,3,opt,name=Devices,proto3" json:"Devices,omitempty"`
+	Outputs              int32    `protobuf:"varint,4,opt,name=Outputs,proto3" json:"Outputs,omitempty"`
+	Firmware             int32    `protobuf:"varint,5,opt,name=Firmware,proto3" json:"Firmware,omitempty"`
+	Messages             int32    `protobuf:"varint,6,opt,name=Messages,proto3" json:"Messages,omitempty"`
+	Conflicts            []string `protobuf:"bytes,7,rep,name=Conflicts,proto3" json:"Conflicts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataRestoreResponse) Reset()         { *m = DataRestoreResponse{} }
+func (m *DataRestoreResponse) String() string { return proto.CompactTextString(m) }
+func (*DataRestoreResponse) ProtoMessage()    {}
+func (*DataRestoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edc174f991dc0a25, []int{36}
+}
+
+func (m *DataRestoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataRestoreResponse.Unmarshal(m, b)
+}
+func (m *DataRestoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataRestoreResponse.Marshal(b, m, deterministic)
+}
+func (m *DataRestoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRestoreResponse.Merge(m, src)
+}
+func (m *DataRestoreResponse) XXX_Size() int {
+	return xxx_messageInfo_DataRestoreResponse.Size(m)
+}
+func (m *DataRestoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRestoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataRestoreResponse proto.InternalMessageInfo
+
+func (m *DataRestoreResponse) GetResult() *Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *DataRestoreResponse) GetCollectionIds() []string {
+	if m != nil {
+		return m.CollectionIds
+	}
+	return nil
+}
+
+func (m *DataRestoreResponse) GetDevices() int32 {
+	if m != nil {
+		return m.Devices
+	}
+	return 0
+}
+
+func (m *DataRestoreResponse) GetOutputs() int32 {
+	if m != nil {
+		return m.Outputs
+	}
+	return 0
+}
+
+func (m *DataRestoreResponse) GetFirmware() int32 {
+	if m != nil {
+		return m.Firmware
+	}
+	return 0
+}
+
+func (m *DataRestoreResponse) GetMessages() int32 {
+	if m != nil {
+		return m.Messages
+	}
+	return 0
+}
+
+func (m *DataRestoreResponse) GetConflicts() []string {
+	if m != nil {
+		return m.Conflicts
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Result)(nil), "managementproto.Result")
 	proto.RegisterType((*APN)(nil), "managementproto.APN")
@@ -1376,65 +1866,97 @@ func init() {
 	proto.RegisterType((*AddTokenResponse)(nil), "managementproto.AddTokenResponse")
 	proto.RegisterType((*RemoveTokenRequest)(nil), "managementproto.RemoveTokenRequest")
 	proto.RegisterType((*RemoveTokenResponse)(nil), "managementproto.RemoveTokenResponse")
+	proto.RegisterType((*TeamQuota)(nil), "managementproto.TeamQuota")
+	proto.RegisterType((*TeamUsage)(nil), "managementproto.TeamUsage")
+	proto.RegisterType((*SetTeamQuotaRequest)(nil), "managementproto.SetTeamQuotaRequest")
+	proto.RegisterType((*SetTeamQuotaResponse)(nil), "managementproto.SetTeamQuotaResponse")
+	proto.RegisterType((*GetTeamQuotaRequest)(nil), "managementproto.GetTeamQuotaRequest")
+	proto.RegisterType((*GetTeamQuotaResponse)(nil), "managementproto.GetTeamQuotaResponse")
+	proto.RegisterType((*DataRestoreRequest)(nil), "managementproto.DataRestoreRequest")
+	proto.RegisterType((*DataRestoreResponse)(nil), "managementproto.DataRestoreResponse")
 }
 
 func init() { proto.RegisterFile("management.proto", fileDescriptor_edc174f991dc0a25) }
 
 var fileDescriptor_edc174f991dc0a25 = []byte{
-	// 837 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x7f, 0x6f, 0xd3, 0x3c,
-	0x10, 0x56, 0x7f, 0xac, 0x6b, 0xaf, 0xda, 0xd6, 0xd7, 0xef, 0x18, 0x59, 0x90, 0x58, 0x67, 0xc6,
-	0x54, 0xd8, 0xd4, 0x49, 0x43, 0x08, 0x84, 0x04, 0x22, 0xda, 0x06, 0x8b, 0xb4, 0x85, 0x28, 0x05,
-	0x09, 0xa1, 0x09, 0x14, 0x5a, 0x6f, 0x8a, 0x68, 0x93, 0x92, 0x64, 0xdb, 0xc7, 0xe0, 0xbb, 0xf0,
-	0x09, 0x51, 0x1c, 0x3b, 0x71, 0x53, 0x27, 0xab, 0x02, 0xff, 0xd9, 0xce, 0xdd, 0xe3, 0xe7, 0xee,
-	0x1e, 0xdf, 0x05, 0x3a, 0x13, 0xdb, 0xb5, 0xaf, 0xc8, 0x84, 0xb8, 0x61, 0x7f, 0xea, 0x7b, 0xa1,
-	0x87, 0xd6, 0xd2, 0x13, 0x7a, 0x80, 0x5f, 0x42, 0xc3, 0x22, 0xc1, 0xf5, 0x38, 0x44, 0x0a, 0x2c,
-	0x0f, 0xae, 0x87, 0x43, 0x12, 0x04, 0x4a, 0xa5, 0x5b, 0xe9, 0x35, 0x2d, 0xbe, 0x45, 0xeb, 0xb0,
-	0x74, 0xe2, 0xfb, 0x9e, 0xaf, 0x54, 0xbb, 0x95, 0x5e, 0xcb, 0x8a, 0x37, 0xf8, 0x00, 0x6a, 0x9a,
-	0x69, 0x44, 0x1f, 0xb5, 0xa9, 0xab, 0x1f, 0x53, 0xa7, 0x25, 0x2b, 0xde, 0x20, 0x04, 0x75, 0xc3,
-	0x9e, 0x10, 0xe6, 0x41, 0xd7, 0xf8, 0x0b, 0x34, 0x0d, 0x6d, 0x60, 0xd9, 0xee, 0x15, 0x89, 0xbc,
-	0x0c, 0x3b, 0x48, 0xbd, 0xe8, 0x06, 0xed, 0xc0, 0x4a, 0xb4, 0x18, 0x11, 0x37, 0x74, 0x2e, 0x1d,
-	0xc2, 0x2f, 0x9c, 0x3d, 0x8c, 0xb0, 0x8f, 0xf4, 0x63, 0x4b, 0xa9, 0xc5, 0xd8, 0xd1, 0x1a, 0x8f,
-	0xa1, 0xa5, 0x99, 0xc6, 0x91, 0xe7, 0x5e, 0x3a, 0x57, 0x68, 0x97, 0x32, 0xa3, 0xd0, 0xed, 0xc3,
-	0xf5, 0x7e, 0x26, 0xe4, 0xbe, 0x66, 0x1a, 0x16, 0xa5, 0xfe, 0x02, 0x5a, 0x86, 0x1d, 0x50, 0x42,
-	0x81, 0x52, 0xeb, 0xd6, 0x7a, 0xed, 0xc3, 0xcd, 0x39, 0x6b, 0x4e, 0xd9, 0x4a, 0x6d, 0xf1, 0x2d,
-	0xac, 0x68, 0xa6, 0xa1, 0x8d, 0xc7, 0xde, 0xd0, 0x0e, 0x1d, 0xcf, 0xcd, 0x09, 0x07, 0x41, 0x5d,
-	0x3f, 0x1f, 0xe8, 0x34, 0x8a, 0x9a, 0x45, 0xd7, 0xf1, 0xd9, 0x89, 0x4e, 0xc9, 0xd3, 0xb3, 0x13,
-	0x1d, 0xad, 0x42, 0x55, 0x37, 0x95, 0x3a, 0x0d, 0xa7, 0xaa, 0x9b, 0x51, 0x25, 0x8e, 0x7c, 0x62,
-	0x87, 0x64, 0xa4, 0x2c, 0x51, 0x33, 0xbe, 0xc5, 0xaf, 0x61, 0x45, 0x1b, 0x8d, 0xa2, 0x00, 0xc8,
-	0xcf, 0x6b, 0x12, 0x84, 0x68, 0x1f, 0x1a, 0x06, 0xb9, 0xbd, 0x2b, 0x5a, 0x66, 0x83, 0x35, 0x58,
-	0xe5, 0xee, 0xc1, 0xd4, 0x73, 0x03, 0x82, 0x0e, 0x78, 0xf9, 0x99, 0xff, 0xfd, 0x39, 0xff, 0xf8,
-	0xb3, 0xc5, 0xcc, 0x70, 0x0f, 0x3a, 0x16, 0x99, 0x78, 0x37, 0x44, 0x20, 0x21, 0x95, 0x00, 0x3e,
-	0x86, 0xff, 0x04, 0xcb, 0xb2, 0xf7, 0x75, 0x60, 0xf5, 0xcc, 0x09, 0xc2, 0xf4, 0x36, 0xec, 0xc3,
-	0x5a, 0x72, 0x52, 0x12, 0x15, 0xf5, 0xa1, 0xae, 0x99, 0x46, 0xa0, 0x54, 0x69, 0xd1, 0x55, 0x59,
-	0xd2, 0x62, 0x2d, 0x59, 0xd4, 0x0e, 0xa3, 0x28, 0xea, 0xb1, 0x67, 0x0b, 0xa9, 0x8f, 0xe3, 0x4b,
-	0xce, 0xca, 0xc6, 0x77, 0x41, 0x2b, 0x1a, 0x89, 0xac, 0x28, 0x99, 0xe8, 0x39, 0x34, 0x0d, 0x72,
-	0x4b, 0xe5, 0x47, 0xe5, 0x54, 0xa8, 0xd4, 0xc4, 0x94, 0x15, 0x9c, 0xa2, 0x97, 0x25, 0x78, 0x09,
-	0xeb, 0x91, 0x66, 0x12, 0xad, 0x17, 0xf3, 0x4c, 0x1e, 0x42, 0x55, 0xf6, 0x10, 0x6a, 0xc2, 0x43,
-	0xc8, 0x88, 0x1e, 0x9f, 0xc2, 0xbd, 0xcc, 0x3d, 0x65, 0x19, 0xbf, 0xe1, 0x12, 0xbd, 0x33, 0xab,
-	0x52, 0xb6, 0xa9, 0x70, 0xff, 0x2a, 0x6f, 0xef, 0x61, 0x93, 0xc9, 0x34, 0x8d, 0x29, 0x28, 0x43,
-	0xe7, 0x57, 0x05, 0x54, 0x19, 0x52, 0x59, 0xed, 0xbf, 0x85, 0xb6, 0x80, 0xc3, 0x9e, 0xc0, 0x43,
-	0xd9, 0x13, 0x10, 0x8a, 0x21, 0xba, 0xe0, 0x0b, 0x50, 0x93, 0x97, 0xfd, 0xcf, 0x85, 0x81, 0x0d,
-	0x78, 0x20, 0x45, 0x2f, 0x5b, 0x88, 0x57, 0xf4, 0x0d, 0x7c, 0x0a, 0x88, 0xcf, 0x19, 0xf2, 0xe1,
-	0x54, 0x49, 0x87, 0x13, 0x9d, 0x71, 0x13, 0xdb, 0x19, 0x27, 0x33, 0x2e, 0xda, 0xe0, 0x1b, 0x58,
-	0x4b, 0x7c, 0xcb, 0xe6, 0x7b, 0x03, 0x1a, 0x11, 0x80, 0x3e, 0x62, 0xd0, 0x6c, 0x87, 0x54, 0x68,
-	0x6a, 0x53, 0xe7, 0xa3, 0xf7, 0x83, 0xb8, 0x6c, 0x94, 0x25, 0x7b, 0xfc, 0x84, 0xde, 0x4b, 0xd7,
-	0x9c, 0x74, 0x0a, 0x53, 0x11, 0x61, 0xf0, 0x37, 0xe8, 0xa4, 0xa6, 0x65, 0x39, 0x8a, 0x5c, 0xaa,
-	0x19, 0x2e, 0xa7, 0x80, 0xe2, 0x7a, 0x2c, 0x42, 0xa7, 0x10, 0xe9, 0x1d, 0xfc, 0x3f, 0x83, 0x54,
-	0x92, 0xed, 0xe1, 0xef, 0x26, 0x6c, 0x9c, 0x7a, 0xfe, 0x88, 0x9c, 0x27, 0x76, 0x03, 0xe2, 0xdf,
-	0x38, 0x43, 0x82, 0x74, 0x68, 0xc4, 0x13, 0x0e, 0x49, 0x14, 0x2d, 0x4e, 0x4e, 0x75, 0x2b, 0xf7,
-	0x3b, 0xa3, 0xf5, 0x35, 0x9e, 0xb5, 0xe9, 0x90, 0x7f, 0x2c, 0xf5, 0xc8, 0xea, 0x5f, 0xdd, 0xbd,
-	0xcb, 0x8c, 0xe1, 0x5b, 0xd0, 0x4a, 0x74, 0x8e, 0xb6, 0x25, 0x31, 0xcf, 0x4e, 0x59, 0x15, 0x17,
-	0x99, 0x88, 0x98, 0x6c, 0x26, 0x49, 0x31, 0x67, 0x67, 0x98, 0x14, 0x33, 0x3b, 0xd2, 0xe2, 0x94,
-	0x1a, 0xda, 0x40, 0x9e, 0xd2, 0xb4, 0xc9, 0xca, 0x53, 0x2a, 0x36, 0xd1, 0x24, 0xe4, 0x08, 0x2d,
-	0x2f, 0x64, 0x01, 0x10, 0x17, 0x99, 0x30, 0xcc, 0x09, 0xa0, 0xf9, 0xee, 0x88, 0x9e, 0xce, 0x79,
-	0xe6, 0x36, 0x63, 0x75, 0x6f, 0x21, 0x5b, 0x76, 0xdd, 0x94, 0x6b, 0x78, 0xf6, 0x07, 0x70, 0x2f,
-	0xbf, 0x38, 0xf3, 0x0a, 0xd9, 0x5f, 0xcc, 0x98, 0xdd, 0x78, 0x06, 0xcb, 0x8c, 0x0f, 0xda, 0xca,
-	0x63, 0xca, 0x91, 0xbb, 0xf9, 0x06, 0x29, 0x1a, 0xeb, 0x68, 0x48, 0x5a, 0x2e, 0xa1, 0x4f, 0x4a,
-	0xd0, 0xb2, 0xcd, 0xf0, 0x03, 0x34, 0x79, 0xf3, 0x41, 0x52, 0x6b, 0xb1, 0x67, 0xa8, 0xdb, 0x05,
-	0x16, 0x0c, 0xf0, 0x33, 0xb4, 0x85, 0x16, 0x81, 0x1e, 0xe5, 0x64, 0x6a, 0x06, 0x76, 0xa7, 0xd8,
-	0x28, 0x46, 0xfe, 0xde, 0xa0, 0x9f, 0x9e, 0xfd, 0x09, 0x00, 0x00, 0xff, 0xff, 0xf1, 0xaf, 0xf9,
-	0xab, 0x14, 0x0d, 0x00, 0x00,
+	// 1226 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xff, 0x6f, 0xdb, 0x44,
+	0x14, 0x57, 0xe2, 0x34, 0x4d, 0x5e, 0xd6, 0x2f, 0xbb, 0x96, 0xe1, 0x19, 0xb4, 0x66, 0x47, 0x57,
+	0x95, 0xad, 0x74, 0xa8, 0x08, 0x81, 0x90, 0x40, 0x98, 0xa6, 0x4b, 0x2d, 0x2d, 0x5e, 0x70, 0x5a,
+	0x69, 0x82, 0x89, 0xc9, 0x24, 0xd7, 0xca, 0x9a, 0x63, 0x07, 0xdb, 0x69, 0x3b, 0xfe, 0x09, 0xfe,
+	0x10, 0xfe, 0x0f, 0x7e, 0xe5, 0xbf, 0x81, 0x5f, 0xd1, 0x9d, 0xcf, 0xf6, 0x39, 0xfe, 0xd2, 0xe0,
+	0xed, 0x37, 0xdf, 0xbb, 0xcf, 0xfb, 0xdc, 0x7b, 0xf7, 0x3e, 0x97, 0xf7, 0x02, 0x9b, 0x53, 0xd3,
+	0x31, 0x2f, 0xc9, 0x94, 0x38, 0xc1, 0xe1, 0xcc, 0x73, 0x03, 0x17, 0x6d, 0x24, 0x16, 0x66, 0xc0,
+	0x5f, 0x43, 0xd3, 0x20, 0xfe, 0xdc, 0x0e, 0x90, 0x0c, 0xab, 0xa3, 0xf9, 0x78, 0x4c, 0x7c, 0x5f,
+	0xae, 0x75, 0x6b, 0xfb, 0x2d, 0x23, 0x5a, 0xa2, 0x6d, 0x58, 0x39, 0xf1, 0x3c, 0xd7, 0x93, 0xeb,
+	0xdd, 0xda, 0x7e, 0xdb, 0x08, 0x17, 0xf8, 0x29, 0x48, 0xea, 0x50, 0xa7, 0x9b, 0xea, 0xcc, 0xd1,
+	0x7a, 0xcc, 0x69, 0xc5, 0x08, 0x17, 0x08, 0x41, 0x43, 0x37, 0xa7, 0x84, 0x7b, 0xb0, 0x6f, 0xfc,
+	0x13, 0xb4, 0x74, 0x75, 0x64, 0x98, 0xce, 0x25, 0xa1, 0x5e, 0xba, 0xe9, 0x27, 0x5e, 0x6c, 0x81,
+	0x76, 0x61, 0x8d, 0x7e, 0x4c, 0x88, 0x13, 0x58, 0x17, 0x16, 0x89, 0x0e, 0x4c, 0x1b, 0x29, 0xf7,
+	0xb1, 0xd6, 0x33, 0x64, 0x29, 0xe4, 0xa6, 0xdf, 0xd8, 0x86, 0xb6, 0x3a, 0xd4, 0x8f, 0x5d, 0xe7,
+	0xc2, 0xba, 0x44, 0x7b, 0x2c, 0x32, 0x46, 0xdd, 0x39, 0xda, 0x3e, 0x5c, 0x48, 0xf9, 0x50, 0x1d,
+	0xea, 0x06, 0x0b, 0xfd, 0x2b, 0x68, 0xeb, 0xa6, 0xcf, 0x02, 0xf2, 0x65, 0xa9, 0x2b, 0xed, 0x77,
+	0x8e, 0xee, 0x67, 0xd0, 0x51, 0xc8, 0x46, 0x82, 0xc5, 0xd7, 0xb0, 0xa6, 0x0e, 0x75, 0xd5, 0xb6,
+	0xdd, 0xb1, 0x19, 0x58, 0xae, 0x53, 0x90, 0x0e, 0x82, 0x86, 0x36, 0x18, 0x69, 0x2c, 0x0b, 0xc9,
+	0x60, 0xdf, 0xa1, 0xed, 0x44, 0x93, 0xa5, 0xc8, 0x76, 0xa2, 0xa1, 0x75, 0xa8, 0x6b, 0x43, 0xb9,
+	0xc1, 0xd2, 0xa9, 0x6b, 0x43, 0x5a, 0x89, 0x63, 0x8f, 0x98, 0x01, 0x99, 0xc8, 0x2b, 0x0c, 0x16,
+	0x2d, 0xf1, 0xb7, 0xb0, 0xa6, 0x4e, 0x26, 0x34, 0x01, 0xf2, 0xdb, 0x9c, 0xf8, 0x01, 0x3a, 0x80,
+	0xa6, 0x4e, 0xae, 0x6f, 0xcb, 0x96, 0x63, 0xb0, 0x0a, 0xeb, 0x91, 0xbb, 0x3f, 0x73, 0x1d, 0x9f,
+	0xa0, 0xa7, 0x51, 0xf9, 0xb9, 0xff, 0x87, 0x19, 0xff, 0x70, 0xdb, 0xe0, 0x30, 0xbc, 0x0f, 0x9b,
+	0x06, 0x99, 0xba, 0x57, 0x44, 0x08, 0x22, 0x57, 0x02, 0xb8, 0x07, 0x77, 0x05, 0x64, 0xd5, 0xf3,
+	0x36, 0x61, 0xfd, 0xb9, 0xe5, 0x07, 0xc9, 0x69, 0xd8, 0x83, 0x8d, 0xd8, 0x52, 0x91, 0x15, 0x1d,
+	0x42, 0x43, 0x1d, 0xea, 0xbe, 0x5c, 0x67, 0x45, 0x57, 0xf2, 0x2e, 0x2d, 0xd4, 0x92, 0xc1, 0x70,
+	0x18, 0xd1, 0xac, 0x6d, 0xd7, 0x14, 0xae, 0x3e, 0xcc, 0x2f, 0xb6, 0x55, 0xcd, 0xef, 0x15, 0xab,
+	0x28, 0x15, 0x59, 0xd9, 0x65, 0xa2, 0x2f, 0xa1, 0xa5, 0x93, 0x6b, 0x26, 0x3f, 0x26, 0xa7, 0x52,
+	0xa5, 0xc6, 0x50, 0x5e, 0x70, 0xc6, 0x5e, 0x35, 0xc0, 0x0b, 0xd8, 0xa6, 0x9a, 0x89, 0xb5, 0x5e,
+	0x1e, 0x67, 0xfc, 0x10, 0xea, 0x79, 0x0f, 0x41, 0x12, 0x1e, 0xc2, 0x82, 0xe8, 0xf1, 0x29, 0x7c,
+	0xb0, 0x70, 0x4e, 0xd5, 0x88, 0xbf, 0x8b, 0x24, 0x7a, 0xeb, 0xad, 0xe6, 0x46, 0x9b, 0x08, 0xf7,
+	0x9d, 0xee, 0xad, 0x0f, 0xf7, 0xb9, 0x4c, 0x93, 0x9c, 0xfc, 0x2a, 0xe1, 0xfc, 0x51, 0x03, 0x25,
+	0x8f, 0xa9, 0xaa, 0xf6, 0xbf, 0x87, 0x8e, 0xc0, 0xc3, 0x9f, 0xc0, 0x83, 0xbc, 0x27, 0x20, 0x14,
+	0x43, 0x74, 0xc1, 0xaf, 0x40, 0x89, 0x5f, 0xf6, 0x7b, 0x17, 0x06, 0xd6, 0xe1, 0xa3, 0x5c, 0xf6,
+	0xaa, 0x85, 0xf8, 0x86, 0xbd, 0x81, 0x73, 0x9f, 0x78, 0x51, 0x84, 0x51, 0x73, 0xaa, 0x25, 0xcd,
+	0x89, 0xf5, 0xb8, 0xa9, 0x69, 0xd9, 0x71, 0x8f, 0xa3, 0x0b, 0x7c, 0x05, 0x1b, 0xb1, 0x6f, 0xd5,
+	0xfb, 0xbe, 0x07, 0x4d, 0x4a, 0xa0, 0x4d, 0x38, 0x35, 0x5f, 0x21, 0x05, 0x5a, 0xea, 0xcc, 0x3a,
+	0x73, 0xdf, 0x10, 0x87, 0xb7, 0xb2, 0x78, 0x8d, 0x3f, 0x65, 0xe7, 0xb2, 0xef, 0x28, 0xe8, 0x84,
+	0xa6, 0x26, 0xd2, 0xe0, 0xd7, 0xb0, 0x99, 0x40, 0xab, 0xc6, 0x28, 0xc6, 0x52, 0x5f, 0x88, 0xe5,
+	0x14, 0x50, 0x58, 0x8f, 0x65, 0xc2, 0x29, 0x65, 0x7a, 0x06, 0x5b, 0x29, 0xa6, 0xaa, 0x15, 0xfd,
+	0xb7, 0x06, 0xed, 0x33, 0x62, 0x4e, 0x7f, 0x9c, 0xbb, 0x81, 0x89, 0x1e, 0x00, 0x0c, 0xcc, 0x9b,
+	0x1e, 0xb9, 0xb2, 0xc6, 0xc4, 0xe7, 0xa2, 0x13, 0x2c, 0x68, 0x0f, 0xd6, 0x07, 0xe6, 0xcd, 0xb1,
+	0x6b, 0xdb, 0x64, 0x1c, 0x49, 0x9e, 0x62, 0x16, 0xac, 0x9c, 0xe7, 0xc5, 0x3c, 0x98, 0xcd, 0x03,
+	0x5f, 0x96, 0x62, 0x1e, 0x6e, 0x41, 0x8f, 0x61, 0x73, 0x60, 0xde, 0x3c, 0xb3, 0xbc, 0xe9, 0xb5,
+	0xe9, 0x91, 0x1f, 0xde, 0x06, 0xc4, 0x67, 0x3f, 0x5f, 0x92, 0x91, 0xb1, 0xa3, 0x7d, 0xd8, 0x18,
+	0x98, 0x37, 0xe7, 0x33, 0xdb, 0x72, 0xde, 0x0c, 0x89, 0xd7, 0x33, 0xdf, 0xb2, 0x4e, 0xbe, 0x62,
+	0x2c, 0x9a, 0xd1, 0x01, 0xdc, 0xa5, 0xb1, 0xba, 0xd7, 0x8e, 0x80, 0x6d, 0x32, 0x6c, 0x76, 0x03,
+	0xff, 0xcd, 0x33, 0x3f, 0xf7, 0xcd, 0x4b, 0x42, 0xe7, 0x84, 0x74, 0xda, 0xd1, 0x12, 0x75, 0xa1,
+	0x93, 0x4d, 0x58, 0x34, 0x51, 0xdf, 0x74, 0xaa, 0xd1, 0x92, 0x0e, 0x61, 0x79, 0x49, 0xa6, 0x8d,
+	0xf4, 0x84, 0x30, 0x8f, 0x33, 0x77, 0x12, 0x67, 0x27, 0x9a, 0x28, 0x4f, 0x14, 0x7d, 0x88, 0x09,
+	0xb3, 0x4a, 0x1b, 0xf1, 0x6b, 0xd8, 0x1a, 0x91, 0x20, 0xae, 0xa6, 0x20, 0x2f, 0x6a, 0x4b, 0xe4,
+	0x15, 0xae, 0xd0, 0xe7, 0xb0, 0xc2, 0x70, 0xbc, 0x09, 0x66, 0x3b, 0x77, 0xc2, 0x14, 0x02, 0x71,
+	0x1f, 0xb6, 0xd3, 0x07, 0x54, 0x55, 0xdd, 0x67, 0xb0, 0xd5, 0x5f, 0x3e, 0x52, 0xfc, 0x67, 0x0d,
+	0xb6, 0xfb, 0xef, 0xe3, 0xe0, 0xff, 0x9f, 0x33, 0xf5, 0x60, 0x0a, 0x91, 0xa5, 0x12, 0x0f, 0x86,
+	0x30, 0x42, 0x20, 0xfe, 0x1d, 0x50, 0xcf, 0x64, 0x41, 0x06, 0xae, 0x47, 0x6e, 0xab, 0x42, 0xd1,
+	0x4f, 0x1a, 0x82, 0x46, 0x6f, 0x3e, 0x9d, 0xb1, 0x63, 0xef, 0x18, 0xec, 0x9b, 0x0a, 0x45, 0x73,
+	0xc6, 0xf6, 0x7c, 0x42, 0xe8, 0x01, 0x4c, 0x4c, 0x2d, 0x43, 0x34, 0xe1, 0x7f, 0x6a, 0xb0, 0x95,
+	0x3a, 0xbc, 0xea, 0x45, 0xed, 0xc2, 0x5a, 0x22, 0x71, 0x6d, 0x12, 0xf6, 0xb6, 0xb6, 0x91, 0x36,
+	0x8a, 0xaf, 0x46, 0x4a, 0xbf, 0x1a, 0xe1, 0x4d, 0x34, 0xd2, 0x6f, 0x42, 0x81, 0x56, 0x24, 0x7f,
+	0x2e, 0xf5, 0x78, 0x4d, 0xf7, 0x06, 0xc4, 0xa7, 0xb7, 0xe8, 0x73, 0x89, 0xc7, 0x6b, 0xf4, 0x31,
+	0xb4, 0xe9, 0x1c, 0x69, 0x5b, 0xe3, 0xc0, 0x97, 0x57, 0x59, 0x34, 0x89, 0xe1, 0xe8, 0x2f, 0x80,
+	0x7b, 0xa7, 0xae, 0x37, 0x21, 0x83, 0x38, 0xaf, 0x11, 0xf1, 0x68, 0x2c, 0x48, 0x83, 0x66, 0x38,
+	0xa9, 0xa3, 0x9c, 0xce, 0x2c, 0xfe, 0x03, 0x50, 0x76, 0x0a, 0xf7, 0xf9, 0x35, 0xfe, 0x12, 0xfe,
+	0x67, 0x48, 0xfe, 0xac, 0x3c, 0xca, 0xf5, 0x58, 0xec, 0xe3, 0xca, 0xde, 0x6d, 0x30, 0xce, 0x6f,
+	0x40, 0x3b, 0xee, 0xd7, 0xe8, 0x61, 0x4e, 0x8d, 0xd2, 0xff, 0x16, 0x14, 0x5c, 0x06, 0x11, 0x39,
+	0xf9, 0x6c, 0x9d, 0xcb, 0x99, 0x9e, 0xc5, 0x15, 0x5c, 0x06, 0xe1, 0x9c, 0xe1, 0x95, 0xea, 0xea,
+	0x28, 0xff, 0x4a, 0x93, 0x61, 0x51, 0xd9, 0x29, 0xdc, 0x5f, 0x4c, 0x99, 0xb2, 0x15, 0xa5, 0x2c,
+	0x10, 0xe2, 0x32, 0x08, 0xe7, 0x9c, 0x02, 0xca, 0x4e, 0x79, 0xe8, 0x71, 0xc6, 0xb3, 0x70, 0xa8,
+	0x54, 0x9e, 0x2c, 0x85, 0xe5, 0xc7, 0xcd, 0xa2, 0x5e, 0x9c, 0xda, 0x47, 0x4f, 0x8a, 0x8b, 0x93,
+	0x55, 0xc8, 0xc1, 0x72, 0x60, 0x7e, 0xe2, 0x73, 0x58, 0xe5, 0xf1, 0xa0, 0x9d, 0xa2, 0x48, 0x23,
+	0xe6, 0x6e, 0x31, 0x20, 0x61, 0xe3, 0x93, 0x19, 0xca, 0x2d, 0x97, 0x30, 0xef, 0x29, 0xdd, 0x62,
+	0x00, 0x67, 0x7b, 0x01, 0xad, 0x68, 0x88, 0x42, 0xb9, 0x68, 0x71, 0xf6, 0x51, 0x1e, 0x96, 0x20,
+	0x38, 0xe1, 0x4b, 0xe8, 0x08, 0xa3, 0x0e, 0xfa, 0xa4, 0xe0, 0xa6, 0x52, 0xb4, 0xbb, 0xe5, 0x20,
+	0xce, 0xfc, 0x33, 0xdc, 0x11, 0xfb, 0x19, 0xca, 0x7a, 0xe5, 0xf4, 0x53, 0xe5, 0xd1, 0x2d, 0xa8,
+	0x84, 0xbc, 0x5f, 0x4e, 0xde, 0x5f, 0x8a, 0x3c, 0xb7, 0xf1, 0xbd, 0x84, 0x8e, 0xf0, 0x33, 0x9f,
+	0x73, 0x27, 0xd9, 0x0e, 0xa4, 0xec, 0x96, 0x83, 0x42, 0xe6, 0x5f, 0x9b, 0x6c, 0xeb, 0x8b, 0xff,
+	0x06, 0x00, 0x57, 0x17, 0x03, 0x63, 0xf0, 0x12, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1489,6 +2011,16 @@ type HordeManagementServiceClient interface {
 	// Used in combination with AddToken this can be used to rotate API tokens
 	// for M2M users.
 	RemoveToken(ctx context.Context, in *RemoveTokenRequest, opts ...grpc.CallOption) (*RemoveTokenResponse, error)
+	// SetTeamQuota sets the resource quota for a team. Limits set to zero are
+	// unlimited.
+	SetTeamQuota(ctx context.Context, in *SetTeamQuotaRequest, opts ...grpc.CallOption) (*SetTeamQuotaResponse, error)
+	// GetTeamQuota returns the resource quota and the current resource usage for
+	// a team.
+	GetTeamQuota(ctx context.Context, in *GetTeamQuotaRequest, opts ...grpc.CallOption) (*GetTeamQuotaResponse, error)
+	// DataRestore restores a data dump from the API in a team. The dump is the
+	// JSON returned by the /datadump resource. Nothing is restored if any of the
+	// devices in the dump conflicts with existing devices.
+	DataRestore(ctx context.Context, in *DataRestoreRequest, opts ...grpc.CallOption) (*DataRestoreResponse, error)
 }
 
 type hordeManagementServiceClient struct {
@@ -1607,6 +2139,33 @@ func (c *hordeManagementServiceClient) RemoveToken(ctx context.Context, in *Remo
 	return out, nil
 }
 
+func (c *hordeManagementServiceClient) SetTeamQuota(ctx context.Context, in *SetTeamQuotaRequest, opts ...grpc.CallOption) (*SetTeamQuotaResponse, error) {
+	out := new(SetTeamQuotaResponse)
+	err := c.cc.Invoke(ctx, "/managementproto.HordeManagementService/SetTeamQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeManagementServiceClient) GetTeamQuota(ctx context.Context, in *GetTeamQuotaRequest, opts ...grpc.CallOption) (*GetTeamQuotaResponse, error) {
+	out := new(GetTeamQuotaResponse)
+	err := c.cc.Invoke(ctx, "/managementproto.HordeManagementService/GetTeamQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hordeManagementServiceClient) DataRestore(ctx context.Context, in *DataRestoreRequest, opts ...grpc.CallOption) (*DataRestoreResponse, error) {
+	out := new(DataRestoreResponse)
+	err := c.cc.Invoke(ctx, "/managementproto.HordeManagementService/DataRestore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HordeManagementServiceServer is the server API for HordeManagementService service.
 type HordeManagementServiceServer interface {
 	// AddAPN creates a new APN. One or more NASRange elements must be supplied.
@@ -1649,6 +2208,16 @@ type HordeManagementServiceServer interface {
 	// Used in combination with AddToken this can be used to rotate API tokens
 	// for M2M users.
 	RemoveToken(context.Context, *RemoveTokenRequest) (*RemoveTokenResponse, error)
+	// SetTeamQuota sets the resource quota for a team. Limits set to zero are
+	// unlimited.
+	SetTeamQuota(context.Context, *SetTeamQuotaRequest) (*SetTeamQuotaResponse, error)
+	// GetTeamQuota returns the resource quota and the current resource usage for
+	// a team.
+	GetTeamQuota(context.Context, *GetTeamQuotaRequest) (*GetTeamQuotaResponse, error)
+	// DataRestore restores a data dump from the API in a team. The dump is the
+	// JSON returned by the /datadump resource. Nothing is restored if any of the
+	// devices in the dump conflicts with existing devices.
+	DataRestore(context.Context, *DataRestoreRequest) (*DataRestoreResponse, error)
 }
 
 func RegisterHordeManagementServiceServer(s *grpc.Server, srv HordeManagementServiceServer) {
@@ -1871,6 +2440,60 @@ func _HordeManagementService_RemoveToken_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HordeManagementService_SetTeamQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTeamQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeManagementServiceServer).SetTeamQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/managementproto.HordeManagementService/SetTeamQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeManagementServiceServer).SetTeamQuota(ctx, req.(*SetTeamQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HordeManagementService_GetTeamQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeamQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeManagementServiceServer).GetTeamQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/managementproto.HordeManagementService/GetTeamQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeManagementServiceServer).GetTeamQuota(ctx, req.(*GetTeamQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HordeManagementService_DataRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HordeManagementServiceServer).DataRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/managementproto.HordeManagementService/DataRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HordeManagementServiceServer).DataRestore(ctx, req.(*DataRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _HordeManagementService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "managementproto.HordeManagementService",
 	HandlerType: (*HordeManagementServiceServer)(nil),
@@ -1923,6 +2546,18 @@ var _HordeManagementService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveToken",
 			Handler:    _HordeManagementService_RemoveToken_Handler,
 		},
+		{
+			MethodName: "SetTeamQuota",
+			Handler:    _HordeManagementService_SetTeamQuota_Handler,
+		},
+		{
+			MethodName: "GetTeamQuota",
+			Handler:    _HordeManagementService_GetTeamQuota_Handler,
+		},
+		{
+			MethodName: "DataRestore",
+			Handler:    _HordeManagementService_DataRestore_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "management.proto",
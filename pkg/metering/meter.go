@@ -0,0 +1,214 @@
+package metering
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"sync"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/model"
+)
+
+// DefaultFlushInterval is the default interval for writing usage to the store
+const DefaultFlushInterval = time.Minute
+
+// Store is the persistent storage for the metered usage. The
+// storage.DataStore type implements this interface.
+type Store interface {
+	RetrieveCollectionTeam(collectionID model.CollectionKey) (model.TeamKey, error)
+	AddUsage(records []model.Usage) error
+}
+
+// DefaultMeter is the default meter. It works similarly to the default
+// counters in the metrics package. Usage is discarded until the meter is
+// started.
+var DefaultMeter = NewMeter()
+
+type usageKey struct {
+	collectionID model.CollectionKey
+	hour         int64
+	transport    string
+}
+
+// Meter aggregates usage in memory until it is flushed to the store. The
+// team that owns the collection is recorded with the usage. The teams are
+// cached until the next flush.
+type Meter struct {
+	mutex   sync.Mutex
+	store   Store
+	pending map[usageKey]*model.Usage
+	teams   map[model.CollectionKey]model.TeamKey
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewMeter creates a new meter. The meter must be started before usage is
+// recorded.
+func NewMeter() *Meter {
+	return &Meter{
+		pending: make(map[usageKey]*model.Usage),
+		teams:   make(map[model.CollectionKey]model.TeamKey),
+	}
+}
+
+// Start starts the meter. Usage is written to the store at the specified
+// interval.
+func (m *Meter) Start(store Store, interval time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.store != nil {
+		logging.Warning("Meter is already started")
+		return
+	}
+	m.store = store
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	go m.flushLoop(interval, m.stop, m.done)
+}
+
+// Stop stops the meter and writes pending usage to the store.
+func (m *Meter) Stop() {
+	m.mutex.Lock()
+	stop, done := m.stop, m.done
+	m.mutex.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+
+	if err := m.Flush(); err != nil {
+		logging.Warning("Unable to write usage when stopping meter: %v", err)
+	}
+	m.mutex.Lock()
+	m.store = nil
+	m.stop = nil
+	m.done = nil
+	m.mutex.Unlock()
+}
+
+func (m *Meter) flushLoop(interval time.Duration, stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.Flush(); err != nil {
+				logging.Warning("Unable to write usage to store: %v", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Flush writes the pending usage to the store. If the write fails the usage
+// is kept and written on the next flush.
+func (m *Meter) Flush() error {
+	m.mutex.Lock()
+	store := m.store
+	if store == nil || len(m.pending) == 0 {
+		m.mutex.Unlock()
+		return nil
+	}
+	pending := m.pending
+	m.pending = make(map[usageKey]*model.Usage)
+	m.teams = make(map[model.CollectionKey]model.TeamKey)
+	m.mutex.Unlock()
+
+	records := make([]model.Usage, 0, len(pending))
+	for _, v := range pending {
+		records = append(records, *v)
+	}
+	if err := store.AddUsage(records); err != nil {
+		m.mutex.Lock()
+		for _, v := range records {
+			m.add(v)
+		}
+		m.mutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+// add adds the usage to the pending usage. The mutex must be held when this
+// is called.
+func (m *Meter) add(usage model.Usage) {
+	usage.Hour = model.UsageHour(usage.Hour)
+	key := usageKey{collectionID: usage.CollectionID, hour: usage.Hour.Unix(), transport: usage.Transport}
+	existing, ok := m.pending[key]
+	if !ok {
+		m.pending[key] = &usage
+		return
+	}
+	existing.Add(usage)
+}
+
+// record adds usage for the current hour. Usage is discarded if the meter
+// isn't started or the collection doesn't exist.
+func (m *Meter) record(usage model.Usage) {
+	m.mutex.Lock()
+	store := m.store
+	teamID, ok := m.teams[usage.CollectionID]
+	m.mutex.Unlock()
+	if store == nil {
+		return
+	}
+	if !ok {
+		var err error
+		if teamID, err = store.RetrieveCollectionTeam(usage.CollectionID); err != nil {
+			logging.Warning("Unable to look up team for collection %d. Discarding usage: %v", usage.CollectionID, err)
+			return
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.store == nil {
+		return
+	}
+	m.teams[usage.CollectionID] = teamID
+	usage.TeamID = teamID
+	usage.Hour = time.Now()
+	m.add(usage)
+}
+
+// Uplink records a message sent from a device
+func (m *Meter) Uplink(collectionID model.CollectionKey, transport model.MessageTransport, bytes int) {
+	m.record(model.Usage{CollectionID: collectionID, Transport: transport.String(), UplinkMessages: 1, UplinkBytes: int64(bytes)})
+}
+
+// Downlink records a message sent to a device
+func (m *Meter) Downlink(collectionID model.CollectionKey, transport model.MessageTransport, bytes int) {
+	m.record(model.Usage{CollectionID: collectionID, Transport: transport.String(), DownlinkMessages: 1, DownlinkBytes: int64(bytes)})
+}
+
+// RADIUSSession records an accepted RADIUS request for a device
+func (m *Meter) RADIUSSession(collectionID model.CollectionKey) {
+	m.record(model.Usage{CollectionID: collectionID, RADIUSSessions: 1})
+}
+
+// FirmwareDelivered records a firmware image delivered to a device
+func (m *Meter) FirmwareDelivered(collectionID model.CollectionKey, bytes int) {
+	m.record(model.Usage{CollectionID: collectionID, FirmwareBytes: int64(bytes)})
+}
+
+// OutputDelivered records messages delivered by an output
+func (m *Meter) OutputDelivered(collectionID model.CollectionKey, count int) {
+	m.record(model.Usage{CollectionID: collectionID, OutputDeliveries: int64(count)})
+}
@@ -0,0 +1,123 @@
+package metering
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	mutex   sync.Mutex
+	fail    bool
+	records []model.Usage
+}
+
+// RetrieveCollectionTeam returns the collection ID + 100 as the team ID.
+// Collection 0 doesn't exist.
+func (f *fakeStore) RetrieveCollectionTeam(collectionID model.CollectionKey) (model.TeamKey, error) {
+	if collectionID == 0 {
+		return 0, errors.New("not found")
+	}
+	return model.TeamKey(collectionID + 100), nil
+}
+
+func (f *fakeStore) AddUsage(records []model.Usage) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.fail {
+		return errors.New("store failure")
+	}
+	f.records = append(f.records, records...)
+	return nil
+}
+
+func (f *fakeStore) total() model.Usage {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	ret := model.Usage{}
+	for _, v := range f.records {
+		ret.Add(v)
+	}
+	return ret
+}
+
+func TestMeter(t *testing.T) {
+	assert := require.New(t)
+
+	store := &fakeStore{}
+	m := NewMeter()
+
+	// Usage is discarded before the meter is started
+	m.Uplink(1, model.UDPTransport, 10)
+	assert.NoError(m.Flush())
+	assert.Len(store.records, 0)
+
+	m.Start(store, time.Hour)
+	m.Uplink(1, model.UDPTransport, 10)
+	m.Uplink(1, model.UDPTransport, 20)
+	m.Uplink(1, model.CoAPTransport, 5)
+	m.Downlink(2, model.UDPTransport, 7)
+	m.RADIUSSession(1)
+	m.FirmwareDelivered(1, 100)
+	m.OutputDelivered(1, 3)
+
+	// Failed writes are retried on the next flush
+	store.fail = true
+	assert.Error(m.Flush())
+	store.fail = false
+	assert.NoError(m.Flush())
+
+	// Usage is aggregated per collection, hour and transport
+	assert.Len(store.records, 4)
+	hour := model.UsageHour(time.Now())
+	for _, v := range store.records {
+		assert.True(v.Hour.Equal(hour))
+		assert.Equal(model.TeamKey(v.CollectionID+100), v.TeamID)
+		if v.CollectionID == 1 && v.Transport == "udp" {
+			assert.Equal(int64(2), v.UplinkMessages)
+			assert.Equal(int64(30), v.UplinkBytes)
+		}
+	}
+	assert.Equal(model.Usage{
+		UplinkMessages:   3,
+		UplinkBytes:      35,
+		DownlinkMessages: 1,
+		DownlinkBytes:    7,
+		RADIUSSessions:   1,
+		FirmwareBytes:    100,
+		OutputDeliveries: 3,
+	}, store.total())
+
+	// Usage for unknown collections is discarded
+	m.OutputDelivered(0, 1)
+	assert.NoError(m.Flush())
+	assert.Equal(int64(3), store.total().OutputDeliveries)
+
+	// Pending usage is written when the meter stops
+	m.OutputDelivered(2, 1)
+	m.Stop()
+	assert.Equal(int64(4), store.total().OutputDeliveries)
+
+	m.OutputDelivered(2, 1)
+	assert.NoError(m.Flush())
+	assert.Equal(int64(4), store.total().OutputDeliveries)
+}
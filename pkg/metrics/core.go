@@ -32,6 +32,7 @@ type CoreCounters struct {
 	OutputCount            prometheus.Gauge       // Total outputs
 	AuthConnectCount       prometheus.Counter     // Authenticated requests via CONNECT ID, incl cookies
 	AuthGithubCount        prometheus.Counter     // Authenticated requests via GitHub, incl cookies
+	AuthOIDCCount          prometheus.Counter     // Authenticated requests via OpenID Connect, incl cookies
 	AuthTokenCount         prometheus.Counter     // Authenticated requests with tokens
 	MessagesInCount        prometheus.Counter     // Incoming messages (aka upstream)
 	MessagesOutCount       prometheus.Counter     // Sent messages (aka downstream)
@@ -43,6 +44,12 @@ type CoreCounters struct {
 	InvitesCreated         prometheus.Counter
 	InvitesAccepted        prometheus.Counter
 	HTTPResponseTime       *prometheus.HistogramVec
+	OutboxPending          prometheus.Gauge   // Messages in the data store outbox
+	OutboxInFlight         prometheus.Gauge   // Messages sent to the data store but not acknowledged
+	OutboxStored           prometheus.Counter // Messages acknowledged by the data store
+	OutboxReplayed         prometheus.Counter // Messages sent to the data store again
+	OutboxErrors           prometheus.Counter // Errors sending to the data store
+	OutboxDeadLettered     prometheus.Counter // Messages moved to the outbox dead letter table
 }
 
 // CounterStore is a type that reports the initial values for the performance counters.
@@ -87,6 +94,10 @@ func NewCoreCounters() *CoreCounters {
 			Name: "auth_github",
 			Help: "GitHub OAuth authentication",
 		}),
+		AuthOIDCCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "auth_oidc",
+			Help: "OpenID Connect authentication",
+		}),
 		AuthTokenCount: prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "auth_token",
 			Help: "API token authentication",
@@ -132,6 +143,30 @@ func NewCoreCounters() *CoreCounters {
 			Help:    "Response time (in ms) for a HTTP request",
 			Buckets: []float64{1, 2, 3, 4, 5, 10, 50, 100, 250, 500, 1000},
 		}, []string{"method"}),
+		OutboxPending: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "outbox_pending",
+			Help: "Messages waiting to be stored in the data store",
+		}),
+		OutboxInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "outbox_in_flight",
+			Help: "Messages sent to the data store waiting for a receipt",
+		}),
+		OutboxStored: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "outbox_stored",
+			Help: "Messages stored in the data store",
+		}),
+		OutboxReplayed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "outbox_replayed",
+			Help: "Messages sent to the data store more than once",
+		}),
+		OutboxErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "outbox_errors",
+			Help: "Errors sending messages to the data store",
+		}),
+		OutboxDeadLettered: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "outbox_dead_lettered",
+			Help: "Messages the data store didn't acknowledge after repeated attempts",
+		}),
 	}
 
 	return ret
@@ -149,6 +184,7 @@ func (c *CoreCounters) Start() {
 		prometheus.MustRegister(c.OutputCount)
 		prometheus.MustRegister(c.AuthConnectCount)
 		prometheus.MustRegister(c.AuthGithubCount)
+		prometheus.MustRegister(c.AuthOIDCCount)
 		prometheus.MustRegister(c.AuthTokenCount)
 		prometheus.MustRegister(c.MessagesInCount)
 		prometheus.MustRegister(c.MessagesOutCount)
@@ -160,6 +196,12 @@ func (c *CoreCounters) Start() {
 		prometheus.MustRegister(c.InvitesCreated)
 		prometheus.MustRegister(c.InvitesAccepted)
 		prometheus.MustRegister(c.HTTPResponseTime)
+		prometheus.MustRegister(c.OutboxPending)
+		prometheus.MustRegister(c.OutboxInFlight)
+		prometheus.MustRegister(c.OutboxStored)
+		prometheus.MustRegister(c.OutboxReplayed)
+		prometheus.MustRegister(c.OutboxErrors)
+		prometheus.MustRegister(c.OutboxDeadLettered)
 	})
 	c.TeamCount.Set(0)
 	c.UserCount.Set(0)
@@ -168,6 +210,7 @@ func (c *CoreCounters) Start() {
 	c.OutputCount.Set(0)
 	c.AuthConnectCount.Add(0)
 	c.AuthGithubCount.Add(0)
+	c.AuthOIDCCount.Add(0)
 	c.AuthTokenCount.Add(0)
 	c.MessagesInCount.Add(0)
 	c.MessagesOutCount.Add(0)
@@ -187,6 +230,13 @@ func (c *CoreCounters) Start() {
 	c.InvitesCreated.Add(0)
 	c.InvitesAccepted.Add(0)
 
+	c.OutboxPending.Set(0)
+	c.OutboxInFlight.Set(0)
+	c.OutboxStored.Add(0)
+	c.OutboxReplayed.Add(0)
+	c.OutboxErrors.Add(0)
+	c.OutboxDeadLettered.Add(0)
+
 	c.HTTPResponseTime.With(prometheus.Labels{"method": "GET"}).Observe(0)
 	c.HTTPResponseTime.With(prometheus.Labels{"method": "POST"}).Observe(0)
 	c.HTTPResponseTime.With(prometheus.Labels{"method": "PATCH"}).Observe(0)
@@ -0,0 +1,89 @@
+package model
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// AuditKey is the ID for audit events
+type AuditKey storageKey
+
+// NewAuditKeyFromString parses a key as a string
+func NewAuditKeyFromString(id string) (AuditKey, error) {
+	k, err := newKeyFromString(id)
+	return AuditKey(k), err
+}
+
+// String returns the string representation of the AuditKey
+func (a AuditKey) String() string {
+	return storageKey(a).String()
+}
+
+// Sources for audit events
+const (
+	AuditSourceAPI        = "api"
+	AuditSourceManagement = "management"
+)
+
+// AuditEvent is a change made through the API or the management service.
+// The before and after fields are JSON snapshots of the resource with any
+// secrets removed. Creates have no before snapshot and removals have no
+// after snapshot. Changes that aren't made on behalf of a team (tokens, users
+// and APNs) have a zero team ID.
+type AuditEvent struct {
+	ID           AuditKey
+	Time         time.Time
+	TeamID       TeamKey
+	Source       string     // The service the change was made through
+	UserID       UserKey    // The user making the change. Zero for the management service
+	TokenID      string     // The API token used, if any
+	AuthMethod   AuthMethod // Authentication method for the user
+	Action       string     // The RPC method, ie "UpdateDevice"
+	ResourceType string     // The type of resource, ie "device"
+	ResourceID   string     // The resource path, ie /collections/{id}/devices/{id}
+	Before       string
+	After        string
+}
+
+// ChangedFields returns the names of the top level fields in the snapshots
+// that differ. Fields that only exist in one of the snapshots are included.
+func (a AuditEvent) ChangedFields() []string {
+	before := make(map[string]json.RawMessage)
+	after := make(map[string]json.RawMessage)
+	if a.Before != "" {
+		json.Unmarshal([]byte(a.Before), &before)
+	}
+	if a.After != "" {
+		json.Unmarshal([]byte(a.After), &after)
+	}
+	ret := make([]string, 0)
+	for k, v := range before {
+		if w, ok := after[k]; !ok || !bytes.Equal(v, w) {
+			ret = append(ret, k)
+		}
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			ret = append(ret, k)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
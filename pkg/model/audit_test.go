@@ -0,0 +1,42 @@
+package model
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuditChangedFields(t *testing.T) {
+	e := AuditEvent{
+		Before: `{"name":"a","imsi":"1","tags":{"a":"b"},"old":"x"}`,
+		After:  `{"name":"b","imsi":"1","tags":{"a":"c"},"new":"y"}`,
+	}
+	expected := []string{"name", "new", "old", "tags"}
+	if changed := e.ChangedFields(); !reflect.DeepEqual(changed, expected) {
+		t.Fatalf("Expected %v but got %v", expected, changed)
+	}
+
+	e = AuditEvent{After: `{"name":"a"}`}
+	if changed := e.ChangedFields(); !reflect.DeepEqual(changed, []string{"name"}) {
+		t.Fatalf("Expected all fields to change for new resources: %v", changed)
+	}
+
+	e = AuditEvent{}
+	if changed := e.ChangedFields(); len(changed) != 0 {
+		t.Fatalf("Expected no changes: %v", changed)
+	}
+}
@@ -36,6 +36,10 @@ const (
 
 	// AuthToken is token authentication
 	AuthToken
+
+	// AuthOIDC is for OpenID Connect authentication. The external ID is the
+	// subject claim from the provider.
+	AuthOIDC
 )
 
 // Login returns true if the user is authenticated via some sort of login, ie
@@ -44,5 +48,25 @@ const (
 // corner cases and potential security risks so we require a logged-in user to
 // manage the tokens)
 func (a AuthMethod) Login() bool {
-	return a == AuthGitHub || a == AuthConnectID
+	return a == AuthGitHub || a == AuthConnectID || a == AuthOIDC
+}
+
+// String returns the name of the authentication method
+func (a AuthMethod) String() string {
+	switch a {
+	case AuthGitHub:
+		return "github"
+	case AuthConnectID:
+		return "connect"
+	case AuthInternal:
+		return "internal"
+	case AuthToken:
+		return "token"
+	case AuthOIDC:
+		return "oidc"
+	case AuthNone:
+		return "none"
+	default:
+		return "unknown"
+	}
 }
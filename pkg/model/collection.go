@@ -61,6 +61,8 @@ type CollectionFirmwareMetadata struct {
 	CurrentFirmwareID FirmwareKey
 	TargetFirmwareID  FirmwareKey
 	Management        FirmwareManagementSetting
+	RequireSignature  bool              // Unsigned images are refused when this is set
+	MaintenanceWindow MaintenanceWindow // Devices are only updated inside the window
 }
 
 // NewCollectionFirmwareMetadata creates a new empty metadata setting
@@ -64,6 +64,8 @@ const (
 	UpdateFailed = DeviceFirmwareState('f') // Update operation has failed
 	TimedOut     = DeviceFirmwareState('t') // Update timed out
 	Reverted     = DeviceFirmwareState('r') // Device was updated but did not report the updated version
+	Incompatible = DeviceFirmwareState('x') // The target firmware image isn't compatible with the device
+	Deferred     = DeviceFirmwareState('w') // The update is waiting for the device's maintenance window
 )
 
 // IsError returns true if the firmware state represents an error
@@ -92,6 +94,10 @@ func (d DeviceFirmwareState) String() string {
 		return "TimedOut"
 	case Reverted:
 		return "Reverted"
+	case Incompatible:
+		return "Incompatible"
+	case Deferred:
+		return "Deferred"
 	}
 	return "Unknown"
 }
@@ -108,6 +114,7 @@ type DeviceFirmwareMetadata struct {
 	Manufacturer      string      // From the device information resource
 	State             DeviceFirmwareState
 	StateMessage      string
+	MaintenanceWindow MaintenanceWindow // Overrides the collection's maintenance window if set
 }
 
 // DeviceNetworkMetadata is the current state of the device.
@@ -0,0 +1,35 @@
+package model
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+
+// DeviceImportError is an error for a single device in a bulk import
+type DeviceImportError struct {
+	Index   int // Index of the device in the import
+	Message string
+}
+
+// DeviceImportResult is the result of a bulk import of devices. Nothing is
+// imported if there are errors.
+type DeviceImportResult struct {
+	Created []Device
+	Updated []Device
+	Errors  []DeviceImportError
+}
+
+// DeviceImportCheck is called before the import is committed with the number
+// of new devices. The import is aborted if it returns an error.
+type DeviceImportCheck func(created int) error
@@ -0,0 +1,138 @@
+package model
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"time"
+)
+
+// ResourceEventType is the type of change for a resource event. The types are
+// named <resource>.<change>, ie "device.moved"
+type ResourceEventType string
+
+// Resource event types
+const (
+	DeviceCreated         ResourceEventType = "device.created"
+	DeviceUpdated         ResourceEventType = "device.updated"
+	DeviceMoved           ResourceEventType = "device.moved"
+	DeviceRetagged        ResourceEventType = "device.retagged"
+	DeviceFirmwareChanged ResourceEventType = "device.firmware"
+	DeviceDeleted         ResourceEventType = "device.deleted"
+	CollectionCreated     ResourceEventType = "collection.created"
+	CollectionUpdated     ResourceEventType = "collection.updated"
+	CollectionDeleted     ResourceEventType = "collection.deleted"
+	OutputCreated         ResourceEventType = "output.created"
+	OutputUpdated         ResourceEventType = "output.updated"
+	OutputDeleted         ResourceEventType = "output.deleted"
+)
+
+// ResourceEventTypes is the list of known event types
+var ResourceEventTypes = []ResourceEventType{
+	DeviceCreated, DeviceUpdated, DeviceMoved, DeviceRetagged, DeviceFirmwareChanged, DeviceDeleted,
+	CollectionCreated, CollectionUpdated, CollectionDeleted,
+	OutputCreated, OutputUpdated, OutputDeleted,
+}
+
+// IsValid returns true if the event type is a known type
+func (r ResourceEventType) IsValid() bool {
+	for _, v := range ResourceEventTypes {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceEvent is a change to a device, collection or output. The events
+// are published to the collection's subscribers alongside the data
+// messages. Only one of the device, collection and output fields is set.
+type ResourceEvent struct {
+	Type                 ResourceEventType
+	Time                 time.Time
+	CollectionID         CollectionKey // The collection the resource belongs to
+	PreviousCollectionID CollectionKey // The previous collection for moved devices
+	Device               *Device
+	Collection           *Collection
+	Output               *Output
+}
+
+// NewDeviceEvent creates a new event for a device
+func NewDeviceEvent(eventType ResourceEventType, device Device) ResourceEvent {
+	return ResourceEvent{
+		Type:         eventType,
+		Time:         time.Now(),
+		CollectionID: device.CollectionID,
+		Device:       &device,
+	}
+}
+
+// NewCollectionEvent creates a new event for a collection
+func NewCollectionEvent(eventType ResourceEventType, collection Collection) ResourceEvent {
+	return ResourceEvent{
+		Type:         eventType,
+		Time:         time.Now(),
+		CollectionID: collection.ID,
+		Collection:   &collection,
+	}
+}
+
+// NewOutputEvent creates a new event for an output. The configuration is
+// left out since it might contain credentials.
+func NewOutputEvent(eventType ResourceEventType, output Output) ResourceEvent {
+	output.Config = nil
+	return ResourceEvent{
+		Type:         eventType,
+		Time:         time.Now(),
+		CollectionID: output.CollectionID,
+		Output:       &output,
+	}
+}
+
+// DeviceChangeEvents returns the events for an updated device. A single
+// update can move the device, change its tags and its firmware state. If
+// none of these apply a DeviceUpdated event is returned.
+func DeviceChangeEvents(before, after Device) []ResourceEvent {
+	var ret []ResourceEvent
+	if before.CollectionID != after.CollectionID {
+		ev := NewDeviceEvent(DeviceMoved, after)
+		ev.PreviousCollectionID = before.CollectionID
+		ret = append(ret, ev)
+	}
+	if !sameTags(before.Tags, after.Tags) {
+		ret = append(ret, NewDeviceEvent(DeviceRetagged, after))
+	}
+	if before.Firmware.State != after.Firmware.State {
+		ret = append(ret, NewDeviceEvent(DeviceFirmwareChanged, after))
+	}
+	if len(ret) == 0 {
+		ret = append(ret, NewDeviceEvent(DeviceUpdated, after))
+	}
+	return ret
+}
+
+// sameTags returns true if the tags are identical. Nil and empty tag maps are
+// the same.
+func sameTags(a, b Tags) bool {
+	if len(a.TagMap) != len(b.TagMap) {
+		return false
+	}
+	for k, v := range a.TagMap {
+		if other, ok := b.TagMap[k]; !ok || other != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package model
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"testing"
+)
+
+func eventTypes(events []ResourceEvent) []ResourceEventType {
+	var ret []ResourceEventType
+	for _, v := range events {
+		ret = append(ret, v.Type)
+	}
+	return ret
+}
+
+func TestDeviceChangeEvents(t *testing.T) {
+	before := NewDevice()
+	before.CollectionID = 1
+	before.SetTag("name", "a")
+	before.Firmware.State = Current
+
+	after := before
+	after.TagMap = before.TagData()
+	events := DeviceChangeEvents(before, after)
+	if len(events) != 1 || events[0].Type != DeviceUpdated {
+		t.Fatalf("Expected a single update event but got %v", eventTypes(events))
+	}
+
+	after.CollectionID = 2
+	after.SetTag("name", "b")
+	after.Firmware.State = Pending
+	events = DeviceChangeEvents(before, after)
+	if len(events) != 3 || events[0].Type != DeviceMoved || events[1].Type != DeviceRetagged || events[2].Type != DeviceFirmwareChanged {
+		t.Fatalf("Unexpected events: %v", eventTypes(events))
+	}
+	if events[0].CollectionID != 2 || events[0].PreviousCollectionID != 1 {
+		t.Fatalf("Moved event has the wrong collections: %+v", events[0])
+	}
+
+	// Empty and nil tags are the same
+	before.TagMap = nil
+	after = before
+	after.TagMap = make(TagMapData)
+	if events := DeviceChangeEvents(before, after); events[0].Type != DeviceUpdated {
+		t.Fatalf("Expected update event but got %v", eventTypes(events))
+	}
+}
+
+func TestResourceEvents(t *testing.T) {
+	output := NewOutput()
+	output.CollectionID = 1
+	output.Config["password"] = "secret"
+	ev := NewOutputEvent(OutputCreated, output)
+	if ev.Output.Config != nil || ev.CollectionID != 1 {
+		t.Fatalf("Output config should be removed from event: %+v", ev.Output)
+	}
+	if output.Config["password"] != "secret" {
+		t.Fatal("Output should not be modified")
+	}
+
+	for _, v := range ResourceEventTypes {
+		if !v.IsValid() {
+			t.Fatalf("%s should be valid", v)
+		}
+	}
+	if ResourceEventType("device.exploded").IsValid() {
+		t.Fatal("Unknown event type should be invalid")
+	}
+}
@@ -14,7 +14,14 @@ package model
 //See the License for the specific language governing permissions and
 //limitations under the License.
 //
-import "time"
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // FirmwareKey is the identifier for firmware images
 type FirmwareKey storageKey
@@ -30,18 +37,123 @@ func (f FirmwareKey) String() string {
 }
 
 // Firmware is the metadata for a firmware image. Most fields are immutable,
-// except the TeamID and Tags.
+// except the TeamID, Tags and compatibility rules.
 type Firmware struct {
-	ID           FirmwareKey // Image ID
-	Version      string      // Unique for collection
-	Filename     string      // Original file name - for informational purposes only
-	Length       int         // Size of image (in bytes)
-	SHA256       string      // SHA256 checksum of image. Computed when uploading. 64 hex characters (and 32 byte)
-	Created      time.Time   // Time the image was created
-	CollectionID CollectionKey
+	ID            FirmwareKey // Image ID
+	Version       string      // Unique for collection
+	Filename      string      // Original file name - for informational purposes only
+	Length        int         // Size of image (in bytes)
+	SHA256        string      // SHA256 checksum of image. Computed when uploading. 64 hex characters (and 32 byte)
+	Created       time.Time   // Time the image was created
+	CollectionID  CollectionKey
+	Signature     []byte        // Signature of the SHA256 checksum. Empty for unsigned images
+	SigningKeyID  SigningKeyKey // The key used for the signature. Zero for unsigned images
+	Compatibility FirmwareCompatibility
 	Tags
 }
 
+// IsSigned returns true if the image is signed
+func (f *Firmware) IsSigned() bool {
+	return len(f.Signature) > 0
+}
+
+// ErrInvalidCompatibility is returned when the model number pattern is
+// invalid
+var ErrInvalidCompatibility = errors.New("invalid model number pattern")
+
+// FirmwareCompatibility is the compatibility rules for a firmware image. Empty
+// fields match all devices. The manufacturer is matched case-insensitive, the
+// model number is matched with a glob pattern (f.e. "EE0*") and the minimum
+// version is compared with the version the device reports.
+type FirmwareCompatibility struct {
+	Manufacturer   string
+	ModelPattern   string
+	MinimumVersion string
+}
+
+// IsEmpty returns true if there are no compatibility rules
+func (f *FirmwareCompatibility) IsEmpty() bool {
+	return f.Manufacturer == "" && f.ModelPattern == "" && f.MinimumVersion == ""
+}
+
+// Validate checks that the model number pattern is valid
+func (f *FirmwareCompatibility) Validate() error {
+	if _, err := path.Match(f.ModelPattern, ""); err != nil {
+		return ErrInvalidCompatibility
+	}
+	return nil
+}
+
+// Check checks if the device is compatible with the firmware image. The error
+// describes why the device isn't compatible. Devices that haven't reported
+// the manufacturer, model number or version are incompatible if the firmware
+// image has a rule for the field.
+func (f *FirmwareCompatibility) Check(device DeviceFirmwareMetadata) error {
+	if f.Manufacturer != "" && !strings.EqualFold(f.Manufacturer, device.Manufacturer) {
+		if device.Manufacturer == "" {
+			return fmt.Errorf("image requires manufacturer %s but device has not reported its manufacturer", f.Manufacturer)
+		}
+		return fmt.Errorf("image requires manufacturer %s but device reports %s", f.Manufacturer, device.Manufacturer)
+	}
+	if f.ModelPattern != "" {
+		if matched, _ := path.Match(f.ModelPattern, device.ModelNumber); !matched {
+			if device.ModelNumber == "" {
+				return fmt.Errorf("image requires model %s but device has not reported its model number", f.ModelPattern)
+			}
+			return fmt.Errorf("image requires model %s but device reports %s", f.ModelPattern, device.ModelNumber)
+		}
+	}
+	if f.MinimumVersion != "" {
+		if device.FirmwareVersion == "" {
+			return fmt.Errorf("image requires version %s or later but device has not reported its version", f.MinimumVersion)
+		}
+		if CompareVersions(device.FirmwareVersion, f.MinimumVersion) < 0 {
+			return fmt.Errorf("image requires version %s or later but device runs %s", f.MinimumVersion, device.FirmwareVersion)
+		}
+	}
+	return nil
+}
+
+// CompareVersions compares two version strings and returns -1 if a is before
+// b, 0 if they are the same and 1 if a is after b. The versions are split on
+// dots, dashes and plus signs and each part is compared numerically if both
+// parts are numbers, otherwise as strings. A leading "v" is ignored.
+func CompareVersions(a, b string) int {
+	split := func(v string) []string {
+		v = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(v)), "v")
+		return strings.FieldsFunc(v, func(r rune) bool {
+			return r == '.' || r == '-' || r == '+'
+		})
+	}
+	pa, pb := split(a), split(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		// Missing parts are treated as zero so 1.0 == 1.0.0
+		x, y := "0", "0"
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		nx, errx := strconv.ParseUint(x, 10, 64)
+		ny, erry := strconv.ParseUint(y, 10, 64)
+		switch {
+		case errx == nil && erry == nil:
+			if nx < ny {
+				return -1
+			}
+			if nx > ny {
+				return 1
+			}
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
 // NewFirmware creates a new empty Firmware instance
 func NewFirmware() Firmware {
 	return Firmware{Tags: NewTags()}
@@ -55,6 +167,9 @@ type FirmwareConfig struct {
 	CollectionTargetVersion  FirmwareKey
 	DeviceCurrentVersion     FirmwareKey
 	DeviceTargetVersion      FirmwareKey
+	CollectionMaintenance    MaintenanceWindow
+	DeviceMaintenance        MaintenanceWindow
+	RequireSignature         bool
 }
 
 // CurrentVersion returns the currently running firmware version (or the one
@@ -82,6 +197,15 @@ func (f *FirmwareConfig) TargetVersion() FirmwareKey {
 	}
 }
 
+// MaintenanceWindow returns the maintenance window for the device. The
+// device's window overrides the collection's window.
+func (f *FirmwareConfig) MaintenanceWindow() MaintenanceWindow {
+	if !f.DeviceMaintenance.IsEmpty() {
+		return f.DeviceMaintenance
+	}
+	return f.CollectionMaintenance
+}
+
 // NeedsUpgrade returns true if the firmware should be upgraded
 func (f *FirmwareConfig) NeedsUpgrade() bool {
 	return f.TargetVersion() != f.CurrentVersion()
@@ -32,3 +32,62 @@ func TestFirmwareKeyStringConversion(t *testing.T) {
 		t.Fatal()
 	}
 }
+
+func TestCompareVersions(t *testing.T) {
+	for _, v := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0", "1.0.0", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2.3", "1.10.0", -1},
+		{"1.10.0", "1.2.3", 1},
+		{"2.0.0-rc1", "2.0.0-rc2", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"abc", "abd", -1},
+	} {
+		if r := CompareVersions(v.a, v.b); r != v.expected {
+			t.Fatalf("Expected %s vs %s to be %d but got %d", v.a, v.b, v.expected, r)
+		}
+	}
+}
+
+func TestFirmwareCompatibility(t *testing.T) {
+	device := DeviceFirmwareMetadata{
+		Manufacturer:    "Exploratory Engineering",
+		ModelNumber:     "EE02",
+		FirmwareVersion: "1.2.0",
+	}
+
+	c := FirmwareCompatibility{}
+	if !c.IsEmpty() || c.Check(device) != nil || c.Validate() != nil {
+		t.Fatal("Empty compatibility rules should match everything")
+	}
+
+	c = FirmwareCompatibility{Manufacturer: "exploratory engineering", ModelPattern: "EE0*", MinimumVersion: "1.1"}
+	if c.IsEmpty() {
+		t.Fatal("Rules should not be empty")
+	}
+	if err := c.Check(device); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []DeviceFirmwareMetadata{
+		{Manufacturer: "Other", ModelNumber: "EE02", FirmwareVersion: "1.2.0"},
+		{ModelNumber: "EE02", FirmwareVersion: "1.2.0"},
+		{Manufacturer: "Exploratory Engineering", ModelNumber: "EE12", FirmwareVersion: "1.2.0"},
+		{Manufacturer: "Exploratory Engineering", FirmwareVersion: "1.2.0"},
+		{Manufacturer: "Exploratory Engineering", ModelNumber: "EE02", FirmwareVersion: "1.0.9"},
+		{Manufacturer: "Exploratory Engineering", ModelNumber: "EE02"},
+	} {
+		if err := c.Check(v); err == nil {
+			t.Fatalf("Expected %+v to be incompatible", v)
+		}
+	}
+
+	c.ModelPattern = "EE0["
+	if c.Validate() != ErrInvalidCompatibility {
+		t.Fatal("Expected invalid pattern")
+	}
+}
@@ -0,0 +1,82 @@
+package model
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import "time"
+
+// FirmwareHistoryKey is the identifier for firmware history entries
+type FirmwareHistoryKey storageKey
+
+// NewFirmwareHistoryKeyFromString parses a key as a string
+func NewFirmwareHistoryKeyFromString(id string) (FirmwareHistoryKey, error) {
+	k, err := newKeyFromString(id)
+	return FirmwareHistoryKey(k), err
+}
+
+// String returns the string representation of the FirmwareHistoryKey
+func (f FirmwareHistoryKey) String() string {
+	return storageKey(f).String()
+}
+
+// FirmwareHistoryEntry is a single firmware state transition for a device.
+// The entries are recorded by the FOTA handlers (both simple FOTA and LwM2M)
+// whenever the device's firmware state changes.
+type FirmwareHistoryEntry struct {
+	ID             FirmwareHistoryKey
+	Time           time.Time
+	CollectionID   CollectionKey
+	DeviceID       DeviceKey
+	FromFirmwareID FirmwareKey // The current firmware on the device. 0 if it is unknown
+	ToFirmwareID   FirmwareKey // The target firmware for the device. 0 if there's no target
+	PreviousState  DeviceFirmwareState
+	State          DeviceFirmwareState
+	Message        string
+	Duration       time.Duration // The time spent in the previous state
+}
+
+// FirmwareStatistics is the aggregated update results for a firmware image.
+// The statistics are based on the transitions in the firmware history where
+// the image is the target.
+type FirmwareStatistics struct {
+	Downloads int // Downloads started
+	Completed int // Downloads completed
+	Updated   int // Devices reporting the image as current after the download
+	Failed    int // Failed updates
+	TimedOut  int // Updates that timed out
+	Reverted  int // Devices reverting to the previous version after the download
+}
+
+// Add counts state transitions in the statistics
+func (f *FirmwareStatistics) Add(previous DeviceFirmwareState, state DeviceFirmwareState, count int) {
+	switch state {
+	case Downloading:
+		f.Downloads += count
+	case Completed:
+		f.Completed += count
+	case Current:
+		// Devices that report the version without being updated (ie new
+		// devices) aren't counted as updated.
+		if previous == Completed {
+			f.Updated += count
+		}
+	case UpdateFailed:
+		f.Failed += count
+	case TimedOut:
+		f.TimedOut += count
+	case Reverted:
+		f.Reverted += count
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirmwareStatistics(t *testing.T) {
+	assert := require.New(t)
+
+	stats := FirmwareStatistics{}
+	stats.Add(Initializing, Downloading, 5)
+	stats.Add(Downloading, Completed, 3)
+	stats.Add(Downloading, UpdateFailed, 1)
+	stats.Add(Downloading, TimedOut, 1)
+	stats.Add(Completed, Current, 2)
+	stats.Add(Completed, Reverted, 1)
+	stats.Add(Unknown, Current, 10)
+	stats.Add(Current, Pending, 4)
+
+	assert.Equal(FirmwareStatistics{
+		Downloads: 5,
+		Completed: 3,
+		Updated:   2,
+		Failed:    1,
+		TimedOut:  1,
+		Reverted:  1,
+	}, stats)
+
+	key, err := NewFirmwareHistoryKeyFromString(FirmwareHistoryKey(1234).String())
+	assert.NoError(err)
+	assert.Equal(FirmwareHistoryKey(1234), key)
+}
@@ -0,0 +1,116 @@
+package model
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ListSort is the sort order for paged lists
+type ListSort string
+
+// Sort orders for lists. Creation order is the ID order since identifiers are
+// allocated in sequence.
+const (
+	SortByCreated  ListSort = "created"   // Creation order. This is the default
+	SortByIMSI     ListSort = "imsi"      // IMSI order. Devices only
+	SortByLastSeen ListSort = "last_seen" // Last network allocation, most recent first. Devices only
+)
+
+// IsValid returns true if the sort order is known
+func (s ListSort) IsValid() bool {
+	return s == SortByCreated || s == SortByIMSI || s == SortByLastSeen
+}
+
+// MaxPageSize is the largest page size for paged lists
+const MaxPageSize = 1000
+
+// ListOptions are the paging, sorting and filtering options for lists. A
+// zero page size returns everything.
+type ListOptions struct {
+	PageSize  int
+	PageToken string // Token returned with the previous page
+	SortBy    ListSort
+	Filter    TagFilter
+}
+
+// TagCondition is a single condition in a tag filter. Names are case
+// insensitive like the tags themselves.
+type TagCondition struct {
+	Name     string
+	Value    string
+	NotEqual bool
+}
+
+// TagFilter is a list of tag conditions. All of the conditions must match.
+type TagFilter []TagCondition
+
+var (
+	filterSeparator = regexp.MustCompile(`(?i)(^|\s+)AND(\s+|$)`)
+	errInvalidTerm  = errors.New("filter terms must be on the form name=value or name!=value")
+)
+
+// ParseTagFilter parses a filter expression like "site=oslo AND type!=gateway".
+// Tags that aren't set match != conditions but not = conditions.
+func ParseTagFilter(expr string) (TagFilter, error) {
+	var ret TagFilter
+	if strings.TrimSpace(expr) == "" {
+		return ret, nil
+	}
+	for _, term := range filterSeparator.Split(strings.TrimSpace(expr), -1) {
+		cond := TagCondition{}
+		pos := strings.Index(term, "=")
+		if pos <= 0 {
+			return nil, errInvalidTerm
+		}
+		cond.Name = term[:pos]
+		cond.Value = term[pos+1:]
+		if strings.HasSuffix(cond.Name, "!") {
+			cond.NotEqual = true
+			cond.Name = strings.TrimSuffix(cond.Name, "!")
+		}
+		cond.Name = strings.ToLower(strings.TrimSpace(cond.Name))
+		cond.Value = strings.TrimSpace(cond.Value)
+		if cond.Name == "" || strings.ContainsAny(cond.Value, "=") {
+			return nil, errInvalidTerm
+		}
+		ret = append(ret, cond)
+	}
+	return ret, nil
+}
+
+// NewPageToken creates a page token from the sort key and the ID of the last
+// element on a page.
+func NewPageToken(sortKey int64, id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", sortKey, id)))
+}
+
+// ParsePageToken returns the sort key and ID from a page token
+func ParsePageToken(token string) (int64, int64, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, 0, errors.New("invalid page token")
+	}
+	var sortKey, id int64
+	if n, err := fmt.Sscanf(string(buf), "%d:%d", &sortKey, &id); err != nil || n != 2 {
+		return 0, 0, errors.New("invalid page token")
+	}
+	return sortKey, id, nil
+}
@@ -0,0 +1,67 @@
+package model
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"testing"
+)
+
+func TestParseTagFilter(t *testing.T) {
+	filter, err := ParseTagFilter("Site = oslo AND type!=gateway and name=a b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := TagFilter{
+		{Name: "site", Value: "oslo"},
+		{Name: "type", Value: "gateway", NotEqual: true},
+		{Name: "name", Value: "a b"},
+	}
+	if len(filter) != len(expected) {
+		t.Fatalf("Expected %v but got %v", expected, filter)
+	}
+	for i := range expected {
+		if filter[i] != expected[i] {
+			t.Fatalf("Expected %v but got %v", expected[i], filter[i])
+		}
+	}
+
+	filter, err = ParseTagFilter("  ")
+	if err != nil || len(filter) != 0 {
+		t.Fatalf("Expected empty filter but got %v (%v)", filter, err)
+	}
+
+	for _, expr := range []string{"site", "=oslo", "site=oslo AND", "a=b=c", "site==oslo", " != x"} {
+		if _, err := ParseTagFilter(expr); err == nil {
+			t.Fatalf("Expected error for %q", expr)
+		}
+	}
+}
+
+func TestPageToken(t *testing.T) {
+	token := NewPageToken(-12, 4711)
+	key, id, err := ParsePageToken(token)
+	if err != nil || key != -12 || id != 4711 {
+		t.Fatalf("Token did not round trip: %d %d %v", key, id, err)
+	}
+	for _, token := range []string{"", "!!", NewPageToken(1, 2)[1:]} {
+		if _, _, err := ParsePageToken(token); err == nil {
+			t.Fatalf("Expected error for token %q", token)
+		}
+	}
+	if !SortByIMSI.IsValid() || ListSort("name").IsValid() {
+		t.Fatal("Invalid sort validation")
+	}
+}
@@ -0,0 +1,298 @@
+package model
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MSTimeZoneTag is the device tag with the time zone the network reports for
+// the device (the RADIUS 3GPP-MS-TimeZone attribute, hex encoded)
+const MSTimeZoneTag = "3GPP-MS-TimeZone"
+
+// DeviceTimeZone is the time zone setting for maintenance windows that use the
+// time zone the network reports for the device. UTC is used if the device
+// hasn't reported a time zone.
+const DeviceTimeZone = "device"
+
+// MaxMaintenanceWindowDuration is the longest maintenance window
+const MaxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+// ErrInvalidMaintenanceWindow is returned when the schedule, duration or time
+// zone for a maintenance window is invalid
+var ErrInvalidMaintenanceWindow = errors.New("invalid maintenance window")
+
+// MaintenanceWindow is a recurring window where firmware updates can be done.
+// The schedule is a cron-like expression for the start of the window with
+// five fields; minute, hour, day of month, month and day of week (0 or 7 is
+// Sunday), f.e. "0 22 * * 1-5" for weekdays at 22:00. All fields must match
+// for the window to start. The window is open for the duration. An empty
+// schedule means that updates can be done at any time.
+type MaintenanceWindow struct {
+	Schedule string        `json:"schedule"`
+	Duration time.Duration `json:"duration"`
+	TimeZone string        `json:"timeZone,omitempty"` // IANA time zone name, DeviceTimeZone or empty for UTC
+}
+
+// IsEmpty returns true if there's no maintenance window
+func (m *MaintenanceWindow) IsEmpty() bool {
+	return m.Schedule == ""
+}
+
+// Validate checks the schedule, duration and time zone for the window
+func (m *MaintenanceWindow) Validate() error {
+	if m.IsEmpty() {
+		return nil
+	}
+	if _, err := parseSchedule(m.Schedule); err != nil {
+		return ErrInvalidMaintenanceWindow
+	}
+	if m.Duration < time.Minute || m.Duration > MaxMaintenanceWindowDuration {
+		return ErrInvalidMaintenanceWindow
+	}
+	if m.TimeZone != "" && m.TimeZone != DeviceTimeZone {
+		if _, err := time.LoadLocation(m.TimeZone); err != nil {
+			return ErrInvalidMaintenanceWindow
+		}
+	}
+	return nil
+}
+
+// Location returns the time zone for the window.
+func (m *MaintenanceWindow) Location(device Device) *time.Location {
+	switch m.TimeZone {
+	case "":
+		return time.UTC
+	case DeviceTimeZone:
+		if loc, ok := msTimeZoneLocation(device.GetTag(MSTimeZoneTag)); ok {
+			return loc
+		}
+		return time.UTC
+	default:
+		loc, err := time.LoadLocation(m.TimeZone)
+		if err != nil {
+			return time.UTC
+		}
+		return loc
+	}
+}
+
+// IsOpen returns true if the window is open for the device at the time. Empty
+// windows are always open.
+func (m *MaintenanceWindow) IsOpen(t time.Time, device Device) bool {
+	if m.IsEmpty() {
+		return true
+	}
+	s, err := parseSchedule(m.Schedule)
+	if err != nil {
+		// Invalid windows are rejected when they are set so this shouldn't
+		// happen. Don't block the updates if it does.
+		return true
+	}
+	loc := m.Location(device)
+	t = t.In(loc)
+	earliest := t.Add(-m.Duration)
+
+	// Look for the start of a window between now and the duration back in time
+	c := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+	for c.After(earliest) {
+		if !s.matchDay(c) {
+			c = time.Date(c.Year(), c.Month(), c.Day(), 0, 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if s.hour&(1<<uint(c.Hour())) == 0 {
+			c = time.Date(c.Year(), c.Month(), c.Day(), c.Hour(), 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if s.minute&(1<<uint(c.Minute())) != 0 {
+			return true
+		}
+		c = c.Add(-time.Minute)
+	}
+	return false
+}
+
+// Next returns the start of the next window after the time. The search is
+// limited to one year ahead.
+func (m *MaintenanceWindow) Next(t time.Time, device Device) (time.Time, bool) {
+	if m.IsEmpty() {
+		return t, true
+	}
+	s, err := parseSchedule(m.Schedule)
+	if err != nil {
+		return t, false
+	}
+	loc := m.Location(device)
+	t = t.In(loc)
+	limit := t.AddDate(1, 0, 0)
+
+	c := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	for c.Before(limit) {
+		if !s.matchDay(c) {
+			c = time.Date(c.Year(), c.Month(), c.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(c.Hour())) == 0 {
+			c = time.Date(c.Year(), c.Month(), c.Day(), c.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(c.Minute())) != 0 {
+			return c, true
+		}
+		c = c.Add(time.Minute)
+	}
+	return t, false
+}
+
+// Scan implements the sql.Scanner interface (to read from db fields). NULL
+// values are read as empty windows.
+func (m *MaintenanceWindow) Scan(src interface{}) error {
+	switch val := src.(type) {
+	case nil:
+		*m = MaintenanceWindow{}
+		return nil
+	case []byte:
+		return json.Unmarshal(val, m)
+	case string:
+		return json.Unmarshal([]byte(val), m)
+	}
+	return errors.New("cant scan anything but bytes")
+}
+
+// Value implements the driver.Valuer interface (for writing to db fields)
+func (m MaintenanceWindow) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+// schedule is the parsed schedule. Each field is a bit mask with the values
+// that match.
+type schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+}
+
+func (s *schedule) matchDay(t time.Time) bool {
+	return s.dom&(1<<uint(t.Day())) != 0 &&
+		s.month&(1<<uint(t.Month())) != 0 &&
+		s.dow&(1<<uint(t.Weekday())) != 0
+}
+
+func parseSchedule(spec string) (schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return schedule{}, fmt.Errorf("expected 5 fields in schedule but got %d", len(fields))
+	}
+	var ret schedule
+	var err error
+	if ret.minute, err = parseScheduleField(fields[0], 0, 59); err != nil {
+		return ret, err
+	}
+	if ret.hour, err = parseScheduleField(fields[1], 0, 23); err != nil {
+		return ret, err
+	}
+	if ret.dom, err = parseScheduleField(fields[2], 1, 31); err != nil {
+		return ret, err
+	}
+	if ret.month, err = parseScheduleField(fields[3], 1, 12); err != nil {
+		return ret, err
+	}
+	if ret.dow, err = parseScheduleField(fields[4], 0, 7); err != nil {
+		return ret, err
+	}
+	// Sunday is both 0 and 7
+	if ret.dow&(1<<7) != 0 {
+		ret.dow |= 1
+	}
+	return ret, nil
+}
+
+// parseScheduleField parses a single schedule field. The field is a comma
+// separated list of values, ranges ("1-5") or wildcards ("*"). Ranges and
+// wildcards can have a step value ("*/15").
+func parseScheduleField(field string, min, max int) (uint64, error) {
+	var ret uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			part = part[:i]
+		}
+		lo, hi := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+			hi = lo
+			if step > 1 {
+				// "5/15" is the same as "5-59/15"
+				hi = max
+			}
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is out of range (%d-%d)", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			ret |= 1 << uint(v)
+		}
+	}
+	return ret, nil
+}
+
+// msTimeZoneLocation decodes the 3GPP-MS-TimeZone attribute. The first octet
+// is the offset from UTC in quarter hours as two swapped semi-octets where
+// bit 3 is the sign. The offset includes the daylight saving adjustment in
+// the second octet (3GPP TS 24.008 10.5.3.8).
+func msTimeZoneLocation(tag string) (*time.Location, bool) {
+	buf, err := hex.DecodeString(tag)
+	if err != nil || len(buf) < 1 {
+		return nil, false
+	}
+	tens := int(buf[0] & 0x07)
+	units := int(buf[0] >> 4)
+	if units > 9 {
+		return nil, false
+	}
+	offset := (tens*10 + units) * 15 * 60
+	sign := '+'
+	if buf[0]&0x08 != 0 {
+		sign = '-'
+	}
+	name := fmt.Sprintf("UTC%c%02d:%02d", sign, offset/3600, (offset%3600)/60)
+	if sign == '-' {
+		offset = -offset
+	}
+	return time.FixedZone(name, offset), true
+}
@@ -0,0 +1,176 @@
+package model
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindowValidate(t *testing.T) {
+	assert := require.New(t)
+
+	valid := []MaintenanceWindow{
+		{},
+		{Schedule: "0 22 * * 1-5", Duration: 8 * time.Hour},
+		{Schedule: "*/15 0,12 1-7 * 0", Duration: time.Minute, TimeZone: "Europe/Oslo"},
+		{Schedule: "30 2 * * 7", Duration: MaxMaintenanceWindowDuration, TimeZone: DeviceTimeZone},
+	}
+	for _, v := range valid {
+		assert.NoError(v.Validate(), "%+v should be valid", v)
+	}
+
+	invalid := []MaintenanceWindow{
+		{Schedule: "0 22 * *", Duration: time.Hour},
+		{Schedule: "60 22 * * *", Duration: time.Hour},
+		{Schedule: "0 24 * * *", Duration: time.Hour},
+		{Schedule: "0 22 0 * *", Duration: time.Hour},
+		{Schedule: "0 22 * 13 *", Duration: time.Hour},
+		{Schedule: "0 22 * * 8", Duration: time.Hour},
+		{Schedule: "0 22 * * 5-1", Duration: time.Hour},
+		{Schedule: "0 22 * * */0", Duration: time.Hour},
+		{Schedule: "a 22 * * *", Duration: time.Hour},
+		{Schedule: "0 22 * * *", Duration: 0},
+		{Schedule: "0 22 * * *", Duration: MaxMaintenanceWindowDuration + time.Minute},
+		{Schedule: "0 22 * * *", Duration: time.Hour, TimeZone: "Nowhere/Special"},
+	}
+	for _, v := range invalid {
+		assert.Equal(ErrInvalidMaintenanceWindow, v.Validate(), "%+v should be invalid", v)
+	}
+}
+
+func TestMaintenanceWindowIsOpen(t *testing.T) {
+	assert := require.New(t)
+
+	device := NewDevice()
+	empty := MaintenanceWindow{}
+	assert.True(empty.IsOpen(time.Now(), device))
+
+	// Weekdays from 22:00 to 06:00 UTC. 2020-06-01 is a Monday
+	w := MaintenanceWindow{Schedule: "0 22 * * 1-5", Duration: 8 * time.Hour}
+	assert.NoError(w.Validate())
+
+	tests := []struct {
+		t    time.Time
+		open bool
+	}{
+		{time.Date(2020, 6, 1, 21, 59, 0, 0, time.UTC), false},
+		{time.Date(2020, 6, 1, 22, 0, 0, 0, time.UTC), true},
+		{time.Date(2020, 6, 2, 5, 59, 59, 0, time.UTC), true},
+		{time.Date(2020, 6, 2, 6, 0, 0, 0, time.UTC), false},
+		{time.Date(2020, 6, 2, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2020, 6, 6, 1, 0, 0, 0, time.UTC), true},   // Friday night
+		{time.Date(2020, 6, 6, 23, 0, 0, 0, time.UTC), false}, // Saturday
+		{time.Date(2020, 6, 7, 23, 0, 0, 0, time.UTC), false}, // Sunday
+	}
+	for _, test := range tests {
+		assert.Equal(test.open, w.IsOpen(test.t, device), "%v", test.t)
+	}
+
+	// Time zones are applied before matching. Oslo is UTC+2 in June.
+	w.TimeZone = "Europe/Oslo"
+	assert.True(w.IsOpen(time.Date(2020, 6, 1, 20, 0, 0, 0, time.UTC), device))
+	assert.False(w.IsOpen(time.Date(2020, 6, 2, 4, 0, 0, 0, time.UTC), device))
+
+	// The device time zone is UTC until the device has reported it
+	w.TimeZone = DeviceTimeZone
+	assert.False(w.IsOpen(time.Date(2020, 6, 1, 20, 0, 0, 0, time.UTC), device))
+	device.SetTag(MSTimeZoneTag, "8000")
+	assert.True(w.IsOpen(time.Date(2020, 6, 1, 20, 0, 0, 0, time.UTC), device))
+	device.SetTag(MSTimeZoneTag, "0a00")
+	assert.True(w.IsOpen(time.Date(2020, 6, 2, 3, 0, 0, 0, time.UTC), device))
+	assert.False(w.IsOpen(time.Date(2020, 6, 1, 22, 0, 0, 0, time.UTC), device))
+}
+
+func TestMaintenanceWindowNext(t *testing.T) {
+	assert := require.New(t)
+
+	device := NewDevice()
+	w := MaintenanceWindow{Schedule: "30 22 * * 1-5", Duration: time.Hour}
+
+	next, ok := w.Next(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC), device)
+	assert.True(ok)
+	assert.Equal(time.Date(2020, 6, 1, 22, 30, 0, 0, time.UTC), next)
+
+	next, ok = w.Next(time.Date(2020, 6, 5, 22, 30, 0, 0, time.UTC), device)
+	assert.True(ok)
+	assert.Equal(time.Date(2020, 6, 8, 22, 30, 0, 0, time.UTC), next)
+
+	// February 30th never happens
+	w.Schedule = "0 0 30 2 *"
+	_, ok = w.Next(time.Now(), device)
+	assert.False(ok)
+}
+
+func TestMSTimeZone(t *testing.T) {
+	assert := require.New(t)
+
+	tests := []struct {
+		tag    string
+		offset int
+		name   string
+	}{
+		{"0000", 0, "UTC+00:00"},
+		{"4000", 3600, "UTC+01:00"},
+		{"8001", 7200, "UTC+02:00"},
+		{"0a00", -5 * 3600, "UTC-05:00"},
+		{"2200", 5*3600 + 30*60, "UTC+05:30"},
+		{"2800", -30 * 60, "UTC-00:30"},
+	}
+	for _, test := range tests {
+		loc, ok := msTimeZoneLocation(test.tag)
+		assert.True(ok, test.tag)
+		name, offset := time.Date(2020, 1, 1, 0, 0, 0, 0, loc).Zone()
+		assert.Equal(test.offset, offset, test.tag)
+		assert.Equal(test.name, name, test.tag)
+	}
+
+	_, ok := msTimeZoneLocation("")
+	assert.False(ok)
+	_, ok = msTimeZoneLocation("xx")
+	assert.False(ok)
+	_, ok = msTimeZoneLocation("a000")
+	assert.False(ok)
+}
+
+func TestMaintenanceWindowScan(t *testing.T) {
+	assert := require.New(t)
+
+	w := MaintenanceWindow{Schedule: "0 22 * * 1-5", Duration: 8 * time.Hour, TimeZone: DeviceTimeZone}
+	v, err := w.Value()
+	assert.NoError(err)
+
+	var read MaintenanceWindow
+	assert.NoError(read.Scan(v))
+	assert.Equal(w, read)
+	assert.NoError(read.Scan(string(v.([]byte))))
+	assert.Equal(w, read)
+	assert.NoError(read.Scan(nil))
+	assert.True(read.IsEmpty())
+	assert.Error(read.Scan(1))
+}
+
+func TestFirmwareConfigMaintenanceWindow(t *testing.T) {
+	assert := require.New(t)
+
+	cfg := FirmwareConfig{CollectionMaintenance: MaintenanceWindow{Schedule: "0 22 * * *", Duration: time.Hour}}
+	assert.Equal(cfg.CollectionMaintenance, cfg.MaintenanceWindow())
+
+	cfg.DeviceMaintenance = MaintenanceWindow{Schedule: "0 2 * * *", Duration: time.Hour}
+	assert.Equal(cfg.DeviceMaintenance, cfg.MaintenanceWindow())
+}
@@ -52,6 +52,27 @@ func (o OutputConfig) HasParameterOfType(name string, expectedType reflect.Kind)
 
 }
 
+// StringList returns a list of strings from the configuration. Lists are
+// []interface{} when the configuration is read from JSON. The second return
+// value is false if the parameter is missing or isn't a list of strings.
+func (o OutputConfig) StringList(name string) ([]string, bool) {
+	switch v := o[name].(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		ret := make([]string, len(v))
+		for i, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			ret[i] = str
+		}
+		return ret, true
+	}
+	return nil, false
+}
+
 // NewOutputConfig creates a new output configuration
 func NewOutputConfig() OutputConfig {
 	return make(OutputConfig)
@@ -102,10 +123,46 @@ type OutputLogEntry struct {
 	Repeated uint8
 }
 
-// OutputStatus is used to report the internal state of the forwarder.
+// OutputStatus is used to report the internal state of the forwarder. The
+// Throttled counter is incremented every time the output has to hold back a
+// request because of its rate or concurrency limits and Queued is the number
+// of messages waiting to be sent. Dropped is the number of messages dropped
+// because the queue was full.
 type OutputStatus struct {
 	Forwarded   int
 	Received    int
 	ErrorCount  int
 	Retransmits int
+	Throttled   int
+	Queued      int
+	Dropped     int
+}
+
+// OutputTLSTrace holds the result of the TLS handshake for a test delivery.
+type OutputTLSTrace struct {
+	HandshakeComplete bool
+	Version           string
+	CipherSuite       string
+	ServerName        string
+	Error             string
+}
+
+// OutputTestResult is the delivery trace for a single test message sent
+// through an output. The fields that are relevant depends on the output type,
+// ie the HTTP fields are only set for webhooks and IFTTT outputs and the
+// MQTT return code is only set for MQTT outputs. All of the durations are
+// measured from the start of the delivery.
+type OutputTestResult struct {
+	Success          bool
+	Message          string
+	RemoteAddress    string
+	TLS              *OutputTLSTrace
+	HTTPStatusCode   int
+	HTTPResponseBody string
+	MQTTReturnCode   int
+	DNSLookup        time.Duration
+	Connect          time.Duration
+	TLSHandshake     time.Duration
+	FirstByte        time.Duration
+	Total            time.Duration
 }
@@ -0,0 +1,131 @@
+package model
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"fmt"
+	"time"
+)
+
+// Quota is the resource limits for a team. Zero values are unlimited.
+type Quota struct {
+	MaxDevices        int   // Maximum number of devices
+	MaxCollections    int   // Maximum number of collections
+	MaxOutputs        int   // Maximum number of outputs
+	MaxFirmwareBytes  int64 // Maximum total size of firmware images
+	MaxUplinkPerDay   int   // Maximum number of upstream messages per day
+	MaxDownlinkPerDay int   // Maximum number of downstream messages per day
+}
+
+// QuotaUsage is the current resource usage for a team
+type QuotaUsage struct {
+	Devices       int
+	Collections   int
+	Outputs       int
+	FirmwareBytes int64
+	UplinkToday   int
+	DownlinkToday int
+}
+
+// MessageDirection is the direction of messages for the message budgets
+type MessageDirection int
+
+const (
+	// Uplink is messages sent from the devices
+	Uplink MessageDirection = iota
+	// Downlink is messages sent to the devices
+	Downlink
+)
+
+// QuotaDay returns the day used for the daily message budgets. Days start at
+// midnight UTC.
+func QuotaDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// QuotaExceededError is returned when an operation would exceed one of the
+// team's quotas. The limit is omitted from the message if it is zero.
+type QuotaExceededError struct {
+	Resource string
+	Limit    int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Limit == 0 {
+		return fmt.Sprintf("team quota exceeded: budget for %s is exhausted", e.Resource)
+	}
+	return fmt.Sprintf("team quota exceeded: maximum %s is %d", e.Resource, e.Limit)
+}
+
+// CheckDevices returns an error if a new device would exceed the quota
+func (q Quota) CheckDevices(usage QuotaUsage) error {
+	return q.CheckNewDevices(usage, 1)
+}
+
+// CheckNewDevices returns an error if the number of new devices would exceed
+// the quota
+func (q Quota) CheckNewDevices(usage QuotaUsage, count int) error {
+	if q.MaxDevices > 0 && usage.Devices+count > q.MaxDevices {
+		return &QuotaExceededError{Resource: "number of devices", Limit: int64(q.MaxDevices)}
+	}
+	return nil
+}
+
+// CheckCollections returns an error if a new collection would exceed the quota
+func (q Quota) CheckCollections(usage QuotaUsage) error {
+	return q.CheckNewCollections(usage, 1)
+}
+
+// CheckNewCollections returns an error if the number of new collections would
+// exceed the quota
+func (q Quota) CheckNewCollections(usage QuotaUsage, count int) error {
+	if q.MaxCollections > 0 && usage.Collections+count > q.MaxCollections {
+		return &QuotaExceededError{Resource: "number of collections", Limit: int64(q.MaxCollections)}
+	}
+	return nil
+}
+
+// CheckOutputs returns an error if a new output would exceed the quota
+func (q Quota) CheckOutputs(usage QuotaUsage) error {
+	return q.CheckNewOutputs(usage, 1)
+}
+
+// CheckNewOutputs returns an error if the number of new outputs would exceed
+// the quota
+func (q Quota) CheckNewOutputs(usage QuotaUsage, count int) error {
+	if q.MaxOutputs > 0 && usage.Outputs+count > q.MaxOutputs {
+		return &QuotaExceededError{Resource: "number of outputs", Limit: int64(q.MaxOutputs)}
+	}
+	return nil
+}
+
+// CheckFirmware returns an error if a new firmware image with the specified
+// length would exceed the quota
+func (q Quota) CheckFirmware(usage QuotaUsage, length int64) error {
+	if q.MaxFirmwareBytes > 0 && usage.FirmwareBytes+length > q.MaxFirmwareBytes {
+		return &QuotaExceededError{Resource: "firmware image size in bytes", Limit: q.MaxFirmwareBytes}
+	}
+	return nil
+}
+
+// MessageLimit returns the daily message budget for the direction. Zero is
+// unlimited.
+func (q Quota) MessageLimit(direction MessageDirection) int {
+	if direction == Downlink {
+		return q.MaxDownlinkPerDay
+	}
+	return q.MaxUplinkPerDay
+}
@@ -24,20 +24,135 @@ var (
 	AdminRole = RoleID(1)
 	// MemberRole is the member role, ie a member without administrative privileges
 	MemberRole = RoleID(0)
+	// ViewerRole is a read-only role. Viewers can see the resources but not
+	// the payloads from the devices.
+	ViewerRole = RoleID(2)
+	// OperatorRole can manage devices and send messages to devices but can't
+	// change collections, outputs, tokens or firmware.
+	OperatorRole = RoleID(3)
+	// FirmwareManagerRole can manage firmware images and firmware updates.
+	FirmwareManagerRole = RoleID(4)
 )
 
-// NewRoleIDFromString converts a string into a role ID
+// Permission is a single permission granted by a role
+type Permission uint8
+
+const (
+	// ReadDataPermission allows reading payloads sent by the devices
+	ReadDataPermission Permission = iota
+	// SendMessagePermission allows sending messages to the devices
+	SendMessagePermission
+	// ManageDevicesPermission allows creating, updating and removing devices
+	ManageDevicesPermission
+	// ManageCollectionsPermission allows creating, updating and removing
+	// collections
+	ManageCollectionsPermission
+	// ManageOutputsPermission allows creating, updating and removing outputs
+	ManageOutputsPermission
+	// ManageFirmwarePermission allows uploading and removing firmware images
+	// and changing the firmware settings for collections and devices
+	ManageFirmwarePermission
+	// ManageTokensPermission allows creating tokens for the team's resources
+	ManageTokensPermission
+	// ManageTeamPermission allows changing the team, its members and invites
+	ManageTeamPermission
+)
+
+var permissionNames = map[Permission]string{
+	ReadDataPermission:          "read data",
+	SendMessagePermission:       "send messages",
+	ManageDevicesPermission:     "manage devices",
+	ManageCollectionsPermission: "manage collections",
+	ManageOutputsPermission:     "manage outputs",
+	ManageFirmwarePermission:    "manage firmware",
+	ManageTokensPermission:      "manage tokens",
+	ManageTeamPermission:        "manage the team",
+}
+
+// String returns a description of the permission
+func (p Permission) String() string {
+	return permissionNames[p]
+}
+
+// rolePermissions is the policy table for the roles. Roles that aren't in
+// the table have no permissions.
+var rolePermissions = map[RoleID][]Permission{
+	AdminRole: {
+		ReadDataPermission,
+		SendMessagePermission,
+		ManageDevicesPermission,
+		ManageCollectionsPermission,
+		ManageOutputsPermission,
+		ManageFirmwarePermission,
+		ManageTokensPermission,
+		ManageTeamPermission,
+	},
+	MemberRole: {
+		ReadDataPermission,
+		SendMessagePermission,
+	},
+	ViewerRole: {},
+	OperatorRole: {
+		ReadDataPermission,
+		SendMessagePermission,
+		ManageDevicesPermission,
+	},
+	FirmwareManagerRole: {
+		ReadDataPermission,
+		ManageFirmwarePermission,
+	},
+}
+
+var roleNames = map[RoleID]string{
+	AdminRole:           "Admin",
+	MemberRole:          "Member",
+	ViewerRole:          "Viewer",
+	OperatorRole:        "Operator",
+	FirmwareManagerRole: "FirmwareManager",
+}
+
+// Roles returns all of the known roles
+func Roles() []RoleID {
+	return []RoleID{MemberRole, AdminRole, ViewerRole, OperatorRole, FirmwareManagerRole}
+}
+
+// NewRoleIDFromString converts a string into a role ID. Unknown roles are
+// converted into the member role.
 func NewRoleIDFromString(s string) RoleID {
-	if strings.ToLower(s) == "admin" {
-		return AdminRole
+	name := strings.ReplaceAll(strings.ToLower(s), "-", "")
+	for id, v := range roleNames {
+		if strings.ToLower(v) == name {
+			return id
+		}
 	}
 	return MemberRole
 }
 
 // String returns the string representation of the role
 func (r RoleID) String() string {
-	if r == AdminRole {
-		return "Admin"
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+	return roleNames[MemberRole]
+}
+
+// Allows returns true if the role grants the permission
+func (r RoleID) Allows(p Permission) bool {
+	for _, v := range rolePermissions[r] {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+// RolesWithPermission returns the roles that grant the permission
+func RolesWithPermission(p Permission) []RoleID {
+	var ret []RoleID
+	for _, r := range Roles() {
+		if r.Allows(p) {
+			ret = append(ret, r)
+		}
 	}
-	return "Member"
+	return ret
 }
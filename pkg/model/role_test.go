@@ -39,4 +39,41 @@ func TestRoleConversion(t *testing.T) {
 	if NewRoleIDFromString(MemberRole.String()) != MemberRole {
 		t.Fatal("not member")
 	}
+
+	for _, v := range Roles() {
+		if NewRoleIDFromString(v.String()) != v {
+			t.Fatalf("Role %d did not survive a roundtrip", v)
+		}
+	}
+	if NewRoleIDFromString("firmware-manager") != FirmwareManagerRole {
+		t.Fatal("Not firmware manager")
+	}
+}
+
+func TestRolePermissions(t *testing.T) {
+	if !AdminRole.Allows(ManageTeamPermission) || !AdminRole.Allows(ManageTokensPermission) {
+		t.Fatal("Admin should have all permissions")
+	}
+	if ViewerRole.Allows(ReadDataPermission) || len(rolePermissions[ViewerRole]) > 0 {
+		t.Fatal("Viewer should be read only")
+	}
+	if !OperatorRole.Allows(SendMessagePermission) || !OperatorRole.Allows(ManageDevicesPermission) {
+		t.Fatal("Operator should manage devices and send messages")
+	}
+	if OperatorRole.Allows(ManageOutputsPermission) || OperatorRole.Allows(ManageTokensPermission) || OperatorRole.Allows(ManageFirmwarePermission) {
+		t.Fatal("Operator should not manage outputs, tokens or firmware")
+	}
+	if !FirmwareManagerRole.Allows(ManageFirmwarePermission) || FirmwareManagerRole.Allows(ManageDevicesPermission) {
+		t.Fatal("Firmware manager should only manage firmware")
+	}
+	if MemberRole.Allows(ManageDevicesPermission) || !MemberRole.Allows(SendMessagePermission) {
+		t.Fatal("Member should not modify resources")
+	}
+	if RoleID(99).Allows(ReadDataPermission) {
+		t.Fatal("Unknown roles should have no permissions")
+	}
+	roles := RolesWithPermission(ManageFirmwarePermission)
+	if len(roles) != 2 || roles[0] != AdminRole || roles[1] != FirmwareManagerRole {
+		t.Fatalf("Expected admin and firmware manager roles, got %v", roles)
+	}
 }
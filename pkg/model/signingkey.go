@@ -0,0 +1,182 @@
+package model
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"time"
+)
+
+// SigningKeyKey is the identifier for firmware signing keys
+type SigningKeyKey storageKey
+
+// NewSigningKeyKeyFromString converts a string into a SigningKeyKey
+func NewSigningKeyKeyFromString(id string) (SigningKeyKey, error) {
+	k, err := newKeyFromString(id)
+	return SigningKeyKey(k), err
+}
+
+func (s SigningKeyKey) String() string {
+	return storageKey(s).String()
+}
+
+// Signature algorithms for firmware signing keys
+const (
+	Ed25519Signature   = "ed25519"
+	ECDSAP256Signature = "ecdsa-p256"
+)
+
+// ErrInvalidSigningKey is returned when the algorithm or the key data is
+// invalid
+var ErrInvalidSigningKey = errors.New("invalid signing key")
+
+// SigningKey is a key used to sign firmware images in a collection. The
+// signature is made over the SHA-256 digest of the image. The public key is
+// PKIX (DER) encoded and the private key is PKCS #8 (DER) encoded. Keys that
+// are registered by the users only have the public key and the images must
+// be signed before they are uploaded. ECDSA signatures are ASN.1 encoded.
+//
+// The private key is stored in plain text in the backend store alongside
+// the rest of the key. Anyone with read access to the database can sign
+// images for the collection. Use registered keys (ie public key only) when
+// the database isn't trusted with the private keys.
+type SigningKey struct {
+	ID           SigningKeyKey
+	CollectionID CollectionKey
+	Algorithm    string
+	PublicKey    []byte
+	PrivateKey   []byte // Not encrypted. Empty for registered keys
+	Created      time.Time
+}
+
+// ecdsaSignature is the ASN.1 structure for ECDSA signatures
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// NewSigningKey generates a new key pair for the algorithm
+func NewSigningKey(algorithm string) (SigningKey, error) {
+	var private interface{}
+	var public interface{}
+	switch algorithm {
+	case Ed25519Signature:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		private, public = priv, pub
+	case ECDSAP256Signature:
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		private, public = priv, &priv.PublicKey
+	default:
+		return SigningKey{}, ErrInvalidSigningKey
+	}
+	privateKey, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return SigningKey{}, err
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return SigningKey{}, err
+	}
+	return SigningKey{Algorithm: algorithm, PublicKey: publicKey, PrivateKey: privateKey}, nil
+}
+
+// NewSigningKeyFromPublicKey creates a signing key from a PKIX encoded
+// public key. The key must match the algorithm.
+func NewSigningKeyFromPublicKey(algorithm string, publicKey []byte) (SigningKey, error) {
+	ret := SigningKey{Algorithm: algorithm, PublicKey: publicKey}
+	if _, err := ret.publicKey(); err != nil {
+		return SigningKey{}, err
+	}
+	return ret, nil
+}
+
+// CanSign returns true if the private key is set, ie images can be signed
+// with this key when they are uploaded.
+func (s *SigningKey) CanSign() bool {
+	return len(s.PrivateKey) > 0
+}
+
+func (s *SigningKey) publicKey() (interface{}, error) {
+	key, err := x509.ParsePKIXPublicKey(s.PublicKey)
+	if err != nil {
+		return nil, ErrInvalidSigningKey
+	}
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		if s.Algorithm == Ed25519Signature {
+			return k, nil
+		}
+	case *ecdsa.PublicKey:
+		if s.Algorithm == ECDSAP256Signature && k.Curve == elliptic.P256() {
+			return k, nil
+		}
+	}
+	return nil, ErrInvalidSigningKey
+}
+
+// Sign signs the SHA-256 digest of an image with the private key
+func (s *SigningKey) Sign(digest []byte) ([]byte, error) {
+	if !s.CanSign() {
+		return nil, errors.New("no private key for signing key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(s.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, digest), nil
+	case *ecdsa.PrivateKey:
+		r, ss, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			return nil, err
+		}
+		return asn1.Marshal(ecdsaSignature{R: r, S: ss})
+	}
+	return nil, ErrInvalidSigningKey
+}
+
+// Verify checks the signature of the SHA-256 digest of an image
+func (s *SigningKey) Verify(digest []byte, signature []byte) bool {
+	key, err := s.publicKey()
+	if err != nil {
+		return false
+	}
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, digest, signature)
+	case *ecdsa.PublicKey:
+		sig := ecdsaSignature{}
+		rest, err := asn1.Unmarshal(signature, &sig)
+		if err != nil || len(rest) > 0 || sig.R == nil || sig.S == nil {
+			return false
+		}
+		return ecdsa.Verify(k, digest, sig.R, sig.S)
+	}
+	return false
+}
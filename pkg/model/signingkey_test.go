@@ -0,0 +1,74 @@
+package model
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+func TestSigningKeys(t *testing.T) {
+	digest := sha256.Sum256([]byte("image"))
+	other := sha256.Sum256([]byte("other image"))
+
+	for _, alg := range []string{Ed25519Signature, ECDSAP256Signature} {
+		key, err := NewSigningKey(alg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !key.CanSign() {
+			t.Fatalf("Generated %s key should be able to sign", alg)
+		}
+		sig, err := key.Sign(digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sig) > 255 {
+			t.Fatalf("Signature for %s is too long (%d bytes)", alg, len(sig))
+		}
+
+		public, err := NewSigningKeyFromPublicKey(alg, key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if public.CanSign() {
+			t.Fatal("Public key should not be able to sign")
+		}
+		if _, err := public.Sign(digest[:]); err == nil {
+			t.Fatal("Expected error when signing without private key")
+		}
+		if !public.Verify(digest[:], sig) {
+			t.Fatalf("%s signature does not verify", alg)
+		}
+		if public.Verify(other[:], sig) {
+			t.Fatalf("%s signature verifies for other digest", alg)
+		}
+		if public.Verify(digest[:], sig[1:]) {
+			t.Fatalf("Truncated %s signature verifies", alg)
+		}
+	}
+
+	ed, _ := NewSigningKey(Ed25519Signature)
+	if _, err := NewSigningKeyFromPublicKey(ECDSAP256Signature, ed.PublicKey); err != ErrInvalidSigningKey {
+		t.Fatal("Expected invalid key when algorithm does not match but got ", err)
+	}
+	if _, err := NewSigningKeyFromPublicKey(Ed25519Signature, []byte("garbage")); err != ErrInvalidSigningKey {
+		t.Fatal("Expected invalid key for garbage but got ", err)
+	}
+	if _, err := NewSigningKey("rsa"); err != ErrInvalidSigningKey {
+		t.Fatal("Expected invalid key for unknown algorithm but got ", err)
+	}
+}
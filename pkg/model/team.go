@@ -76,6 +76,24 @@ func (t *Team) IsAdmin(userID UserKey) bool {
 	return false
 }
 
+// RoleOf returns the role of the user in the team. The boolean flag is
+// false if the user isn't a member of the team. Not thread safe
+func (t *Team) RoleOf(userID UserKey) (RoleID, bool) {
+	for _, v := range t.Members {
+		if v.User.ID == userID {
+			return v.Role, true
+		}
+	}
+	return MemberRole, false
+}
+
+// HasPermission returns true if the user is a member of the team and the
+// user's role grants the permission. Not thread safe
+func (t *Team) HasPermission(userID UserKey, p Permission) bool {
+	role, ok := t.RoleOf(userID)
+	return ok && role.Allows(p)
+}
+
 // AddMember adds member to team. Not thread safe
 func (t *Team) AddMember(newMember Member) bool {
 	if t.IsMember(newMember.User.ID) {
@@ -17,21 +17,37 @@ package model
 //
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"time"
 )
 
-// Token is access tokens for the REST API
+// Token is access tokens for the REST API. The token itself is never stored,
+// only the ID which is a hash of the token. The Token field is only set when
+// the token is generated.
 type Token struct {
-	UserID   UserKey
-	Resource string
-	Write    bool
-	Token    string
+	ID         string
+	UserID     UserKey
+	Resource   string
+	Write      bool
+	Token      string
+	Created    time.Time
+	Expires    time.Time // Zero value means the token never expires
+	LastUsed   time.Time // Zero value means the token hasn't been used
+	LastUsedIP string
 	Tags
 }
 
+// TokenID returns the ID for a token, ie the hex-encoded SHA-256 hash of the
+// token.
+func TokenID(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
 // GenerateToken generates a new token. Note that this will overwrite the
-// existing token
+// existing token and ID
 func (t *Token) GenerateToken() error {
 	buf := make([]byte, 32)
 	n, err := rand.Read(buf)
@@ -39,9 +55,15 @@ func (t *Token) GenerateToken() error {
 		return fmt.Errorf("unable to generate token %d bytes long. Only got %d bytes", len(buf), n)
 	}
 	t.Token = hex.EncodeToString(buf)
+	t.ID = TokenID(t.Token)
 	return nil
 }
 
+// Expired returns true if the token has expired at the specified time
+func (t *Token) Expired(now time.Time) bool {
+	return !t.Expires.IsZero() && !now.Before(t.Expires)
+}
+
 // NewToken creates a new token
 func NewToken() Token {
 	return Token{Tags: NewTags()}
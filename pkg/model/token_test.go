@@ -14,7 +14,10 @@ package model
 //See the License for the specific language governing permissions and
 //limitations under the License.
 //
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestToken(t *testing.T) {
 	token := NewToken()
@@ -27,4 +30,22 @@ func TestToken(t *testing.T) {
 	if token.Token == "" {
 		t.Fatal("Token should be set")
 	}
+	if token.ID != TokenID(token.Token) || token.ID == token.Token {
+		t.Fatal("ID should be the hash of the token")
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	now := time.Now()
+	token := NewToken()
+	if token.Expired(now) {
+		t.Fatal("Token without expiry should not expire")
+	}
+	token.Expires = now.Add(time.Minute)
+	if token.Expired(now) {
+		t.Fatal("Token should not be expired yet")
+	}
+	if !token.Expired(now.Add(time.Minute)) {
+		t.Fatal("Token should be expired")
+	}
 }
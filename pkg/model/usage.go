@@ -0,0 +1,53 @@
+package model
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import "time"
+
+// Usage is the metered resource usage for a collection in a one hour period.
+// Message counts are split by transport. Usage that isn't related to a
+// message transport (RADIUS sessions, firmware and output deliveries) have an
+// empty transport.
+type Usage struct {
+	TeamID           TeamKey
+	CollectionID     CollectionKey
+	Hour             time.Time // Start of the hour
+	Transport        string
+	UplinkMessages   int64
+	UplinkBytes      int64
+	DownlinkMessages int64
+	DownlinkBytes    int64
+	RADIUSSessions   int64
+	FirmwareBytes    int64 // Bytes of firmware images delivered to devices
+	OutputDeliveries int64 // Messages delivered by outputs
+}
+
+// UsageHour returns the start of the hour used for metering
+func UsageHour(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
+
+// Add adds the counters from another usage record to this one. The
+// identifying fields are left unchanged.
+func (u *Usage) Add(other Usage) {
+	u.UplinkMessages += other.UplinkMessages
+	u.UplinkBytes += other.UplinkBytes
+	u.DownlinkMessages += other.DownlinkMessages
+	u.DownlinkBytes += other.DownlinkBytes
+	u.RADIUSSessions += other.RADIUSSessions
+	u.FirmwareBytes += other.FirmwareBytes
+	u.OutputDeliveries += other.OutputDeliveries
+}
@@ -0,0 +1,72 @@
+package oidclogin
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// discoveryPath is the well-known path for the provider configuration
+const discoveryPath = "/.well-known/openid-configuration"
+
+// providerConfig is the subset of the discovery document that we use
+type providerConfig struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	EndSessionEndpoint    string   `json:"end_session_endpoint"`
+	SigningAlgorithms     []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// discover retrieves the discovery document for the issuer. The issuer in
+// the document must match the configured issuer.
+func discover(client *http.Client, issuer string) (providerConfig, error) {
+	ret := providerConfig{}
+	discoveryURL := strings.TrimSuffix(issuer, "/") + discoveryPath
+	if err := getJSON(client, discoveryURL, &ret); err != nil {
+		return ret, err
+	}
+	if strings.TrimSuffix(ret.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return ret, fmt.Errorf("issuer in discovery document (%s) does not match %s", ret.Issuer, issuer)
+	}
+	if ret.AuthorizationEndpoint == "" || ret.TokenEndpoint == "" || ret.JWKSURI == "" {
+		return ret, errors.New("discovery document is missing required endpoints")
+	}
+	return ret, nil
+}
+
+// getJSON does a GET request and decodes the JSON response
+func getJSON(client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s returned %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
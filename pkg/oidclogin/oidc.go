@@ -0,0 +1,332 @@
+package oidclogin
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+)
+
+const (
+	// OIDCAuthCookieName is the name of the cookie used to store the session ID
+	OIDCAuthCookieName = "ee_oidc_session"
+	// expireCheckInterval is the interval between removal of expired sessions
+	expireCheckInterval = time.Minute * 5
+)
+
+// oidcSessionType is a type used to store the profile in the request context
+type oidcSessionType string
+
+// OIDCSessionProfile is the value for the profile in the context
+const OIDCSessionProfile = oidcSessionType("OIDCSession")
+
+// Authenticator is the OpenID Connect login. The provider is discovered
+// through the issuer's discovery document when the first login starts.
+type Authenticator struct {
+	Config   Config // Config is the authenticator's configuration
+	sessions SessionStore
+	client   *http.Client
+	mutex    *sync.Mutex
+	provider *providerConfig
+	keys     *keySet
+}
+
+// New creates a new OpenID Connect authenticator instance
+func New(config Config) (*Authenticator, error) {
+	sessionStore, err := NewSQLSessionStore(config.DBDriver, config.DBConnectionString)
+	if err != nil {
+		return nil, err
+	}
+	return newAuthenticator(config, sessionStore, &http.Client{Timeout: 10 * time.Second})
+}
+
+func newAuthenticator(config Config, sessions SessionStore, client *http.Client) (*Authenticator, error) {
+	if config.Issuer == "" || config.ClientID == "" {
+		return nil, errors.New("issuer and client ID must be set")
+	}
+	return &Authenticator{
+		Config:   config,
+		sessions: sessions,
+		client:   client,
+		mutex:    &sync.Mutex{},
+	}, nil
+}
+
+// Handler returns a handler for the (local) OpenID Connect resource
+func (a *Authenticator) Handler() http.Handler {
+	return a
+}
+
+// AuthHandlerFunc returns a http.HandlerFunc wrapped in an authentication handler.
+// If the user isn't authenticated it will return a 401 response, otherwise the wrapped function will be executed.
+func (a *Authenticator) AuthHandlerFunc(funcToWrap http.HandlerFunc) http.HandlerFunc {
+	return (&authHandler{existingHandler: funcToWrap, sessions: a.sessions}).ServeHTTP
+}
+
+// Profile reads the user profile from the http.Request context
+func (a *Authenticator) Profile(r *http.Request) (Profile, error) {
+	p := getProfileFromContext(r)
+	if p == nil {
+		return Profile{}, errors.New("not logged in")
+	}
+	return *p, nil
+}
+
+// StartSessionChecker launches a goroutine that removes expired sessions
+func (a *Authenticator) StartSessionChecker() {
+	go func() {
+		for {
+			time.Sleep(expireCheckInterval)
+			if err := a.sessions.RemoveExpiredSessions(time.Now().UnixNano()); err != nil {
+				logging.Warning("Unable to remove expired OIDC sessions: %v", err)
+			}
+		}
+	}()
+}
+
+const (
+	loginPath    = "/login"
+	callbackPath = "/callback"
+	logoutPath   = "/logout"
+)
+
+func (a *Authenticator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, loginPath) {
+		a.startLogin(w, r)
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, callbackPath) {
+		a.handleCallback(w, r)
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, logoutPath) {
+		a.startLogout(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte(fmt.Sprintf("Don't know how to handle %s", r.URL.String())))
+}
+
+// discover returns the provider configuration, retrieving the discovery
+// document if required.
+func (a *Authenticator) discover() (providerConfig, *keySet, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.provider != nil {
+		return *a.provider, a.keys, nil
+	}
+	provider, err := discover(a.client, a.Config.Issuer)
+	if err != nil {
+		return providerConfig{}, nil, err
+	}
+	keys := newKeySet(a.client, provider.JWKSURI)
+	if err := keys.refresh(); err != nil {
+		return providerConfig{}, nil, err
+	}
+	a.provider = &provider
+	a.keys = keys
+	return provider, keys, nil
+}
+
+// startLogin starts the authorization code flow with PKCE
+func (a *Authenticator) startLogin(w http.ResponseWriter, r *http.Request) {
+	provider, _, err := a.discover()
+	if err != nil {
+		logging.Error("Unable to retrieve OIDC provider configuration from %s: %v", a.Config.Issuer, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Unable to contact login provider"))
+		return
+	}
+
+	state := LoginState{
+		State:        randomString(),
+		CodeVerifier: randomString(),
+		Nonce:        randomString(),
+	}
+	if err := a.sessions.PutState(state); err != nil {
+		logging.Error("Unable to persist state for OIDC login: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("error generating random state for session"))
+		return
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", a.Config.ClientID)
+	params.Set("redirect_uri", a.Config.CallbackURL)
+	params.Set("scope", a.Config.Scopes)
+	params.Set("state", state.State)
+	params.Set("nonce", state.Nonce)
+	params.Set("code_challenge", codeChallenge(state.CodeVerifier))
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(provider.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	http.Redirect(w, r, provider.AuthorizationEndpoint+separator+params.Encode(), http.StatusTemporaryRedirect)
+}
+
+// handleCallback handles the callback from the provider. The code is
+// exchanged for an ID token which is then verified.
+func (a *Authenticator) handleCallback(w http.ResponseWriter, r *http.Request) {
+	if errCode := r.URL.Query().Get("error"); errCode != "" {
+		logging.Warning("Got error response from OIDC provider: %s (%s)", errCode, r.URL.Query().Get("error_description"))
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("Login was not successful. Please try logging in again"))
+		return
+	}
+
+	state, err := a.sessions.RemoveState(r.URL.Query().Get("state"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Unknown state in callback. Please try logging in again"))
+		return
+	}
+
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Did not get an authorization code. Please try logging in again"))
+		return
+	}
+
+	provider, keys, err := a.discover()
+	if err != nil {
+		logging.Error("Unable to retrieve OIDC provider configuration from %s: %v", a.Config.Issuer, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Unable to contact login provider"))
+		return
+	}
+
+	idToken, err := a.exchangeCode(provider, code, state.CodeVerifier)
+	if err != nil {
+		logging.Error("Unable to exchange authorization code: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Unable to request token from login provider"))
+		return
+	}
+
+	claims, err := verifyIDToken(keys, idToken, provider.Issuer, a.Config.ClientID, state.Nonce, time.Now())
+	if err != nil {
+		logging.Warning("Invalid ID token from OIDC provider: %v", err)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("Could not verify login. Please try logging in again"))
+		return
+	}
+	profile := profileFromClaims(claims, a.Config)
+
+	expires := time.Now().UnixNano() + int64(a.Config.SessionLength)
+	sessionID := newSessionID()
+	if err := a.sessions.CreateSession(sessionID, idToken, expires, profile); err != nil {
+		logging.Error("Could not create session for user %+v: %v", profile, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Profile could not be stored"))
+		return
+	}
+
+	cookie := &http.Cookie{
+		Name:     OIDCAuthCookieName,
+		Value:    sessionID,
+		HttpOnly: true,
+		MaxAge:   0,
+		Path:     "/",
+		Secure:   a.Config.SecureCookie,
+	}
+	http.SetCookie(w, cookie)
+
+	http.Redirect(w, r, a.Config.LoginSuccess, http.StatusTemporaryRedirect)
+}
+
+// exchangeCode exchanges the authorization code for an ID token at the
+// token endpoint
+func (a *Authenticator) exchangeCode(provider providerConfig, code string, verifier string) (string, error) {
+	params := url.Values{}
+	params.Set("grant_type", "authorization_code")
+	params.Set("code", code)
+	params.Set("redirect_uri", a.Config.CallbackURL)
+	params.Set("code_verifier", verifier)
+
+	req, err := http.NewRequest(http.MethodPost, provider.TokenEndpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(a.Config.ClientID), url.QueryEscape(a.Config.ClientSecret))
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	tokenResponse := struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("malformed response from token endpoint (%s): %v", resp.Status, err)
+	}
+	if tokenResponse.Error != "" {
+		return "", fmt.Errorf("token endpoint returned %s: %s", tokenResponse.Error, tokenResponse.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+	if tokenResponse.IDToken == "" {
+		return "", errors.New("token endpoint did not return an ID token")
+	}
+	return tokenResponse.IDToken, nil
+}
+
+// startLogout removes the local session. If the provider supports
+// RP-initiated logout the client is redirected to the provider.
+func (a *Authenticator) startLogout(w http.ResponseWriter, r *http.Request) {
+	redirectURL := a.Config.LogoutSuccess
+	cookie, err := r.Cookie(OIDCAuthCookieName)
+	if err == nil {
+		session, err := a.sessions.GetSession(cookie.Value, time.Now().UnixNano())
+		if err == nil {
+			if err := a.sessions.RemoveSession(cookie.Value); err != nil {
+				logging.Warning("Couldn't remove session: %v", err)
+			}
+			a.mutex.Lock()
+			provider := a.provider
+			a.mutex.Unlock()
+			if provider != nil && provider.EndSessionEndpoint != "" {
+				params := url.Values{}
+				params.Set("id_token_hint", session.IDToken)
+				params.Set("client_id", a.Config.ClientID)
+				if strings.HasPrefix(a.Config.LogoutSuccess, "http") {
+					params.Set("post_logout_redirect_uri", a.Config.LogoutSuccess)
+				}
+				redirectURL = provider.EndSessionEndpoint + "?" + params.Encode()
+			}
+		}
+	}
+	removeCookie(w)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
@@ -0,0 +1,314 @@
+package oidclogin
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeProvider is an in-process OpenID Connect provider. It issues a code
+// immediately when the authorization endpoint is called and returns an ID
+// token from the token endpoint when the PKCE verifier matches.
+type fakeProvider struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+	secret   string
+	// claims modifies the claims before the token is signed
+	claims func(map[string]interface{})
+	codes  map[string]fakeCode
+}
+
+type fakeCode struct {
+	challenge string
+	nonce     string
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &fakeProvider{
+		key:      key,
+		clientID: "client",
+		secret:   "secret",
+		claims:   func(map[string]interface{}) {},
+		codes:    make(map[string]fakeCode),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *fakeProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"issuer":                 p.server.URL,
+		"authorization_endpoint": p.server.URL + "/authorize",
+		"token_endpoint":         p.server.URL + "/token",
+		"jwks_uri":               p.server.URL + "/jwks",
+		"end_session_endpoint":   p.server.URL + "/logout",
+	})
+}
+
+func (p *fakeProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *fakeProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != p.clientID || q.Get("code_challenge_method") != "S256" || q.Get("response_type") != "code" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	code := randomString()
+	p.codes[code] = fakeCode{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+	http.Redirect(w, r, q.Get("redirect_uri")+"?"+url.Values{"code": {code}, "state": {q.Get("state")}}.Encode(), http.StatusFound)
+}
+
+func (p *fakeProvider) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != p.clientID || secret != p.secret {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+	code, ok := p.codes[r.FormValue("code")]
+	delete(p.codes, r.FormValue("code"))
+	if !ok || codeChallenge(r.FormValue("code_verifier")) != code.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+	claims := map[string]interface{}{
+		"iss":                p.server.URL,
+		"aud":                p.clientID,
+		"sub":                "user-1",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              code.nonce,
+		"name":               "Some User",
+		"email":              "user@example.com",
+		"email_verified":     true,
+		"preferred_username": "someuser",
+	}
+	p.claims(claims)
+	json.NewEncoder(w).Encode(map[string]string{"id_token": p.sign("RS256", "key1", claims)})
+}
+
+func (p *fakeProvider) sign(alg string, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	body, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	digest := sha256.Sum256([]byte(signed))
+	sig, _ := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// login runs a login roundtrip and returns the last response
+func login(t *testing.T, p *fakeProvider, modify func(map[string]interface{})) (*http.Response, *Authenticator, *http.Client, *httptest.Server) {
+	ss, err := NewSQLSessionStore("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	config := Config{
+		Issuer:        p.server.URL,
+		ClientID:      p.clientID,
+		ClientSecret:  p.secret,
+		CallbackURL:   server.URL + "/oidc/callback",
+		Scopes:        "openid profile email",
+		LoginSuccess:  "/ok",
+		LogoutSuccess: "/bye",
+		SessionLength: time.Hour,
+		LoginClaim:    "preferred_username",
+		NameClaim:     "name",
+		EmailClaim:    "email",
+		AvatarClaim:   "picture",
+	}
+	auth, err := newAuthenticator(config, ss, &http.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux.Handle("/oidc/", auth.Handler())
+	mux.HandleFunc("/ok", auth.AuthHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		profile, err := auth.Profile(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		json.NewEncoder(w).Encode(profile)
+	}))
+
+	if modify != nil {
+		p.claims = modify
+	}
+	jar, _ := cookiejar.New(nil)
+	client := &http.Client{Jar: jar}
+	resp, err := client.Get(server.URL + "/oidc/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, auth, client, server
+}
+
+func TestLoginFlow(t *testing.T) {
+	p := newFakeProvider(t)
+	defer p.server.Close()
+
+	resp, _, client, server := login(t, p, nil)
+	defer server.Close()
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 OK after login but got %s", resp.Status)
+	}
+	profile := Profile{}
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		t.Fatal(err)
+	}
+	expected := Profile{Subject: "user-1", Login: "someuser", Name: "Some User", Email: "user@example.com", EmailVerified: true}
+	if profile != expected {
+		t.Fatalf("Unexpected profile: %+v", profile)
+	}
+
+	// Logging out redirects to the provider's end session endpoint and
+	// removes the session
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := client.Get(server.URL + "/oidc/logout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if !strings.HasPrefix(resp.Header.Get("Location"), p.server.URL+"/logout?") {
+		t.Fatalf("Expected redirect to end session endpoint but got %s", resp.Header.Get("Location"))
+	}
+	resp, err = client.Get(server.URL + "/ok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 after logout but got %s", resp.Status)
+	}
+}
+
+func TestLoginRejectsInvalidTokens(t *testing.T) {
+	p := newFakeProvider(t)
+	defer p.server.Close()
+
+	tests := map[string]func(map[string]interface{}){
+		"nonce":    func(c map[string]interface{}) { c["nonce"] = "other" },
+		"audience": func(c map[string]interface{}) { c["aud"] = "other" },
+		"issuer":   func(c map[string]interface{}) { c["iss"] = "https://example.com" },
+		"expired":  func(c map[string]interface{}) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"subject":  func(c map[string]interface{}) { delete(c, "sub") },
+	}
+	for name, modify := range tests {
+		resp, _, _, server := login(t, p, modify)
+		resp.Body.Close()
+		server.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("Expected 401 for invalid %s but got %s", name, resp.Status)
+		}
+	}
+}
+
+func TestUnknownState(t *testing.T) {
+	p := newFakeProvider(t)
+	defer p.server.Close()
+	resp, _, client, server := login(t, p, nil)
+	resp.Body.Close()
+	defer server.Close()
+
+	resp, err := client.Get(server.URL + "/oidc/callback?state=unknown&code=123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for unknown state but got %s", resp.Status)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	data := []byte("some data")
+	digest := sha256.Sum256(data)
+
+	rsaSig, _ := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	if err := verifySignature("RS256", &rsaKey.PublicKey, data, rsaSig); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifySignature("RS256", &rsaKey.PublicKey, []byte("other data"), rsaSig); err == nil {
+		t.Fatal("Expected error with modified data")
+	}
+	if err := verifySignature("none", &rsaKey.PublicKey, data, rsaSig); err == nil {
+		t.Fatal("Expected error with none algorithm")
+	}
+
+	r, s, _ := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+	ecSig := make([]byte, 64)
+	r.FillBytes(ecSig[:32])
+	s.FillBytes(ecSig[32:])
+	if err := verifySignature("ES256", &ecKey.PublicKey, data, ecSig); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifySignature("RS256", &ecKey.PublicKey, data, ecSig); err == nil {
+		t.Fatal("Expected error when algorithm doesn't match key")
+	}
+
+	jwk := jsonWebKey{
+		KeyType: "EC",
+		Curve:   "P-256",
+		X:       base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()),
+		Y:       base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()),
+	}
+	pub, err := jwk.publicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifySignature("ES256", pub, data, ecSig); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,38 @@
+package oidclogin
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import "time"
+
+// Config is the configuration for the OpenID Connect login
+type Config struct {
+	Enabled            bool          `param:"desc=Enable OpenID Connect login;default=false"`
+	Issuer             string        `param:"desc=Issuer URL for the OpenID Connect provider"`
+	ClientID           string        `param:"desc=OAuth client ID"`
+	ClientSecret       string        `param:"desc=OAuth client secret"`
+	CallbackURL        string        `param:"desc=Callback URL for client;default=http://localhost:8080/oidc/callback"`
+	Scopes             string        `param:"desc=Scopes to request;default=openid profile email"`
+	LoginSuccess       string        `param:"desc=Redirect after successful login;default=/"`
+	LogoutSuccess      string        `param:"desc=Redirect after logout;default=/"`
+	SecureCookie       bool          `param:"desc=Secure flag on cookie;default=false"`
+	SessionLength      time.Duration `param:"desc=Length of login sessions;default=12h"`
+	LoginClaim         string        `param:"desc=Claim used for the login name;default=preferred_username"`
+	NameClaim          string        `param:"desc=Claim used for the user's name;default=name"`
+	EmailClaim         string        `param:"desc=Claim used for the user's email address;default=email"`
+	AvatarClaim        string        `param:"desc=Claim used for the user's avatar URL;default=picture"`
+	DBDriver           string        `param:"desc=Database driver (postgres, sqlite3);default=sqlite3"`
+	DBConnectionString string        `param:"desc=Connection string for session store;default=:memory:"`
+}
@@ -0,0 +1,72 @@
+package oidclogin
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Profile is the user profile built from the claims in the ID token
+type Profile struct {
+	Subject       string `json:"sub"`
+	Login         string `json:"loginName"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	AvatarURL     string `json:"avatarUrl"`
+}
+
+// Scan implements the sql.Scanner interface
+func (p *Profile) Scan(src interface{}) error {
+	val, ok := src.([]byte)
+	if !ok {
+		return errors.New("cant scan anything but bytes")
+	}
+	return json.Unmarshal(val, p)
+}
+
+// Value implements the driver.Valuer interface
+func (p *Profile) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// profileFromClaims maps the claims to a profile. The subject is always
+// the sub claim, the other claims are configurable.
+func profileFromClaims(claims map[string]interface{}, config Config) Profile {
+	claimString := func(name string) string {
+		val, ok := claims[name].(string)
+		if !ok {
+			return ""
+		}
+		return val
+	}
+
+	ret := Profile{}
+	ret.Subject = claimString("sub")
+	ret.Login = claimString(config.LoginClaim)
+	ret.Name = claimString(config.NameClaim)
+	ret.Email = claimString(config.EmailClaim)
+	ret.AvatarURL = claimString(config.AvatarClaim)
+	if verified, ok := claims["email_verified"].(bool); ok {
+		ret.EmailVerified = verified
+	}
+	if ret.Login == "" {
+		ret.Login = ret.Email
+	}
+	return ret
+}
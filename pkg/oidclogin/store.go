@@ -0,0 +1,60 @@
+package oidclogin
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+
+// LoginState is the state kept between the start of the login and the
+// callback from the provider.
+type LoginState struct {
+	State        string
+	CodeVerifier string
+	Nonce        string
+}
+
+// Session is a single login session
+type Session struct {
+	Expires   int64
+	SessionID string
+	IDToken   string
+	Profile   Profile
+}
+
+// SessionStore stores the login states and the sessions. This follows the
+// session store in the GitHub login.
+type SessionStore interface {
+	// PutState inserts a new login state in the storage. The state will
+	// never expire.
+	PutState(state LoginState) error
+
+	// RemoveState removes a login state from the storage and returns it. An
+	// error is returned if the state does not exist.
+	RemoveState(state string) (LoginState, error)
+
+	// CreateSession creates a new session in the store. The expires
+	// parameter is the expire time (in ns) for the session.
+	CreateSession(sessionID string, idToken string, expires int64, profile Profile) error
+
+	// GetSession returns the session. The ignoreOlder parameter is the
+	// current time stamp.
+	GetSession(sessionID string, ignoreOlder int64) (Session, error)
+
+	// RemoveSession removes the session from the store
+	RemoveSession(sessionID string) error
+
+	// RemoveExpiredSessions removes sessions that expired before the time
+	// stamp.
+	RemoveExpiredSessions(time int64) error
+}
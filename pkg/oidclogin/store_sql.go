@@ -0,0 +1,205 @@
+package oidclogin
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"database/sql"
+	"errors"
+
+	// SQLite3 driver for testing, local instances and in-memory database
+	_ "github.com/mattn/go-sqlite3"
+	//PostgreSQL driver for production servers and Real Backends (tm)
+	_ "github.com/lib/pq"
+)
+
+// NewSQLSessionStore creates a sql-backed session store
+func NewSQLSessionStore(driver, connectionString string) (SessionStore, error) {
+	ret := sqlSessionStore{}
+
+	var err error
+	if ret.db, err = sql.Open(driver, connectionString); err != nil {
+		return nil, err
+	}
+	if err := ret.createSchema(); err != nil {
+		return nil, err
+	}
+	if err := ret.init(); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+type sqlSessionStore struct {
+	db              *sql.DB
+	createState     *sql.Stmt
+	retrieveState   *sql.Stmt
+	removeState     *sql.Stmt
+	createSession   *sql.Stmt
+	retrieveSession *sql.Stmt
+	removeSession   *sql.Stmt
+	removeExpired   *sql.Stmt
+}
+
+func (s *sqlSessionStore) createSchema() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS oidcstate (
+			state          VARCHAR(128)   NOT NULL,
+			code_verifier  VARCHAR(128)   NOT NULL,
+			nonce          VARCHAR(128)   NOT NULL,
+			CONSTRAINT oidcstate_pk PRIMARY KEY (state)
+		)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS oidcsession (
+			session_id     VARCHAR(32)   NOT NULL,
+			id_token       TEXT          NOT NULL,
+			expires        BIGINT        NOT NULL,
+			profile        JSON          NOT NULL,
+			CONSTRAINT oidcsession_pk PRIMARY KEY (session_id)
+		)`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE INDEX IF NOT EXISTS oidcsession_expires ON oidcsession(expires)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *sqlSessionStore) init() error {
+	var err error
+	if s.createState, err = s.db.Prepare(`
+		INSERT INTO oidcstate (state, code_verifier, nonce)
+			VALUES ($1, $2, $3)`); err != nil {
+		return err
+	}
+	if s.retrieveState, err = s.db.Prepare(`
+		SELECT state, code_verifier, nonce
+			FROM oidcstate
+			WHERE state = $1`); err != nil {
+		return err
+	}
+	if s.removeState, err = s.db.Prepare(`
+		DELETE FROM oidcstate
+			WHERE state = $1`); err != nil {
+		return err
+	}
+	if s.createSession, err = s.db.Prepare(`
+		INSERT INTO oidcsession (session_id, id_token, expires, profile)
+			VALUES ($1, $2, $3, $4)`); err != nil {
+		return err
+	}
+	if s.retrieveSession, err = s.db.Prepare(`
+		SELECT session_id, id_token, expires, profile
+			FROM oidcsession
+			WHERE session_id = $1 AND expires > $2`); err != nil {
+		return err
+	}
+	if s.removeSession, err = s.db.Prepare(`
+		DELETE FROM oidcsession
+			WHERE session_id = $1`); err != nil {
+		return err
+	}
+	if s.removeExpired, err = s.db.Prepare(`
+		DELETE FROM oidcsession
+			WHERE expires < $1`); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *sqlSessionStore) PutState(state LoginState) error {
+	rows, err := s.createState.Exec(state.State, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return err
+	}
+	if count, err := rows.RowsAffected(); err != nil || count == 0 {
+		if err != nil {
+			return err
+		}
+		return errors.New("state not stored")
+	}
+	return nil
+}
+
+func (s *sqlSessionStore) RemoveState(state string) (LoginState, error) {
+	ret := LoginState{}
+	if err := s.retrieveState.QueryRow(state).Scan(&ret.State, &ret.CodeVerifier, &ret.Nonce); err != nil {
+		if err == sql.ErrNoRows {
+			return ret, errors.New("state not found")
+		}
+		return ret, err
+	}
+	rows, err := s.removeState.Exec(state)
+	if err != nil {
+		return ret, err
+	}
+	count, err := rows.RowsAffected()
+	if err != nil {
+		return ret, err
+	}
+	if count == 0 {
+		// Another request consumed the state before us
+		return ret, errors.New("state not found")
+	}
+	return ret, nil
+}
+
+func (s *sqlSessionStore) CreateSession(sessionID string, idToken string, expires int64, profile Profile) error {
+	rows, err := s.createSession.Exec(&sessionID, &idToken, &expires, &profile)
+	if err != nil {
+		return err
+	}
+	count, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("no session created")
+	}
+	return nil
+}
+
+func (s *sqlSessionStore) GetSession(sessionID string, ignoreOlder int64) (Session, error) {
+	ret := Session{}
+	return ret, s.retrieveSession.QueryRow(sessionID, ignoreOlder).Scan(&ret.SessionID, &ret.IDToken, &ret.Expires, &ret.Profile)
+}
+
+func (s *sqlSessionStore) RemoveSession(sessionID string) error {
+	res, err := s.removeSession.Exec(sessionID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("session not found")
+	}
+	return nil
+}
+
+func (s *sqlSessionStore) RemoveExpiredSessions(time int64) error {
+	_, err := s.removeExpired.Exec(time)
+	return err
+}
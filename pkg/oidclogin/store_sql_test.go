@@ -0,0 +1,82 @@
+package oidclogin
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import "testing"
+
+func TestSQLSessionStore(t *testing.T) {
+	ss, err := NewSQLSessionStore("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ss.PutState(LoginState{State: "s1", CodeVerifier: "v1", Nonce: "n1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.PutState(LoginState{State: "s1", CodeVerifier: "v2", Nonce: "n2"}); err == nil {
+		t.Fatal("Should not be able to create the same state twice")
+	}
+	if _, err := ss.RemoveState("s2"); err == nil {
+		t.Fatal("Invalid state should yield error")
+	}
+	state, err := ss.RemoveState("s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.CodeVerifier != "v1" || state.Nonce != "n1" {
+		t.Fatalf("Unexpected state: %+v", state)
+	}
+	if _, err := ss.RemoveState("s1"); err == nil {
+		t.Fatal("Should not be able to remove state twice")
+	}
+
+	if err := ss.CreateSession("1", "token1", 10, Profile{Subject: "sub1", Login: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.CreateSession("2", "token2", 20, Profile{Subject: "sub2", Login: "u2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.CreateSession("1", "token3", 10, Profile{Subject: "sub3"}); err == nil {
+		t.Fatal("Should have unique session IDs")
+	}
+
+	sess, err := ss.GetSession("1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sess.IDToken != "token1" || sess.Profile.Subject != "sub1" || sess.Profile.Login != "u1" {
+		t.Fatalf("Unexpected session: %+v", sess)
+	}
+	if _, err := ss.GetSession("1", 10); err == nil {
+		t.Fatal("Should not retrieve expired sessions")
+	}
+	if _, err := ss.GetSession("99", 0); err == nil {
+		t.Fatal("Unknown session should be unknown")
+	}
+
+	if err := ss.RemoveExpiredSessions(15); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.RemoveSession("1"); err == nil {
+		t.Fatal("Expired session should be removed")
+	}
+	if err := ss.RemoveSession("2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.RemoveSession("2"); err == nil {
+		t.Fatal("Session should not exist")
+	}
+}
@@ -0,0 +1,543 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputcluster"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/eesrc/horde/pkg/utils/grpcutil"
+	"google.golang.org/grpc"
+)
+
+// ClusterParameters is the configuration for outputs running in a cluster
+// of core instances.
+type ClusterParameters struct {
+	Enabled bool `param:"desc=Run outputs in a cluster of core instances;default=false"`
+	// NodeID is the node's identifier in the cluster. The gRPC endpoint is
+	// used when the ID isn't set.
+	NodeID string `param:"desc=Node ID in cluster (defaults to the gRPC endpoint)"`
+	// LeaseTime is the lease time for nodes and outputs. Nodes that haven't
+	// renewed their leases within the lease time are considered dead and
+	// their outputs are moved to other nodes.
+	LeaseTime time.Duration `param:"desc=Lease time for nodes and outputs;default=15s"`
+	// Secret is the shared secret for the cluster service. Every node in the
+	// cluster must use the same secret. TLS should be enabled for the
+	// service since the secret is sent with every request.
+	Secret string `param:"desc=Shared secret for the cluster service"`
+	// GRPC is the endpoint for the cluster service. The endpoint must be
+	// reachable from the other nodes in the cluster.
+	GRPC grpcutil.GRPCServerParam
+}
+
+// OutputLister returns all of the outputs in the backend store. The cluster
+// manager uses the list to pick up outputs changed through other nodes.
+type OutputLister func() ([]model.Output, error)
+
+// publishQueueLength is the length of the queue for messages forwarded to
+// other nodes.
+const publishQueueLength = 1000
+
+// clusterRequestTimeout is the timeout for requests to other nodes
+const clusterRequestTimeout = 2 * time.Second
+
+// clusterManager runs the outputs across several core instances. Every
+// output is assigned to a single node through a lease in the lease store.
+// Unassigned outputs are picked up by the node with the highest rendezvous
+// hash for the output so the nodes agree on the assignment without any
+// coordination. Leases are sticky, ie outputs stay on the node until the
+// node stops or dies. Messages are published on the local node and
+// forwarded to the nodes that own outputs for the message's collection.
+// Outputs are stopped if the node can't renew the leases before they expire
+// since another node might take over the outputs.
+type clusterManager struct {
+	params          ClusterParameters
+	nodeID          string
+	endpoint        string
+	local           *localManager
+	leases          storage.LeaseStore
+	lister          OutputLister
+	server          grpcutil.GRPCServer
+	mutex           *sync.Mutex
+	systemFieldMask model.FieldMask
+	outputs         map[model.OutputKey]model.Output
+	owners          map[model.OutputKey]string
+	owned           map[model.OutputKey]time.Time // Lease expiry for outputs owned by the node
+	nodes           map[string]*clusterNode
+	liveNodes       []string
+	routes          map[model.CollectionKey][]*clusterNode
+	stop            chan struct{}
+	done            chan struct{}
+}
+
+// NewClusterManager creates a manager that runs outputs in a cluster. The
+// gRPC service for the cluster is launched and the node registers in the
+// lease store before it returns.
+func NewClusterManager(params ClusterParameters, leases storage.LeaseStore, lister OutputLister) (Manager, error) {
+	if params.LeaseTime <= 0 {
+		return nil, errors.New("lease time must be greater than zero")
+	}
+	if params.Secret == "" {
+		return nil, errors.New("the cluster secret must be set")
+	}
+	listOutputTypes()
+	ret := &clusterManager{
+		params:  params,
+		local:   newLocalManager(),
+		leases:  leases,
+		lister:  lister,
+		mutex:   &sync.Mutex{},
+		outputs: make(map[model.OutputKey]model.Output),
+		owners:  make(map[model.OutputKey]string),
+		owned:   make(map[model.OutputKey]time.Time),
+		nodes:   make(map[string]*clusterNode),
+		routes:  make(map[model.CollectionKey][]*clusterNode),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	var err error
+	ret.server, err = grpcutil.NewGRPCServer(params.GRPC, grpc.UnaryInterceptor(clusterAuthInterceptor(params.Secret)))
+	if err != nil {
+		return nil, err
+	}
+	if err := ret.server.Launch(func(s *grpc.Server) {
+		outputcluster.RegisterOutputClusterServer(s, &clusterServer{mgr: ret})
+	}, 250*time.Millisecond); err != nil {
+		return nil, err
+	}
+	ret.endpoint = ret.server.Endpoint()
+	ret.nodeID = params.NodeID
+	if ret.nodeID == "" {
+		ret.nodeID = ret.endpoint
+	}
+	if err := ret.heartbeat(time.Now()); err != nil {
+		ret.server.Stop()
+		return nil, err
+	}
+	logging.Info("Output cluster node %s running on %s", ret.nodeID, ret.endpoint)
+	go ret.leaseLoop()
+	return ret, nil
+}
+
+// leaseLoop renews the leases at regular intervals. The interval is a third
+// of the lease time so a single failed renewal won't expire the leases.
+func (c *clusterManager) leaseLoop() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.params.LeaseTime / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			if c.lister != nil {
+				outputs, err := c.lister()
+				if err != nil {
+					logging.Warning("Unable to list outputs: %v", err)
+				} else {
+					c.mutex.Lock()
+					c.setOutputs(outputs)
+					c.mutex.Unlock()
+				}
+			}
+			c.reconcile()
+		}
+	}
+}
+
+func (c *clusterManager) heartbeat(now time.Time) error {
+	return c.leases.Heartbeat(storage.OutputNode{
+		NodeID:   c.nodeID,
+		Endpoint: c.endpoint,
+		Expires:  now.Add(c.params.LeaseTime),
+	})
+}
+
+// setOutputs replaces the list of known outputs. The mutex must be held
+// when this method is called.
+func (c *clusterManager) setOutputs(outputs []model.Output) {
+	c.outputs = make(map[model.OutputKey]model.Output)
+	for _, v := range outputs {
+		c.outputs[v.ID] = v
+	}
+}
+
+// preferredNode returns the node with the highest rendezvous hash for the
+// output.
+func preferredNode(nodes []string, outputID model.OutputKey) string {
+	ret := ""
+	max := uint64(0)
+	for _, v := range nodes {
+		h := fnv.New64a()
+		h.Write([]byte(v))
+		h.Write([]byte(outputID.String()))
+		if score := h.Sum64(); ret == "" || score > max {
+			ret = v
+			max = score
+		}
+	}
+	return ret
+}
+
+// reconcile renews the node's heartbeat and leases, acquires unassigned
+// outputs and stops outputs where the lease is lost.
+func (c *clusterManager) reconcile() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	if err := c.heartbeat(now); err != nil {
+		logging.Warning("Unable to renew lease for node %s: %v", c.nodeID, err)
+	}
+	nodes, err := c.leases.Nodes(now)
+	if err != nil {
+		logging.Warning("Unable to list cluster nodes: %v", err)
+		c.stopExpiring(now)
+		return
+	}
+	leases, err := c.leases.Leases(now)
+	if err != nil {
+		logging.Warning("Unable to list output leases: %v", err)
+		c.stopExpiring(now)
+		return
+	}
+	c.updateNodes(nodes)
+
+	owners := make(map[model.OutputKey]string)
+	for _, v := range leases {
+		owners[v.OutputID] = v.NodeID
+	}
+	var owned []model.Output
+	for id, op := range c.outputs {
+		owner, held := owners[id]
+		if held && owner != c.nodeID {
+			continue
+		}
+		if !held && preferredNode(c.liveNodes, id) != c.nodeID {
+			continue
+		}
+		acquired, err := c.leases.AcquireLease(id, c.nodeID, now.Add(c.params.LeaseTime), now)
+		if err != nil {
+			logging.Warning("Unable to acquire lease for output %s: %v", id.String(), err)
+			continue
+		}
+		if !acquired {
+			delete(owners, id)
+			continue
+		}
+		owners[id] = c.nodeID
+		owned = append(owned, op)
+	}
+
+	// Stop the outputs that are removed or where another node has taken over
+	for id := range c.owned {
+		if owners[id] == c.nodeID {
+			continue
+		}
+		if _, exists := c.outputs[id]; !exists {
+			c.leases.ReleaseLease(id, c.nodeID)
+		} else {
+			logging.Warning("Lost lease for output %s", id.String())
+		}
+		c.local.Stop(id)
+		delete(c.owned, id)
+	}
+	for _, v := range owned {
+		c.owned[v.ID] = now.Add(c.params.LeaseTime)
+	}
+	c.local.Refresh(owned, c.systemFieldMask)
+	c.owners = owners
+	c.stopExpiring(now)
+}
+
+// stopExpiring stops the outputs where the lease expires before the next
+// renewal. The output might be picked up by another node as soon as the
+// lease expires so the output is stopped even if the node is unable to
+// verify the lease. The mutex must be held when this method is called.
+func (c *clusterManager) stopExpiring(now time.Time) {
+	nextRenewal := now.Add(c.params.LeaseTime / 3)
+	for id, expires := range c.owned {
+		if expires.After(nextRenewal) {
+			continue
+		}
+		logging.Warning("Unable to renew lease for output %s. Stopping output", id.String())
+		c.local.Stop(id)
+		delete(c.owned, id)
+		if c.owners[id] == c.nodeID {
+			delete(c.owners, id)
+		}
+	}
+	c.updateRoutes()
+}
+
+// updateNodes keeps a client for every live node in the cluster. The mutex
+// must be held when this method is called.
+func (c *clusterManager) updateNodes(nodes []storage.OutputNode) {
+	live := make(map[string]bool)
+	c.liveNodes = nil
+	for _, v := range nodes {
+		live[v.NodeID] = true
+		c.liveNodes = append(c.liveNodes, v.NodeID)
+		if v.NodeID == c.nodeID {
+			continue
+		}
+		if existing, ok := c.nodes[v.NodeID]; ok {
+			if existing.endpoint == v.Endpoint {
+				continue
+			}
+			existing.close()
+		}
+		node, err := newClusterNode(v.NodeID, v.Endpoint, c.params)
+		if err != nil {
+			logging.Warning("Unable to connect to node %s on %s: %v", v.NodeID, v.Endpoint, err)
+			delete(c.nodes, v.NodeID)
+			continue
+		}
+		c.nodes[v.NodeID] = node
+	}
+	if !live[c.nodeID] {
+		c.liveNodes = append(c.liveNodes, c.nodeID)
+	}
+	for id, node := range c.nodes {
+		if !live[id] {
+			node.close()
+			delete(c.nodes, id)
+		}
+	}
+}
+
+// updateRoutes builds the list of nodes that should receive messages for
+// each collection. The mutex must be held when this method is called.
+func (c *clusterManager) updateRoutes() {
+	routes := make(map[model.CollectionKey][]*clusterNode)
+	added := make(map[model.CollectionKey]map[string]bool)
+	for id, owner := range c.owners {
+		op, exists := c.outputs[id]
+		if !exists || !op.Enabled || owner == c.nodeID {
+			continue
+		}
+		node, ok := c.nodes[owner]
+		if !ok {
+			continue
+		}
+		if added[op.CollectionID] == nil {
+			added[op.CollectionID] = make(map[string]bool)
+		}
+		if added[op.CollectionID][owner] {
+			continue
+		}
+		added[op.CollectionID][owner] = true
+		routes[op.CollectionID] = append(routes[op.CollectionID], node)
+	}
+	c.routes = routes
+}
+
+// target returns the node that should run the output, ie the owner or the
+// preferred node if the output isn't assigned to a node.
+func (c *clusterManager) target(id model.OutputKey) (string, *clusterNode) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	owner, exists := c.owners[id]
+	if !exists {
+		owner = preferredNode(c.liveNodes, id)
+	}
+	if owner == c.nodeID || owner == "" {
+		return c.nodeID, nil
+	}
+	return owner, c.nodes[owner]
+}
+
+func (c *clusterManager) Verify(output model.Output) (model.ErrorMessage, error) {
+	return c.local.Verify(output)
+}
+
+func (c *clusterManager) Refresh(outputs []model.Output, systemFieldMask model.FieldMask) {
+	c.mutex.Lock()
+	c.systemFieldMask = systemFieldMask
+	c.setOutputs(outputs)
+	c.mutex.Unlock()
+	c.reconcile()
+}
+
+func (c *clusterManager) Update(output model.Output, systemFieldMask model.FieldMask) error {
+	c.mutex.Lock()
+	c.outputs[output.ID] = output
+	c.systemFieldMask = systemFieldMask
+	c.mutex.Unlock()
+
+	nodeID, node := c.target(output.ID)
+	if nodeID == c.nodeID {
+		return c.updateOwned(output, systemFieldMask)
+	}
+	if node == nil {
+		return errors.New("unknown node")
+	}
+	return node.update(output, systemFieldMask)
+}
+
+// updateOwned applies the configuration to an output that runs on this
+// node. The lease is acquired if the output isn't running.
+func (c *clusterManager) updateOwned(output model.Output, systemFieldMask model.FieldMask) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.outputs[output.ID] = output
+	c.systemFieldMask = systemFieldMask
+
+	now := time.Now()
+	acquired, err := c.leases.AcquireLease(output.ID, c.nodeID, now.Add(c.params.LeaseTime), now)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return errors.New("output is owned by another node")
+	}
+	c.owners[output.ID] = c.nodeID
+	c.owned[output.ID] = now.Add(c.params.LeaseTime)
+	err = c.local.Update(output, systemFieldMask)
+	c.updateRoutes()
+	return err
+}
+
+func (c *clusterManager) Stop(key model.OutputKey) error {
+	c.mutex.Lock()
+	delete(c.outputs, key)
+	c.mutex.Unlock()
+
+	nodeID, node := c.target(key)
+	if nodeID == c.nodeID {
+		return c.stopOwned(key)
+	}
+	if node == nil {
+		return errors.New("unknown node")
+	}
+	return node.stop(key)
+}
+
+// stopOwned stops an output running on this node and releases the lease.
+func (c *clusterManager) stopOwned(key model.OutputKey) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.outputs, key)
+	if c.owners[key] == c.nodeID {
+		delete(c.owners, key)
+	}
+	delete(c.owned, key)
+	if err := c.leases.ReleaseLease(key, c.nodeID); err != nil && err != storage.ErrNotFound {
+		logging.Warning("Unable to release lease for output %s: %v", key.String(), err)
+	}
+	c.updateRoutes()
+	return c.local.Stop(key)
+}
+
+// halt stops the lease renewal and the cluster service
+func (c *clusterManager) halt() {
+	close(c.stop)
+	<-c.done
+	c.server.Stop()
+}
+
+func (c *clusterManager) Shutdown() {
+	c.halt()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.local.Shutdown()
+	if err := c.leases.RemoveNode(c.nodeID); err != nil {
+		logging.Warning("Unable to remove node %s from cluster: %v", c.nodeID, err)
+	}
+	for id, node := range c.nodes {
+		node.close()
+		delete(c.nodes, id)
+	}
+	c.owned = make(map[model.OutputKey]time.Time)
+	c.owners = make(map[model.OutputKey]string)
+	c.routes = make(map[model.CollectionKey][]*clusterNode)
+}
+
+func (c *clusterManager) Get(key model.OutputKey) (Output, error) {
+	nodeID, node := c.target(key)
+	if nodeID == c.nodeID {
+		return c.local.Get(key)
+	}
+	if node == nil {
+		return nil, errors.New("unknown node")
+	}
+	return node.outputInfo(key)
+}
+
+func (c *clusterManager) Publish(msg model.DataMessage) {
+	c.local.Publish(msg)
+
+	c.mutex.Lock()
+	nodes := c.routes[msg.Device.CollectionID]
+	c.mutex.Unlock()
+	if len(nodes) == 0 {
+		return
+	}
+	req := &outputcluster.PublishRequest{Message: newClusterDataMessage(msg)}
+	for _, v := range nodes {
+		v.publish(req)
+	}
+}
+
+func (c *clusterManager) PublishEvent(ev model.ResourceEvent) {
+	c.local.PublishEvent(ev)
+
+	// Forward the event once to every node with outputs for one of the
+	// collections.
+	c.mutex.Lock()
+	var nodes []*clusterNode
+	seen := make(map[*clusterNode]bool)
+	for _, topic := range eventTopics(ev) {
+		for _, v := range c.routes[topic] {
+			if !seen[v] {
+				seen[v] = true
+				nodes = append(nodes, v)
+			}
+		}
+	}
+	c.mutex.Unlock()
+	if len(nodes) == 0 {
+		return
+	}
+	event, err := newClusterResourceEvent(ev)
+	if err != nil {
+		logging.Warning("Unable to encode event for cluster: %v", err)
+		return
+	}
+	req := &outputcluster.PublishRequest{Event: event}
+	for _, v := range nodes {
+		v.publish(req)
+	}
+}
+
+func (c *clusterManager) Subscribe(collectionID model.CollectionKey) <-chan interface{} {
+	return c.local.Subscribe(collectionID)
+}
+
+func (c *clusterManager) Unsubscribe(ch <-chan interface{}) {
+	c.local.Unsubscribe(ch)
+}
+
+func (c *clusterManager) Test(output model.Output, systemFieldMask model.FieldMask, msg model.DataMessage) (model.OutputTestResult, error) {
+	return c.local.Test(output, systemFieldMask, msg)
+}
@@ -0,0 +1,350 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputcluster"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testLeaseTime     = 600 * time.Millisecond
+	testClusterSecret = "cluster secret"
+)
+
+func newTestLeaseStore(t *testing.T) (storage.LeaseStore, func()) {
+	dir, err := ioutil.TempDir("", "leases")
+	require.NoError(t, err)
+	store, err := sqlstore.NewLeaseStore(sqlstore.Parameters{
+		Type:             "sqlite3",
+		ConnectionString: filepath.Join(dir, "leases.db"),
+		CreateSchema:     true,
+	})
+	require.NoError(t, err)
+	return store, func() { os.RemoveAll(dir) }
+}
+
+func newTestClusterManager(t *testing.T, nodeID string, leases storage.LeaseStore, lister OutputLister) *clusterManager {
+	mgr, err := NewClusterManager(ClusterParameters{
+		Enabled:   true,
+		NodeID:    nodeID,
+		LeaseTime: testLeaseTime,
+		Secret:    testClusterSecret,
+	}, leases, lister)
+	require.NoError(t, err)
+	return mgr.(*clusterManager)
+}
+
+func TestClusterManager(t *testing.T) {
+	leases, cleanup := newTestLeaseStore(t)
+	defer cleanup()
+	managerTest(t, newTestClusterManager(t, "single", leases, nil))
+}
+
+// waitForLeases waits until every output has a lease held by one of the nodes
+func waitForLeases(t *testing.T, leases storage.LeaseStore, outputs []model.Output, nodes ...string) map[model.OutputKey]string {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		list, err := leases.Leases(time.Now())
+		require.NoError(t, err)
+		ret := make(map[model.OutputKey]string)
+		for _, v := range list {
+			for _, n := range nodes {
+				if v.NodeID == n {
+					ret[v.OutputID] = v.NodeID
+				}
+			}
+		}
+		if len(ret) == len(outputs) {
+			return ret
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Outputs weren't assigned to nodes %v", nodes)
+	return nil
+}
+
+// waitForDelivery waits until every output has received the expected number
+// of messages.
+func waitForDelivery(counter *payloadCounter, outputs []model.Output, expected int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		counter.mutex.Lock()
+		done := true
+		for i := range outputs {
+			if counter.servers[fmt.Sprintf("output-%d", i)] < expected {
+				done = false
+			}
+		}
+		counter.mutex.Unlock()
+		if done {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClusterManagerFailover(t *testing.T) {
+	assert := require.New(t)
+	DisableLocalhostChecks()
+
+	leases, cleanup := newTestLeaseStore(t)
+	defer cleanup()
+
+	counter := &payloadCounter{mutex: &sync.Mutex{}, payloads: make(map[string]int), servers: make(map[string]int)}
+	ms := sqlstore.NewMemoryStore()
+	collectionID := ms.NewCollectionID()
+
+	const outputCount = 8
+	outputs := make([]model.Output, outputCount)
+	for i := range outputs {
+		server := httptest.NewServer(counter.handler(fmt.Sprintf("output-%d", i)))
+		defer server.Close()
+		outputs[i] = model.NewOutput()
+		outputs[i].ID = ms.NewOutputID()
+		outputs[i].CollectionID = collectionID
+		outputs[i].Type = "webhook"
+		outputs[i].Enabled = true
+		outputs[i].Config = model.OutputConfig{outputconfig.WebhookURLField: server.URL}
+	}
+	lister := func() ([]model.Output, error) {
+		return outputs, nil
+	}
+
+	nodeA := newTestClusterManager(t, "a", leases, lister)
+	defer nodeA.Shutdown()
+	nodeB := newTestClusterManager(t, "b", leases, lister)
+
+	nodeA.Refresh(outputs, 0)
+	nodeB.Refresh(outputs, 0)
+	owners := waitForLeases(t, leases, outputs, "a", "b")
+	// Wait for the nodes to pick up each other's leases
+	time.Sleep(testLeaseTime)
+
+	device := model.NewDevice()
+	device.CollectionID = collectionID
+
+	const msgCount = 10
+	for i := 0; i < msgCount; i++ {
+		msg := model.NewDataMessage(device, []byte(fmt.Sprintf("msg-%d", i)), model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{})
+		if i%2 == 0 {
+			nodeA.Publish(msg)
+		} else {
+			nodeB.Publish(msg)
+		}
+	}
+	waitForDelivery(counter, outputs, msgCount)
+	// Give the outputs time to deliver duplicates if there are any
+	time.Sleep(100 * time.Millisecond)
+
+	counter.mutex.Lock()
+	assert.Len(counter.payloads, msgCount)
+	for _, n := range counter.payloads {
+		assert.Equal(outputCount, n, "Messages should be delivered once per output")
+	}
+	for i := range outputs {
+		assert.Equal(msgCount, counter.servers[fmt.Sprintf("output-%d", i)])
+	}
+	counter.mutex.Unlock()
+
+	// The logs and status are available from both nodes
+	for _, op := range outputs {
+		for _, node := range []*clusterManager{nodeA, nodeB} {
+			o, err := node.Get(op.ID)
+			assert.NoError(err)
+			assert.NotEmpty(o.Logs())
+			assert.Equal("Output started", o.Logs()[0].Message)
+			assert.Equal(msgCount, o.Status().Received, "Output %s on node %s", op.ID, owners[op.ID])
+		}
+	}
+
+	// Halt node B without releasing the leases. Node A should take over the
+	// outputs when the leases expire.
+	nodeB.halt()
+	nodeB.local.Shutdown()
+	waitForLeases(t, leases, outputs, "a")
+	time.Sleep(testLeaseTime / 2)
+
+	for i := 0; i < msgCount; i++ {
+		nodeA.Publish(model.NewDataMessage(device, []byte(fmt.Sprintf("failover-%d", i)), model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{}))
+	}
+	waitForDelivery(counter, outputs, 2*msgCount)
+	time.Sleep(100 * time.Millisecond)
+
+	counter.mutex.Lock()
+	assert.Len(counter.payloads, 2*msgCount)
+	for _, n := range counter.payloads {
+		assert.Equal(outputCount, n, "Messages should be delivered once per output")
+	}
+	counter.mutex.Unlock()
+}
+
+// failingLeaseStore is a lease store that can be cut off from the backend
+type failingLeaseStore struct {
+	storage.LeaseStore
+	mutex   *sync.Mutex
+	failing bool
+}
+
+var errLeaseStoreUnavailable = errors.New("lease store unavailable")
+
+func (f *failingLeaseStore) setFailing(failing bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.failing = failing
+}
+
+func (f *failingLeaseStore) isFailing() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.failing
+}
+
+func (f *failingLeaseStore) Heartbeat(node storage.OutputNode) error {
+	if f.isFailing() {
+		return errLeaseStoreUnavailable
+	}
+	return f.LeaseStore.Heartbeat(node)
+}
+
+func (f *failingLeaseStore) Nodes(now time.Time) ([]storage.OutputNode, error) {
+	if f.isFailing() {
+		return nil, errLeaseStoreUnavailable
+	}
+	return f.LeaseStore.Nodes(now)
+}
+
+func (f *failingLeaseStore) AcquireLease(outputID model.OutputKey, nodeID string, expires time.Time, now time.Time) (bool, error) {
+	if f.isFailing() {
+		return false, errLeaseStoreUnavailable
+	}
+	return f.LeaseStore.AcquireLease(outputID, nodeID, expires, now)
+}
+
+func (f *failingLeaseStore) Leases(now time.Time) ([]storage.OutputLease, error) {
+	if f.isFailing() {
+		return nil, errLeaseStoreUnavailable
+	}
+	return f.LeaseStore.Leases(now)
+}
+
+// Outputs must be stopped before the leases expire when the node can't
+// reach the lease store since another node will take over the outputs.
+func TestClusterManagerLeaseStoreFailure(t *testing.T) {
+	assert := require.New(t)
+
+	store, cleanup := newTestLeaseStore(t)
+	defer cleanup()
+	leases := &failingLeaseStore{LeaseStore: store, mutex: &sync.Mutex{}}
+
+	ms := sqlstore.NewMemoryStore()
+	output := model.NewOutput()
+	output.ID = ms.NewOutputID()
+	output.CollectionID = ms.NewCollectionID()
+	output.Type = "udp"
+	output.Enabled = true
+	output.Config = model.OutputConfig{outputconfig.UDPHost: "127.0.0.1", outputconfig.UDPPort: float64(4711)}
+	outputs := []model.Output{output}
+
+	node := newTestClusterManager(t, "a", leases, func() ([]model.Output, error) { return outputs, nil })
+	defer node.Shutdown()
+	node.Refresh(outputs, 0)
+	waitForLeases(t, leases, outputs, "a")
+
+	isRunning := func() bool {
+		_, err := node.local.Get(output.ID)
+		return err == nil
+	}
+	waitFor := func(running bool, timeout time.Duration) bool {
+		deadline := time.Now().Add(timeout)
+		for time.Now().Before(deadline) {
+			if isRunning() == running {
+				return true
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		return false
+	}
+	assert.True(waitFor(true, testLeaseTime))
+
+	// The lease was renewed at the latest when the store failed so the
+	// output must stop within the lease time.
+	leases.setFailing(true)
+	assert.True(waitFor(false, testLeaseTime), "Output should stop before the lease expires")
+
+	// The output is started again when the lease store is available
+	leases.setFailing(false)
+	assert.True(waitFor(true, 2*testLeaseTime), "Output should start when the lease is acquired")
+}
+
+// The cluster service requires the cluster secret
+func TestClusterServiceSecret(t *testing.T) {
+	assert := require.New(t)
+
+	leases, cleanup := newTestLeaseStore(t)
+	defer cleanup()
+
+	_, err := NewClusterManager(ClusterParameters{Enabled: true, LeaseTime: testLeaseTime}, leases, nil)
+	assert.Error(err, "Cluster secret is required")
+
+	node := newTestClusterManager(t, "a", leases, nil)
+	defer node.Shutdown()
+
+	stop := func(opts ...grpc.DialOption) error {
+		conn, err := grpc.Dial(node.endpoint, append(opts, grpc.WithInsecure())...)
+		assert.NoError(err)
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err = outputcluster.NewOutputClusterClient(conn).Stop(ctx, &outputcluster.OutputRequest{OutputId: 1})
+		return err
+	}
+	assert.Equal(codes.Unauthenticated, status.Code(stop()))
+	assert.Equal(codes.Unauthenticated, status.Code(stop(grpc.WithPerRPCCredentials(clusterCredentials("wrong")))))
+	// The output isn't running on the node
+	assert.Equal(codes.NotFound, status.Code(stop(grpc.WithPerRPCCredentials(clusterCredentials(testClusterSecret)))))
+}
+
+// Messages for other nodes are counted when they are dropped
+func TestClusterNodeDroppedMessages(t *testing.T) {
+	node := &clusterNode{
+		nodeID:   "full",
+		mutex:    &sync.Mutex{},
+		messages: make(chan *outputcluster.PublishRequest, 1),
+	}
+	for i := 0; i < 3; i++ {
+		node.publish(&outputcluster.PublishRequest{})
+	}
+	require.Equal(t, 2, node.droppedMessages())
+}
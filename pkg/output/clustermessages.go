@@ -0,0 +1,335 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"fmt"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputcluster"
+)
+
+// This file contains the conversions between the model and the messages for
+// the output cluster service.
+
+func timeToNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func nanosToTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+func newTagsFromCluster(tags map[string]string) model.Tags {
+	ret := model.NewTags()
+	for k, v := range tags {
+		ret.TagMap[k] = v
+	}
+	return ret
+}
+
+func newClusterMaintenanceWindow(w model.MaintenanceWindow) *outputcluster.MaintenanceWindow {
+	return &outputcluster.MaintenanceWindow{
+		Schedule: w.Schedule,
+		Duration: int64(w.Duration),
+		TimeZone: w.TimeZone,
+	}
+}
+
+func newMaintenanceWindowFromCluster(w *outputcluster.MaintenanceWindow) model.MaintenanceWindow {
+	if w == nil {
+		return model.MaintenanceWindow{}
+	}
+	return model.MaintenanceWindow{
+		Schedule: w.Schedule,
+		Duration: time.Duration(w.Duration),
+		TimeZone: w.TimeZone,
+	}
+}
+
+func newClusterDevice(d model.Device) *outputcluster.Device {
+	return &outputcluster.Device{
+		Id:           uint64(d.ID),
+		Imsi:         d.IMSI,
+		Imei:         d.IMEI,
+		CollectionId: uint64(d.CollectionID),
+		Network: &outputcluster.NetworkMetadata{
+			AllocatedIp: d.Network.AllocatedIP,
+			AllocatedAt: timeToNanos(d.Network.AllocatedAt),
+			CellId:      d.Network.CellID,
+			ApnId:       int32(d.Network.ApnID),
+			NasId:       int32(d.Network.NasID),
+		},
+		Firmware: &outputcluster.DeviceFirmware{
+			CurrentFirmwareId: uint64(d.Firmware.CurrentFirmwareID),
+			TargetFirmwareId:  uint64(d.Firmware.TargetFirmwareID),
+			FirmwareVersion:   d.Firmware.FirmwareVersion,
+			SerialNumber:      d.Firmware.SerialNumber,
+			ModelNumber:       d.Firmware.ModelNumber,
+			Manufacturer:      d.Firmware.Manufacturer,
+			State:             int32(d.Firmware.State),
+			StateMessage:      d.Firmware.StateMessage,
+			MaintenanceWindow: newClusterMaintenanceWindow(d.Firmware.MaintenanceWindow),
+		},
+		Tags: d.TagMap,
+	}
+}
+
+func newDeviceFromCluster(d *outputcluster.Device) model.Device {
+	ret := model.NewDevice()
+	if d == nil {
+		return ret
+	}
+	ret.ID = model.DeviceKey(d.Id)
+	ret.IMSI = d.Imsi
+	ret.IMEI = d.Imei
+	ret.CollectionID = model.CollectionKey(d.CollectionId)
+	if d.Network != nil {
+		ret.Network = model.DeviceNetworkMetadata{
+			AllocatedIP: d.Network.AllocatedIp,
+			AllocatedAt: nanosToTime(d.Network.AllocatedAt),
+			CellID:      d.Network.CellId,
+			ApnID:       int(d.Network.ApnId),
+			NasID:       int(d.Network.NasId),
+		}
+	}
+	if d.Firmware != nil {
+		ret.Firmware = model.DeviceFirmwareMetadata{
+			CurrentFirmwareID: model.FirmwareKey(d.Firmware.CurrentFirmwareId),
+			TargetFirmwareID:  model.FirmwareKey(d.Firmware.TargetFirmwareId),
+			FirmwareVersion:   d.Firmware.FirmwareVersion,
+			SerialNumber:      d.Firmware.SerialNumber,
+			ModelNumber:       d.Firmware.ModelNumber,
+			Manufacturer:      d.Firmware.Manufacturer,
+			State:             model.DeviceFirmwareState(d.Firmware.State),
+			StateMessage:      d.Firmware.StateMessage,
+			MaintenanceWindow: newMaintenanceWindowFromCluster(d.Firmware.MaintenanceWindow),
+		}
+	}
+	ret.Tags = newTagsFromCluster(d.Tags)
+	return ret
+}
+
+func newClusterCollection(c model.Collection) *outputcluster.Collection {
+	return &outputcluster.Collection{
+		Id:        uint64(c.ID),
+		TeamId:    uint64(c.TeamID),
+		FieldMask: uint64(c.FieldMask),
+		Firmware: &outputcluster.CollectionFirmware{
+			CurrentFirmwareId: uint64(c.Firmware.CurrentFirmwareID),
+			TargetFirmwareId:  uint64(c.Firmware.TargetFirmwareID),
+			Management:        int32(c.Firmware.Management),
+			RequireSignature:  c.Firmware.RequireSignature,
+			MaintenanceWindow: newClusterMaintenanceWindow(c.Firmware.MaintenanceWindow),
+		},
+		Tags: c.TagMap,
+	}
+}
+
+func newCollectionFromCluster(c *outputcluster.Collection) model.Collection {
+	ret := model.NewCollection()
+	if c == nil {
+		return ret
+	}
+	ret.ID = model.CollectionKey(c.Id)
+	ret.TeamID = model.TeamKey(c.TeamId)
+	ret.FieldMask = model.FieldMask(c.FieldMask)
+	if c.Firmware != nil {
+		ret.Firmware = model.CollectionFirmwareMetadata{
+			CurrentFirmwareID: model.FirmwareKey(c.Firmware.CurrentFirmwareId),
+			TargetFirmwareID:  model.FirmwareKey(c.Firmware.TargetFirmwareId),
+			Management:        model.FirmwareManagementSetting(c.Firmware.Management),
+			RequireSignature:  c.Firmware.RequireSignature,
+			MaintenanceWindow: newMaintenanceWindowFromCluster(c.Firmware.MaintenanceWindow),
+		}
+	}
+	ret.Tags = newTagsFromCluster(c.Tags)
+	return ret
+}
+
+// newClusterConfigValue converts a value in the output configuration. The
+// configuration is read from JSON so the values are strings, numbers,
+// booleans or lists of these.
+func newClusterConfigValue(v interface{}) (*outputcluster.ConfigValue, error) {
+	switch val := v.(type) {
+	case string:
+		return &outputcluster.ConfigValue{Value: &outputcluster.ConfigValue_StringValue{StringValue: val}}, nil
+	case float64:
+		return &outputcluster.ConfigValue{Value: &outputcluster.ConfigValue_NumberValue{NumberValue: val}}, nil
+	case bool:
+		return &outputcluster.ConfigValue{Value: &outputcluster.ConfigValue_BoolValue{BoolValue: val}}, nil
+	case []string:
+		list := &outputcluster.ConfigList{}
+		for _, item := range val {
+			list.Values = append(list.Values, &outputcluster.ConfigValue{Value: &outputcluster.ConfigValue_StringValue{StringValue: item}})
+		}
+		return &outputcluster.ConfigValue{Value: &outputcluster.ConfigValue_ListValue{ListValue: list}}, nil
+	case []interface{}:
+		list := &outputcluster.ConfigList{}
+		for _, item := range val {
+			cv, err := newClusterConfigValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list.Values = append(list.Values, cv)
+		}
+		return &outputcluster.ConfigValue{Value: &outputcluster.ConfigValue_ListValue{ListValue: list}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type for configuration value: %T", v)
+	}
+}
+
+func newConfigValueFromCluster(v *outputcluster.ConfigValue) interface{} {
+	switch val := v.GetValue().(type) {
+	case *outputcluster.ConfigValue_StringValue:
+		return val.StringValue
+	case *outputcluster.ConfigValue_NumberValue:
+		return val.NumberValue
+	case *outputcluster.ConfigValue_BoolValue:
+		return val.BoolValue
+	case *outputcluster.ConfigValue_ListValue:
+		// Use the same type as the JSON-encoded configuration
+		ret := make([]interface{}, 0)
+		for _, item := range val.ListValue.GetValues() {
+			ret = append(ret, newConfigValueFromCluster(item))
+		}
+		return ret
+	default:
+		return nil
+	}
+}
+
+func newClusterOutput(o model.Output) (*outputcluster.Output, error) {
+	ret := &outputcluster.Output{
+		Id:                  uint64(o.ID),
+		Type:                o.Type,
+		Config:              make(map[string]*outputcluster.ConfigValue),
+		CollectionId:        uint64(o.CollectionID),
+		Enabled:             o.Enabled,
+		CollectionFieldMask: uint64(o.CollectionFieldMask),
+		Tags:                o.TagMap,
+	}
+	for k, v := range o.Config {
+		if v == nil {
+			continue
+		}
+		cv, err := newClusterConfigValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", k, err)
+		}
+		ret.Config[k] = cv
+	}
+	return ret, nil
+}
+
+func newOutputFromCluster(o *outputcluster.Output) model.Output {
+	ret := model.NewOutput()
+	if o == nil {
+		return ret
+	}
+	ret.ID = model.OutputKey(o.Id)
+	ret.Type = o.Type
+	for k, v := range o.Config {
+		ret.Config[k] = newConfigValueFromCluster(v)
+	}
+	ret.CollectionID = model.CollectionKey(o.CollectionId)
+	ret.Enabled = o.Enabled
+	ret.CollectionFieldMask = model.FieldMask(o.CollectionFieldMask)
+	ret.Tags = newTagsFromCluster(o.Tags)
+	return ret
+}
+
+func newClusterDataMessage(msg model.DataMessage) *outputcluster.DataMessage {
+	return &outputcluster.DataMessage{
+		Device:        newClusterDevice(msg.Device),
+		Received:      timeToNanos(msg.Received),
+		Payload:       msg.Payload,
+		Transport:     int32(msg.Transport),
+		UdpLocalPort:  int32(msg.UDP.LocalPort),
+		UdpRemotePort: int32(msg.UDP.RemotePort),
+		CoapCode:      msg.CoAP.Code,
+		CoapPath:      msg.CoAP.Path,
+	}
+}
+
+func newDataMessageFromCluster(msg *outputcluster.DataMessage) model.DataMessage {
+	return model.DataMessage{
+		Device:    newDeviceFromCluster(msg.Device),
+		Received:  nanosToTime(msg.Received),
+		Payload:   msg.Payload,
+		Transport: model.MessageTransport(msg.Transport),
+		UDP: model.UDPMetaData{
+			LocalPort:  int(msg.UdpLocalPort),
+			RemotePort: int(msg.UdpRemotePort),
+		},
+		CoAP: model.CoAPMetaData{
+			Code: msg.CoapCode,
+			Path: msg.CoapPath,
+		},
+	}
+}
+
+func newClusterResourceEvent(ev model.ResourceEvent) (*outputcluster.ResourceEvent, error) {
+	ret := &outputcluster.ResourceEvent{
+		Type:                 string(ev.Type),
+		Time:                 timeToNanos(ev.Time),
+		CollectionId:         uint64(ev.CollectionID),
+		PreviousCollectionId: uint64(ev.PreviousCollectionID),
+	}
+	if ev.Device != nil {
+		ret.Device = newClusterDevice(*ev.Device)
+	}
+	if ev.Collection != nil {
+		ret.Collection = newClusterCollection(*ev.Collection)
+	}
+	if ev.Output != nil {
+		op, err := newClusterOutput(*ev.Output)
+		if err != nil {
+			return nil, err
+		}
+		ret.Output = op
+	}
+	return ret, nil
+}
+
+func newResourceEventFromCluster(ev *outputcluster.ResourceEvent) model.ResourceEvent {
+	ret := model.ResourceEvent{
+		Type:                 model.ResourceEventType(ev.Type),
+		Time:                 nanosToTime(ev.Time),
+		CollectionID:         model.CollectionKey(ev.CollectionId),
+		PreviousCollectionID: model.CollectionKey(ev.PreviousCollectionId),
+	}
+	if ev.Device != nil {
+		device := newDeviceFromCluster(ev.Device)
+		ret.Device = &device
+	}
+	if ev.Collection != nil {
+		collection := newCollectionFromCluster(ev.Collection)
+		ret.Collection = &collection
+	}
+	if ev.Output != nil {
+		output := newOutputFromCluster(ev.Output)
+		ret.Output = &output
+	}
+	return ret
+}
@@ -0,0 +1,104 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputcluster"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterMessageConversion(t *testing.T) {
+	assert := require.New(t)
+	now := time.Unix(0, time.Now().UnixNano())
+
+	device := model.NewDevice()
+	device.ID = 1
+	device.IMSI = 2
+	device.IMEI = 3
+	device.CollectionID = 4
+	device.Network = model.DeviceNetworkMetadata{AllocatedIP: "10.0.0.1", AllocatedAt: now, CellID: 5, ApnID: 6, NasID: 7}
+	device.Firmware = model.DeviceFirmwareMetadata{
+		CurrentFirmwareID: 8,
+		TargetFirmwareID:  9,
+		FirmwareVersion:   "1.0",
+		SerialNumber:      "sn",
+		ModelNumber:       "mn",
+		Manufacturer:      "mf",
+		State:             model.Pending,
+		StateMessage:      "pending",
+		MaintenanceWindow: model.MaintenanceWindow{Schedule: "0 2 * * *", Duration: time.Hour, TimeZone: "UTC"},
+	}
+	device.SetTag("name", "device")
+
+	msg := model.NewDataMessage(device, []byte("payload"), model.CoAPTransport, model.UDPMetaData{LocalPort: 1, RemotePort: 2}, model.CoAPMetaData{Code: "POST", Path: "/p"})
+	msg.Received = now
+
+	req := &outputcluster.PublishRequest{Message: newClusterDataMessage(msg)}
+	buf, err := proto.Marshal(req)
+	assert.NoError(err)
+	decoded := &outputcluster.PublishRequest{}
+	assert.NoError(proto.Unmarshal(buf, decoded))
+	assert.Equal(msg, newDataMessageFromCluster(decoded.Message))
+
+	output := model.NewOutput()
+	output.ID = 10
+	output.Type = "webhook"
+	output.CollectionID = 4
+	output.Enabled = true
+	output.CollectionFieldMask = model.IMSIMask
+	output.Config = model.OutputConfig{
+		outputconfig.WebhookURLField: "http://example.com",
+		outputconfig.MaxBatchSize:    float64(10),
+		outputconfig.EventTypes:      []interface{}{string(model.DeviceCreated)},
+	}
+	output.SetTag("name", "output")
+
+	collection := model.NewCollection()
+	collection.ID = 4
+	collection.TeamID = 11
+	collection.FieldMask = model.IMEIMask
+	collection.Firmware.RequireSignature = true
+	collection.SetTag("name", "collection")
+
+	ev := model.ResourceEvent{
+		Type:                 model.DeviceMoved,
+		Time:                 now,
+		CollectionID:         4,
+		PreviousCollectionID: 12,
+		Device:               &device,
+		Collection:           &collection,
+		Output:               &output,
+	}
+	event, err := newClusterResourceEvent(ev)
+	assert.NoError(err)
+	req = &outputcluster.PublishRequest{Event: event}
+	buf, err = proto.Marshal(req)
+	assert.NoError(err)
+	decoded = &outputcluster.PublishRequest{}
+	assert.NoError(proto.Unmarshal(buf, decoded))
+	assert.Equal(ev, newResourceEventFromCluster(decoded.Event))
+
+	// Configuration values must be JSON types
+	output.Config["invalid"] = struct{}{}
+	_, err = newClusterOutput(output)
+	assert.Error(err)
+}
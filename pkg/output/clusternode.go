@@ -0,0 +1,278 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"context"
+	"crypto/subtle"
+	"sync"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputcluster"
+	"github.com/eesrc/horde/pkg/utils/grpcutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// clusterSecretHeader is the metadata header with the cluster secret
+const clusterSecretHeader = "x-cluster-secret"
+
+// clusterCredentials adds the cluster secret to the requests to the other
+// nodes.
+type clusterCredentials string
+
+func (c clusterCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{clusterSecretHeader: string(c)}, nil
+}
+
+// RequireTransportSecurity returns false since the cluster service might run
+// without TLS inside a private network.
+func (c clusterCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// clusterAuthInterceptor rejects requests to the cluster service that don't
+// have the cluster secret.
+func clusterAuthInterceptor(secret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(clusterSecretHeader)
+		if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(secret)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "Invalid cluster secret")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// clusterNode is a client for another node in the cluster. Messages are
+// forwarded to the node by a separate goroutine. Messages are dropped if the
+// queue is full.
+type clusterNode struct {
+	nodeID   string
+	endpoint string
+	conn     *grpc.ClientConn
+	client   outputcluster.OutputClusterClient
+	mutex    *sync.Mutex
+	closed   bool
+	dropped  int
+	messages chan *outputcluster.PublishRequest
+}
+
+func newClusterNode(nodeID, endpoint string, params ClusterParameters) (*clusterNode, error) {
+	conn, err := grpcutil.NewGRPCClientConnection(grpcutil.GRPCClientParam{
+		ServerEndpoint: endpoint,
+		TLS:            params.GRPC.TLS,
+		CAFile:         params.GRPC.CertFile,
+	}, grpc.WithPerRPCCredentials(clusterCredentials(params.Secret)))
+	if err != nil {
+		return nil, err
+	}
+	ret := &clusterNode{
+		nodeID:   nodeID,
+		endpoint: endpoint,
+		conn:     conn,
+		client:   outputcluster.NewOutputClusterClient(conn),
+		mutex:    &sync.Mutex{},
+		messages: make(chan *outputcluster.PublishRequest, publishQueueLength),
+	}
+	go ret.forwardMessages()
+	return ret, nil
+}
+
+func (n *clusterNode) forwardMessages() {
+	defer n.conn.Close()
+	for req := range n.messages {
+		ctx, cancel := context.WithTimeout(context.Background(), clusterRequestTimeout)
+		if _, err := n.client.Publish(ctx, req); err != nil {
+			logging.Warning("Unable to forward message to node %s: %v", n.nodeID, err)
+		}
+		cancel()
+	}
+}
+
+// close stops the forwarding and closes the connection when the queue is
+// empty.
+func (n *clusterNode) close() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if !n.closed {
+		n.closed = true
+		close(n.messages)
+	}
+}
+
+func (n *clusterNode) publish(req *outputcluster.PublishRequest) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.closed {
+		return
+	}
+	select {
+	case n.messages <- req:
+	default:
+		n.dropped++
+		logging.Warning("Queue for node %s is full. Dropping message (%d dropped so far)", n.nodeID, n.dropped)
+	}
+}
+
+// droppedMessages returns the number of messages dropped because the queue
+// was full.
+func (n *clusterNode) droppedMessages() int {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.dropped
+}
+
+func (n *clusterNode) update(output model.Output, systemFieldMask model.FieldMask) error {
+	op, err := newClusterOutput(output)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), clusterRequestTimeout)
+	defer cancel()
+	_, err = n.client.Update(ctx, &outputcluster.UpdateRequest{
+		Output:          op,
+		SystemFieldMask: int64(systemFieldMask),
+	})
+	return err
+}
+
+func (n *clusterNode) stop(key model.OutputKey) error {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterRequestTimeout)
+	defer cancel()
+	_, err := n.client.Stop(ctx, &outputcluster.OutputRequest{OutputId: int64(key)})
+	return err
+}
+
+func (n *clusterNode) outputInfo(key model.OutputKey) (Output, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterRequestTimeout)
+	defer cancel()
+	res, err := n.client.OutputInfo(ctx, &outputcluster.OutputRequest{OutputId: int64(key)})
+	if err != nil {
+		return nil, err
+	}
+	ret := &remoteOutput{
+		status: model.OutputStatus{
+			Forwarded:   int(res.Forwarded),
+			Received:    int(res.Received),
+			ErrorCount:  int(res.ErrorCount),
+			Retransmits: int(res.Retransmits),
+			Throttled:   int(res.Throttled),
+			Queued:      int(res.Queued),
+			Dropped:     int(res.Dropped),
+		},
+	}
+	for _, v := range res.Logs {
+		ret.logs = append(ret.logs, model.OutputLogEntry{
+			Message:  v.Message,
+			Time:     time.Unix(0, v.Time),
+			Repeated: uint8(v.Repeated),
+		})
+	}
+	return ret, nil
+}
+
+// remoteOutput is a snapshot of the logs and status for an output running
+// on another node.
+type remoteOutput struct {
+	logs   []model.OutputLogEntry
+	status model.OutputStatus
+}
+
+func (r *remoteOutput) Validate(config model.OutputConfig) (model.ErrorMessage, error) {
+	return nil, nil
+}
+
+func (r *remoteOutput) Start(config model.OutputConfig, collectionFieldMask model.FieldMask, systemFieldMask model.FieldMask, message <-chan interface{}) {
+}
+
+func (r *remoteOutput) Stop(timeout time.Duration) {
+}
+
+func (r *remoteOutput) Logs() []model.OutputLogEntry {
+	return r.logs
+}
+
+func (r *remoteOutput) Status() model.OutputStatus {
+	return r.status
+}
+
+func (r *remoteOutput) Test(config model.OutputConfig, collectionFieldMask model.FieldMask, systemFieldMask model.FieldMask, msg model.DataMessage, timeout time.Duration) model.OutputTestResult {
+	return model.OutputTestResult{Message: "Output is running on another node"}
+}
+
+// clusterServer is the gRPC service for the other nodes in the cluster
+type clusterServer struct {
+	mgr *clusterManager
+}
+
+func (s *clusterServer) Publish(ctx context.Context, req *outputcluster.PublishRequest) (*outputcluster.PublishResponse, error) {
+	switch {
+	case req.Event != nil:
+		s.mgr.local.PublishEvent(newResourceEventFromCluster(req.Event))
+	case req.Message != nil:
+		s.mgr.local.Publish(newDataMessageFromCluster(req.Message))
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Missing message or event")
+	}
+	return &outputcluster.PublishResponse{}, nil
+}
+
+func (s *clusterServer) OutputInfo(ctx context.Context, req *outputcluster.OutputRequest) (*outputcluster.OutputInfoResponse, error) {
+	op, err := s.mgr.local.Get(model.OutputKey(req.OutputId))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Output isn't running on node")
+	}
+	st := op.Status()
+	ret := &outputcluster.OutputInfoResponse{
+		Forwarded:   int64(st.Forwarded),
+		Received:    int64(st.Received),
+		ErrorCount:  int64(st.ErrorCount),
+		Retransmits: int64(st.Retransmits),
+		Throttled:   int64(st.Throttled),
+		Queued:      int64(st.Queued),
+		Dropped:     int64(st.Dropped),
+	}
+	for _, v := range op.Logs() {
+		ret.Logs = append(ret.Logs, &outputcluster.LogEntry{
+			Message:  v.Message,
+			Time:     v.Time.UnixNano(),
+			Repeated: int32(v.Repeated),
+		})
+	}
+	return ret, nil
+}
+
+func (s *clusterServer) Update(ctx context.Context, req *outputcluster.UpdateRequest) (*outputcluster.UpdateResponse, error) {
+	if req.Output == nil {
+		return nil, status.Error(codes.InvalidArgument, "Missing output")
+	}
+	if err := s.mgr.updateOwned(newOutputFromCluster(req.Output), model.FieldMask(req.SystemFieldMask)); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &outputcluster.UpdateResponse{}, nil
+}
+
+func (s *clusterServer) Stop(ctx context.Context, req *outputcluster.OutputRequest) (*outputcluster.StopResponse, error) {
+	if err := s.mgr.stopOwned(model.OutputKey(req.OutputId)); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &outputcluster.StopResponse{}, nil
+}
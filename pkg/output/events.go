@@ -0,0 +1,82 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+)
+
+// eventFilter is the set of resource event types an output has opted in to.
+// Outputs get no events unless they are configured with a list of event
+// types.
+type eventFilter map[model.ResourceEventType]bool
+
+// newEventFilter reads the event types from the output configuration
+func newEventFilter(config model.OutputConfig) eventFilter {
+	ret := make(eventFilter)
+	types, _ := config.StringList(outputconfig.EventTypes)
+	for _, v := range types {
+		ret[model.ResourceEventType(v)] = true
+	}
+	return ret
+}
+
+// accept returns true if the message should be forwarded to the output. Data
+// messages are always forwarded.
+func (e eventFilter) accept(msg interface{}) bool {
+	ev, ok := msg.(model.ResourceEvent)
+	if !ok {
+		return true
+	}
+	return e[ev.Type]
+}
+
+// validateEventConfig checks the list of event types
+func validateEventConfig(config model.OutputConfig, errs model.ErrorMessage) {
+	if _, exists := config[outputconfig.EventTypes]; !exists {
+		return
+	}
+	types, ok := config.StringList(outputconfig.EventTypes)
+	if !ok {
+		errs[outputconfig.EventTypes] = "parameter is incorrect type"
+		return
+	}
+	for _, v := range types {
+		if !model.ResourceEventType(v).IsValid() {
+			errs[outputconfig.EventTypes] = "unknown event type: " + v
+			return
+		}
+	}
+}
+
+// rejectEventConfig flags the list of event types as an error for outputs
+// that don't forward events.
+func rejectEventConfig(config model.OutputConfig, errs model.ErrorMessage) {
+	if _, exists := config[outputconfig.EventTypes]; exists {
+		errs[outputconfig.EventTypes] = "events are not supported by this output type"
+	}
+}
+
+// eventTopics returns the collections the event is published to. Moved
+// devices are published to both the new and the previous collection.
+func eventTopics(ev model.ResourceEvent) []model.CollectionKey {
+	ret := []model.CollectionKey{ev.CollectionID}
+	if ev.PreviousCollectionID != 0 && ev.PreviousCollectionID != ev.CollectionID {
+		ret = append(ret, ev.PreviousCollectionID)
+	}
+	return ret
+}
@@ -0,0 +1,153 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/require"
+)
+
+// eventRecorder is a webhook endpoint that records the message and event
+// types it receives
+type eventRecorder struct {
+	mutex    *sync.Mutex
+	received []string
+}
+
+func (e *eventRecorder) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msgs := &apipb.ListMessagesResponse{}
+		if err := jsonpb.Unmarshal(r.Body, msgs); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+		for _, m := range msgs.Messages {
+			if m.Type == apipb.OutputDataMessage_event {
+				e.received = append(e.received, m.ResourceEvent.Type)
+				continue
+			}
+			e.received = append(e.received, string(m.Payload))
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (e *eventRecorder) wait(count int) []string {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		e.mutex.Lock()
+		n := len(e.received)
+		e.mutex.Unlock()
+		if n >= count {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Wait a bit to catch any extra messages
+	time.Sleep(50 * time.Millisecond)
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return append([]string{}, e.received...)
+}
+
+func TestLocalManagerEvents(t *testing.T) {
+	assert := require.New(t)
+	DisableLocalhostChecks()
+
+	withEvents := &eventRecorder{mutex: &sync.Mutex{}}
+	server1 := httptest.NewServer(withEvents.handler())
+	defer server1.Close()
+	withoutEvents := &eventRecorder{mutex: &sync.Mutex{}}
+	server2 := httptest.NewServer(withoutEvents.handler())
+	defer server2.Close()
+
+	mgr := NewLocalManager()
+	defer mgr.Shutdown()
+
+	ms := sqlstore.NewMemoryStore()
+	collectionID := ms.NewCollectionID()
+	newWebhook := func(url string, config model.OutputConfig) model.Output {
+		op := model.NewOutput()
+		op.ID = ms.NewOutputID()
+		op.CollectionID = collectionID
+		op.Type = "webhook"
+		op.Enabled = true
+		op.Config = config
+		op.Config[outputconfig.WebhookURLField] = url
+		assert.NoError(mgr.Update(op, 0))
+		return op
+	}
+	newWebhook(server1.URL, model.OutputConfig{
+		outputconfig.EventTypes: []interface{}{"device.created", "device.moved"},
+	})
+	newWebhook(server2.URL, model.OutputConfig{})
+
+	device := model.NewDevice()
+	device.ID = ms.NewDeviceID()
+	device.CollectionID = collectionID
+
+	mgr.PublishEvent(model.NewDeviceEvent(model.DeviceCreated, device))
+	mgr.PublishEvent(model.NewDeviceEvent(model.DeviceRetagged, device))
+	mgr.Publish(model.NewDataMessage(device, []byte("data"), model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{}))
+
+	// Moved devices are published to the previous collection as well
+	moved := model.NewDeviceEvent(model.DeviceMoved, device)
+	moved.CollectionID = ms.NewCollectionID()
+	moved.PreviousCollectionID = collectionID
+	mgr.PublishEvent(moved)
+
+	assert.Equal([]string{"device.created", "data", "device.moved"}, withEvents.wait(3))
+	assert.Equal([]string{"data"}, withoutEvents.wait(1))
+}
+
+func TestEventConfigValidation(t *testing.T) {
+	assert := require.New(t)
+
+	errs := make(model.ErrorMessage)
+	validateEventConfig(model.OutputConfig{}, errs)
+	assert.Empty(errs)
+
+	validateEventConfig(model.OutputConfig{outputconfig.EventTypes: []interface{}{"device.created", "output.deleted"}}, errs)
+	assert.Empty(errs)
+
+	validateEventConfig(model.OutputConfig{outputconfig.EventTypes: []interface{}{"device.exploded"}}, errs)
+	assert.Contains(errs, outputconfig.EventTypes)
+
+	errs = make(model.ErrorMessage)
+	validateEventConfig(model.OutputConfig{outputconfig.EventTypes: "device.created"}, errs)
+	assert.Contains(errs, outputconfig.EventTypes)
+
+	udp := newUDP()
+	errs, err := udp.Validate(model.OutputConfig{
+		outputconfig.UDPHost:    "127.0.0.1",
+		outputconfig.UDPPort:    float64(4711),
+		outputconfig.EventTypes: []interface{}{"device.created"},
+	})
+	assert.Error(err)
+	assert.Contains(errs, outputconfig.EventTypes)
+}
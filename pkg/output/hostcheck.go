@@ -109,6 +109,14 @@ func (e *endpointChecker) Host() string {
 	return h
 }
 
+// Port returns the port
+func (e *endpointChecker) Port() string {
+	if e.u == nil {
+		return ""
+	}
+	return e.u.Port()
+}
+
 var cidrList = []string{
 	"10.0.0.0/8",
 	"172.16.0.0/12",
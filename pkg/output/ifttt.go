@@ -21,25 +21,47 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"sync"
 	"time"
 
 	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/metering"
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/output/outputconfig"
 	"github.com/eesrc/horde/pkg/utils/audit"
 )
 
-// ifttt is a special-purpose webhook integration with IFTTT
+// ifttt is a special-purpose webhook integration with IFTTT. Each message
+// is sent as a separate request. The request rate and the number of
+// concurrent requests can be limited through the configuration.
 type ifttt struct {
-	status model.OutputStatus
-	logs   Logger
-	config model.OutputConfig
-	mutex  *sync.Mutex
-	client *http.Client
+	status     model.OutputStatus
+	logs       Logger
+	config     model.OutputConfig
+	mutex      *sync.Mutex
+	client     *http.Client
+	dispatcher *dispatcher
+}
+
+const (
+	// iftttRetryDelay is the delay before the first retry. The delay doubles
+	// for every retry.
+	iftttRetryDelay = time.Second
+	// iftttMaxAttempts is the number of times a message is sent before it
+	// is dropped
+	iftttMaxAttempts = 3
+)
+
+// iftttRetry is a message that is put back into the dispatcher's queue after
+// a failed request.
+type iftttRetry struct {
+	msg      model.DataMessage
+	attempts int
 }
 
 // newIFTTT creates a new output.
@@ -63,85 +85,139 @@ type iftttBody struct {
 	Value3 string `json:"value3"`
 }
 
-func (i *ifttt) sendMessage(data iftttBody, event, key string) bool {
+// newIFTTTBody creates the request body for a data message
+func newIFTTTBody(msg model.DataMessage, asIs bool) iftttBody {
+	var payload string
+	if asIs {
+		payload = string(msg.Payload)
+	} else {
+		payload = base64.StdEncoding.EncodeToString(msg.Payload)
+	}
+	return iftttBody{Value1: payload, Value2: msg.Device.ID.String(), Value3: ""}
+}
+
+// newRequest creates a new POST request for the IFTTT maker endpoint
+func (i *ifttt) newRequest(data iftttBody, event, key string) (*http.Request, error) {
 	buf, err := json.Marshal(&data)
 	if err != nil {
-		logging.Warning("Unable to marshal ifttt body: %v", err)
-		return false
+		return nil, err
 	}
-	body := bytes.NewReader(buf)
 	ifttURL := fmt.Sprintf("https://maker.ifttt.com/trigger/%s/with/key/%s", event, key)
-	req, err := http.NewRequest("POST", ifttURL, body)
+	req, err := http.NewRequest("POST", ifttURL, bytes.NewReader(buf))
 	if err != nil {
-		logging.Warning("Unable to create request for webhook POST: %v", err)
+		return nil, err
 	}
 	req.Header.Add("Content-Type", "application/json")
+	return req, nil
+}
+
+func (i *ifttt) sendMessage(data iftttBody, event, key string) deliveryResult {
+	req, err := i.newRequest(data, event, key)
+	if err != nil {
+		logging.Warning("Unable to create request for IFTTT POST: %v", err)
+		i.mutex.Lock()
+		i.status.ErrorCount++
+		i.mutex.Unlock()
+		return rejected
+	}
 	res, err := i.client.Do(req)
 	if err != nil {
 		logging.Warning("Error calling IFTTT URL: %v", err)
 		i.mutex.Lock()
 		i.status.ErrorCount++
 		i.mutex.Unlock()
-		return false
+		return retryLater
 	}
+	io.Copy(ioutil.Discard, res.Body)
+	res.Body.Close()
 
 	if res.StatusCode < 200 || res.StatusCode > 299 {
-		i.logs.Append(fmt.Sprintf("Got %d response code from IFTTT", res.StatusCode))
-		time.Sleep(5 * time.Second)
 		i.mutex.Lock()
+		i.logs.Append(fmt.Sprintf("Got %d response code from IFTTT", res.StatusCode))
 		i.status.ErrorCount++
 		i.mutex.Unlock()
-		return false
+		if isPermanentHTTPError(res.StatusCode) {
+			return rejected
+		}
+		return retryLater
 	}
 	metrics.DefaultCoreCounters.MessagesForwardIFTTT.Add(1)
-	return true
+	return delivered
 }
 
-func (i *ifttt) sender(receiver <-chan interface{}, event, key string, asIs bool) {
+func (i *ifttt) sender(receiver <-chan interface{}, d *dispatcher, event, key string, asIs bool) {
+	i.mutex.Lock()
 	i.logs.Append("Starting IFTTT output")
-	for msg := range receiver {
-		dataMessage, ok := msg.(model.DataMessage)
-		if !ok {
-			logging.Debug("Output message isn't output data. Skipping")
-			continue
+	i.mutex.Unlock()
+	d.Run(receiver, nil, func(batch []interface{}) {
+		for _, msg := range batch {
+			i.sendDataMessage(d, msg, event, key, asIs)
 		}
+	})
+	i.mutex.Lock()
+	i.logs.Append("IFTTT output stopped")
+	i.mutex.Unlock()
+}
+
+// sendDataMessage sends a single message to IFTTT. Failed requests are put
+// back into the dispatcher's queue and retried with an increasing delay. The
+// message is dropped after iftttMaxAttempts attempts or if IFTTT rejects it.
+func (i *ifttt) sendDataMessage(d *dispatcher, msg interface{}, event, key string, asIs bool) {
+	attempts := 0
+	if retry, ok := msg.(iftttRetry); ok {
+		msg = retry.msg
+		attempts = retry.attempts
+	}
+	dataMessage, ok := msg.(model.DataMessage)
+	if !ok {
+		logging.Debug("Output message isn't output data. Skipping")
+		return
+	}
+	result := i.sendMessage(newIFTTTBody(dataMessage, asIs), event, key)
+	attempts++
+	if attempts > 1 {
 		i.mutex.Lock()
-		i.status.Received++
+		i.status.Retransmits++
 		i.mutex.Unlock()
-		var payload string
-		if asIs {
-			payload = string(dataMessage.Payload)
-		} else {
-			payload = base64.StdEncoding.EncodeToString(dataMessage.Payload)
-		}
-		data := iftttBody{Value1: payload, Value2: dataMessage.Device.ID.String(), Value3: ""}
-		retries := 0
-		success := false
-		for retries < 3 && !success {
-			success = i.sendMessage(data, event, key)
-			retries++
-			if retries > 1 {
-				i.mutex.Lock()
-				i.status.Retransmits++
-				i.mutex.Unlock()
-			}
-		}
+	}
+
+	switch {
+	case result == delivered:
+		metering.DefaultMeter.OutputDelivered(dataMessage.Device.CollectionID, 1)
 		i.mutex.Lock()
 		i.status.Forwarded++
 		audit.Log("IFTTT: Forwarded %d bytes from device with IMSI %d, Device ID=%s, Collection ID=%s",
 			len(dataMessage.Payload), dataMessage.Device.IMSI,
 			dataMessage.Device.ID.String(), dataMessage.Device.CollectionID.String())
 		i.mutex.Unlock()
+	case result == retryLater && attempts < iftttMaxAttempts:
+		retryAt := time.Now().Add(iftttRetryDelay << uint(attempts-1))
+		d.Retry([]interface{}{iftttRetry{msg: dataMessage, attempts: attempts}}, retryAt)
+	default:
+		i.mutex.Lock()
+		i.logs.Append(fmt.Sprintf("Dropping message from device %s after %d attempt(s)", dataMessage.Device.ID.String(), attempts))
+		i.mutex.Unlock()
+		d.Discard(1)
+	}
+}
+
+// messageReceived is called by the dispatcher for every received message
+func (i *ifttt) messageReceived(msg interface{}) {
+	if _, ok := msg.(model.DataMessage); ok {
+		i.mutex.Lock()
+		i.status.Received++
+		i.mutex.Unlock()
 	}
-	i.logs.Append("IFTTT output stopped")
 }
 
 func (i *ifttt) Validate(config model.OutputConfig) (model.ErrorMessage, error) {
-	errs := validateConfig(config, []fieldSpec{
+	errs := validateConfig(config, append([]fieldSpec{
 		fieldSpec{outputconfig.IFTTTEvent, reflect.String, true},
 		fieldSpec{outputconfig.IFTTTKey, reflect.String, true},
 		fieldSpec{outputconfig.FTTTAsIsPayload, reflect.Bool, false},
-	})
+	}, throttleFields...))
+	validateThrottleConfig(config, errs)
+	rejectEventConfig(config, errs)
 	if len(errs) > 0 {
 		return errs, errors.New("invalid config")
 	}
@@ -154,8 +230,15 @@ func (i *ifttt) Start(config model.OutputConfig, collectionFieldMask model.Field
 		logging.Warning("Invalid config. Stopping output")
 
 	}
+	// Messages are sent one by one so the batch size is fixed
+	throttle := newThrottleConfig(config)
+	throttle.maxBatchSize = 1
+	throttle.maxLinger = 0
+
 	i.mutex.Lock()
 	i.config = config
+	i.dispatcher = newDispatcher(throttle, i.messageReceived)
+	d := i.dispatcher
 	i.mutex.Unlock()
 
 	event := config[outputconfig.IFTTTEvent].(string)
@@ -165,7 +248,7 @@ func (i *ifttt) Start(config model.OutputConfig, collectionFieldMask model.Field
 	if !ok {
 		asIs = false
 	}
-	go i.sender(message, event, key, asIs)
+	go i.sender(message, d, event, key, asIs)
 }
 
 func (i *ifttt) Stop(timeout time.Duration) {
@@ -180,5 +263,23 @@ func (i *ifttt) Status() model.OutputStatus {
 	i.mutex.Lock()
 	defer i.mutex.Unlock()
 	ret := i.status
+	if i.dispatcher != nil {
+		ret.Throttled = i.dispatcher.Throttled()
+		ret.Queued = i.dispatcher.Queued()
+		ret.Dropped = i.dispatcher.Dropped()
+	}
 	return ret
 }
+
+func (i *ifttt) Test(config model.OutputConfig, collectionFieldMask model.FieldMask, systemFieldMask model.FieldMask, msg model.DataMessage, timeout time.Duration) model.OutputTestResult {
+	event, _ := config[outputconfig.IFTTTEvent].(string)
+	key, _ := config[outputconfig.IFTTTKey].(string)
+	asIs, _ := config[outputconfig.FTTTAsIsPayload].(bool)
+
+	req, err := i.newRequest(newIFTTTBody(msg, asIs), event, key)
+	if err != nil {
+		return model.OutputTestResult{Message: fmt.Sprintf("Unable to create request: %v", err)}
+	}
+	client := &http.Client{Timeout: timeout}
+	return traceHTTPRequest(client, req)
+}
@@ -0,0 +1,89 @@
+package output
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+)
+
+// redirectTransport sends all requests to the test server
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (r *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestIFTTTRetry(t *testing.T) {
+	responses := make(chan int, 10)
+	requests := make(chan bool, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- true
+		w.WriteHeader(<-responses)
+	}))
+	defer server.Close()
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := newIFTTT().(*ifttt)
+	output.client.Transport = &redirectTransport{target: target}
+	dataChan := make(chan interface{})
+	output.Start(model.OutputConfig{
+		outputconfig.IFTTTEvent: "event",
+		outputconfig.IFTTTKey:   "key",
+	}, 0, 0, dataChan)
+	defer close(dataChan)
+
+	waitForRequests := func(count int) {
+		for i := 0; i < count; i++ {
+			select {
+			case <-requests:
+			case <-time.After(3 * time.Second):
+				t.Fatal("Did not get a request from the output")
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The first request fails and the message is sent again
+	responses <- http.StatusServiceUnavailable
+	responses <- http.StatusOK
+	dataChan <- model.DataMessage{Payload: []byte{1, 2, 3}, Device: model.NewDevice()}
+	waitForRequests(2)
+	if s := output.Status(); s.Forwarded != 1 || s.Retransmits != 1 || s.ErrorCount != 1 || s.Dropped != 0 {
+		t.Fatalf("Incorrect status: %+v", s)
+	}
+
+	// Rejected messages are dropped right away
+	responses <- http.StatusBadRequest
+	dataChan <- model.DataMessage{Payload: []byte{1, 2, 3}, Device: model.NewDevice()}
+	waitForRequests(1)
+	if s := output.Status(); s.Forwarded != 1 || s.Dropped != 1 || s.Queued != 0 {
+		t.Fatalf("Incorrect status: %+v", s)
+	}
+}
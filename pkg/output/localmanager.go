@@ -17,6 +17,7 @@ package output
 //
 import (
 	"errors"
+	"reflect"
 	"sync"
 	"time"
 
@@ -28,15 +29,24 @@ import (
 // queueLength is the length of the event router's queue
 const queueLength = 100
 
+// outputEntry is a running (or disabled) output. Each entry has a single
+// subscription on the event router that is kept across reconfigurations. The
+// relay forwards the messages from the subscription to the current output
+// instance. Disabled outputs are kept as entries without a subscription so
+// the transitions are still visible in the logs.
 type outputEntry struct {
-	ch     <-chan interface{}
-	output Output
+	config          model.Output
+	systemFieldMask model.FieldMask
+	sub             <-chan interface{}
+	relay           *relay
+	output          Output
+	logs            *transitionLog
 }
 
 // localManager is a manager running on the local instance. It will only keep
 // track of outputs launched locally.
 type localManager struct {
-	running   map[model.OutputKey]outputEntry
+	running   map[model.OutputKey]*outputEntry
 	publisher pubsub.EventRouter
 	mutex     *sync.Mutex
 }
@@ -44,8 +54,12 @@ type localManager struct {
 // NewLocalManager creates a new manager running locally
 func NewLocalManager() Manager {
 	listOutputTypes()
+	return newLocalManager()
+}
+
+func newLocalManager() *localManager {
 	return &localManager{
-		running:   make(map[model.OutputKey]outputEntry),
+		running:   make(map[model.OutputKey]*outputEntry),
 		publisher: pubsub.NewEventRouter(queueLength),
 		mutex:     &sync.Mutex{},
 	}
@@ -59,26 +73,32 @@ func (l *localManager) Verify(output model.Output) (model.ErrorMessage, error) {
 	return op.Validate(output.Config)
 }
 
+// configChanged returns true if the running output must be reconfigured. Tag
+// changes won't affect the running output.
+func configChanged(entry *outputEntry, output model.Output, systemFieldMask model.FieldMask) bool {
+	return entry.config.Type != output.Type ||
+		entry.config.Enabled != output.Enabled ||
+		entry.config.CollectionID != output.CollectionID ||
+		entry.config.CollectionFieldMask != output.CollectionFieldMask ||
+		entry.systemFieldMask != systemFieldMask ||
+		!reflect.DeepEqual(entry.config.Config, output.Config)
+}
+
 func (l *localManager) Refresh(outputs []model.Output, systemFieldMask model.FieldMask) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 
 	for _, v := range outputs {
-		_, exists := l.running[v.ID]
-		if exists {
+		entry, exists := l.running[v.ID]
+		if exists && !configChanged(entry, v, systemFieldMask) {
 			continue
 		}
-		if !v.Enabled {
+		if !exists && !v.Enabled {
 			continue
 		}
-		new, err := NewOutput(v.Type)
-		if err != nil {
+		if err := l.update(v, systemFieldMask); err != nil {
 			logging.Warning("Unable to launch output with ID %v: %v. Ignoring", v.ID, err)
-			continue
 		}
-		ch := l.Subscribe(v.CollectionID)
-		new.Start(v.Config, v.CollectionFieldMask, systemFieldMask, ch)
-		l.running[v.ID] = outputEntry{ch: ch, output: new}
 	}
 }
 
@@ -86,10 +106,32 @@ func (l *localManager) Update(output model.Output, systemFieldMask model.FieldMa
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 
-	v, exists := l.running[output.ID]
-	if exists {
-		l.Unsubscribe(v.ch)
-		v.output.Stop(stopTimeout)
+	return l.update(output, systemFieldMask)
+}
+
+// update applies the configuration to the output. A new output instance is
+// started and takes over the subscription before the old instance drains
+// its queue and stops, ie there is no gap where messages are dropped and
+// messages are delivered by one instance only. The mutex must be held when
+// this method is called.
+func (l *localManager) update(output model.Output, systemFieldMask model.FieldMask) error {
+	entry, exists := l.running[output.ID]
+	if !output.Enabled {
+		if !exists {
+			logging.Info("Won't start disabled output with ID %s (type: %s)", output.ID.String(), output.Type)
+			return nil
+		}
+		if entry.relay != nil {
+			l.Unsubscribe(entry.sub)
+			entry.output.Stop(stopTimeout)
+			entry.logs.Append("Output disabled")
+		}
+		entry.config = output
+		entry.systemFieldMask = systemFieldMask
+		entry.sub = nil
+		entry.relay = nil
+		entry.output = newNullOutput()
+		return nil
 	}
 
 	newOutput, err := NewOutput(output.Type)
@@ -97,14 +139,42 @@ func (l *localManager) Update(output model.Output, systemFieldMask model.FieldMa
 		logging.Warning("Couldn't create new output: %v", err)
 		return err
 	}
+	ch := make(chan interface{}, queueLength)
+	newOutput.Start(output.Config, output.CollectionFieldMask, systemFieldMask, ch)
 
-	if !output.Enabled {
-		logging.Info("Won't start disabled output with ID %s (type: %s)", output.ID.String(), output.Type)
-		return nil
+	if !exists {
+		entry = &outputEntry{logs: newTransitionLog()}
+		l.running[output.ID] = entry
+		entry.logs.Append("Output started")
 	}
-	ch := l.Subscribe(output.CollectionID)
-	newOutput.Start(output.Config, output.CollectionFieldMask, systemFieldMask, ch)
-	l.running[output.ID] = outputEntry{ch: ch, output: newOutput}
+
+	switch {
+	case entry.relay != nil && entry.config.CollectionID == output.CollectionID:
+		// Hand over the subscription to the new instance. The old instance
+		// will drain its queue when the channel is closed.
+		old := entry.output
+		close(entry.relay.swap(ch, newEventFilter(output.Config)))
+		go old.Stop(stopTimeout)
+		entry.logs.Append("Configuration updated")
+
+	case entry.relay != nil:
+		// The collection has changed so the subscription must be replaced
+		l.Unsubscribe(entry.sub)
+		go entry.output.Stop(stopTimeout)
+		entry.sub = l.Subscribe(output.CollectionID)
+		entry.relay = newRelay(entry.sub, ch, newEventFilter(output.Config))
+		entry.logs.Append("Configuration updated")
+
+	default:
+		if exists {
+			entry.logs.Append("Output enabled")
+		}
+		entry.sub = l.Subscribe(output.CollectionID)
+		entry.relay = newRelay(entry.sub, ch, newEventFilter(output.Config))
+	}
+	entry.config = output
+	entry.systemFieldMask = systemFieldMask
+	entry.output = newOutput
 	return nil
 }
 
@@ -117,8 +187,10 @@ func (l *localManager) Stop(key model.OutputKey) error {
 		return errors.New("unknown output")
 	}
 	delete(l.running, key)
-	l.Unsubscribe(v.ch)
-	v.output.Stop(stopTimeout)
+	if v.relay != nil {
+		l.Unsubscribe(v.sub)
+		v.output.Stop(stopTimeout)
+	}
 	return nil
 }
 
@@ -129,8 +201,10 @@ func (l *localManager) Shutdown() {
 	defer l.mutex.Unlock()
 
 	for k, v := range l.running {
-		l.Unsubscribe(v.ch)
-		v.output.Stop(stopTimeout)
+		if v.relay != nil {
+			l.Unsubscribe(v.sub)
+			v.output.Stop(stopTimeout)
+		}
 		delete(l.running, k)
 	}
 }
@@ -143,13 +217,19 @@ func (l *localManager) Get(key model.OutputKey) (Output, error) {
 	if !exists {
 		return nil, errors.New("unknown output")
 	}
-	return ret.output, nil
+	return &managedOutput{Output: ret.output, logs: ret.logs}, nil
 }
 
 func (l *localManager) Publish(msg model.DataMessage) {
 	l.publisher.Publish(msg.Device.CollectionID, msg)
 }
 
+func (l *localManager) PublishEvent(ev model.ResourceEvent) {
+	for _, topic := range eventTopics(ev) {
+		l.publisher.Publish(topic, ev)
+	}
+}
+
 func (l *localManager) Subscribe(collectionID model.CollectionKey) <-chan interface{} {
 	return l.publisher.Subscribe(collectionID)
 }
@@ -157,3 +237,7 @@ func (l *localManager) Subscribe(collectionID model.CollectionKey) <-chan interf
 func (l *localManager) Unsubscribe(ch <-chan interface{}) {
 	l.publisher.Unsubscribe(ch)
 }
+
+func (l *localManager) Test(output model.Output, systemFieldMask model.FieldMask, msg model.DataMessage) (model.OutputTestResult, error) {
+	return testOutput(output, systemFieldMask, msg)
+}
@@ -30,14 +30,15 @@ type Manager interface {
 	Verify(model.Output) (model.ErrorMessage, error)
 
 	// Load loads outputs from backend store and launches the ones that aren't
-	// up and running yet. The Load call might be performed multiple times to
-	// update the list. The field mask is the system-level field mask, ie forced
-	// field mask
+	// up and running yet. Running outputs with a changed configuration are
+	// updated. The Load call might be performed multiple times to update the
+	// list. The field mask is the system-level field mask, ie forced field mask
 	Refresh([]model.Output, model.FieldMask)
 
 	// Update refreshes the output. If it isn't launched yet it will be
 	// launched. If it is already running the new configuration will be
-	// applied. The field mask is the system field mask, ie the forced
+	// applied without dropping or duplicating messages. Disabled outputs are
+	// stopped. The field mask is the system field mask, ie the forced
 	// field mask
 	Update(model.Output, model.FieldMask) error
 
@@ -55,11 +56,27 @@ type Manager interface {
 	// outputs subscribing to the data it will be discarded.
 	Publish(model.DataMessage)
 
+	EventPublisher
+
 	// Subscribe subscribes to a topic
 	Subscribe(collectionID model.CollectionKey) <-chan interface{}
 
 	// Unsubscribe unsubscribes from a topic
 	Unsubscribe(ch <-chan interface{})
+
+	// Test sends a single message through a new instance of the output and
+	// returns the delivery trace. The running outputs are not affected. The
+	// field mask is the system field mask.
+	Test(model.Output, model.FieldMask, model.DataMessage) (model.OutputTestResult, error)
+}
+
+// EventPublisher publishes resource change events. The events use the same
+// topics as the data messages, ie the collection ID.
+type EventPublisher interface {
+	// PublishEvent publishes a resource event to the running outputs and the
+	// subscribers. Events for moved devices are published to both the new
+	// and the previous collection.
+	PublishEvent(model.ResourceEvent)
 }
 
 // NewManager creates a new manager for outputs.
@@ -103,6 +120,12 @@ func (m *dummyManager) Publish(msg model.DataMessage) {
 	m.router.Publish(msg.Device.CollectionID, msg)
 }
 
+func (m *dummyManager) PublishEvent(ev model.ResourceEvent) {
+	for _, topic := range eventTopics(ev) {
+		m.router.Publish(topic, ev)
+	}
+}
+
 func (m *dummyManager) Subscribe(collectionID model.CollectionKey) <-chan interface{} {
 	return m.router.Subscribe(collectionID)
 }
@@ -111,6 +134,20 @@ func (m *dummyManager) Unsubscribe(ch <-chan interface{}) {
 	m.router.Unsubscribe(ch)
 }
 
+func (m *dummyManager) Test(op model.Output, systemFieldMask model.FieldMask, msg model.DataMessage) (model.OutputTestResult, error) {
+	return testOutput(op, systemFieldMask, msg)
+}
+
+// testOutput creates a new output instance and sends a single message through
+// it. The configuration should be validated before this is called.
+func testOutput(op model.Output, systemFieldMask model.FieldMask, msg model.DataMessage) (model.OutputTestResult, error) {
+	o, err := NewOutput(op.Type)
+	if err != nil {
+		return model.OutputTestResult{}, err
+	}
+	return o.Test(op.Config, op.CollectionFieldMask, systemFieldMask, msg, testTimeout), nil
+}
+
 // NewDummyManager For testing: Return a dummy manager
 func NewDummyManager() Manager {
 	return &dummyManager{router: pubsub.NewEventRouter(2)}
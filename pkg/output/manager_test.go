@@ -16,10 +16,19 @@ package output
 //limitations under the License.
 //
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
 	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/require"
 )
 
 const numOutputs = 10
@@ -76,3 +85,158 @@ func managerTest(t *testing.T, mgr Manager) {
 func TestLocalManager(t *testing.T) {
 	managerTest(t, NewLocalManager())
 }
+
+// payloadCounter is a webhook endpoint that counts the payloads it receives
+type payloadCounter struct {
+	mutex    *sync.Mutex
+	payloads map[string]int
+	servers  map[string]int
+}
+
+func (p *payloadCounter) handler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msgs := &apipb.ListMessagesResponse{}
+		if err := jsonpb.Unmarshal(r.Body, msgs); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		for _, m := range msgs.Messages {
+			p.payloads[string(m.Payload)]++
+			p.servers[name]++
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (p *payloadCounter) count() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.payloads)
+}
+
+func TestLocalManagerReconfiguration(t *testing.T) {
+	assert := require.New(t)
+	DisableLocalhostChecks()
+
+	counter := &payloadCounter{mutex: &sync.Mutex{}, payloads: make(map[string]int), servers: make(map[string]int)}
+	server1 := httptest.NewServer(counter.handler("first"))
+	defer server1.Close()
+	server2 := httptest.NewServer(counter.handler("second"))
+	defer server2.Close()
+
+	mgr := NewLocalManager()
+	defer mgr.Shutdown()
+
+	ms := sqlstore.NewMemoryStore()
+	op := model.NewOutput()
+	op.ID = ms.NewOutputID()
+	op.CollectionID = ms.NewCollectionID()
+	op.Type = "webhook"
+	op.Enabled = true
+	op.Config = model.OutputConfig{outputconfig.WebhookURLField: server1.URL}
+	assert.NoError(mgr.Update(op, 0))
+
+	device := model.NewDevice()
+	device.CollectionID = op.CollectionID
+
+	const msgCount = 200
+	for i := 0; i < msgCount; i++ {
+		if i == msgCount/2 {
+			op.Config = model.OutputConfig{outputconfig.WebhookURLField: server2.URL}
+			// Refresh applies the changed configuration to the running output
+			mgr.Refresh([]model.Output{op}, 0)
+		}
+		mgr.Publish(model.NewDataMessage(device, []byte(strings.Repeat("x", i+1)), model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{}))
+		time.Sleep(time.Millisecond)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for counter.count() < msgCount && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	counter.mutex.Lock()
+	assert.Len(counter.payloads, msgCount, "All messages should be delivered")
+	for _, n := range counter.payloads {
+		assert.Equal(1, n, "Messages should be delivered once")
+	}
+	assert.True(counter.servers["first"] > 0)
+	assert.True(counter.servers["second"] > 0)
+	counter.mutex.Unlock()
+
+	// Disable the output. It should still be available but won't forward
+	// any messages.
+	op.Enabled = false
+	assert.NoError(mgr.Update(op, 0))
+	o, err := mgr.Get(op.ID)
+	assert.NoError(err)
+	logs := o.Logs()
+	assert.NotEmpty(logs)
+	assert.Equal("Output disabled", logs[len(logs)-1].Message)
+
+	mgr.Publish(model.NewDataMessage(device, []byte("disabled"), model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{}))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(msgCount, counter.count())
+
+	// ...and enable it again
+	op.Enabled = true
+	assert.NoError(mgr.Update(op, 0))
+	o, err = mgr.Get(op.ID)
+	assert.NoError(err)
+	found := false
+	for _, l := range o.Logs() {
+		if l.Message == "Output enabled" {
+			found = true
+		}
+	}
+	assert.True(found)
+
+	mgr.Publish(model.NewDataMessage(device, []byte("enabled"), model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{}))
+	deadline = time.Now().Add(time.Second)
+	for counter.count() == msgCount && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(msgCount+1, counter.count())
+}
+
+// Swapping the target must not block when the previous output has stopped
+// reading from its channel.
+func TestRelaySwapWithBlockedOutput(t *testing.T) {
+	assert := require.New(t)
+
+	sub := make(chan interface{})
+	old := make(chan interface{}, 1)
+	r := newRelay(sub, old, eventFilter{})
+
+	// The first message fills the old output's queue and the second blocks
+	// the relay
+	sub <- "1"
+	sub <- "2"
+
+	next := make(chan interface{}, 10)
+	swapped := make(chan chan interface{})
+	go func() {
+		swapped <- r.swap(next, eventFilter{})
+	}()
+	select {
+	case ch := <-swapped:
+		assert.Equal(old, ch)
+		close(ch)
+	case <-time.After(time.Second):
+		assert.Fail("Swap blocked on the old output")
+	}
+
+	sub <- "3"
+	close(sub)
+
+	assert.Equal("1", <-old)
+	_, ok := <-old
+	assert.False(ok)
+
+	var received []interface{}
+	for msg := range next {
+		received = append(received, msg)
+	}
+	assert.Equal([]interface{}{"2", "3"}, received)
+}
@@ -19,11 +19,14 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"sync"
 	"time"
 
+	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/api/apitoolbox"
+	"github.com/eesrc/horde/pkg/metering"
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/output/outputconfig"
 	"github.com/eesrc/horde/pkg/utils/audit"
@@ -75,6 +78,63 @@ func newMQTTConfig(config model.OutputConfig) mqttConfig {
 	return ret
 }
 
+// newMQTTClientOptions creates the client options for the configuration
+func newMQTTClientOptions(mqttConf mqttConfig) *mqtt.ClientOptions {
+	checker := newEndpointChecker(mqttConf.endpoint)
+	opts := mqtt.NewClientOptions()
+	opts.AddBroker(mqttConf.endpoint)
+	opts.SetClientID(mqttConf.clientID)
+	opts.SetKeepAlive(2 * time.Second)
+	opts.SetPingTimeout(1 * time.Second)
+	opts.SetWriteTimeout(1 * time.Second)
+	// If the client auto reconnects it will block until a connection becomes
+	// available. This isn't very helpful if the messages is going to be
+	// queued and the decoding pipeline might drop messages that aren't processed
+	// quickly enough by the clients. The backlog will keep up to 50 messages
+	// in memory until they are discarded.
+	opts.SetAutoReconnect(false)
+	opts.SetMessageChannelDepth(1)
+	opts.SetCleanSession(true)
+	if mqttConf.username != "" {
+		opts.SetUsername(mqttConf.username)
+	}
+	if mqttConf.password != "" {
+		opts.SetPassword(mqttConf.password)
+	}
+
+	if checker.IsSSLScheme() {
+		opts.SetTLSConfig(&tls.Config{
+			InsecureSkipVerify: mqttConf.disableCertCheck,
+		})
+	}
+	return opts
+}
+
+// marshalMQTTMessage converts the data message into the JSON payload that is
+// published to the broker.
+func marshalMQTTMessage(dataMsg model.DataMessage, collectionFieldMask model.FieldMask) ([]byte, error) {
+	tmpColl := model.NewCollection()
+	tmpColl.FieldMask = collectionFieldMask
+	return marshalMQTTOutput(apitoolbox.NewOutputDataMessageFromModel(dataMsg, tmpColl))
+}
+
+// marshalMQTTEvent converts the resource event into the JSON payload that is
+// published to the broker.
+func marshalMQTTEvent(ev model.ResourceEvent, collectionFieldMask model.FieldMask) ([]byte, error) {
+	tmpColl := model.NewCollection()
+	tmpColl.FieldMask = collectionFieldMask
+	return marshalMQTTOutput(apitoolbox.NewOutputEventMessageFromModel(ev, tmpColl))
+}
+
+func marshalMQTTOutput(dataOutput *apipb.OutputDataMessage) ([]byte, error) {
+	ma := apitoolbox.JSONMarshaler()
+	str, err := ma.MarshalToString(dataOutput)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(str), nil
+}
+
 type mqttOutput struct {
 	client              mqtt.Client
 	logs                Logger
@@ -82,6 +142,7 @@ type mqttOutput struct {
 	mutex               sync.Mutex
 	collectionFieldMask model.FieldMask
 	systemFieldMask     model.FieldMask
+	stopped             chan bool
 }
 
 func newMQTT() Output {
@@ -100,6 +161,7 @@ func (m *mqttOutput) Validate(config model.OutputConfig) (model.ErrorMessage, er
 		fieldSpec{outputconfig.MQTTPassword, reflect.String, false},
 		fieldSpec{outputconfig.MQTTUsername, reflect.String, false},
 	})
+	validateEventConfig(config, errs)
 	val, ok := config[outputconfig.MQTTEndpoint]
 	if ok {
 		ep, ok := val.(string)
@@ -131,38 +193,13 @@ func (m *mqttOutput) Start(config model.OutputConfig, collectionFieldMask model.
 		logging.Warning("Invalid config, won't start MQTT output: %+v", config)
 	}
 	mqttConf := newMQTTConfig(config)
-	checker := newEndpointChecker(mqttConf.endpoint)
-	opts := mqtt.NewClientOptions()
 	logging.Debug("Starting MQTT broker with config %+v and field mask %b", mqttConf, m.collectionFieldMask)
-	opts.AddBroker(mqttConf.endpoint)
-	opts.SetClientID(mqttConf.clientID)
-	opts.SetKeepAlive(2 * time.Second)
-	opts.SetPingTimeout(1 * time.Second)
-	opts.SetWriteTimeout(1 * time.Second)
-	// If the client auto reconnects it will block until a connection becomes
-	// available. This isn't very helpful if the messages is going to be
-	// queued and the decoding pipeline might drop messages that aren't processed
-	// quickly enough by the clients. The backlog will keep up to 50 messages
-	// in memory until they are discarded.
-	opts.SetAutoReconnect(false)
-	opts.SetMessageChannelDepth(1)
-	opts.SetCleanSession(true)
-	if mqttConf.username != "" {
-		opts.SetUsername(mqttConf.username)
-	}
-	if mqttConf.password != "" {
-		opts.SetPassword(mqttConf.password)
-	}
-
-	if checker.IsSSLScheme() {
-		opts.SetTLSConfig(&tls.Config{
-			InsecureSkipVerify: mqttConf.disableCertCheck,
-		})
-	}
+	opts := newMQTTClientOptions(mqttConf)
 
 	m.client = mqtt.NewClient(opts)
+	m.stopped = make(chan bool)
 
-	go m.sender(messages, mqttConf)
+	go m.sender(messages, mqttConf, m.stopped)
 }
 
 func (m *mqttOutput) connect() {
@@ -174,7 +211,8 @@ func (m *mqttOutput) connect() {
 	m.logs.Append("Connected to broker")
 }
 
-func (m *mqttOutput) sender(messages <-chan interface{}, config mqttConfig) {
+func (m *mqttOutput) sender(messages <-chan interface{}, config mqttConfig, stopped chan bool) {
+	defer close(stopped)
 	if m.client == nil {
 		logging.Warning("MQTT client is nil. Terminating output to %s", config.endpoint)
 		return
@@ -193,22 +231,23 @@ func (m *mqttOutput) sender(messages <-chan interface{}, config mqttConfig) {
 		m.mutex.Unlock()
 		// Payload is an data structure. Convert into same format as the websocket
 		// output (apiDeviceData) and pass on.
+		var buf []byte
+		var err error
 		dataMsg, ok := msg.(model.DataMessage)
-		if !ok {
+		switch v := msg.(type) {
+		case model.DataMessage:
+			buf, err = marshalMQTTMessage(v, m.collectionFieldMask)
+		case model.ResourceEvent:
+			buf, err = marshalMQTTEvent(v, m.collectionFieldMask)
+		default:
 			logging.Warning("Didn't receive a DataMessage type on channel but got %T. Silently dropping it.", msg)
 			continue
 		}
-		logging.Info("Making new output from model message. Field mask = %b", m.collectionFieldMask)
-		tmpColl := model.NewCollection()
-		tmpColl.FieldMask = m.collectionFieldMask
-		dataOutput := apitoolbox.NewOutputDataMessageFromModel(dataMsg, tmpColl)
-		ma := apitoolbox.JSONMarshaler()
-		str, err := ma.MarshalToString(dataOutput)
 		if err != nil {
-			logging.Warning("Unable to marshal %T into JSON: %v. Silently dropping it.", dataMsg, err)
+			logging.Warning("Unable to marshal %T into JSON: %v. Silently dropping it.", msg, err)
 			continue
 		}
-		token := m.client.Publish(config.topicName, qos, retained, []byte(str))
+		token := m.client.Publish(config.topicName, qos, retained, buf)
 		token.Wait()
 		if err := token.Error(); err != nil {
 			logging.Info("Unable to send message to MQTT server %s: %v", config.endpoint, err)
@@ -222,6 +261,10 @@ func (m *mqttOutput) sender(messages <-chan interface{}, config mqttConfig) {
 		m.status.Forwarded++
 		m.mutex.Unlock()
 		metrics.DefaultCoreCounters.MessagesForwardMQTT.Add(1)
+		if !ok {
+			continue
+		}
+		metering.DefaultMeter.OutputDelivered(dataMsg.Device.CollectionID, 1)
 		audit.Log("MQTT: Forwarded %d bytes from device with IMSI %d, Device ID=%s, Collection ID=%s",
 			len(dataMsg.Payload), dataMsg.Device.IMSI,
 			dataMsg.Device.ID.String(), dataMsg.Device.CollectionID.String())
@@ -236,6 +279,12 @@ func (m *mqttOutput) Stop(timeout time.Duration) {
 	if m.client == nil {
 		return
 	}
+	// The sender drains the queue if the message channel is closed. Wait for
+	// it to complete before disconnecting.
+	select {
+	case <-m.stopped:
+	case <-time.After(timeout):
+	}
 	defer func() {
 		if r := recover(); r != nil {
 			logging.Warning("Recovered from panic: %v", r)
@@ -254,3 +303,71 @@ func (m *mqttOutput) Logs() []model.OutputLogEntry {
 func (m *mqttOutput) Status() model.OutputStatus {
 	return m.status
 }
+
+// Test connects to the broker, publishes a single message and disconnects.
+// The TLS handshake is traced through a separate connection to the broker
+// since the MQTT client doesn't expose the connection state.
+func (m *mqttOutput) Test(config model.OutputConfig, collectionFieldMask model.FieldMask, systemFieldMask model.FieldMask, msg model.DataMessage, timeout time.Duration) (ret model.OutputTestResult) {
+	start := time.Now()
+	defer func() {
+		ret.Total = time.Since(start)
+	}()
+
+	mqttConf := newMQTTConfig(config)
+	checker := newEndpointChecker(mqttConf.endpoint)
+	addrs, err := net.LookupHost(checker.Host())
+	ret.DNSLookup = time.Since(start)
+	if err != nil {
+		ret.Message = fmt.Sprintf("Unable to resolve host: %v", err)
+		return ret
+	}
+	if len(addrs) > 0 {
+		ret.RemoteAddress = net.JoinHostPort(addrs[0], checker.Port())
+	}
+
+	if checker.IsSSLScheme() {
+		ret.TLS = traceTLSHandshake(ret.RemoteAddress, checker.Host(), mqttConf.disableCertCheck, timeout)
+		ret.TLSHandshake = time.Since(start)
+		if !ret.TLS.HandshakeComplete {
+			ret.Message = fmt.Sprintf("TLS handshake failed: %s", ret.TLS.Error)
+			return ret
+		}
+	}
+
+	opts := newMQTTClientOptions(mqttConf)
+	opts.SetConnectTimeout(timeout)
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(timeout) {
+		ret.Message = "Timed out connecting to broker"
+		return ret
+	}
+	ret.Connect = time.Since(start)
+	if ct, ok := token.(*mqtt.ConnectToken); ok {
+		ret.MQTTReturnCode = int(ct.ReturnCode())
+	}
+	if err := token.Error(); err != nil {
+		ret.Message = fmt.Sprintf("Unable to connect to broker: %v", err)
+		return ret
+	}
+	defer client.Disconnect(250)
+
+	buf, err := marshalMQTTMessage(msg, collectionFieldMask)
+	if err != nil {
+		ret.Message = fmt.Sprintf("Unable to marshal message: %v", err)
+		return ret
+	}
+	token = client.Publish(mqttConf.topicName, byte(1), false, buf)
+	if !token.WaitTimeout(timeout) {
+		ret.Message = "Timed out publishing message"
+		return ret
+	}
+	ret.FirstByte = time.Since(start)
+	if err := token.Error(); err != nil {
+		ret.Message = fmt.Sprintf("Unable to publish message: %v", err)
+		return ret
+	}
+	ret.Success = true
+	ret.Message = fmt.Sprintf("Published %d bytes to %s", len(buf), mqttConf.topicName)
+	return ret
+}
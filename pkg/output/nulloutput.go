@@ -24,7 +24,7 @@ import (
 
 // NullOutput is an output that will discard all received messages.
 type nullOutput struct {
-	started   bool
+	started   int32
 	terminate chan bool
 	received  int32
 }
@@ -57,7 +57,7 @@ func (n *nullOutput) Validate(config model.OutputConfig) (model.ErrorMessage, er
 
 func (n *nullOutput) Start(config model.OutputConfig, collectionFieldMask model.FieldMask, systemFieldMask model.FieldMask, message <-chan interface{}) {
 	go n.messageReader(message)
-	n.started = true
+	atomic.StoreInt32(&n.started, 1)
 }
 
 func (n *nullOutput) Stop(timeout time.Duration) {
@@ -67,12 +67,12 @@ func (n *nullOutput) Stop(timeout time.Duration) {
 	case <-time.After(timeout):
 		break
 	}
-	n.started = false
+	atomic.StoreInt32(&n.started, 0)
 }
 
 func (n *nullOutput) Logs() []model.OutputLogEntry {
 	var le []model.OutputLogEntry
-	if n.started {
+	if atomic.LoadInt32(&n.started) == 1 {
 		le = append(le, model.OutputLogEntry{Message: "started", Time: time.Now(), Repeated: 0})
 	}
 	return le
@@ -86,3 +86,7 @@ func (n *nullOutput) Status() model.OutputStatus {
 		Retransmits: 0,
 	}
 }
+
+func (n *nullOutput) Test(config model.OutputConfig, collectionFieldMask model.FieldMask, systemFieldMask model.FieldMask, msg model.DataMessage, timeout time.Duration) model.OutputTestResult {
+	return model.OutputTestResult{Success: true, Message: "Message discarded"}
+}
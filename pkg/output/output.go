@@ -54,6 +54,10 @@ type Output interface {
 	Logs() []model.OutputLogEntry
 	// Status reports the internal status of the forwarder.
 	Status() model.OutputStatus
+	// Test sends a single message through the output and returns the delivery
+	// trace. The output doesn't have to be started to run the test and the
+	// test won't affect the logs or status for the output.
+	Test(config model.OutputConfig, collectionFieldMask model.FieldMask, systemFieldMask model.FieldMask, msg model.DataMessage, timeout time.Duration) model.OutputTestResult
 }
 
 // NewOutput creates a new output. It will be running until it shuts down.
@@ -0,0 +1,3 @@
+package outputcluster
+
+//go:generate protoc -I=../../../protobuf --go_out=plugins=grpc:. ../../../protobuf/outputcluster.proto
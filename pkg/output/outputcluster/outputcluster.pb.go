@@ -0,0 +1,1591 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: outputcluster.proto
+
+package outputcluster
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// MaintenanceWindow is the firmware maintenance window for a collection or
+// device.
+type MaintenanceWindow struct {
+	Schedule             string   `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Duration             int64    `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	TimeZone             string   `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MaintenanceWindow) Reset()         { *m = MaintenanceWindow{} }
+func (m *MaintenanceWindow) String() string { return proto.CompactTextString(m) }
+func (*MaintenanceWindow) ProtoMessage()    {}
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{0}
+}
+
+func (m *MaintenanceWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MaintenanceWindow.Unmarshal(m, b)
+}
+func (m *MaintenanceWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MaintenanceWindow.Marshal(b, m, deterministic)
+}
+func (m *MaintenanceWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaintenanceWindow.Merge(m, src)
+}
+func (m *MaintenanceWindow) XXX_Size() int {
+	return xxx_messageInfo_MaintenanceWindow.Size(m)
+}
+func (m *MaintenanceWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaintenanceWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaintenanceWindow proto.InternalMessageInfo
+
+func (m *MaintenanceWindow) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
+
+func (m *MaintenanceWindow) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *MaintenanceWindow) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+// NetworkMetadata is the network state for a device.
+type NetworkMetadata struct {
+	AllocatedIp          string   `protobuf:"bytes,1,opt,name=allocated_ip,json=allocatedIp,proto3" json:"allocated_ip,omitempty"`
+	AllocatedAt          int64    `protobuf:"varint,2,opt,name=allocated_at,json=allocatedAt,proto3" json:"allocated_at,omitempty"`
+	CellId               int64    `protobuf:"varint,3,opt,name=cell_id,json=cellId,proto3" json:"cell_id,omitempty"`
+	ApnId                int32    `protobuf:"varint,4,opt,name=apn_id,json=apnId,proto3" json:"apn_id,omitempty"`
+	NasId                int32    `protobuf:"varint,5,opt,name=nas_id,json=nasId,proto3" json:"nas_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkMetadata) Reset()         { *m = NetworkMetadata{} }
+func (m *NetworkMetadata) String() string { return proto.CompactTextString(m) }
+func (*NetworkMetadata) ProtoMessage()    {}
+func (*NetworkMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{1}
+}
+
+func (m *NetworkMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkMetadata.Unmarshal(m, b)
+}
+func (m *NetworkMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkMetadata.Marshal(b, m, deterministic)
+}
+func (m *NetworkMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkMetadata.Merge(m, src)
+}
+func (m *NetworkMetadata) XXX_Size() int {
+	return xxx_messageInfo_NetworkMetadata.Size(m)
+}
+func (m *NetworkMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkMetadata proto.InternalMessageInfo
+
+func (m *NetworkMetadata) GetAllocatedIp() string {
+	if m != nil {
+		return m.AllocatedIp
+	}
+	return ""
+}
+
+func (m *NetworkMetadata) GetAllocatedAt() int64 {
+	if m != nil {
+		return m.AllocatedAt
+	}
+	return 0
+}
+
+func (m *NetworkMetadata) GetCellId() int64 {
+	if m != nil {
+		return m.CellId
+	}
+	return 0
+}
+
+func (m *NetworkMetadata) GetApnId() int32 {
+	if m != nil {
+		return m.ApnId
+	}
+	return 0
+}
+
+func (m *NetworkMetadata) GetNasId() int32 {
+	if m != nil {
+		return m.NasId
+	}
+	return 0
+}
+
+// DeviceFirmware is the firmware metadata for a device.
+type DeviceFirmware struct {
+	CurrentFirmwareId    uint64             `protobuf:"varint,1,opt,name=current_firmware_id,json=currentFirmwareId,proto3" json:"current_firmware_id,omitempty"`
+	TargetFirmwareId     uint64             `protobuf:"varint,2,opt,name=target_firmware_id,json=targetFirmwareId,proto3" json:"target_firmware_id,omitempty"`
+	FirmwareVersion      string             `protobuf:"bytes,3,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	SerialNumber         string             `protobuf:"bytes,4,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	ModelNumber          string             `protobuf:"bytes,5,opt,name=model_number,json=modelNumber,proto3" json:"model_number,omitempty"`
+	Manufacturer         string             `protobuf:"bytes,6,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	State                int32              `protobuf:"varint,7,opt,name=state,proto3" json:"state,omitempty"`
+	StateMessage         string             `protobuf:"bytes,8,opt,name=state_message,json=stateMessage,proto3" json:"state_message,omitempty"`
+	MaintenanceWindow    *MaintenanceWindow `protobuf:"bytes,9,opt,name=maintenance_window,json=maintenanceWindow,proto3" json:"maintenance_window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DeviceFirmware) Reset()         { *m = DeviceFirmware{} }
+func (m *DeviceFirmware) String() string { return proto.CompactTextString(m) }
+func (*DeviceFirmware) ProtoMessage()    {}
+func (*DeviceFirmware) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{2}
+}
+
+func (m *DeviceFirmware) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceFirmware.Unmarshal(m, b)
+}
+func (m *DeviceFirmware) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceFirmware.Marshal(b, m, deterministic)
+}
+func (m *DeviceFirmware) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceFirmware.Merge(m, src)
+}
+func (m *DeviceFirmware) XXX_Size() int {
+	return xxx_messageInfo_DeviceFirmware.Size(m)
+}
+func (m *DeviceFirmware) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceFirmware.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceFirmware proto.InternalMessageInfo
+
+func (m *DeviceFirmware) GetCurrentFirmwareId() uint64 {
+	if m != nil {
+		return m.CurrentFirmwareId
+	}
+	return 0
+}
+
+func (m *DeviceFirmware) GetTargetFirmwareId() uint64 {
+	if m != nil {
+		return m.TargetFirmwareId
+	}
+	return 0
+}
+
+func (m *DeviceFirmware) GetFirmwareVersion() string {
+	if m != nil {
+		return m.FirmwareVersion
+	}
+	return ""
+}
+
+func (m *DeviceFirmware) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *DeviceFirmware) GetModelNumber() string {
+	if m != nil {
+		return m.ModelNumber
+	}
+	return ""
+}
+
+func (m *DeviceFirmware) GetManufacturer() string {
+	if m != nil {
+		return m.Manufacturer
+	}
+	return ""
+}
+
+func (m *DeviceFirmware) GetState() int32 {
+	if m != nil {
+		return m.State
+	}
+	return 0
+}
+
+func (m *DeviceFirmware) GetStateMessage() string {
+	if m != nil {
+		return m.StateMessage
+	}
+	return ""
+}
+
+func (m *DeviceFirmware) GetMaintenanceWindow() *MaintenanceWindow {
+	if m != nil {
+		return m.MaintenanceWindow
+	}
+	return nil
+}
+
+type Device struct {
+	Id                   uint64            `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Imsi                 int64             `protobuf:"varint,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	Imei                 int64             `protobuf:"varint,3,opt,name=imei,proto3" json:"imei,omitempty"`
+	CollectionId         uint64            `protobuf:"varint,4,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Network              *NetworkMetadata  `protobuf:"bytes,5,opt,name=network,proto3" json:"network,omitempty"`
+	Firmware             *DeviceFirmware   `protobuf:"bytes,6,opt,name=firmware,proto3" json:"firmware,omitempty"`
+	Tags                 map[string]string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Device) Reset()         { *m = Device{} }
+func (m *Device) String() string { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()    {}
+func (*Device) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{3}
+}
+
+func (m *Device) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Device.Unmarshal(m, b)
+}
+func (m *Device) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Device.Marshal(b, m, deterministic)
+}
+func (m *Device) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Device.Merge(m, src)
+}
+func (m *Device) XXX_Size() int {
+	return xxx_messageInfo_Device.Size(m)
+}
+func (m *Device) XXX_DiscardUnknown() {
+	xxx_messageInfo_Device.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Device proto.InternalMessageInfo
+
+func (m *Device) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Device) GetImsi() int64 {
+	if m != nil {
+		return m.Imsi
+	}
+	return 0
+}
+
+func (m *Device) GetImei() int64 {
+	if m != nil {
+		return m.Imei
+	}
+	return 0
+}
+
+func (m *Device) GetCollectionId() uint64 {
+	if m != nil {
+		return m.CollectionId
+	}
+	return 0
+}
+
+func (m *Device) GetNetwork() *NetworkMetadata {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+func (m *Device) GetFirmware() *DeviceFirmware {
+	if m != nil {
+		return m.Firmware
+	}
+	return nil
+}
+
+func (m *Device) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+// CollectionFirmware is the firmware settings for a collection.
+type CollectionFirmware struct {
+	CurrentFirmwareId    uint64             `protobuf:"varint,1,opt,name=current_firmware_id,json=currentFirmwareId,proto3" json:"current_firmware_id,omitempty"`
+	TargetFirmwareId     uint64             `protobuf:"varint,2,opt,name=target_firmware_id,json=targetFirmwareId,proto3" json:"target_firmware_id,omitempty"`
+	Management           int32              `protobuf:"varint,3,opt,name=management,proto3" json:"management,omitempty"`
+	RequireSignature     bool               `protobuf:"varint,4,opt,name=require_signature,json=requireSignature,proto3" json:"require_signature,omitempty"`
+	MaintenanceWindow    *MaintenanceWindow `protobuf:"bytes,5,opt,name=maintenance_window,json=maintenanceWindow,proto3" json:"maintenance_window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CollectionFirmware) Reset()         { *m = CollectionFirmware{} }
+func (m *CollectionFirmware) String() string { return proto.CompactTextString(m) }
+func (*CollectionFirmware) ProtoMessage()    {}
+func (*CollectionFirmware) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{4}
+}
+
+func (m *CollectionFirmware) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionFirmware.Unmarshal(m, b)
+}
+func (m *CollectionFirmware) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionFirmware.Marshal(b, m, deterministic)
+}
+func (m *CollectionFirmware) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionFirmware.Merge(m, src)
+}
+func (m *CollectionFirmware) XXX_Size() int {
+	return xxx_messageInfo_CollectionFirmware.Size(m)
+}
+func (m *CollectionFirmware) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionFirmware.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionFirmware proto.InternalMessageInfo
+
+func (m *CollectionFirmware) GetCurrentFirmwareId() uint64 {
+	if m != nil {
+		return m.CurrentFirmwareId
+	}
+	return 0
+}
+
+func (m *CollectionFirmware) GetTargetFirmwareId() uint64 {
+	if m != nil {
+		return m.TargetFirmwareId
+	}
+	return 0
+}
+
+func (m *CollectionFirmware) GetManagement() int32 {
+	if m != nil {
+		return m.Management
+	}
+	return 0
+}
+
+func (m *CollectionFirmware) GetRequireSignature() bool {
+	if m != nil {
+		return m.RequireSignature
+	}
+	return false
+}
+
+func (m *CollectionFirmware) GetMaintenanceWindow() *MaintenanceWindow {
+	if m != nil {
+		return m.MaintenanceWindow
+	}
+	return nil
+}
+
+type Collection struct {
+	Id                   uint64              `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TeamId               uint64              `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	FieldMask            uint64              `protobuf:"varint,3,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+	Firmware             *CollectionFirmware `protobuf:"bytes,4,opt,name=firmware,proto3" json:"firmware,omitempty"`
+	Tags                 map[string]string   `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Collection) Reset()         { *m = Collection{} }
+func (m *Collection) String() string { return proto.CompactTextString(m) }
+func (*Collection) ProtoMessage()    {}
+func (*Collection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{5}
+}
+
+func (m *Collection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Collection.Unmarshal(m, b)
+}
+func (m *Collection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Collection.Marshal(b, m, deterministic)
+}
+func (m *Collection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Collection.Merge(m, src)
+}
+func (m *Collection) XXX_Size() int {
+	return xxx_messageInfo_Collection.Size(m)
+}
+func (m *Collection) XXX_DiscardUnknown() {
+	xxx_messageInfo_Collection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Collection proto.InternalMessageInfo
+
+func (m *Collection) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Collection) GetTeamId() uint64 {
+	if m != nil {
+		return m.TeamId
+	}
+	return 0
+}
+
+func (m *Collection) GetFieldMask() uint64 {
+	if m != nil {
+		return m.FieldMask
+	}
+	return 0
+}
+
+func (m *Collection) GetFirmware() *CollectionFirmware {
+	if m != nil {
+		return m.Firmware
+	}
+	return nil
+}
+
+func (m *Collection) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+// ConfigValue is a single value in the output configuration. The values
+// have the same types as the JSON-encoded configuration.
+type ConfigValue struct {
+	// Types that are valid to be assigned to Value:
+	//	*ConfigValue_StringValue
+	//	*ConfigValue_NumberValue
+	//	*ConfigValue_BoolValue
+	//	*ConfigValue_ListValue
+	Value                isConfigValue_Value `protobuf_oneof:"value"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ConfigValue) Reset()         { *m = ConfigValue{} }
+func (m *ConfigValue) String() string { return proto.CompactTextString(m) }
+func (*ConfigValue) ProtoMessage()    {}
+func (*ConfigValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{6}
+}
+
+func (m *ConfigValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigValue.Unmarshal(m, b)
+}
+func (m *ConfigValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigValue.Marshal(b, m, deterministic)
+}
+func (m *ConfigValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigValue.Merge(m, src)
+}
+func (m *ConfigValue) XXX_Size() int {
+	return xxx_messageInfo_ConfigValue.Size(m)
+}
+func (m *ConfigValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigValue proto.InternalMessageInfo
+
+type isConfigValue_Value interface {
+	isConfigValue_Value()
+}
+
+type ConfigValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type ConfigValue_NumberValue struct {
+	NumberValue float64 `protobuf:"fixed64,2,opt,name=number_value,json=numberValue,proto3,oneof"`
+}
+
+type ConfigValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,3,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type ConfigValue_ListValue struct {
+	ListValue *ConfigList `protobuf:"bytes,4,opt,name=list_value,json=listValue,proto3,oneof"`
+}
+
+func (*ConfigValue_StringValue) isConfigValue_Value() {}
+
+func (*ConfigValue_NumberValue) isConfigValue_Value() {}
+
+func (*ConfigValue_BoolValue) isConfigValue_Value() {}
+
+func (*ConfigValue_ListValue) isConfigValue_Value() {}
+
+func (m *ConfigValue) GetValue() isConfigValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ConfigValue) GetStringValue() string {
+	if x, ok := m.GetValue().(*ConfigValue_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (m *ConfigValue) GetNumberValue() float64 {
+	if x, ok := m.GetValue().(*ConfigValue_NumberValue); ok {
+		return x.NumberValue
+	}
+	return 0
+}
+
+func (m *ConfigValue) GetBoolValue() bool {
+	if x, ok := m.GetValue().(*ConfigValue_BoolValue); ok {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (m *ConfigValue) GetListValue() *ConfigList {
+	if x, ok := m.GetValue().(*ConfigValue_ListValue); ok {
+		return x.ListValue
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ConfigValue) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ConfigValue_StringValue)(nil),
+		(*ConfigValue_NumberValue)(nil),
+		(*ConfigValue_BoolValue)(nil),
+		(*ConfigValue_ListValue)(nil),
+	}
+}
+
+type ConfigList struct {
+	Values               []*ConfigValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ConfigList) Reset()         { *m = ConfigList{} }
+func (m *ConfigList) String() string { return proto.CompactTextString(m) }
+func (*ConfigList) ProtoMessage()    {}
+func (*ConfigList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{7}
+}
+
+func (m *ConfigList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigList.Unmarshal(m, b)
+}
+func (m *ConfigList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigList.Marshal(b, m, deterministic)
+}
+func (m *ConfigList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigList.Merge(m, src)
+}
+func (m *ConfigList) XXX_Size() int {
+	return xxx_messageInfo_ConfigList.Size(m)
+}
+func (m *ConfigList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigList proto.InternalMessageInfo
+
+func (m *ConfigList) GetValues() []*ConfigValue {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type Output struct {
+	Id                   uint64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 string                  `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Config               map[string]*ConfigValue `protobuf:"bytes,3,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CollectionId         uint64                  `protobuf:"varint,4,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Enabled              bool                    `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	CollectionFieldMask  uint64                  `protobuf:"varint,6,opt,name=collection_field_mask,json=collectionFieldMask,proto3" json:"collection_field_mask,omitempty"`
+	Tags                 map[string]string       `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *Output) Reset()         { *m = Output{} }
+func (m *Output) String() string { return proto.CompactTextString(m) }
+func (*Output) ProtoMessage()    {}
+func (*Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{8}
+}
+
+func (m *Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Output.Unmarshal(m, b)
+}
+func (m *Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Output.Marshal(b, m, deterministic)
+}
+func (m *Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Output.Merge(m, src)
+}
+func (m *Output) XXX_Size() int {
+	return xxx_messageInfo_Output.Size(m)
+}
+func (m *Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Output proto.InternalMessageInfo
+
+func (m *Output) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Output) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Output) GetConfig() map[string]*ConfigValue {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *Output) GetCollectionId() uint64 {
+	if m != nil {
+		return m.CollectionId
+	}
+	return 0
+}
+
+func (m *Output) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *Output) GetCollectionFieldMask() uint64 {
+	if m != nil {
+		return m.CollectionFieldMask
+	}
+	return 0
+}
+
+func (m *Output) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+// DataMessage is an upstream message from a device.
+type DataMessage struct {
+	Device               *Device  `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Received             int64    `protobuf:"varint,2,opt,name=received,proto3" json:"received,omitempty"`
+	Payload              []byte   `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Transport            int32    `protobuf:"varint,4,opt,name=transport,proto3" json:"transport,omitempty"`
+	UdpLocalPort         int32    `protobuf:"varint,5,opt,name=udp_local_port,json=udpLocalPort,proto3" json:"udp_local_port,omitempty"`
+	UdpRemotePort        int32    `protobuf:"varint,6,opt,name=udp_remote_port,json=udpRemotePort,proto3" json:"udp_remote_port,omitempty"`
+	CoapCode             string   `protobuf:"bytes,7,opt,name=coap_code,json=coapCode,proto3" json:"coap_code,omitempty"`
+	CoapPath             string   `protobuf:"bytes,8,opt,name=coap_path,json=coapPath,proto3" json:"coap_path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataMessage) Reset()         { *m = DataMessage{} }
+func (m *DataMessage) String() string { return proto.CompactTextString(m) }
+func (*DataMessage) ProtoMessage()    {}
+func (*DataMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{9}
+}
+
+func (m *DataMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataMessage.Unmarshal(m, b)
+}
+func (m *DataMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataMessage.Marshal(b, m, deterministic)
+}
+func (m *DataMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataMessage.Merge(m, src)
+}
+func (m *DataMessage) XXX_Size() int {
+	return xxx_messageInfo_DataMessage.Size(m)
+}
+func (m *DataMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataMessage proto.InternalMessageInfo
+
+func (m *DataMessage) GetDevice() *Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+func (m *DataMessage) GetReceived() int64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
+func (m *DataMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *DataMessage) GetTransport() int32 {
+	if m != nil {
+		return m.Transport
+	}
+	return 0
+}
+
+func (m *DataMessage) GetUdpLocalPort() int32 {
+	if m != nil {
+		return m.UdpLocalPort
+	}
+	return 0
+}
+
+func (m *DataMessage) GetUdpRemotePort() int32 {
+	if m != nil {
+		return m.UdpRemotePort
+	}
+	return 0
+}
+
+func (m *DataMessage) GetCoapCode() string {
+	if m != nil {
+		return m.CoapCode
+	}
+	return ""
+}
+
+func (m *DataMessage) GetCoapPath() string {
+	if m != nil {
+		return m.CoapPath
+	}
+	return ""
+}
+
+// ResourceEvent is a change to a device, collection or output.
+type ResourceEvent struct {
+	Type                 string      `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Time                 int64       `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	CollectionId         uint64      `protobuf:"varint,3,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	PreviousCollectionId uint64      `protobuf:"varint,4,opt,name=previous_collection_id,json=previousCollectionId,proto3" json:"previous_collection_id,omitempty"`
+	Device               *Device     `protobuf:"bytes,5,opt,name=device,proto3" json:"device,omitempty"`
+	Collection           *Collection `protobuf:"bytes,6,opt,name=collection,proto3" json:"collection,omitempty"`
+	Output               *Output     `protobuf:"bytes,7,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ResourceEvent) Reset()         { *m = ResourceEvent{} }
+func (m *ResourceEvent) String() string { return proto.CompactTextString(m) }
+func (*ResourceEvent) ProtoMessage()    {}
+func (*ResourceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{10}
+}
+
+func (m *ResourceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceEvent.Unmarshal(m, b)
+}
+func (m *ResourceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceEvent.Marshal(b, m, deterministic)
+}
+func (m *ResourceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceEvent.Merge(m, src)
+}
+func (m *ResourceEvent) XXX_Size() int {
+	return xxx_messageInfo_ResourceEvent.Size(m)
+}
+func (m *ResourceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceEvent proto.InternalMessageInfo
+
+func (m *ResourceEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ResourceEvent) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ResourceEvent) GetCollectionId() uint64 {
+	if m != nil {
+		return m.CollectionId
+	}
+	return 0
+}
+
+func (m *ResourceEvent) GetPreviousCollectionId() uint64 {
+	if m != nil {
+		return m.PreviousCollectionId
+	}
+	return 0
+}
+
+func (m *ResourceEvent) GetDevice() *Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+func (m *ResourceEvent) GetCollection() *Collection {
+	if m != nil {
+		return m.Collection
+	}
+	return nil
+}
+
+func (m *ResourceEvent) GetOutput() *Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+// PublishRequest holds a single upstream message or resource event for the
+// outputs on the node.
+type PublishRequest struct {
+	Message              *DataMessage   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Event                *ResourceEvent `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PublishRequest) Reset()         { *m = PublishRequest{} }
+func (m *PublishRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishRequest) ProtoMessage()    {}
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{11}
+}
+
+func (m *PublishRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishRequest.Unmarshal(m, b)
+}
+func (m *PublishRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishRequest.Merge(m, src)
+}
+func (m *PublishRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishRequest.Size(m)
+}
+func (m *PublishRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishRequest proto.InternalMessageInfo
+
+func (m *PublishRequest) GetMessage() *DataMessage {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *PublishRequest) GetEvent() *ResourceEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type PublishResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishResponse) Reset()         { *m = PublishResponse{} }
+func (m *PublishResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishResponse) ProtoMessage()    {}
+func (*PublishResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{12}
+}
+
+func (m *PublishResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishResponse.Unmarshal(m, b)
+}
+func (m *PublishResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishResponse.Merge(m, src)
+}
+func (m *PublishResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishResponse.Size(m)
+}
+func (m *PublishResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishResponse proto.InternalMessageInfo
+
+// OutputRequest identifies an output running on the node.
+type OutputRequest struct {
+	OutputId             int64    `protobuf:"varint,1,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OutputRequest) Reset()         { *m = OutputRequest{} }
+func (m *OutputRequest) String() string { return proto.CompactTextString(m) }
+func (*OutputRequest) ProtoMessage()    {}
+func (*OutputRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{13}
+}
+
+func (m *OutputRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputRequest.Unmarshal(m, b)
+}
+func (m *OutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputRequest.Marshal(b, m, deterministic)
+}
+func (m *OutputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputRequest.Merge(m, src)
+}
+func (m *OutputRequest) XXX_Size() int {
+	return xxx_messageInfo_OutputRequest.Size(m)
+}
+func (m *OutputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputRequest proto.InternalMessageInfo
+
+func (m *OutputRequest) GetOutputId() int64 {
+	if m != nil {
+		return m.OutputId
+	}
+	return 0
+}
+
+// LogEntry is a single log entry for an output.
+type LogEntry struct {
+	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Time                 int64    `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Repeated             int32    `protobuf:"varint,3,opt,name=repeated,proto3" json:"repeated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{14}
+}
+
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+}
+func (m *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(m, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return xxx_messageInfo_LogEntry.Size(m)
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+
+func (m *LogEntry) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *LogEntry) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *LogEntry) GetRepeated() int32 {
+	if m != nil {
+		return m.Repeated
+	}
+	return 0
+}
+
+// OutputInfoResponse holds the logs and status for an output.
+type OutputInfoResponse struct {
+	Logs                 []*LogEntry `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	Forwarded            int64       `protobuf:"varint,2,opt,name=forwarded,proto3" json:"forwarded,omitempty"`
+	Received             int64       `protobuf:"varint,3,opt,name=received,proto3" json:"received,omitempty"`
+	ErrorCount           int64       `protobuf:"varint,4,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	Retransmits          int64       `protobuf:"varint,5,opt,name=retransmits,proto3" json:"retransmits,omitempty"`
+	Throttled            int64       `protobuf:"varint,6,opt,name=throttled,proto3" json:"throttled,omitempty"`
+	Queued               int64       `protobuf:"varint,7,opt,name=queued,proto3" json:"queued,omitempty"`
+	Dropped              int64       `protobuf:"varint,8,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *OutputInfoResponse) Reset()         { *m = OutputInfoResponse{} }
+func (m *OutputInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*OutputInfoResponse) ProtoMessage()    {}
+func (*OutputInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{15}
+}
+
+func (m *OutputInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputInfoResponse.Unmarshal(m, b)
+}
+func (m *OutputInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *OutputInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputInfoResponse.Merge(m, src)
+}
+func (m *OutputInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_OutputInfoResponse.Size(m)
+}
+func (m *OutputInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputInfoResponse proto.InternalMessageInfo
+
+func (m *OutputInfoResponse) GetLogs() []*LogEntry {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *OutputInfoResponse) GetForwarded() int64 {
+	if m != nil {
+		return m.Forwarded
+	}
+	return 0
+}
+
+func (m *OutputInfoResponse) GetReceived() int64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
+func (m *OutputInfoResponse) GetErrorCount() int64 {
+	if m != nil {
+		return m.ErrorCount
+	}
+	return 0
+}
+
+func (m *OutputInfoResponse) GetRetransmits() int64 {
+	if m != nil {
+		return m.Retransmits
+	}
+	return 0
+}
+
+func (m *OutputInfoResponse) GetThrottled() int64 {
+	if m != nil {
+		return m.Throttled
+	}
+	return 0
+}
+
+func (m *OutputInfoResponse) GetQueued() int64 {
+	if m != nil {
+		return m.Queued
+	}
+	return 0
+}
+
+func (m *OutputInfoResponse) GetDropped() int64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+// UpdateRequest holds a new configuration for an output.
+type UpdateRequest struct {
+	Output               *Output  `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	SystemFieldMask      int64    `protobuf:"varint,2,opt,name=system_field_mask,json=systemFieldMask,proto3" json:"system_field_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
+func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRequest) ProtoMessage()    {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{16}
+}
+
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+}
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(m, src)
+}
+func (m *UpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRequest.Size(m)
+}
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+
+func (m *UpdateRequest) GetOutput() *Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *UpdateRequest) GetSystemFieldMask() int64 {
+	if m != nil {
+		return m.SystemFieldMask
+	}
+	return 0
+}
+
+type UpdateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
+func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateResponse) ProtoMessage()    {}
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{17}
+}
+
+func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
+}
+func (m *UpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateResponse.Merge(m, src)
+}
+func (m *UpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateResponse.Size(m)
+}
+func (m *UpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateResponse proto.InternalMessageInfo
+
+type StopResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopResponse) Reset()         { *m = StopResponse{} }
+func (m *StopResponse) String() string { return proto.CompactTextString(m) }
+func (*StopResponse) ProtoMessage()    {}
+func (*StopResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b49eed9e7abbc90e, []int{18}
+}
+
+func (m *StopResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopResponse.Unmarshal(m, b)
+}
+func (m *StopResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopResponse.Marshal(b, m, deterministic)
+}
+func (m *StopResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopResponse.Merge(m, src)
+}
+func (m *StopResponse) XXX_Size() int {
+	return xxx_messageInfo_StopResponse.Size(m)
+}
+func (m *StopResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*MaintenanceWindow)(nil), "outputcluster.MaintenanceWindow")
+	proto.RegisterType((*NetworkMetadata)(nil), "outputcluster.NetworkMetadata")
+	proto.RegisterType((*DeviceFirmware)(nil), "outputcluster.DeviceFirmware")
+	proto.RegisterType((*Device)(nil), "outputcluster.Device")
+	proto.RegisterMapType((map[string]string)(nil), "outputcluster.Device.TagsEntry")
+	proto.RegisterType((*CollectionFirmware)(nil), "outputcluster.CollectionFirmware")
+	proto.RegisterType((*Collection)(nil), "outputcluster.Collection")
+	proto.RegisterMapType((map[string]string)(nil), "outputcluster.Collection.TagsEntry")
+	proto.RegisterType((*ConfigValue)(nil), "outputcluster.ConfigValue")
+	proto.RegisterType((*ConfigList)(nil), "outputcluster.ConfigList")
+	proto.RegisterType((*Output)(nil), "outputcluster.Output")
+	proto.RegisterMapType((map[string]*ConfigValue)(nil), "outputcluster.Output.ConfigEntry")
+	proto.RegisterMapType((map[string]string)(nil), "outputcluster.Output.TagsEntry")
+	proto.RegisterType((*DataMessage)(nil), "outputcluster.DataMessage")
+	proto.RegisterType((*ResourceEvent)(nil), "outputcluster.ResourceEvent")
+	proto.RegisterType((*PublishRequest)(nil), "outputcluster.PublishRequest")
+	proto.RegisterType((*PublishResponse)(nil), "outputcluster.PublishResponse")
+	proto.RegisterType((*OutputRequest)(nil), "outputcluster.OutputRequest")
+	proto.RegisterType((*LogEntry)(nil), "outputcluster.LogEntry")
+	proto.RegisterType((*OutputInfoResponse)(nil), "outputcluster.OutputInfoResponse")
+	proto.RegisterType((*UpdateRequest)(nil), "outputcluster.UpdateRequest")
+	proto.RegisterType((*UpdateResponse)(nil), "outputcluster.UpdateResponse")
+	proto.RegisterType((*StopResponse)(nil), "outputcluster.StopResponse")
+}
+
+func init() { proto.RegisterFile("outputcluster.proto", fileDescriptor_b49eed9e7abbc90e) }
+
+var fileDescriptor_b49eed9e7abbc90e = []byte{
+	// 1432 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x49, 0x6f, 0xdb, 0x48,
+	0x16, 0x8e, 0x44, 0x89, 0x92, 0x9e, 0xe4, 0xad, 0xb2, 0x71, 0xec, 0x2c, 0x0a, 0x33, 0x18, 0x78,
+	0x26, 0x19, 0x63, 0xa0, 0x04, 0xc8, 0x02, 0x0c, 0x30, 0x19, 0x27, 0x83, 0x08, 0x88, 0x93, 0xa0,
+	0xb2, 0x0c, 0x30, 0x17, 0xa2, 0x4c, 0x96, 0x65, 0x8e, 0x49, 0x16, 0x53, 0x2c, 0xda, 0x70, 0xee,
+	0x7d, 0x6d, 0x74, 0xdf, 0xfa, 0xd4, 0xfd, 0x03, 0xfa, 0xd6, 0xf7, 0xbe, 0xf7, 0xcf, 0x6a, 0xd4,
+	0x46, 0x52, 0xb2, 0x9c, 0x0e, 0x82, 0x46, 0xdf, 0xf8, 0xbe, 0xfa, 0xde, 0xab, 0x57, 0xef, 0x7d,
+	0xb5, 0x10, 0x2e, 0xb2, 0x52, 0xe4, 0xa5, 0x08, 0x93, 0xb2, 0x10, 0x94, 0xef, 0xe4, 0x9c, 0x09,
+	0x86, 0x56, 0xe6, 0x40, 0xff, 0x10, 0x36, 0xf6, 0x48, 0x9c, 0x09, 0x9a, 0x91, 0x2c, 0xa4, 0xff,
+	0x8d, 0xb3, 0x88, 0x9d, 0xa0, 0x4d, 0xe8, 0x17, 0xe1, 0x21, 0x8d, 0xca, 0x84, 0x7a, 0xad, 0x71,
+	0x6b, 0x7b, 0x80, 0x2b, 0x5b, 0x8e, 0x45, 0x25, 0x27, 0x22, 0x66, 0x99, 0xd7, 0x1e, 0xb7, 0xb6,
+	0x1d, 0x5c, 0xd9, 0x68, 0x0b, 0x06, 0x22, 0x4e, 0x69, 0xf0, 0x91, 0x65, 0xd4, 0x73, 0xb4, 0xa3,
+	0x04, 0xfe, 0xc7, 0x32, 0xea, 0x7f, 0xdf, 0x82, 0xb5, 0x97, 0x54, 0x9c, 0x30, 0x7e, 0xb4, 0x47,
+	0x05, 0x89, 0x88, 0x20, 0xe8, 0x16, 0x8c, 0x48, 0x92, 0xb0, 0x90, 0x08, 0x1a, 0x05, 0x71, 0x6e,
+	0x26, 0x1b, 0x56, 0xd8, 0x34, 0x9f, 0xa7, 0x10, 0x61, 0xe6, 0xac, 0x29, 0x4f, 0x04, 0xba, 0x0a,
+	0xbd, 0x90, 0x26, 0x49, 0x10, 0x47, 0x6a, 0x52, 0x07, 0xbb, 0xd2, 0x9c, 0x46, 0xe8, 0x32, 0xb8,
+	0x24, 0xcf, 0x24, 0xde, 0x19, 0xb7, 0xb6, 0xbb, 0xb8, 0x4b, 0xf2, 0x4c, 0xc3, 0x19, 0x29, 0x24,
+	0xdc, 0xd5, 0x70, 0x46, 0x8a, 0x69, 0xe4, 0x7f, 0xeb, 0xc0, 0xea, 0x53, 0x7a, 0x1c, 0x87, 0xf4,
+	0x3f, 0x31, 0x4f, 0x4f, 0x08, 0xa7, 0x68, 0x07, 0x2e, 0x86, 0x25, 0xe7, 0x34, 0x13, 0xc1, 0x81,
+	0xc1, 0xa4, 0x9b, 0x4c, 0xb3, 0x83, 0x37, 0xcc, 0x90, 0x65, 0x4f, 0x23, 0x74, 0x17, 0x90, 0x20,
+	0x7c, 0x46, 0xe7, 0xe9, 0x6d, 0x45, 0x5f, 0xd7, 0x23, 0x0d, 0xf6, 0x5f, 0x61, 0xbd, 0xa2, 0x1d,
+	0x53, 0x5e, 0xc8, 0x92, 0xea, 0xaa, 0xad, 0x59, 0xfc, 0xbd, 0x86, 0xd1, 0x6d, 0x58, 0x29, 0x28,
+	0x8f, 0x49, 0x12, 0x64, 0x65, 0xba, 0x4f, 0xb9, 0x5a, 0xd0, 0x00, 0x8f, 0x34, 0xf8, 0x52, 0x61,
+	0xb2, 0x54, 0x29, 0x8b, 0x68, 0xc5, 0xe9, 0xea, 0x6a, 0x2a, 0xcc, 0x50, 0x7c, 0x18, 0xa5, 0x24,
+	0x2b, 0x0f, 0x48, 0x28, 0x4a, 0x4e, 0xb9, 0xe7, 0xea, 0x30, 0x4d, 0x0c, 0x5d, 0x82, 0x6e, 0x21,
+	0x88, 0xa0, 0x5e, 0x4f, 0x57, 0x47, 0x19, 0x2a, 0x03, 0xf9, 0x11, 0xa4, 0xb4, 0x28, 0xc8, 0x8c,
+	0x7a, 0x7d, 0x93, 0x81, 0x04, 0xf7, 0x34, 0x86, 0x5e, 0x01, 0x4a, 0x6b, 0x35, 0x05, 0x27, 0x4a,
+	0x4e, 0xde, 0x60, 0xdc, 0xda, 0x1e, 0x4e, 0xc6, 0x3b, 0xf3, 0x72, 0x3c, 0x23, 0x3b, 0xbc, 0x91,
+	0x2e, 0x42, 0xfe, 0x2f, 0x6d, 0x70, 0x75, 0x4f, 0xd0, 0x2a, 0xb4, 0xab, 0xd2, 0xb7, 0xe3, 0x08,
+	0x21, 0xe8, 0xc4, 0x69, 0x11, 0x1b, 0x41, 0xa8, 0x6f, 0x8d, 0xd1, 0xd8, 0xc8, 0x40, 0x7d, 0xcb,
+	0xc4, 0x43, 0x96, 0x24, 0x34, 0x94, 0x12, 0xb5, 0x5a, 0xe8, 0xe0, 0x51, 0x0d, 0x4e, 0x23, 0xf4,
+	0x10, 0x7a, 0x99, 0xd6, 0xa6, 0xaa, 0xda, 0x70, 0x72, 0x63, 0x21, 0xdb, 0x05, 0xe5, 0x62, 0x4b,
+	0x47, 0x8f, 0xa0, 0x6f, 0x9b, 0xa5, 0xaa, 0x39, 0x9c, 0x5c, 0x5f, 0x70, 0x9d, 0xd7, 0x14, 0xae,
+	0xe8, 0xe8, 0x1e, 0x74, 0x04, 0x99, 0x15, 0x5e, 0x6f, 0xec, 0x6c, 0x0f, 0x27, 0x37, 0x97, 0xba,
+	0xed, 0xbc, 0x25, 0xb3, 0xe2, 0x59, 0x26, 0xf8, 0x29, 0x56, 0xe4, 0xcd, 0x07, 0x30, 0xa8, 0x20,
+	0xb4, 0x0e, 0xce, 0x11, 0x3d, 0x35, 0xdb, 0x46, 0x7e, 0xca, 0xe6, 0x1d, 0x93, 0xa4, 0xa4, 0xaa,
+	0x2c, 0x03, 0xac, 0x8d, 0xc7, 0xed, 0x87, 0x2d, 0xff, 0x9b, 0x36, 0xa0, 0xdd, 0x6a, 0xcd, 0x7f,
+	0x90, 0xc4, 0x6f, 0x00, 0xa4, 0x24, 0x23, 0x33, 0x9a, 0xd2, 0x4c, 0xa8, 0xb6, 0x74, 0x71, 0x03,
+	0x41, 0x77, 0x60, 0x83, 0xd3, 0x0f, 0x65, 0xcc, 0x69, 0x50, 0xc4, 0xb3, 0x8c, 0x48, 0x05, 0xaa,
+	0x06, 0xf5, 0xf1, 0xba, 0x19, 0x78, 0x63, 0xf1, 0x73, 0xd4, 0xd5, 0xfd, 0x72, 0x75, 0x7d, 0xd5,
+	0x06, 0xa8, 0x4b, 0x72, 0x46, 0x61, 0x57, 0xa1, 0x27, 0x28, 0x49, 0xeb, 0xf5, 0xb9, 0xd2, 0x9c,
+	0x46, 0xe8, 0x3a, 0xc0, 0x41, 0x4c, 0x93, 0x28, 0x48, 0x49, 0x71, 0xa4, 0x56, 0xd5, 0xc1, 0x03,
+	0x85, 0xec, 0x91, 0xe2, 0x08, 0xfd, 0xb3, 0x21, 0x89, 0x8e, 0xca, 0xee, 0xd6, 0x42, 0x76, 0x67,
+	0xfb, 0xd0, 0x90, 0xc5, 0x03, 0x23, 0x8b, 0xae, 0x92, 0xc5, 0xed, 0x73, 0x5d, 0x7f, 0x3f, 0x69,
+	0xfc, 0xdc, 0x82, 0xe1, 0x2e, 0xcb, 0x0e, 0xe2, 0xd9, 0x7b, 0x89, 0xa1, 0xdb, 0x30, 0x2a, 0x04,
+	0x8f, 0xb3, 0x59, 0xa0, 0x1d, 0x54, 0x90, 0xe7, 0x17, 0xf0, 0x50, 0xa3, 0x15, 0x49, 0x9f, 0x33,
+	0x41, 0x1d, 0xb5, 0x25, 0x49, 0x1a, 0xd5, 0xa4, 0x9b, 0x00, 0xfb, 0x8c, 0x25, 0x86, 0x22, 0x2b,
+	0xd5, 0x7f, 0x7e, 0x01, 0x0f, 0x24, 0xa6, 0x09, 0x8f, 0x01, 0x92, 0xb8, 0x10, 0x86, 0xa0, 0xab,
+	0xf5, 0xa7, 0x33, 0x4b, 0x96, 0xa9, 0xbd, 0x88, 0x0b, 0x21, 0x7d, 0x25, 0x5d, 0xf9, 0xfe, 0xbb,
+	0x67, 0x16, 0xe4, 0xff, 0x0b, 0xa0, 0xe6, 0xa0, 0x09, 0xb8, 0x0a, 0x2e, 0xbc, 0x96, 0xaa, 0xe0,
+	0xe6, 0xd2, 0x70, 0x2a, 0x04, 0x36, 0x4c, 0xff, 0x07, 0x07, 0xdc, 0x57, 0x8a, 0xb5, 0xec, 0x9c,
+	0x11, 0xa7, 0xb9, 0xad, 0x9a, 0xfa, 0x46, 0x8f, 0xc0, 0x0d, 0x55, 0x14, 0xcf, 0x19, 0x3b, 0x4b,
+	0xfa, 0xab, 0x43, 0x99, 0x99, 0x74, 0x8b, 0x8c, 0xc3, 0xe7, 0x1d, 0x47, 0x1e, 0xf4, 0x68, 0x46,
+	0xf6, 0x13, 0xaa, 0xaf, 0xa8, 0x3e, 0xb6, 0x26, 0x9a, 0xc0, 0xe5, 0x86, 0x7b, 0x43, 0x85, 0xae,
+	0x0a, 0x73, 0x31, 0x6c, 0x28, 0xcb, 0xea, 0xf1, 0xd3, 0xe7, 0x8c, 0xc9, 0x75, 0x51, 0x4c, 0xef,
+	0xac, 0x24, 0xce, 0x93, 0xd3, 0x3f, 0x9a, 0x72, 0xfa, 0x74, 0x95, 0x6b, 0xa9, 0x7d, 0xb9, 0x46,
+	0xbf, 0x6b, 0xc3, 0xf0, 0x29, 0x11, 0xc4, 0x5e, 0x35, 0x7f, 0x07, 0x37, 0x52, 0x27, 0xa4, 0x72,
+	0x1f, 0x4e, 0x2e, 0x2f, 0x3d, 0x3e, 0xb1, 0x21, 0xc9, 0x67, 0x0b, 0xa7, 0x21, 0x8d, 0x8f, 0x69,
+	0x64, 0x9f, 0x2d, 0xd6, 0x96, 0xd5, 0xce, 0xc9, 0x69, 0xc2, 0x88, 0x7e, 0x3f, 0x8c, 0xb0, 0x35,
+	0xd1, 0x35, 0x18, 0x08, 0x4e, 0xb2, 0x22, 0x67, 0x5c, 0x98, 0x37, 0x44, 0x0d, 0xa0, 0x3f, 0xc3,
+	0x6a, 0x19, 0xe5, 0x81, 0x7c, 0x88, 0x24, 0x81, 0xa2, 0xe8, 0xf7, 0xc4, 0xa8, 0x8c, 0xf2, 0x17,
+	0x12, 0x7c, 0x2d, 0x59, 0x7f, 0x81, 0x35, 0xc9, 0xe2, 0x34, 0x65, 0x82, 0x6a, 0x9a, 0xab, 0x68,
+	0x2b, 0x65, 0x94, 0x63, 0x85, 0x2a, 0xde, 0x16, 0x0c, 0x42, 0x46, 0xf2, 0x20, 0x64, 0x91, 0xbe,
+	0x7a, 0x07, 0xb8, 0x2f, 0x81, 0x5d, 0x16, 0xd1, 0x6a, 0x30, 0x27, 0xe2, 0xd0, 0xeb, 0xd7, 0x83,
+	0xaf, 0x89, 0x38, 0xf4, 0x7f, 0x6c, 0xc3, 0x0a, 0xa6, 0x05, 0x2b, 0x79, 0x48, 0x9f, 0x1d, 0xcb,
+	0x63, 0xd5, 0x6a, 0xb6, 0xd5, 0xd0, 0xac, 0xc4, 0xe2, 0x94, 0xda, 0xfb, 0x52, 0x7e, 0x9f, 0x15,
+	0xa3, 0xb3, 0x44, 0x8c, 0xf7, 0xe1, 0x4a, 0xce, 0xe9, 0x71, 0xcc, 0xca, 0x22, 0x58, 0x26, 0xdd,
+	0x4b, 0x76, 0x74, 0xb7, 0xe9, 0x55, 0xf7, 0xa7, 0xfb, 0x39, 0xfd, 0x79, 0x04, 0x50, 0xc7, 0xf6,
+	0xdc, 0x73, 0xce, 0x01, 0x4b, 0xc0, 0x0d, 0xb2, 0x9c, 0x49, 0xf3, 0xbc, 0xde, 0xd2, 0x99, 0xb4,
+	0xc0, 0xb1, 0x21, 0xf9, 0x1f, 0x61, 0xf5, 0x75, 0xb9, 0x9f, 0xc4, 0xc5, 0x21, 0xa6, 0x1f, 0x4a,
+	0x5a, 0x08, 0x74, 0x1f, 0x7a, 0xf6, 0x51, 0xd3, 0x5a, 0xaa, 0xe5, 0x86, 0xee, 0xb0, 0xa5, 0xa2,
+	0x09, 0x74, 0xa9, 0x2c, 0xb6, 0xd1, 0xff, 0xb5, 0x05, 0x9f, 0xb9, 0x86, 0x60, 0x4d, 0xf5, 0x37,
+	0x60, 0xad, 0x9a, 0xbb, 0xc8, 0x59, 0x56, 0x50, 0xff, 0x2e, 0xac, 0x98, 0x04, 0x4d, 0x36, 0x5b,
+	0x30, 0xd0, 0x91, 0xec, 0x35, 0xec, 0xe0, 0xbe, 0x06, 0xa6, 0x91, 0xff, 0x16, 0xfa, 0x2f, 0x98,
+	0xd9, 0x92, 0xde, 0x7c, 0xda, 0x83, 0x3a, 0xb5, 0x65, 0xad, 0x56, 0x1b, 0x20, 0xa7, 0xf2, 0xc9,
+	0x6c, 0xee, 0xe1, 0xca, 0xf6, 0xbf, 0x6e, 0x03, 0xd2, 0x49, 0x4c, 0xb3, 0x03, 0x66, 0x53, 0x43,
+	0x77, 0xa0, 0x93, 0xb0, 0x99, 0x3d, 0x46, 0xaf, 0x2e, 0x2c, 0xd0, 0xe6, 0x81, 0x15, 0x49, 0x6e,
+	0x95, 0x03, 0xc6, 0x4f, 0x08, 0x8f, 0xaa, 0x1d, 0x56, 0x03, 0x73, 0xdb, 0xcf, 0x59, 0xd8, 0x7e,
+	0x37, 0x61, 0x48, 0x39, 0x67, 0x3c, 0x08, 0x59, 0x99, 0xe9, 0x6d, 0xe6, 0x60, 0x50, 0xd0, 0xae,
+	0x44, 0xd0, 0x18, 0x86, 0x9c, 0xaa, 0x6d, 0x97, 0xc6, 0xa2, 0x50, 0x7a, 0x72, 0x70, 0x13, 0x52,
+	0xfb, 0xf4, 0x90, 0x33, 0x21, 0xe4, 0x89, 0xe9, 0xea, 0xc9, 0x2b, 0x00, 0x5d, 0x01, 0xf7, 0x43,
+	0x49, 0x4b, 0x1a, 0x29, 0x81, 0x38, 0xd8, 0x58, 0xb2, 0x80, 0x11, 0x67, 0x79, 0x4e, 0x23, 0xb5,
+	0xa5, 0x1c, 0x6c, 0x4d, 0xff, 0xff, 0xb0, 0xf2, 0x2e, 0x8f, 0x88, 0xa0, 0xb6, 0x29, 0xb5, 0xc6,
+	0x5a, 0x9f, 0xa1, 0x31, 0xf4, 0x37, 0xd8, 0x28, 0x4e, 0x0b, 0x41, 0xd3, 0xe6, 0x09, 0xad, 0x8b,
+	0xb2, 0xa6, 0x07, 0xaa, 0xd3, 0xd9, 0x5f, 0x87, 0x55, 0x3b, 0x97, 0x91, 0xc4, 0x2a, 0x8c, 0xde,
+	0x08, 0x96, 0x5b, 0x7b, 0xf2, 0x53, 0xdb, 0x6a, 0x64, 0x57, 0x4f, 0x87, 0x9e, 0x43, 0xcf, 0xe8,
+	0x08, 0x2d, 0xbe, 0x36, 0xe7, 0xb5, 0xbd, 0x79, 0xe3, 0xbc, 0x61, 0xd3, 0xe3, 0x3d, 0x80, 0xba,
+	0xf3, 0xe8, 0xda, 0xf2, 0x65, 0x99, 0x58, 0xcb, 0x6f, 0xb9, 0x39, 0xc9, 0x3c, 0x03, 0x57, 0x2f,
+	0xe6, 0x4c, 0xa8, 0xb9, 0x7a, 0x6e, 0x5e, 0x3f, 0x67, 0xd4, 0x84, 0x79, 0x02, 0x1d, 0x59, 0x81,
+	0xdf, 0xc8, 0x67, 0x6b, 0x61, 0xb4, 0x59, 0xb4, 0x7d, 0x57, 0xfd, 0xee, 0xde, 0xfb, 0x75, 0x00,
+	0x97, 0x23, 0xd3, 0xf2, 0x05, 0x0f, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// OutputClusterClient is the client API for OutputCluster service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OutputClusterClient interface {
+	// Publish sends a message to the outputs running on the node.
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	// OutputInfo returns the logs and status for an output running on the node.
+	OutputInfo(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (*OutputInfoResponse, error)
+	// Update applies a new configuration to an output owned by the node.
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	// Stop stops an output owned by the node.
+	Stop(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (*StopResponse, error)
+}
+
+type outputClusterClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOutputClusterClient(cc *grpc.ClientConn) OutputClusterClient {
+	return &outputClusterClient{cc}
+}
+
+func (c *outputClusterClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
+	out := new(PublishResponse)
+	err := c.cc.Invoke(ctx, "/outputcluster.OutputCluster/Publish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputClusterClient) OutputInfo(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (*OutputInfoResponse, error) {
+	out := new(OutputInfoResponse)
+	err := c.cc.Invoke(ctx, "/outputcluster.OutputCluster/OutputInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputClusterClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/outputcluster.OutputCluster/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputClusterClient) Stop(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	out := new(StopResponse)
+	err := c.cc.Invoke(ctx, "/outputcluster.OutputCluster/Stop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutputClusterServer is the server API for OutputCluster service.
+type OutputClusterServer interface {
+	// Publish sends a message to the outputs running on the node.
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	// OutputInfo returns the logs and status for an output running on the node.
+	OutputInfo(context.Context, *OutputRequest) (*OutputInfoResponse, error)
+	// Update applies a new configuration to an output owned by the node.
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	// Stop stops an output owned by the node.
+	Stop(context.Context, *OutputRequest) (*StopResponse, error)
+}
+
+func RegisterOutputClusterServer(s *grpc.Server, srv OutputClusterServer) {
+	s.RegisterService(&_OutputCluster_serviceDesc, srv)
+}
+
+func _OutputCluster_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputClusterServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/outputcluster.OutputCluster/Publish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputClusterServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputCluster_OutputInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputClusterServer).OutputInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/outputcluster.OutputCluster/OutputInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputClusterServer).OutputInfo(ctx, req.(*OutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputCluster_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputClusterServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/outputcluster.OutputCluster/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputClusterServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputCluster_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputClusterServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/outputcluster.OutputCluster/Stop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputClusterServer).Stop(ctx, req.(*OutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OutputCluster_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "outputcluster.OutputCluster",
+	HandlerType: (*OutputClusterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Publish",
+			Handler:    _OutputCluster_Publish_Handler,
+		},
+		{
+			MethodName: "OutputInfo",
+			Handler:    _OutputCluster_OutputInfo_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _OutputCluster_Update_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _OutputCluster_Stop_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "outputcluster.proto",
+}
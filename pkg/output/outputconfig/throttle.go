@@ -0,0 +1,32 @@
+package outputconfig
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+const (
+	// MaxBatchSize is the configuration key for the maximum number of
+	// messages sent in a single request. This is used by the webhook output.
+	MaxBatchSize = "maxBatchSize"
+	// MaxLingerMs is the configuration key for the maximum time (in
+	// milliseconds) the output waits for a batch to fill up before it is
+	// sent.
+	MaxLingerMs = "maxLingerMs"
+	// MaxRequestsPerSecond is the configuration key for the maximum number
+	// of requests per second the output will send.
+	MaxRequestsPerSecond = "maxRequestsPerSecond"
+	// MaxConcurrentRequests is the configuration key for the maximum number
+	// of requests in flight at the same time.
+	MaxConcurrentRequests = "maxConcurrentRequests"
+)
@@ -0,0 +1,140 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"sort"
+	"sync"
+
+	"github.com/eesrc/horde/pkg/model"
+)
+
+// relay forwards messages from a subscription to an output. The target can
+// be swapped while the relay is running. Every message is forwarded to
+// exactly one target. The target channel is closed when the subscription
+// is closed. Resource events are only forwarded if the output has opted in
+// to the event type.
+//
+// The relay doesn't hold the mutex while it waits for the target so an
+// output that has stopped reading won't block the swap. A pending send is
+// aborted by the swap and the message goes to the new target instead.
+type relay struct {
+	mutex   *sync.Mutex
+	sending *sync.Mutex
+	target  chan interface{}
+	events  eventFilter
+	swapped chan struct{}
+}
+
+// newRelay creates a new relay and starts forwarding messages
+func newRelay(sub <-chan interface{}, target chan interface{}, events eventFilter) *relay {
+	ret := &relay{
+		mutex:   &sync.Mutex{},
+		sending: &sync.Mutex{},
+		target:  target,
+		events:  events,
+		swapped: make(chan struct{}),
+	}
+	go ret.forward(sub)
+	return ret
+}
+
+func (r *relay) forward(sub <-chan interface{}) {
+	for msg := range sub {
+		for !r.send(msg) {
+			// The target was swapped. Try again with the new target.
+		}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	close(r.target)
+}
+
+// send forwards a single message to the current target. It returns false if
+// the target was swapped before the message could be sent.
+func (r *relay) send(msg interface{}) bool {
+	r.sending.Lock()
+	defer r.sending.Unlock()
+
+	r.mutex.Lock()
+	target, events, swapped := r.target, r.events, r.swapped
+	r.mutex.Unlock()
+
+	if !events.accept(msg) {
+		return true
+	}
+	select {
+	case target <- msg:
+		return true
+	case <-swapped:
+		return false
+	}
+}
+
+// swap sets a new target and event filter and returns the previous target.
+// No messages will be sent on the previous target when swap returns.
+func (r *relay) swap(target chan interface{}, events eventFilter) chan interface{} {
+	r.mutex.Lock()
+	old := r.target
+	r.target = target
+	r.events = events
+	close(r.swapped)
+	r.swapped = make(chan struct{})
+	r.mutex.Unlock()
+
+	// Wait for any pending send on the previous target to complete or abort
+	r.sending.Lock()
+	defer r.sending.Unlock()
+	return old
+}
+
+// transitionLog is the log for the manager's changes to an output. The log
+// is kept across output instances.
+type transitionLog struct {
+	mutex  *sync.Mutex
+	logger Logger
+}
+
+func newTransitionLog() *transitionLog {
+	return &transitionLog{mutex: &sync.Mutex{}, logger: NewLogger()}
+}
+
+func (t *transitionLog) Append(msg string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.logger.Append(msg)
+}
+
+func (t *transitionLog) Entries() []model.OutputLogEntry {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]model.OutputLogEntry{}, t.logger.Entries()...)
+}
+
+// managedOutput is the output returned by the local manager. The logs
+// include the manager's transitions for the output.
+type managedOutput struct {
+	Output
+	logs *transitionLog
+}
+
+func (m *managedOutput) Logs() []model.OutputLogEntry {
+	ret := append(m.logs.Entries(), m.Output.Logs()...)
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Time.Before(ret[j].Time)
+	})
+	return ret
+}
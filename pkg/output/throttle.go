@@ -0,0 +1,375 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+)
+
+const (
+	// defaultMaxBatchSize is the default batch size. This is the same as the
+	// queue length in the event router.
+	defaultMaxBatchSize = queueLength
+	// maxQueuedMessages is the upper limit on messages held back by the
+	// dispatcher. Messages are dropped when the queue is full.
+	maxQueuedMessages = 10000
+	// Upper limits for the configuration parameters
+	maxBatchSizeLimit          = 1000
+	maxLingerLimit             = 60 * time.Second
+	maxRequestsPerSecondLimit  = 1000
+	maxConcurrentRequestsLimit = 32
+)
+
+// throttleConfig holds the batching and rate limiting parameters shared by
+// the HTTP based outputs. A zero request rate means no rate limit.
+type throttleConfig struct {
+	maxBatchSize      int
+	maxLinger         time.Duration
+	requestsPerSecond float64
+	maxConcurrent     int
+}
+
+// throttleFields is the field specification for the throttle parameters
+var throttleFields = []fieldSpec{
+	fieldSpec{outputconfig.MaxBatchSize, reflect.Float64, false},
+	fieldSpec{outputconfig.MaxLingerMs, reflect.Float64, false},
+	fieldSpec{outputconfig.MaxRequestsPerSecond, reflect.Float64, false},
+	fieldSpec{outputconfig.MaxConcurrentRequests, reflect.Float64, false},
+}
+
+// validateThrottleConfig checks the ranges for the throttle parameters. The
+// types are checked by validateConfig.
+func validateThrottleConfig(config model.OutputConfig, errs model.ErrorMessage) {
+	checkRange := func(name string, min, max float64) {
+		v, ok := config[name].(float64)
+		if !ok {
+			return
+		}
+		if v < min || v > max {
+			errs[name] = "parameter is out of range"
+		}
+	}
+	checkRange(outputconfig.MaxBatchSize, 1, maxBatchSizeLimit)
+	checkRange(outputconfig.MaxLingerMs, 0, float64(maxLingerLimit/time.Millisecond))
+	checkRange(outputconfig.MaxRequestsPerSecond, 0, maxRequestsPerSecondLimit)
+	checkRange(outputconfig.MaxConcurrentRequests, 1, maxConcurrentRequestsLimit)
+}
+
+// newThrottleConfig reads the throttle parameters from the output
+// configuration. Missing parameters are set to their default values.
+func newThrottleConfig(config model.OutputConfig) throttleConfig {
+	ret := throttleConfig{
+		maxBatchSize:  defaultMaxBatchSize,
+		maxConcurrent: 1,
+	}
+	if v, ok := config[outputconfig.MaxBatchSize].(float64); ok && v >= 1 {
+		ret.maxBatchSize = int(v)
+	}
+	if v, ok := config[outputconfig.MaxLingerMs].(float64); ok && v > 0 {
+		ret.maxLinger = time.Duration(v) * time.Millisecond
+	}
+	if v, ok := config[outputconfig.MaxRequestsPerSecond].(float64); ok && v > 0 {
+		ret.requestsPerSecond = v
+	}
+	if v, ok := config[outputconfig.MaxConcurrentRequests].(float64); ok && v >= 1 {
+		ret.maxConcurrent = int(v)
+	}
+	return ret
+}
+
+// rateLimiter is a simple token bucket with a bucket size of one request.
+type rateLimiter struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	ret := &rateLimiter{}
+	if requestsPerSecond > 0 {
+		ret.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return ret
+}
+
+// reserve reserves the next slot and returns the time to wait before the
+// request can be sent.
+func (r *rateLimiter) reserve() time.Duration {
+	if r.interval == 0 {
+		return 0
+	}
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	wait := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+	return wait
+}
+
+// dispatcher reads messages from the output's channel and hands them off in
+// batches to a send function while honouring the batch size, linger time,
+// request rate and concurrency limits. Messages that can't be sent right
+// away are kept in a queue so the event router won't drop them. Batches that
+// fail are put back into the queue with Retry.
+type dispatcher struct {
+	config    throttleConfig
+	limiter   *rateLimiter
+	inFlight  chan bool
+	mutex     *sync.Mutex
+	queue     []interface{}
+	queued    chan bool
+	closed    bool
+	throttled int
+	dropped   int
+	retryAt   time.Time
+	wg        *sync.WaitGroup
+	received  func(msg interface{})
+}
+
+// newDispatcher creates a new dispatcher. The received function is called
+// for every message read from the channel.
+func newDispatcher(config throttleConfig, received func(msg interface{})) *dispatcher {
+	return &dispatcher{
+		received: received,
+		config:   config,
+		limiter:  newRateLimiter(config.requestsPerSecond),
+		inFlight: make(chan bool, config.maxConcurrent),
+		mutex:    &sync.Mutex{},
+		queue:    make([]interface{}, 0),
+		queued:   make(chan bool, 1),
+		wg:       &sync.WaitGroup{},
+	}
+}
+
+// Throttled returns the number of times a request has been held back by the
+// rate or concurrency limits.
+func (d *dispatcher) Throttled() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.throttled
+}
+
+// Queued returns the number of messages waiting in the queue.
+func (d *dispatcher) Queued() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return len(d.queue)
+}
+
+// Dropped returns the number of messages dropped because the queue was full
+// or because the output discarded them.
+func (d *dispatcher) Dropped() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.dropped
+}
+
+// Discard counts messages that the output drops because they can't be
+// delivered.
+func (d *dispatcher) Discard(count int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.dropped += count
+}
+
+// Retry puts a batch that couldn't be sent back at the front of the queue.
+// No batches are sent before the retry time.
+func (d *dispatcher) Retry(batch []interface{}, retryAt time.Time) {
+	d.mutex.Lock()
+	queue := make([]interface{}, 0, len(batch)+len(d.queue))
+	d.queue = append(append(queue, batch...), d.queue...)
+	d.trimQueue()
+	if retryAt.After(d.retryAt) {
+		d.retryAt = retryAt
+	}
+	d.mutex.Unlock()
+	d.signal()
+}
+
+// trimQueue drops the oldest messages when the queue is full. The mutex must
+// be held when this is called.
+func (d *dispatcher) trimQueue() {
+	if n := len(d.queue) - maxQueuedMessages; n > 0 {
+		logging.Warning("Output queue is full. Dropping %d oldest message(s)", n)
+		d.queue = d.queue[n:]
+		d.dropped += n
+	}
+}
+
+// retryDelay returns the time left until batches can be sent again.
+func (d *dispatcher) retryDelay() time.Duration {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return time.Until(d.retryAt)
+}
+
+func (d *dispatcher) signal() {
+	select {
+	case d.queued <- true:
+	default:
+	}
+}
+
+// reader moves messages from the channel into the queue until the channel is
+// closed or the dispatcher stops.
+func (d *dispatcher) reader(messages <-chan interface{}, done <-chan bool) {
+	defer func() {
+		d.mutex.Lock()
+		d.closed = true
+		d.mutex.Unlock()
+		d.signal()
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if d.received != nil {
+				d.received(msg)
+			}
+			d.mutex.Lock()
+			d.queue = append(d.queue, msg)
+			d.trimQueue()
+			d.mutex.Unlock()
+			d.signal()
+		}
+	}
+}
+
+// nextBatch waits for the next batch of messages. The returned bool is
+// false when the dispatcher should stop.
+func (d *dispatcher) nextBatch(terminate <-chan bool) ([]interface{}, bool) {
+	var lingerTimer <-chan time.Time
+	for {
+		d.mutex.Lock()
+		count := len(d.queue)
+		closed := d.closed
+		d.mutex.Unlock()
+
+		if count > 0 && (count >= d.config.maxBatchSize || closed || d.config.maxLinger == 0) {
+			break
+		}
+		if count == 0 && closed {
+			return nil, false
+		}
+		if count > 0 && lingerTimer == nil {
+			lingerTimer = time.After(d.config.maxLinger)
+		}
+		select {
+		case <-terminate:
+			return nil, false
+		case <-d.queued:
+		case <-lingerTimer:
+			d.mutex.Lock()
+			count = len(d.queue)
+			d.mutex.Unlock()
+			if count > 0 {
+				return d.takeBatch(), true
+			}
+			lingerTimer = nil
+		}
+	}
+	return d.takeBatch(), true
+}
+
+func (d *dispatcher) takeBatch() []interface{} {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	n := len(d.queue)
+	if n > d.config.maxBatchSize {
+		n = d.config.maxBatchSize
+	}
+	ret := make([]interface{}, n)
+	copy(ret, d.queue)
+	d.queue = d.queue[n:]
+	return ret
+}
+
+// Run reads messages from the channel and calls the send function with
+// batches of messages until the channel is closed or the terminate channel
+// is signalled. The send function might be called concurrently if the
+// concurrency limit is greater than 1. Run returns when all of the pending
+// requests have completed.
+func (d *dispatcher) Run(messages <-chan interface{}, terminate <-chan bool, send func(batch []interface{})) {
+	done := make(chan bool)
+	go d.reader(messages, done)
+	defer func() {
+		close(done)
+		d.wg.Wait()
+	}()
+	for {
+		// The slot is reserved before the batch is taken from the queue so
+		// failed batches are put back into the queue before the next batch
+		// is taken.
+		select {
+		case d.inFlight <- true:
+		default:
+			if d.Queued() > 0 {
+				d.addThrottled()
+			}
+			select {
+			case d.inFlight <- true:
+			case <-terminate:
+				return
+			}
+		}
+		if wait := d.retryDelay(); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-terminate:
+				<-d.inFlight
+				return
+			}
+		}
+		batch, ok := d.nextBatch(terminate)
+		if !ok {
+			<-d.inFlight
+			return
+		}
+		if wait := d.limiter.reserve(); wait > 0 {
+			d.addThrottled()
+			select {
+			case <-time.After(wait):
+			case <-terminate:
+				<-d.inFlight
+				return
+			}
+		}
+		d.wg.Add(1)
+		go func(batch []interface{}) {
+			defer func() {
+				<-d.inFlight
+				d.wg.Done()
+			}()
+			send(batch)
+		}(batch)
+	}
+}
+
+func (d *dispatcher) addThrottled() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.throttled++
+}
@@ -0,0 +1,188 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottleConfig(t *testing.T) {
+	assert := require.New(t)
+
+	tc := newThrottleConfig(model.OutputConfig{})
+	assert.Equal(defaultMaxBatchSize, tc.maxBatchSize)
+	assert.Equal(1, tc.maxConcurrent)
+	assert.Equal(time.Duration(0), tc.maxLinger)
+	assert.Equal(0.0, tc.requestsPerSecond)
+
+	tc = newThrottleConfig(model.OutputConfig{
+		outputconfig.MaxBatchSize:          float64(10),
+		outputconfig.MaxLingerMs:           float64(250),
+		outputconfig.MaxRequestsPerSecond:  float64(5),
+		outputconfig.MaxConcurrentRequests: float64(4),
+	})
+	assert.Equal(10, tc.maxBatchSize)
+	assert.Equal(4, tc.maxConcurrent)
+	assert.Equal(250*time.Millisecond, tc.maxLinger)
+	assert.Equal(5.0, tc.requestsPerSecond)
+
+	errs := make(model.ErrorMessage)
+	validateThrottleConfig(model.OutputConfig{
+		outputconfig.MaxBatchSize:          float64(0),
+		outputconfig.MaxLingerMs:           float64(-1),
+		outputconfig.MaxRequestsPerSecond:  float64(1e6),
+		outputconfig.MaxConcurrentRequests: float64(100),
+	}, errs)
+	assert.Len(errs, 4)
+}
+
+func TestDispatcherBatchSize(t *testing.T) {
+	assert := require.New(t)
+
+	d := newDispatcher(throttleConfig{maxBatchSize: 3, maxLinger: time.Second, maxConcurrent: 1}, nil)
+	ch := make(chan interface{}, 10)
+	for i := 0; i < 7; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	var batches [][]interface{}
+	d.Run(ch, nil, func(batch []interface{}) {
+		batches = append(batches, batch)
+	})
+
+	assert.Len(batches, 3)
+	assert.Len(batches[0], 3)
+	assert.Len(batches[1], 3)
+	assert.Len(batches[2], 1)
+	assert.Equal(0, d.Queued())
+}
+
+func TestDispatcherLinger(t *testing.T) {
+	assert := require.New(t)
+
+	d := newDispatcher(throttleConfig{maxBatchSize: 100, maxLinger: 50 * time.Millisecond, maxConcurrent: 1}, nil)
+	ch := make(chan interface{})
+	terminate := make(chan bool)
+	sizes := make(chan int, 10)
+	go d.Run(ch, terminate, func(batch []interface{}) {
+		sizes <- len(batch)
+	})
+	ch <- 1
+	ch <- 2
+	ch <- 3
+
+	select {
+	case n := <-sizes:
+		assert.Equal(3, n)
+	case <-time.After(time.Second):
+		assert.Fail("Batch wasn't sent after linger time")
+	}
+	terminate <- true
+}
+
+func TestDispatcherRateLimit(t *testing.T) {
+	assert := require.New(t)
+
+	d := newDispatcher(throttleConfig{maxBatchSize: 1, requestsPerSecond: 100, maxConcurrent: 1}, nil)
+	ch := make(chan interface{}, 10)
+	for i := 0; i < 5; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	count := 0
+	start := time.Now()
+	d.Run(ch, nil, func(batch []interface{}) {
+		count++
+	})
+	// 5 requests at 100/s should take at least 40 ms
+	assert.True(time.Since(start) >= 40*time.Millisecond)
+	assert.Equal(5, count)
+	assert.True(d.Throttled() > 0)
+}
+
+func TestDispatcherConcurrency(t *testing.T) {
+	assert := require.New(t)
+
+	d := newDispatcher(throttleConfig{maxBatchSize: 1, maxConcurrent: 2}, nil)
+	ch := make(chan interface{}, 10)
+	for i := 0; i < 6; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	mutex := &sync.Mutex{}
+	current := 0
+	maxSeen := 0
+	d.Run(ch, nil, func(batch []interface{}) {
+		mutex.Lock()
+		current++
+		if current > maxSeen {
+			maxSeen = current
+		}
+		mutex.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mutex.Lock()
+		current--
+		mutex.Unlock()
+	})
+	assert.Equal(2, maxSeen)
+	assert.True(d.Throttled() > 0)
+}
+
+func TestDispatcherRetry(t *testing.T) {
+	assert := require.New(t)
+
+	d := newDispatcher(throttleConfig{maxBatchSize: 2, maxConcurrent: 1}, nil)
+	ch := make(chan interface{}, 10)
+	for i := 0; i < 4; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	// The first attempt fails and the batch is sent again after the delay
+	var batches [][]interface{}
+	var sent []time.Time
+	start := time.Now()
+	d.Run(ch, nil, func(batch []interface{}) {
+		batches = append(batches, batch)
+		sent = append(sent, time.Now())
+		if len(batches) == 1 {
+			d.Retry(batch, time.Now().Add(50*time.Millisecond))
+		}
+	})
+	assert.Len(batches, 3)
+	assert.Equal(batches[0], batches[1])
+	assert.True(sent[1].Sub(start) >= 50*time.Millisecond)
+	assert.Equal(0, d.Dropped())
+}
+
+func TestDispatcherDropped(t *testing.T) {
+	assert := require.New(t)
+
+	d := newDispatcher(throttleConfig{maxBatchSize: 1, maxConcurrent: 1}, nil)
+	batch := make([]interface{}, maxQueuedMessages+10)
+	d.Retry(batch, time.Now())
+	assert.Equal(maxQueuedMessages, d.Queued())
+	assert.Equal(10, d.Dropped())
+}
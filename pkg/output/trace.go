@@ -0,0 +1,160 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+)
+
+const (
+	// testTimeout is the timeout for test deliveries
+	testTimeout = defaultHTTPClientTimeout
+	// maxResponseExcerpt is the maximum number of bytes from the response
+	// body included in the test result.
+	maxResponseExcerpt = 512
+)
+
+// tlsVersionName returns the name of the TLS version
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	default:
+		return fmt.Sprintf("0x%04x", version)
+	}
+}
+
+// traceTLSHandshake connects to the address and performs a TLS handshake
+func traceTLSHandshake(address, serverName string, insecure bool, timeout time.Duration) *model.OutputTLSTrace {
+	ret := &model.OutputTLSTrace{}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", address, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: insecure,
+	})
+	if err != nil {
+		ret.Error = err.Error()
+		return ret
+	}
+	defer conn.Close()
+	state := conn.ConnectionState()
+	ret.HandshakeComplete = state.HandshakeComplete
+	ret.Version = tlsVersionName(state.Version)
+	ret.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+	ret.ServerName = state.ServerName
+	return ret
+}
+
+// httpTracer records the delivery trace for a single HTTP request. The
+// callbacks from the client trace might be invoked from other goroutines.
+type httpTracer struct {
+	mutex  *sync.Mutex
+	start  time.Time
+	result model.OutputTestResult
+}
+
+func (h *httpTracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			h.mutex.Lock()
+			defer h.mutex.Unlock()
+			h.result.DNSLookup = time.Since(h.start)
+		},
+		ConnectDone: func(network, addr string, err error) {
+			h.mutex.Lock()
+			defer h.mutex.Unlock()
+			if err == nil {
+				h.result.RemoteAddress = addr
+				h.result.Connect = time.Since(h.start)
+			}
+		},
+		TLSHandshakeStart: func() {
+			h.mutex.Lock()
+			defer h.mutex.Unlock()
+			h.result.TLS = &model.OutputTLSTrace{}
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			h.mutex.Lock()
+			defer h.mutex.Unlock()
+			h.result.TLSHandshake = time.Since(h.start)
+			if h.result.TLS == nil {
+				h.result.TLS = &model.OutputTLSTrace{}
+			}
+			if err != nil {
+				h.result.TLS.Error = err.Error()
+				return
+			}
+			h.result.TLS.HandshakeComplete = state.HandshakeComplete
+			h.result.TLS.Version = tlsVersionName(state.Version)
+			h.result.TLS.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+			h.result.TLS.ServerName = state.ServerName
+		},
+		GotFirstResponseByte: func() {
+			h.mutex.Lock()
+			defer h.mutex.Unlock()
+			h.result.FirstByte = time.Since(h.start)
+		},
+	}
+}
+
+// traceHTTPRequest sends a request and returns the delivery trace. Requests
+// with a 2xx response code are successful.
+func traceHTTPRequest(client *http.Client, req *http.Request) model.OutputTestResult {
+	tracer := &httpTracer{mutex: &sync.Mutex{}, start: time.Now()}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), tracer.clientTrace()))
+
+	res, err := client.Do(req)
+
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	ret := tracer.result
+	if err != nil {
+		ret.Message = err.Error()
+		ret.Total = time.Since(tracer.start)
+		return ret
+	}
+	defer res.Body.Close()
+	buf, err := ioutil.ReadAll(io.LimitReader(res.Body, maxResponseExcerpt))
+	if err != nil {
+		ret.Message = fmt.Sprintf("Error reading response body: %v", err)
+	}
+	// Read the rest of the body to keep the connection reusable
+	io.Copy(ioutil.Discard, res.Body)
+
+	ret.Total = time.Since(tracer.start)
+	ret.HTTPStatusCode = res.StatusCode
+	ret.HTTPResponseBody = string(buf)
+	ret.Success = res.StatusCode >= 200 && res.StatusCode <= 299
+	if ret.Message == "" {
+		ret.Message = res.Status
+	}
+	return ret
+}
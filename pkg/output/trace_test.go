@@ -0,0 +1,124 @@
+package output
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookTestDelivery(t *testing.T) {
+	assert := require.New(t)
+	DisableLocalhostChecks()
+
+	hookserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(strings.Repeat("x", 2*maxResponseExcerpt)))
+	}))
+	defer hookserver.Close()
+
+	msg := model.NewDataMessage(model.NewDevice(), []byte("hello"), model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{})
+	config := model.OutputConfig{outputconfig.WebhookURLField: hookserver.URL}
+
+	res := newWebhook().Test(config, 0, 0, msg, time.Second)
+	assert.True(res.Success)
+	assert.Equal(http.StatusCreated, res.HTTPStatusCode)
+	assert.Len(res.HTTPResponseBody, maxResponseExcerpt)
+	assert.Equal(hookserver.Listener.Addr().String(), res.RemoteAddress)
+	assert.Nil(res.TLS)
+	assert.True(res.Total > 0)
+
+	// The test server uses a self-signed certificate so the handshake fails
+	tlsserver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer tlsserver.Close()
+
+	config = model.OutputConfig{outputconfig.WebhookURLField: tlsserver.URL}
+	res = newWebhook().Test(config, 0, 0, msg, time.Second)
+	assert.False(res.Success)
+	assert.NotNil(res.TLS)
+	assert.False(res.TLS.HandshakeComplete)
+	assert.NotEmpty(res.TLS.Error)
+
+	// Error responses are reported as failures
+	errserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer errserver.Close()
+
+	config = model.OutputConfig{outputconfig.WebhookURLField: errserver.URL}
+	res = newWebhook().Test(config, 0, 0, msg, time.Second)
+	assert.False(res.Success)
+	assert.Equal(http.StatusUnauthorized, res.HTTPStatusCode)
+}
+
+func TestUDPTestDelivery(t *testing.T) {
+	assert := require.New(t)
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(err)
+	defer pc.Close()
+	host, port, err := net.SplitHostPort(pc.LocalAddr().String())
+	assert.NoError(err)
+	p, _ := strconv.ParseInt(port, 10, 32)
+
+	config := model.OutputConfig{
+		outputconfig.UDPHost: host,
+		outputconfig.UDPPort: float64(p),
+	}
+	msg := model.NewDataMessage(model.NewDevice(), []byte("hello"), model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{})
+	res := newUDP().Test(config, 0, 0, msg, time.Second)
+	assert.True(res.Success)
+	assert.Equal(pc.LocalAddr().String(), res.RemoteAddress)
+	assert.True(res.Total >= res.Connect && res.Total > 0)
+
+	buf := make([]byte, 100)
+	pc.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	assert.NoError(err)
+	assert.Equal("hello", string(buf[:n]))
+}
+
+func TestMQTTTestUnreachableBroker(t *testing.T) {
+	assert := require.New(t)
+
+	// Grab a free port and close it so the connection is refused
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	addr := l.Addr().String()
+	l.Close()
+
+	config := model.OutputConfig{
+		outputconfig.MQTTEndpoint:  "tcp://" + addr,
+		outputconfig.MQTTClientID:  "test",
+		outputconfig.MQTTTopicName: "test",
+	}
+	msg := model.NewDataMessage(model.NewDevice(), []byte("hello"), model.UDPTransport, model.UDPMetaData{}, model.CoAPMetaData{})
+	res := newMQTT().Test(config, 0, 0, msg, time.Second)
+	assert.False(res.Success)
+	assert.Equal(addr, res.RemoteAddress)
+	assert.True(res.Total >= res.Connect && res.Total > 0)
+}
@@ -23,6 +23,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/eesrc/horde/pkg/metering"
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/output/outputconfig"
 	"github.com/eesrc/horde/pkg/utils/audit"
@@ -96,6 +97,7 @@ func (u *udp) udpSender(messages <-chan interface{}, config model.OutputConfig)
 		} else {
 			u.status.Forwarded++
 			metrics.DefaultCoreCounters.MessagesForwardUDP.Add(1)
+			metering.DefaultMeter.OutputDelivered(msg.Device.CollectionID, 1)
 			audit.Log("UDP: Sent %d bytes to device with IMSI %d, Device ID=%s, Collection ID=%s, Target=%s",
 				len(msg.Payload), msg.Device.IMSI,
 				msg.Device.ID.String(), msg.Device.CollectionID.String(), remoteAddr.String())
@@ -111,6 +113,7 @@ func (u *udp) Validate(config model.OutputConfig) (model.ErrorMessage, error) {
 		fieldSpec{outputconfig.UDPHost, reflect.String, true},
 		fieldSpec{outputconfig.UDPPort, reflect.Float64, true},
 	})
+	rejectEventConfig(config, errs)
 	if len(errs) > 0 {
 		return errs, errors.New("invalid config")
 	}
@@ -153,3 +156,37 @@ func (u *udp) Status() model.OutputStatus {
 	ret := u.status
 	return ret
 }
+
+func (u *udp) Test(config model.OutputConfig, collectionFieldMask model.FieldMask, systemFieldMask model.FieldMask, msg model.DataMessage, timeout time.Duration) (ret model.OutputTestResult) {
+	start := time.Now()
+	defer func() {
+		ret.Total = time.Since(start)
+	}()
+	host, _ := config[outputconfig.UDPHost].(string)
+	port, _ := config[outputconfig.UDPPort].(float64)
+
+	remoteAddr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", host, int(port)))
+	ret.DNSLookup = time.Since(start)
+	if err != nil {
+		ret.Message = fmt.Sprintf("Could not resolve address: %v", err)
+		return ret
+	}
+	ret.RemoteAddress = remoteAddr.String()
+	output, err := net.DialUDP("udp", nil, remoteAddr)
+	ret.Connect = time.Since(start)
+	if err != nil {
+		ret.Message = fmt.Sprintf("Unable to dial UDP: %v", err)
+		return ret
+	}
+	defer output.Close()
+	output.SetWriteDeadline(time.Now().Add(timeout))
+	n, err := output.Write(msg.Payload)
+	if err != nil {
+		ret.Message = fmt.Sprintf("Error sending payload: %v", err)
+		return ret
+	}
+	// UDP is fire-and-forget so a successful write is as good as it gets
+	ret.Success = true
+	ret.Message = fmt.Sprintf("Sent %d bytes", n)
+	return ret
+}
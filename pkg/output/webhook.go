@@ -28,6 +28,7 @@ import (
 
 	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/api/apitoolbox"
+	"github.com/eesrc/horde/pkg/metering"
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/output/outputconfig"
 	"github.com/eesrc/horde/pkg/utils/audit"
@@ -41,12 +42,13 @@ import (
 // Configuration for each output is quite simple: Just an URL with an optional
 // basic auth. The web hooks will throttle back if the server returns anything
 // but a 2xx status code. The first time it will throttle back 1 second, then
-// 2 seconds, then 4 seconds until it reaches 256 seconds when it will be
-// disabled.
+// 2 seconds, then 4 seconds until it reaches 256 seconds. Messages rejected
+// with a 4xx status code (except 429) aren't retried.
 // The webhook may either use a header with a secret or basic auth with
 // an username and a password.
 type webhook struct {
 	terminate           chan bool
+	stopped             chan bool
 	status              model.OutputStatus
 	logs                Logger
 	config              model.OutputConfig
@@ -56,9 +58,30 @@ type webhook struct {
 	client              *http.Client
 	collectionFieldMask model.FieldMask
 	systemFieldMask     model.FieldMask
+	dispatcher          *dispatcher
 }
 
-const defaultHTTPClientTimeout = 10 * time.Second
+const (
+	defaultHTTPClientTimeout = 10 * time.Second
+	// maxBackOffTime is the upper limit for the webhook backoff time
+	maxBackOffTime = 256 * time.Second
+)
+
+// deliveryResult is the result of a request from the HTTP based outputs
+type deliveryResult int
+
+const (
+	delivered  deliveryResult = iota // The messages are delivered
+	retryLater                       // The request should be retried
+	rejected                         // The request failed permanently
+)
+
+// isPermanentHTTPError returns true if the status code means that the
+// request will fail if it is retried, ie any 4xx code except 429 (too many
+// requests).
+func isPermanentHTTPError(statusCode int) bool {
+	return statusCode >= 400 && statusCode < 500 && statusCode != http.StatusTooManyRequests
+}
 
 func (w *webhook) configURL() string {
 	val, ok := w.config[outputconfig.WebhookURLField]
@@ -106,21 +129,17 @@ func init() {
 	registerOutput("webhook", newWebhook)
 }
 
-// sendMessage sends aggregated messages to the configured endpoint.
-func (w *webhook) sendMessages(msgs *apipb.ListMessagesResponse) bool {
-	if time.Now().Before(w.nextSendTime) {
-		return false
-	}
+// newRequest creates a new POST request with the messages to the configured
+// endpoint.
+func (w *webhook) newRequest(msgs *apipb.ListMessagesResponse) (*http.Request, error) {
 	ma := apitoolbox.JSONMarshaler()
 	buf, err := ma.MarshalToString(msgs)
 	if err != nil {
-		logging.Warning("Unable to marshal webhook body: %v", err)
-		return false
+		return nil, err
 	}
-	body := bytes.NewReader([]byte(buf))
-	req, err := http.NewRequest("POST", w.configURL(), body)
+	req, err := http.NewRequest("POST", w.configURL(), bytes.NewReader([]byte(buf)))
 	if err != nil {
-		logging.Warning("Unable to create request for webhook POST: %v", err)
+		return nil, err
 	}
 	if w.hasBasicAuth() {
 		req.SetBasicAuth(w.configString(outputconfig.WebhookBasicAuthUser), w.configString(outputconfig.WebhookBasicAuthPass))
@@ -130,16 +149,46 @@ func (w *webhook) sendMessages(msgs *apipb.ListMessagesResponse) bool {
 	if w.hasCustomHeader() {
 		req.Header.Add(w.configString(outputconfig.WebhookCustomHeaderName), w.configString(outputconfig.WebhookCustomHeaderValue))
 	}
+	return req, nil
+}
+
+// backOff sets the next send time and doubles the backoff time up to
+// maxBackOffTime. The mutex must be held when this is called.
+func (w *webhook) backOff() {
+	w.nextSendTime = time.Now().Add(w.backOffTime)
+	w.backOffTime *= 2
+	if w.backOffTime > maxBackOffTime {
+		w.backOffTime = maxBackOffTime
+	}
+}
+
+// sendMessage sends aggregated messages to the configured endpoint. Messages
+// that should be retried are sent again after the backoff time.
+func (w *webhook) sendMessages(msgs *apipb.ListMessagesResponse) deliveryResult {
+	w.mutex.Lock()
+	backingOff := time.Now().Before(w.nextSendTime)
+	w.mutex.Unlock()
+	if backingOff {
+		return retryLater
+	}
+	req, err := w.newRequest(msgs)
+	if err != nil {
+		logging.Warning("Unable to create request for webhook POST: %v", err)
+		w.mutex.Lock()
+		w.logs.Append(fmt.Sprintf("Unable to create request. Dropping %d message(s)", len(msgs.Messages)))
+		w.status.ErrorCount++
+		w.mutex.Unlock()
+		return rejected
+	}
 
 	res, err := w.client.Do(req)
 	if err != nil {
-		w.nextSendTime = time.Now().Add(w.backOffTime)
-		w.backOffTime *= 2
-		logging.Warning("Error calling remote URL: %v. Backoff is %d seconds", err, w.backOffTime/time.Second)
 		w.mutex.Lock()
+		w.backOff()
+		logging.Warning("Error calling remote URL: %v. Backoff is %d seconds", err, w.backOffTime/time.Second)
 		w.status.ErrorCount++
 		w.mutex.Unlock()
-		return false
+		return retryLater
 	}
 
 	// Read the entire response, then discard. This ensures the http.Client is
@@ -147,86 +196,106 @@ func (w *webhook) sendMessages(msgs *apipb.ListMessagesResponse) bool {
 	io.Copy(ioutil.Discard, res.Body)
 	res.Body.Close()
 
+	if isPermanentHTTPError(res.StatusCode) {
+		w.mutex.Lock()
+		w.logs.Append(fmt.Sprintf("Got %d response code from %s. Dropping %d message(s)",
+			res.StatusCode, w.configURL(), len(msgs.Messages)))
+		w.status.ErrorCount++
+		w.mutex.Unlock()
+		return rejected
+	}
 	if res.StatusCode < 200 || res.StatusCode > 299 {
-		w.backOffTime *= 2
+		w.mutex.Lock()
+		w.backOff()
 		w.logs.Append(fmt.Sprintf("Got %d response code from %s. Will retry in %d seconds",
-			res.StatusCode, w.configURL(), w.backOffTime/time.Second))
-		w.nextSendTime = time.Now().Add(w.backOffTime)
+			res.StatusCode, w.configURL(), time.Until(w.nextSendTime)/time.Second))
 		logging.Debug("Got %d response code from %s. Will retry in %d seconds",
-			res.StatusCode, w.configURL(), w.backOffTime/time.Second)
-		w.mutex.Lock()
+			res.StatusCode, w.configURL(), time.Until(w.nextSendTime)/time.Second)
 		w.status.ErrorCount++
 		w.mutex.Unlock()
-		return false
+		return retryLater
 	}
 	for _, msg := range msgs.Messages {
+		if msg.Type != apipb.OutputDataMessage_data {
+			continue
+		}
 		audit.Log("Webhook: Forwarded %d bytes from device with IMSI %s, Device ID=%s, Collection ID=%s",
 			len(msg.Payload), msg.Device.Imsi.Value,
 			msg.Device.DeviceId.Value, msg.Device.CollectionId.Value)
+		if collectionID, err := model.NewCollectionKeyFromString(msg.Device.CollectionId.Value); err == nil {
+			metering.DefaultMeter.OutputDelivered(collectionID, 1)
+		}
 	}
 
-	w.backOffTime = time.Second
 	w.mutex.Lock()
+	w.backOffTime = time.Second
 	w.status.Forwarded += len(msgs.Messages)
 	metrics.DefaultCoreCounters.MessagesForwardWebhook.Add(float64(len(msgs.Messages)))
 	w.mutex.Unlock()
 
-	return true
+	return delivered
 }
 
-func (w *webhook) webhookSender(receiver <-chan interface{}) {
+func (w *webhook) webhookSender(receiver <-chan interface{}, d *dispatcher, stopped chan bool) {
+	defer close(stopped)
 	if _, err := w.Validate(w.config); err != nil {
 		w.logs.Append("Invalid configuration. Stopped.")
 		return
 	}
-	for {
-		select {
-		case <-w.terminate:
-			logging.Debug("terminate signal, webhook terminates")
-			return
-		case msg, ok := <-receiver:
-			if !ok {
-				return
-			}
-			msgs := &apipb.ListMessagesResponse{
-				Messages: make([]*apipb.OutputDataMessage, 0),
-			}
-			for {
-				if m, ok := msg.(model.DataMessage); ok {
-					//TODO(stalehd): This should *probably* be the correct collection but
-					// we'll save us a lookup at this point. The field mask and
-					// the firmware settings are used to infer the firmware status
-					// that should be set on the resulting device.
-					tmpColl := model.NewCollection()
-					tmpColl.FieldMask = w.collectionFieldMask
-					msgs.Messages = append(msgs.Messages, apitoolbox.NewOutputDataMessageFromModel(m, tmpColl))
-				} else {
-					logging.Warning("Not a message: %T", m)
-				}
-				if len(receiver) == 0 {
-					break
-				}
-				msg = <-receiver
+	d.Run(receiver, w.terminate, func(batch []interface{}) {
+		msgs := &apipb.ListMessagesResponse{
+			Messages: make([]*apipb.OutputDataMessage, 0),
+		}
+		//TODO(stalehd): This should *probably* be the correct collection but
+		// we'll save us a lookup at this point. The field mask and
+		// the firmware settings are used to infer the firmware status
+		// that should be set on the resulting device.
+		tmpColl := model.NewCollection()
+		tmpColl.FieldMask = w.collectionFieldMask
+		for _, msg := range batch {
+			switch m := msg.(type) {
+			case model.DataMessage:
+				msgs.Messages = append(msgs.Messages, apitoolbox.NewOutputDataMessageFromModel(m, tmpColl))
+			case model.ResourceEvent:
+				msgs.Messages = append(msgs.Messages, apitoolbox.NewOutputEventMessageFromModel(m, tmpColl))
+			default:
+				logging.Warning("Not a message: %T", m)
 			}
+		}
+		switch w.sendMessages(msgs) {
+		case retryLater:
+			// Keep the messages in the queue until the endpoint is available
 			w.mutex.Lock()
-			w.status.Received += len(msgs.Messages)
+			retryAt := w.nextSendTime
 			w.mutex.Unlock()
-
-			if !w.sendMessages(msgs) {
-				logging.Warning("Lost %d messages", len(msgs.Messages))
-			}
+			d.Retry(batch, retryAt)
+		case rejected:
+			d.Discard(len(batch))
 		}
+	})
+	logging.Debug("webhook terminates")
+}
+
+// messageReceived is called by the dispatcher for every received message
+func (w *webhook) messageReceived(msg interface{}) {
+	switch msg.(type) {
+	case model.DataMessage, model.ResourceEvent:
+		w.mutex.Lock()
+		w.status.Received++
+		w.mutex.Unlock()
 	}
 }
 
 func (w *webhook) Validate(config model.OutputConfig) (model.ErrorMessage, error) {
-	errs := validateConfig(config, []fieldSpec{
+	errs := validateConfig(config, append([]fieldSpec{
 		fieldSpec{outputconfig.WebhookURLField, reflect.String, true},
 		fieldSpec{outputconfig.WebhookBasicAuthUser, reflect.String, false},
 		fieldSpec{outputconfig.WebhookBasicAuthPass, reflect.String, false},
 		fieldSpec{outputconfig.WebhookCustomHeaderName, reflect.String, false},
 		fieldSpec{outputconfig.WebhookCustomHeaderValue, reflect.String, false},
-	})
+	}, throttleFields...))
+	validateThrottleConfig(config, errs)
+	validateEventConfig(config, errs)
 	val, ok := config[outputconfig.WebhookURLField]
 	if ok {
 		url, ok := val.(string)
@@ -251,17 +320,36 @@ func (w *webhook) Start(config model.OutputConfig, collectionFieldMask model.Fie
 	w.collectionFieldMask = collectionFieldMask
 	w.systemFieldMask = systemFieldMask
 	w.config = config
+	w.dispatcher = newDispatcher(newThrottleConfig(config), w.messageReceived)
+	d := w.dispatcher
+	w.stopped = make(chan bool)
+	stopped := w.stopped
 	w.mutex.Unlock()
-	go w.webhookSender(message)
+	go w.webhookSender(message, d, stopped)
 }
 
 func (w *webhook) Stop(timeout time.Duration) {
+	w.mutex.Lock()
+	stopped := w.stopped
+	w.mutex.Unlock()
+	if stopped == nil {
+		return
+	}
+	// If the message channel is closed the sender will drain the queue and
+	// stop by itself. Give it until the timeout before terminating.
+	select {
+	case <-stopped:
+		return
+	case <-time.After(timeout):
+	}
 	select {
 	case w.terminate <- true:
-	default:
+	case <-stopped:
 		// already terminated
-
+		return
 	}
+	// Wait for the requests in flight to complete
+	<-stopped
 }
 
 func (w *webhook) Logs() []model.OutputLogEntry {
@@ -273,5 +361,30 @@ func (w *webhook) Status() model.OutputStatus {
 	defer w.mutex.Unlock()
 	ret := w.status
 	ret.ErrorCount = w.logs.Messages()
+	if w.dispatcher != nil {
+		ret.Throttled = w.dispatcher.Throttled()
+		ret.Queued = w.dispatcher.Queued()
+		ret.Dropped = w.dispatcher.Dropped()
+	}
 	return ret
 }
+
+func (w *webhook) Test(config model.OutputConfig, collectionFieldMask model.FieldMask, systemFieldMask model.FieldMask, msg model.DataMessage, timeout time.Duration) model.OutputTestResult {
+	w.mutex.Lock()
+	w.config = config
+	w.collectionFieldMask = collectionFieldMask
+	w.systemFieldMask = systemFieldMask
+	w.mutex.Unlock()
+
+	tmpColl := model.NewCollection()
+	tmpColl.FieldMask = collectionFieldMask
+	msgs := &apipb.ListMessagesResponse{
+		Messages: []*apipb.OutputDataMessage{apitoolbox.NewOutputDataMessageFromModel(msg, tmpColl)},
+	}
+	req, err := w.newRequest(msgs)
+	if err != nil {
+		return model.OutputTestResult{Message: fmt.Sprintf("Unable to create request: %v", err)}
+	}
+	client := &http.Client{Timeout: timeout}
+	return traceHTTPRequest(client, req)
+}
@@ -19,8 +19,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/eesrc/horde/pkg/api/apipb"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output/outputconfig"
+	"github.com/golang/protobuf/jsonpb"
 )
 
 func BenchmarkWebhook(b *testing.B) {
@@ -52,3 +56,152 @@ func TestWebhook(t *testing.T) {
 
 	outputTests(newWebhook(), config, t)
 }
+
+func TestWebhookBatching(t *testing.T) {
+	DisableLocalhostChecks()
+	requests := make(chan int, 10)
+	hookserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msgs := &apipb.ListMessagesResponse{}
+		if err := jsonpb.Unmarshal(r.Body, msgs); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests <- len(msgs.Messages)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hookserver.Close()
+
+	config := model.OutputConfig{
+		outputconfig.WebhookURLField: hookserver.URL,
+		outputconfig.MaxBatchSize:    float64(4),
+		outputconfig.MaxLingerMs:     float64(100),
+	}
+	wh := newWebhook()
+	if errs, err := wh.Validate(config); err != nil {
+		t.Fatalf("Invalid configuration: %+v: %v", errs, err)
+	}
+	dataChan := make(chan interface{})
+	wh.Start(config, 0, 0, dataChan)
+	defer wh.Stop(100 * time.Millisecond)
+
+	for i := 0; i < 6; i++ {
+		dataChan <- model.DataMessage{Payload: []byte{1, 2, 3}, Device: model.NewDevice(), Received: time.Now()}
+	}
+
+	for _, expected := range []int{4, 2} {
+		select {
+		case n := <-requests:
+			if n != expected {
+				t.Fatalf("Expected batch with %d messages but got %d", expected, n)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Did not get a request from the webhook")
+		}
+	}
+	// The last response might still be in transit
+	time.Sleep(10 * time.Millisecond)
+	if s := wh.Status(); s.Received != 6 || s.Forwarded != 6 {
+		t.Fatalf("Incorrect status: %+v", s)
+	}
+}
+
+func TestWebhookRetry(t *testing.T) {
+	DisableLocalhostChecks()
+	requests := make(chan int, 10)
+	failed := false
+	hookserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first request. The messages should be sent again.
+		if !failed {
+			failed = true
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		msgs := &apipb.ListMessagesResponse{}
+		if err := jsonpb.Unmarshal(r.Body, msgs); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests <- len(msgs.Messages)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hookserver.Close()
+
+	config := model.OutputConfig{
+		outputconfig.WebhookURLField: hookserver.URL,
+		outputconfig.MaxBatchSize:    float64(3),
+		outputconfig.MaxLingerMs:     float64(100),
+	}
+	wh := newWebhook()
+	dataChan := make(chan interface{})
+	wh.Start(config, 0, 0, dataChan)
+	defer wh.Stop(100 * time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		dataChan <- model.DataMessage{Payload: []byte{1, 2, 3}, Device: model.NewDevice(), Received: time.Now()}
+	}
+
+	select {
+	case n := <-requests:
+		if n != 3 {
+			t.Fatalf("Expected 3 messages in the retried batch but got %d", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Batch was not retried")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if s := wh.Status(); s.Forwarded != 3 || s.Dropped != 0 || s.Queued != 0 {
+		t.Fatalf("Incorrect status: %+v", s)
+	}
+}
+
+func TestWebhookPermanentError(t *testing.T) {
+	DisableLocalhostChecks()
+	requests := make(chan int, 10)
+	hookserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- 1
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer hookserver.Close()
+
+	config := model.OutputConfig{
+		outputconfig.WebhookURLField: hookserver.URL,
+		outputconfig.MaxBatchSize:    float64(3),
+		outputconfig.MaxLingerMs:     float64(100),
+	}
+	wh := newWebhook()
+	dataChan := make(chan interface{})
+	wh.Start(config, 0, 0, dataChan)
+	defer wh.Stop(100 * time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		dataChan <- model.DataMessage{Payload: []byte{1, 2, 3}, Device: model.NewDevice(), Received: time.Now()}
+	}
+
+	select {
+	case <-requests:
+	case <-time.After(time.Second):
+		t.Fatal("Did not get a request from the webhook")
+	}
+	// The batch is dropped and not retried
+	select {
+	case <-requests:
+		t.Fatal("Rejected batch should not be retried")
+	case <-time.After(200 * time.Millisecond):
+	}
+	if s := wh.Status(); s.Forwarded != 0 || s.Dropped != 3 || s.Queued != 0 {
+		t.Fatalf("Incorrect status: %+v", s)
+	}
+}
+
+func TestWebhookBackOffLimit(t *testing.T) {
+	wh := newWebhook().(*webhook)
+	for i := 0; i < 20; i++ {
+		wh.backOff()
+	}
+	if wh.backOffTime != maxBackOffTime {
+		t.Fatalf("Expected backoff time to be limited to %v but it is %v", maxBackOffTime, wh.backOffTime)
+	}
+	if time.Until(wh.nextSendTime) > maxBackOffTime {
+		t.Fatalf("Next send time is too far in the future: %v", wh.nextSendTime)
+	}
+}
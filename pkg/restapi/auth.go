@@ -17,8 +17,8 @@ package restapi
 //
 import (
 	"context"
+	"net"
 	"net/http"
-	"strings"
 
 	"github.com/TelenorDigital/goconnect"
 	"github.com/gorilla/websocket"
@@ -29,18 +29,20 @@ import (
 	"github.com/eesrc/horde/pkg/ghlogin"
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/oidclogin"
 	"github.com/eesrc/horde/pkg/storage"
 )
 
 const tokenHeader = "X-API-Token"
 const tokenParam = "api_token"
 
-// newAuthHandler dispatches authentication to one of the three authentication
-// handlers -- check for tokens, check for CONNECT ID and check for GitHub auth.
+// newAuthHandler dispatches authentication to one of the four authentication
+// handlers -- check for tokens, check for GitHub auth, check for OpenID Connect
+// and check for CONNECT ID.
 // These are all expensive, so check for cookies and headers before invoking
 // them. They also assume that they're the final authority so they'll return
 // 401 if it doesn't work.
-func (s *restServer) newAuthHandler(connectHandler http.HandlerFunc, githubHandler http.HandlerFunc, tokenHandler http.HandlerFunc) http.HandlerFunc {
+func (s *restServer) newAuthHandler(connectHandler http.HandlerFunc, githubHandler http.HandlerFunc, oidcHandler http.HandlerFunc, tokenHandler http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Test token first. It's the quickest auth check
 		token := r.Header.Get(tokenHeader)
@@ -60,6 +62,12 @@ func (s *restServer) newAuthHandler(connectHandler http.HandlerFunc, githubHandl
 			metrics.DefaultCoreCounters.AuthGithubCount.Add(1)
 			return
 		}
+		oidcCookie, err := r.Cookie(oidclogin.OIDCAuthCookieName)
+		if err == nil && oidcCookie != nil && oidcCookie.Value != "" {
+			oidcHandler(w, r)
+			metrics.DefaultCoreCounters.AuthOIDCCount.Add(1)
+			return
+		}
 		// Test the CONNECT ID handler last since we don't know what the cookie is called.
 		connectHandler(w, r)
 
@@ -155,6 +163,26 @@ func (s *restServer) createGitHubHandler(ghConfig ghlogin.Config, secureCookie b
 	return s.githubAuth.AuthHandlerFunc(existingHandler)
 }
 
+func (s *restServer) createOIDCHandler(oidcConfig oidclogin.Config, secureCookie bool, existingHandler http.HandlerFunc) http.HandlerFunc {
+	if !oidcConfig.Enabled {
+		return existingHandler
+	}
+	logging.Debug("OpenID Connect is enabled")
+
+	oidcConfig.SecureCookie = secureCookie
+	var err error
+	s.oidcAuth, err = oidclogin.New(oidcConfig)
+	if err != nil {
+		logging.Error("Unable to create OpenID Connect auth handler. Returning a deny-all-handler: %v", err)
+		return func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Authentication is disabled", http.StatusForbidden)
+		}
+	}
+	s.oidcAuth.StartSessionChecker()
+	s.mux.Handle("/oidc/", s.oidcAuth.Handler())
+	return s.oidcAuth.AuthHandlerFunc(existingHandler)
+}
+
 // Handler for the API tokens.
 func (s *restServer) createTokenHandler(handler http.HandlerFunc, tokenstore storage.DataStore) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -169,13 +197,17 @@ func (s *restServer) createTokenHandler(handler http.HandlerFunc, tokenstore sto
 			wsTokenInQuery = true
 		}
 
-		token, err := tokenstore.RetrieveToken(tokenVal)
+		token, err := api.LookupToken(tokenstore, tokenVal)
 		if err != nil {
 			if err == storage.ErrNotFound {
 				reportError(w, http.StatusUnauthorized, "Unknown API token", nil)
 				return
 			}
-			logging.Warning("Unable to retrieve token %s: %v", tokenVal, err)
+			if err == api.ErrTokenExpired {
+				reportError(w, http.StatusUnauthorized, "API token has expired", nil)
+				return
+			}
+			logging.Warning("Unable to retrieve token %s: %v", model.TokenID(tokenVal), err)
 			reportError(w, http.StatusInternalServerError, "Unable to process request", nil)
 			return
 		}
@@ -185,18 +217,12 @@ func (s *restServer) createTokenHandler(handler http.HandlerFunc, tokenstore sto
 			reportError(w, http.StatusBadRequest, "Tokens in WebSockets must be read-only", nil)
 			return
 		}
-		// Check if the token matches the request
-		path := r.URL.Path
+		// Check if the token matches the request. Anything but GET, HEAD and
+		// OPTIONS requests are writes. The same check is used for tokens in
+		// the gRPC API.
 		method := r.Method
-		// Ensure the token matches the path
-		if !strings.HasPrefix(path, token.Resource) {
-			reportError(w, http.StatusForbidden, "Access denied", nil)
-			return
-		}
-
-		// Block POST, DELETE and GET unless it is a write operation
-		if !token.Write && (method == http.MethodPatch ||
-			method == http.MethodPost || method == http.MethodDelete) {
+		write := !(method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions)
+		if !api.TokenAllows(token, r.URL.Path, write) {
 			reportError(w, http.StatusForbidden, "Access denied", nil)
 			return
 		}
@@ -208,12 +234,23 @@ func (s *restServer) createTokenHandler(handler http.HandlerFunc, tokenstore sto
 				reportError(w, http.StatusUnauthorized, "Access denied", nil)
 				return
 			}
-			logging.Warning("Unable to retrieve user for token %s: %v", tokenVal, err)
+			logging.Warning("Unable to retrieve user for token %s: %v", token.ID, err)
 			reportError(w, http.StatusInternalServerError, "Unable to read user", nil)
 			return
 		}
+		s.tokenUsage.Record(token.ID, remoteIP(r))
 		newContext := context.WithValue(r.Context(), api.UserKey, &user)
 		newContext = context.WithValue(newContext, api.AuthKey, model.AuthToken)
+		newContext = context.WithValue(newContext, api.TokenKey, token.ID)
 		handler(w, r.WithContext(newContext))
 	}
 }
+
+// remoteIP returns the IP address of the client
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -20,7 +20,9 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/eesrc/horde/pkg/api"
 	"github.com/eesrc/horde/pkg/storage/sqlstore"
 	"github.com/eesrc/horde/pkg/storage/storetest"
 
@@ -36,13 +38,15 @@ func TestTokenHandler(t *testing.T) {
 	tokenStore := sqlstore.NewMemoryStore()
 	env := storetest.NewTestEnvironment(t, tokenStore)
 	tokens := []model.Token{
-		model.Token{Token: "all", UserID: env.U1.ID, Resource: "/", Write: true, Tags: model.NewTags()},
-		model.Token{Token: "read", UserID: env.U2.ID, Resource: "/applications", Write: false, Tags: model.NewTags()},
+		model.Token{ID: model.TokenID("all"), UserID: env.U1.ID, Resource: "/", Write: true, Tags: model.NewTags()},
+		model.Token{ID: model.TokenID("read"), UserID: env.U2.ID, Resource: "/applications", Write: false, Tags: model.NewTags()},
+		model.Token{ID: model.TokenID("expired"), UserID: env.U1.ID, Resource: "/", Write: true, Expires: time.Now().Add(-time.Second), Tags: model.NewTags()},
 	}
 	for _, v := range tokens {
 		tokenStore.CreateToken(v)
 	}
-	s := restServer{}
+	s := restServer{tokenUsage: api.NewTokenUsageRecorder(tokenStore, time.Hour)}
+	defer s.tokenUsage.Stop()
 	tokenHandler := s.createTokenHandler(innerHandler, tokenStore)
 
 	w := httptest.NewRecorder()
@@ -70,6 +74,9 @@ func TestTokenHandler(t *testing.T) {
 	r.Method = http.MethodDelete
 	testInvocations(2)
 
+	r.Method = http.MethodPut
+	testInvocations(2)
+
 	r.Method = http.MethodGet
 	r.URL.Path = "/secrets"
 	testInvocations(2)
@@ -83,4 +90,25 @@ func TestTokenHandler(t *testing.T) {
 	r.Header.Set(tokenHeader, "nouser")
 	testInvocations(2)
 
+	// The token's resource must match complete path elements
+	r.Header.Set(tokenHeader, "read")
+	r.URL.Path = "/applications2"
+	testInvocations(2)
+
+	r.URL.Path = "/applications/2"
+	testInvocations(3)
+
+	// Expired tokens are rejected
+	r.Header.Set(tokenHeader, "expired")
+	testInvocations(3)
+
+	// The last use of the token is recorded
+	s.tokenUsage.Flush()
+	token, err := tokenStore.RetrieveToken(model.TokenID("read"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.LastUsed.IsZero() || token.LastUsedIP != "192.0.2.1" {
+		t.Fatalf("Token usage isn't recorded: %+v", token)
+	}
 }
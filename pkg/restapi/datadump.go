@@ -0,0 +1,184 @@
+package restapi
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/api/apitoolbox"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// dumpStreamWriter is the server side of the StreamDataDump stream. The chunks
+// are written to the HTTP response as newline-delimited JSON, optionally
+// inside a ZIP file. The response headers are written with the first chunk so
+// errors that occur before the dump starts are reported as regular HTTP
+// errors.
+type dumpStreamWriter struct {
+	ctx       context.Context
+	w         http.ResponseWriter
+	zip       *zip.Writer
+	out       io.Writer
+	marshaler jsonpb.Marshaler
+	started   bool
+}
+
+func (d *dumpStreamWriter) Context() context.Context {
+	return d.ctx
+}
+
+func (d *dumpStreamWriter) start() error {
+	d.started = true
+	d.out = d.w
+	if d.zip == nil {
+		d.w.Header().Set("Content-Type", "application/x-ndjson")
+		d.w.Header().Set("Content-Disposition", `attachment; filename="datadump.ndjson"`)
+		d.w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	d.w.Header().Set("Content-Type", "application/zip")
+	d.w.Header().Set("Content-Disposition", `attachment; filename="datadump.zip"`)
+	d.w.WriteHeader(http.StatusOK)
+	var err error
+	d.out, err = d.zip.CreateHeader(&zip.FileHeader{
+		Name:     "datadump.ndjson",
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	return err
+}
+
+func (d *dumpStreamWriter) Send(chunk *apipb.DataDumpChunk) error {
+	if !d.started {
+		if err := d.start(); err != nil {
+			return err
+		}
+	}
+	if err := d.marshaler.Marshal(d.out, chunk); err != nil {
+		return err
+	}
+	if _, err := d.out.Write([]byte("\n")); err != nil {
+		return err
+	}
+	if f, ok := d.w.(http.Flusher); ok && d.zip == nil {
+		f.Flush()
+	}
+	return nil
+}
+
+func (d *dumpStreamWriter) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (d *dumpStreamWriter) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (d *dumpStreamWriter) SetTrailer(metadata.MD) {
+}
+
+func (d *dumpStreamWriter) SendMsg(m interface{}) error {
+	return d.Send(m.(*apipb.DataDumpChunk))
+}
+
+func (d *dumpStreamWriter) RecvMsg(m interface{}) error {
+	return errors.New("the data dump stream is send-only")
+}
+
+// dataDumpHandler serves the streamed data dump. The format query parameter
+// is either "ndjson" (the default) or "zip". The other query parameters are
+// the same as for the StreamDataDump method in the API.
+func (s *restServer) dataDumpHandler(dumpService apipb.HordeServer, w http.ResponseWriter, r *http.Request) {
+	req := &apipb.StreamDataDumpRequest{}
+	query := r.URL.Query()
+	for name, field := range map[string]**wrappers.Int64Value{"since": &req.Since, "until": &req.Until} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			reportError(w, http.StatusBadRequest, fmt.Sprintf("Invalid %s parameter", name), nil)
+			return
+		}
+		*field = &wrappers.Int64Value{Value: ms}
+	}
+	if v := query.Get("page_size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			reportError(w, http.StatusBadRequest, "Invalid page_size parameter", nil)
+			return
+		}
+		req.PageSize = &wrappers.Int32Value{Value: int32(n)}
+	}
+	if v := query.Get("include_data"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			reportError(w, http.StatusBadRequest, "Invalid include_data parameter", nil)
+			return
+		}
+		req.IncludeData = &wrappers.BoolValue{Value: b}
+	}
+
+	stream := &dumpStreamWriter{
+		ctx:       r.Context(),
+		w:         w,
+		marshaler: apitoolbox.JSONMarshaler(),
+	}
+	switch query.Get("format") {
+	case "", "ndjson":
+	case "zip":
+		stream.zip = zip.NewWriter(w)
+	default:
+		reportError(w, http.StatusBadRequest, "Format must be ndjson or zip", nil)
+		return
+	}
+
+	err := dumpService.StreamDataDump(req, stream)
+	if err != nil && !stream.started {
+		reportError(w, runtime.HTTPStatusFromCode(status.Code(err)), status.Convert(err).Message(), nil)
+		return
+	}
+	// The status is already sent so the last chunk marks the end of the dump
+	// and reports errors. Dumps without the last chunk are truncated.
+	end := &apipb.DataDumpEnd{}
+	if err != nil {
+		logging.Info("Unable to complete data dump: %v", err)
+		end.Error = &wrappers.StringValue{Value: status.Convert(err).Message()}
+	}
+	if err := stream.Send(&apipb.DataDumpChunk{End: end}); err != nil {
+		logging.Info("Unable to complete data dump: %v", err)
+		return
+	}
+	if stream.zip != nil {
+		if err := stream.zip.Close(); err != nil {
+			logging.Info("Unable to complete data dump: %v", err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package restapi
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eesrc/horde/pkg/api"
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/golang/protobuf/jsonpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readDumpChunks decodes the NDJSON data dump
+func readDumpChunks(r io.Reader, t *testing.T) []*apipb.DataDumpChunk {
+	var ret []*apipb.DataDumpChunk
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		chunk := &apipb.DataDumpChunk{}
+		if err := jsonpb.Unmarshal(bytes.NewReader(scanner.Bytes()), chunk); err != nil {
+			t.Fatalf("Unable to decode chunk %s: %v", scanner.Text(), err)
+		}
+		ret = append(ret, chunk)
+	}
+	return ret
+}
+
+func TestStreamedDataDump(t *testing.T) {
+	store := sqlstore.NewMemoryStore()
+	s := NewServer(testParams, dataClientParams, connectParams, ghParams, oidcParams,
+		store, imageStore, &dummySender{}, output.NewDummyManager(), mask)
+	server := s.(*restServer)
+
+	user := makeTestUser(store, t)
+	collection := model.NewCollection()
+	collection.ID = store.NewCollectionID()
+	collection.TeamID = user.PrivateTeamID
+	if err := store.CreateCollection(user.ID, collection); err != nil {
+		t.Fatal("Unable to create collection: ", err)
+	}
+
+	ctx := context.WithValue(context.Background(), api.UserKey, &user)
+	ctx = context.WithValue(ctx, api.AuthKey, model.AuthConnectID)
+
+	// Unauthenticated requests fail before the dump starts
+	w := httptest.NewRecorder()
+	server.dataDumpHandler(server.apiServer, w, httptest.NewRequest("GET", "/datadump/stream?include_data=false", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 but got %d", w.Code)
+	}
+
+	for _, path := range []string{"/datadump/stream?format=csv", "/datadump/stream?since=yesterday", "/datadump/stream?include_data=maybe"} {
+		w = httptest.NewRecorder()
+		server.dataDumpHandler(server.apiServer, w, httptest.NewRequest("GET", path, nil).WithContext(ctx))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected 400 for %s but got %d", path, w.Code)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	server.dataDumpHandler(server.apiServer, w, httptest.NewRequest("GET", "/datadump/stream?include_data=false", nil).WithContext(ctx))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK but got %d (%s)", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Fatalf("Expected NDJSON content type but got %s", ct)
+	}
+	chunks := readDumpChunks(w.Body, t)
+	if len(chunks) != 4 || chunks[0].Profile == nil || chunks[1].Team == nil || chunks[2].Collection == nil {
+		t.Fatalf("Unexpected chunks in dump: %v", chunks)
+	}
+	if chunks[3].End == nil || chunks[3].End.Error != nil {
+		t.Fatalf("Expected end of dump but got %v", chunks[3])
+	}
+
+	w = httptest.NewRecorder()
+	server.dataDumpHandler(server.apiServer, w, httptest.NewRequest("GET", "/datadump/stream?include_data=false&format=zip", nil).WithContext(ctx))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK but got %d (%s)", w.Code, w.Body.String())
+	}
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatal("Unable to open ZIP file: ", err)
+	}
+	if len(zr.File) != 1 || zr.File[0].Name != "datadump.ndjson" {
+		t.Fatalf("Unexpected files in ZIP file: %v", zr.File)
+	}
+	f, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatal("Unable to open dump in ZIP file: ", err)
+	}
+	defer f.Close()
+	if chunks := readDumpChunks(f, t); len(chunks) != 4 || chunks[3].End == nil {
+		t.Fatalf("Expected 4 chunks in the ZIP file but got %d", len(chunks))
+	}
+}
+
+// failingDump fails the data dump after the first chunk
+type failingDump struct {
+	apipb.HordeServer
+}
+
+func (f *failingDump) StreamDataDump(req *apipb.StreamDataDumpRequest, svr apipb.Horde_StreamDataDumpServer) error {
+	if err := svr.Send(&apipb.DataDumpChunk{Profile: &apipb.UserProfile{}}); err != nil {
+		return err
+	}
+	return status.Error(codes.Internal, "Unable to read collections")
+}
+
+func TestStreamedDataDumpError(t *testing.T) {
+	server := &restServer{}
+
+	for _, format := range []string{"ndjson", "zip"} {
+		w := httptest.NewRecorder()
+		server.dataDumpHandler(&failingDump{}, w, httptest.NewRequest("GET", "/datadump/stream?format="+format, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200 OK but got %d (%s)", w.Code, w.Body.String())
+		}
+		var r io.Reader = w.Body
+		if format == "zip" {
+			// The ZIP file is closed when the dump fails
+			zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+			if err != nil {
+				t.Fatal("Unable to open ZIP file: ", err)
+			}
+			f, err := zr.File[0].Open()
+			if err != nil {
+				t.Fatal("Unable to open dump in ZIP file: ", err)
+			}
+			defer f.Close()
+			r = f
+		}
+		chunks := readDumpChunks(r, t)
+		if len(chunks) != 2 || chunks[1].End == nil || chunks[1].End.Error.GetValue() != "Unable to read collections" {
+			t.Fatalf("Expected error at the end of the %s dump but got %v", format, chunks)
+		}
+	}
+}
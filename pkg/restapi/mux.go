@@ -28,9 +28,10 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// Create a custom handler and mux for the service. Root handler, Web sockets
-// and file uploads are handled by regular handlers that wrap the gRPC services
-// and all other requests are handled by the grpc-gateway mux
+// Create a custom handler and mux for the service. Root handler, Web sockets,
+// file uploads, CSV exports and the streamed data dump are handled by regular
+// handlers that wrap the gRPC services and all other requests are handled by
+// the grpc-gateway mux
 
 // MaxUploadSize is the maximum request body. Max body size is 2MB. This might
 // be too small for the largest firmware images but it's a starting point.
@@ -67,6 +68,16 @@ func (s *restServer) createWrappedMux(mux *runtime.ServeMux) http.HandlerFunc {
 				}
 			}
 		}
+		// Matching the /teams/{id}/usage/csv
+		if len(pathParts) == 5 && pathParts[1] == "teams" && pathParts[3] == "usage" && pathParts[4] == "csv" && r.Method == http.MethodGet {
+			s.usageCSVHandler(pathParts[2], s.apiServer, w, r)
+			return
+		}
+		// Matching the /datadump/stream
+		if len(pathParts) == 3 && pathParts[1] == "datadump" && pathParts[2] == "stream" && r.Method == http.MethodGet {
+			s.dataDumpHandler(s.apiServer, w, r)
+			return
+		}
 		// Matching the /collections/{id}/devices/{id}/from
 		if len(pathParts) == 6 && pathParts[1] == "collections" && pathParts[3] == "devices" && pathParts[5] == "from" {
 			collectionID := pathParts[2]
@@ -103,6 +114,32 @@ func (s *restServer) imageUploadHandler(collectionID string, firmwareService api
 		Image:        buf,
 		Filename:     &wrappers.StringValue{Value: header.Filename},
 	}
+	// The detached signature is optional and is sent as a separate file
+	// with the raw signature bytes.
+	if sigFile, _, err := r.FormFile("signature"); err == nil {
+		defer sigFile.Close()
+		req.Signature, err = ioutil.ReadAll(sigFile)
+		if err != nil {
+			logging.Warning("Got error reading firmware signature: %v", err)
+			reportError(w, http.StatusInternalServerError, "Unable to read firmware signature", nil)
+			return
+		}
+	}
+	if keyID := r.FormValue("signingKeyId"); keyID != "" {
+		req.SigningKeyId = &wrappers.StringValue{Value: keyID}
+	}
+	// Compatibility rules are optional form values
+	compat := &apipb.FirmwareCompatibility{}
+	for name, field := range map[string]**wrappers.StringValue{
+		"manufacturer":   &compat.Manufacturer,
+		"modelPattern":   &compat.ModelPattern,
+		"minimumVersion": &compat.MinimumVersion,
+	} {
+		if v := r.FormValue(name); v != "" {
+			*field = &wrappers.StringValue{Value: v}
+			req.Compatibility = compat
+		}
+	}
 	fw, err := firmwareService.CreateFirmware(r.Context(), req)
 	if err != nil {
 		errorCode := runtime.HTTPStatusFromCode(status.Code(err))
@@ -30,6 +30,7 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/eesrc/horde/pkg/ghlogin"
+	"github.com/eesrc/horde/pkg/oidclogin"
 	"github.com/eesrc/horde/pkg/utils/grpcutil"
 
 	"github.com/ExploratoryEngineering/logging"
@@ -64,7 +65,9 @@ type restServer struct {
 	mgr             output.Manager
 	deviceFieldMask model.FieldMaskParameters
 	githubAuth      *ghlogin.Authenticator
+	oidcAuth        *oidclogin.Authenticator
 	apiServer       apipb.HordeServer
+	tokenUsage      *api.TokenUsageRecorder
 }
 
 // NewServer creates a new REST API server
@@ -72,6 +75,7 @@ func NewServer(params ServerParameters,
 	dataClientParams grpcutil.GRPCClientParam,
 	cc ConnectIDParameters,
 	ghc ghlogin.Config,
+	oc oidclogin.Config,
 	store storage.DataStore,
 	imageStore storage.FirmwareImageStore,
 	sender api.DownstreamMessageSender,
@@ -113,9 +117,11 @@ func NewServer(params ServerParameters,
 	secureCookie := (params.ACME.Enabled || params.TLSCertFile != "")
 	connectHandler := ret.createConnectHandler(cc, secureCookie, handler)
 	githubHandler := ret.createGitHubHandler(ghc, secureCookie, handler)
+	oidcHandler := ret.createOIDCHandler(oc, secureCookie, handler)
+	ret.tokenUsage = api.NewTokenUsageRecorder(store, api.DefaultTokenUsageInterval)
 	tokenHandler := ret.createTokenHandler(handler, store)
 
-	handler = ret.newAuthHandler(connectHandler, githubHandler, tokenHandler)
+	handler = ret.newAuthHandler(connectHandler, githubHandler, oidcHandler, tokenHandler)
 
 	ret.mux.HandleFunc("/", ret.perfCounterHandler(rest.AddCORSHeaders(handler).ServeHTTP))
 	ret.server = &http.Server{
@@ -184,6 +190,7 @@ func (s *restServer) Start() error {
 func (s *restServer) Stop() error {
 	ctx, cancelFunc := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancelFunc()
+	defer s.tokenUsage.Stop()
 	if err := s.server.Shutdown(ctx); err != nil {
 		return err
 	}
@@ -398,6 +405,67 @@ func (s *restServer) addOrUpdateGitHubUser(profile ghlogin.Profile) *model.User
 	return user
 }
 
+// addOrUpdateOIDCUser adds or updates the user in the backend store. The
+// external ID is the subject claim.
+func (s *restServer) addOrUpdateOIDCUser(profile oidclogin.Profile) *model.User {
+	user, err := s.storage.RetrieveUserByExternalID(profile.Subject, model.AuthOIDC)
+	if err != nil && err != storage.ErrNotFound {
+		logging.Warning("Unable to do user lookup for user with OIDC subject %s: %v", profile.Subject, err)
+		return nil
+	}
+	if err == storage.ErrNotFound {
+		t := model.NewTeam()
+		t.ID = s.storage.NewTeamID()
+		t.Tags.SetTag("name", "My private team")
+
+		u := model.NewUser(s.storage.NewUserID(), profile.Subject, model.AuthOIDC, t.ID)
+		u.Email = profile.Email
+		u.Name = profile.Name
+		u.VerifiedEmail = profile.EmailVerified
+		u.AvatarURL = profile.AvatarURL
+
+		t.AddMember(model.NewMember(u, model.AdminRole))
+
+		if err := s.storage.CreateUser(u, t); err != nil {
+			logging.Warning("Unable to store user: %v (user = %+v", err, u)
+			return nil
+		}
+		logging.Debug("Created OIDC user: %+v", u)
+
+		c := model.NewCollection()
+		c.FieldMask = s.deviceFieldMask.DefaultFields()
+		c.ID = s.storage.NewCollectionID()
+		c.TeamID = t.ID
+		c.Tags.SetTag("name", "My default collection")
+		if err := s.storage.CreateCollection(u.ID, c); err != nil {
+			logging.Warning("Unable to create a collection for a new user: %v", err)
+			return nil
+		}
+		if err := s.storage.UpdateTeam(u.ID, t); err != nil {
+			logging.Warning("Unable to update team for new user: %v", err)
+			return nil
+		}
+		user = &u
+	}
+	if user != nil {
+		if user.Name != profile.Name ||
+			user.Email != profile.Email ||
+			user.VerifiedEmail != profile.EmailVerified ||
+			user.AvatarURL != profile.AvatarURL {
+
+			user.Name = profile.Name
+			user.Email = profile.Email
+			user.VerifiedEmail = profile.EmailVerified
+			user.AvatarURL = profile.AvatarURL
+
+			if err := s.storage.UpdateUser(user); err != nil {
+				logging.Warning("Unable to update user: %v", err)
+			}
+		}
+	}
+	return user
+}
+
 // authSessionToUserHandlerFunc grabs the Connect ID session from the context and
 // injects the actual user information into the context.
 func (s *restServer) authSessionToUserHandlerFunc(f http.HandlerFunc) http.HandlerFunc {
@@ -422,6 +490,15 @@ func (s *restServer) authSessionToUserHandlerFunc(f http.HandlerFunc) http.Handl
 				}
 			}
 		}
+		if s.oidcAuth != nil {
+			profile, err := s.oidcAuth.Profile(r)
+			if err == nil {
+				if user := s.addOrUpdateOIDCUser(profile); user != nil {
+					newContext = context.WithValue(newContext, api.UserKey, user)
+					newContext = context.WithValue(newContext, api.AuthKey, model.AuthOIDC)
+				}
+			}
+		}
 		f(w, r.WithContext(newContext))
 	}
 }
@@ -25,6 +25,7 @@ import (
 
 	"github.com/eesrc/horde/pkg/api"
 	"github.com/eesrc/horde/pkg/ghlogin"
+	"github.com/eesrc/horde/pkg/oidclogin"
 	"github.com/eesrc/horde/pkg/utils/grpcutil"
 
 	"github.com/TelenorDigital/goconnect"
@@ -49,6 +50,10 @@ var ghParams = ghlogin.Config{
 	DBDriver:           "sqlite3",
 	DBConnectionString: ":memory:",
 }
+
+var oidcParams = oidclogin.Config{
+	Enabled: false,
+}
 var dataClientParams grpcutil.GRPCClientParam
 
 var mask = model.FieldMaskParameters{Forced: "", Default: ""}
@@ -73,7 +78,7 @@ func (d *dummySender) Send(dev model.Device, m model.DownstreamMessage) error {
 func TestServer(t *testing.T) {
 
 	server := NewServer(testParams, dataClientParams, connectParams,
-		ghParams, sqlstore.NewMemoryStore(), imageStore, newDummyMessageSender(),
+		ghParams, oidcParams, sqlstore.NewMemoryStore(), imageStore, newDummyMessageSender(),
 		output.NewDummyManager(), mask)
 
 	go server.Start()
@@ -90,7 +95,7 @@ func TestRootHandler(t *testing.T) {
 	rec := httptest.NewRecorder()
 
 	s := NewServer(testParams, dataClientParams, connectParams,
-		ghParams, sqlstore.NewMemoryStore(), imageStore, &dummySender{},
+		ghParams, oidcParams, sqlstore.NewMemoryStore(), imageStore, &dummySender{},
 		output.NewDummyManager(), mask)
 	server := s.(*restServer)
 	server.rootHandler(rec, req)
@@ -104,7 +109,7 @@ func TestRootHandler(t *testing.T) {
 func TestConnectIntegration(t *testing.T) {
 	store := sqlstore.NewMemoryStore()
 	s := NewServer(testParams, dataClientParams, connectParams,
-		ghParams, store, imageStore, &dummySender{}, output.NewDummyManager(), mask)
+		ghParams, oidcParams, store, imageStore, &dummySender{}, output.NewDummyManager(), mask)
 	if s == nil {
 		t.Fatal("Couldn't create server")
 	}
@@ -150,7 +155,7 @@ func TestConnectIntegration(t *testing.T) {
 // Test the connect ID emulator code (just because OCD)
 func TestConnectEmulator(t *testing.T) {
 	store := sqlstore.NewMemoryStore()
-	s := NewServer(testParams, dataClientParams, connectParams, ghParams,
+	s := NewServer(testParams, dataClientParams, connectParams, ghParams, oidcParams,
 		store, imageStore, &dummySender{}, output.NewDummyManager(), mask)
 	server := s.(*restServer)
 	f := server.emulateConnect(func(w http.ResponseWriter, r *http.Request) {
@@ -188,7 +193,7 @@ func makeSessionContext(ctx context.Context, connectUser model.User) context.Con
 // Ensure Connect ID sessions are properly converted to users
 func TestConnectUserConversion(t *testing.T) {
 	store := sqlstore.NewMemoryStore()
-	s := NewServer(testParams, dataClientParams, connectParams, ghParams,
+	s := NewServer(testParams, dataClientParams, connectParams, ghParams, oidcParams,
 		store, imageStore, &dummySender{}, output.NewDummyManager(), mask)
 	server := s.(*restServer)
 	var req *http.Request
@@ -0,0 +1,92 @@
+package restapi
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/api/apipb"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+)
+
+// usageCSVHeader is the header row for the usage CSV export
+var usageCSVHeader = []string{
+	"collection_id", "hour", "transport",
+	"uplink_messages", "uplink_bytes", "downlink_messages", "downlink_bytes",
+	"radius_sessions", "firmware_bytes", "output_deliveries",
+}
+
+// usageCSVHandler exports the metered usage for a team as CSV. The query
+// parameters are the same as for the Usage method in the API.
+func (s *restServer) usageCSVHandler(teamID string, usageService apipb.HordeServer, w http.ResponseWriter, r *http.Request) {
+	req := &apipb.UsageRequest{
+		TeamId: &wrappers.StringValue{Value: teamID},
+	}
+	query := r.URL.Query()
+	if v := query.Get("collection_id"); v != "" {
+		req.CollectionId = &wrappers.StringValue{Value: v}
+	}
+	for name, field := range map[string]**wrappers.Int64Value{"since": &req.Since, "until": &req.Until} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			reportError(w, http.StatusBadRequest, fmt.Sprintf("Invalid %s parameter", name), nil)
+			return
+		}
+		*field = &wrappers.Int64Value{Value: ms}
+	}
+
+	usage, err := usageService.Usage(r.Context(), req)
+	if err != nil {
+		reportError(w, runtime.HTTPStatusFromCode(status.Code(err)), status.Convert(err).Message(), nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="usage-%s.csv"`, teamID))
+	w.WriteHeader(http.StatusOK)
+
+	out := csv.NewWriter(w)
+	out.Write(usageCSVHeader)
+	for _, v := range usage.Records {
+		out.Write([]string{
+			v.CollectionId.GetValue(),
+			time.Unix(0, v.Hour.GetValue()*int64(time.Millisecond)).UTC().Format(time.RFC3339),
+			v.Transport.GetValue(),
+			strconv.FormatInt(v.UplinkMessages.GetValue(), 10),
+			strconv.FormatInt(v.UplinkBytes.GetValue(), 10),
+			strconv.FormatInt(v.DownlinkMessages.GetValue(), 10),
+			strconv.FormatInt(v.DownlinkBytes.GetValue(), 10),
+			strconv.FormatInt(v.RadiusSessions.GetValue(), 10),
+			strconv.FormatInt(v.FirmwareBytes.GetValue(), 10),
+			strconv.FormatInt(v.OutputDeliveries.GetValue(), 10),
+		})
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		logging.Info("Unable to write usage CSV for team %s: %v", teamID, err)
+	}
+}
@@ -0,0 +1,93 @@
+package restapi
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/api"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/output"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+)
+
+func TestUsageCSVExport(t *testing.T) {
+	store := sqlstore.NewMemoryStore()
+	s := NewServer(testParams, dataClientParams, connectParams, ghParams, oidcParams,
+		store, imageStore, &dummySender{}, output.NewDummyManager(), mask)
+	server := s.(*restServer)
+
+	user := makeTestUser(store, t)
+	collection := model.NewCollection()
+	collection.ID = store.NewCollectionID()
+	collection.TeamID = user.PrivateTeamID
+	if err := store.CreateCollection(user.ID, collection); err != nil {
+		t.Fatal("Unable to create collection: ", err)
+	}
+
+	hour := model.UsageHour(time.Now())
+	if err := store.AddUsage([]model.Usage{
+		{CollectionID: collection.ID, TeamID: user.PrivateTeamID, Hour: hour, Transport: "udp", UplinkMessages: 2, UplinkBytes: 20},
+		{CollectionID: collection.ID, TeamID: user.PrivateTeamID, Hour: hour, RADIUSSessions: 1},
+	}); err != nil {
+		t.Fatal("Unable to add usage: ", err)
+	}
+
+	ctx := context.WithValue(context.Background(), api.UserKey, &user)
+	ctx = context.WithValue(ctx, api.AuthKey, model.AuthConnectID)
+
+	path := fmt.Sprintf("/teams/%s/usage/csv", user.PrivateTeamID.String())
+	w := httptest.NewRecorder()
+	server.usageCSVHandler(user.PrivateTeamID.String(), server.apiServer, w, httptest.NewRequest("GET", path, nil).WithContext(ctx))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK but got %d (%s)", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/csv" {
+		t.Fatalf("Expected CSV content type but got %s", ct)
+	}
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatal("Unable to parse CSV: ", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected header and 2 rows but got %d rows", len(rows))
+	}
+	if rows[0][0] != "collection_id" || len(rows[1]) != len(usageCSVHeader) {
+		t.Fatalf("Unexpected CSV layout: %v", rows)
+	}
+	if rows[1][1] != hour.Format(time.RFC3339) {
+		t.Fatalf("Expected hour %s but got %s", hour.Format(time.RFC3339), rows[1][1])
+	}
+
+	w = httptest.NewRecorder()
+	server.usageCSVHandler(user.PrivateTeamID.String(), server.apiServer, w, httptest.NewRequest("GET", path+"?since=foo", nil).WithContext(ctx))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 Bad request for invalid time but got %d", w.Code)
+	}
+
+	// Unauthenticated requests are rejected
+	w = httptest.NewRecorder()
+	server.usageCSVHandler(user.PrivateTeamID.String(), server.apiServer, w, httptest.NewRequest("GET", path, nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 Unauthorized but got %d", w.Code)
+	}
+}
@@ -16,9 +16,6 @@ package server
 //limitations under the License.
 //
 import (
-	"context"
-	"time"
-
 	"github.com/eesrc/horde/pkg/api/apitoolbox"
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/storage"
@@ -32,25 +29,28 @@ import (
 	"github.com/eesrc/horde/pkg/restapi"
 )
 
-const storeChannelLength = 100
-
-// NewServer creates a new Horde server
-func newServer(cfg grpcutil.GRPCClientParam) *hordeServer {
+// NewServer creates a new Horde server. Device data is kept in the outbox
+// until the data store has stored it. The server can't run without the
+// outbox when the data store is enabled since messages would be lost.
+func newServer(cfg grpcutil.GRPCClientParam, outboxDatabase string) (*hordeServer, error) {
 	ret := &hordeServer{}
 	if cfg.ServerEndpoint == "" {
 		logging.Info("Device data client is disabled")
-		return ret
+		return ret, nil
 	}
 	conn, err := grpcutil.NewGRPCClientConnection(cfg)
 	if err != nil {
 		logging.Info("Unable to create device data client: %v", err)
-		return ret
+		return ret, nil
 	}
 	ret.dataStoreClient = datastore.NewDataStoreClient(conn)
-	ret.storeChannel = make(chan model.DataMessage, storeChannelLength)
-	go ret.storeData()
+	ret.outbox, err = newDataOutbox(outboxDatabase, ret.dataStoreClient)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
 	logging.Info("Device data client connected to %v", cfg.ServerEndpoint)
-	return ret
+	return ret, nil
 }
 
 type hordeServer struct {
@@ -58,7 +58,7 @@ type hordeServer struct {
 	mgr              output.Manager
 	upstreamMessages <-chan model.DataMessage
 	store            storage.DataStore
-	storeChannel     chan model.DataMessage
+	outbox           *dataOutbox
 	dataStoreClient  datastore.DataStoreClient
 }
 
@@ -90,50 +90,31 @@ func (h *hordeServer) Start(store storage.DataStore,
 // makeMetadata converts the message's device into binary metadata
 // TODO(stalehd): Rewrite into sane format
 func makeMetadata(msg model.DataMessage) []byte {
-	ma := apitoolbox.JSONMarshaler()
-	odm := apitoolbox.NewOutputDataMessageFromModel(msg, model.Collection{})
-	buf, err := ma.MarshalToString(odm)
-	if err != nil {
-		return []byte{}
-	}
-	return []byte(buf)
+	return apitoolbox.MarshalDataStoreMetadata(msg)
 }
 
-func (h *hordeServer) storeData() {
-	if h.dataStoreClient == nil {
+// storeData puts the message in the outbox for the data store
+func (h *hordeServer) storeData(msg model.DataMessage) {
+	if h.outbox == nil {
 		return
 	}
-
-	var sequence = int64(1)
-	for msg := range h.storeChannel {
-		dataMsg := &datastore.DataMessage{
-			Sequence:     sequence,
-			CollectionId: msg.Device.CollectionID.String(),
-			DeviceId:     msg.Device.ID.String(),
-			Payload:      msg.Payload,
-			Created:      msg.Received.UnixNano(),
-			Metadata:     makeMetadata(msg),
-		}
-		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*500)
-		logging.Debug("Storing message (type:%s, payload=%d bytes)", msg.Transport.String(), len(msg.Payload))
-		_, err := h.dataStoreClient.StoreData(ctx, dataMsg)
-		if err != nil {
-			logging.Warning("Got error calling PutData: %v", err)
-		}
-		cancel()
-		sequence++
+	logging.Debug("Storing message (type:%s, payload=%d bytes)", msg.Transport.String(), len(msg.Payload))
+	err := h.outbox.Add(&datastore.DataMessage{
+		CollectionId: msg.Device.CollectionID.String(),
+		DeviceId:     msg.Device.ID.String(),
+		Payload:      msg.Payload,
+		Created:      msg.Received.UnixNano(),
+		Metadata:     makeMetadata(msg),
+	})
+	if err != nil {
+		logging.Error("Unable to add message from device %s to the data store outbox: %v", msg.Device.ID.String(), err)
 	}
 }
 
 func (h *hordeServer) upstreamForwarder() {
 	for msg := range h.upstreamMessages {
 		h.mgr.Publish(msg)
-		select {
-		case h.storeChannel <- msg:
-			// ok
-		default:
-			logging.Warning("Store channel dropped message.")
-		}
+		h.storeData(msg)
 		metrics.DefaultCoreCounters.MessagesInCount.Add(1)
 	}
 }
@@ -143,5 +124,10 @@ func (h *hordeServer) Stop() error {
 		return err
 	}
 	h.mgr.Shutdown()
+	if h.outbox != nil {
+		if err := h.outbox.Close(); err != nil {
+			logging.Warning("Error closing data store outbox: %v", err)
+		}
+	}
 	return nil
 }
@@ -46,6 +46,7 @@ func TestLocalServer(t *testing.T) {
 	args := []string{
 		"--log-type=plain",
 		"--log-level=debug",
+		"--data-outbox=:memory:",
 	}
 	if !testing.Short() {
 		go func() {
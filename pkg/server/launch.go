@@ -31,7 +31,9 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/eesrc/horde/pkg/addons/magpie"
+	"github.com/eesrc/horde/pkg/api"
 	"github.com/eesrc/horde/pkg/apn"
+	"github.com/eesrc/horde/pkg/metering"
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
@@ -110,6 +112,13 @@ func LaunchHorde(args []string) {
 	}
 	metrics.DefaultCoreCounters.Update(storeCounter)
 	store = counters.NewCounterWrapperStore(store)
+
+	metering.DefaultMeter.Start(store, config.MeteringInterval)
+	defer metering.DefaultMeter.Stop()
+
+	auditPurger := api.NewAuditTrailPurger(store, config.AuditRetention, api.DefaultAuditPurgeInterval)
+	defer auditPurger.Stop()
+
 	if config.EnableLocalOutputs {
 		output.DisableLocalhostChecks()
 	}
@@ -131,8 +140,29 @@ func LaunchHorde(args []string) {
 		config.GRPCDataStore.TLS = config.DataStorage.GRPC.TLS
 	}
 
-	hordeserver := newServer(config.GRPCDataStore)
-	mgr := output.NewLocalManager()
+	hordeserver, err := newServer(config.GRPCDataStore, config.DataOutbox)
+	if err != nil {
+		logging.Error("Unable to open data store outbox (%s): %v", config.DataOutbox, err)
+		return
+	}
+	var mgr output.Manager
+	if config.OutputCluster.Enabled {
+		if config.Caching {
+			logging.Warning("Outputs are running in a cluster with a cached data store. Changes on other nodes won't be picked up.")
+		}
+		leaseStore, err := sqlstore.NewLeaseStore(config.DB)
+		if err != nil {
+			logging.Error("Error creating output lease store: %v", err)
+			return
+		}
+		mgr, err = output.NewClusterManager(config.OutputCluster, leaseStore, store.OutputListAll)
+		if err != nil {
+			logging.Error("Error launching output cluster: %v", err)
+			return
+		}
+	} else {
+		mgr = output.NewLocalManager()
+	}
 
 	config.Connect.SetSessionStoreConfig(config.DB.Type, config.DB.ConnectionString)
 
@@ -142,7 +172,12 @@ func LaunchHorde(args []string) {
 		return
 	}
 
-	fwStore, err := fwimage.NewSQLStore(config.DB)
+	var fwStore storage.FirmwareImageStore
+	if config.FirmwareImageStore == "grpc" {
+		fwStore, err = fwimage.NewGRPCStore(config.GRPCFirmwareImages)
+	} else {
+		fwStore, err = fwimage.NewSQLStore(config.DB)
+	}
 	if err != nil {
 		logging.Error("Error creating firmware image store: %v", err)
 		return
@@ -185,7 +220,7 @@ func LaunchHorde(args []string) {
 
 	publisher := make(chan model.DataMessage)
 	rxtxReceiver := apn.NewRxTxReceiver(apnConfig, store, apnStore, downstreamStore, publisher)
-	if err := fota.SetupFOTA(config.FOTA, rxtxReceiver, store, fwStore); err != nil {
+	if err := fota.SetupFOTA(config.FOTA, rxtxReceiver, store, fwStore, mgr); err != nil {
 		return
 	}
 
@@ -229,11 +264,11 @@ func LaunchHorde(args []string) {
 		defer ul.Stop()
 		logging.Info("Started embedded UDP and CoAP listener")
 	}
-	api := restapi.NewServer(config.HTTP, config.GRPCDataStore, config.Connect,
-		config.Github, store, fwStore, &messageSender{rxtxReceiver}, mgr, config.DeviceFieldMask)
+	restAPI := restapi.NewServer(config.HTTP, config.GRPCDataStore, config.Connect,
+		config.Github, config.OIDC, store, fwStore, &messageSender{rxtxReceiver}, mgr, config.DeviceFieldMask)
 
 	// Fire up Horde server
-	if err := hordeserver.Start(store, api, publisher, mgr, config.DeviceFieldMask.ForcedFields()); err != nil {
+	if err := hordeserver.Start(store, restAPI, publisher, mgr, config.DeviceFieldMask.ForcedFields()); err != nil {
 		logging.Error("Unable to launch Horde main server: %v", err)
 		return
 	}
@@ -248,7 +283,8 @@ func LaunchHorde(args []string) {
 		return
 	}
 
-	if err := StartHordeManagementInterface(config.Management, apnStore, store, apnConfig); err != nil {
+	restorer := api.NewDataRestorer(store, hordeserver.dataStoreClient, config.DeviceFieldMask, mgr)
+	if err := StartHordeManagementInterface(config.Management, apnStore, store, apnConfig, restorer); err != nil {
 		logging.Error("Unable to start the management interface: %v", err)
 		return
 	}
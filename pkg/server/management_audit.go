@@ -0,0 +1,170 @@
+package server
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/api"
+	"github.com/eesrc/horde/pkg/managementproto"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/golang/protobuf/proto"
+)
+
+// auditManagementServer records the changes made through the management
+// service in the audit trail. The events are stored in the main store so
+// changes are only recorded when the management service has one. Failed
+// requests aren't recorded.
+type auditManagementServer struct {
+	managementproto.HordeManagementServiceServer
+	store storage.DataStore
+}
+
+func (a *auditManagementServer) record(action, resourceType, resourceID string, teamID model.TeamKey, before, after proto.Message) {
+	event := model.AuditEvent{
+		ID:           a.store.NewAuditEventID(),
+		Time:         time.Now(),
+		TeamID:       teamID,
+		Source:       model.AuditSourceManagement,
+		AuthMethod:   model.AuthNone,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Before:       api.AuditSnapshot(before),
+		After:        api.AuditSnapshot(after),
+	}
+	if err := a.store.CreateAuditEvent(event); err != nil {
+		logging.Warning("Unable to store audit event for %s %s: %v", action, resourceID, err)
+	}
+}
+
+func (a *auditManagementServer) AddAPN(ctx context.Context, req *managementproto.AddAPNRequest) (*managementproto.AddAPNResponse, error) {
+	ret, err := a.HordeManagementServiceServer.AddAPN(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("AddAPN", "apn", fmt.Sprintf("/apns/%d", req.NewAPN.ApnID), 0, nil, req.NewAPN)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) RemoveAPN(ctx context.Context, req *managementproto.RemoveAPNRequest) (*managementproto.RemoveAPNResponse, error) {
+	ret, err := a.HordeManagementServiceServer.RemoveAPN(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("RemoveAPN", "apn", fmt.Sprintf("/apns/%d", req.ApnID), 0, req, nil)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) ReloadAPN(ctx context.Context, req *managementproto.ReloadAPNRequest) (*managementproto.ReloadAPNResponse, error) {
+	ret, err := a.HordeManagementServiceServer.ReloadAPN(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("ReloadAPN", "apn", "/apns", 0, nil, nil)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) AddNAS(ctx context.Context, req *managementproto.AddNASRequest) (*managementproto.AddNASResponse, error) {
+	ret, err := a.HordeManagementServiceServer.AddNAS(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("AddNAS", "nas", fmt.Sprintf("/apns/%d/nas/%d", req.ApnID, req.NewRange.NasID), 0, nil, req.NewRange)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) RemoveNAS(ctx context.Context, req *managementproto.RemoveNASRequest) (*managementproto.RemoveNASResponse, error) {
+	ret, err := a.HordeManagementServiceServer.RemoveNAS(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("RemoveNAS", "nas", fmt.Sprintf("/apns/%d/nas/%d", req.ApnID, req.NasID), 0, req, nil)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) AddAllocation(ctx context.Context, req *managementproto.AddAllocationRequest) (*managementproto.AddAllocationResponse, error) {
+	ret, err := a.HordeManagementServiceServer.AddAllocation(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("AddAllocation", "allocation", fmt.Sprintf("/apns/%d/nas/%d/allocations/%d", req.ApnID, req.NasID, req.IMSI), 0, nil, req)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) RemoveAPNAllocation(ctx context.Context, req *managementproto.RemoveAPNAllocationRequest) (*managementproto.RemoveAPNAllocationResponse, error) {
+	ret, err := a.HordeManagementServiceServer.RemoveAPNAllocation(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("RemoveAPNAllocation", "allocation", fmt.Sprintf("/apns/%d/nas/%d/allocations/%d", req.ApnID, req.NasID, req.IMSI), 0, req, nil)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) AddUser(ctx context.Context, req *managementproto.AddUserRequest) (*managementproto.AddUserResponse, error) {
+	ret, err := a.HordeManagementServiceServer.AddUser(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("AddUser", "user", "/users/"+ret.UserId, 0, nil, req)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) AddToken(ctx context.Context, req *managementproto.AddTokenRequest) (*managementproto.AddTokenResponse, error) {
+	ret, err := a.HordeManagementServiceServer.AddToken(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("AddToken", "token", "/tokens/"+model.TokenID(ret.ApiToken), 0, nil, req)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) RemoveToken(ctx context.Context, req *managementproto.RemoveTokenRequest) (*managementproto.RemoveTokenResponse, error) {
+	// The token can be referenced either by the token itself or the ID
+	tokenID := req.ApiToken
+	if _, err := a.store.RetrieveToken(model.TokenID(req.ApiToken)); err == nil {
+		tokenID = model.TokenID(req.ApiToken)
+	}
+	ret, err := a.HordeManagementServiceServer.RemoveToken(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("RemoveToken", "token", "/tokens/"+tokenID, 0, req, nil)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) SetTeamQuota(ctx context.Context, req *managementproto.SetTeamQuotaRequest) (*managementproto.SetTeamQuotaResponse, error) {
+	var before proto.Message
+	teamID, _ := model.NewTeamKeyFromString(req.TeamId)
+	if quota, err := a.store.RetrieveTeamQuota(teamID); err == nil {
+		before = &managementproto.TeamQuota{
+			MaxDevices:        int32(quota.MaxDevices),
+			MaxCollections:    int32(quota.MaxCollections),
+			MaxOutputs:        int32(quota.MaxOutputs),
+			MaxFirmwareBytes:  quota.MaxFirmwareBytes,
+			MaxUplinkPerDay:   int32(quota.MaxUplinkPerDay),
+			MaxDownlinkPerDay: int32(quota.MaxDownlinkPerDay),
+		}
+	}
+	ret, err := a.HordeManagementServiceServer.SetTeamQuota(ctx, req)
+	if err == nil && ret.Result.Success {
+		a.record("SetTeamQuota", "quota", "/teams/"+teamID.String()+"/quota", teamID, before, req.Quota)
+	}
+	return ret, err
+}
+
+func (a *auditManagementServer) DataRestore(ctx context.Context, req *managementproto.DataRestoreRequest) (*managementproto.DataRestoreResponse, error) {
+	ret, err := a.HordeManagementServiceServer.DataRestore(ctx, req)
+	if err == nil && ret.Result.Success {
+		teamID, _ := model.NewTeamKeyFromString(req.TeamId)
+		a.record("DataRestore", "team", "/teams/"+teamID.String(), teamID, nil, ret)
+	}
+	return ret, err
+}
@@ -0,0 +1,160 @@
+package server
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eesrc/horde/pkg/api"
+	"github.com/eesrc/horde/pkg/managementproto"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+)
+
+func TestManagementAuditTrail(t *testing.T) {
+	store := sqlstore.NewMemoryStore()
+	mgmt := newManagementServer(nil, store, nil, nil)
+	ctx := context.Background()
+
+	user, err := mgmt.AddUser(ctx, &managementproto.AddUserRequest{Name: "Some user"})
+	if err != nil || !user.Result.Success {
+		t.Fatalf("Unable to create user: %v %+v", err, user)
+	}
+	token, err := mgmt.AddToken(ctx, &managementproto.AddTokenRequest{UserId: user.UserId})
+	if err != nil || !token.Result.Success {
+		t.Fatalf("Unable to create token: %v %+v", err, token)
+	}
+	removed, err := mgmt.RemoveToken(ctx, &managementproto.RemoveTokenRequest{UserId: user.UserId, ApiToken: token.ApiToken})
+	if err != nil || !removed.Result.Success {
+		t.Fatalf("Unable to remove token: %v %+v", err, removed)
+	}
+	// Failed requests aren't recorded
+	if res, err := mgmt.AddToken(ctx, &managementproto.AddTokenRequest{UserId: "0"}); err != nil || res.Result.Success {
+		t.Fatalf("Expected AddToken to fail: %v %+v", err, res)
+	}
+
+	events, err := store.ListAuditEvents(0, 0, 10)
+	if err != nil {
+		t.Fatal("Unable to list audit events: ", err)
+	}
+	expected := []string{"RemoveToken", "AddToken", "AddUser"}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events but got %d (%+v)", len(expected), len(events), events)
+	}
+	for i, e := range events {
+		if e.Action != expected[i] || e.Source != model.AuditSourceManagement || e.UserID != 0 {
+			t.Fatalf("Unexpected event: %+v", e)
+		}
+		if strings.Contains(e.Before+e.After, token.ApiToken) {
+			t.Fatalf("Event contains the API token: %+v", e)
+		}
+	}
+	if events[0].ResourceID != "/tokens/"+model.TokenID(token.ApiToken) || events[0].ResourceID != events[1].ResourceID {
+		t.Fatalf("Unexpected resource ID for tokens: %s and %s", events[0].ResourceID, events[1].ResourceID)
+	}
+
+	userID, _ := model.NewUserKeyFromString(user.UserId)
+	u, err := store.RetrieveUser(userID)
+	if err != nil {
+		t.Fatal("Unable to retrieve user: ", err)
+	}
+	res, err := mgmt.SetTeamQuota(ctx, &managementproto.SetTeamQuotaRequest{
+		TeamId: u.PrivateTeamID.String(),
+		Quota:  &managementproto.TeamQuota{MaxDevices: 10},
+	})
+	if err != nil || !res.Result.Success {
+		t.Fatalf("Unable to set quota: %v %+v", err, res)
+	}
+	events, err = store.ListAuditEvents(u.PrivateTeamID, 0, 10)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("Expected a single event for the team but got %d (err=%v)", len(events), err)
+	}
+	if changed := events[0].ChangedFields(); len(changed) != 1 || changed[0] != "MaxDevices" {
+		t.Fatalf("Expected MaxDevices to change: %v (%+v)", changed, events[0])
+	}
+}
+
+func TestManagementDataRestore(t *testing.T) {
+	store := sqlstore.NewMemoryStore()
+	restorer := api.NewDataRestorer(store, nil, model.FieldMaskParameters{}, nil)
+	mgmt := newManagementServer(nil, store, nil, restorer)
+	ctx := context.Background()
+
+	user, err := mgmt.AddUser(ctx, &managementproto.AddUserRequest{Name: "Some user"})
+	if err != nil || !user.Result.Success {
+		t.Fatalf("Unable to create user: %v %+v", err, user)
+	}
+	userID, _ := model.NewUserKeyFromString(user.UserId)
+	u, err := store.RetrieveUser(userID)
+	if err != nil {
+		t.Fatal("Unable to retrieve user: ", err)
+	}
+
+	// This is the JSON from the /datadump resource
+	dump := `{
+		"collections": [{
+			"collection": {"collectionId": "1", "tags": {"name": "restored"}},
+			"devices": [{"device": {"deviceId": "2", "imsi": "4711", "imei": "4712"}}],
+			"outputs": [{"type": "udp", "config": {"host": "example.com", "port": 4711}}]
+		}],
+		"profile": {"name": "Some user"}
+	}`
+	if res, err := mgmt.DataRestore(ctx, &managementproto.DataRestoreRequest{
+		TeamId: u.PrivateTeamID.String(),
+		UserId: user.UserId,
+		Dump:   []byte("not json"),
+	}); err != nil || res.Result.Success {
+		t.Fatalf("Expected invalid dump to fail: %v %+v", err, res)
+	}
+	// Downloaded dumps must be complete
+	truncated := `{"profile": {"name": "Some user"}}
+		{"collection": {"collectionId": "1"}}`
+	if res, err := mgmt.DataRestore(ctx, &managementproto.DataRestoreRequest{
+		TeamId: u.PrivateTeamID.String(),
+		UserId: user.UserId,
+		Dump:   []byte(truncated),
+	}); err != nil || res.Result.Success {
+		t.Fatalf("Expected incomplete dump to fail: %v %+v", err, res)
+	}
+	res, err := mgmt.DataRestore(ctx, &managementproto.DataRestoreRequest{
+		TeamId: u.PrivateTeamID.String(),
+		UserId: user.UserId,
+		Dump:   []byte(dump),
+	})
+	if err != nil || !res.Result.Success {
+		t.Fatalf("Unable to restore dump: %v %+v", err, res)
+	}
+	if len(res.CollectionIds) != 1 || res.Devices != 1 || res.Outputs != 1 {
+		t.Fatalf("Unexpected restore response: %+v", res)
+	}
+
+	// The device is already restored
+	res, err = mgmt.DataRestore(ctx, &managementproto.DataRestoreRequest{
+		TeamId: u.PrivateTeamID.String(),
+		UserId: user.UserId,
+		Dump:   []byte(dump),
+	})
+	if err != nil || res.Result.Success || len(res.Conflicts) != 1 {
+		t.Fatalf("Expected conflict when restoring twice: %v %+v", err, res)
+	}
+
+	events, err := store.ListAuditEvents(u.PrivateTeamID, 0, 10)
+	if err != nil || len(events) != 1 || events[0].Action != "DataRestore" {
+		t.Fatalf("Expected a single restore event for the team: %v %+v", err, events)
+	}
+}
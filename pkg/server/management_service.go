@@ -22,11 +22,14 @@ import (
 	"time"
 
 	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/api"
+	"github.com/eesrc/horde/pkg/api/apitoolbox"
 	"github.com/eesrc/horde/pkg/managementproto"
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
 	"github.com/eesrc/horde/pkg/utils/grpcutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 // This is the management service implementation. This is more or less a quite
@@ -41,12 +44,12 @@ const (
 // StartHordeManagementInterface starts the gRPC management interface. If one
 // of the store parameters is nil the store won't support operations on that
 // particular store.
-func StartHordeManagementInterface(config grpcutil.GRPCServerParam, apnStore storage.APNStore, mainStore storage.DataStore, apnConfig *storage.APNConfigCache) error {
+func StartHordeManagementInterface(config grpcutil.GRPCServerParam, apnStore storage.APNStore, mainStore storage.DataStore, apnConfig *storage.APNConfigCache, restorer *api.DataRestorer) error {
 	server, err := grpcutil.NewGRPCServer(config)
 	if err != nil {
 		return err
 	}
-	mgmtServer := newManagementServer(apnStore, mainStore, apnConfig)
+	mgmtServer := newManagementServer(apnStore, mainStore, apnConfig, restorer)
 
 	if err := server.Launch(func(srv *grpc.Server) {
 		managementproto.RegisterHordeManagementServiceServer(srv, mgmtServer)
@@ -61,16 +64,23 @@ type hordeManagementServer struct {
 	apnStore  storage.APNStore
 	mainStore storage.DataStore
 	apnCache  *storage.APNConfigCache
+	restorer  *api.DataRestorer
 }
 
 // newManagementServer creates a new management server. If the mainStore is omitted
-// it will only support APN operations.
-func newManagementServer(apnStore storage.APNStore, mainStore storage.DataStore, apnConfig *storage.APNConfigCache) managementproto.HordeManagementServiceServer {
-	return &hordeManagementServer{
+// it will only support APN operations and the changes won't be recorded in
+// the audit trail. Data dumps can't be restored if the restorer is omitted.
+func newManagementServer(apnStore storage.APNStore, mainStore storage.DataStore, apnConfig *storage.APNConfigCache, restorer *api.DataRestorer) managementproto.HordeManagementServiceServer {
+	ret := &hordeManagementServer{
 		apnStore:  apnStore,
 		mainStore: mainStore,
 		apnCache:  apnConfig,
+		restorer:  restorer,
 	}
+	if mainStore == nil {
+		return ret
+	}
+	return &auditManagementServer{HordeManagementServiceServer: ret, store: mainStore}
 }
 
 func makeResult(success bool, message string) *managementproto.Result {
@@ -308,6 +318,7 @@ func (m *hordeManagementServer) AddUser(ctx context.Context, req *managementprot
 	token.UserID = u.ID
 	token.Write = true
 	token.Resource = "/"
+	token.Created = time.Now()
 	if err := m.mainStore.CreateToken(token); err != nil {
 		logging.Warning("Unable to create API token for user %v: %v", u.ID, err)
 		return &managementproto.AddUserResponse{
@@ -355,6 +366,7 @@ func (m *hordeManagementServer) AddToken(ctx context.Context, req *managementpro
 	newToken.UserID = userID
 	newToken.Resource = "/"
 	newToken.Write = true
+	newToken.Created = time.Now()
 	if err := m.mainStore.CreateToken(newToken); err != nil {
 		logging.Warning("Unable to store token for user %d: %v", userID, err)
 		return &managementproto.AddTokenResponse{
@@ -394,9 +406,14 @@ func (m *hordeManagementServer) RemoveToken(ctx context.Context, req *management
 		}, nil
 	}
 
-	if err := m.mainStore.DeleteToken(userID, req.ApiToken); err != nil {
+	// The token can be referenced either by the token itself or the ID
+	err = m.mainStore.DeleteToken(userID, model.TokenID(req.ApiToken))
+	if err == storage.ErrNotFound {
+		err = m.mainStore.DeleteToken(userID, req.ApiToken)
+	}
+	if err != nil {
 		if err != storage.ErrNotFound {
-			logging.Warning("Unable to remove token %s: %v", req.ApiToken, err)
+			logging.Warning("Unable to remove token %s: %v", model.TokenID(req.ApiToken), err)
 		}
 		return &managementproto.RemoveTokenResponse{
 			Result: makeResult(false, "Unable to remove token, consult application logs"),
@@ -406,3 +423,141 @@ func (m *hordeManagementServer) RemoveToken(ctx context.Context, req *management
 		Result: makeResult(true, ""),
 	}, nil
 }
+
+func (m *hordeManagementServer) SetTeamQuota(ctx context.Context, req *managementproto.SetTeamQuotaRequest) (*managementproto.SetTeamQuotaResponse, error) {
+	if m.mainStore == nil {
+		return nil, errors.New("this management server does not support user operations")
+	}
+	teamID, err := model.NewTeamKeyFromString(req.TeamId)
+	if err != nil {
+		return &managementproto.SetTeamQuotaResponse{
+			Result: makeResult(false, "Invalid team ID"),
+		}, nil
+	}
+	if req.Quota == nil {
+		return &managementproto.SetTeamQuotaResponse{
+			Result: makeResult(false, "Missing quota"),
+		}, nil
+	}
+	quota := model.Quota{
+		MaxDevices:        int(req.Quota.MaxDevices),
+		MaxCollections:    int(req.Quota.MaxCollections),
+		MaxOutputs:        int(req.Quota.MaxOutputs),
+		MaxFirmwareBytes:  req.Quota.MaxFirmwareBytes,
+		MaxUplinkPerDay:   int(req.Quota.MaxUplinkPerDay),
+		MaxDownlinkPerDay: int(req.Quota.MaxDownlinkPerDay),
+	}
+	if quota.MaxDevices < 0 || quota.MaxCollections < 0 || quota.MaxOutputs < 0 ||
+		quota.MaxFirmwareBytes < 0 || quota.MaxUplinkPerDay < 0 || quota.MaxDownlinkPerDay < 0 {
+		return &managementproto.SetTeamQuotaResponse{
+			Result: makeResult(false, "Quota limits can't be negative"),
+		}, nil
+	}
+	if err := m.mainStore.UpdateTeamQuota(teamID, quota); err != nil {
+		if err == storage.ErrNotFound {
+			return &managementproto.SetTeamQuotaResponse{
+				Result: makeResult(false, "Unknown team"),
+			}, nil
+		}
+		logging.Warning("Unable to update quota for team %d: %v", teamID, err)
+		return &managementproto.SetTeamQuotaResponse{
+			Result: makeResult(false, "Unable to update quota, consult application logs"),
+		}, nil
+	}
+	return &managementproto.SetTeamQuotaResponse{
+		Result: makeResult(true, ""),
+	}, nil
+}
+
+func (m *hordeManagementServer) GetTeamQuota(ctx context.Context, req *managementproto.GetTeamQuotaRequest) (*managementproto.GetTeamQuotaResponse, error) {
+	if m.mainStore == nil {
+		return nil, errors.New("this management server does not support user operations")
+	}
+	teamID, err := model.NewTeamKeyFromString(req.TeamId)
+	if err != nil {
+		return &managementproto.GetTeamQuotaResponse{
+			Result: makeResult(false, "Invalid team ID"),
+		}, nil
+	}
+	quota, err := m.mainStore.RetrieveTeamQuota(teamID)
+	if err != nil {
+		logging.Warning("Unable to retrieve quota for team %d: %v", teamID, err)
+		return &managementproto.GetTeamQuotaResponse{
+			Result: makeResult(false, "Unable to retrieve quota, consult application logs"),
+		}, nil
+	}
+	usage, err := m.mainStore.RetrieveTeamUsage(teamID, time.Now())
+	if err != nil {
+		logging.Warning("Unable to retrieve resource usage for team %d: %v", teamID, err)
+		return &managementproto.GetTeamQuotaResponse{
+			Result: makeResult(false, "Unable to retrieve usage, consult application logs"),
+		}, nil
+	}
+	return &managementproto.GetTeamQuotaResponse{
+		Result: makeResult(true, ""),
+		Quota: &managementproto.TeamQuota{
+			MaxDevices:        int32(quota.MaxDevices),
+			MaxCollections:    int32(quota.MaxCollections),
+			MaxOutputs:        int32(quota.MaxOutputs),
+			MaxFirmwareBytes:  quota.MaxFirmwareBytes,
+			MaxUplinkPerDay:   int32(quota.MaxUplinkPerDay),
+			MaxDownlinkPerDay: int32(quota.MaxDownlinkPerDay),
+		},
+		Usage: &managementproto.TeamUsage{
+			Devices:       int32(usage.Devices),
+			Collections:   int32(usage.Collections),
+			Outputs:       int32(usage.Outputs),
+			FirmwareBytes: usage.FirmwareBytes,
+			UplinkToday:   int32(usage.UplinkToday),
+			DownlinkToday: int32(usage.DownlinkToday),
+		},
+	}, nil
+}
+
+func (m *hordeManagementServer) DataRestore(ctx context.Context, req *managementproto.DataRestoreRequest) (*managementproto.DataRestoreResponse, error) {
+	if m.mainStore == nil || m.restorer == nil {
+		return nil, errors.New("this management server does not support restoring data dumps")
+	}
+	teamID, err := model.NewTeamKeyFromString(req.TeamId)
+	if err != nil {
+		return &managementproto.DataRestoreResponse{
+			Result: makeResult(false, "Invalid team ID"),
+		}, nil
+	}
+	userID, err := model.NewUserKeyFromString(req.UserId)
+	if err != nil {
+		return &managementproto.DataRestoreResponse{
+			Result: makeResult(false, "Invalid user ID"),
+		}, nil
+	}
+	// Downloaded dumps are rejected if they are incomplete
+	dump, err := apitoolbox.ReadDataDump(req.Dump)
+	if err != nil {
+		return &managementproto.DataRestoreResponse{
+			Result: makeResult(false, "Invalid data dump: "+err.Error()),
+		}, nil
+	}
+	restored, err := m.restorer.Restore(ctx, userID, teamID, dump, req.IncludeData)
+	if err != nil {
+		return &managementproto.DataRestoreResponse{
+			Result: makeResult(false, status.Convert(err).Message()),
+		}, nil
+	}
+	if len(restored.Conflicts) > 0 {
+		return &managementproto.DataRestoreResponse{
+			Result:    makeResult(false, "Devices in the data dump conflicts with existing devices"),
+			Conflicts: restored.Conflicts,
+		}, nil
+	}
+	ret := &managementproto.DataRestoreResponse{
+		Result: makeResult(true, ""),
+	}
+	for _, c := range restored.Collections {
+		ret.CollectionIds = append(ret.CollectionIds, c.Collection.CollectionId.Value)
+		ret.Devices += c.Devices
+		ret.Outputs += c.Outputs
+		ret.Firmware += c.Firmware
+		ret.Messages += c.Messages
+	}
+	return ret, nil
+}
@@ -0,0 +1,498 @@
+package server
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/addons/magpie/datastore"
+	"github.com/eesrc/horde/pkg/metrics"
+	"github.com/golang/protobuf/proto"
+	_ "github.com/mattn/go-sqlite3" // use sqlite driver
+)
+
+const createOutboxTable = `
+CREATE TABLE IF NOT EXISTS outbox (
+	seq INTEGER PRIMARY KEY AUTOINCREMENT,
+	msg BLOB NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0
+)`
+
+// The dead letter table holds the messages the data store hasn't acknowledged
+// after outboxMaxAttempts attempts. They are kept for inspection and aren't
+// sent again.
+const createOutboxDeadTable = `
+CREATE TABLE IF NOT EXISTS outbox_dead (
+	seq INTEGER PRIMARY KEY,
+	msg BLOB NOT NULL,
+	attempts INTEGER NOT NULL,
+	removed INTEGER NOT NULL
+)`
+
+const (
+	outboxInsertQuery = `INSERT INTO outbox (msg) VALUES ($1)`
+	outboxRemoveQuery = `DELETE FROM outbox WHERE seq = $1`
+	outboxSelectQuery = `SELECT seq, msg FROM outbox WHERE seq > $1 ORDER BY seq LIMIT $2`
+	outboxCountQuery  = `SELECT COUNT(*) FROM outbox`
+
+	outboxAttemptQuery    = `UPDATE outbox SET attempts = attempts + 1 WHERE seq = $1`
+	outboxDeadLetterQuery = `INSERT INTO outbox_dead (seq, msg, attempts, removed)
+		SELECT seq, msg, attempts, $1 FROM outbox WHERE attempts >= $2`
+	outboxRemoveDeadQuery = `DELETE FROM outbox WHERE attempts >= $1`
+)
+
+const (
+	// outboxBatchSize is the number of messages read from the outbox at a time
+	outboxBatchSize = 100
+
+	// outboxWindow is the maximum number of messages sent to the data store
+	// without a receipt
+	outboxWindow = 500
+
+	// outboxAckTimeout is the time to wait for the receipt for a message. The
+	// stream is restarted if the data store doesn't acknowledge the oldest
+	// message in flight in time. The data store doesn't send receipts for
+	// messages it can't store so these are sent again on the new stream. Note
+	// that the
+	// data store doesn't check for duplicates in PutData so every message
+	// that is in flight when the stream is restarted is stored again if the
+	// data store has stored it without sending the receipt. The sequence
+	// number is the message's row in the outbox and stays the same when the
+	// message is sent again but it is only unique for a single outbox.
+	outboxAckTimeout = 30 * time.Second
+
+	// outboxMaxAttempts is the number of times a message can time out before
+	// it is moved to the dead letter table. A message the data store refuses
+	// to store would otherwise block the outbox and be sent again forever.
+	outboxMaxAttempts = 5
+
+	// outboxMinRetry and outboxMaxRetry is the backoff interval when the data
+	// store is unavailable
+	outboxMinRetry = time.Second
+	outboxMaxRetry = 30 * time.Second
+)
+
+var errOutboxClosed = errors.New("outbox is closed")
+
+// dataOutbox is a persistent queue for the messages that are forwarded to the
+// data store. Messages are written to a local database when they arrive and
+// streamed to the data store via the PutData call. Messages are removed from
+// the outbox when the data store sends a receipt for the message. Messages
+// without receipts are sent again when the stream is restarted, ie after a
+// restart of the data store or the core. The delivery is at-least-once;
+// the data store might get duplicates if the receipts are lost. Messages that
+// time out maxAttempts times are moved to the dead letter table.
+type dataOutbox struct {
+	db          *sql.DB
+	client      datastore.DataStoreClient
+	notify      chan struct{}
+	stop        chan struct{}
+	done        chan struct{}
+	closeOnce   sync.Once
+	minRetry    time.Duration
+	maxRetry    time.Duration
+	ackTimeout  time.Duration
+	maxAttempts int
+	sent        int64 // The highest sequence number sent to the data store
+}
+
+// newDataOutbox opens the outbox and starts forwarding messages to the data
+// store. Messages left in the outbox from an earlier run are sent first.
+func newDataOutbox(database string, client datastore.DataStoreClient) (*dataOutbox, error) {
+	ret, err := openDataOutbox(database, client)
+	if err != nil {
+		return nil, err
+	}
+	go ret.forwardLoop()
+	return ret, nil
+}
+
+func openDataOutbox(database string, client datastore.DataStoreClient) (*dataOutbox, error) {
+	db, err := sql.Open("sqlite3", database)
+	if err != nil {
+		return nil, err
+	}
+	// Use a single connection to avoid locking issues with SQLite. This also
+	// makes in-memory databases work.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	for _, stmt := range []string{createOutboxTable, createOutboxDeadTable} {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	if err := migrateOutbox(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	ret := &dataOutbox{
+		db:          db,
+		client:      client,
+		notify:      make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+		minRetry:    outboxMinRetry,
+		maxRetry:    outboxMaxRetry,
+		ackTimeout:  outboxAckTimeout,
+		maxAttempts: outboxMaxAttempts,
+	}
+	pending, err := ret.Pending()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if pending > 0 {
+		logging.Info("%d messages in the data store outbox will be sent again", pending)
+	}
+	// Messages left from an earlier run have (probably) been sent before
+	if err := db.QueryRow(`SELECT COALESCE(MAX(seq), 0) FROM outbox`).Scan(&ret.sent); err != nil {
+		db.Close()
+		return nil, err
+	}
+	metrics.DefaultCoreCounters.OutboxPending.Set(float64(pending))
+	return ret, nil
+}
+
+// migrateOutbox adds the attempts column to outboxes created by earlier
+// versions.
+func migrateOutbox(db *sql.DB) error {
+	probe, err := db.Query(`SELECT attempts FROM outbox WHERE 1 = 0`)
+	if err == nil {
+		probe.Close()
+		return nil
+	}
+	logging.Info("Adding attempts to the data store outbox")
+	_, err = db.Exec(`ALTER TABLE outbox ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0`)
+	return err
+}
+
+// Add stores the message in the outbox. The message's sequence number is
+// assigned by the outbox.
+func (o *dataOutbox) Add(msg *datastore.DataMessage) error {
+	select {
+	case <-o.stop:
+		return errOutboxClosed
+	default:
+	}
+	msg.Sequence = 0
+	buf, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := o.db.Exec(outboxInsertQuery, buf); err != nil {
+		return err
+	}
+	metrics.DefaultCoreCounters.OutboxPending.Inc()
+	select {
+	case o.notify <- struct{}{}:
+	default:
+		// There's a notification pending already
+	}
+	return nil
+}
+
+// Pending returns the number of messages in the outbox
+func (o *dataOutbox) Pending() (int, error) {
+	var count int
+	if err := o.db.QueryRow(outboxCountQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Close stops the forwarding and closes the database. Messages that haven't
+// been acknowledged stay in the outbox.
+func (o *dataOutbox) Close() error {
+	o.closeOnce.Do(func() {
+		close(o.stop)
+	})
+	<-o.done
+	return o.db.Close()
+}
+
+// forwardLoop streams messages to the data store until the outbox is closed.
+// The stream is restarted with an increasing backoff if it fails.
+func (o *dataOutbox) forwardLoop() {
+	defer close(o.done)
+	retry := o.minRetry
+	for {
+		acknowledged, err := o.stream()
+		if err == errOutboxClosed {
+			return
+		}
+		metrics.DefaultCoreCounters.OutboxErrors.Inc()
+		logging.Warning("Data store stream stopped: %v. Retrying in %v", err, retry)
+		if acknowledged > 0 {
+			retry = o.minRetry
+		}
+		select {
+		case <-time.After(retry):
+		case <-o.stop:
+			return
+		}
+		retry *= 2
+		if retry > o.maxRetry {
+			retry = o.maxRetry
+		}
+	}
+}
+
+// stream runs a single PutData stream. Every message in the outbox is sent
+// in order, starting with the oldest message, and removed when the receipt
+// arrives. The stream stops if the oldest message in flight isn't acknowledged
+// within the ack timeout. It returns the number of receipts and the error that stopped the
+// stream.
+func (o *dataOutbox) stream() (int, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := o.client.PutData(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	receipts := make(chan int64)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			r, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case receipts <- r.Sequence:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// The messages in flight with the time they were sent. The order slice
+	// holds the sequence numbers in the order they were sent; acknowledged
+	// messages are dropped from the front when the oldest message is looked
+	// up.
+	inFlight := make(map[int64]time.Time)
+	var order []int64
+	defer metrics.DefaultCoreCounters.OutboxInFlight.Set(0)
+	acknowledged := 0
+
+	oldest := func() (time.Time, bool) {
+		for len(order) > 0 {
+			if sent, ok := inFlight[order[0]]; ok {
+				return sent, true
+			}
+			order = order[1:]
+		}
+		return time.Time{}, false
+	}
+
+	// There's a single timer for the oldest message in flight. It is only
+	// reset when the oldest message changes.
+	timer := time.NewTimer(o.ackTimeout)
+	stopTimer(timer)
+	defer timer.Stop()
+	var deadline time.Time
+
+	receipt := func(seq int64) error {
+		if _, ok := inFlight[seq]; !ok {
+			return nil
+		}
+		delete(inFlight, seq)
+		if _, err := o.db.Exec(outboxRemoveQuery, seq); err != nil {
+			return err
+		}
+		acknowledged++
+		metrics.DefaultCoreCounters.OutboxInFlight.Set(float64(len(inFlight)))
+		metrics.DefaultCoreCounters.OutboxPending.Dec()
+		metrics.DefaultCoreCounters.OutboxStored.Inc()
+		return nil
+	}
+
+	var lastSent int64
+	for {
+		batch, err := o.nextBatch(lastSent, outboxWindow-len(inFlight))
+		if err != nil {
+			return acknowledged, err
+		}
+		for _, msg := range batch {
+			if err := stream.Send(msg); err != nil {
+				return acknowledged, err
+			}
+			lastSent = msg.Sequence
+			inFlight[msg.Sequence] = time.Now()
+			order = append(order, msg.Sequence)
+			if msg.Sequence <= o.sent {
+				metrics.DefaultCoreCounters.OutboxReplayed.Inc()
+			} else {
+				o.sent = msg.Sequence
+			}
+		}
+		metrics.DefaultCoreCounters.OutboxInFlight.Set(float64(len(inFlight)))
+
+		// Wait for new messages or receipts. New messages have to wait for
+		// receipts when the window is full.
+		var notify <-chan struct{}
+		var timeout <-chan time.Time
+		if len(inFlight) < outboxWindow {
+			notify = o.notify
+			if len(batch) > 0 {
+				continue
+			}
+		}
+		if sent, ok := oldest(); ok {
+			if next := sent.Add(o.ackTimeout); !next.Equal(deadline) {
+				deadline = next
+				stopTimer(timer)
+				timer.Reset(time.Until(deadline))
+			}
+			timeout = timer.C
+		}
+		select {
+		case seq := <-receipts:
+			if err := receipt(seq); err != nil {
+				return acknowledged, err
+			}
+		case <-notify:
+		case err := <-recvErr:
+			return acknowledged, err
+		case <-timeout:
+			var expired []int64
+			now := time.Now()
+			for _, seq := range order {
+				if sent, ok := inFlight[seq]; ok && !now.Before(sent.Add(o.ackTimeout)) {
+					expired = append(expired, seq)
+				}
+			}
+			if err := o.countAttempts(expired); err != nil {
+				return acknowledged, err
+			}
+			return acknowledged, errors.New("timed out waiting for receipts")
+		case <-o.stop:
+			stream.CloseSend()
+			return acknowledged, errOutboxClosed
+		}
+	}
+}
+
+// stopTimer stops the timer and drains the channel so the timer can be reset
+func stopTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+}
+
+// countAttempts increments the attempts for messages that timed out and
+// moves the messages that have reached the maximum number of attempts to the
+// dead letter table.
+func (o *dataOutbox) countAttempts(seqs []int64) error {
+	if len(seqs) == 0 {
+		return nil
+	}
+	tx, err := o.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, seq := range seqs {
+		if _, err := tx.Exec(outboxAttemptQuery, seq); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	res, err := tx.Exec(outboxDeadLetterQuery, time.Now().UnixNano(), o.maxAttempts)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	dead, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(outboxRemoveDeadQuery, o.maxAttempts); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if dead > 0 {
+		logging.Error("Moved %d messages to the outbox dead letter table after %d attempts", dead, o.maxAttempts)
+		metrics.DefaultCoreCounters.OutboxPending.Sub(float64(dead))
+		metrics.DefaultCoreCounters.OutboxDeadLettered.Add(float64(dead))
+	}
+	return nil
+}
+
+// nextBatch reads the messages after the sequence number from the outbox
+func (o *dataOutbox) nextBatch(after int64, max int) ([]*datastore.DataMessage, error) {
+	if max <= 0 {
+		return nil, nil
+	}
+	if max > outboxBatchSize {
+		max = outboxBatchSize
+	}
+	rows, err := o.db.Query(outboxSelectQuery, after, max)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*datastore.DataMessage
+	var unreadable []int64
+	for rows.Next() {
+		var seq int64
+		var buf []byte
+		if err := rows.Scan(&seq, &buf); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		msg := &datastore.DataMessage{}
+		if err := proto.Unmarshal(buf, msg); err != nil {
+			logging.Error("Removing unreadable message %d from the outbox: %v", seq, err)
+			unreadable = append(unreadable, seq)
+			continue
+		}
+		msg.Sequence = seq
+		ret = append(ret, msg)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	// The rows must be closed before the next query since there's only one
+	// connection to the database.
+	rows.Close()
+
+	// Don't block the outbox with messages that can't be read
+	for _, seq := range unreadable {
+		if _, err := o.db.Exec(outboxRemoveQuery, seq); err != nil {
+			return nil, err
+		}
+		metrics.DefaultCoreCounters.OutboxPending.Dec()
+	}
+	return ret, nil
+}
@@ -0,0 +1,275 @@
+package server
+
+//
+// Copyright 2020 Telenor Digital AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/addons/magpie/datastore"
+	"github.com/eesrc/horde/pkg/utils/grpcutil"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeDataStore is a data store client that only implements PutData. The
+// messages sent are written to the received channel.
+type fakeDataStore struct {
+	datastore.DataStoreClient
+	mutex     sync.Mutex
+	available bool
+	ack       bool
+	reject    string // Payload that is never acknowledged
+	received  chan *datastore.DataMessage
+}
+
+func newFakeDataStore() *fakeDataStore {
+	return &fakeDataStore{received: make(chan *datastore.DataMessage, 1000)}
+}
+
+func (f *fakeDataStore) set(available, ack bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.available = available
+	f.ack = ack
+}
+
+func (f *fakeDataStore) acking(msg *datastore.DataMessage) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.ack && string(msg.Payload) != f.reject
+}
+
+func (f *fakeDataStore) PutData(ctx context.Context, opts ...grpc.CallOption) (datastore.DataStore_PutDataClient, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if !f.available {
+		return nil, errors.New("unavailable")
+	}
+	return &fakePutDataStream{ctx: ctx, store: f, receipts: make(chan int64, 1000)}, nil
+}
+
+type fakePutDataStream struct {
+	grpc.ClientStream
+	ctx      context.Context
+	store    *fakeDataStore
+	receipts chan int64
+}
+
+func (f *fakePutDataStream) Send(msg *datastore.DataMessage) error {
+	f.store.received <- msg
+	if f.store.acking(msg) {
+		f.receipts <- msg.Sequence
+	}
+	return nil
+}
+
+func (f *fakePutDataStream) Recv() (*datastore.Receipt, error) {
+	select {
+	case seq := <-f.receipts:
+		return &datastore.Receipt{Sequence: seq}, nil
+	case <-f.ctx.Done():
+		return nil, f.ctx.Err()
+	}
+}
+
+func (f *fakePutDataStream) CloseSend() error {
+	return nil
+}
+
+func startTestOutbox(t *testing.T, database string, client datastore.DataStoreClient) *dataOutbox {
+	outbox, err := openDataOutbox(database, client)
+	require.NoError(t, err)
+	outbox.minRetry = 10 * time.Millisecond
+	outbox.maxRetry = 50 * time.Millisecond
+	outbox.ackTimeout = 100 * time.Millisecond
+	go outbox.forwardLoop()
+	return outbox
+}
+
+func waitForEmptyOutbox(t *testing.T, outbox *dataOutbox) {
+	for i := 0; i < 200; i++ {
+		pending, err := outbox.Pending()
+		require.NoError(t, err)
+		if pending == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Outbox isn't empty")
+}
+
+func TestDataOutboxReplay(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "outbox")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	database := filepath.Join(dir, "outbox.db")
+
+	// The data store is unavailable so the messages stay in the outbox
+	client := newFakeDataStore()
+	outbox := startTestOutbox(t, database, client)
+	for i := 0; i < 10; i++ {
+		assert.NoError(outbox.Add(&datastore.DataMessage{
+			CollectionId: "1",
+			DeviceId:     "2",
+			Payload:      []byte(fmt.Sprintf("message %d", i)),
+		}))
+	}
+	time.Sleep(50 * time.Millisecond)
+	pending, err := outbox.Pending()
+	assert.NoError(err)
+	assert.Equal(10, pending)
+	assert.Len(client.received, 0)
+	assert.NoError(outbox.Close())
+	assert.Error(outbox.Add(&datastore.DataMessage{}))
+
+	// The messages are sent when the outbox is reopened, followed by new messages
+	client.set(true, true)
+	outbox = startTestOutbox(t, database, client)
+	defer outbox.Close()
+	assert.NoError(outbox.Add(&datastore.DataMessage{Payload: []byte("message 10")}))
+	waitForEmptyOutbox(t, outbox)
+
+	assert.Len(client.received, 11)
+	for i := 0; i < 11; i++ {
+		msg := <-client.received
+		assert.Equal(fmt.Sprintf("message %d", i), string(msg.Payload))
+		assert.Equal(int64(i+1), msg.Sequence)
+	}
+}
+
+func TestDataOutboxReceipts(t *testing.T) {
+	assert := require.New(t)
+
+	// Messages without receipts are sent again when the stream is restarted
+	client := newFakeDataStore()
+	client.set(true, false)
+	outbox := startTestOutbox(t, ":memory:", client)
+	defer outbox.Close()
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(outbox.Add(&datastore.DataMessage{Payload: []byte(fmt.Sprintf("message %d", i))}))
+	}
+	for i := 0; i < 5; i++ {
+		select {
+		case msg := <-client.received:
+			assert.Equal(fmt.Sprintf("message %d", i), string(msg.Payload))
+		case <-time.After(time.Second):
+			t.Fatal("Message not sent")
+		}
+	}
+	pending, err := outbox.Pending()
+	assert.NoError(err)
+	assert.Equal(5, pending)
+
+	select {
+	case msg := <-client.received:
+		assert.Equal("message 0", string(msg.Payload), "Oldest message is sent first")
+	case <-time.After(time.Second):
+		t.Fatal("Message not sent again")
+	}
+
+	client.set(true, true)
+	waitForEmptyOutbox(t, outbox)
+}
+
+func TestDataOutboxDeadLetter(t *testing.T) {
+	assert := require.New(t)
+
+	// The data store stores every message except one. The message is
+	// moved to the dead letter table after the maximum number of attempts
+	client := newFakeDataStore()
+	client.set(true, true)
+	client.reject = "message 2"
+	outbox := startTestOutbox(t, ":memory:", client)
+	defer outbox.Close()
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(outbox.Add(&datastore.DataMessage{Payload: []byte(fmt.Sprintf("message %d", i))}))
+	}
+	waitForEmptyOutbox(t, outbox)
+
+	sent := 0
+	for len(client.received) > 0 {
+		if msg := <-client.received; string(msg.Payload) == "message 2" {
+			sent++
+		}
+	}
+	assert.Equal(outboxMaxAttempts, sent)
+
+	var payload []byte
+	var attempts int
+	assert.NoError(outbox.db.QueryRow(`SELECT msg, attempts FROM outbox_dead`).Scan(&payload, &attempts))
+	assert.Equal(outboxMaxAttempts, attempts)
+	msg := &datastore.DataMessage{}
+	assert.NoError(proto.Unmarshal(payload, msg))
+	assert.Equal("message 2", string(msg.Payload))
+}
+
+// Outboxes from earlier versions don't have the attempts column
+func TestDataOutboxMigration(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "outbox")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	database := filepath.Join(dir, "outbox.db")
+
+	db, err := sql.Open("sqlite3", database)
+	assert.NoError(err)
+	_, err = db.Exec(`CREATE TABLE outbox (seq INTEGER PRIMARY KEY AUTOINCREMENT, msg BLOB NOT NULL)`)
+	assert.NoError(err)
+	buf, err := proto.Marshal(&datastore.DataMessage{Payload: []byte("message 0")})
+	assert.NoError(err)
+	_, err = db.Exec(`INSERT INTO outbox (msg) VALUES ($1)`, buf)
+	assert.NoError(err)
+	assert.NoError(db.Close())
+
+	client := newFakeDataStore()
+	client.set(true, true)
+	outbox := startTestOutbox(t, database, client)
+	defer outbox.Close()
+	waitForEmptyOutbox(t, outbox)
+	assert.Len(client.received, 1)
+}
+
+// The server must refuse to start if the outbox can't be opened since the
+// device data would be lost.
+func TestNewServerOutboxError(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "outbox")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	_, err = newServer(grpcutil.GRPCClientParam{ServerEndpoint: "127.0.0.1:1"}, filepath.Join(dir, "missing", "outbox.db"))
+	assert.Error(err)
+
+	srv, err := newServer(grpcutil.GRPCClientParam{}, filepath.Join(dir, "missing", "outbox.db"))
+	assert.NoError(err)
+	assert.Nil(srv.outbox)
+}
@@ -16,11 +16,15 @@ package server
 //limitations under the License.
 //
 import (
+	"time"
+
 	"github.com/eesrc/horde/pkg/apn/radius"
 	"github.com/eesrc/horde/pkg/deviceio"
 	"github.com/eesrc/horde/pkg/fota"
 	"github.com/eesrc/horde/pkg/ghlogin"
 	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/oidclogin"
+	"github.com/eesrc/horde/pkg/output"
 	"github.com/eesrc/horde/pkg/storage/sqlstore"
 	"github.com/eesrc/horde/pkg/utils"
 	"github.com/eesrc/horde/pkg/utils/grpcutil"
@@ -45,11 +49,15 @@ type hordeParameters struct {
 	HTTP               restapi.ServerParameters
 	Connect            restapi.ConnectIDParameters
 	Github             ghlogin.Config
+	OIDC               oidclogin.Config
 	GRPCDataStore      grpcutil.GRPCClientParam
+	DataOutbox         string `param:"desc=Outbox database for device data waiting to be stored;default=data-outbox.db"`
 	LaunchDataStorage  bool   `param:"desc=Launch embedded data storage server;default=false"`
 	MonitoringEndpoint string `param:"desc=Monitoring (varz) and trace endpoint;default=127.0.0.1:0"`
 	EnableLocalOutputs bool   `param:"desc=Enable outputs to local IP range;default=false"`
 	DataStorage        dataStoreParams
+	FirmwareImageStore string `param:"desc=Firmware image store (sql or grpc);default=sql;options=sql,grpc"`
+	GRPCFirmwareImages grpcutil.GRPCClientParam
 	DeviceFieldMask    model.FieldMaskParameters
 	Management         grpcutil.GRPCServerParam
 	AuditLog           bool `param:"desc=Device audit logging;default=true"`
@@ -61,5 +69,8 @@ type hordeParameters struct {
 	EmbeddedCOAP       deviceio.CoAPParameters
 	EmbeddedUDP        deviceio.UDPParameters
 	FOTA               fota.Parameters
-	Version            bool `param:"desc=Show version;default=false"`
+	OutputCluster      output.ClusterParameters
+	MeteringInterval   time.Duration `param:"desc=Interval for writing metered usage to the database;default=1m"`
+	AuditRetention     time.Duration `param:"desc=Retention time for the audit trail. Events are kept forever if this is 0;default=2160h"`
+	Version            bool          `param:"desc=Show version;default=false"`
 }
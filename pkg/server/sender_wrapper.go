@@ -79,6 +79,9 @@ func (m *messageSender) Send(device model.Device, msg model.DownstreamMessage) e
 		metrics.DefaultCoreCounters.MessagesOutCount.Add(1)
 		return nil
 	}
+	if quotaErr, ok := err.(*model.QuotaExceededError); ok {
+		return quotaErr
+	}
 	logging.Info("Got error sending message: %v (%s) (dest address: %s, port: %d, IMSI: %d)",
 		err, code.String(), device.Network.AllocatedIP, msg.Port, device.IMSI)
 	return errors.New(code.String())
@@ -18,6 +18,8 @@ package counters
 //limitations under the License.
 //
 import (
+	"time"
+
 	"github.com/eesrc/horde/pkg/metrics"
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
@@ -155,6 +157,10 @@ func (c *counterWrapStore) UpdateTokenTags(userID model.UserKey, token string, t
 	return c.store.UpdateTokenTags(userID, token, tags)
 }
 
+func (c *counterWrapStore) UpdateTokenUsage(tokenID string, lastUsed time.Time, ip string) error {
+	return c.store.UpdateTokenUsage(tokenID, lastUsed, ip)
+}
+
 func (c *counterWrapStore) RetrieveToken(token string) (model.Token, error) {
 	return c.store.RetrieveToken(token)
 }
@@ -167,6 +173,18 @@ func (c *counterWrapStore) ListDevices(userID model.UserKey, collectionID model.
 	return c.store.ListDevices(userID, collectionID)
 }
 
+func (c *counterWrapStore) ListDevicesPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Device, string, error) {
+	return c.store.ListDevicesPage(userID, collectionID, opts)
+}
+
+func (c *counterWrapStore) ImportDevices(userID model.UserKey, collectionID model.CollectionKey, devices []model.Device, upsert bool, check model.DeviceImportCheck) (model.DeviceImportResult, error) {
+	ret, err := c.store.ImportDevices(userID, collectionID, devices, upsert, check)
+	if err == nil {
+		metrics.DefaultCoreCounters.DeviceCount.Add(float64(len(ret.Created)))
+	}
+	return ret, err
+}
+
 func (c *counterWrapStore) RetrieveDevice(userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey) (model.Device, error) {
 	return c.store.RetrieveDevice(userID, collectionID, deviceID)
 }
@@ -203,6 +221,10 @@ func (c *counterWrapStore) ListCollections(userID model.UserKey) ([]model.Collec
 	return c.store.ListCollections(userID)
 }
 
+func (c *counterWrapStore) ListCollectionsPage(userID model.UserKey, opts model.ListOptions) ([]model.Collection, string, error) {
+	return c.store.ListCollectionsPage(userID, opts)
+}
+
 func (c *counterWrapStore) RetrieveCollection(userID model.UserKey, collectionID model.CollectionKey) (model.Collection, error) {
 	return c.store.RetrieveCollection(userID, collectionID)
 }
@@ -227,6 +249,10 @@ func (c *counterWrapStore) ListOutputs(userID model.UserKey, collectionID model.
 	return c.store.ListOutputs(userID, collectionID)
 }
 
+func (c *counterWrapStore) ListOutputsPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Output, string, error) {
+	return c.store.ListOutputsPage(userID, collectionID, opts)
+}
+
 func (c *counterWrapStore) RetrieveOutput(userID model.UserKey, collectionID model.CollectionKey, outputID model.OutputKey) (model.Output, error) {
 	return c.store.RetrieveOutput(userID, collectionID, outputID)
 }
@@ -304,6 +330,10 @@ func (c *counterWrapStore) DeleteFirmware(userID model.UserKey, collectionID mod
 func (c *counterWrapStore) ListFirmware(userID model.UserKey, collectionID model.CollectionKey) ([]model.Firmware, error) {
 	return c.store.ListFirmware(userID, collectionID)
 }
+
+func (c *counterWrapStore) ListFirmwarePage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Firmware, string, error) {
+	return c.store.ListFirmwarePage(userID, collectionID, opts)
+}
 func (c *counterWrapStore) UpdateFirmware(userID model.UserKey, collectionID model.CollectionKey, fw model.Firmware) error {
 	return c.store.UpdateFirmware(userID, collectionID, fw)
 }
@@ -328,3 +358,91 @@ func (c *counterWrapStore) RetrieveFirmwareByVersion(collectionID model.Collecti
 func (c *counterWrapStore) UpdateFirmwareStateForDevice(imsi int64, state model.DeviceFirmwareState, message string) error {
 	return c.store.UpdateFirmwareStateForDevice(imsi, state, message)
 }
+
+func (c *counterWrapStore) NewFirmwareHistoryID() model.FirmwareHistoryKey {
+	return c.store.NewFirmwareHistoryID()
+}
+
+func (c *counterWrapStore) CreateFirmwareHistory(entry model.FirmwareHistoryEntry) error {
+	return c.store.CreateFirmwareHistory(entry)
+}
+
+func (c *counterWrapStore) RetrieveLatestFirmwareHistory(deviceID model.DeviceKey) (model.FirmwareHistoryEntry, error) {
+	return c.store.RetrieveLatestFirmwareHistory(deviceID)
+}
+
+func (c *counterWrapStore) ListDeviceFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error) {
+	return c.store.ListDeviceFirmwareHistory(userID, collectionID, deviceID, before, limit)
+}
+
+func (c *counterWrapStore) ListFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error) {
+	return c.store.ListFirmwareHistory(userID, collectionID, firmwareID, before, limit)
+}
+
+func (c *counterWrapStore) RetrieveFirmwareStatistics(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey) (model.FirmwareStatistics, error) {
+	return c.store.RetrieveFirmwareStatistics(userID, collectionID, firmwareID)
+}
+
+func (c *counterWrapStore) NewSigningKeyID() model.SigningKeyKey {
+	return c.store.NewSigningKeyID()
+}
+
+func (c *counterWrapStore) CreateSigningKey(userID model.UserKey, key model.SigningKey) error {
+	return c.store.CreateSigningKey(userID, key)
+}
+
+func (c *counterWrapStore) RetrieveSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) (model.SigningKey, error) {
+	return c.store.RetrieveSigningKey(userID, collectionID, keyID)
+}
+
+func (c *counterWrapStore) ListSigningKeys(userID model.UserKey, collectionID model.CollectionKey) ([]model.SigningKey, error) {
+	return c.store.ListSigningKeys(userID, collectionID)
+}
+
+func (c *counterWrapStore) DeleteSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) error {
+	return c.store.DeleteSigningKey(userID, collectionID, keyID)
+}
+
+func (c *counterWrapStore) RetrieveTeamQuota(teamID model.TeamKey) (model.Quota, error) {
+	return c.store.RetrieveTeamQuota(teamID)
+}
+
+func (c *counterWrapStore) UpdateTeamQuota(teamID model.TeamKey, quota model.Quota) error {
+	return c.store.UpdateTeamQuota(teamID, quota)
+}
+
+func (c *counterWrapStore) RetrieveTeamUsage(teamID model.TeamKey, day time.Time) (model.QuotaUsage, error) {
+	return c.store.RetrieveTeamUsage(teamID, day)
+}
+
+func (c *counterWrapStore) AddMessageUsage(collectionID model.CollectionKey, direction model.MessageDirection, day time.Time) (bool, error) {
+	return c.store.AddMessageUsage(collectionID, direction, day)
+}
+
+func (c *counterWrapStore) RetrieveCollectionTeam(collectionID model.CollectionKey) (model.TeamKey, error) {
+	return c.store.RetrieveCollectionTeam(collectionID)
+}
+
+func (c *counterWrapStore) AddUsage(records []model.Usage) error {
+	return c.store.AddUsage(records)
+}
+
+func (c *counterWrapStore) RetrieveUsage(teamID model.TeamKey, from time.Time, to time.Time) ([]model.Usage, error) {
+	return c.store.RetrieveUsage(teamID, from, to)
+}
+
+func (c *counterWrapStore) NewAuditEventID() model.AuditKey {
+	return c.store.NewAuditEventID()
+}
+
+func (c *counterWrapStore) CreateAuditEvent(event model.AuditEvent) error {
+	return c.store.CreateAuditEvent(event)
+}
+
+func (c *counterWrapStore) ListAuditEvents(teamID model.TeamKey, before model.AuditKey, limit int) ([]model.AuditEvent, error) {
+	return c.store.ListAuditEvents(teamID, before, limit)
+}
+
+func (c *counterWrapStore) DeleteAuditEvents(olderThan time.Time) (int64, error) {
+	return c.store.DeleteAuditEvents(olderThan)
+}
@@ -16,6 +16,8 @@ package storage
 //limitations under the License.
 //
 import (
+	"time"
+
 	"github.com/eesrc/horde/pkg/model"
 )
 
@@ -32,7 +34,8 @@ type DataStore interface {
 	// RetreiveUser retrieves a single user from its ID
 	RetrieveUser(model.UserKey) (model.User, error)
 
-	// CreateToken creates a new access token in the backend store.
+	// CreateToken creates a new access token in the backend store. Only the
+	// token ID is stored, the token itself is discarded.
 	CreateToken(model.Token) error
 	// ListTokens lists all tokens available for the specified user. If the user
 	// is an administrator of the team owning the tokens both read-only and
@@ -44,16 +47,19 @@ type DataStore interface {
 	UpdateToken(model.Token) error
 	// DeleteToken removes a token from the backend store. The user must be the
 	// owner of the token.
-	DeleteToken(userID model.UserKey, token string) error
+	DeleteToken(userID model.UserKey, tokenID string) error
 	// RetrieveTokenTags retrieves the tags for the token. The user must be
 	// the owner of the token.
-	RetrieveTokenTags(userID model.UserKey, token string) (model.Tags, error)
+	RetrieveTokenTags(userID model.UserKey, tokenID string) (model.Tags, error)
 	// UpdateTokenTags updates the tags on the token. The user must be owner
 	// of the token.
-	UpdateTokenTags(userID model.UserKey, token string, tags model.Tags) error
+	UpdateTokenTags(userID model.UserKey, tokenID string, tags model.Tags) error
+	// UpdateTokenUsage sets the time and the source IP address for when the
+	// token was last used.
+	UpdateTokenUsage(tokenID string, lastUsed time.Time, ip string) error
 
 	// Retrieve token retrieves a single token
-	RetrieveToken(token string) (model.Token, error)
+	RetrieveToken(tokenID string) (model.Token, error)
 
 	// DeviceNewID creates a new device ID. The returned keys are unique for
 	// every call to DeviceNewID.
@@ -67,6 +73,18 @@ type DataStore interface {
 	// be a member of the team owning the collection.
 	ListDevices(userID model.UserKey, collectionID model.CollectionKey) ([]model.Device, error)
 
+	// ListDevicesPage lists a single page of devices in a collection. The
+	// next page token is empty when there are no more pages.
+	ListDevicesPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Device, string, error)
+
+	// ImportDevices creates devices in a collection in a single transaction.
+	// Devices without an ID get a new ID. Existing devices with the same ID,
+	// IMSI or IMEI are updated with the new IMSI, IMEI and tags if upsert is
+	// set and the device is in the same collection. Nothing is imported if
+	// any of the devices fails. The check is called before the import is
+	// committed.
+	ImportDevices(userID model.UserKey, collectionID model.CollectionKey, devices []model.Device, upsert bool, check model.DeviceImportCheck) (model.DeviceImportResult, error)
+
 	// RetrieveDevice retrieves a single device. The user must be a member of
 	// the team that owns the device.
 	RetrieveDevice(userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey) (model.Device, error)
@@ -107,6 +125,9 @@ type DataStore interface {
 	// user must be a member of the team owning the collection for it to show up
 	// in the list.
 	ListCollections(userID model.UserKey) ([]model.Collection, error)
+	// ListCollectionsPage lists a single page of collections available to the
+	// user. The next page token is empty when there are no more pages.
+	ListCollectionsPage(userID model.UserKey, opts model.ListOptions) ([]model.Collection, string, error)
 	// CreateCollection creates a new collection. If the collection already exists
 	// it will return storage.ErrAlreadyExists.
 	CreateCollection(userID model.UserKey, collection model.Collection) error
@@ -141,6 +162,9 @@ type DataStore interface {
 	// collection can't be found (or if the user isn't a member of the team
 	// owning the collection) it will return storage.ErrNotFound.
 	ListOutputs(userID model.UserKey, collectionID model.CollectionKey) ([]model.Output, error)
+	// ListOutputsPage lists a single page of outputs for a collection. The
+	// next page token is empty when there are no more pages.
+	ListOutputsPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Output, string, error)
 
 	// CreateOutput creates a new output for the specified collection. The
 	// user must be an administrator in the team owning the collection. If the
@@ -224,6 +248,9 @@ type DataStore interface {
 	DeleteFirmware(userID model.UserKey, collectionID model.CollectionKey, fwID model.FirmwareKey) error
 	// ListFirmware lists all firmware images owned by teams the user is a member of
 	ListFirmware(userID model.UserKey, collectionID model.CollectionKey) ([]model.Firmware, error)
+	// ListFirmwarePage lists a single page of firmware images in a
+	// collection. The next page token is empty when there are no more pages.
+	ListFirmwarePage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Firmware, string, error)
 	// UpdateFirmware updates the firmware image. The only fields that can be updated are
 	// TeamID and tags.
 	UpdateFirmware(userID model.UserKey, collectionID model.CollectionKey, fw model.Firmware) error
@@ -253,5 +280,81 @@ type DataStore interface {
 	// UpdateFirmwareStateForDevice updates the firmware state field for a device
 	UpdateFirmwareStateForDevice(imsi int64, state model.DeviceFirmwareState, message string) error
 
+	// NewFirmwareHistoryID creates a new identifier for firmware history
+	// entries
+	NewFirmwareHistoryID() model.FirmwareHistoryKey
+	// CreateFirmwareHistory adds a state transition to the firmware history.
+	// This is used by the FOTA handlers and there's no access check.
+	CreateFirmwareHistory(entry model.FirmwareHistoryEntry) error
+	// RetrieveLatestFirmwareHistory returns the most recent firmware history
+	// entry for the device. If the device has no history storage.ErrNotFound
+	// is returned. There's no access check.
+	RetrieveLatestFirmwareHistory(deviceID model.DeviceKey) (model.FirmwareHistoryEntry, error)
+	// ListDeviceFirmwareHistory lists the firmware history for a device,
+	// newest first. If the before parameter is set only entries older than
+	// that entry are returned. If the entry doesn't exist storage.ErrNotFound
+	// is returned. The user must be a member of the team owning the collection.
+	ListDeviceFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error)
+	// ListFirmwareHistory lists the firmware history entries where the image
+	// is either the current or the target firmware. The paging works the
+	// same way as for ListDeviceFirmwareHistory.
+	ListFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error)
+	// RetrieveFirmwareStatistics returns the aggregated update results for
+	// the firmware image. The user must be a member of the team owning the
+	// collection.
+	RetrieveFirmwareStatistics(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey) (model.FirmwareStatistics, error)
+
+	// NewSigningKeyID creates a new identifier for firmware signing keys
+	NewSigningKeyID() model.SigningKeyKey
+	// CreateSigningKey creates a new signing key for a collection. The user
+	// must be an administrator of the team owning the collection.
+	CreateSigningKey(userID model.UserKey, key model.SigningKey) error
+	// RetrieveSigningKey retrieves a signing key. The user must be a member
+	// of the team owning the collection.
+	RetrieveSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) (model.SigningKey, error)
+	// ListSigningKeys lists the signing keys for a collection.
+	ListSigningKeys(userID model.UserKey, collectionID model.CollectionKey) ([]model.SigningKey, error)
+	// DeleteSigningKey removes a signing key. Images signed with the key
+	// keep their signatures. The user must be an administrator of the team
+	// owning the collection.
+	DeleteSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) error
+
+	// RetrieveTeamQuota returns the quota for the team. Teams without a quota
+	// get a zero (ie unlimited) quota.
+	RetrieveTeamQuota(teamID model.TeamKey) (model.Quota, error)
+	// UpdateTeamQuota sets the quota for the team. If the team doesn't exist
+	// storage.ErrNotFound is returned.
+	UpdateTeamQuota(teamID model.TeamKey, quota model.Quota) error
+	// RetrieveTeamUsage returns the resources used by the team. The message
+	// counts are for the day containing the time stamp.
+	RetrieveTeamUsage(teamID model.TeamKey, day time.Time) (model.QuotaUsage, error)
+	// AddMessageUsage counts a message for the team owning the collection. The
+	// message isn't counted and false is returned if the team's message budget
+	// for the day is exhausted.
+	AddMessageUsage(collectionID model.CollectionKey, direction model.MessageDirection, day time.Time) (bool, error)
+
+	// RetrieveCollectionTeam returns the team that owns the collection. The
+	// meter records the team with the usage so usage is kept for collections
+	// that are removed before it is written.
+	RetrieveCollectionTeam(collectionID model.CollectionKey) (model.TeamKey, error)
+	// AddUsage adds metered usage to the hourly usage records. The team is
+	// set in the usage records.
+	AddUsage(records []model.Usage) error
+	// RetrieveUsage returns the metered usage for a team in the time range
+	// [from, to). The records are sorted by hour.
+	RetrieveUsage(teamID model.TeamKey, from time.Time, to time.Time) ([]model.Usage, error)
+
+	// NewAuditEventID creates a new audit event ID
+	NewAuditEventID() model.AuditKey
+	// CreateAuditEvent adds an event to the audit trail
+	CreateAuditEvent(event model.AuditEvent) error
+	// ListAuditEvents lists the audit events for a team, newest first. If the
+	// before parameter is set only events older than that event are returned.
+	// If the event doesn't exist storage.ErrNotFound is returned.
+	ListAuditEvents(teamID model.TeamKey, before model.AuditKey, limit int) ([]model.AuditEvent, error)
+	// DeleteAuditEvents removes audit events older than the time stamp. The
+	// number of removed events is returned.
+	DeleteAuditEvents(olderThan time.Time) (int64, error)
+
 	SequenceStore
 }
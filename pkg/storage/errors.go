@@ -33,4 +33,7 @@ var (
 	// ErrReference is returned when there's an reference error deleting or
 	// modifying a resource
 	ErrReference = errors.New("entity is referenced elsewhere")
+	// ErrInvalidListOptions is returned when the page token or sort order
+	// for a list is invalid
+	ErrInvalidListOptions = errors.New("invalid list options")
 )
@@ -30,6 +30,14 @@ type FirmwareImageStore interface {
 	// Retrieve retrieves a firmware image from the backend store. The reader should
 	// be closed when the client has finished reading the data.
 	Retrieve(model.FirmwareKey) (io.ReadCloser, error)
-	// Delete removes the firmware image from the backend store.
+	// Delete removes the firmware image from the backend store. Deltas to and
+	// from the image are removed as well.
 	Delete(model.FirmwareKey) error
+	// CreateDelta persists a delta between two firmware images. The SHA256
+	// checksum of the delta is returned.
+	CreateDelta(from model.FirmwareKey, to model.FirmwareKey, data io.Reader) (string, error)
+	// RetrieveDelta retrieves a delta between two firmware images. ErrNotFound
+	// is returned if there's no delta stored. The reader should be closed when
+	// the client has finished reading the data.
+	RetrieveDelta(from model.FirmwareKey, to model.FirmwareKey) (io.ReadCloser, error)
 }
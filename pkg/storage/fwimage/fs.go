@@ -21,8 +21,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
@@ -31,7 +33,8 @@ import (
 // NewFileSystemStore returns an image store that stores firmware images
 // to the local file system. The path must be a writable directory.
 //
-// Images are stored as <fw id>.image inside the image directory.
+// Images are stored as <fw id>.image inside the image directory and deltas
+// are stored as <from fw id>-<to fw id>.delta.
 func NewFileSystemStore(path string) storage.FirmwareImageStore {
 	return &fsStore{imagePath: path}
 }
@@ -43,20 +46,48 @@ type fsStore struct {
 const bufSize = 100 * 1024
 
 func (f *fsStore) Create(fwID model.FirmwareKey, data io.Reader) (string, error) {
-	fileName := path.Join(f.imagePath, fmt.Sprintf("%s.image", fwID.String()))
+	return f.writeFile(path.Join(f.imagePath, fmt.Sprintf("%s.image", fwID.String())), data)
+}
+
+func (f *fsStore) deltaFileName(from, to model.FirmwareKey) string {
+	return path.Join(f.imagePath, fmt.Sprintf("%s-%s.delta", from.String(), to.String()))
+}
+
+func (f *fsStore) CreateDelta(from, to model.FirmwareKey, data io.Reader) (string, error) {
+	return f.writeFile(f.deltaFileName(from, to), data)
+}
+
+// writeFile writes the data to a temporary file in the image directory and
+// renames it when all of the data is written. Readers won't see partially
+// written images and the temporary file is removed if the write fails.
+func (f *fsStore) writeFile(fileName string, data io.Reader) (string, error) {
 	_, err := os.Stat(fileName)
 	if !os.IsNotExist(err) {
 		return "", errors.New("image already exists")
 	}
-	fh, err := os.Create(fileName)
+	fh, err := ioutil.TempFile(path.Dir(fileName), path.Base(fileName)+".*.tmp")
 	if err != nil {
 		return "", err
 	}
-	defer fh.Close()
+	checksum, err := copyWithChecksum(fh, data)
+	if closeErr := fh.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(fh.Name(), fileName)
+	}
+	if err != nil {
+		os.Remove(fh.Name())
+		return "", err
+	}
+	return checksum, nil
+}
 
+// copyWithChecksum copies the data to the file and returns the SHA256
+// checksum of the data.
+func copyWithChecksum(fh *os.File, data io.Reader) (string, error) {
 	buf := make([]byte, bufSize)
 	h := sha256.New()
-	written := 0
 	for {
 		n, err := data.Read(buf)
 		if err != nil && err != io.EOF {
@@ -69,15 +100,27 @@ func (f *fsStore) Create(fwID model.FirmwareKey, data io.Reader) (string, error)
 		if _, err := fh.Write(buf[:n]); err != nil {
 			return "", err
 		}
-		written += n
 	}
-
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 func (f *fsStore) Delete(fwID model.FirmwareKey) error {
 	fileName := path.Join(f.imagePath, fmt.Sprintf("%s.image", fwID.String()))
-	return os.Remove(fileName)
+	if err := os.Remove(fileName); err != nil {
+		return err
+	}
+	for _, pattern := range []string{"%s-*.delta", "*-%s.delta"} {
+		deltas, err := filepath.Glob(path.Join(f.imagePath, fmt.Sprintf(pattern, fwID.String())))
+		if err != nil {
+			return err
+		}
+		for _, v := range deltas {
+			if err := os.Remove(v); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func (f *fsStore) Retrieve(fwID model.FirmwareKey) (io.ReadCloser, error) {
@@ -88,3 +131,14 @@ func (f *fsStore) Retrieve(fwID model.FirmwareKey) (io.ReadCloser, error) {
 	}
 	return fh, nil
 }
+
+func (f *fsStore) RetrieveDelta(from, to model.FirmwareKey) (io.ReadCloser, error) {
+	fh, err := os.OpenFile(f.deltaFileName(from, to), os.O_RDONLY, 0)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, err
+	}
+	return fh, nil
+}
@@ -15,10 +15,61 @@ package fwimage
 //limitations under the License.
 //
 import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
+
+	"github.com/eesrc/horde/pkg/model"
 )
 
 func TestFileSystemStore(t *testing.T) {
 	fs := NewFileSystemStore(".")
 	testFirmwareStore(t, fs)
 }
+
+type failingReader struct {
+	r io.Reader
+}
+
+func (f *failingReader) Read(buf []byte) (int, error) {
+	n, err := f.r.Read(buf)
+	if err == io.EOF {
+		return 0, errors.New("read failed")
+	}
+	return n, err
+}
+
+func TestFileSystemStoreFailedWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fwimage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fs := NewFileSystemStore(dir)
+	from, to := model.FirmwareKey(1), model.FirmwareKey(2)
+	if _, err := fs.CreateDelta(from, to, &failingReader{strings.NewReader("partial delta")}); err == nil {
+		t.Fatal("Expected error when the data can't be read")
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("Expected no files after a failed write but got %d", len(files))
+	}
+	if _, err := fs.CreateDelta(from, to, strings.NewReader("delta")); err != nil {
+		t.Fatal(err)
+	}
+	r, err := fs.RetrieveDelta(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if buf, _ := ioutil.ReadAll(r); string(buf) != "delta" {
+		t.Fatalf("Unexpected delta: %s", buf)
+	}
+}
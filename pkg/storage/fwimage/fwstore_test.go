@@ -85,4 +85,35 @@ func testFirmwareStore(t *testing.T, s storage.FirmwareImageStore) {
 		t.Fatal("Buffers are different")
 	}
 
+	delta := make([]byte, 2048)
+	rand.Read(delta)
+	if _, err := s.RetrieveDelta(id1, id2); err != storage.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound for missing delta but got %v", err)
+	}
+	if _, err := s.CreateDelta(id1, id2, bytes.NewReader(delta)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RetrieveDelta(id2, id1); err != storage.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound for reversed delta but got %v", err)
+	}
+	rd3, err := s.RetrieveDelta(id1, id2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd3.Close()
+	buf, err = ioutil.ReadAll(rd3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(buf, delta) {
+		t.Fatal("Delta buffers are different")
+	}
+
+	// Deltas are removed with the images
+	if err := s.Delete(id2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RetrieveDelta(id1, id2); err != storage.ErrNotFound {
+		t.Fatalf("Expected delta to be removed with image but got %v", err)
+	}
 }
@@ -16,33 +16,171 @@ package fwimage
 //limitations under the License.
 //
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"hash"
 	"io"
+	"time"
 
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
+	"github.com/eesrc/horde/pkg/storage/fwimage/imagestore"
+	"github.com/eesrc/horde/pkg/utils/grpcutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// grpcTimeout is the timeout for uploads and deletes. Downloads are streamed
+// to the caller and runs until the reader is closed.
+const grpcTimeout = 60 * time.Second
+
 type grpcStore struct {
+	client imagestore.ImageStoreClient
 }
 
 // NewGRPCStore creates a new firmware image store that uses a gRPC service
 // to store images
-func NewGRPCStore() storage.FirmwareImageStore {
-	return &grpcStore{}
+func NewGRPCStore(config grpcutil.GRPCClientParam) (storage.FirmwareImageStore, error) {
+	conn, err := grpcutil.NewGRPCClientConnection(config)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcStore{client: imagestore.NewImageStoreClient(conn)}, nil
+}
+
+// fromStatus converts the gRPC status errors into the storage errors
+func fromStatus(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return storage.ErrNotFound
+	}
+	return err
+}
+
+func (g *grpcStore) Create(id model.FirmwareKey, data io.Reader) (string, error) {
+	return g.put(0, id, data)
+}
+
+func (g *grpcStore) CreateDelta(from, to model.FirmwareKey, data io.Reader) (string, error) {
+	return g.put(from, to, data)
+}
+
+// put streams an image to the server. Deltas are streamed with the base
+// firmware ID set.
+func (g *grpcStore) put(base, id model.FirmwareKey, data io.Reader) (string, error) {
+	ctx, done := context.WithTimeout(context.Background(), grpcTimeout)
+	defer done()
+
+	stream, err := g.client.PutImage(ctx)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := data.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			if err := stream.Send(&imagestore.ImageChunk{FirmwareId: int64(id), BaseFirmwareId: int64(base), Data: buf[:n]}); err != nil {
+				return "", err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	sha := hex.EncodeToString(h.Sum(nil))
+	if err := stream.Send(&imagestore.ImageChunk{FirmwareId: int64(id), BaseFirmwareId: int64(base), Sha256: sha}); err != nil {
+		return "", err
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", err
+	}
+	if res.Sha256 != sha {
+		return "", errors.New("checksum mismatch for stored image")
+	}
+	return sha, nil
 }
 
-func (g *grpcStore) Create(model.FirmwareKey, io.Reader) (string, error) {
-	sha := ""
-	err := errors.New("not implemented")
+// imageReader reads the image from the download stream. The checksum is
+// verified when the stream ends.
+type imageReader struct {
+	stream imagestore.ImageStore_GetImageClient
+	done   context.CancelFunc
+	buf    []byte
+	hash   hash.Hash
+	sha256 string
+}
+
+func (r *imageReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.sha256 != "" {
+			if hex.EncodeToString(r.hash.Sum(nil)) != r.sha256 {
+				return 0, errors.New("checksum mismatch for image")
+			}
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.hash.Write(r.buf[:n])
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// next receives the next chunk from the stream
+func (r *imageReader) next() error {
+	chunk, err := r.stream.Recv()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return fromStatus(err)
+	}
+	r.buf = chunk.Data
+	r.sha256 = chunk.Sha256
+	return nil
+}
+
+func (r *imageReader) Close() error {
+	r.done()
+	return nil
+}
+
+func (g *grpcStore) Retrieve(id model.FirmwareKey) (io.ReadCloser, error) {
+	return g.get(&imagestore.ImageRequest{FirmwareId: int64(id)})
+}
 
-	return sha, err
+func (g *grpcStore) RetrieveDelta(from, to model.FirmwareKey) (io.ReadCloser, error) {
+	return g.get(&imagestore.ImageRequest{FirmwareId: int64(to), BaseFirmwareId: int64(from)})
 }
 
-func (g *grpcStore) Retrieve(model.FirmwareKey) (io.ReadCloser, error) {
-	return nil, errors.New("not implemented")
+func (g *grpcStore) get(req *imagestore.ImageRequest) (io.ReadCloser, error) {
+	ctx, done := context.WithCancel(context.Background())
+	stream, err := g.client.GetImage(ctx, req)
+	if err != nil {
+		done()
+		return nil, err
+	}
+	ret := &imageReader{stream: stream, done: done, hash: sha256.New()}
+	// Read the first chunk to report missing images here rather than in Read
+	if err := ret.next(); err != nil {
+		done()
+		return nil, err
+	}
+	return ret, nil
 }
 
-func (g *grpcStore) Delete(model.FirmwareKey) error {
-	return errors.New("not implemented")
+func (g *grpcStore) Delete(id model.FirmwareKey) error {
+	ctx, done := context.WithTimeout(context.Background(), grpcTimeout)
+	defer done()
+	_, err := g.client.DeleteImage(ctx, &imagestore.ImageRequest{FirmwareId: int64(id)})
+	return fromStatus(err)
 }
@@ -0,0 +1,88 @@
+package fwimage
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"context"
+	"testing"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/eesrc/horde/pkg/storage/fwimage/imagestore"
+	"github.com/eesrc/horde/pkg/storage/sqlstore"
+	"github.com/eesrc/horde/pkg/utils/grpcutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestGRPCStore(t *testing.T, backend storage.FirmwareImageStore) storage.FirmwareImageStore {
+	ep, err := StartServer(NewImageServer(backend), grpcutil.GRPCServerParam{Endpoint: "localhost:0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewGRPCStore(grpcutil.GRPCClientParam{ServerEndpoint: ep})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestGRPCStore(t *testing.T) {
+	testFirmwareStore(t, newTestGRPCStore(t, NewFileSystemStore(".")))
+
+	backend, err := NewSQLStore(sqlstore.Parameters{Type: "sqlite3", ConnectionString: ":memory:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestGRPCStore(t, backend)
+	testFirmwareStore(t, s)
+
+	if err := s.Delete(model.FirmwareKey(300001)); err != storage.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound when removing unknown image but got %v", err)
+	}
+}
+
+func TestGRPCChecksumMismatch(t *testing.T) {
+	backend, err := NewSQLStore(sqlstore.Parameters{Type: "sqlite3", ConnectionString: ":memory:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ep, err := StartServer(NewImageServer(backend), grpcutil.GRPCServerParam{Endpoint: "localhost:0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpcutil.NewGRPCClientConnection(grpcutil.GRPCClientParam{ServerEndpoint: ep})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := imagestore.NewImageStoreClient(conn)
+	stream, err := client.PutImage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&imagestore.ImageChunk{FirmwareId: 1, Data: []byte("image data")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&imagestore.ImageChunk{FirmwareId: 1, Sha256: "0000"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.DataLoss {
+		t.Fatalf("Expected DataLoss error but got %v", err)
+	}
+	if _, err := backend.Retrieve(model.FirmwareKey(1)); err == nil {
+		t.Fatal("Image with checksum mismatch should be removed")
+	}
+}
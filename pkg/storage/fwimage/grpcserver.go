@@ -0,0 +1,186 @@
+package fwimage
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/ExploratoryEngineering/logging"
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/eesrc/horde/pkg/storage/fwimage/imagestore"
+	"github.com/eesrc/horde/pkg/utils/grpcutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chunkSize is the maximum size of the data in each chunk that is streamed
+const chunkSize = 64 * 1024
+
+type imageServer struct {
+	store storage.FirmwareImageStore
+}
+
+// NewImageServer creates a gRPC image store service that stores images in
+// another image store, usually the file system or SQL store.
+func NewImageServer(store storage.FirmwareImageStore) imagestore.ImageStoreServer {
+	return &imageServer{store: store}
+}
+
+// StartServer launches the gRPC service and returns the endpoint
+func StartServer(srv imagestore.ImageStoreServer, cfg grpcutil.GRPCServerParam) (string, error) {
+	server, err := grpcutil.NewGRPCServer(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	if err := server.Launch(func(s *grpc.Server) {
+		imagestore.RegisterImageStoreServer(s, srv)
+	}, 200*time.Millisecond); err != nil {
+		return "", err
+	}
+	return server.Endpoint(), nil
+}
+
+// toStatus converts errors from the image stores into gRPC status errors
+func toStatus(err error) error {
+	if err == storage.ErrNotFound || err == sql.ErrNoRows || os.IsNotExist(err) {
+		return status.Error(codes.NotFound, "Image not found")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// chunkReader reads the image data from the upload stream. The checksum
+// from the last chunk is kept when the stream ends.
+type chunkReader struct {
+	stream imagestore.ImageStore_PutImageServer
+	buf    []byte
+	sha256 string
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		chunk, err := c.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		c.buf = chunk.Data
+		if chunk.Sha256 != "" {
+			c.sha256 = chunk.Sha256
+		}
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (s *imageServer) PutImage(stream imagestore.ImageStore_PutImageServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if first.FirmwareId == 0 {
+		return status.Error(codes.InvalidArgument, "Missing firmware ID")
+	}
+	id := model.FirmwareKey(first.FirmwareId)
+	reader := &chunkReader{stream: stream, buf: first.Data, sha256: first.Sha256}
+	if first.BaseFirmwareId != 0 {
+		return s.putDelta(model.FirmwareKey(first.BaseFirmwareId), id, reader, stream)
+	}
+	sum, err := s.store.Create(id, reader)
+	if err != nil {
+		logging.Warning("Unable to store image %s: %v", id.String(), err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	if reader.sha256 != sum {
+		logging.Warning("Checksum mismatch for image %s (client: %s, stored: %s)", id.String(), reader.sha256, sum)
+		if err := s.store.Delete(id); err != nil {
+			logging.Warning("Unable to remove image %s with checksum mismatch: %v", id.String(), err)
+		}
+		return status.Error(codes.DataLoss, "Checksum mismatch")
+	}
+	return stream.SendAndClose(&imagestore.PutImageResponse{Sha256: sum})
+}
+
+// putDelta stores a delta. Deltas can't be removed separately so the checksum
+// is verified before the delta is stored. Deltas are small enough to be kept
+// in memory.
+func (s *imageServer) putDelta(from, to model.FirmwareKey, reader *chunkReader, stream imagestore.ImageStore_PutImageServer) error {
+	buf, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	h := sha256.Sum256(buf)
+	if sum := hex.EncodeToString(h[:]); sum != reader.sha256 {
+		logging.Warning("Checksum mismatch for delta %s-%s (client: %s, received: %s)", from.String(), to.String(), reader.sha256, sum)
+		return status.Error(codes.DataLoss, "Checksum mismatch")
+	}
+	sum, err := s.store.CreateDelta(from, to, bytes.NewReader(buf))
+	if err != nil {
+		logging.Warning("Unable to store delta %s-%s: %v", from.String(), to.String(), err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.SendAndClose(&imagestore.PutImageResponse{Sha256: sum})
+}
+
+func (s *imageServer) GetImage(req *imagestore.ImageRequest, stream imagestore.ImageStore_GetImageServer) error {
+	var rc io.ReadCloser
+	var err error
+	if req.BaseFirmwareId != 0 {
+		rc, err = s.store.RetrieveDelta(model.FirmwareKey(req.BaseFirmwareId), model.FirmwareKey(req.FirmwareId))
+	} else {
+		rc, err = s.store.Retrieve(model.FirmwareKey(req.FirmwareId))
+	}
+	if err != nil {
+		return toStatus(err)
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			if err := stream.Send(&imagestore.ImageChunk{FirmwareId: req.FirmwareId, BaseFirmwareId: req.BaseFirmwareId, Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return toStatus(err)
+		}
+	}
+	return stream.Send(&imagestore.ImageChunk{FirmwareId: req.FirmwareId, BaseFirmwareId: req.BaseFirmwareId, Sha256: hex.EncodeToString(h.Sum(nil))})
+}
+
+func (s *imageServer) DeleteImage(ctx context.Context, req *imagestore.ImageRequest) (*imagestore.DeleteImageResponse, error) {
+	if err := s.store.Delete(model.FirmwareKey(req.FirmwareId)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &imagestore.DeleteImageResponse{}, nil
+}
@@ -0,0 +1,3 @@
+package imagestore
+
+//go:generate protoc -I=../../../../protobuf --go_out=plugins=grpc:. ../../../../protobuf/fwimage.proto
@@ -35,10 +35,13 @@ import (
 const MaxImageSize = 2 * 1024 * 1024
 
 type sqlStore struct {
-	db           *sql.DB
-	createStmt   *sql.Stmt
-	retrieveStmt *sql.Stmt
-	deleteStmt   *sql.Stmt
+	db                *sql.DB
+	createStmt        *sql.Stmt
+	retrieveStmt      *sql.Stmt
+	deleteStmt        *sql.Stmt
+	createDeltaStmt   *sql.Stmt
+	retrieveDeltaStmt *sql.Stmt
+	deleteDeltaStmt   *sql.Stmt
 }
 
 // NewSQLStore creates a new firmware image store that stores images in a database
@@ -71,6 +74,16 @@ func (s *sqlStore) createSchema(params sqlstore.Parameters) error {
 
 		CONSTRAINT firmware_image_pk PRIMARY KEY (image_id)
 	);
+
+	CREATE TABLE IF NOT EXISTS firmware_delta (
+		from_image_id BIGINT NOT NULL,
+		to_image_id   BIGINT NOT NULL,
+		delta_data    BYTEA  NOT NULL,
+
+		CONSTRAINT firmware_delta_pk PRIMARY KEY (from_image_id, to_image_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS firmware_delta_to ON firmware_delta (to_image_id);
 	`
 	schema := sqlstore.NewSchema(params.Type, create)
 	for _, v := range schema.Statements() {
@@ -96,6 +109,22 @@ func (s *sqlStore) prepare() error {
 	}
 	if s.deleteStmt, err = s.db.Prepare(`
 	DELETE FROM firmware_image WHERE image_id = $1
+`); err != nil {
+		return err
+	}
+	if s.retrieveDeltaStmt, err = s.db.Prepare(`
+	SELECT delta_data FROM firmware_delta WHERE from_image_id = $1 AND to_image_id = $2
+`); err != nil {
+		return err
+	}
+	if s.createDeltaStmt, err = s.db.Prepare(`
+	INSERT INTO firmware_delta (from_image_id, to_image_id, delta_data)
+	VALUES ($1, $2, $3)
+`); err != nil {
+		return err
+	}
+	if s.deleteDeltaStmt, err = s.db.Prepare(`
+	DELETE FROM firmware_delta WHERE from_image_id = $1 OR to_image_id = $1
 `); err != nil {
 		return err
 	}
@@ -126,6 +155,10 @@ func (s *sqlStore) Retrieve(id model.FirmwareKey) (io.ReadCloser, error) {
 }
 
 func (s *sqlStore) Delete(id model.FirmwareKey) error {
+	if _, err := s.deleteDeltaStmt.Exec(id); err != nil {
+		logging.Warning("Error removing deltas for firmware: %v", err)
+		return err
+	}
 	res, err := s.deleteStmt.Exec(id)
 	if err != nil {
 		if strings.Contains(err.Error(), "constraint") {
@@ -139,3 +172,29 @@ func (s *sqlStore) Delete(id model.FirmwareKey) error {
 	}
 	return nil
 }
+
+func (s *sqlStore) CreateDelta(from, to model.FirmwareKey, reader io.Reader) (string, error) {
+	buf, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(buf)
+	sha := hex.EncodeToString(h.Sum(nil))
+	_, err = s.createDeltaStmt.Exec(from, to, buf)
+	if err != nil {
+		logging.Warning("Unable to store delta from %s to %s: %v", from, to, err)
+	}
+	return sha, err
+}
+
+func (s *sqlStore) RetrieveDelta(from, to model.FirmwareKey) (io.ReadCloser, error) {
+	var buf []byte
+	if err := s.retrieveDeltaStmt.QueryRow(from, to).Scan(&buf); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, storage.ErrNotFound
+		}
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(buf)), nil
+}
@@ -0,0 +1,64 @@
+package storage
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+)
+
+// OutputNode is a core instance running outputs. The node is alive until it
+// expires.
+type OutputNode struct {
+	NodeID   string
+	Endpoint string
+	Expires  time.Time
+}
+
+// OutputLease is the assignment of an output to a node. The node owns the
+// output until the lease expires.
+type OutputLease struct {
+	OutputID model.OutputKey
+	NodeID   string
+	Expires  time.Time
+}
+
+// LeaseStore keeps track of the nodes in a cluster and which node is running
+// the outputs. The store is shared between all of the nodes in the cluster.
+type LeaseStore interface {
+	// Heartbeat registers the node or extends the node's expiry time.
+	Heartbeat(node OutputNode) error
+
+	// RemoveNode removes the node from the cluster. Any leases held by the
+	// node are removed as well.
+	RemoveNode(nodeID string) error
+
+	// Nodes returns the nodes that haven't expired at the specified time.
+	Nodes(now time.Time) ([]OutputNode, error)
+
+	// AcquireLease acquires or renews the lease for an output. The lease
+	// is granted if there's no lease for the output, the lease has expired or
+	// the node already holds the lease. The return value is true if the lease
+	// is granted.
+	AcquireLease(outputID model.OutputKey, nodeID string, expires time.Time, now time.Time) (bool, error)
+
+	// ReleaseLease releases a lease held by the node.
+	ReleaseLease(outputID model.OutputKey, nodeID string) error
+
+	// Leases returns the leases that haven't expired at the specified time.
+	Leases(now time.Time) ([]OutputLease, error)
+}
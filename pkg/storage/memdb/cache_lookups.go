@@ -41,31 +41,31 @@ func (c *cacheLookups) EnsureAdminOfTeam(tx *sql.Tx, userID model.UserKey, teamI
 	return c.utils.EnsureAdminOfTeam(wtx, userID, teamID)
 }
 
-func (c *cacheLookups) EnsureAdminOfCollection(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey) (model.TeamKey, error) {
+func (c *cacheLookups) EnsureAdminOfCollection(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, p model.Permission) (model.TeamKey, error) {
 	wtx, err := c.db.Begin()
 	if err != nil {
 		return model.TeamKey(0), err
 	}
 	defer wtx.Commit()
-	return c.utils.EnsureAdminOfCollection(wtx, userID, collectionID)
+	return c.utils.EnsureAdminOfCollection(wtx, userID, collectionID, p)
 }
 
-func (c *cacheLookups) EnsureAdminOfDevice(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey) error {
+func (c *cacheLookups) EnsureAdminOfDevice(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey, p model.Permission) error {
 	wtx, err := c.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer wtx.Commit()
-	return c.utils.EnsureAdminOfDevice(wtx, userID, collectionID, deviceID)
+	return c.utils.EnsureAdminOfDevice(wtx, userID, collectionID, deviceID, p)
 }
 
-func (c *cacheLookups) EnsureAdminOfOutput(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, outputID model.OutputKey) error {
+func (c *cacheLookups) EnsureAdminOfOutput(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, outputID model.OutputKey, p model.Permission) error {
 	wtx, err := c.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer wtx.Commit()
-	return c.utils.EnsureAdminOfOutput(wtx, userID, collectionID, outputID)
+	return c.utils.EnsureAdminOfOutput(wtx, userID, collectionID, outputID, p)
 }
 
 func (c *cacheLookups) EnsureNotPrivateTeam(tx *sql.Tx, teamID model.TeamKey) error {
@@ -77,13 +77,13 @@ func (c *cacheLookups) EnsureNotPrivateTeam(tx *sql.Tx, teamID model.TeamKey) er
 	return c.utils.EnsureNotPrivateTeam(wtx, teamID)
 }
 
-func (c *cacheLookups) EnsureAdminOfFirmware(tx *sql.Tx, userID model.UserKey, fwID model.FirmwareKey) error {
+func (c *cacheLookups) EnsureAdminOfFirmware(tx *sql.Tx, userID model.UserKey, fwID model.FirmwareKey, p model.Permission) error {
 	wtx, err := c.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer wtx.Commit()
-	return c.utils.EnsureAdminOfFirmware(wtx, userID, fwID)
+	return c.utils.EnsureAdminOfFirmware(wtx, userID, fwID, p)
 }
 
 func (c *cacheLookups) EnsureCollectionFirmware(tx *sql.Tx, collectionID model.CollectionKey, fwID model.FirmwareKey) error {
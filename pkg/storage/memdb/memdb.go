@@ -2,6 +2,7 @@ package memdb
 
 import (
 	"net"
+	"time"
 
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
@@ -98,6 +99,16 @@ func (m *memoryDB) UpdateTokenTags(userID model.UserKey, token string, tags mode
 	return nil
 }
 
+func (m *memoryDB) UpdateTokenUsage(tokenID string, lastUsed time.Time, ip string) error {
+	if err := m.persistent.UpdateTokenUsage(tokenID, lastUsed, ip); err != nil {
+		return err
+	}
+	if err := m.inmem.UpdateTokenUsage(tokenID, lastUsed, ip); err != nil {
+		panic(err)
+	}
+	return nil
+}
+
 func (m *memoryDB) RetrieveToken(token string) (model.Token, error) {
 	return m.inmem.RetrieveToken(token)
 }
@@ -162,6 +173,10 @@ func (m *memoryDB) ListCollections(userID model.UserKey) ([]model.Collection, er
 	return m.inmem.ListCollections(userID)
 }
 
+func (m *memoryDB) ListCollectionsPage(userID model.UserKey, opts model.ListOptions) ([]model.Collection, string, error) {
+	return m.inmem.ListCollectionsPage(userID, opts)
+}
+
 func (m *memoryDB) CreateCollection(userID model.UserKey, collection model.Collection) error {
 	if err := m.persistent.CreateCollection(userID, collection); err != nil {
 		return err
@@ -228,6 +243,22 @@ func (m *memoryDB) ListDevices(userID model.UserKey, collectionID model.Collecti
 	return m.inmem.ListDevices(userID, collectionID)
 }
 
+func (m *memoryDB) ListDevicesPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Device, string, error) {
+	return m.inmem.ListDevicesPage(userID, collectionID, opts)
+}
+
+func (m *memoryDB) ImportDevices(userID model.UserKey, collectionID model.CollectionKey, devices []model.Device, upsert bool, check model.DeviceImportCheck) (model.DeviceImportResult, error) {
+	ret, err := m.persistent.ImportDevices(userID, collectionID, devices, upsert, check)
+	if err != nil || len(ret.Errors) > 0 {
+		return ret, err
+	}
+	imported := append(append([]model.Device{}, ret.Created...), ret.Updated...)
+	if _, err := m.inmem.ImportDevices(userID, collectionID, imported, true, nil); err != nil {
+		panic(err)
+	}
+	return ret, nil
+}
+
 func (m *memoryDB) RetrieveDevice(userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey) (model.Device, error) {
 	return m.inmem.RetrieveDevice(userID, collectionID, deviceID)
 }
@@ -316,6 +347,10 @@ func (m *memoryDB) ListFirmware(userID model.UserKey, collectionID model.Collect
 	return m.inmem.ListFirmware(userID, collectionID)
 }
 
+func (m *memoryDB) ListFirmwarePage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Firmware, string, error) {
+	return m.inmem.ListFirmwarePage(userID, collectionID, opts)
+}
+
 func (m *memoryDB) UpdateFirmware(userID model.UserKey, collectionID model.CollectionKey, fw model.Firmware) error {
 	if err := m.persistent.UpdateFirmware(userID, collectionID, fw); err != nil {
 		return err
@@ -366,6 +401,50 @@ func (m *memoryDB) UpdateFirmwareStateForDevice(imsi int64, state model.DeviceFi
 	return nil
 }
 
+func (m *memoryDB) NewFirmwareHistoryID() model.FirmwareHistoryKey {
+	return m.persistent.NewFirmwareHistoryID()
+}
+
+func (m *memoryDB) CreateFirmwareHistory(entry model.FirmwareHistoryEntry) error {
+	return m.persistent.CreateFirmwareHistory(entry)
+}
+
+func (m *memoryDB) RetrieveLatestFirmwareHistory(deviceID model.DeviceKey) (model.FirmwareHistoryEntry, error) {
+	return m.persistent.RetrieveLatestFirmwareHistory(deviceID)
+}
+
+func (m *memoryDB) ListDeviceFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error) {
+	return m.persistent.ListDeviceFirmwareHistory(userID, collectionID, deviceID, before, limit)
+}
+
+func (m *memoryDB) ListFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error) {
+	return m.persistent.ListFirmwareHistory(userID, collectionID, firmwareID, before, limit)
+}
+
+func (m *memoryDB) RetrieveFirmwareStatistics(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey) (model.FirmwareStatistics, error) {
+	return m.persistent.RetrieveFirmwareStatistics(userID, collectionID, firmwareID)
+}
+
+func (m *memoryDB) NewSigningKeyID() model.SigningKeyKey {
+	return m.persistent.NewSigningKeyID()
+}
+
+func (m *memoryDB) CreateSigningKey(userID model.UserKey, key model.SigningKey) error {
+	return m.persistent.CreateSigningKey(userID, key)
+}
+
+func (m *memoryDB) RetrieveSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) (model.SigningKey, error) {
+	return m.persistent.RetrieveSigningKey(userID, collectionID, keyID)
+}
+
+func (m *memoryDB) ListSigningKeys(userID model.UserKey, collectionID model.CollectionKey) ([]model.SigningKey, error) {
+	return m.persistent.ListSigningKeys(userID, collectionID)
+}
+
+func (m *memoryDB) DeleteSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) error {
+	return m.persistent.DeleteSigningKey(userID, collectionID, keyID)
+}
+
 func (m *memoryDB) NewOutputID() model.OutputKey {
 	return m.persistent.NewOutputID()
 }
@@ -374,6 +453,10 @@ func (m *memoryDB) ListOutputs(userID model.UserKey, collectionID model.Collecti
 	return m.inmem.ListOutputs(userID, collectionID)
 }
 
+func (m *memoryDB) ListOutputsPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Output, string, error) {
+	return m.inmem.ListOutputsPage(userID, collectionID, opts)
+}
+
 func (m *memoryDB) CreateOutput(userID model.UserKey, output model.Output) error {
 	if err := m.persistent.CreateOutput(userID, output); err != nil {
 		return err
@@ -601,3 +684,53 @@ func (m *memoryDB) LookupIMSIFromIP(ip net.IP, ranges model.NASRanges) (int64, e
 func (m *memoryDB) RetrieveNAS(apnID int, nasid int) (model.NAS, error) {
 	return m.inmemAPN.RetrieveNAS(apnID, nasid)
 }
+
+// The quotas and usage counters aren't primed in the in-memory store. The
+// message counters are updated for every message so they're kept in the
+// persistent store only.
+
+func (m *memoryDB) RetrieveTeamQuota(teamID model.TeamKey) (model.Quota, error) {
+	return m.persistent.RetrieveTeamQuota(teamID)
+}
+
+func (m *memoryDB) UpdateTeamQuota(teamID model.TeamKey, quota model.Quota) error {
+	return m.persistent.UpdateTeamQuota(teamID, quota)
+}
+
+func (m *memoryDB) RetrieveTeamUsage(teamID model.TeamKey, day time.Time) (model.QuotaUsage, error) {
+	return m.persistent.RetrieveTeamUsage(teamID, day)
+}
+
+func (m *memoryDB) AddMessageUsage(collectionID model.CollectionKey, direction model.MessageDirection, day time.Time) (bool, error) {
+	return m.persistent.AddMessageUsage(collectionID, direction, day)
+}
+
+// Metered usage is only used for reporting and is kept in the persistent store.
+
+func (m *memoryDB) RetrieveCollectionTeam(collectionID model.CollectionKey) (model.TeamKey, error) {
+	return m.inmem.RetrieveCollectionTeam(collectionID)
+}
+
+func (m *memoryDB) AddUsage(records []model.Usage) error {
+	return m.persistent.AddUsage(records)
+}
+
+func (m *memoryDB) RetrieveUsage(teamID model.TeamKey, from time.Time, to time.Time) ([]model.Usage, error) {
+	return m.persistent.RetrieveUsage(teamID, from, to)
+}
+
+func (m *memoryDB) NewAuditEventID() model.AuditKey {
+	return m.persistent.NewAuditEventID()
+}
+
+func (m *memoryDB) CreateAuditEvent(event model.AuditEvent) error {
+	return m.persistent.CreateAuditEvent(event)
+}
+
+func (m *memoryDB) ListAuditEvents(teamID model.TeamKey, before model.AuditKey, limit int) ([]model.AuditEvent, error) {
+	return m.persistent.ListAuditEvents(teamID, before, limit)
+}
+
+func (m *memoryDB) DeleteAuditEvents(olderThan time.Time) (int64, error) {
+	return m.persistent.DeleteAuditEvents(olderThan)
+}
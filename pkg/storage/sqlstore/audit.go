@@ -0,0 +1,122 @@
+package sqlstore
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"database/sql"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+type auditStatements struct {
+	insert       *sql.Stmt
+	list         *sql.Stmt
+	listBefore   *sql.Stmt
+	retrieveTime *sql.Stmt
+	deleteOlder  *sql.Stmt
+}
+
+const auditEventFields = `audit_id, time, team_id, source, user_id, token_id, auth_method,
+	action, resource_type, resource_id, before_state, after_state`
+
+func (s *sqlStore) initAuditStatements() error {
+	var err error
+	if s.auditStatements.insert, err = s.db.Prepare(`
+		INSERT INTO audit_event (` + auditEventFields + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`); err != nil {
+		return err
+	}
+	if s.auditStatements.list, err = s.db.Prepare(`
+		SELECT ` + auditEventFields + `
+			FROM audit_event
+			WHERE team_id = $1
+			ORDER BY time DESC, audit_id DESC
+			LIMIT $2`); err != nil {
+		return err
+	}
+	if s.auditStatements.listBefore, err = s.db.Prepare(`
+		SELECT ` + auditEventFields + `
+			FROM audit_event
+			WHERE team_id = $1 AND (time < $2 OR (time = $3 AND audit_id < $4))
+			ORDER BY time DESC, audit_id DESC
+			LIMIT $5`); err != nil {
+		return err
+	}
+	if s.auditStatements.retrieveTime, err = s.db.Prepare(`
+		SELECT time FROM audit_event WHERE team_id = $1 AND audit_id = $2`); err != nil {
+		return err
+	}
+	if s.auditStatements.deleteOlder, err = s.db.Prepare(`
+		DELETE FROM audit_event WHERE time < $1`); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *sqlStore) NewAuditEventID() model.AuditKey {
+	return model.AuditKey(s.auditKeyGen.NewID())
+}
+
+func (s *sqlStore) CreateAuditEvent(event model.AuditEvent) error {
+	_, err := s.auditStatements.insert.Exec(event.ID, event.Time.UnixNano(), event.TeamID,
+		event.Source, event.UserID, event.TokenID, event.AuthMethod, event.Action,
+		event.ResourceType, event.ResourceID, event.Before, event.After)
+	return err
+}
+
+func (s *sqlStore) ListAuditEvents(teamID model.TeamKey, before model.AuditKey, limit int) ([]model.AuditEvent, error) {
+	var rows *sql.Rows
+	var err error
+	if before == 0 {
+		rows, err = s.auditStatements.list.Query(teamID, limit)
+	} else {
+		var t int64
+		if err := s.auditStatements.retrieveTime.QueryRow(teamID, before).Scan(&t); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, storage.ErrNotFound
+			}
+			return nil, err
+		}
+		rows, err = s.auditStatements.listBefore.Query(teamID, t, t, before, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]model.AuditEvent, 0)
+	for rows.Next() {
+		var t int64
+		e := model.AuditEvent{}
+		if err := rows.Scan(&e.ID, &t, &e.TeamID, &e.Source, &e.UserID, &e.TokenID, &e.AuthMethod,
+			&e.Action, &e.ResourceType, &e.ResourceID, &e.Before, &e.After); err != nil {
+			return nil, err
+		}
+		e.Time = time.Unix(0, t)
+		ret = append(ret, e)
+	}
+	return ret, rows.Err()
+}
+
+func (s *sqlStore) DeleteAuditEvents(olderThan time.Time) (int64, error) {
+	res, err := s.auditStatements.deleteOlder.Exec(olderThan.UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -40,19 +40,19 @@ type collectionStatements struct {
 func (s *sqlStore) initCollectionStratements() error {
 	var err error
 	if s.collectionStatements.list, err = s.db.Prepare(`
-		SELECT c.collection_id, c.team_id, c.tags, c.field_mask, c.fw_current_version, c.fw_target_version, c.fw_management
+		SELECT c.collection_id, c.team_id, c.tags, c.field_mask, c.fw_current_version, c.fw_target_version, c.fw_management, c.fw_require_signature, c.fw_maintenance
 			FROM collection c, member m
 			WHERE c.team_id = m.team_id AND
 				m.user_id = $1`); err != nil {
 		return err
 	}
 	if s.collectionStatements.create, err = s.db.Prepare(`
-		INSERT INTO collection (collection_id, team_id, tags, field_mask, fw_current_version, fw_target_version, fw_management)
-			VALUES ($1, $2, $3, $4, $5, $6, $7)`); err != nil {
+		INSERT INTO collection (collection_id, team_id, tags, field_mask, fw_current_version, fw_target_version, fw_management, fw_require_signature, fw_maintenance)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`); err != nil {
 		return err
 	}
 	if s.collectionStatements.retrieve, err = s.db.Prepare(`
-		SELECT c.collection_id, c.team_id, c.tags, c.field_mask, c.fw_current_version, c.fw_target_version, c.fw_management
+		SELECT c.collection_id, c.team_id, c.tags, c.field_mask, c.fw_current_version, c.fw_target_version, c.fw_management, c.fw_require_signature, c.fw_maintenance
 			FROM collection c, member m
 			WHERE c.collection_id = $1 AND
 				c.team_id = m.team_id AND
@@ -66,8 +66,10 @@ func (s *sqlStore) initCollectionStratements() error {
 				field_mask = $3,
 				fw_current_version = $4,
 				fw_target_version = $5,
-				fw_management = $6
-			WHERE collection_id = $7`); err != nil {
+				fw_management = $6,
+				fw_require_signature = $7,
+				fw_maintenance = $8
+			WHERE collection_id = $9`); err != nil {
 		return err
 	}
 	if s.collectionStatements.delete, err = s.db.Prepare(`
@@ -93,7 +95,7 @@ func (s *sqlStore) initCollectionStratements() error {
 					INNER JOIN member m ON c.team_id = m.team_id
 					WHERE c.collection_id = $2 AND
 						m.user_id = $3 AND
-						m.role_id = 1)
+						m.role_id IN (` + rolesWithPermission(model.ManageCollectionsPermission) + `))
 		`); err != nil {
 		return err
 	}
@@ -135,7 +137,7 @@ func (s *sqlStore) ListCollections(userID model.UserKey) ([]model.Collection, er
 		if err := rows.Scan(
 			&coll.ID, &coll.TeamID, &coll.TagMap, &coll.FieldMask,
 			&current, &target,
-			&coll.Firmware.Management); err != nil {
+			&coll.Firmware.Management, &coll.Firmware.RequireSignature, &coll.Firmware.MaintenanceWindow); err != nil {
 			return nil, err
 		}
 		s.setFirmwareValues(current, target, &coll)
@@ -165,7 +167,8 @@ func (s *sqlStore) CreateCollection(userID model.UserKey, collection model.Colle
 	current, target := s.getFirmwareValues(&collection)
 	if _, err = tx.Stmt(s.collectionStatements.create).Exec(
 		collection.ID, collection.TeamID, collection.TagMap, collection.FieldMask,
-		current, target, collection.Firmware.Management); err != nil {
+		current, target, collection.Firmware.Management, collection.Firmware.RequireSignature,
+		collection.Firmware.MaintenanceWindow); err != nil {
 		tx.Rollback()
 		if strings.Contains(err.Error(), "constraint") {
 			return storage.ErrAlreadyExists
@@ -177,11 +180,16 @@ func (s *sqlStore) CreateCollection(userID model.UserKey, collection model.Colle
 }
 
 func (s *sqlStore) RetrieveCollection(userID model.UserKey, collectionID model.CollectionKey) (model.Collection, error) {
+	return s.readCollection(s.collectionStatements.retrieve.QueryRow(collectionID, userID))
+}
+
+func (s *sqlStore) readCollection(row rowScanner) (model.Collection, error) {
 	ret := model.Collection{}
 	var current, target sql.NullInt64
-	if err := s.collectionStatements.retrieve.QueryRow(collectionID, userID).Scan(
+	if err := row.Scan(
 		&ret.ID, &ret.TeamID, &ret.TagMap, &ret.FieldMask,
-		&current, &target, &ret.Firmware.Management); err != nil {
+		&current, &target, &ret.Firmware.Management, &ret.Firmware.RequireSignature,
+		&ret.Firmware.MaintenanceWindow); err != nil {
 		if err == sql.ErrNoRows {
 			return model.Collection{}, storage.ErrNotFound
 		}
@@ -192,19 +200,26 @@ func (s *sqlStore) RetrieveCollection(userID model.UserKey, collectionID model.C
 }
 
 func (s *sqlStore) UpdateCollection(userID model.UserKey, collection model.Collection) error {
-	// User must be an admin of current team -- ie retrieve current team ID. The result is clunky
+	// User must have the permissions for the changes in the current team and be
+	// an admin of the new team if the collection is moved. The result is clunky
 	// since we must do multiple queries for a team update
 	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
-	// User must be an admin of old team
-	currentTeamID, err := s.utils.EnsureAdminOfCollection(tx, userID, collection.ID)
+	// The permissions required depend on the fields that are changed
+	existing, err := s.readCollection(tx.Stmt(s.collectionStatements.retrieve).QueryRow(collection.ID, userID))
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	if currentTeamID != collection.TeamID {
+	for _, p := range collectionUpdatePermissions(existing, collection) {
+		if _, err := s.utils.EnsureAdminOfCollection(tx, userID, collection.ID, p); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if existing.TeamID != collection.TeamID {
 		// ...and of new team
 		if err := s.utils.EnsureAdminOfTeam(tx, userID, collection.TeamID); err != nil {
 			tx.Rollback()
@@ -224,7 +239,7 @@ func (s *sqlStore) UpdateCollection(userID model.UserKey, collection model.Colle
 	_, err = tx.Stmt(s.collectionStatements.update).Exec(
 		collection.TeamID, collection.TagMap, collection.FieldMask,
 		current, target, collection.Firmware.Management,
-		collection.ID)
+		collection.Firmware.RequireSignature, collection.Firmware.MaintenanceWindow, collection.ID)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -238,7 +253,15 @@ func (s *sqlStore) DeleteCollection(userID model.UserKey, collectionID model.Col
 	if err != nil {
 		return err
 	}
-	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID); err != nil {
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID, model.ManageCollectionsPermission); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Stmt(s.signingKeyStatements.deleteCollection).Exec(collectionID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Stmt(s.fwHistoryStatements.deleteCollection).Exec(collectionID); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -287,7 +310,7 @@ func (s *sqlStore) UpdateCollectionTags(userID model.UserKey, collectionID strin
 		tx.Rollback()
 		return err
 	} else if count == 0 {
-		_, err := s.utils.EnsureAdminOfCollection(tx, userID, k)
+		_, err := s.utils.EnsureAdminOfCollection(tx, userID, k, model.ManageCollectionsPermission)
 		tx.Rollback()
 		return err
 	}
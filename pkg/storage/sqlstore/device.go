@@ -43,6 +43,8 @@ type deviceStatements struct {
 	retrieveByMSISDN     *sql.Stmt
 	allocUpdate          *sql.Stmt
 	fwStateUpdate        *sql.Stmt
+	importLookup         *sql.Stmt
+	importUpdate         *sql.Stmt
 }
 
 func (s *sqlStore) initDeviceStatements() error {
@@ -66,7 +68,8 @@ func (s *sqlStore) initDeviceStatements() error {
 				fw_manufacturer,
 				fw_version,
 				fw_state,
-				fw_state_message)
+				fw_state_message,
+				fw_maintenance)
 		VALUES ($1,
 				$2,
 				$3,
@@ -84,7 +87,8 @@ func (s *sqlStore) initDeviceStatements() error {
 				$15,
 				$16,
 				$17,
-				$18)
+				$18,
+				$19)
 			`); err != nil {
 		return err
 	}
@@ -107,7 +111,8 @@ func (s *sqlStore) initDeviceStatements() error {
 			d.fw_manufacturer,
 			d.fw_version,
 			d.fw_state,
-			d.fw_state_message
+			d.fw_state_message,
+			d.fw_maintenance
 		FROM
 			device d, collection c, member m
 		WHERE
@@ -118,6 +123,48 @@ func (s *sqlStore) initDeviceStatements() error {
 		`); err != nil {
 		return err
 	}
+	if s.deviceStatements.importLookup, err = s.db.Prepare(`
+		SELECT
+			d.device_id,
+			d.imsi,
+			d.imei,
+			d.collection_id,
+			d.tags,
+			d.net_apn_id,
+			d.net_nas_id,
+			d.net_allocated_ip,
+			d.net_allocated_at,
+			d.net_cell_id,
+			d.fw_current_version,
+			d.fw_target_version,
+			d.fw_serial_number,
+			d.fw_model_number,
+			d.fw_manufacturer,
+			d.fw_version,
+			d.fw_state,
+			d.fw_state_message,
+			d.fw_maintenance
+		FROM
+			device d
+		WHERE
+			d.device_id = $1 OR
+			d.imsi = $2 OR
+			d.imei = $3
+		`); err != nil {
+		return err
+	}
+	if s.deviceStatements.importUpdate, err = s.db.Prepare(`
+		UPDATE
+			device
+		SET
+			imsi = $1,
+			imei = $2,
+			tags = $3
+		WHERE
+			device_id = $4
+		`); err != nil {
+		return err
+	}
 	// TODO: Might be better to check membership separately
 	if s.deviceStatements.memberCheck, err = s.db.Prepare(`
 		SELECT
@@ -150,7 +197,8 @@ func (s *sqlStore) initDeviceStatements() error {
 			d.fw_manufacturer,
 			d.fw_version,
 			d.fw_state,
-			d.fw_state_message
+			d.fw_state_message,
+			d.fw_maintenance
 		FROM
 			device d, collection c, member m
 		WHERE
@@ -190,9 +238,10 @@ func (s *sqlStore) initDeviceStatements() error {
 			fw_manufacturer = $14,
 			fw_version = $15,
 			fw_state = $16,
-			fw_state_message = $17
+			fw_state_message = $17,
+			fw_maintenance = $18
 		WHERE
-			device_id = $18
+			device_id = $19
 		`); err != nil {
 		return err
 	}
@@ -225,7 +274,7 @@ func (s *sqlStore) initDeviceStatements() error {
 					d.collection_id = c.collection_id AND
 					c.team_id = m.team_id AND
 					user_id = $3 AND
-					role_id = 1
+					role_id IN (` + rolesWithPermission(model.ManageDevicesPermission) + `)
 			)
 		`); err != nil {
 		return err
@@ -259,7 +308,8 @@ func (s *sqlStore) initDeviceStatements() error {
 			d.fw_manufacturer,
 			d.fw_version,
 			d.fw_state,
-			d.fw_state_message
+			d.fw_state_message,
+			d.fw_maintenance
 		FROM
 			device d
 		WHERE
@@ -287,7 +337,8 @@ func (s *sqlStore) initDeviceStatements() error {
 			d.fw_manufacturer,
 			d.fw_version,
 			d.fw_state,
-			d.fw_state_message
+			d.fw_state_message,
+			d.fw_maintenance
 		FROM
 			device d, device_lookup l
 		WHERE
@@ -313,9 +364,10 @@ func (s *sqlStore) initDeviceStatements() error {
 			fw_manufacturer = $11,
 			fw_version = $12,
 			fw_state = $13,
-			fw_state_message = $14
+			fw_state_message = $14,
+			fw_maintenance = $15
 		WHERE
-			device_id = $15
+			device_id = $16
 		`); err != nil {
 		return err
 	}
@@ -340,11 +392,21 @@ func (s *sqlStore) CreateDevice(userID model.UserKey, newDevice model.Device) er
 		return err
 	}
 
-	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, newDevice.CollectionID); err != nil {
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, newDevice.CollectionID, model.ManageDevicesPermission); err != nil {
 		tx.Rollback()
 		return err
 	}
 
+	if err := s.insertDevice(tx, newDevice); err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// insertDevice inserts a new device in the transaction
+func (s *sqlStore) insertDevice(tx *sql.Tx, newDevice model.Device) error {
 	var curVer, tarVer, ci sql.NullInt64
 	var ip, sn, mn, mf, fv sql.NullString
 	var aa pq.NullTime
@@ -384,21 +446,120 @@ func (s *sqlStore) CreateDevice(userID model.UserKey, newDevice model.Device) er
 		aa.Time = newDevice.Network.AllocatedAt
 		aa.Valid = true
 	}
-	_, err = tx.Stmt(s.deviceStatements.create).Exec(
+	_, err := tx.Stmt(s.deviceStatements.create).Exec(
 		newDevice.ID, newDevice.IMSI, newDevice.IMEI,
 		newDevice.CollectionID, newDevice.TagMap, newDevice.Network.ApnID, newDevice.Network.NasID,
-		ip, aa, ci, curVer, tarVer, sn, mn, mf, fv, string(newDevice.Firmware.State), newDevice.Firmware.StateMessage)
+		ip, aa, ci, curVer, tarVer, sn, mn, mf, fv, string(newDevice.Firmware.State), newDevice.Firmware.StateMessage,
+		newDevice.Firmware.MaintenanceWindow)
 	if err != nil {
-		tx.Rollback()
 		if strings.Contains(err.Error(), "constraint") {
 			return storage.ErrAlreadyExists
 		}
 		return err
 	}
-	tx.Commit()
 	return nil
 }
 
+func (s *sqlStore) ImportDevices(userID model.UserKey, collectionID model.CollectionKey, devices []model.Device, upsert bool, check model.DeviceImportCheck) (model.DeviceImportResult, error) {
+	ret := model.DeviceImportResult{}
+	// The IDs are allocated up front since the key generator might need the
+	// database. Generated IDs aren't used for updates.
+	newIDs := make([]model.DeviceKey, len(devices))
+	for i := range devices {
+		if devices[i].ID == 0 {
+			newIDs[i] = s.NewDeviceID()
+		}
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return ret, err
+	}
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID, model.ManageDevicesPermission); err != nil {
+		tx.Rollback()
+		return ret, err
+	}
+
+	var created, updated []model.Device
+	lookup := tx.Stmt(s.deviceStatements.importLookup)
+	update := tx.Stmt(s.deviceStatements.importUpdate)
+	for i, device := range devices {
+		device.CollectionID = collectionID
+		existing, err := s.lookupImportDevice(lookup, device)
+		if err != nil {
+			tx.Rollback()
+			return ret, err
+		}
+		message := ""
+		switch {
+		case len(existing) == 0:
+			if device.ID == 0 {
+				device.ID = newIDs[i]
+			}
+			if err := s.insertDevice(tx, device); err != nil {
+				tx.Rollback()
+				return ret, err
+			}
+			created = append(created, device)
+			continue
+		case !upsert:
+			message = "A device with the same ID, IMSI or IMEI already exists"
+		case len(existing) > 1:
+			message = "The ID, IMSI and IMEI belong to different devices"
+		case existing[0].CollectionID != collectionID:
+			message = "The device exists in another collection"
+		case device.ID != 0 && device.ID != existing[0].ID:
+			message = "The IMSI or IMEI is used by another device"
+		default:
+			d := existing[0]
+			d.IMSI = device.IMSI
+			d.IMEI = device.IMEI
+			d.TagMap = device.TagMap
+			if _, err := update.Exec(d.IMSI, d.IMEI, d.TagMap, d.ID); err != nil {
+				tx.Rollback()
+				return ret, err
+			}
+			updated = append(updated, d)
+			continue
+		}
+		ret.Errors = append(ret.Errors, model.DeviceImportError{Index: i, Message: message})
+	}
+	if len(ret.Errors) > 0 {
+		tx.Rollback()
+		return ret, nil
+	}
+	if check != nil {
+		if err := check(len(created)); err != nil {
+			tx.Rollback()
+			return ret, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return ret, err
+	}
+	ret.Created = created
+	ret.Updated = updated
+	return ret, nil
+}
+
+// lookupImportDevice returns the devices with the same ID, IMSI or IMEI as
+// the imported device.
+func (s *sqlStore) lookupImportDevice(lookup *sql.Stmt, device model.Device) ([]model.Device, error) {
+	rows, err := lookup.Query(device.ID, device.IMSI, device.IMEI)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []model.Device
+	for rows.Next() {
+		d, err := s.readDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, d)
+	}
+	return ret, rows.Err()
+}
+
 func (s *sqlStore) readDevice(row rowScanner) (model.Device, error) {
 	var ret model.Device
 	var apnID, nasID, curVer, tarVer, ci sql.NullInt64
@@ -408,7 +569,7 @@ func (s *sqlStore) readDevice(row rowScanner) (model.Device, error) {
 	if err := row.Scan(
 		&ret.ID, &ret.IMSI, &ret.IMEI, &ret.CollectionID, &ret.TagMap,
 		&apnID, &nasID, &ip, &aa, &ci, &curVer, &tarVer, &sn, &mn, &mf, &fv,
-		&stateStr, &ret.Firmware.StateMessage); err != nil {
+		&stateStr, &ret.Firmware.StateMessage, &ret.Firmware.MaintenanceWindow); err != nil {
 		if err == sql.ErrNoRows {
 			return ret, storage.ErrNotFound
 		}
@@ -489,7 +650,7 @@ func (s *sqlStore) DeleteDevice(userID model.UserKey, collectionID model.Collect
 		return err
 	}
 
-	if err := s.utils.EnsureAdminOfDevice(tx, userID, collectionID, deviceID); err != nil {
+	if err := s.utils.EnsureAdminOfDevice(tx, userID, collectionID, deviceID, model.ManageDevicesPermission); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -510,12 +671,20 @@ func (s *sqlStore) UpdateDevice(userID model.UserKey, collectionID model.Collect
 	if err != nil {
 		return err
 	}
-	if err := s.utils.EnsureAdminOfDevice(tx, userID, collectionID, device.ID); err != nil {
+	// The permissions required depend on the fields that are changed
+	existing, err := s.readDevice(tx.Stmt(s.deviceStatements.retrieve).QueryRow(userID, device.ID, collectionID))
+	if err != nil {
 		tx.Rollback()
 		return err
 	}
+	for _, p := range deviceUpdatePermissions(existing, device) {
+		if err := s.utils.EnsureAdminOfDevice(tx, userID, collectionID, device.ID, p); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
 	if device.CollectionID != collectionID {
-		if _, err := s.utils.EnsureAdminOfCollection(tx, userID, device.CollectionID); err != nil {
+		if _, err := s.utils.EnsureAdminOfCollection(tx, userID, device.CollectionID, model.ManageDevicesPermission); err != nil {
 			tx.Rollback()
 			return err
 		}
@@ -566,7 +735,8 @@ func (s *sqlStore) UpdateDevice(userID model.UserKey, collectionID model.Collect
 		device.IMSI, device.IMEI, device.CollectionID, device.TagMap,
 		device.Network.ApnID, device.Network.NasID, ip, aa,
 		ci, curVer, tarVer, sn, mn, mf, fv,
-		string(device.Firmware.State), device.Firmware.StateMessage, device.ID); err != nil {
+		string(device.Firmware.State), device.Firmware.StateMessage,
+		device.Firmware.MaintenanceWindow, device.ID); err != nil {
 		tx.Rollback()
 		if strings.Contains(err.Error(), "constraint") {
 			return storage.ErrAlreadyExists
@@ -620,7 +790,7 @@ func (s *sqlStore) UpdateDeviceTags(userID model.UserKey, deviceID string, tags
 			}
 			return err
 		}
-		_, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID)
+		_, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID, model.ManageDevicesPermission)
 		tx.Rollback()
 		return err
 	}
@@ -680,7 +850,8 @@ func (s *sqlStore) UpdateDeviceMetadata(device model.Device) error {
 	res, err := s.deviceStatements.allocUpdate.Exec(
 		device.TagMap, device.Network.ApnID, device.Network.NasID, ip,
 		aa, ci, curVer, tarVer, sn, mn, mf, fv,
-		string(device.Firmware.State), device.Firmware.StateMessage, device.ID)
+		string(device.Firmware.State), device.Firmware.StateMessage,
+		device.Firmware.MaintenanceWindow, device.ID)
 	if err != nil {
 		return err
 	}
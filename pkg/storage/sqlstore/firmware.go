@@ -42,8 +42,9 @@ func (s *sqlStore) initFirmwareStatements() error {
 	var err error
 
 	if s.firmwareStatements.insert, err = s.db.Prepare(`
-		INSERT INTO firmware (firmware_id, filename, version, length, sha256, created, collection_id, tags)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO firmware (firmware_id, filename, version, length, sha256, created, collection_id, tags, signature, signing_key_id,
+			compat_manufacturer, compat_model_pattern, compat_min_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`); err != nil {
 		return err
 	}
@@ -51,7 +52,8 @@ func (s *sqlStore) initFirmwareStatements() error {
 	if s.firmwareStatements.retrieve, err = s.db.Prepare(`
 		SELECT
 			fw.firmware_id, fw.filename, fw.version, fw.length, fw.sha256,
-			fw.created, fw.collection_id, fw.tags
+			fw.created, fw.collection_id, fw.tags, fw.signature, fw.signing_key_id,
+			fw.compat_manufacturer, fw.compat_model_pattern, fw.compat_min_version
 		FROM
 			firmware fw,
 			collection c,
@@ -78,7 +80,8 @@ func (s *sqlStore) initFirmwareStatements() error {
 	if s.firmwareStatements.list, err = s.db.Prepare(`
 		SELECT
 			fw.firmware_id, fw.filename, fw.version, fw.length, fw.sha256,
-			fw.created, fw.collection_id, fw.tags
+			fw.created, fw.collection_id, fw.tags, fw.signature, fw.signing_key_id,
+			fw.compat_manufacturer, fw.compat_model_pattern, fw.compat_min_version
 		FROM
 			firmware fw, collection c, member m
 		WHERE fw.collection_id = c.collection_id AND
@@ -94,8 +97,11 @@ func (s *sqlStore) initFirmwareStatements() error {
 		UPDATE firmware
 			SET version = $1,
 				collection_id = $2,
-				tags = $3
-			WHERE firmware_id = $4
+				tags = $3,
+				compat_manufacturer = $4,
+				compat_model_pattern = $5,
+				compat_min_version = $6
+			WHERE firmware_id = $7
 	`); err != nil {
 		return err
 	}
@@ -119,14 +125,15 @@ func (s *sqlStore) initFirmwareStatements() error {
 				WHERE fw.firmware_id  = $2 AND
 					fw.collection_id = c.collection_id AND
 					c.team_id = m.team_id AND
-					m.user_id =  $3 AND m.role_id = 1)
+					m.user_id =  $3 AND m.role_id IN (` + rolesWithPermission(model.ManageFirmwarePermission) + `))
 	`); err != nil {
 		return err
 	}
 	if s.firmwareStatements.retrieveTwo, err = s.db.Prepare(`
 		SELECT
 			fw.firmware_id, fw.filename, fw.version, fw.length, fw.sha256,
-			fw.created, fw.collection_id, fw.tags
+			fw.created, fw.collection_id, fw.tags, fw.signature, fw.signing_key_id,
+			fw.compat_manufacturer, fw.compat_model_pattern, fw.compat_min_version
 		FROM
 			firmware fw, collection c
 		WHERE fw.collection_id = c.collection_id AND
@@ -141,7 +148,10 @@ func (s *sqlStore) initFirmwareStatements() error {
 			c.fw_target_version AS c_target,
 			c.fw_management,
 			d.fw_current_version AS d_current,
-			d.fw_target_version AS d_target
+			d.fw_target_version AS d_target,
+			c.fw_maintenance AS c_maintenance,
+			d.fw_maintenance AS d_maintenance,
+			c.fw_require_signature
 		FROM
 			device d, collection c
 		WHERE
@@ -166,7 +176,8 @@ func (s *sqlStore) initFirmwareStatements() error {
 	if s.firmwareStatements.retrieveByVersion, err = s.db.Prepare(`
 		SELECT
 			fw.firmware_id, fw.filename, fw.version, fw.length, fw.sha256,
-			fw.created, fw.collection_id, fw.tags
+			fw.created, fw.collection_id, fw.tags, fw.signature, fw.signing_key_id,
+			fw.compat_manufacturer, fw.compat_model_pattern, fw.compat_min_version
 		FROM
 			firmware fw
 		WHERE
@@ -187,11 +198,12 @@ func (s *sqlStore) CreateFirmware(userID model.UserKey, fw model.Firmware) error
 	if err != nil {
 		return err
 	}
-	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, fw.CollectionID); err != nil {
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, fw.CollectionID, model.ManageFirmwarePermission); err != nil {
 		tx.Rollback()
 		return err
 	}
-	_, err = tx.Stmt(s.firmwareStatements.insert).Exec(fw.ID, fw.Filename, fw.Version, fw.Length, fw.SHA256, fw.Created, fw.CollectionID, fw.TagMap)
+	_, err = tx.Stmt(s.firmwareStatements.insert).Exec(fw.ID, fw.Filename, fw.Version, fw.Length, fw.SHA256, fw.Created, fw.CollectionID, fw.TagMap, fw.Signature, fw.SigningKeyID,
+		fw.Compatibility.Manufacturer, fw.Compatibility.ModelPattern, fw.Compatibility.MinimumVersion)
 	if err != nil {
 		tx.Rollback()
 		if strings.Contains(err.Error(), "constraint") {
@@ -212,7 +224,8 @@ func (s *sqlStore) RetrieveFirmware(userID model.UserKey, collectionID model.Col
 	var ret model.Firmware
 	if err := s.firmwareStatements.retrieve.QueryRow(userID, collectionID, fwID).Scan(
 		&ret.ID, &ret.Filename, &ret.Version, &ret.Length, &ret.SHA256, &ret.Created,
-		&ret.CollectionID, &ret.TagMap); err != nil {
+		&ret.CollectionID, &ret.TagMap, &ret.Signature, &ret.SigningKeyID,
+		&ret.Compatibility.Manufacturer, &ret.Compatibility.ModelPattern, &ret.Compatibility.MinimumVersion); err != nil {
 		if err == sql.ErrNoRows {
 			return model.Firmware{}, storage.ErrNotFound
 		}
@@ -226,7 +239,7 @@ func (s *sqlStore) DeleteFirmware(userID model.UserKey, collectionID model.Colle
 	if err != nil {
 		return err
 	}
-	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID); err != nil {
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID, model.ManageFirmwarePermission); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -257,7 +270,8 @@ func (s *sqlStore) ListFirmware(userID model.UserKey, collectionID model.Collect
 
 	for rows.Next() {
 		var fw model.Firmware
-		if err := rows.Scan(&fw.ID, &fw.Filename, &fw.Version, &fw.Length, &fw.SHA256, &fw.Created, &fw.CollectionID, &fw.TagMap); err != nil {
+		if err := rows.Scan(&fw.ID, &fw.Filename, &fw.Version, &fw.Length, &fw.SHA256, &fw.Created, &fw.CollectionID, &fw.TagMap, &fw.Signature, &fw.SigningKeyID,
+			&fw.Compatibility.Manufacturer, &fw.Compatibility.ModelPattern, &fw.Compatibility.MinimumVersion); err != nil {
 			if err == sql.ErrNoRows {
 				return nil, storage.ErrNotFound
 			}
@@ -273,16 +287,17 @@ func (s *sqlStore) UpdateFirmware(userID model.UserKey, collectionID model.Colle
 	if err != nil {
 		return err
 	}
-	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID); err != nil {
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID, model.ManageFirmwarePermission); err != nil {
 		tx.Rollback()
 		return err
 	}
-	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, fw.CollectionID); err != nil {
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, fw.CollectionID, model.ManageFirmwarePermission); err != nil {
 		tx.Rollback()
 		return err
 	}
 	if _, err := tx.Stmt(s.firmwareStatements.update).Exec(
-		fw.Version, fw.CollectionID, fw.TagMap, fw.ID); err != nil {
+		fw.Version, fw.CollectionID, fw.TagMap, fw.Compatibility.Manufacturer,
+		fw.Compatibility.ModelPattern, fw.Compatibility.MinimumVersion, fw.ID); err != nil {
 		tx.Rollback()
 		// We can get constraint errors if the same version is set for more than
 		// one image at a time.
@@ -331,7 +346,7 @@ func (s *sqlStore) UpdateFirmwareTags(userID model.UserKey, firmwareID string, t
 		tx.Rollback()
 		return err
 	} else if count == 0 {
-		err := s.utils.EnsureAdminOfFirmware(tx, userID, k)
+		err := s.utils.EnsureAdminOfFirmware(tx, userID, k, model.ManageFirmwarePermission)
 		tx.Rollback()
 		return err
 	}
@@ -356,7 +371,8 @@ func (s *sqlStore) RetrieveCurrentAndTargetFirmware(collectionID model.Collectio
 	defer rows.Close()
 	for rows.Next() {
 		var fw model.Firmware
-		if err := rows.Scan(&fw.ID, &fw.Filename, &fw.Version, &fw.Length, &fw.SHA256, &fw.Created, &fw.CollectionID, &fw.TagMap); err != nil {
+		if err := rows.Scan(&fw.ID, &fw.Filename, &fw.Version, &fw.Length, &fw.SHA256, &fw.Created, &fw.CollectionID, &fw.TagMap, &fw.Signature, &fw.SigningKeyID,
+			&fw.Compatibility.Manufacturer, &fw.Compatibility.ModelPattern, &fw.Compatibility.MinimumVersion); err != nil {
 			if err == sql.ErrNoRows {
 				return fwA, fwB, storage.ErrNotFound
 			}
@@ -381,7 +397,8 @@ func (s *sqlStore) RetrieveFirmwareConfig(collectionID model.CollectionKey, devi
 	cfg := model.FirmwareConfig{}
 	var ccFW, ctFW, dcFW, dtFW sql.NullInt64
 	if err := s.firmwareStatements.retrieveConfig.QueryRow(collectionID, deviceID).Scan(
-		&ccFW, &ctFW, &cfg.Management, &dcFW, &dtFW); err != nil {
+		&ccFW, &ctFW, &cfg.Management, &dcFW, &dtFW,
+		&cfg.CollectionMaintenance, &cfg.DeviceMaintenance, &cfg.RequireSignature); err != nil {
 		if err == sql.ErrNoRows {
 			return cfg, storage.ErrNotFound
 		}
@@ -437,7 +454,8 @@ func (s *sqlStore) RetrieveFirmwareByVersion(collectionID model.CollectionKey, v
 	var ret model.Firmware
 	if err := s.firmwareStatements.retrieveByVersion.QueryRow(collectionID, strings.TrimSpace(version)).Scan(
 		&ret.ID, &ret.Filename, &ret.Version, &ret.Length, &ret.SHA256, &ret.Created,
-		&ret.CollectionID, &ret.TagMap); err != nil {
+		&ret.CollectionID, &ret.TagMap, &ret.Signature, &ret.SigningKeyID,
+		&ret.Compatibility.Manufacturer, &ret.Compatibility.ModelPattern, &ret.Compatibility.MinimumVersion); err != nil {
 		if err == sql.ErrNoRows {
 			return model.Firmware{}, storage.ErrNotFound
 		}
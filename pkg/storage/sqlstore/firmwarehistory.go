@@ -0,0 +1,226 @@
+package sqlstore
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"database/sql"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+type firmwareHistoryStatements struct {
+	insert             *sql.Stmt
+	latest             *sql.Stmt
+	listDevice         *sql.Stmt
+	listDeviceBefore   *sql.Stmt
+	listFirmware       *sql.Stmt
+	listFirmwareBefore *sql.Stmt
+	retrieveTime       *sql.Stmt
+	statistics         *sql.Stmt
+	deleteCollection   *sql.Stmt
+}
+
+const firmwareHistoryFields = `h.history_id, h.time, h.collection_id, h.device_id, h.from_firmware_id,
+	h.to_firmware_id, h.previous_state, h.state, h.message, h.duration`
+
+// The user must be a member of the team owning the collection to see the
+// history.
+const firmwareHistoryAccess = `
+	FROM firmware_history h, collection c, member m
+	WHERE h.collection_id = c.collection_id AND
+		c.team_id = m.team_id AND
+		m.user_id = $1 AND
+		c.collection_id = $2`
+
+func (s *sqlStore) initFirmwareHistoryStatements() error {
+	var err error
+	if s.fwHistoryStatements.insert, err = s.db.Prepare(`
+		INSERT INTO firmware_history (history_id, time, collection_id, device_id, from_firmware_id,
+			to_firmware_id, previous_state, state, message, duration)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`); err != nil {
+		return err
+	}
+	if s.fwHistoryStatements.latest, err = s.db.Prepare(`
+		SELECT ` + firmwareHistoryFields + `
+			FROM firmware_history h
+			WHERE h.device_id = $1
+			ORDER BY h.time DESC, h.history_id DESC
+			LIMIT 1`); err != nil {
+		return err
+	}
+	if s.fwHistoryStatements.listDevice, err = s.db.Prepare(`
+		SELECT ` + firmwareHistoryFields + firmwareHistoryAccess + ` AND
+			h.device_id = $3
+			ORDER BY h.time DESC, h.history_id DESC
+			LIMIT $4`); err != nil {
+		return err
+	}
+	if s.fwHistoryStatements.listDeviceBefore, err = s.db.Prepare(`
+		SELECT ` + firmwareHistoryFields + firmwareHistoryAccess + ` AND
+			h.device_id = $3 AND
+			(h.time < $4 OR (h.time = $5 AND h.history_id < $6))
+			ORDER BY h.time DESC, h.history_id DESC
+			LIMIT $7`); err != nil {
+		return err
+	}
+	if s.fwHistoryStatements.listFirmware, err = s.db.Prepare(`
+		SELECT ` + firmwareHistoryFields + firmwareHistoryAccess + ` AND
+			(h.from_firmware_id = $3 OR h.to_firmware_id = $4)
+			ORDER BY h.time DESC, h.history_id DESC
+			LIMIT $5`); err != nil {
+		return err
+	}
+	if s.fwHistoryStatements.listFirmwareBefore, err = s.db.Prepare(`
+		SELECT ` + firmwareHistoryFields + firmwareHistoryAccess + ` AND
+			(h.from_firmware_id = $3 OR h.to_firmware_id = $4) AND
+			(h.time < $5 OR (h.time = $6 AND h.history_id < $7))
+			ORDER BY h.time DESC, h.history_id DESC
+			LIMIT $8`); err != nil {
+		return err
+	}
+	if s.fwHistoryStatements.retrieveTime, err = s.db.Prepare(`
+		SELECT time FROM firmware_history WHERE collection_id = $1 AND history_id = $2`); err != nil {
+		return err
+	}
+	if s.fwHistoryStatements.statistics, err = s.db.Prepare(`
+		SELECT h.previous_state, h.state, COUNT(*)` + firmwareHistoryAccess + ` AND
+			h.to_firmware_id = $3
+			GROUP BY h.previous_state, h.state`); err != nil {
+		return err
+	}
+	if s.fwHistoryStatements.deleteCollection, err = s.db.Prepare(`
+		DELETE FROM firmware_history WHERE collection_id = $1`); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *sqlStore) NewFirmwareHistoryID() model.FirmwareHistoryKey {
+	return model.FirmwareHistoryKey(s.fwHistoryKeyGen.NewID())
+}
+
+func (s *sqlStore) CreateFirmwareHistory(entry model.FirmwareHistoryEntry) error {
+	_, err := s.fwHistoryStatements.insert.Exec(entry.ID, entry.Time.UnixNano(), entry.CollectionID,
+		entry.DeviceID, entry.FromFirmwareID, entry.ToFirmwareID, string(entry.PreviousState),
+		string(entry.State), entry.Message, int64(entry.Duration))
+	return err
+}
+
+func (s *sqlStore) RetrieveLatestFirmwareHistory(deviceID model.DeviceKey) (model.FirmwareHistoryEntry, error) {
+	rows, err := s.fwHistoryStatements.latest.Query(deviceID)
+	if err != nil {
+		return model.FirmwareHistoryEntry{}, err
+	}
+	list, err := s.readFirmwareHistory(rows)
+	if err != nil {
+		return model.FirmwareHistoryEntry{}, err
+	}
+	if len(list) == 0 {
+		return model.FirmwareHistoryEntry{}, storage.ErrNotFound
+	}
+	return list[0], nil
+}
+
+// retrieveHistoryTime returns the time stamp for the history entry used when
+// paging through the history.
+func (s *sqlStore) retrieveHistoryTime(collectionID model.CollectionKey, before model.FirmwareHistoryKey) (int64, error) {
+	var t int64
+	if err := s.fwHistoryStatements.retrieveTime.QueryRow(collectionID, before).Scan(&t); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, storage.ErrNotFound
+		}
+		return 0, err
+	}
+	return t, nil
+}
+
+func (s *sqlStore) ListDeviceFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error) {
+	var rows *sql.Rows
+	var err error
+	if before == 0 {
+		rows, err = s.fwHistoryStatements.listDevice.Query(userID, collectionID, deviceID, limit)
+	} else {
+		var t int64
+		if t, err = s.retrieveHistoryTime(collectionID, before); err != nil {
+			return nil, err
+		}
+		rows, err = s.fwHistoryStatements.listDeviceBefore.Query(userID, collectionID, deviceID, t, t, before, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.readFirmwareHistory(rows)
+}
+
+func (s *sqlStore) ListFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error) {
+	var rows *sql.Rows
+	var err error
+	if before == 0 {
+		rows, err = s.fwHistoryStatements.listFirmware.Query(userID, collectionID, firmwareID, firmwareID, limit)
+	} else {
+		var t int64
+		if t, err = s.retrieveHistoryTime(collectionID, before); err != nil {
+			return nil, err
+		}
+		rows, err = s.fwHistoryStatements.listFirmwareBefore.Query(userID, collectionID, firmwareID, firmwareID, t, t, before, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.readFirmwareHistory(rows)
+}
+
+func (s *sqlStore) readFirmwareHistory(rows *sql.Rows) ([]model.FirmwareHistoryEntry, error) {
+	defer rows.Close()
+
+	ret := make([]model.FirmwareHistoryEntry, 0)
+	for rows.Next() {
+		var t, duration int64
+		var previousState, state string
+		e := model.FirmwareHistoryEntry{}
+		if err := rows.Scan(&e.ID, &t, &e.CollectionID, &e.DeviceID, &e.FromFirmwareID, &e.ToFirmwareID,
+			&previousState, &state, &e.Message, &duration); err != nil {
+			return nil, err
+		}
+		e.Time = time.Unix(0, t)
+		e.PreviousState = model.DeviceFirmwareState(previousState[0])
+		e.State = model.DeviceFirmwareState(state[0])
+		e.Duration = time.Duration(duration)
+		ret = append(ret, e)
+	}
+	return ret, rows.Err()
+}
+
+func (s *sqlStore) RetrieveFirmwareStatistics(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey) (model.FirmwareStatistics, error) {
+	rows, err := s.fwHistoryStatements.statistics.Query(userID, collectionID, firmwareID)
+	if err != nil {
+		return model.FirmwareStatistics{}, err
+	}
+	defer rows.Close()
+
+	ret := model.FirmwareStatistics{}
+	for rows.Next() {
+		var previousState, state string
+		var count int
+		if err := rows.Scan(&previousState, &state, &count); err != nil {
+			return model.FirmwareStatistics{}, err
+		}
+		ret.Add(model.DeviceFirmwareState(previousState[0]), model.DeviceFirmwareState(state[0]), count)
+	}
+	return ret, rows.Err()
+}
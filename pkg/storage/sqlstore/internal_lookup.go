@@ -21,24 +21,26 @@ type InternalLookups interface {
 	// EnsureAdminOfTeam ensures that the user is an admin member of the team
 	EnsureAdminOfTeam(tx *sql.Tx, userID model.UserKey, teamID model.TeamKey) error
 
-	// EnsureAdminOfCollection ensures that the user is an admin of the team
-	// that owns the collection
-	EnsureAdminOfCollection(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey) (model.TeamKey, error)
+	// EnsureAdminOfCollection ensures that the user's role in the team that
+	// owns the collection grants the permission. The permissions for the
+	// roles are defined in the model package. This applies to the device,
+	// output and firmware checks as well.
+	EnsureAdminOfCollection(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, p model.Permission) (model.TeamKey, error)
 
-	// EnsureAdminOfDevice ensures that the user is an admin of the team that
-	// owns the collection the device is a part of.
-	EnsureAdminOfDevice(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey) error
+	// EnsureAdminOfDevice ensures that the user's role in the team that owns
+	// the collection the device is a part of grants the permission.
+	EnsureAdminOfDevice(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey, p model.Permission) error
 
-	// EnsureAdminOfOutput ensures that the user is an admin of the team that
-	// owns the collection that contains the output.
-	EnsureAdminOfOutput(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, outputID model.OutputKey) error
+	// EnsureAdminOfOutput ensures that the user's role in the team that owns
+	// the collection that contains the output grants the permission.
+	EnsureAdminOfOutput(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, outputID model.OutputKey, p model.Permission) error
 
 	// EnsureNotPrivateTeam ensures that the team is not a private team
 	EnsureNotPrivateTeam(tx *sql.Tx, teamID model.TeamKey) error
 
 	// EsnureAdminOfFirmware ensures that the user is a member of a team that
 	// owns the collection that the firmware is a part of.
-	EnsureAdminOfFirmware(tx *sql.Tx, userID model.UserKey, fwID model.FirmwareKey) error
+	EnsureAdminOfFirmware(tx *sql.Tx, userID model.UserKey, fwID model.FirmwareKey, p model.Permission) error
 
 	// EnsureCollectionFirmware ensures that the collection contains the firmware
 	EnsureCollectionFirmware(tx *sql.Tx, collectionID model.CollectionKey, fwID model.FirmwareKey) error
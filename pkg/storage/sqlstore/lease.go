@@ -0,0 +1,226 @@
+package sqlstore
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"database/sql"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+const leaseStoreSchema = `
+		CREATE TABLE IF NOT EXISTS output_node (
+			node_id  VARCHAR(128) NOT NULL,
+			endpoint VARCHAR(128) NOT NULL,
+			expires  BIGINT       NOT NULL,
+			CONSTRAINT output_node_pk PRIMARY KEY (node_id));
+		CREATE TABLE IF NOT EXISTS output_lease (
+			output_id BIGINT       NOT NULL,
+			node_id   VARCHAR(128) NOT NULL,
+			expires   BIGINT       NOT NULL,
+			CONSTRAINT output_lease_pk PRIMARY KEY (output_id));
+		CREATE INDEX IF NOT EXISTS output_lease_node ON output_lease(node_id);
+	`
+
+// NewLeaseStore returns a new lease store for outputs. The SQL parameters
+// are typically the same as for the regular backend store.
+func NewLeaseStore(params Parameters) (storage.LeaseStore, error) {
+	ret := &leaseStore{}
+	var err error
+	ret.db, err = sql.Open(params.Type, params.ConnectionString)
+	if err != nil {
+		return nil, err
+	}
+	if params.CreateSchema {
+		schema := NewSchema(params.Type, leaseStoreSchema)
+		if err := schema.Create(ret.db); err != nil {
+			return nil, err
+		}
+	}
+	if err := ret.prepareStatements(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+type leaseStore struct {
+	db           *sql.DB
+	updateNode   *sql.Stmt
+	createNode   *sql.Stmt
+	deleteNode   *sql.Stmt
+	deleteLeases *sql.Stmt
+	listNodes    *sql.Stmt
+	expireLease  *sql.Stmt
+	renewLease   *sql.Stmt
+	createLease  *sql.Stmt
+	releaseLease *sql.Stmt
+	listLeases   *sql.Stmt
+}
+
+func (l *leaseStore) prepareStatements() error {
+	var err error
+	if l.updateNode, err = l.db.Prepare(`
+		UPDATE output_node SET endpoint = $1, expires = $2 WHERE node_id = $3`); err != nil {
+		return err
+	}
+	if l.createNode, err = l.db.Prepare(`
+		INSERT INTO output_node (node_id, endpoint, expires) VALUES ($1, $2, $3)`); err != nil {
+		return err
+	}
+	if l.deleteNode, err = l.db.Prepare(`
+		DELETE FROM output_node WHERE node_id = $1`); err != nil {
+		return err
+	}
+	if l.deleteLeases, err = l.db.Prepare(`
+		DELETE FROM output_lease WHERE node_id = $1`); err != nil {
+		return err
+	}
+	if l.listNodes, err = l.db.Prepare(`
+		SELECT node_id, endpoint, expires FROM output_node WHERE expires > $1 ORDER BY node_id`); err != nil {
+		return err
+	}
+	if l.expireLease, err = l.db.Prepare(`
+		DELETE FROM output_lease WHERE output_id = $1 AND expires <= $2`); err != nil {
+		return err
+	}
+	if l.renewLease, err = l.db.Prepare(`
+		UPDATE output_lease SET expires = $1 WHERE output_id = $2 AND node_id = $3`); err != nil {
+		return err
+	}
+	if l.createLease, err = l.db.Prepare(`
+		INSERT INTO output_lease (output_id, node_id, expires) VALUES ($1, $2, $3)`); err != nil {
+		return err
+	}
+	if l.releaseLease, err = l.db.Prepare(`
+		DELETE FROM output_lease WHERE output_id = $1 AND node_id = $2`); err != nil {
+		return err
+	}
+	if l.listLeases, err = l.db.Prepare(`
+		SELECT output_id, node_id, expires FROM output_lease WHERE expires > $1 ORDER BY output_id`); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (l *leaseStore) Heartbeat(node storage.OutputNode) error {
+	res, err := l.updateNode.Exec(node.Endpoint, node.Expires.UnixNano(), node.NodeID)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err == nil && count > 0 {
+		return nil
+	}
+	_, err = l.createNode.Exec(node.NodeID, node.Endpoint, node.Expires.UnixNano())
+	return err
+}
+
+func (l *leaseStore) RemoveNode(nodeID string) error {
+	if _, err := l.deleteLeases.Exec(nodeID); err != nil {
+		return err
+	}
+	res, err := l.deleteNode.Exec(nodeID)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err == nil && count == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (l *leaseStore) Nodes(now time.Time) ([]storage.OutputNode, error) {
+	rows, err := l.listNodes.Query(now.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []storage.OutputNode
+	for rows.Next() {
+		var expires int64
+		node := storage.OutputNode{}
+		if err := rows.Scan(&node.NodeID, &node.Endpoint, &expires); err != nil {
+			return nil, err
+		}
+		node.Expires = time.Unix(0, expires)
+		ret = append(ret, node)
+	}
+	return ret, nil
+}
+
+// AcquireLease removes the lease if it has expired, then tries to renew the
+// lease. If there's no lease to renew a new one is inserted. The primary key
+// ensures that only one node gets the lease if two nodes race for it.
+func (l *leaseStore) AcquireLease(outputID model.OutputKey, nodeID string, expires time.Time, now time.Time) (bool, error) {
+	if _, err := l.expireLease.Exec(int64(outputID), now.UnixNano()); err != nil {
+		return false, err
+	}
+	res, err := l.renewLease.Exec(expires.UnixNano(), int64(outputID), nodeID)
+	if err != nil {
+		return false, err
+	}
+	if count, err := res.RowsAffected(); err == nil && count > 0 {
+		return true, nil
+	}
+	if _, err := l.createLease.Exec(int64(outputID), nodeID, expires.UnixNano()); err != nil {
+		if l.leaseHeld(outputID) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// leaseHeld returns true if there's a lease for the output
+func (l *leaseStore) leaseHeld(outputID model.OutputKey) bool {
+	var count int
+	if err := l.db.QueryRow(`SELECT COUNT(*) FROM output_lease WHERE output_id = $1`, int64(outputID)).Scan(&count); err != nil {
+		return false
+	}
+	return count > 0
+}
+
+func (l *leaseStore) ReleaseLease(outputID model.OutputKey, nodeID string) error {
+	res, err := l.releaseLease.Exec(int64(outputID), nodeID)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err == nil && count == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (l *leaseStore) Leases(now time.Time) ([]storage.OutputLease, error) {
+	rows, err := l.listLeases.Query(now.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []storage.OutputLease
+	for rows.Next() {
+		var outputID, expires int64
+		lease := storage.OutputLease{}
+		if err := rows.Scan(&outputID, &lease.NodeID, &expires); err != nil {
+			return nil, err
+		}
+		lease.OutputID = model.OutputKey(outputID)
+		lease.Expires = time.Unix(0, expires)
+		ret = append(ret, lease)
+	}
+	return ret, nil
+}
@@ -0,0 +1,92 @@
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaseStore(t *testing.T) {
+	assert := require.New(t)
+
+	params := Parameters{
+		ConnectionString: ":memory:",
+		Type:             "sqlite3",
+		CreateSchema:     true,
+	}
+
+	store, err := NewLeaseStore(params)
+	assert.NoError(err)
+	assert.NotNil(store)
+
+	now := time.Now()
+	expires := now.Add(10 * time.Second)
+
+	// Register two nodes and let one of them expire
+	assert.NoError(store.Heartbeat(storage.OutputNode{NodeID: "a", Endpoint: "a:1", Expires: expires}))
+	assert.NoError(store.Heartbeat(storage.OutputNode{NodeID: "b", Endpoint: "b:1", Expires: now.Add(-time.Second)}))
+	nodes, err := store.Nodes(now)
+	assert.NoError(err)
+	assert.Len(nodes, 1)
+	assert.Equal("a", nodes[0].NodeID)
+	assert.Equal(expires.UnixNano(), nodes[0].Expires.UnixNano())
+
+	// Heartbeats extend the expiry time
+	assert.NoError(store.Heartbeat(storage.OutputNode{NodeID: "b", Endpoint: "b:2", Expires: expires}))
+	nodes, err = store.Nodes(now)
+	assert.NoError(err)
+	assert.Len(nodes, 2)
+	assert.Equal("b:2", nodes[1].Endpoint)
+
+	// The first node gets the lease, the second node is refused
+	output := model.OutputKey(1)
+	ok, err := store.AcquireLease(output, "a", expires, now)
+	assert.NoError(err)
+	assert.True(ok)
+	ok, err = store.AcquireLease(output, "b", expires, now)
+	assert.NoError(err)
+	assert.False(ok)
+
+	// Renewals are granted to the owner
+	ok, err = store.AcquireLease(output, "a", expires.Add(time.Second), now)
+	assert.NoError(err)
+	assert.True(ok)
+
+	leases, err := store.Leases(now)
+	assert.NoError(err)
+	assert.Len(leases, 1)
+	assert.Equal(output, leases[0].OutputID)
+	assert.Equal("a", leases[0].NodeID)
+
+	// Expired leases can be taken over by other nodes
+	later := expires.Add(2 * time.Second)
+	leases, err = store.Leases(later)
+	assert.NoError(err)
+	assert.Len(leases, 0)
+	ok, err = store.AcquireLease(output, "b", later.Add(10*time.Second), later)
+	assert.NoError(err)
+	assert.True(ok)
+	ok, err = store.AcquireLease(output, "a", later.Add(10*time.Second), later)
+	assert.NoError(err)
+	assert.False(ok)
+
+	// Only the owner can release the lease
+	assert.Equal(storage.ErrNotFound, store.ReleaseLease(output, "a"))
+	assert.NoError(store.ReleaseLease(output, "b"))
+	ok, err = store.AcquireLease(output, "a", later.Add(10*time.Second), later)
+	assert.NoError(err)
+	assert.True(ok)
+
+	// Removing the node removes its leases
+	assert.NoError(store.RemoveNode("a"))
+	assert.Equal(storage.ErrNotFound, store.RemoveNode("a"))
+	leases, err = store.Leases(later)
+	assert.NoError(err)
+	assert.Len(leases, 0)
+	nodes, err = store.Nodes(now)
+	assert.NoError(err)
+	assert.Len(nodes, 1)
+}
@@ -0,0 +1,127 @@
+package sqlstore
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"database/sql"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+type meteringStatements struct {
+	update         *sql.Stmt
+	insert         *sql.Stmt
+	retrieve       *sql.Stmt
+	collectionTeam *sql.Stmt
+}
+
+func (s *sqlStore) initMeteringStatements() error {
+	var err error
+	if s.meteringStatements.update, err = s.db.Prepare(`
+		UPDATE metering
+			SET uplink_msgs = uplink_msgs + $1,
+				uplink_bytes = uplink_bytes + $2,
+				downlink_msgs = downlink_msgs + $3,
+				downlink_bytes = downlink_bytes + $4,
+				radius = radius + $5,
+				fw_bytes = fw_bytes + $6,
+				outputs = outputs + $7
+			WHERE collection_id = $8 AND hour = $9 AND transport = $10`); err != nil {
+		return err
+	}
+	if s.meteringStatements.insert, err = s.db.Prepare(`
+		INSERT INTO metering (
+			collection_id, team_id, hour, transport, uplink_msgs, uplink_bytes,
+			downlink_msgs, downlink_bytes, radius, fw_bytes, outputs)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`); err != nil {
+		return err
+	}
+	if s.meteringStatements.retrieve, err = s.db.Prepare(`
+		SELECT collection_id, team_id, hour, transport, uplink_msgs, uplink_bytes,
+			downlink_msgs, downlink_bytes, radius, fw_bytes, outputs
+			FROM metering
+			WHERE team_id = $1 AND hour >= $2 AND hour < $3
+			ORDER BY hour, collection_id, transport`); err != nil {
+		return err
+	}
+	if s.meteringStatements.collectionTeam, err = s.db.Prepare(`
+		SELECT team_id FROM collection WHERE collection_id = $1`); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *sqlStore) RetrieveCollectionTeam(collectionID model.CollectionKey) (model.TeamKey, error) {
+	var teamID model.TeamKey
+	if err := s.meteringStatements.collectionTeam.QueryRow(collectionID).Scan(&teamID); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, storage.ErrNotFound
+		}
+		return 0, err
+	}
+	return teamID, nil
+}
+
+func (s *sqlStore) AddUsage(records []model.Usage) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	update := tx.Stmt(s.meteringStatements.update)
+	insert := tx.Stmt(s.meteringStatements.insert)
+	for _, u := range records {
+		hour := model.UsageHour(u.Hour).UnixNano()
+		res, err := update.Exec(u.UplinkMessages, u.UplinkBytes, u.DownlinkMessages, u.DownlinkBytes,
+			u.RADIUSSessions, u.FirmwareBytes, u.OutputDeliveries, u.CollectionID, hour, u.Transport)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if count, err := res.RowsAffected(); err == nil && count > 0 {
+			continue
+		}
+		if _, err := insert.Exec(u.CollectionID, u.TeamID, hour, u.Transport, u.UplinkMessages, u.UplinkBytes,
+			u.DownlinkMessages, u.DownlinkBytes, u.RADIUSSessions, u.FirmwareBytes, u.OutputDeliveries); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *sqlStore) RetrieveUsage(teamID model.TeamKey, from time.Time, to time.Time) ([]model.Usage, error) {
+	rows, err := s.meteringStatements.retrieve.Query(teamID, from.UnixNano(), to.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]model.Usage, 0)
+	for rows.Next() {
+		var hour int64
+		u := model.Usage{}
+		if err := rows.Scan(&u.CollectionID, &u.TeamID, &hour, &u.Transport,
+			&u.UplinkMessages, &u.UplinkBytes, &u.DownlinkMessages, &u.DownlinkBytes,
+			&u.RADIUSSessions, &u.FirmwareBytes, &u.OutputDeliveries); err != nil {
+			return nil, err
+		}
+		u.Hour = time.Unix(0, hour).UTC()
+		ret = append(ret, u)
+	}
+	return ret, rows.Err()
+}
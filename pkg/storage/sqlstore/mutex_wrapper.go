@@ -17,6 +17,7 @@ package sqlstore
 //
 import (
 	"sync"
+	"time"
 
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
@@ -92,6 +93,11 @@ func (m *mutexWrapper) UpdateTokenTags(userID model.UserKey, token string, tags
 	defer m.m.Unlock()
 	return m.src.UpdateTokenTags(userID, token, tags)
 }
+func (m *mutexWrapper) UpdateTokenUsage(tokenID string, lastUsed time.Time, ip string) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.UpdateTokenUsage(tokenID, lastUsed, ip)
+}
 func (m *mutexWrapper) RetrieveToken(token string) (model.Token, error) {
 	m.m.Lock()
 	defer m.m.Unlock()
@@ -112,6 +118,16 @@ func (m *mutexWrapper) ListDevices(userID model.UserKey, collectionID model.Coll
 	defer m.m.Unlock()
 	return m.src.ListDevices(userID, collectionID)
 }
+func (m *mutexWrapper) ListDevicesPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Device, string, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.ListDevicesPage(userID, collectionID, opts)
+}
+func (m *mutexWrapper) ImportDevices(userID model.UserKey, collectionID model.CollectionKey, devices []model.Device, upsert bool, check model.DeviceImportCheck) (model.DeviceImportResult, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.ImportDevices(userID, collectionID, devices, upsert, check)
+}
 func (m *mutexWrapper) RetrieveDevice(userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey) (model.Device, error) {
 	m.m.Lock()
 	defer m.m.Unlock()
@@ -162,6 +178,11 @@ func (m *mutexWrapper) ListCollections(userID model.UserKey) ([]model.Collection
 	defer m.m.Unlock()
 	return m.src.ListCollections(userID)
 }
+func (m *mutexWrapper) ListCollectionsPage(userID model.UserKey, opts model.ListOptions) ([]model.Collection, string, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.ListCollectionsPage(userID, opts)
+}
 func (m *mutexWrapper) CreateCollection(userID model.UserKey, collection model.Collection) error {
 	m.m.Lock()
 	defer m.m.Unlock()
@@ -202,6 +223,11 @@ func (m *mutexWrapper) ListOutputs(userID model.UserKey, collectionID model.Coll
 	defer m.m.Unlock()
 	return m.src.ListOutputs(userID, collectionID)
 }
+func (m *mutexWrapper) ListOutputsPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Output, string, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.ListOutputsPage(userID, collectionID, opts)
+}
 func (m *mutexWrapper) CreateOutput(userID model.UserKey, output model.Output) error {
 	m.m.Lock()
 	defer m.m.Unlock()
@@ -341,6 +367,11 @@ func (m *mutexWrapper) ListFirmware(userID model.UserKey, collectionID model.Col
 	defer m.m.Unlock()
 	return m.src.ListFirmware(userID, collectionID)
 }
+func (m *mutexWrapper) ListFirmwarePage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Firmware, string, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.ListFirmwarePage(userID, collectionID, opts)
+}
 
 func (m *mutexWrapper) UpdateFirmware(userID model.UserKey, collectionID model.CollectionKey, fw model.Firmware) error {
 	m.m.Lock()
@@ -388,3 +419,135 @@ func (m *mutexWrapper) UpdateFirmwareStateForDevice(imsi int64, state model.Devi
 	defer m.m.Unlock()
 	return m.src.UpdateFirmwareStateForDevice(imsi, state, message)
 }
+
+func (m *mutexWrapper) NewFirmwareHistoryID() model.FirmwareHistoryKey {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.NewFirmwareHistoryID()
+}
+
+func (m *mutexWrapper) CreateFirmwareHistory(entry model.FirmwareHistoryEntry) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.CreateFirmwareHistory(entry)
+}
+
+func (m *mutexWrapper) RetrieveLatestFirmwareHistory(deviceID model.DeviceKey) (model.FirmwareHistoryEntry, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.RetrieveLatestFirmwareHistory(deviceID)
+}
+
+func (m *mutexWrapper) ListDeviceFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.ListDeviceFirmwareHistory(userID, collectionID, deviceID, before, limit)
+}
+
+func (m *mutexWrapper) ListFirmwareHistory(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey, before model.FirmwareHistoryKey, limit int) ([]model.FirmwareHistoryEntry, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.ListFirmwareHistory(userID, collectionID, firmwareID, before, limit)
+}
+
+func (m *mutexWrapper) RetrieveFirmwareStatistics(userID model.UserKey, collectionID model.CollectionKey, firmwareID model.FirmwareKey) (model.FirmwareStatistics, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.RetrieveFirmwareStatistics(userID, collectionID, firmwareID)
+}
+
+func (m *mutexWrapper) NewSigningKeyID() model.SigningKeyKey {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.NewSigningKeyID()
+}
+
+func (m *mutexWrapper) CreateSigningKey(userID model.UserKey, key model.SigningKey) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.CreateSigningKey(userID, key)
+}
+
+func (m *mutexWrapper) RetrieveSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) (model.SigningKey, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.RetrieveSigningKey(userID, collectionID, keyID)
+}
+
+func (m *mutexWrapper) ListSigningKeys(userID model.UserKey, collectionID model.CollectionKey) ([]model.SigningKey, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.ListSigningKeys(userID, collectionID)
+}
+
+func (m *mutexWrapper) DeleteSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.DeleteSigningKey(userID, collectionID, keyID)
+}
+
+func (m *mutexWrapper) RetrieveTeamQuota(teamID model.TeamKey) (model.Quota, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.RetrieveTeamQuota(teamID)
+}
+
+func (m *mutexWrapper) UpdateTeamQuota(teamID model.TeamKey, quota model.Quota) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.UpdateTeamQuota(teamID, quota)
+}
+
+func (m *mutexWrapper) RetrieveTeamUsage(teamID model.TeamKey, day time.Time) (model.QuotaUsage, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.RetrieveTeamUsage(teamID, day)
+}
+
+func (m *mutexWrapper) AddMessageUsage(collectionID model.CollectionKey, direction model.MessageDirection, day time.Time) (bool, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.AddMessageUsage(collectionID, direction, day)
+}
+
+func (m *mutexWrapper) RetrieveCollectionTeam(collectionID model.CollectionKey) (model.TeamKey, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.RetrieveCollectionTeam(collectionID)
+}
+
+func (m *mutexWrapper) AddUsage(records []model.Usage) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.AddUsage(records)
+}
+
+func (m *mutexWrapper) RetrieveUsage(teamID model.TeamKey, from time.Time, to time.Time) ([]model.Usage, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.RetrieveUsage(teamID, from, to)
+}
+
+func (m *mutexWrapper) NewAuditEventID() model.AuditKey {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.NewAuditEventID()
+}
+
+func (m *mutexWrapper) CreateAuditEvent(event model.AuditEvent) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.CreateAuditEvent(event)
+}
+
+func (m *mutexWrapper) ListAuditEvents(teamID model.TeamKey, before model.AuditKey, limit int) ([]model.AuditEvent, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.ListAuditEvents(teamID, before, limit)
+}
+
+func (m *mutexWrapper) DeleteAuditEvents(olderThan time.Time) (int64, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.src.DeleteAuditEvents(olderThan)
+}
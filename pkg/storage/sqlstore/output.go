@@ -110,7 +110,7 @@ func (s *sqlStore) initOutputStatements() error {
 					o.collection_id = c.collection_id AND
 					c.team_id = m.team_id AND
 					user_id = $3 AND
-					role_id = 1)`); err != nil {
+					role_id IN (` + rolesWithPermission(model.ManageOutputsPermission) + `))`); err != nil {
 		return err
 	}
 	if s.outputStatements.collectionExists, err = s.db.Prepare(`
@@ -161,7 +161,7 @@ func (s *sqlStore) CreateOutput(userID model.UserKey, output model.Output) error
 	if err != nil {
 		return err
 	}
-	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, output.CollectionID); err != nil {
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, output.CollectionID, model.ManageOutputsPermission); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -194,7 +194,7 @@ func (s *sqlStore) DeleteOutput(userID model.UserKey, collectionID model.Collect
 	if err != nil {
 		return err
 	}
-	if err := s.utils.EnsureAdminOfOutput(tx, userID, collectionID, outputID); err != nil {
+	if err := s.utils.EnsureAdminOfOutput(tx, userID, collectionID, outputID, model.ManageOutputsPermission); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -211,12 +211,12 @@ func (s *sqlStore) UpdateOutput(userID model.UserKey, collectionID model.Collect
 	if err != nil {
 		return err
 	}
-	if err := s.utils.EnsureAdminOfOutput(tx, userID, collectionID, output.ID); err != nil {
+	if err := s.utils.EnsureAdminOfOutput(tx, userID, collectionID, output.ID, model.ManageOutputsPermission); err != nil {
 		tx.Rollback()
 		return err
 	}
 	if output.CollectionID != collectionID {
-		if _, err := s.utils.EnsureAdminOfCollection(tx, userID, output.CollectionID); err != nil {
+		if _, err := s.utils.EnsureAdminOfCollection(tx, userID, output.CollectionID, model.ManageOutputsPermission); err != nil {
 			tx.Rollback()
 			return err
 		}
@@ -271,7 +271,7 @@ func (s *sqlStore) UpdateOutputTags(userID model.UserKey, outputID string, tags
 			}
 			return err
 		}
-		_, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID)
+		_, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID, model.ManageOutputsPermission)
 		tx.Rollback()
 		return err
 	}
@@ -0,0 +1,365 @@
+package sqlstore
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/lib/pq"
+)
+
+// pageQuery builds the queries for paged lists. The tag filters and cursors
+// vary from request to request so these can't be prepared statements.
+type pageQuery struct {
+	postgres bool
+	where    []string
+	args     []interface{}
+}
+
+func (s *sqlStore) newPageQuery(where ...string) *pageQuery {
+	return &pageQuery{postgres: s.postgres, where: where}
+}
+
+// isPostgres returns true if the connection uses the PostgreSQL driver
+func isPostgres(db *sql.DB) bool {
+	_, ok := db.Driver().(*pq.Driver)
+	return ok
+}
+
+// arg adds a parameter to the query and returns the placeholder for it
+func (q *pageQuery) arg(v interface{}) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *pageQuery) addWhere(cond string) {
+	q.where = append(q.where, cond)
+}
+
+// addTagFilter adds the tag conditions for the tag column. PostgreSQL stores
+// the tags as JSONB and can look up the values directly. SQLite doesn't have
+// the JSON functions so the filter looks for the JSON encoded key/value pair
+// instead. The tags are encoded with encoding/json when written so the pair
+// will be encoded the same way.
+func (q *pageQuery) addTagFilter(column string, filter model.TagFilter) {
+	for _, cond := range filter {
+		if q.postgres {
+			name := q.arg(cond.Name)
+			value := q.arg(cond.Value)
+			if cond.NotEqual {
+				q.addWhere(fmt.Sprintf("(%[1]s->>CAST(%[2]s AS TEXT) IS NULL OR %[1]s->>CAST(%[2]s AS TEXT) <> %[3]s)", column, name, value))
+				continue
+			}
+			q.addWhere(fmt.Sprintf("%s->>CAST(%s AS TEXT) = %s", column, name, value))
+			continue
+		}
+		name, _ := json.Marshal(cond.Name)
+		value, _ := json.Marshal(cond.Value)
+		pair := q.arg(string(name) + ":" + string(value))
+		if cond.NotEqual {
+			q.addWhere(fmt.Sprintf("(%[1]s IS NULL OR instr(%[1]s, %[2]s) = 0)", column, pair))
+			continue
+		}
+		q.addWhere(fmt.Sprintf("instr(%s, %s) > 0", column, pair))
+	}
+}
+
+// addIDCursor adds the cursor for lists sorted by the ID column
+func (q *pageQuery) addIDCursor(column string, token string) error {
+	if token == "" {
+		return nil
+	}
+	_, id, err := model.ParsePageToken(token)
+	if err != nil {
+		return storage.ErrInvalidListOptions
+	}
+	q.addWhere(fmt.Sprintf("%s > %s", column, q.arg(id)))
+	return nil
+}
+
+// query runs the query. The limit is one more than the page size to
+// detect if there's more pages.
+func (q *pageQuery) query(db *sql.DB, selectFrom string, orderBy string, pageSize int) (*sql.Rows, error) {
+	stmt := selectFrom + " WHERE " + strings.Join(q.where, " AND ") + " ORDER BY " + orderBy
+	if pageSize > 0 {
+		stmt += " LIMIT " + q.arg(pageSize+1)
+	}
+	return db.Query(stmt, q.args...)
+}
+
+// pageSize returns the page size for the options
+func pageSize(opts model.ListOptions) int {
+	if opts.PageSize > model.MaxPageSize {
+		return model.MaxPageSize
+	}
+	return opts.PageSize
+}
+
+// morePages returns true if there's more pages. The query returns one extra
+// element when there's more pages.
+func morePages(count int, pageSize int) bool {
+	return pageSize > 0 && count > pageSize
+}
+
+const deviceListColumns = `
+		SELECT
+			d.device_id,
+			d.imsi,
+			d.imei,
+			d.collection_id,
+			d.tags,
+			d.net_apn_id,
+			d.net_nas_id,
+			d.net_allocated_ip,
+			d.net_allocated_at,
+			d.net_cell_id,
+			d.fw_current_version,
+			d.fw_target_version,
+			d.fw_serial_number,
+			d.fw_model_number,
+			d.fw_manufacturer,
+			d.fw_version,
+			d.fw_state,
+			d.fw_state_message,
+			d.fw_maintenance
+		FROM
+			device d, collection c, member m`
+
+// The last seen sort key is the allocation time in nanoseconds. Devices that
+// haven't been seen are sorted last and use zero as the sort key.
+func lastSeenKey(d model.Device) int64 {
+	if d.Network.AllocatedAt.IsZero() {
+		return 0
+	}
+	return d.Network.AllocatedAt.UnixNano()
+}
+
+func (s *sqlStore) ListDevicesPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Device, string, error) {
+	q := s.newPageQuery(
+		"d.collection_id = c.collection_id",
+		"c.team_id = m.team_id")
+	q.addWhere("m.user_id = " + q.arg(userID))
+	q.addWhere("c.collection_id = " + q.arg(collectionID))
+	q.addTagFilter("d.tags", opts.Filter)
+
+	var sortKey, lastID int64
+	if opts.PageToken != "" {
+		var err error
+		if sortKey, lastID, err = model.ParsePageToken(opts.PageToken); err != nil {
+			return nil, "", storage.ErrInvalidListOptions
+		}
+	}
+	orderBy := "d.device_id"
+	switch opts.SortBy {
+	case model.SortByCreated, "":
+		if opts.PageToken != "" {
+			q.addWhere("d.device_id > " + q.arg(lastID))
+		}
+	case model.SortByIMSI:
+		orderBy = "d.imsi, d.device_id"
+		if opts.PageToken != "" {
+			imsi := q.arg(sortKey)
+			q.addWhere(fmt.Sprintf("(d.imsi > %[1]s OR (d.imsi = %[1]s AND d.device_id > %[2]s))", imsi, q.arg(lastID)))
+		}
+	case model.SortByLastSeen:
+		orderBy = "(d.net_allocated_at IS NULL), d.net_allocated_at DESC, d.device_id"
+		if opts.PageToken != "" {
+			if sortKey == 0 {
+				q.addWhere("(d.net_allocated_at IS NULL AND d.device_id > " + q.arg(lastID) + ")")
+				break
+			}
+			seen := q.arg(time.Unix(0, sortKey))
+			q.addWhere(fmt.Sprintf("(d.net_allocated_at IS NULL OR d.net_allocated_at < %[1]s OR (d.net_allocated_at = %[1]s AND d.device_id > %[2]s))", seen, q.arg(lastID)))
+		}
+	default:
+		return nil, "", storage.ErrInvalidListOptions
+	}
+
+	size := pageSize(opts)
+	rows, err := q.query(s.db, deviceListColumns, orderBy, size)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	devices := make([]model.Device, 0)
+	for rows.Next() {
+		dev, err := s.readDevice(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		devices = append(devices, dev)
+	}
+	if len(devices) == 0 {
+		count := 0
+		if err := s.deviceStatements.memberCheck.QueryRow(collectionID, userID).Scan(&count); err == sql.ErrNoRows || count == 0 {
+			return nil, "", storage.ErrNotFound
+		}
+	}
+	if !morePages(len(devices), size) {
+		return devices, "", nil
+	}
+	devices = devices[:size]
+	last := devices[size-1]
+	switch opts.SortBy {
+	case model.SortByIMSI:
+		return devices, model.NewPageToken(last.IMSI, int64(last.ID)), nil
+	case model.SortByLastSeen:
+		return devices, model.NewPageToken(lastSeenKey(last), int64(last.ID)), nil
+	default:
+		return devices, model.NewPageToken(0, int64(last.ID)), nil
+	}
+}
+
+// validateIDSort checks the sort order for lists that only can be sorted by
+// creation order.
+func validateIDSort(opts model.ListOptions) error {
+	if opts.SortBy != "" && opts.SortBy != model.SortByCreated {
+		return storage.ErrInvalidListOptions
+	}
+	return nil
+}
+
+func (s *sqlStore) ListCollectionsPage(userID model.UserKey, opts model.ListOptions) ([]model.Collection, string, error) {
+	if err := validateIDSort(opts); err != nil {
+		return nil, "", err
+	}
+	q := s.newPageQuery("c.team_id = m.team_id")
+	q.addWhere("m.user_id = " + q.arg(userID))
+	q.addTagFilter("c.tags", opts.Filter)
+	if err := q.addIDCursor("c.collection_id", opts.PageToken); err != nil {
+		return nil, "", err
+	}
+	size := pageSize(opts)
+	rows, err := q.query(s.db, `
+		SELECT c.collection_id, c.team_id, c.tags, c.field_mask, c.fw_current_version, c.fw_target_version, c.fw_management, c.fw_require_signature, c.fw_maintenance
+			FROM collection c, member m`, "c.collection_id", size)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	cl := make([]model.Collection, 0)
+	for rows.Next() {
+		var coll model.Collection
+		var current, target sql.NullInt64
+		if err := rows.Scan(
+			&coll.ID, &coll.TeamID, &coll.TagMap, &coll.FieldMask,
+			&current, &target,
+			&coll.Firmware.Management, &coll.Firmware.RequireSignature, &coll.Firmware.MaintenanceWindow); err != nil {
+			return nil, "", err
+		}
+		s.setFirmwareValues(current, target, &coll)
+		cl = append(cl, coll)
+	}
+	if !morePages(len(cl), size) {
+		return cl, "", nil
+	}
+	cl = cl[:size]
+	return cl, model.NewPageToken(0, int64(cl[size-1].ID)), nil
+}
+
+func (s *sqlStore) ListOutputsPage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Output, string, error) {
+	if err := validateIDSort(opts); err != nil {
+		return nil, "", err
+	}
+	q := s.newPageQuery(
+		"o.collection_id = c.collection_id",
+		"c.team_id = m.team_id")
+	q.addWhere("c.collection_id = " + q.arg(collectionID))
+	q.addWhere("m.user_id = " + q.arg(userID))
+	q.addTagFilter("o.tags", opts.Filter)
+	if err := q.addIDCursor("o.output_id", opts.PageToken); err != nil {
+		return nil, "", err
+	}
+	size := pageSize(opts)
+	rows, err := q.query(s.db, `
+		SELECT o.output_id, o.output_type, o.collection_id, o.config, o.enabled, o.tags, c.field_mask
+			FROM output o, collection c, member m`, "o.output_id", size)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	ret := make([]model.Output, 0)
+	for rows.Next() {
+		var o model.Output
+		if err := rows.Scan(&o.ID, &o.Type, &o.CollectionID, &o.Config, &o.Enabled, &o.TagMap, &o.CollectionFieldMask); err != nil {
+			return nil, "", err
+		}
+		ret = append(ret, o)
+	}
+	if len(ret) == 0 {
+		count := 0
+		if err := s.outputStatements.collectionMembership.QueryRow(collectionID, userID).Scan(&count); err == sql.ErrNoRows || count == 0 {
+			return nil, "", storage.ErrNotFound
+		}
+	}
+	if !morePages(len(ret), size) {
+		return ret, "", nil
+	}
+	ret = ret[:size]
+	return ret, model.NewPageToken(0, int64(ret[size-1].ID)), nil
+}
+
+func (s *sqlStore) ListFirmwarePage(userID model.UserKey, collectionID model.CollectionKey, opts model.ListOptions) ([]model.Firmware, string, error) {
+	if err := validateIDSort(opts); err != nil {
+		return nil, "", err
+	}
+	q := s.newPageQuery(
+		"fw.collection_id = c.collection_id",
+		"c.team_id = m.team_id")
+	q.addWhere("m.user_id = " + q.arg(userID))
+	q.addWhere("c.collection_id = " + q.arg(collectionID))
+	q.addTagFilter("fw.tags", opts.Filter)
+	if err := q.addIDCursor("fw.firmware_id", opts.PageToken); err != nil {
+		return nil, "", err
+	}
+	size := pageSize(opts)
+	rows, err := q.query(s.db, `
+		SELECT
+			fw.firmware_id, fw.filename, fw.version, fw.length, fw.sha256,
+			fw.created, fw.collection_id, fw.tags, fw.signature, fw.signing_key_id,
+			fw.compat_manufacturer, fw.compat_model_pattern, fw.compat_min_version
+		FROM
+			firmware fw, collection c, member m`, "fw.firmware_id", size)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	ret := make([]model.Firmware, 0)
+	for rows.Next() {
+		var fw model.Firmware
+		if err := rows.Scan(&fw.ID, &fw.Filename, &fw.Version, &fw.Length, &fw.SHA256, &fw.Created, &fw.CollectionID, &fw.TagMap, &fw.Signature, &fw.SigningKeyID,
+			&fw.Compatibility.Manufacturer, &fw.Compatibility.ModelPattern, &fw.Compatibility.MinimumVersion); err != nil {
+			return nil, "", err
+		}
+		ret = append(ret, fw)
+	}
+	if len(ret) == 0 {
+		count := 0
+		if err := s.deviceStatements.memberCheck.QueryRow(collectionID, userID).Scan(&count); err == sql.ErrNoRows || count == 0 {
+			return nil, "", storage.ErrNotFound
+		}
+	}
+	if !morePages(len(ret), size) {
+		return ret, "", nil
+	}
+	ret = ret[:size]
+	return ret, model.NewPageToken(0, int64(ret[size-1].ID)), nil
+}
@@ -0,0 +1,70 @@
+package sqlstore
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"github.com/eesrc/horde/pkg/model"
+)
+
+func tagsEqual(a, b model.Tags) bool {
+	if len(a.TagMap) != len(b.TagMap) {
+		return false
+	}
+	for k, v := range a.TagMap {
+		if other, ok := b.TagMap[k]; !ok || other != v {
+			return false
+		}
+	}
+	return true
+}
+
+// collectionUpdatePermissions returns the permissions required to update the
+// collection. The firmware settings require the firmware permission and
+// everything else requires the collection permission.
+func collectionUpdatePermissions(existing, updated model.Collection) []model.Permission {
+	var ret []model.Permission
+	if existing.TeamID != updated.TeamID ||
+		existing.FieldMask != updated.FieldMask ||
+		!tagsEqual(existing.Tags, updated.Tags) {
+		ret = append(ret, model.ManageCollectionsPermission)
+	}
+	if existing.Firmware != updated.Firmware {
+		ret = append(ret, model.ManageFirmwarePermission)
+	}
+	return ret
+}
+
+// deviceUpdatePermissions returns the permissions required to update the
+// device. The firmware metadata requires the firmware permission and
+// everything else requires the device permission.
+func deviceUpdatePermissions(existing, updated model.Device) []model.Permission {
+	var ret []model.Permission
+	if existing.IMSI != updated.IMSI ||
+		existing.IMEI != updated.IMEI ||
+		existing.CollectionID != updated.CollectionID ||
+		existing.Network.AllocatedIP != updated.Network.AllocatedIP ||
+		!existing.Network.AllocatedAt.Equal(updated.Network.AllocatedAt) ||
+		existing.Network.CellID != updated.Network.CellID ||
+		existing.Network.ApnID != updated.Network.ApnID ||
+		existing.Network.NasID != updated.Network.NasID ||
+		!tagsEqual(existing.Tags, updated.Tags) {
+		ret = append(ret, model.ManageDevicesPermission)
+	}
+	if existing.Firmware != updated.Firmware {
+		ret = append(ret, model.ManageFirmwarePermission)
+	}
+	return ret
+}
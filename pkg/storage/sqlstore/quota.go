@@ -0,0 +1,204 @@
+package sqlstore
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"database/sql"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+type quotaStatements struct {
+	retrieve         *sql.Stmt
+	teamExists       *sql.Stmt
+	delete           *sql.Stmt
+	insert           *sql.Stmt
+	countDevices     *sql.Stmt
+	countCollections *sql.Stmt
+	countOutputs     *sql.Stmt
+	firmwareBytes    *sql.Stmt
+	retrieveUsage    *sql.Stmt
+	collectionQuota  *sql.Stmt
+	addUplink        *sql.Stmt
+	addDownlink      *sql.Stmt
+}
+
+func (s *sqlStore) initQuotaStatements() error {
+	var err error
+	if s.quotaStatements.retrieve, err = s.db.Prepare(`
+		SELECT max_devices, max_collections, max_outputs, max_fw_bytes, max_uplink_day, max_downlink_day
+			FROM team_quota
+			WHERE team_id = $1`); err != nil {
+		return err
+	}
+	if s.quotaStatements.teamExists, err = s.db.Prepare(`
+		SELECT team_id FROM team WHERE team_id = $1`); err != nil {
+		return err
+	}
+	if s.quotaStatements.delete, err = s.db.Prepare(`
+		DELETE FROM team_quota WHERE team_id = $1`); err != nil {
+		return err
+	}
+	if s.quotaStatements.insert, err = s.db.Prepare(`
+		INSERT INTO team_quota (
+			team_id, max_devices, max_collections, max_outputs, max_fw_bytes, max_uplink_day, max_downlink_day)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`); err != nil {
+		return err
+	}
+	if s.quotaStatements.countDevices, err = s.db.Prepare(`
+		SELECT COUNT(*)
+			FROM device d
+				INNER JOIN collection c ON d.collection_id = c.collection_id
+			WHERE c.team_id = $1`); err != nil {
+		return err
+	}
+	if s.quotaStatements.countCollections, err = s.db.Prepare(`
+		SELECT COUNT(*) FROM collection WHERE team_id = $1`); err != nil {
+		return err
+	}
+	if s.quotaStatements.countOutputs, err = s.db.Prepare(`
+		SELECT COUNT(*)
+			FROM output o
+				INNER JOIN collection c ON o.collection_id = c.collection_id
+			WHERE c.team_id = $1`); err != nil {
+		return err
+	}
+	if s.quotaStatements.firmwareBytes, err = s.db.Prepare(`
+		SELECT COALESCE(SUM(f.length), 0)
+			FROM firmware f
+				INNER JOIN collection c ON f.collection_id = c.collection_id
+			WHERE c.team_id = $1`); err != nil {
+		return err
+	}
+	if s.quotaStatements.retrieveUsage, err = s.db.Prepare(`
+		SELECT uplink, downlink
+			FROM team_usage
+			WHERE team_id = $1 AND day = $2`); err != nil {
+		return err
+	}
+	if s.quotaStatements.collectionQuota, err = s.db.Prepare(`
+		SELECT c.team_id, COALESCE(q.max_uplink_day, 0), COALESCE(q.max_downlink_day, 0)
+			FROM collection c
+				LEFT OUTER JOIN team_quota q ON c.team_id = q.team_id
+			WHERE c.collection_id = $1`); err != nil {
+		return err
+	}
+	// The usage is counted with a single upsert. The counter is only
+	// incremented if it's below the limit ($3). A limit of 0 is unlimited.
+	if s.quotaStatements.addUplink, err = s.db.Prepare(`
+		INSERT INTO team_usage (team_id, day, uplink, downlink)
+			VALUES ($1, $2, 1, 0)
+			ON CONFLICT (team_id, day) DO UPDATE
+				SET uplink = team_usage.uplink + 1
+				WHERE $3 = 0 OR team_usage.uplink < $3`); err != nil {
+		return err
+	}
+	if s.quotaStatements.addDownlink, err = s.db.Prepare(`
+		INSERT INTO team_usage (team_id, day, uplink, downlink)
+			VALUES ($1, $2, 0, 1)
+			ON CONFLICT (team_id, day) DO UPDATE
+				SET downlink = team_usage.downlink + 1
+				WHERE $3 = 0 OR team_usage.downlink < $3`); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *sqlStore) RetrieveTeamQuota(teamID model.TeamKey) (model.Quota, error) {
+	ret := model.Quota{}
+	err := s.quotaStatements.retrieve.QueryRow(teamID).Scan(&ret.MaxDevices, &ret.MaxCollections,
+		&ret.MaxOutputs, &ret.MaxFirmwareBytes, &ret.MaxUplinkPerDay, &ret.MaxDownlinkPerDay)
+	if err == sql.ErrNoRows {
+		// No quota set for team
+		return model.Quota{}, nil
+	}
+	return ret, err
+}
+
+func (s *sqlStore) UpdateTeamQuota(teamID model.TeamKey, quota model.Quota) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	var id model.TeamKey
+	if err := tx.Stmt(s.quotaStatements.teamExists).QueryRow(teamID).Scan(&id); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return storage.ErrNotFound
+		}
+		return err
+	}
+	if _, err := tx.Stmt(s.quotaStatements.delete).Exec(teamID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Stmt(s.quotaStatements.insert).Exec(teamID, quota.MaxDevices, quota.MaxCollections,
+		quota.MaxOutputs, quota.MaxFirmwareBytes, quota.MaxUplinkPerDay, quota.MaxDownlinkPerDay); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *sqlStore) RetrieveTeamUsage(teamID model.TeamKey, day time.Time) (model.QuotaUsage, error) {
+	ret := model.QuotaUsage{}
+	if err := s.quotaStatements.countDevices.QueryRow(teamID).Scan(&ret.Devices); err != nil {
+		return ret, err
+	}
+	if err := s.quotaStatements.countCollections.QueryRow(teamID).Scan(&ret.Collections); err != nil {
+		return ret, err
+	}
+	if err := s.quotaStatements.countOutputs.QueryRow(teamID).Scan(&ret.Outputs); err != nil {
+		return ret, err
+	}
+	if err := s.quotaStatements.firmwareBytes.QueryRow(teamID).Scan(&ret.FirmwareBytes); err != nil {
+		return ret, err
+	}
+	err := s.quotaStatements.retrieveUsage.QueryRow(teamID, model.QuotaDay(day).UnixNano()).Scan(&ret.UplinkToday, &ret.DownlinkToday)
+	if err != nil && err != sql.ErrNoRows {
+		return ret, err
+	}
+	return ret, nil
+}
+
+func (s *sqlStore) AddMessageUsage(collectionID model.CollectionKey, direction model.MessageDirection, day time.Time) (bool, error) {
+	var teamID model.TeamKey
+	quota := model.Quota{}
+	if err := s.quotaStatements.collectionQuota.QueryRow(collectionID).Scan(
+		&teamID, &quota.MaxUplinkPerDay, &quota.MaxDownlinkPerDay); err != nil {
+		if err == sql.ErrNoRows {
+			return false, storage.ErrNotFound
+		}
+		return false, err
+	}
+
+	update := s.quotaStatements.addUplink
+	if direction == model.Downlink {
+		update = s.quotaStatements.addDownlink
+	}
+	// No rows are changed when the budget is exhausted
+	res, err := update.Exec(teamID, model.QuotaDay(day).UnixNano(), quota.MessageLimit(direction))
+	if err != nil {
+		return false, err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -19,6 +19,8 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+
+	"github.com/ExploratoryEngineering/logging"
 )
 
 // Schema contains a database schema.
@@ -54,13 +56,20 @@ CREATE INDEX IF NOT EXISTS user_email ON hordeuser (email);
 CREATE UNIQUE INDEX IF NOT EXISTS user_external ON hordeuser(external_id);
 
 -- Token table. This holds the API token for users. Tokens are generated
--- by a secure random generator inside the service.
+-- by a secure random generator inside the service. Only the SHA-256 hash of
+-- the token is stored. The timestamps are nanoseconds since epoch, 0 when
+-- they aren't set. Columns after tags are added by the token migration for
+-- existing tables.
 CREATE TABLE IF NOT EXISTS token (
-	token     VARCHAR(64)   NOT NULL,
-	resource  VARCHAR(128)  NOT NULL,
-	user_id   BIGINT        NOT NULL REFERENCES hordeuser (user_id) ON DELETE CASCADE,
-	write     BOOL          NOT NULL DEFAULT FALSE,
-	tags      JSON          NULL,
+	token        VARCHAR(64)   NOT NULL,            -- hash of token
+	resource     VARCHAR(128)  NOT NULL,
+	user_id      BIGINT        NOT NULL REFERENCES hordeuser (user_id) ON DELETE CASCADE,
+	write        BOOL          NOT NULL DEFAULT FALSE,
+	tags         JSON          NULL,
+	created      BIGINT        NOT NULL DEFAULT 0,
+	expires      BIGINT        NOT NULL DEFAULT 0,
+	last_used    BIGINT        NOT NULL DEFAULT 0,
+	last_used_ip VARCHAR(64)   NOT NULL DEFAULT '',
 
 	CONSTRAINT token_pk PRIMARY KEY (token)
 );
@@ -91,6 +100,9 @@ CREATE TABLE IF NOT EXISTS role (
 -- Default roles.
 INSERT INTO role (role_id, name) SELECT 1, 'Admin' WHERE NOT EXISTS (SELECT role_id FROM role WHERE role_id = 1);
 INSERT INTO role (role_id, name) SELECT 0, 'Member' WHERE NOT EXISTS (SELECT role_id FROM role WHERE role_id = 0);
+INSERT INTO role (role_id, name) SELECT 2, 'Viewer' WHERE NOT EXISTS (SELECT role_id FROM role WHERE role_id = 2);
+INSERT INTO role (role_id, name) SELECT 3, 'Operator' WHERE NOT EXISTS (SELECT role_id FROM role WHERE role_id = 3);
+INSERT INTO role (role_id, name) SELECT 4, 'FirmwareManager' WHERE NOT EXISTS (SELECT role_id FROM role WHERE role_id = 4);
 
 -- Teams are collections of users. A team may contain one or more members, not
 -- zero. By default new users are member of their own team (and nothing else)
@@ -127,6 +139,11 @@ CREATE TABLE IF NOT EXISTS firmware (
 	created       DATETIME     NOT NULL,
 	collection_id BIGINT       NOT NULL, -- constraint is added later
 	tags          JSON         NULL,
+	signature     BYTES        NULL,
+	signing_key_id BIGINT       NOT NULL DEFAULT 0, -- zero for unsigned images
+	compat_manufacturer  VARCHAR(128) NOT NULL DEFAULT '', -- empty matches all devices
+	compat_model_pattern VARCHAR(128) NOT NULL DEFAULT '', -- glob pattern for model number
+	compat_min_version   VARCHAR(128) NOT NULL DEFAULT '', -- minimum version on device
 
 	CONSTRAINT firmware_pk PRIMARY KEY (firmware_id)
 );
@@ -135,6 +152,43 @@ CREATE INDEX IF NOT EXISTS firmware_fk1 ON firmware(collection_id);
 CREATE UNIQUE INDEX IF NOT EXISTS firmware_sha ON firmware(collection_id, sha256);
 CREATE UNIQUE INDEX IF NOT EXISTS firmware_version ON firmware(collection_id, version);
 
+--
+-- Firmware signing keys. The private key is only set for keys generated by
+-- the service. Keys registered by users only have the public key.
+CREATE TABLE IF NOT EXISTS signing_key (
+	key_id        BIGINT      NOT NULL,
+	collection_id BIGINT      NOT NULL, -- removed with the collection
+	algorithm     VARCHAR(16) NOT NULL,
+	public_key    BYTES       NOT NULL,
+	private_key   BYTES       NULL,
+	created       DATETIME    NOT NULL,
+
+	CONSTRAINT signing_key_pk PRIMARY KEY (key_id)
+);
+
+CREATE INDEX IF NOT EXISTS signing_key_fk1 ON signing_key(collection_id);
+
+--
+-- Firmware state transitions for devices. The entries are removed with the
+-- collection. Times and durations are in nanoseconds.
+CREATE TABLE IF NOT EXISTS firmware_history (
+	history_id       BIGINT       NOT NULL,
+	time             BIGINT       NOT NULL,
+	collection_id    BIGINT       NOT NULL,
+	device_id        BIGINT       NOT NULL,
+	from_firmware_id BIGINT       NOT NULL, -- zero if the current firmware is unknown
+	to_firmware_id   BIGINT       NOT NULL, -- zero if there's no target firmware
+	previous_state   CHAR(1)      NOT NULL,
+	state            CHAR(1)      NOT NULL,
+	message          VARCHAR(128) NOT NULL,
+	duration         BIGINT       NOT NULL,
+
+	CONSTRAINT firmware_history_pk PRIMARY KEY (history_id)
+);
+
+CREATE INDEX IF NOT EXISTS firmware_history_device ON firmware_history(device_id, time);
+CREATE INDEX IF NOT EXISTS firmware_history_collection ON firmware_history(collection_id, time);
+
 -- Collections of devices. A device will always be in a collection.
 CREATE TABLE IF NOT EXISTS collection (
 	collection_id      BIGINT  NOT NULL,                            -- Collection ID
@@ -143,6 +197,8 @@ CREATE TABLE IF NOT EXISTS collection (
 	fw_current_version BIGINT  NULL     REFERENCES firmware (firmware_id),
 	fw_target_version  BIGINT  NULL     REFERENCES firmware (firmware_id),
 	fw_management      SMALLINT NOT NULL DEFAULT 32,
+	fw_require_signature BOOL NOT NULL DEFAULT FALSE,                -- Refuse unsigned images
+	fw_maintenance     JSON    NULL,                                -- Maintenance window for updates
 	tags               JSON    NULL,                                -- Tags for collection
 
 	CONSTRAINT collection_pk PRIMARY KEY (collection_id)
@@ -176,6 +232,7 @@ CREATE TABLE IF NOT EXISTS device (
 	fw_version         VARCHAR(64)  NULL,
 	fw_state           CHAR(1)      NOT NULL DEFAULT 'i',
 	fw_state_message   VARCHAR(128) NOT NULL DEFAULT '',
+	fw_maintenance     JSON         NULL, -- Maintenance window, overrides the collection's window
 
 	CONSTRAINT device_pk PRIMARY KEY (device_id)
 );
@@ -218,6 +275,75 @@ CREATE TABLE IF NOT EXISTS invite (
 CREATE INDEX IF NOT EXISTS invite_fk1 ON invite (team_id);
 CREATE INDEX IF NOT EXISTS invite_fk2 ON invite (user_id);
 
+-- Team quotas. Teams without a quota have no limits and a zero value means
+-- the resource is unlimited.
+CREATE TABLE IF NOT EXISTS team_quota (
+	team_id          BIGINT NOT NULL REFERENCES team (team_id) ON DELETE CASCADE,
+	max_devices      INT    NOT NULL DEFAULT 0,
+	max_collections  INT    NOT NULL DEFAULT 0,
+	max_outputs      INT    NOT NULL DEFAULT 0,
+	max_fw_bytes     BIGINT NOT NULL DEFAULT 0,
+	max_uplink_day   INT    NOT NULL DEFAULT 0,
+	max_downlink_day INT    NOT NULL DEFAULT 0,
+
+	CONSTRAINT team_quota_pk PRIMARY KEY (team_id)
+);
+
+-- Daily message counts for teams. The day is the start of the day (UTC) in
+-- nanoseconds since epoch.
+CREATE TABLE IF NOT EXISTS team_usage (
+	team_id  BIGINT NOT NULL REFERENCES team (team_id) ON DELETE CASCADE,
+	day      BIGINT NOT NULL,
+	uplink   INT    NOT NULL DEFAULT 0,
+	downlink INT    NOT NULL DEFAULT 0,
+
+	CONSTRAINT team_usage_pk PRIMARY KEY (team_id, day)
+);
+
+-- Metered usage per collection in hourly buckets. The hour is the start of
+-- the hour (UTC) in nanoseconds since epoch. The team ID is set when the row
+-- is created and the rows are kept when the collection is removed.
+CREATE TABLE IF NOT EXISTS metering (
+	collection_id  BIGINT      NOT NULL,
+	team_id        BIGINT      NOT NULL,
+	hour           BIGINT      NOT NULL,
+	transport      VARCHAR(32) NOT NULL,
+	uplink_msgs    BIGINT      NOT NULL DEFAULT 0,
+	uplink_bytes   BIGINT      NOT NULL DEFAULT 0,
+	downlink_msgs  BIGINT      NOT NULL DEFAULT 0,
+	downlink_bytes BIGINT      NOT NULL DEFAULT 0,
+	radius         BIGINT      NOT NULL DEFAULT 0,
+	fw_bytes       BIGINT      NOT NULL DEFAULT 0,
+	outputs        BIGINT      NOT NULL DEFAULT 0,
+
+	CONSTRAINT metering_pk PRIMARY KEY (collection_id, hour, transport)
+);
+
+CREATE INDEX IF NOT EXISTS metering_team ON metering(team_id, hour);
+
+-- Audit trail for changes made through the API and the management service.
+-- The time is nanoseconds since epoch. The events aren't removed with the
+-- team; they're removed when they expire.
+CREATE TABLE IF NOT EXISTS audit_event (
+	audit_id      BIGINT       NOT NULL,
+	time          BIGINT       NOT NULL,
+	team_id       BIGINT       NOT NULL,
+	source        VARCHAR(32)  NOT NULL,
+	user_id       BIGINT       NOT NULL,
+	token_id      VARCHAR(128) NOT NULL,
+	auth_method   INT          NOT NULL,
+	action        VARCHAR(64)  NOT NULL,
+	resource_type VARCHAR(32)  NOT NULL,
+	resource_id   VARCHAR(512) NOT NULL,
+	before_state  TEXT         NOT NULL,
+	after_state   TEXT         NOT NULL,
+
+	CONSTRAINT audit_event_pk PRIMARY KEY (audit_id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_event_team ON audit_event(team_id, time);
+CREATE INDEX IF NOT EXISTS audit_event_time ON audit_event(time);
+
 CREATE TABLE IF NOT EXISTS device_lookup (
 	imsi     BIGINT      NOT NULL, -- IMSI for device
 	msisdn   VARCHAR(20) NOT NULL, -- MSISDN including country code
@@ -382,3 +508,43 @@ func NewSchema(driver string, statements ...string) *Schema {
 
 	return &Schema{driver: driver, statements: strings.Join(statements, "\n")}
 }
+
+// addedColumn is a column that was added to a table after the table was
+// created. CREATE TABLE IF NOT EXISTS leaves existing tables unchanged so the
+// column is added with ALTER TABLE when it is missing. The definition must
+// match the column in DBSchema.
+type addedColumn struct {
+	table      string
+	column     string
+	definition string
+}
+
+// addedColumns are the columns added to existing tables, in the order they
+// were added.
+var addedColumns = []addedColumn{
+	{"firmware", "signature", "BYTES NULL"},
+	{"firmware", "signing_key_id", "BIGINT NOT NULL DEFAULT 0"},
+	{"collection", "fw_require_signature", "BOOL NOT NULL DEFAULT FALSE"},
+	{"firmware", "compat_manufacturer", "VARCHAR(128) NOT NULL DEFAULT ''"},
+	{"firmware", "compat_model_pattern", "VARCHAR(128) NOT NULL DEFAULT ''"},
+	{"firmware", "compat_min_version", "VARCHAR(128) NOT NULL DEFAULT ''"},
+	{"collection", "fw_maintenance", "JSON NULL"},
+	{"device", "fw_maintenance", "JSON NULL"},
+}
+
+// migrateColumns adds the missing columns in addedColumns to existing tables
+func migrateColumns(db *sql.DB, driver string) error {
+	for _, c := range addedColumns {
+		probe, err := db.Query(fmt.Sprintf("SELECT %s FROM %s WHERE 1 = 0", c.column, c.table))
+		if err == nil {
+			probe.Close()
+			continue
+		}
+		logging.Info("Adding column %s to table %s", c.column, c.table)
+		alter := NewSchema(driver, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.column, c.definition))
+		if err := alter.Create(db); err != nil {
+			return fmt.Errorf("unable to add column %s to table %s: %v", c.column, c.table, err)
+		}
+	}
+	return nil
+}
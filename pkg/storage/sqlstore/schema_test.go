@@ -14,7 +14,15 @@ package sqlstore
 //See the License for the specific language governing permissions and
 //limitations under the License.
 //
-import "testing"
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
 
 func TestSchemaDDL(t *testing.T) {
 	_, err := NewSQLStore("sqlite3", ":memory:", true, 1, 1)
@@ -30,3 +38,111 @@ func TestSchemaDump(t *testing.T) {
 	s := NewSchema("sqlite3", DBSchema+"\n"+DBAPNSchema)
 	s.DDL()
 }
+
+// The firmware, collection and device tables before columns were added
+const legacyColumnSchema = `
+CREATE TABLE firmware (
+	firmware_id   BIGINT       NOT NULL,
+	filename      VARCHAR(128) NOT NULL,
+	version       VARCHAR(128) NOT NULL,
+	length        INT          NOT NULL,
+	sha256        VARCHAR(64)  NOT NULL,
+	created       DATETIME     NOT NULL,
+	collection_id BIGINT       NOT NULL,
+	tags          JSON         NULL,
+
+	CONSTRAINT firmware_pk PRIMARY KEY (firmware_id)
+);
+
+CREATE TABLE collection (
+	collection_id      BIGINT  NOT NULL,
+	field_mask         INT     NOT NULL DEFAULT 0,
+	team_id            BIGINT  NOT NULL,
+	fw_current_version BIGINT  NULL,
+	fw_target_version  BIGINT  NULL,
+	fw_management      SMALLINT NOT NULL DEFAULT 32,
+	tags               JSON    NULL,
+
+	CONSTRAINT collection_pk PRIMARY KEY (collection_id)
+);
+
+CREATE TABLE device (
+	device_id          BIGINT       NOT NULL,
+	imei               BIGINT       NOT NULL,
+	imsi               BIGINT       NOT NULL,
+	collection_id      BIGINT       NOT NULL,
+	tags               JSON         NULL,
+	net_apn_id         INT          NULL,
+	net_nas_id         INT          NULL,
+	net_allocated_ip   VARCHAR(32)  NULL,
+	net_allocated_at   DATETIME     NULL,
+	net_cell_id        BIGINT       NULL,
+	fw_current_version BIGINT       NULL,
+	fw_target_version  BIGINT       NULL,
+	fw_serial_number   VARCHAR(64)  NULL,
+	fw_model_number    VARCHAR(64)  NULL,
+	fw_manufacturer    VARCHAR(64)  NULL,
+	fw_version         VARCHAR(64)  NULL,
+	fw_state           CHAR(1)      NOT NULL DEFAULT 'i',
+	fw_state_message   VARCHAR(128) NOT NULL DEFAULT '',
+
+	CONSTRAINT device_pk PRIMARY KEY (device_id)
+);
+
+INSERT INTO firmware (firmware_id, filename, version, length, sha256, created, collection_id)
+	VALUES (1, 'image.bin', '1.0.0', 100, 'abc', '2020-01-01 00:00:00', 2);
+INSERT INTO collection (collection_id, team_id) VALUES (2, 3);
+INSERT INTO device (device_id, imei, imsi, collection_id) VALUES (4, 5, 6, 2);
+`
+
+func TestColumnMigration(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "columnmigration")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	dbFile := filepath.Join(dir, "columns.db")
+
+	db, err := sql.Open("sqlite3", dbFile)
+	assert.NoError(err)
+	defer db.Close()
+	assert.NoError(NewSchema("sqlite3", legacyColumnSchema).Create(db))
+
+	// The columns are added with default values for existing rows and the
+	// second migration is a no-op.
+	for i := 0; i < 2; i++ {
+		assert.NoError(migrateColumns(db, "sqlite3"))
+
+		var signature []byte
+		var keyID int64
+		assert.NoError(db.QueryRow(`SELECT signature, signing_key_id FROM firmware WHERE firmware_id = 1`).Scan(&signature, &keyID))
+		assert.Nil(signature)
+		assert.Equal(int64(0), keyID)
+
+		var manufacturer, modelPattern, minVersion string
+		assert.NoError(db.QueryRow(`SELECT compat_manufacturer, compat_model_pattern, compat_min_version FROM firmware WHERE firmware_id = 1`).Scan(&manufacturer, &modelPattern, &minVersion))
+		assert.Equal("", manufacturer)
+		assert.Equal("", modelPattern)
+		assert.Equal("", minVersion)
+
+		var requireSignature bool
+		assert.NoError(db.QueryRow(`SELECT fw_require_signature FROM collection WHERE collection_id = 2`).Scan(&requireSignature))
+		assert.False(requireSignature)
+
+		var collectionMaintenance, deviceMaintenance sql.NullString
+		assert.NoError(db.QueryRow(`SELECT fw_maintenance FROM collection WHERE collection_id = 2`).Scan(&collectionMaintenance))
+		assert.False(collectionMaintenance.Valid)
+		assert.NoError(db.QueryRow(`SELECT fw_maintenance FROM device WHERE device_id = 4`).Scan(&deviceMaintenance))
+		assert.False(deviceMaintenance.Valid)
+	}
+
+	// The store can be opened on the migrated tables
+	store, err := NewSQLStore("sqlite3", dbFile, true, 1, 1)
+	assert.NoError(err)
+	defer SQLConnection(store).Close()
+	cfg, err := store.RetrieveFirmwareConfig(2, 4)
+	assert.NoError(err)
+	assert.True(cfg.CollectionMaintenance.IsEmpty())
+	assert.True(cfg.DeviceMaintenance.IsEmpty())
+	assert.False(cfg.RequireSignature)
+}
@@ -0,0 +1,143 @@
+package sqlstore
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"database/sql"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+type signingKeyStatements struct {
+	insert           *sql.Stmt
+	retrieve         *sql.Stmt
+	list             *sql.Stmt
+	delete           *sql.Stmt
+	deleteCollection *sql.Stmt
+}
+
+func (s *sqlStore) initSigningKeyStatements() error {
+	var err error
+	if s.signingKeyStatements.insert, err = s.db.Prepare(`
+		INSERT INTO signing_key (key_id, collection_id, algorithm, public_key, private_key, created)
+			VALUES ($1, $2, $3, $4, $5, $6)`); err != nil {
+		return err
+	}
+	if s.signingKeyStatements.retrieve, err = s.db.Prepare(`
+		SELECT k.key_id, k.collection_id, k.algorithm, k.public_key, k.private_key, k.created
+			FROM signing_key k, collection c, member m
+			WHERE k.collection_id = c.collection_id AND
+				c.team_id = m.team_id AND
+				m.user_id = $1 AND
+				c.collection_id = $2 AND
+				k.key_id = $3`); err != nil {
+		return err
+	}
+	if s.signingKeyStatements.list, err = s.db.Prepare(`
+		SELECT k.key_id, k.collection_id, k.algorithm, k.public_key, k.private_key, k.created
+			FROM signing_key k, collection c, member m
+			WHERE k.collection_id = c.collection_id AND
+				c.team_id = m.team_id AND
+				m.user_id = $1 AND
+				c.collection_id = $2
+			ORDER BY k.key_id`); err != nil {
+		return err
+	}
+	if s.signingKeyStatements.delete, err = s.db.Prepare(`
+		DELETE FROM signing_key WHERE collection_id = $1 AND key_id = $2`); err != nil {
+		return err
+	}
+	if s.signingKeyStatements.deleteCollection, err = s.db.Prepare(`
+		DELETE FROM signing_key WHERE collection_id = $1`); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *sqlStore) NewSigningKeyID() model.SigningKeyKey {
+	return model.SigningKeyKey(s.signingKeyGen.NewID())
+}
+
+func (s *sqlStore) CreateSigningKey(userID model.UserKey, key model.SigningKey) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, key.CollectionID, model.ManageFirmwarePermission); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Stmt(s.signingKeyStatements.insert).Exec(key.ID, key.CollectionID,
+		key.Algorithm, key.PublicKey, key.PrivateKey, key.Created); err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (s *sqlStore) RetrieveSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) (model.SigningKey, error) {
+	var ret model.SigningKey
+	if err := s.signingKeyStatements.retrieve.QueryRow(userID, collectionID, keyID).Scan(
+		&ret.ID, &ret.CollectionID, &ret.Algorithm, &ret.PublicKey, &ret.PrivateKey, &ret.Created); err != nil {
+		if err == sql.ErrNoRows {
+			return model.SigningKey{}, storage.ErrNotFound
+		}
+		return model.SigningKey{}, err
+	}
+	return ret, nil
+}
+
+func (s *sqlStore) ListSigningKeys(userID model.UserKey, collectionID model.CollectionKey) ([]model.SigningKey, error) {
+	rows, err := s.signingKeyStatements.list.Query(userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]model.SigningKey, 0)
+	for rows.Next() {
+		var key model.SigningKey
+		if err := rows.Scan(&key.ID, &key.CollectionID, &key.Algorithm, &key.PublicKey, &key.PrivateKey, &key.Created); err != nil {
+			return nil, err
+		}
+		ret = append(ret, key)
+	}
+	return ret, rows.Err()
+}
+
+func (s *sqlStore) DeleteSigningKey(userID model.UserKey, collectionID model.CollectionKey, keyID model.SigningKeyKey) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := s.utils.EnsureAdminOfCollection(tx, userID, collectionID, model.ManageFirmwarePermission); err != nil {
+		tx.Rollback()
+		return err
+	}
+	result, err := tx.Stmt(s.signingKeyStatements.delete).Exec(collectionID, keyID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if count, err := result.RowsAffected(); err != nil || count == 0 {
+		tx.Rollback()
+		return storage.ErrNotFound
+	}
+	tx.Commit()
+	return nil
+}
@@ -46,6 +46,9 @@ type sqlStore struct {
 	deviceKeyGen         *storage.KeyGenerator
 	outputKeyGen         *storage.KeyGenerator
 	firmwareKeyGen       *storage.KeyGenerator
+	auditKeyGen          *storage.KeyGenerator
+	signingKeyGen        *storage.KeyGenerator
+	fwHistoryKeyGen      *storage.KeyGenerator
 	tokenStatements      tokenStatements
 	userStatements       userStatements
 	inviteStatements     inviteStatements
@@ -56,6 +59,12 @@ type sqlStore struct {
 	outputStatements     outputStatements
 	utils                InternalLookups
 	firmwareStatements   firmwareStatements
+	quotaStatements      quotaStatements
+	meteringStatements   meteringStatements
+	auditStatements      auditStatements
+	signingKeyStatements signingKeyStatements
+	fwHistoryStatements  firmwareHistoryStatements
+	postgres             bool
 }
 
 // SQLConnection returns the internal *sql.DB connection used by the data store.
@@ -92,7 +101,7 @@ func SetInternalLookups(store storage.DataStore, newLookups InternalLookups) err
 
 // NewSQLStoreWithConnection creates a DataStore instance with an existing sql.DB connection.
 func NewSQLStoreWithConnection(db *sql.DB, dataCenterID uint8, workerID uint16) (storage.DataStore, error) {
-	ret := &sqlStore{db: db, utils: NewInternalLookup()}
+	ret := &sqlStore{db: db, utils: NewInternalLookup(), postgres: isPostgres(db)}
 
 	ret.userKeyGen = storage.NewKeyGenerator(dataCenterID, workerID, "user", ret)
 	ret.userKeyGen.Start()
@@ -112,6 +121,15 @@ func NewSQLStoreWithConnection(db *sql.DB, dataCenterID uint8, workerID uint16)
 	ret.firmwareKeyGen = storage.NewKeyGenerator(dataCenterID, workerID, "fw", ret)
 	ret.firmwareKeyGen.Start()
 
+	ret.auditKeyGen = storage.NewKeyGenerator(dataCenterID, workerID, "audit", ret)
+	ret.auditKeyGen.Start()
+
+	ret.signingKeyGen = storage.NewKeyGenerator(dataCenterID, workerID, "sigkey", ret)
+	ret.signingKeyGen.Start()
+
+	ret.fwHistoryKeyGen = storage.NewKeyGenerator(dataCenterID, workerID, "fwhistory", ret)
+	ret.fwHistoryKeyGen.Start()
+
 	if err := ret.initTokenStatements(); err != nil {
 		return nil, fmt.Errorf("error preparing token statements: %v", err)
 	}
@@ -139,6 +157,21 @@ func NewSQLStoreWithConnection(db *sql.DB, dataCenterID uint8, workerID uint16)
 	if err := ret.initFirmwareStatements(); err != nil {
 		return nil, fmt.Errorf("error preparing firmware statements: %v", err)
 	}
+	if err := ret.initQuotaStatements(); err != nil {
+		return nil, fmt.Errorf("error preparing quota statements: %v", err)
+	}
+	if err := ret.initMeteringStatements(); err != nil {
+		return nil, fmt.Errorf("error preparing metering statements: %v", err)
+	}
+	if err := ret.initAuditStatements(); err != nil {
+		return nil, fmt.Errorf("error preparing audit statements: %v", err)
+	}
+	if err := ret.initSigningKeyStatements(); err != nil {
+		return nil, fmt.Errorf("error preparing signing key statements: %v", err)
+	}
+	if err := ret.initFirmwareHistoryStatements(); err != nil {
+		return nil, fmt.Errorf("error preparing firmware history statements: %v", err)
+	}
 
 	if err := ret.utils.Prepare(ret.db); err != nil {
 		return nil, fmt.Errorf("error preparing util statements: %v", err)
@@ -165,6 +198,12 @@ func NewSQLStore(driver string, connectionString string, create bool, dataCenter
 			return nil, err
 		}
 	}
+	if err := migrateTokens(db); err != nil {
+		return nil, err
+	}
+	if err := migrateColumns(db, driver); err != nil {
+		return nil, err
+	}
 
 	return NewSQLStoreWithConnection(db, dataCenterID, workerID)
 }
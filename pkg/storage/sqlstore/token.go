@@ -18,7 +18,9 @@ package sqlstore
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ExploratoryEngineering/logging"
 	"github.com/eesrc/horde/pkg/model"
@@ -32,6 +34,7 @@ type tokenStatements struct {
 	delete      *sql.Stmt
 	retrieveTag *sql.Stmt
 	updateTag   *sql.Stmt
+	updateUsage *sql.Stmt
 	retrieve    *sql.Stmt
 }
 
@@ -39,21 +42,21 @@ func (s *sqlStore) initTokenStatements() error {
 	var err error
 	if s.tokenStatements.insert, err = s.db.Prepare(`
 		INSERT INTO token (
-			token, resource, user_id, write, tags)
-			VALUES ($1, $2, $3, $4, $5)`); err != nil {
+			token, resource, user_id, write, tags, created, expires, last_used, last_used_ip)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`); err != nil {
 		return err
 	}
 	if s.tokenStatements.list, err = s.db.Prepare(`
 		SELECT
-			token, resource, user_id, write, tags
+			token, resource, user_id, write, tags, created, expires, last_used, last_used_ip
 			FROM token
 			WHERE user_id = $1`); err != nil {
 		return err
 	}
 	if s.tokenStatements.update, err = s.db.Prepare(`
 		UPDATE token
-			SET resource = $1, write = $2, tags = $3
-			WHERE token = $4`); err != nil {
+			SET resource = $1, write = $2, tags = $3, expires = $4
+			WHERE token = $5`); err != nil {
 		return err
 	}
 	if s.tokenStatements.delete, err = s.db.Prepare(`
@@ -74,8 +77,14 @@ func (s *sqlStore) initTokenStatements() error {
 			WHERE user_id = $2 AND token = $3`); err != nil {
 		return err
 	}
+	if s.tokenStatements.updateUsage, err = s.db.Prepare(`
+		UPDATE token
+			SET last_used = $1, last_used_ip = $2
+			WHERE token = $3`); err != nil {
+		return err
+	}
 	if s.tokenStatements.retrieve, err = s.db.Prepare(`
-		SELECT token, resource, user_id, write, tags
+		SELECT token, resource, user_id, write, tags, created, expires, last_used, last_used_ip
 			FROM token
 			WHERE token = $1`); err != nil {
 		return err
@@ -83,9 +92,43 @@ func (s *sqlStore) initTokenStatements() error {
 	return nil
 }
 
+// The timestamps for tokens are stored as nanoseconds since epoch with 0 as
+// the zero value.
+func tokenTimeToNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func tokenNanosToTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+func (s *sqlStore) readToken(r rowScanner) (model.Token, error) {
+	token := model.NewToken()
+	var created, expires, lastUsed int64
+	if err := r.Scan(&token.ID, &token.Resource, &token.UserID, &token.Write,
+		&token.TagMap, &created, &expires, &lastUsed, &token.LastUsedIP); err != nil {
+		return token, err
+	}
+	token.Created = tokenNanosToTime(created)
+	token.Expires = tokenNanosToTime(expires)
+	token.LastUsed = tokenNanosToTime(lastUsed)
+	return token, nil
+}
+
 func (s *sqlStore) CreateToken(token model.Token) error {
+	if token.ID == "" {
+		return errors.New("token ID is not set")
+	}
 	_, err := s.tokenStatements.insert.Exec(
-		token.Token, token.Resource, token.UserID, token.Write, token.TagMap)
+		token.ID, token.Resource, token.UserID, token.Write, token.TagMap,
+		tokenTimeToNanos(token.Created), tokenTimeToNanos(token.Expires),
+		tokenTimeToNanos(token.LastUsed), token.LastUsedIP)
 	if err != nil {
 		logging.Warning("Unable to create token: %v", err)
 		if strings.Contains(err.Error(), "constraint") {
@@ -103,8 +146,8 @@ func (s *sqlStore) ListTokens(userID model.UserKey) ([]model.Token, error) {
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var token model.Token
-		if err := rows.Scan(&token.Token, &token.Resource, &token.UserID, &token.Write, &token.TagMap); err != nil {
+		token, err := s.readToken(rows)
+		if err != nil {
 			return nil, err
 		}
 		tokens = append(tokens, token)
@@ -113,7 +156,8 @@ func (s *sqlStore) ListTokens(userID model.UserKey) ([]model.Token, error) {
 }
 
 func (s *sqlStore) UpdateToken(token model.Token) error {
-	result, err := s.tokenStatements.update.Exec(token.Resource, token.Write, token.TagMap, token.Token)
+	result, err := s.tokenStatements.update.Exec(token.Resource, token.Write, token.TagMap,
+		tokenTimeToNanos(token.Expires), token.ID)
 	if err != nil {
 		return err
 	}
@@ -123,8 +167,8 @@ func (s *sqlStore) UpdateToken(token model.Token) error {
 	return nil
 }
 
-func (s *sqlStore) DeleteToken(userID model.UserKey, token string) error {
-	result, err := s.tokenStatements.delete.Exec(token, userID)
+func (s *sqlStore) DeleteToken(userID model.UserKey, tokenID string) error {
+	result, err := s.tokenStatements.delete.Exec(tokenID, userID)
 	if err != nil {
 		return err
 	}
@@ -134,9 +178,9 @@ func (s *sqlStore) DeleteToken(userID model.UserKey, token string) error {
 	return nil
 }
 
-func (s *sqlStore) RetrieveTokenTags(userID model.UserKey, token string) (model.Tags, error) {
+func (s *sqlStore) RetrieveTokenTags(userID model.UserKey, tokenID string) (model.Tags, error) {
 	ret := model.NewTags()
-	row := s.tokenStatements.retrieveTag.QueryRow(token, userID)
+	row := s.tokenStatements.retrieveTag.QueryRow(tokenID, userID)
 	if row == nil {
 		return ret, errors.New("unable to query for tokens")
 	}
@@ -147,8 +191,19 @@ func (s *sqlStore) RetrieveTokenTags(userID model.UserKey, token string) (model.
 	return ret, err
 }
 
-func (s *sqlStore) UpdateTokenTags(userID model.UserKey, token string, tags model.Tags) error {
-	res, err := s.tokenStatements.updateTag.Exec(tags.TagMap, userID, token)
+func (s *sqlStore) UpdateTokenTags(userID model.UserKey, tokenID string, tags model.Tags) error {
+	res, err := s.tokenStatements.updateTag.Exec(tags.TagMap, userID, tokenID)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err != nil || count == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (s *sqlStore) UpdateTokenUsage(tokenID string, lastUsed time.Time, ip string) error {
+	res, err := s.tokenStatements.updateUsage.Exec(tokenTimeToNanos(lastUsed), ip, tokenID)
 	if err != nil {
 		return err
 	}
@@ -158,17 +213,70 @@ func (s *sqlStore) UpdateTokenTags(userID model.UserKey, token string, tags mode
 	return nil
 }
 
-func (s *sqlStore) RetrieveToken(tokenString string) (model.Token, error) {
-	var token model.Token
-	if err := s.tokenStatements.retrieve.QueryRow(tokenString).Scan(
-		&token.Token, &token.Resource, &token.UserID,
-		&token.Write, &token.TagMap); err != nil {
-		if err == sql.ErrNoRows {
-			return token, storage.ErrNotFound
+func (s *sqlStore) RetrieveToken(tokenID string) (model.Token, error) {
+	token, err := s.readToken(s.tokenStatements.retrieve.QueryRow(tokenID))
+	if err == sql.ErrNoRows {
+		return token, storage.ErrNotFound
+	}
+	return token, err
+}
+
+// tokenMigrationColumns are the columns added to the token table when the
+// tokens were changed to hashes
+var tokenMigrationColumns = []string{
+	"created BIGINT NOT NULL DEFAULT 0",
+	"expires BIGINT NOT NULL DEFAULT 0",
+	"last_used BIGINT NOT NULL DEFAULT 0",
+	"last_used_ip VARCHAR(64) NOT NULL DEFAULT ''",
+}
+
+// migrateTokens upgrades token tables with plain text tokens. The new columns
+// are added and the tokens are replaced by their hashes in a single
+// transaction. Tables with the created column are already migrated.
+func migrateTokens(db *sql.DB) error {
+	probe, err := db.Query(`SELECT created FROM token WHERE 1 = 0`)
+	if err == nil {
+		probe.Close()
+		return nil
+	}
+	logging.Info("Migrating token table to hashed tokens")
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := migrateTokensTx(tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("unable to migrate token table: %v", err)
+	}
+	return tx.Commit()
+}
+
+func migrateTokensTx(tx *sql.Tx) error {
+	for _, v := range tokenMigrationColumns {
+		if _, err := tx.Exec("ALTER TABLE token ADD COLUMN " + v); err != nil {
+			return err
 		}
-		if err != nil {
-			return token, err
+	}
+	rows, err := tx.Query(`SELECT token FROM token`)
+	if err != nil {
+		return err
+	}
+	var tokens []string
+	for rows.Next() {
+		var token string
+		if err := rows.Scan(&token); err != nil {
+			rows.Close()
+			return err
 		}
+		tokens = append(tokens, token)
 	}
-	return token, nil
+	rows.Close()
+
+	for _, v := range tokens {
+		if _, err := tx.Exec(`UPDATE token SET token = $1 WHERE token = $2`, model.TokenID(v), v); err != nil {
+			return err
+		}
+	}
+	logging.Info("Migrated %d tokens", len(tokens))
+	return nil
 }
@@ -0,0 +1,68 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+// The token table before the tokens were hashed
+const legacyTokenSchema = `
+CREATE TABLE token (
+	token     VARCHAR(64)   NOT NULL,
+	resource  VARCHAR(128)  NOT NULL,
+	user_id   BIGINT        NOT NULL,
+	write     BOOL          NOT NULL DEFAULT FALSE,
+	tags      JSON          NULL,
+
+	CONSTRAINT token_pk PRIMARY KEY (token)
+)`
+
+func TestTokenMigration(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "tokenmigration")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	dbFile := filepath.Join(dir, "tokens.db")
+
+	db, err := sql.Open("sqlite3", dbFile)
+	assert.NoError(err)
+	_, err = db.Exec(legacyTokenSchema)
+	assert.NoError(err)
+	insert := `INSERT INTO token (token, resource, user_id, write, tags) VALUES ($1, $2, 1, $3, $4)`
+	_, err = db.Exec(insert, "plain1", "/", true, []byte("{}"))
+	assert.NoError(err)
+	_, err = db.Exec(insert, "plain2", "/collections", false, []byte("{}"))
+	assert.NoError(err)
+	assert.NoError(db.Close())
+
+	// The store migrates the table when it is opened and the second time it
+	// is a no-op.
+	for i := 0; i < 2; i++ {
+		store, err := NewSQLStore("sqlite3", dbFile, true, 1, 1)
+		assert.NoError(err)
+
+		_, err = store.RetrieveToken("plain1")
+		assert.Equal(storage.ErrNotFound, err)
+
+		token, err := store.RetrieveToken(model.TokenID("plain1"))
+		assert.NoError(err)
+		assert.Equal("/", token.Resource)
+		assert.True(token.Write)
+		assert.True(token.Expires.IsZero())
+
+		token, err = store.RetrieveToken(model.TokenID("plain2"))
+		assert.NoError(err)
+		assert.Equal("/collections", token.Resource)
+		assert.False(token.Write)
+
+		assert.NoError(SQLConnection(store).Close())
+	}
+}
@@ -17,6 +17,8 @@ package sqlstore
 //
 import (
 	"database/sql"
+	"strconv"
+	"strings"
 
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
@@ -115,7 +117,7 @@ func (u *utilStatements) EnsureAdminOfTeam(tx *sql.Tx, userID model.UserKey, tea
 	return nil
 }
 
-func (u *utilStatements) EnsureAdminOfCollection(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey) (model.TeamKey, error) {
+func (u *utilStatements) EnsureAdminOfCollection(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, p model.Permission) (model.TeamKey, error) {
 	var teamID model.TeamKey
 	var role model.RoleID
 	if err := tx.Stmt(u.adminOfCollection).QueryRow(collectionID, userID).Scan(&teamID, &role); err != nil {
@@ -125,13 +127,13 @@ func (u *utilStatements) EnsureAdminOfCollection(tx *sql.Tx, userID model.UserKe
 		}
 		return 0, err
 	}
-	if role != model.AdminRole {
+	if !role.Allows(p) {
 		return teamID, storage.ErrAccess
 	}
 	return teamID, nil
 }
 
-func (u *utilStatements) EnsureAdminOfDevice(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey) error {
+func (u *utilStatements) EnsureAdminOfDevice(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, deviceID model.DeviceKey, p model.Permission) error {
 	var role model.RoleID
 	if err := tx.Stmt(u.adminOfDevice).QueryRow(deviceID, collectionID, userID).Scan(&role); err != nil {
 		tx.Rollback()
@@ -140,13 +142,13 @@ func (u *utilStatements) EnsureAdminOfDevice(tx *sql.Tx, userID model.UserKey, c
 		}
 		return err
 	}
-	if role != model.AdminRole {
+	if !role.Allows(p) {
 		return storage.ErrAccess
 	}
 	return nil
 }
 
-func (u *utilStatements) EnsureAdminOfOutput(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, outputID model.OutputKey) error {
+func (u *utilStatements) EnsureAdminOfOutput(tx *sql.Tx, userID model.UserKey, collectionID model.CollectionKey, outputID model.OutputKey, p model.Permission) error {
 	var role model.RoleID
 	if err := tx.Stmt(u.adminOfOutput).QueryRow(outputID, collectionID, userID).Scan(&role); err != nil {
 		tx.Rollback()
@@ -155,7 +157,7 @@ func (u *utilStatements) EnsureAdminOfOutput(tx *sql.Tx, userID model.UserKey, c
 		}
 		return err
 	}
-	if role != model.AdminRole {
+	if !role.Allows(p) {
 		return storage.ErrAccess
 	}
 	return nil
@@ -171,7 +173,7 @@ func (u *utilStatements) EnsureNotPrivateTeam(tx *sql.Tx, teamID model.TeamKey)
 	return nil
 }
 
-func (u *utilStatements) EnsureAdminOfFirmware(tx *sql.Tx, userID model.UserKey, fwID model.FirmwareKey) error {
+func (u *utilStatements) EnsureAdminOfFirmware(tx *sql.Tx, userID model.UserKey, fwID model.FirmwareKey, p model.Permission) error {
 	var role model.RoleID
 	if err := tx.Stmt(u.adminOfFirmware).QueryRow(fwID, userID).Scan(&role); err != nil {
 		tx.Rollback()
@@ -180,7 +182,7 @@ func (u *utilStatements) EnsureAdminOfFirmware(tx *sql.Tx, userID model.UserKey,
 		}
 		return err
 	}
-	if role != model.AdminRole {
+	if !role.Allows(p) {
 		return storage.ErrAccess
 	}
 	return nil
@@ -203,3 +205,14 @@ func (u *utilStatements) EnsureCollectionFirmware(tx *sql.Tx, collectionID model
 	}
 	return nil
 }
+
+// rolesWithPermission returns the role IDs that grant the permission as a
+// list for SQL IN clauses. The statements that check the role directly use
+// this to follow the policy table in the model package.
+func rolesWithPermission(p model.Permission) string {
+	var ids []string
+	for _, r := range model.RolesWithPermission(p) {
+		ids = append(ids, strconv.Itoa(int(r)))
+	}
+	return strings.Join(ids, ", ")
+}
@@ -0,0 +1,108 @@
+package storetest
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// testAuditStore runs tests on the audit trail
+func testAuditStore(e TestEnvironment, s storage.DataStore, t *testing.T) {
+	events, err := s.ListAuditEvents(e.T3.ID, 0, 10)
+	if err != nil {
+		t.Fatal("Unable to list audit events: ", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Expected no events but got %d", len(events))
+	}
+
+	now := time.Now()
+	var ids []model.AuditKey
+	for i := 0; i < 5; i++ {
+		event := model.AuditEvent{
+			ID:           s.NewAuditEventID(),
+			Time:         now.Add(time.Duration(i) * time.Second),
+			TeamID:       e.T3.ID,
+			Source:       model.AuditSourceAPI,
+			UserID:       e.U3.ID,
+			AuthMethod:   model.AuthGitHub,
+			Action:       "UpdateDevice",
+			ResourceType: "device",
+			ResourceID:   "/collections/" + e.C3.ID.String() + "/devices/1",
+			Before:       `{"name":"a"}`,
+			After:        `{"name":"b"}`,
+		}
+		if err := s.CreateAuditEvent(event); err != nil {
+			t.Fatal("Unable to create audit event: ", err)
+		}
+		ids = append(ids, event.ID)
+	}
+	// Events for other teams shouldn't be listed
+	if err := s.CreateAuditEvent(model.AuditEvent{
+		ID:         s.NewAuditEventID(),
+		Time:       now,
+		TeamID:     e.T1.ID,
+		Source:     model.AuditSourceManagement,
+		Action:     "SetTeamQuota",
+		AuthMethod: model.AuthNone,
+	}); err != nil {
+		t.Fatal("Unable to create audit event: ", err)
+	}
+
+	events, err = s.ListAuditEvents(e.T3.ID, 0, 3)
+	if err != nil {
+		t.Fatal("Unable to list audit events: ", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events but got %d", len(events))
+	}
+	if events[0].ID != ids[4] || events[2].ID != ids[2] {
+		t.Fatalf("Expected the newest events first: %+v", events)
+	}
+	first := events[0]
+	if first.UserID != e.U3.ID || first.AuthMethod != model.AuthGitHub || first.Before != `{"name":"a"}` ||
+		first.After != `{"name":"b"}` || first.Source != model.AuditSourceAPI || !first.Time.Equal(now.Add(4*time.Second)) {
+		t.Fatalf("Event isn't stored correctly: %+v", first)
+	}
+
+	events, err = s.ListAuditEvents(e.T3.ID, events[2].ID, 3)
+	if err != nil {
+		t.Fatal("Unable to list the next page of audit events: ", err)
+	}
+	if len(events) != 2 || events[0].ID != ids[1] || events[1].ID != ids[0] {
+		t.Fatalf("Unexpected second page: %+v", events)
+	}
+
+	if _, err := s.ListAuditEvents(e.T1.ID, ids[0], 3); err != storage.ErrNotFound {
+		t.Fatal("Expected ErrNotFound when paging from another team's event but got ", err)
+	}
+
+	removed, err := s.DeleteAuditEvents(now.Add(2 * time.Second))
+	if err != nil {
+		t.Fatal("Unable to remove audit events: ", err)
+	}
+	if removed != 3 {
+		t.Fatalf("Expected 3 removed events but got %d", removed)
+	}
+	events, err = s.ListAuditEvents(e.T3.ID, 0, 10)
+	if err != nil || len(events) != 3 {
+		t.Fatalf("Expected 3 remaining events but got %d (err=%v)", len(events), err)
+	}
+}
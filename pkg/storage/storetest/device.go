@@ -375,3 +375,57 @@ func testStateUpdate(t *testing.T, s storage.DataStore, env TestEnvironment, d m
 
 	assert.Error(storage.ErrNotFound, s.UpdateFirmwareStateForDevice(-1, model.Initializing, ""))
 }
+
+// testRolePermissions checks that the updates are limited by the role's
+// permissions and not just the team membership.
+func testRolePermissions(env TestEnvironment, s storage.DataStore, t *testing.T) {
+	team := model.NewTeam()
+	team.ID = s.NewTeamID()
+	team.AddMember(model.NewMember(env.U1, model.AdminRole))
+	team.AddMember(model.NewMember(env.U2, model.OperatorRole))
+	if err := s.CreateTeam(team); err != nil {
+		t.Fatal("Unable to create team: ", err)
+	}
+
+	c := model.NewCollection()
+	c.ID = s.NewCollectionID()
+	c.TeamID = team.ID
+	if err := s.CreateCollection(env.U1.ID, c); err != nil {
+		t.Fatal("Unable to create collection: ", err)
+	}
+
+	d := model.NewDevice()
+	d.ID = s.NewDeviceID()
+	d.IMSI = int64(d.ID)
+	d.IMEI = int64(d.ID)
+	d.CollectionID = c.ID
+	if err := s.CreateDevice(env.U1.ID, d); err != nil {
+		t.Fatal("Unable to create device: ", err)
+	}
+
+	// Operators can manage devices...
+	d.SetTag("name", "operator device")
+	if err := s.UpdateDevice(env.U2.ID, c.ID, d); err != nil {
+		t.Fatal("Operator should be able to update the device: ", err)
+	}
+
+	// ...but not the firmware on the device...
+	fwDevice := d
+	fwDevice.Firmware.TargetFirmwareID = s.NewFirmwareID()
+	if err := s.UpdateDevice(env.U2.ID, c.ID, fwDevice); err != storage.ErrAccess {
+		t.Fatal("Operator should not be able to change the device firmware: ", err)
+	}
+
+	// ...or the collection
+	c.SetTag("name", "operator collection")
+	if err := s.UpdateCollection(env.U2.ID, c); err != storage.ErrAccess {
+		t.Fatal("Operator should not be able to update the collection: ", err)
+	}
+
+	if err := s.DeleteDevice(env.U1.ID, c.ID, d.ID); err != nil {
+		t.Fatal("Unable to remove device: ", err)
+	}
+	if err := s.DeleteCollection(env.U1.ID, c.ID); err != nil {
+		t.Fatal("Unable to remove collection: ", err)
+	}
+}
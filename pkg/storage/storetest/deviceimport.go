@@ -0,0 +1,124 @@
+package storetest
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"errors"
+	"testing"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+func newImportDevice(imsi int64, tags map[string]string) model.Device {
+	d := model.NewDevice()
+	d.IMSI = imsi
+	d.IMEI = imsi
+	d.SetTags(tags)
+	return d
+}
+
+// testDeviceImport runs tests on the bulk device import
+func testDeviceImport(e TestEnvironment, s storage.DataStore, t *testing.T) {
+	coll := model.NewCollection()
+	coll.ID = s.NewCollectionID()
+	coll.TeamID = e.T1.ID
+	if err := s.CreateCollection(e.U1.ID, coll); err != nil {
+		t.Fatal("Unable to create collection for import tests: ", err)
+	}
+
+	withID := newImportDevice(710001, map[string]string{"name": "with id"})
+	withID.ID = s.NewDeviceID()
+	devices := []model.Device{
+		newImportDevice(710002, map[string]string{"name": "a"}),
+		newImportDevice(710003, map[string]string{"name": "b"}),
+		withID,
+	}
+	res, err := s.ImportDevices(e.U1.ID, coll.ID, devices, false, nil)
+	if err != nil || len(res.Errors) > 0 {
+		t.Fatalf("Unable to import devices: %v %+v", err, res.Errors)
+	}
+	if len(res.Created) != 3 || len(res.Updated) != 0 {
+		t.Fatalf("Expected 3 new devices but got %d created and %d updated", len(res.Created), len(res.Updated))
+	}
+	if res.Created[2].ID != withID.ID || res.Created[0].ID == 0 {
+		t.Fatal("Devices did not get the expected IDs")
+	}
+	list, err := s.ListDevices(e.U1.ID, coll.ID)
+	if err != nil || len(list) != 3 {
+		t.Fatalf("Expected 3 devices in collection but got %d (err=%v)", len(list), err)
+	}
+
+	// Importing the same devices again fails without upsert and nothing is
+	// imported.
+	devices = append(devices, newImportDevice(710004, nil))
+	res, err = s.ImportDevices(e.U1.ID, coll.ID, devices, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Errors) != 3 || res.Errors[0].Index != 0 || res.Errors[2].Index != 2 || len(res.Created) != 0 {
+		t.Fatalf("Expected errors for the existing devices but got %+v", res)
+	}
+	if _, err := s.RetrieveDeviceByIMSI(710004); err != storage.ErrNotFound {
+		t.Fatal("Expected the import to be rolled back but got ", err)
+	}
+
+	// Upsert updates the existing devices
+	devices[0].SetTag("name", "updated")
+	res, err = s.ImportDevices(e.U1.ID, coll.ID, devices, true, nil)
+	if err != nil || len(res.Errors) > 0 {
+		t.Fatalf("Unable to upsert devices: %v %+v", err, res.Errors)
+	}
+	if len(res.Created) != 1 || len(res.Updated) != 3 {
+		t.Fatalf("Expected 1 new and 3 updated devices but got %d and %d", len(res.Created), len(res.Updated))
+	}
+	d, err := s.RetrieveDeviceByIMSI(710002)
+	if err != nil || d.GetTag("name") != "updated" {
+		t.Fatalf("Device wasn't updated: %+v (err=%v)", d, err)
+	}
+
+	// The check aborts the import
+	errQuota := errors.New("quota")
+	res, err = s.ImportDevices(e.U1.ID, coll.ID, []model.Device{newImportDevice(710005, nil)}, false, func(created int) error {
+		if created != 1 {
+			t.Fatalf("Expected 1 new device in check but got %d", created)
+		}
+		return errQuota
+	})
+	if err != errQuota {
+		t.Fatal("Expected check error but got ", err)
+	}
+	if _, err := s.RetrieveDeviceByIMSI(710005); err != storage.ErrNotFound {
+		t.Fatal("Expected the import to be rolled back but got ", err)
+	}
+
+	// Devices in other collections can't be updated
+	other := newImportDevice(710006, nil)
+	other.ID = s.NewDeviceID()
+	other.CollectionID = e.C1.ID
+	if err := s.CreateDevice(e.U1.ID, other); err != nil {
+		t.Fatal(err)
+	}
+	res, err = s.ImportDevices(e.U1.ID, coll.ID, []model.Device{other}, true, nil)
+	if err != nil || len(res.Errors) != 1 {
+		t.Fatalf("Expected error for device in other collection but got %+v (err=%v)", res, err)
+	}
+
+	// Only administrators can import
+	if _, err := s.ImportDevices(e.U2.ID, coll.ID, []model.Device{newImportDevice(710007, nil)}, false, nil); err == nil {
+		t.Fatal("Expected error when importing to collection without access")
+	}
+}
@@ -35,6 +35,11 @@ func testFirmwareStore(e TestEnvironment, s storage.DataStore, t *testing.T) {
 		Length:       99,
 		Created:      time.Now(),
 		Tags:         model.NewTags(),
+		Compatibility: model.FirmwareCompatibility{
+			Manufacturer:   "EE",
+			ModelPattern:   "EE0*",
+			MinimumVersion: "1.0.0",
+		},
 	}
 	fw12 := model.Firmware{
 		ID:           s.NewFirmwareID(),
@@ -144,6 +149,7 @@ func testFirmwareStore(e TestEnvironment, s storage.DataStore, t *testing.T) {
 	// Update firmware image fw21
 	fw21.SetTag("name", "the new")
 	fw21.CollectionID = e.C2.ID
+	fw21.Compatibility.ModelPattern = "EE1*"
 	if err := s.UpdateFirmware(e.U2.ID, e.C21.ID, fw21); err != nil {
 		t.Fatal(err)
 	}
@@ -157,6 +163,9 @@ func testFirmwareStore(e TestEnvironment, s storage.DataStore, t *testing.T) {
 	if fwB.CollectionID != e.C2.ID {
 		t.Fatal("Team ID isn't set correctly")
 	}
+	if fwB.Compatibility != fw21.Compatibility {
+		t.Fatalf("Compatibility isn't updated: %+v", fwB.Compatibility)
+	}
 	fw12.CollectionID = e.C21.ID
 	if err := s.UpdateFirmware(e.U1.ID, e.C12.ID, fw12); err != storage.ErrAccess {
 		t.Fatal("Expected ErrAccess when attempting to transfer firmware to non-admin team but got ", err)
@@ -326,6 +335,7 @@ func testFirmwareConfig(t *testing.T, e TestEnvironment, s storage.DataStore) {
 	d.Firmware.CurrentFirmwareID = fwC.ID
 	d.Firmware.TargetFirmwareID = fwD.ID
 	d.CollectionID = e.C1.ID
+	d.Firmware.MaintenanceWindow = model.MaintenanceWindow{Schedule: "0 2 * * *", Duration: time.Hour, TimeZone: model.DeviceTimeZone}
 
 	if err := s.CreateDevice(e.U1.ID, d); err != nil {
 		t.Fatal(err)
@@ -334,9 +344,29 @@ func testFirmwareConfig(t *testing.T, e TestEnvironment, s storage.DataStore) {
 	e.C1.Firmware.CurrentFirmwareID = fwA.ID
 	e.C1.Firmware.TargetFirmwareID = fwB.ID
 	e.C1.Firmware.Management = model.CollectionManagement
+	e.C1.Firmware.MaintenanceWindow = model.MaintenanceWindow{Schedule: "0 22 * * 1-5", Duration: 8 * time.Hour}
 	if err := s.UpdateCollection(e.U1.ID, e.C1); err != nil {
 		t.Fatal(err)
 	}
+	defer func() {
+		e.C1.Firmware.MaintenanceWindow = model.MaintenanceWindow{}
+		s.UpdateCollection(e.U1.ID, e.C1)
+	}()
+
+	c, err := s.RetrieveCollection(e.U1.ID, e.C1.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Firmware.MaintenanceWindow != e.C1.Firmware.MaintenanceWindow {
+		t.Fatalf("Collection maintenance window is incorrect: %+v", c.Firmware.MaintenanceWindow)
+	}
+	rd, err := s.RetrieveDevice(e.U1.ID, e.C1.ID, d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rd.Firmware.MaintenanceWindow != d.Firmware.MaintenanceWindow {
+		t.Fatalf("Device maintenance window is incorrect: %+v", rd.Firmware.MaintenanceWindow)
+	}
 
 	cfg, err := s.RetrieveFirmwareConfig(e.C1.ID, d.ID)
 	if err != nil {
@@ -353,6 +383,22 @@ func testFirmwareConfig(t *testing.T, e TestEnvironment, s storage.DataStore) {
 	if cfg.Management != e.C1.Firmware.Management {
 		t.Fatal("Management setting is incorrect")
 	}
+	if cfg.CollectionMaintenance != e.C1.Firmware.MaintenanceWindow ||
+		cfg.DeviceMaintenance != d.Firmware.MaintenanceWindow {
+		t.Fatal("Maintenance windows are incorrect")
+	}
+
+	d.Firmware.MaintenanceWindow = model.MaintenanceWindow{}
+	if err := s.UpdateDevice(e.U1.ID, e.C1.ID, d); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = s.RetrieveFirmwareConfig(e.C1.ID, d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.DeviceMaintenance.IsEmpty() || cfg.MaintenanceWindow() != e.C1.Firmware.MaintenanceWindow {
+		t.Fatal("Expected collection maintenance window")
+	}
 }
 
 func testFirmwareInUse(t *testing.T, e TestEnvironment, s storage.DataStore) {
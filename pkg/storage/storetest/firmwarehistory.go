@@ -0,0 +1,132 @@
+package storetest
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// testFirmwareHistoryStore runs tests on the firmware history
+func testFirmwareHistoryStore(e TestEnvironment, s storage.DataStore, t *testing.T) {
+	deviceA := s.NewDeviceID()
+	deviceB := s.NewDeviceID()
+	fwA := s.NewFirmwareID()
+	fwB := s.NewFirmwareID()
+
+	if _, err := s.RetrieveLatestFirmwareHistory(deviceA); err != storage.ErrNotFound {
+		t.Fatal("Expected ErrNotFound for device without history but got ", err)
+	}
+
+	// Device A is updated from A to B, device B fails the update
+	now := time.Now()
+	transitions := []struct {
+		device   model.DeviceKey
+		previous model.DeviceFirmwareState
+		state    model.DeviceFirmwareState
+	}{
+		{deviceA, model.Current, model.Initializing},
+		{deviceA, model.Initializing, model.Downloading},
+		{deviceB, model.Current, model.Downloading},
+		{deviceA, model.Downloading, model.Completed},
+		{deviceB, model.Downloading, model.UpdateFailed},
+		{deviceA, model.Completed, model.Current},
+	}
+	var ids []model.FirmwareHistoryKey
+	for i, v := range transitions {
+		entry := model.FirmwareHistoryEntry{
+			ID:             s.NewFirmwareHistoryID(),
+			Time:           now.Add(time.Duration(i) * time.Second),
+			CollectionID:   e.C12.ID,
+			DeviceID:       v.device,
+			FromFirmwareID: fwA,
+			ToFirmwareID:   fwB,
+			PreviousState:  v.previous,
+			State:          v.state,
+			Message:        v.state.String(),
+			Duration:       time.Duration(i) * time.Millisecond,
+		}
+		if err := s.CreateFirmwareHistory(entry); err != nil {
+			t.Fatal("Unable to create firmware history: ", err)
+		}
+		ids = append(ids, entry.ID)
+	}
+
+	latest, err := s.RetrieveLatestFirmwareHistory(deviceA)
+	if err != nil {
+		t.Fatal("Unable to retrieve latest firmware history: ", err)
+	}
+	if latest.ID != ids[5] || latest.State != model.Current || latest.PreviousState != model.Completed ||
+		latest.FromFirmwareID != fwA || latest.ToFirmwareID != fwB || latest.Message != "Current" ||
+		latest.Duration != 5*time.Millisecond || !latest.Time.Equal(now.Add(5*time.Second)) ||
+		latest.CollectionID != e.C12.ID || latest.DeviceID != deviceA {
+		t.Fatalf("Entry isn't stored correctly: %+v", latest)
+	}
+
+	list, err := s.ListDeviceFirmwareHistory(e.U2.ID, e.C12.ID, deviceA, 0, 3)
+	if err != nil {
+		t.Fatal("Unable to list device firmware history: ", err)
+	}
+	if len(list) != 3 || list[0].ID != ids[5] || list[2].ID != ids[1] {
+		t.Fatalf("Expected the newest entries first: %+v", list)
+	}
+	list, err = s.ListDeviceFirmwareHistory(e.U2.ID, e.C12.ID, deviceA, list[2].ID, 3)
+	if err != nil {
+		t.Fatal("Unable to list the next page of device firmware history: ", err)
+	}
+	if len(list) != 1 || list[0].ID != ids[0] {
+		t.Fatalf("Unexpected second page: %+v", list)
+	}
+	if list, err := s.ListDeviceFirmwareHistory(e.U3.ID, e.C12.ID, deviceA, 0, 10); err != nil || len(list) != 0 {
+		t.Fatalf("Should not list history for other teams (err=%v, list=%+v)", err, list)
+	}
+	if _, err := s.ListDeviceFirmwareHistory(e.U1.ID, e.C1.ID, deviceA, ids[0], 10); err != storage.ErrNotFound {
+		t.Fatal("Expected ErrNotFound when paging from another collection's entry but got ", err)
+	}
+
+	list, err = s.ListFirmwareHistory(e.U1.ID, e.C12.ID, fwA, 0, 10)
+	if err != nil {
+		t.Fatal("Unable to list firmware history: ", err)
+	}
+	if len(list) != 6 {
+		t.Fatalf("Expected 6 entries but got %d", len(list))
+	}
+	list, err = s.ListFirmwareHistory(e.U1.ID, e.C12.ID, fwB, ids[2], 10)
+	if err != nil {
+		t.Fatal("Unable to list firmware history: ", err)
+	}
+	if len(list) != 2 || list[0].ID != ids[1] || list[1].ID != ids[0] {
+		t.Fatalf("Unexpected second page: %+v", list)
+	}
+
+	stats, err := s.RetrieveFirmwareStatistics(e.U1.ID, e.C12.ID, fwB)
+	if err != nil {
+		t.Fatal("Unable to retrieve firmware statistics: ", err)
+	}
+	if stats != (model.FirmwareStatistics{Downloads: 2, Completed: 1, Updated: 1, Failed: 1}) {
+		t.Fatalf("Unexpected statistics: %+v", stats)
+	}
+	stats, err = s.RetrieveFirmwareStatistics(e.U3.ID, e.C12.ID, fwB)
+	if err != nil {
+		t.Fatal("Unable to retrieve firmware statistics: ", err)
+	}
+	if stats != (model.FirmwareStatistics{}) {
+		t.Fatalf("Should not count history for other teams: %+v", stats)
+	}
+}
@@ -0,0 +1,105 @@
+package storetest
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// testMeteringStore runs tests on the metered usage records
+func testMeteringStore(e TestEnvironment, s storage.DataStore, t *testing.T) {
+	hour := model.UsageHour(time.Now())
+
+	records, err := s.RetrieveUsage(e.T3.ID, hour, hour.Add(time.Hour))
+	if err != nil {
+		t.Fatal("Unable to retrieve usage for team: ", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("Expected no usage records but got %d", len(records))
+	}
+
+	teamID, err := s.RetrieveCollectionTeam(e.C3.ID)
+	if err != nil || teamID != e.T3.ID {
+		t.Fatalf("Expected team %d for collection but got %d (err=%v)", e.T3.ID, teamID, err)
+	}
+	if _, err := s.RetrieveCollectionTeam(s.NewCollectionID()); err != storage.ErrNotFound {
+		t.Fatal("Expected not found for unknown collection but got ", err)
+	}
+
+	// The last record is for a collection that is removed before the usage
+	// is written. The usage is kept for the team.
+	removedID := s.NewCollectionID()
+	usage := []model.Usage{
+		{CollectionID: e.C3.ID, TeamID: e.T3.ID, Hour: hour, Transport: "udp", UplinkMessages: 1, UplinkBytes: 10},
+		{CollectionID: e.C3.ID, TeamID: e.T3.ID, Hour: hour, Transport: "", RADIUSSessions: 1, OutputDeliveries: 2},
+		{CollectionID: e.C3.ID, TeamID: e.T3.ID, Hour: hour.Add(-time.Hour), Transport: "udp", DownlinkMessages: 1, DownlinkBytes: 5},
+		{CollectionID: e.C1.ID, TeamID: e.T1.ID, Hour: hour, Transport: "udp", UplinkMessages: 1, UplinkBytes: 10},
+		{CollectionID: removedID, TeamID: e.T3.ID, Hour: hour, Transport: "udp", UplinkMessages: 1},
+	}
+	if err := s.AddUsage(usage); err != nil {
+		t.Fatal("Unable to add usage: ", err)
+	}
+	// Adding the same records updates the counters
+	if err := s.AddUsage(usage[:1]); err != nil {
+		t.Fatal("Unable to add usage a second time: ", err)
+	}
+
+	records, err = s.RetrieveUsage(e.T3.ID, hour, hour.Add(time.Hour))
+	if err != nil {
+		t.Fatal("Unable to retrieve usage for team: ", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 usage records but got %d (%+v)", len(records), records)
+	}
+	for _, r := range records {
+		if r.TeamID != e.T3.ID || !r.Hour.Equal(hour) {
+			t.Fatalf("Unexpected usage record: %+v", r)
+		}
+		if r.CollectionID == removedID {
+			if r.UplinkMessages != 1 {
+				t.Fatalf("Unexpected usage for removed collection: %+v", r)
+			}
+			continue
+		}
+		if r.CollectionID != e.C3.ID {
+			t.Fatalf("Unexpected collection in usage record: %+v", r)
+		}
+		switch r.Transport {
+		case "udp":
+			if r.UplinkMessages != 2 || r.UplinkBytes != 20 {
+				t.Fatalf("Expected usage to be counted twice: %+v", r)
+			}
+		case "":
+			if r.RADIUSSessions != 1 || r.OutputDeliveries != 2 {
+				t.Fatalf("Unexpected counters: %+v", r)
+			}
+		default:
+			t.Fatalf("Unexpected transport: %+v", r)
+		}
+	}
+
+	records, err = s.RetrieveUsage(e.T3.ID, hour.Add(-time.Hour), hour.Add(time.Hour))
+	if err != nil || len(records) != 4 {
+		t.Fatalf("Expected 4 records but got %d (err=%v)", len(records), err)
+	}
+	if !records[0].Hour.Equal(hour.Add(-time.Hour)) || records[0].DownlinkBytes != 5 {
+		t.Fatalf("Expected the oldest record first: %+v", records[0])
+	}
+}
@@ -0,0 +1,248 @@
+package storetest
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// testPagedLists runs tests on the paged lists with sorting and tag filters
+func testPagedLists(e TestEnvironment, s storage.DataStore, t *testing.T) {
+	coll := model.NewCollection()
+	coll.ID = s.NewCollectionID()
+	coll.TeamID = e.T1.ID
+	coll.SetTag("paging", "yes")
+	if err := s.CreateCollection(e.U1.ID, coll); err != nil {
+		t.Fatal("Unable to create collection for paging tests: ", err)
+	}
+
+	// Devices with IMSI in reverse creation order and every other device
+	// seen on the network.
+	now := time.Now()
+	var devices []model.Device
+	for i := 0; i < 7; i++ {
+		d := model.NewDevice()
+		d.ID = s.NewDeviceID()
+		d.IMSI = int64(900000 - i)
+		d.IMEI = int64(d.ID)
+		d.CollectionID = coll.ID
+		if i%2 == 0 {
+			d.SetTag("site", "oslo")
+		} else {
+			d.SetTag("site", "bergen")
+		}
+		if i%3 == 0 {
+			d.SetTag("type", "gateway")
+		}
+		if i%2 == 1 {
+			d.Network.AllocatedAt = now.Add(time.Duration(i) * time.Minute)
+		}
+		if err := s.CreateDevice(e.U1.ID, d); err != nil {
+			t.Fatal("Unable to create device for paging tests: ", err)
+		}
+		devices = append(devices, d)
+	}
+	unseen := model.NewDevice()
+	unseen.ID = s.NewDeviceID()
+	unseen.IMSI = 800000
+	unseen.IMEI = int64(unseen.ID)
+	unseen.CollectionID = coll.ID
+	if err := s.CreateDevice(e.U1.ID, unseen); err != nil {
+		t.Fatal("Unable to create untagged device for paging tests: ", err)
+	}
+	devices = append(devices, unseen)
+
+	listDevices := func(opts model.ListOptions) string {
+		ret := ""
+		for {
+			list, next, err := s.ListDevicesPage(e.U1.ID, coll.ID, opts)
+			if err != nil {
+				t.Fatalf("Error listing devices with options %+v: %v", opts, err)
+			}
+			if opts.PageSize > 0 && len(list) > opts.PageSize {
+				t.Fatalf("Page size is %d but got %d devices", opts.PageSize, len(list))
+			}
+			for _, v := range list {
+				ret += fmt.Sprintf("%d ", indexOfDevice(devices, v.ID))
+			}
+			if next == "" {
+				return ret
+			}
+			opts.PageToken = next
+		}
+	}
+	filter := func(expr string) model.TagFilter {
+		f, err := model.ParseTagFilter(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	tests := []struct {
+		opts     model.ListOptions
+		expected string
+	}{
+		{model.ListOptions{}, "0 1 2 3 4 5 6 7 "},
+		{model.ListOptions{PageSize: 3}, "0 1 2 3 4 5 6 7 "},
+		{model.ListOptions{PageSize: 1, SortBy: model.SortByCreated}, "0 1 2 3 4 5 6 7 "},
+		{model.ListOptions{PageSize: 2, SortBy: model.SortByIMSI}, "7 6 5 4 3 2 1 0 "},
+		{model.ListOptions{PageSize: 2, SortBy: model.SortByLastSeen}, "5 3 1 0 2 4 6 7 "},
+		{model.ListOptions{PageSize: 3, Filter: filter("site=oslo")}, "0 2 4 6 "},
+		{model.ListOptions{PageSize: 3, Filter: filter("site=oslo AND type!=gateway")}, "2 4 "},
+		{model.ListOptions{Filter: filter("type!=gateway")}, "1 2 4 5 7 "},
+		{model.ListOptions{Filter: filter("SITE=bergen and type=gateway")}, "3 "},
+		{model.ListOptions{Filter: filter("site=Oslo")}, ""},
+		{model.ListOptions{Filter: filter("site=osl")}, ""},
+		{model.ListOptions{PageSize: 1, SortBy: model.SortByLastSeen, Filter: filter("site=oslo")}, "0 2 4 6 "},
+	}
+	for _, test := range tests {
+		if actual := listDevices(test.opts); actual != test.expected {
+			t.Fatalf("Expected devices [%s] with options %+v but got [%s]", test.expected, test.opts, actual)
+		}
+	}
+
+	if _, _, err := s.ListDevicesPage(e.U1.ID, coll.ID, model.ListOptions{PageToken: "invalid"}); err != storage.ErrInvalidListOptions {
+		t.Fatal("Expected invalid list options error for invalid token but got ", err)
+	}
+	if _, _, err := s.ListDevicesPage(e.U1.ID, coll.ID, model.ListOptions{SortBy: "name"}); err != storage.ErrInvalidListOptions {
+		t.Fatal("Expected invalid list options error for invalid sort but got ", err)
+	}
+	if _, _, err := s.ListDevicesPage(e.U3.ID, coll.ID, model.ListOptions{}); err != storage.ErrNotFound {
+		t.Fatal("Expected not found error for non-member but got ", err)
+	}
+
+	// Outputs and firmware images are sorted by creation order
+	var outputs []model.Output
+	var images []model.Firmware
+	for i := 0; i < 5; i++ {
+		o := model.NewOutput()
+		o.ID = s.NewOutputID()
+		o.Type = "webhook"
+		o.CollectionID = coll.ID
+		o.SetTag("index", fmt.Sprintf("%d", i%2))
+		if err := s.CreateOutput(e.U1.ID, o); err != nil {
+			t.Fatal("Unable to create output for paging tests: ", err)
+		}
+		outputs = append(outputs, o)
+
+		fw := model.Firmware{
+			ID:           s.NewFirmwareID(),
+			Version:      fmt.Sprintf("v%d", 5-i),
+			Filename:     "paging",
+			SHA256:       fmt.Sprintf("paging%d", i),
+			Length:       1,
+			Created:      now,
+			CollectionID: coll.ID,
+			Tags:         model.NewTags(),
+		}
+		fw.SetTag("index", fmt.Sprintf("%d", i%2))
+		if err := s.CreateFirmware(e.U1.ID, fw); err != nil {
+			t.Fatal("Unable to create firmware for paging tests: ", err)
+		}
+		images = append(images, fw)
+	}
+
+	opts := model.ListOptions{PageSize: 2, Filter: filter("index=0")}
+	var outputIDs []model.OutputKey
+	for {
+		list, next, err := s.ListOutputsPage(e.U1.ID, coll.ID, opts)
+		if err != nil {
+			t.Fatal("Error listing outputs: ", err)
+		}
+		for _, v := range list {
+			outputIDs = append(outputIDs, v.ID)
+		}
+		if next == "" {
+			break
+		}
+		opts.PageToken = next
+	}
+	if len(outputIDs) != 3 || outputIDs[0] != outputs[0].ID || outputIDs[1] != outputs[2].ID || outputIDs[2] != outputs[4].ID {
+		t.Fatalf("Unexpected output list: %v", outputIDs)
+	}
+
+	opts = model.ListOptions{PageSize: 2, Filter: filter("index!=0")}
+	var imageIDs []model.FirmwareKey
+	for {
+		list, next, err := s.ListFirmwarePage(e.U1.ID, coll.ID, opts)
+		if err != nil {
+			t.Fatal("Error listing firmware: ", err)
+		}
+		for _, v := range list {
+			imageIDs = append(imageIDs, v.ID)
+		}
+		if next == "" {
+			break
+		}
+		opts.PageToken = next
+	}
+	if len(imageIDs) != 2 || imageIDs[0] != images[1].ID || imageIDs[1] != images[3].ID {
+		t.Fatalf("Unexpected firmware list: %v", imageIDs)
+	}
+
+	if _, _, err := s.ListOutputsPage(e.U1.ID, coll.ID, model.ListOptions{SortBy: model.SortByIMSI}); err != storage.ErrInvalidListOptions {
+		t.Fatal("Expected invalid list options error for outputs sorted by IMSI but got ", err)
+	}
+	if _, _, err := s.ListOutputsPage(e.U3.ID, coll.ID, model.ListOptions{}); err != storage.ErrNotFound {
+		t.Fatal("Expected not found error when listing outputs for non-member but got ", err)
+	}
+	if _, _, err := s.ListFirmwarePage(e.U3.ID, coll.ID, model.ListOptions{}); err != storage.ErrNotFound {
+		t.Fatal("Expected not found error when listing firmware for non-member but got ", err)
+	}
+
+	// Collections
+	collections, next, err := s.ListCollectionsPage(e.U1.ID, model.ListOptions{PageSize: 1})
+	if err != nil || len(collections) != 1 || next == "" {
+		t.Fatalf("Expected a single collection and a page token but got %d collections, token %q (err=%v)", len(collections), next, err)
+	}
+	all, err := s.ListCollections(e.U1.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := len(collections)
+	for next != "" {
+		collections, next, err = s.ListCollectionsPage(e.U1.ID, model.ListOptions{PageSize: 1, PageToken: next})
+		if err != nil {
+			t.Fatal("Error listing collections: ", err)
+		}
+		count += len(collections)
+	}
+	if count != len(all) {
+		t.Fatalf("Expected %d collections when paging but got %d", len(all), count)
+	}
+	collections, next, err = s.ListCollectionsPage(e.U1.ID, model.ListOptions{Filter: filter("paging=yes")})
+	if err != nil || len(collections) != 1 || collections[0].ID != coll.ID || next != "" {
+		t.Fatalf("Expected the paging collection but got %v (err=%v)", collections, err)
+	}
+	if collections, _, err = s.ListCollectionsPage(e.U2.ID, model.ListOptions{Filter: filter("paging=yes")}); err != nil || len(collections) != 0 {
+		t.Fatalf("Did not expect any collections for U2 but got %v (err=%v)", collections, err)
+	}
+}
+
+func indexOfDevice(devices []model.Device, id model.DeviceKey) int {
+	for i, v := range devices {
+		if v.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,116 @@
+package storetest
+
+//
+//Copyright 2019 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+// testQuotaStore runs tests on the team quotas and usage counters
+func testQuotaStore(e TestEnvironment, s storage.DataStore, t *testing.T) {
+	quota, err := s.RetrieveTeamQuota(e.T3.ID)
+	if err != nil {
+		t.Fatal("Unable to retrieve quota for team without quota: ", err)
+	}
+	if quota != (model.Quota{}) {
+		t.Fatalf("Expected empty quota but got %+v", quota)
+	}
+
+	if err := s.UpdateTeamQuota(s.NewTeamID(), model.Quota{MaxDevices: 1}); err != storage.ErrNotFound {
+		t.Fatal("Expected ErrNotFound when setting quota for unknown team but got ", err)
+	}
+
+	newQuota := model.Quota{
+		MaxDevices:        10,
+		MaxCollections:    2,
+		MaxOutputs:        3,
+		MaxFirmwareBytes:  1024,
+		MaxUplinkPerDay:   2,
+		MaxDownlinkPerDay: 1,
+	}
+	if err := s.UpdateTeamQuota(e.T3.ID, newQuota); err != nil {
+		t.Fatal("Unable to set quota: ", err)
+	}
+	// Setting it twice replaces the old one
+	if err := s.UpdateTeamQuota(e.T3.ID, newQuota); err != nil {
+		t.Fatal("Unable to set quota a second time: ", err)
+	}
+	if quota, err = s.RetrieveTeamQuota(e.T3.ID); err != nil || quota != newQuota {
+		t.Fatalf("Expected quota %+v but got %+v (err=%v)", newQuota, quota, err)
+	}
+
+	now := time.Now()
+	before, err := s.RetrieveTeamUsage(e.T3.ID, now)
+	if err != nil {
+		t.Fatal("Unable to retrieve usage: ", err)
+	}
+	if before.Collections == 0 {
+		t.Fatalf("Expected at least one collection for T3 but got %+v", before)
+	}
+
+	o := model.NewOutput()
+	o.ID = s.NewOutputID()
+	o.Type = "quota"
+	o.CollectionID = e.C3.ID
+	if err := s.CreateOutput(e.U3.ID, o); err != nil {
+		t.Fatal("Unable to create output: ", err)
+	}
+	defer s.DeleteOutput(e.U3.ID, e.C3.ID, o.ID)
+
+	// Downlink budget is 1 per day, uplink is 2 per day.
+	for i, expected := range []bool{true, true, false} {
+		ok, err := s.AddMessageUsage(e.C3.ID, model.Uplink, now)
+		if err != nil || ok != expected {
+			t.Fatalf("Uplink message %d: expected %t but got %t (err=%v)", i, expected, ok, err)
+		}
+	}
+	for i, expected := range []bool{true, false} {
+		ok, err := s.AddMessageUsage(e.C3.ID, model.Downlink, now)
+		if err != nil || ok != expected {
+			t.Fatalf("Downlink message %d: expected %t but got %t (err=%v)", i, expected, ok, err)
+		}
+	}
+	// Next day the budget is reset
+	if ok, err := s.AddMessageUsage(e.C3.ID, model.Downlink, now.Add(24*time.Hour)); err != nil || !ok {
+		t.Fatalf("Expected message to be counted the next day (ok=%t, err=%v)", ok, err)
+	}
+	if _, err := s.AddMessageUsage(s.NewCollectionID(), model.Uplink, now); err != storage.ErrNotFound {
+		t.Fatal("Expected ErrNotFound for unknown collection but got ", err)
+	}
+
+	after, err := s.RetrieveTeamUsage(e.T3.ID, now)
+	if err != nil {
+		t.Fatal("Unable to retrieve usage: ", err)
+	}
+	if after.Outputs != before.Outputs+1 {
+		t.Fatalf("Expected output count to increase by one: before=%+v after=%+v", before, after)
+	}
+	if after.UplinkToday != before.UplinkToday+2 || after.DownlinkToday != before.DownlinkToday+1 {
+		t.Fatalf("Expected message counts to increase: before=%+v after=%+v", before, after)
+	}
+
+	// Zero values are unlimited
+	if err := s.UpdateTeamQuota(e.T3.ID, model.Quota{}); err != nil {
+		t.Fatal("Unable to clear quota: ", err)
+	}
+	if ok, err := s.AddMessageUsage(e.C3.ID, model.Uplink, now); err != nil || !ok {
+		t.Fatalf("Expected unlimited uplink (ok=%t, err=%v)", ok, err)
+	}
+}
@@ -0,0 +1,178 @@
+package storetest
+
+//
+//Copyright 2020 Telenor Digital AS
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+//
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/eesrc/horde/pkg/model"
+	"github.com/eesrc/horde/pkg/storage"
+)
+
+func testSigningKeyStore(e TestEnvironment, s storage.DataStore, t *testing.T) {
+	key, err := model.NewSigningKey(model.Ed25519Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key.ID = s.NewSigningKeyID()
+	key.CollectionID = e.C12.ID
+	key.Created = time.Now()
+
+	// U2 is a member, not an administrator
+	if err := s.CreateSigningKey(e.U2.ID, key); err == nil {
+		t.Fatal("Should not be able to create signing keys as a member")
+	}
+	if err := s.CreateSigningKey(e.U1.ID, key); err != nil {
+		t.Fatal(err)
+	}
+
+	// Registered keys have no private key
+	registered, err := model.NewSigningKeyFromPublicKey(model.Ed25519Signature, key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registered.ID = s.NewSigningKeyID()
+	registered.CollectionID = e.C12.ID
+	registered.Created = time.Now()
+	if err := s.CreateSigningKey(e.U1.ID, registered); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := s.RetrieveSigningKey(e.U2.ID, e.C12.ID, key.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Algorithm != key.Algorithm || !bytes.Equal(k.PublicKey, key.PublicKey) ||
+		!bytes.Equal(k.PrivateKey, key.PrivateKey) || k.Created.UnixNano() != key.Created.UnixNano() {
+		t.Fatalf("Retrieved key does not match: %+v != %+v", k, key)
+	}
+	if _, err := s.RetrieveSigningKey(e.U1.ID, e.C1.ID, key.ID); err != storage.ErrNotFound {
+		t.Fatal("Expected not found for key in other collection but got ", err)
+	}
+	if _, err := s.RetrieveSigningKey(e.U1.ID, e.C3.ID, key.ID); err != storage.ErrNotFound {
+		t.Fatal("Expected not found for collection in other team but got ", err)
+	}
+
+	list, err := s.ListSigningKeys(e.U1.ID, e.C12.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 keys but got %d", len(list))
+	}
+	for _, v := range list {
+		if v.ID == registered.ID && v.CanSign() {
+			t.Fatal("Registered key should not have a private key")
+		}
+	}
+	list, err = s.ListSigningKeys(e.U3.ID, e.C12.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 0 {
+		t.Fatal("Should not list keys for other teams")
+	}
+
+	// Signatures are kept with the firmware
+	data := []byte("signed image")
+	digest := sha256.Sum256(data)
+	sig, err := key.Sign(digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := model.NewFirmware()
+	fw.ID = s.NewFirmwareID()
+	fw.Filename = "signed"
+	fw.Version = "signed"
+	fw.SHA256 = hex.EncodeToString(digest[:])
+	fw.Length = len(data)
+	fw.Created = time.Now()
+	fw.CollectionID = e.C12.ID
+	fw.Signature = sig
+	fw.SigningKeyID = key.ID
+	if err := s.CreateFirmware(e.U1.ID, fw); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := s.RetrieveFirmware(e.U1.ID, e.C12.ID, fw.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.IsSigned() || !bytes.Equal(stored.Signature, sig) || stored.SigningKeyID != key.ID {
+		t.Fatalf("Signature not stored with firmware: %+v", stored)
+	}
+	if !k.Verify(digest[:], stored.Signature) {
+		t.Fatal("Signature does not verify")
+	}
+	if err := s.DeleteFirmware(e.U1.ID, e.C12.ID, fw.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DeleteSigningKey(e.U2.ID, e.C12.ID, key.ID); err == nil {
+		t.Fatal("Should not be able to delete signing keys as a member")
+	}
+	if err := s.DeleteSigningKey(e.U1.ID, e.C12.ID, key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteSigningKey(e.U1.ID, e.C12.ID, key.ID); err != storage.ErrNotFound {
+		t.Fatal("Expected not found when deleting key twice but got ", err)
+	}
+	if err := s.DeleteSigningKey(e.U1.ID, e.C12.ID, registered.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The signature requirement is stored with the collection
+	coll := e.C12
+	coll.Firmware.RequireSignature = true
+	if err := s.UpdateCollection(e.U1.ID, coll); err != nil {
+		t.Fatal(err)
+	}
+	c, err := s.RetrieveCollection(e.U1.ID, coll.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Firmware.RequireSignature {
+		t.Fatal("Signature requirement not stored for collection")
+	}
+
+	// ...and included in the firmware config for the devices
+	d := model.NewDevice()
+	d.ID = s.NewDeviceID()
+	d.IMSI = int64(d.ID)
+	d.IMEI = int64(d.ID)
+	d.CollectionID = coll.ID
+	if err := s.CreateDevice(e.U1.ID, d); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := s.RetrieveFirmwareConfig(coll.ID, d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.RequireSignature {
+		t.Fatal("Signature requirement not set in firmware config")
+	}
+	if err := s.DeleteDevice(e.U1.ID, coll.ID, d.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	coll.Firmware.RequireSignature = false
+	if err := s.UpdateCollection(e.U1.ID, coll); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -30,12 +30,26 @@ func StorageTest(t *testing.T, s storage.DataStore) {
 	testTokenStore(e, s, t)
 	testCollectionStore(e, s, t)
 	testDeviceStore(e, s, t)
+	testRolePermissions(e, s, t)
 	testOutputStore(e, s, t)
 	testInviteStore(e, s, t)
 
 	testUserUpdates(e, s, t)
 
 	testFirmwareStore(e, s, t)
+	testSigningKeyStore(e, s, t)
+
+	testQuotaStore(e, s, t)
+
+	testMeteringStore(e, s, t)
+
+	testAuditStore(e, s, t)
+
+	testFirmwareHistoryStore(e, s, t)
+
+	testPagedLists(e, s, t)
+
+	testDeviceImport(e, s, t)
 }
 
 func testUserUpdates(e TestEnvironment, s storage.DataStore, t *testing.T) {
@@ -16,8 +16,8 @@ package storetest
 //limitations under the License.
 //
 import (
-	"reflect"
 	"testing"
+	"time"
 
 	"github.com/eesrc/horde/pkg/model"
 	"github.com/eesrc/horde/pkg/storage"
@@ -30,6 +30,7 @@ func testTokenStore(env TestEnvironment, store storage.DataStore, t *testing.T)
 	t1.GenerateToken()
 	t1.Resource = "/one"
 	t1.Write = true
+	t1.Created = time.Now()
 
 	if err := store.CreateToken(t1); err != nil {
 		t.Fatal("Coulnd't create token 1")
@@ -83,24 +84,57 @@ func testTokenStore(env TestEnvironment, store storage.DataStore, t *testing.T)
 		t.Fatal("Error listing tokens: ", err)
 	}
 	for _, v := range tokens {
-		if v.Token == t2.Token {
-			if !reflect.DeepEqual(v, t2) || v.GetTag("name") != "number2" {
+		if v.ID == t2.ID {
+			if v.Resource != t2.Resource || v.Write != t2.Write || v.UserID != t2.UserID || v.GetTag("name") != "number2" {
 				t.Fatalf("Returned token does not match (%+v != %+v)", v, t2)
 			}
+			if v.Token != "" {
+				t.Fatal("The token itself should not be stored")
+			}
 		}
 	}
 
+	// Expiry and usage
+	t2.Expires = time.Now().Add(time.Hour)
+	if err := store.UpdateToken(t2); err != nil {
+		t.Fatal("Unable to update token expiry: ", err)
+	}
+	lastUsed := time.Now()
+	if err := store.UpdateTokenUsage(t2.ID, lastUsed, "127.0.0.1"); err != nil {
+		t.Fatal("Unable to update token usage: ", err)
+	}
+	if err := store.UpdateTokenUsage(tx.ID, lastUsed, "127.0.0.1"); err != storage.ErrNotFound {
+		t.Fatal("Should not be able to update usage for unknown token but got ", err)
+	}
+	ret, err := store.RetrieveToken(t2.ID)
+	if err != nil {
+		t.Fatal("Unable to retrieve token 2: ", err)
+	}
+	if !ret.Expires.Equal(t2.Expires) || !ret.LastUsed.Equal(lastUsed) || ret.LastUsedIP != "127.0.0.1" {
+		t.Fatalf("Expiry and usage does not match (%+v)", ret)
+	}
+	if _, err := store.RetrieveToken(t2.Token); err != storage.ErrNotFound {
+		t.Fatal("Tokens should be retrieved by ID, not the token itself but got ", err)
+	}
+	ret, err = store.RetrieveToken(t1.ID)
+	if err != nil {
+		t.Fatal("Unable to retrieve token 1: ", err)
+	}
+	if !ret.Created.Equal(t1.Created) || !ret.Expires.IsZero() || !ret.LastUsed.IsZero() {
+		t.Fatalf("Timestamps for token 1 does not match (%+v)", ret)
+	}
+
 	// Test tag implementations
-	testTagSetAndGet(t, env, t1.Token, false, store.UpdateTokenTags, store.RetrieveTokenTags)
+	testTagSetAndGet(t, env, t1.ID, false, store.UpdateTokenTags, store.RetrieveTokenTags)
 
-	if err := store.DeleteToken(env.U1.ID, t1.Token); err != nil {
+	if err := store.DeleteToken(env.U1.ID, t1.ID); err != nil {
 		t.Fatal("Unable to remove token 1")
 	}
-	if err := store.DeleteToken(env.U1.ID, t2.Token); err != storage.ErrNotFound {
+	if err := store.DeleteToken(env.U1.ID, t2.ID); err != storage.ErrNotFound {
 		t.Fatal("Should not be able to remove someone else's token but got ", err)
 	}
-	store.DeleteToken(env.U2.ID, t2.Token)
-	if err := store.DeleteToken(env.U2.ID, t2.Token); err != storage.ErrNotFound {
+	store.DeleteToken(env.U2.ID, t2.ID)
+	if err := store.DeleteToken(env.U2.ID, t2.ID); err != storage.ErrNotFound {
 		t.Fatal("Should not be able to remove the same token twice")
 	}
 }
@@ -37,12 +37,13 @@ func getDialOpts(config GRPCClientParam) ([]grpc.DialOption, error) {
 	return []grpc.DialOption{grpc.WithTransportCredentials(creds)}, nil
 }
 
-// NewGRPCClientConnection is a factory method to create gRPC client connections
-func NewGRPCClientConnection(config GRPCClientParam) (*grpc.ClientConn, error) {
+// NewGRPCClientConnection is a factory method to create gRPC client
+// connections. The options are added to the dial options.
+func NewGRPCClientConnection(config GRPCClientParam, extraOpts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	opts, err := getDialOpts(config)
 	if err != nil {
 		return nil, err
 	}
 
-	return grpc.Dial(config.ServerEndpoint, opts...)
+	return grpc.Dial(config.ServerEndpoint, append(opts, extraOpts...)...)
 }
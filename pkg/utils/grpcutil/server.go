@@ -37,9 +37,10 @@ type GRPCServer interface {
 	Stop()
 }
 
-// NewGRPCServer configures a new GRPC server. A port will be allocated for the server
-func NewGRPCServer(params GRPCServerParam) (GRPCServer, error) {
-	ret := grpcServer{config: params}
+// NewGRPCServer configures a new GRPC server. A port will be allocated for
+// the server. The options are added to the server's options.
+func NewGRPCServer(params GRPCServerParam, opts ...grpc.ServerOption) (GRPCServer, error) {
+	ret := grpcServer{config: params, opts: opts}
 
 	var err error
 	ret.listener, err = net.Listen("tcp", ret.config.Endpoint)
@@ -51,6 +52,7 @@ func NewGRPCServer(params GRPCServerParam) (GRPCServer, error) {
 
 type grpcServer struct {
 	config   GRPCServerParam
+	opts     []grpc.ServerOption
 	listener net.Listener
 	server   *grpc.Server
 }
@@ -69,15 +71,19 @@ func (g *grpcServer) getOpts(config GRPCServerParam) ([]grpc.ServerOption, error
 	return []grpc.ServerOption{grpc.Creds(creds)}, nil
 }
 
-func (g *grpcServer) Start(register func(s *grpc.Server)) error {
+// createServer creates the server and registers the services
+func (g *grpcServer) createServer(register func(s *grpc.Server)) error {
 	opts, err := g.getOpts(g.config)
 	if err != nil {
 		return err
 	}
-	g.server = grpc.NewServer(opts...)
+	g.server = grpc.NewServer(append(opts, g.opts...)...)
 
 	register(g.server)
+	return nil
+}
 
+func (g *grpcServer) serve() error {
 	if err := g.server.Serve(g.listener); err != nil {
 		logging.Error("Unable to serve gRPC: %v", err)
 		return err
@@ -85,11 +91,23 @@ func (g *grpcServer) Start(register func(s *grpc.Server)) error {
 	return nil
 }
 
+func (g *grpcServer) Start(register func(s *grpc.Server)) error {
+	if err := g.createServer(register); err != nil {
+		return err
+	}
+	return g.serve()
+}
+
 func (g *grpcServer) Launch(register func(s *grpc.Server), timeout time.Duration) error {
+	// The server is created before the goroutine is launched so Stop can be
+	// called safely when Launch returns.
+	if err := g.createServer(register); err != nil {
+		return err
+	}
 	errCh := make(chan error)
 
 	go func() {
-		if err := g.Start(register); err != nil {
+		if err := g.serve(); err != nil {
 			errCh <- err
 		}
 	}()